@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// NewScopedCache creates a cache that is separate from the cache of the Manager, and adds
+// it to the Manager so that it is started and synced before the controllers. It can be
+// passed to WithCache to give one or more controllers their own cache, e.g. because they
+// watch objects with selectors, transforms or namespaces that would otherwise force a
+// broad shared cache onto all controllers.
+//
+// The Scheme, Mapper and HTTPClient of the options default to the ones of the Manager.
+// The Manager's client keeps reading from the Manager's cache, reconcilers must read the
+// objects that are only in the scoped cache from the returned cache.
+func NewScopedCache(mgr manager.Manager, opts cache.Options) (cache.Cache, error) {
+	if opts.Scheme == nil {
+		opts.Scheme = mgr.GetScheme()
+	}
+	if opts.Mapper == nil {
+		opts.Mapper = mgr.GetRESTMapper()
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = mgr.GetHTTPClient()
+	}
+	c, err := cache.New(mgr.GetConfig(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scoped cache: %w", err)
+	}
+	if err := mgr.Add(scopedCache{Cache: c}); err != nil {
+		return nil, fmt.Errorf("failed to add scoped cache to the manager: %w", err)
+	}
+	return c, nil
+}
+
+// scopedCache makes the Manager start a scoped cache together with its own cache, and
+// wait for it to sync before it starts the controllers.
+type scopedCache struct {
+	cache.Cache
+}
+
+// GetCache returns the scoped cache.
+func (c scopedCache) GetCache() cache.Cache {
+	return c.Cache
+}
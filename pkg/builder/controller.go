@@ -24,9 +24,13 @@ import (
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -64,6 +68,8 @@ type TypedBuilder[request comparable] struct {
 	ctrl             controller.TypedController[request]
 	ctrlOptions      controller.TypedOptions[request]
 	name             string
+	healthChecks     bool
+	cache            cache.Cache
 	newController    func(name string, mgr manager.Manager, options controller.TypedOptions[request]) (controller.TypedController[request], error)
 }
 
@@ -83,6 +89,9 @@ type ForInput struct {
 	predicates       []predicate.Predicate
 	objectProjection objectProjection
 	err              error
+
+	// enqueueExistingSelector is set by EnqueueExistingOnStart.
+	enqueueExistingSelector labels.Selector
 }
 
 // For defines the type of Object being *reconciled*, and configures the ControllerManagedBy to respond to create / delete /
@@ -256,6 +265,25 @@ func (blder *TypedBuilder[request]) Named(name string) *TypedBuilder[request] {
 	return blder
 }
 
+// WithHealthChecks adds the readiness check of the controller, see controller.ReadinessChecker,
+// and its liveness check, see controller.LivenessChecker, to the Manager as checks named after
+// the controller. They replace checks of the Manager with the same name, and are removed from
+// the Manager again by controller.Remove.
+//
+// Checks can only be added before the Manager is started, Build returns an error otherwise.
+func (blder *TypedBuilder[request]) WithHealthChecks() *TypedBuilder[request] {
+	blder.healthChecks = true
+	return blder
+}
+
+// WithCache makes the controller watch the objects of For, Owns, Watches and
+// WatchesMetadata through c instead of the cache of the Manager, see NewScopedCache.
+// Sources passed to WatchesRawSource are not affected.
+func (blder *TypedBuilder[request]) WithCache(c cache.Cache) *TypedBuilder[request] {
+	blder.cache = c
+	return blder
+}
+
 // Complete builds the Application Controller.
 func (blder *TypedBuilder[request]) Complete(r reconcile.TypedReconciler[request]) error {
 	_, err := blder.Build(r)
@@ -304,6 +332,44 @@ func (blder *TypedBuilder[request]) project(obj client.Object, proj objectProjec
 	}
 }
 
+// newList returns an empty list for the type of obj.
+func (blder *TypedBuilder[request]) newList(obj client.Object) (client.ObjectList, error) {
+	gvk, err := apiutil.GVKForObject(obj, blder.mgr.GetScheme())
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine GVK of %T to list its existing objects: %w", obj, err)
+	}
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+
+	switch obj.(type) {
+	case *metav1.PartialObjectMetadata:
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(listGVK)
+		return list, nil
+	case runtime.Unstructured:
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGVK)
+		return list, nil
+	}
+
+	listObj, err := blder.mgr.GetScheme().New(listGVK)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a list of %T to list its existing objects: %w", obj, err)
+	}
+	list, ok := listObj.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.ObjectList", listObj)
+	}
+	return list, nil
+}
+
+// getCache returns the cache that the sources of the controller watch through.
+func (blder *TypedBuilder[request]) getCache() cache.Cache {
+	if blder.cache != nil {
+		return blder.cache
+	}
+	return blder.mgr.GetCache()
+}
+
 func (blder *TypedBuilder[request]) doWatch() error {
 	// Reconcile type
 	if blder.forInput.object != nil {
@@ -320,10 +386,25 @@ func (blder *TypedBuilder[request]) doWatch() error {
 		reflect.ValueOf(&hdler).Elem().Set(reflect.ValueOf(&handler.EnqueueRequestForObject{}))
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, blder.forInput.predicates...)
-		src := source.TypedKind(blder.mgr.GetCache(), obj, hdler, allPredicates...)
+		src := source.TypedKind(blder.getCache(), obj, hdler, allPredicates...)
 		if err := blder.ctrl.Watch(src); err != nil {
 			return err
 		}
+
+		if blder.forInput.enqueueExistingSelector != nil {
+			list, err := blder.newList(obj)
+			if err != nil {
+				return err
+			}
+			var src source.TypedSource[request]
+			reflect.ValueOf(&src).Elem().Set(reflect.ValueOf(source.ExistingObjects(
+				blder.getCache(), list,
+				client.MatchingLabelsSelector{Selector: blder.forInput.enqueueExistingSelector},
+			)))
+			if err := blder.ctrl.Watch(src); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Watches the managed types
@@ -348,7 +429,7 @@ func (blder *TypedBuilder[request]) doWatch() error {
 		)))
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, own.predicates...)
-		src := source.TypedKind(blder.mgr.GetCache(), obj, hdler, allPredicates...)
+		src := source.TypedKind(blder.getCache(), obj, hdler, allPredicates...)
 		if err := blder.ctrl.Watch(src); err != nil {
 			return err
 		}
@@ -365,7 +446,7 @@ func (blder *TypedBuilder[request]) doWatch() error {
 		}
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, w.predicates...)
-		if err := blder.ctrl.Watch(source.TypedKind(blder.mgr.GetCache(), projected, w.handler, allPredicates...)); err != nil {
+		if err := blder.ctrl.Watch(source.TypedKind(blder.getCache(), projected, w.handler, allPredicates...)); err != nil {
 			return err
 		}
 	}
@@ -462,5 +543,26 @@ func (blder *TypedBuilder[request]) doController(r reconcile.TypedReconciler[req
 
 	// Build the controller and return.
 	blder.ctrl, err = blder.newController(controllerName, blder.mgr, ctrlOptions)
-	return err
+	if err != nil {
+		return err
+	}
+	if blder.healthChecks {
+		return blder.addHealthChecks(controllerName)
+	}
+	return nil
+}
+
+// addHealthChecks adds the health checks of the controller to the Manager.
+func (blder *TypedBuilder[request]) addHealthChecks(controllerName string) error {
+	if check := controller.ReadinessChecker(blder.ctrl); check != nil {
+		if err := controller.AddReadyzCheck(blder.mgr, blder.ctrl, controllerName, check); err != nil {
+			return fmt.Errorf("failed to add the readiness check of controller %q: %w", controllerName, err)
+		}
+	}
+	if check := controller.LivenessChecker(blder.ctrl); check != nil {
+		if err := controller.AddHealthzCheck(blder.mgr, blder.ctrl, controllerName, check); err != nil {
+			return fmt.Errorf("failed to add the liveness check of controller %q: %w", controllerName, err)
+		}
+	}
+	return nil
 }
@@ -28,6 +28,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -400,6 +402,36 @@ var _ = Describe("application", func() {
 		})
 	})
 
+	Describe("health checks", func() {
+		var m *checkRecordingManager
+		BeforeEach(func() {
+			mgr, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+			m = &checkRecordingManager{Manager: mgr}
+		})
+
+		It("should add the health checks of the controller to the manager with WithHealthChecks", func() {
+			Expect(ControllerManagedBy(m).
+				For(&appsv1.Deployment{}).
+				Named("deployment-health-checks").
+				WithHealthChecks().
+				Complete(noop)).To(Succeed())
+
+			Expect(m.readyzChecks).To(ConsistOf("deployment-health-checks"))
+			Expect(m.healthzChecks).To(ConsistOf("deployment-health-checks"))
+		})
+
+		It("should not add the health checks of the controller by default", func() {
+			Expect(ControllerManagedBy(m).
+				For(&appsv1.Deployment{}).
+				Named("deployment-without-health-checks").
+				Complete(noop)).To(Succeed())
+
+			Expect(m.readyzChecks).To(BeEmpty())
+			Expect(m.healthzChecks).To(BeEmpty())
+		})
+	})
+
 	Describe("Start with ControllerManagedBy", func() {
 		It("should Reconcile Owns objects", func(ctx SpecContext) {
 			m, err := manager.New(cfg, manager.Options{})
@@ -516,6 +548,128 @@ var _ = Describe("application", func() {
 		})
 	})
 
+	Describe("EnqueueExistingOnStart", func() {
+		It("should reconcile the existing objects that match the selector once", func(ctx SpecContext) {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Creating the Deployments before the controller starts")
+			for _, name := range []string{"existing-match-6", "existing-other-6"} {
+				dep := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      name,
+						Labels:    map[string]string{"existing": strings.TrimSuffix(strings.TrimPrefix(name, "existing-"), "-6")},
+					},
+					Spec: appsv1.DeploymentSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+							Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+						},
+					},
+				}
+				Expect(m.GetClient().Create(ctx, dep)).To(Succeed())
+			}
+
+			ch := make(chan reconcile.Request, 10)
+			err = ControllerManagedBy(m).
+				For(&appsv1.Deployment{},
+					// Filter the initial Create events, only the existing objects are enqueued.
+					WithPredicates(predicate.Funcs{CreateFunc: func(event.CreateEvent) bool { return false }}),
+					EnqueueExistingOnStart(labels.SelectorFromSet(labels.Set{"existing": "match"})),
+				).
+				Named("deployment-existing").
+				Complete(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					if strings.HasSuffix(req.Name, "-6") {
+						ch <- req
+					}
+					return reconcile.Result{}, nil
+				}))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Starting the manager")
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+
+			Eventually(ch).Should(Receive(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "existing-match-6"}})))
+			Consistently(ch).ShouldNot(Receive())
+		})
+
+		It("should return an error if the list type of the For type is unknown", func() {
+			scheme := runtime.NewScheme()
+			scheme.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, &fakeType{})
+			m, err := manager.New(cfg, manager.Options{Scheme: scheme})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ControllerManagedBy(m).
+				For(&fakeType{}, EnqueueExistingOnStart(nil)).
+				Complete(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{}, nil
+				}))
+			Expect(err).To(MatchError(ContainSubstring("unable to create a list of *builder.fakeType")))
+		})
+	})
+
+	Describe("WithCache", func() {
+		It("should watch the objects through the scoped cache", func(ctx SpecContext) {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			scoped, err := NewScopedCache(m, cache.Options{
+				ByObject: map[client.Object]cache.ByObject{
+					&appsv1.Deployment{}: {Label: labels.SelectorFromSet(labels.Set{"scoped": "true"})},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			ch := make(chan reconcile.Request, 10)
+			err = ControllerManagedBy(m).
+				For(&appsv1.Deployment{}).
+				Named("deployment-scoped-cache").
+				WithCache(scoped).
+				Complete(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					if strings.HasSuffix(req.Name, "-7") {
+						ch <- req
+					}
+					return reconcile.Result{}, nil
+				}))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Starting the manager")
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+
+			By("Creating a Deployment that is outside and one that is inside of the scoped cache")
+			for _, name := range []string{"unscoped-7", "scoped-7"} {
+				dep := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      name,
+						Labels:    map[string]string{"scoped": fmt.Sprint(name == "scoped-7")},
+					},
+					Spec: appsv1.DeploymentSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "bar"}},
+							Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
+						},
+					},
+				}
+				Expect(m.GetClient().Create(ctx, dep)).To(Succeed())
+			}
+
+			Eventually(ch).Should(Receive(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "scoped-7"}})))
+			Consistently(ch).ShouldNot(Receive())
+		})
+	})
+
 	Describe("watching with projections", func() {
 		var mgr manager.Manager
 		BeforeEach(func() {
@@ -728,6 +882,23 @@ func doReconcileTest(ctx context.Context, nameSuffix string, mgr manager.Manager
 		NamespacedName: types.NamespacedName{Namespace: "default", Name: deployName}})))
 }
 
+// checkRecordingManager records the names of the health checks that are added to it.
+type checkRecordingManager struct {
+	manager.Manager
+	readyzChecks  []string
+	healthzChecks []string
+}
+
+func (m *checkRecordingManager) AddReadyzCheck(name string, check healthz.Checker) error {
+	m.readyzChecks = append(m.readyzChecks, name)
+	return m.Manager.AddReadyzCheck(name, check)
+}
+
+func (m *checkRecordingManager) AddHealthzCheck(name string, check healthz.Checker) error {
+	m.healthzChecks = append(m.healthzChecks, name)
+	return m.Manager.AddHealthzCheck(name, check)
+}
+
 var _ runtime.Object = &fakeType{}
 
 type fakeType struct {
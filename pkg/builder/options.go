@@ -17,6 +17,8 @@ limitations under the License.
 package builder
 
 import (
+	"k8s.io/apimachinery/pkg/labels"
+
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -154,3 +156,25 @@ type matchEveryOwner struct{}
 func (o matchEveryOwner) ApplyToOwns(opts *OwnsInput) {
 	opts.matchEveryOwner = true
 }
+
+// EnqueueExistingOnStart enqueues a request for every object of the For type that matches
+// the selector, or for every object if the selector is nil, once the cache synced when the
+// controller starts. The requests are enqueued at handler.LowPriority before the workers of
+// the controller start, so that every matching object is processed once at startup, also if
+// the WithEventFilter or WithPredicates predicates filter the initial Create events of the
+// informer. See source.ExistingObjects.
+func EnqueueExistingOnStart(selector labels.Selector) ForOption {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	return enqueueExistingOnStart{selector: selector}
+}
+
+type enqueueExistingOnStart struct {
+	selector labels.Selector
+}
+
+// ApplyToFor applies this configuration to the given ForInput options.
+func (e enqueueExistingOnStart) ApplyToFor(opts *ForInput) {
+	opts.enqueueExistingSelector = e.selector
+}
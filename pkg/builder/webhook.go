@@ -32,6 +32,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 )
@@ -223,6 +224,13 @@ func (blder *WebhookBuilder[T]) registerDefaultingWebhook() error {
 				"path", path)
 			blder.mgr.GetWebhookServer().Register(path, mwh)
 		}
+		blder.describeRoute(webhook.Route{
+			Path:       path,
+			Type:       webhook.RouteTypeMutating,
+			GVKs:       []schema.GroupVersionKind{blder.gvk},
+			Operations: mwh.Operations(),
+			Handler:    mwh,
+		})
 	}
 
 	return nil
@@ -270,6 +278,13 @@ func (blder *WebhookBuilder[T]) registerValidatingWebhook() error {
 				"path", path)
 			blder.mgr.GetWebhookServer().Register(path, vwh)
 		}
+		blder.describeRoute(webhook.Route{
+			Path:       path,
+			Type:       webhook.RouteTypeValidating,
+			GVKs:       []schema.GroupVersionKind{blder.gvk},
+			Operations: vwh.Operations(),
+			Handler:    vwh,
+		})
 	}
 
 	return nil
@@ -316,6 +331,11 @@ func (blder *WebhookBuilder[T]) registerConversionWebhook() error {
 	if !blder.isAlreadyHandled("/convert") {
 		blder.mgr.GetWebhookServer().Register("/convert", conversion.NewWebhookHandler(blder.mgr.GetScheme(), blder.mgr.GetConverterRegistry()))
 	}
+	blder.describeRoute(webhook.Route{
+		Path: "/convert",
+		Type: webhook.RouteTypeConversion,
+		GVKs: []schema.GroupVersionKind{blder.gvk},
+	})
 	log.Info("Conversion webhook enabled", "GVK", blder.gvk)
 
 	return nil
@@ -339,6 +359,14 @@ func (blder *WebhookBuilder[T]) isAlreadyHandled(path string) bool {
 	return false
 }
 
+// describeRoute describes what the registered handler serves if the webhook server supports it,
+// so that conflicting registrations across builders are detected when the server starts.
+func (blder *WebhookBuilder[T]) describeRoute(route webhook.Route) {
+	if describer, ok := blder.mgr.GetWebhookServer().(webhook.RouteDescriber); ok {
+		describer.DescribeRoute(route)
+	}
+}
+
 func generateMutatePath(gvk schema.GroupVersionKind) string {
 	return "/mutate-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" +
 		gvk.Version + "-" + strings.ToLower(gvk.Kind)
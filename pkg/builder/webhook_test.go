@@ -917,6 +917,25 @@ func runTests(admissionReviewVersion string) {
 		ExpectWithOffset(1, err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("only one of Defaulter or CustomDefaulter can be set"))
 	})
+	It("should refuse to start the webhook server if two builders register the same path", func(specCtx SpecContext) {
+		m, err := manager.New(cfg, manager.Options{})
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+		addToScheme(m.GetScheme())
+
+		for range 2 {
+			err = WebhookManagedBy(m, &TestDefaulterObject{}).
+				WithCustomDefaulter(&TestCustomDefaulter{}).
+				Complete()
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		}
+
+		ctx, cancel := context.WithCancel(specCtx)
+		cancel()
+		err = m.GetWebhookServer().Start(ctx)
+		ExpectWithOffset(1, err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("path %s is registered more than once", generateMutatePath(testDefaulterGVK))))
+	})
 	It("should error if both a validator and a custom validator are set", func() {
 		m, err := manager.New(cfg, manager.Options{})
 		ExpectWithOffset(1, err).NotTo(HaveOccurred())
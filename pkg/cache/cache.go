@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/internal/apiwarnings"
 )
 
 var (
@@ -83,6 +84,15 @@ type Informers interface {
 	GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...InformerGetOption) (Informer, error)
 
 	// RemoveInformer removes an informer entry and stops it if it was running.
+	// It waits for the informer to stop or for ctx to be done, and drops the
+	// objects of its store.
+	//
+	// Kind sources that use the informer, e.g. the ones of controllers, are notified
+	// through StoppedNotifier. They stop and remove their event handlers, so that the
+	// informer can be freed, e.g. once the CRD of the object was uninstalled, and
+	// fail the liveness check of their controller if it is still running. They get a
+	// new informer if their controller is started again. Other event handlers aren't
+	// called anymore but must be removed by whoever added them.
 	RemoveInformer(ctx context.Context, obj client.Object) error
 
 	// Start runs all the informers known to this cache until the context is closed.
@@ -135,6 +145,15 @@ type Informer interface {
 	IsStopped() bool
 }
 
+// StoppedNotifier is implemented by informers that notify when they stopped, like
+// the informers of the caches created with New. It allows to react to a stopped
+// informer without polling IsStopped.
+type StoppedNotifier interface {
+	// Stopped returns a channel that is closed once the informer stopped, e.g.
+	// because it was removed with RemoveInformer.
+	Stopped() <-chan struct{}
+}
+
 // AllNamespaces should be used as the map key to deliminate namespace settings
 // that apply to all namespaces that themselves do not have explicit settings.
 const AllNamespaces = metav1.NamespaceAll
@@ -195,6 +214,100 @@ type Options struct {
 	// for every new requested resource.
 	ReaderFailOnMissingInformer bool
 
+	// DefaultSortBy sorts the objects of every List that doesn't pass a client.SortBy
+	// option, e.g. client.SortByName. Without it, objects are listed in no particular
+	// order, which can change between Lists and makes reconcilers flaky if their
+	// result depends on it, e.g. because they pick the first matching object.
+	//
+	// Sorting requires all matching objects to be read for every List, also when
+	// a limit is set.
+	DefaultSortBy client.SortBy
+
+	// EnableObjectMetrics reports the number and estimated size of the objects in the
+	// cache per group, version, kind and namespace as the controller_runtime_cache_objects
+	// and controller_runtime_cache_objects_size_bytes metrics. They are kept up to date
+	// by an event handler on every informer, which adds estimating the size of every
+	// added and updated object to the processing of events.
+	//
+	// Defaults to false.
+	EnableObjectMetrics bool
+
+	// MaxWatchSilence restarts the watch of an informer that didn't receive any
+	// event, including bookmarks, for the given duration. This guards against
+	// watches that silently died, e.g. because the connection to the API server
+	// went stale, which would leave controllers without events. The informer
+	// watches again from the resource version it last saw, so no events are lost.
+	//
+	// As the API server only sends bookmarks about once a minute, and only if
+	// watch bookmarks are enabled, it should be a multiple of that. Watches of
+	// objects that rarely change are restarted every MaxWatchSilence if the API
+	// server doesn't send bookmarks.
+	//
+	// Independent of it, the time of the last event of the watches, whether they
+	// are failing, their errors and restarts are reported per watch, i.e. per group,
+	// version, kind, form, namespace and selector of the informer, as the
+	// controller_runtime_watch_last_event_timestamp_seconds,
+	// controller_runtime_watch_failing, controller_runtime_watch_errors_total and
+	// controller_runtime_watch_restarts_total metrics. A watch is failing from its
+	// first error until it receives an event again, see HealthChecker.
+	//
+	// Defaults to 0, which disables restarting silent watches.
+	MaxWatchSilence time.Duration
+
+	// IdleInformerTTL stops and removes informers that were created on demand by a
+	// Get or List of the cache and weren't read from for the given duration. This
+	// prevents the cache from growing with every type that was ever read, e.g. in
+	// command line tools or multi-tenant uses of the cached client. The next read
+	// of an evicted type creates its informer again and waits for it to sync.
+	//
+	// Informers that were requested with GetInformer or GetInformerForKind, e.g.
+	// by controllers or to add indexes, are never evicted.
+	//
+	// Defaults to 0, which disables evicting informers.
+	IdleInformerTTL time.Duration
+
+	// ResumeStore saves the objects of every informer and the resourceVersion up to
+	// which they are current when the cache stops, so that the informers resume from
+	// it when the cache is started again, e.g. after a restart of the manager. Instead
+	// of listing all objects from the API server, a resumed informer only watches for
+	// the changes since then, which reduces the load a restart puts on the API server
+	// of large clusters considerably. If the API server doesn't have these changes
+	// anymore, the informer lists the objects as usual.
+	//
+	// The saved state is only used by informers with the same namespace and selectors,
+	// but it is used regardless of other options like transforms and filters, so it
+	// needs to be cleared if they change. Informers with a ResumeStore sync with a List
+	// even if EnableWatchList is set.
+	//
+	// Defaults to nil, which disables saving and resuming informers.
+	ResumeStore ResumeStore
+
+	// ResumeCheckpointInterval additionally saves the state of the informers in the
+	// ResumeStore in the given interval, so that they can resume after the process
+	// was killed without stopping the cache. Every checkpoint serializes all objects
+	// in the cache.
+	//
+	// Defaults to 0, which only saves the state when the cache stops.
+	ResumeCheckpointInterval time.Duration
+
+	// InitialListPageSize is the number of objects the informers request per page when
+	// they list objects in pages, which they do for their initial list. Smaller pages
+	// make each request cheaper for the API server, so that they are less likely to be
+	// throttled by API Priority and Fairness. The API server ignores it for lists that
+	// it serves from its watch cache.
+	//
+	// Defaults to 0, which uses the default page size of client-go of 500.
+	InitialListPageSize int64
+
+	// InitialListInterval starts the informers at least the given interval apart, so
+	// that a cache with many informers doesn't send all of their initial lists to the
+	// API server at once. The informers of all namespaces and objects of the cache are
+	// spread together, in the order they are created. This delays the sync of the
+	// cache by up to the interval times the number of informers.
+	//
+	// Defaults to 0, which starts all informers right away.
+	InitialListInterval time.Duration
+
 	// DefaultNamespaces maps namespace names to cache configs. If set, only
 	// the namespaces in here will be watched and it will by used to default
 	// ByObject.Namespaces for all objects if that is nil.
@@ -216,6 +329,13 @@ type Options struct {
 	// unless there is already one set in ByObject or DefaultNamespaces.
 	DefaultFieldSelector fields.Selector
 
+	// DefaultExclusions will be used as exclusions for all object types
+	// unless there are already some set in ByObject or DefaultNamespaces.
+	//
+	// RecommendedExclusions is a set of exclusions that is safe to use for
+	// most operators and often removes the majority of the cached Secret bytes.
+	DefaultExclusions []Exclusion
+
 	// DefaultTransform will be used as transform for all object types
 	// unless there is already one set in ByObject or DefaultNamespaces.
 	//
@@ -227,6 +347,9 @@ type Options struct {
 	// whenever ListAndWatch drops the connection with an error.
 	//
 	// After calling this handler, the informer will backoff and retry.
+	//
+	// This will be used for all object types, unless there is already one set in
+	// ByObject or DefaultNamespaces.
 	DefaultWatchErrorHandler toolscache.WatchErrorHandlerWithContext
 
 	// DefaultUnsafeDisableDeepCopy is the default for UnsafeDisableDeepCopy
@@ -251,10 +374,47 @@ type Options struct {
 	// Defaults to true.
 	DefaultEnableWatchBookmarks *bool
 
+	// DefaultEnableWatchList makes the informers sync initially with a streaming
+	// list, i.e. a watch that starts with the existing objects, instead of paginated
+	// List requests. It lowers the memory usage of the API server and speeds up the
+	// initial sync of large amounts of objects. Disable it for API servers that don't
+	// support streaming lists, e.g. aggregated APIs, to save the fallback request.
+	//
+	// Streaming lists also require the WatchListClient feature gate of client-go,
+	// which is enabled by default. Informers list the objects if it is disabled.
+	//
+	// This will be used for all object types, unless it is set in ByObject or
+	// DefaultNamespaces.
+	//
+	// Defaults to true.
+	DefaultEnableWatchList *bool
+
 	// ByObject restricts the cache's ListWatch to the desired fields per GVK at the specified object.
-	// If unset, this will fall through to the Default* settings.
+	// If unset, this will fall through to the ByGroup setting of its group and then
+	// to the Default* settings.
 	ByObject map[client.Object]ByObject
 
+	// ByGroup configures the cache for all types of an API group, keyed by the name
+	// of the group, e.g. "apps" or "" for the core group. It applies to the types of
+	// the group that are not in ByObject, and is the default for the settings that
+	// are unset in the ByObject of the types of the group.
+	//
+	// Settings are inherited in the following precedence order, from the most to the
+	// least specific, settings that are unset fall through to the next level:
+	// 1. ByObject.Namespaces[namespace]
+	// 2. ByObject
+	// 3. ByGroup.Namespaces[namespace]
+	// 4. ByGroup
+	// 5. DefaultNamespaces[namespace]
+	// 6. Default*
+	//
+	// Set a setting to its empty value, e.g. labels.Everything(), to prevent it from
+	// being inherited. The Namespaces of a ByGroup only apply to namespaced types,
+	// cluster-scoped types of the group are cached with its other settings.
+	//
+	// Use EffectiveConfigs to see which configuration the cache uses for a type.
+	ByGroup map[string]ByObject
+
 	// NewInformer allows overriding of NewSharedIndexInformer, for example for testing
 	// or if someone wants to write their own Informer.
 	NewInformer func(toolscache.ListerWatcher, runtime.Object, time.Duration, toolscache.Indexers) toolscache.SharedIndexInformer
@@ -290,6 +450,14 @@ type ByObject struct {
 	// Field represents a field selector for the object.
 	Field fields.Selector
 
+	// Exclusions exclude objects from the cache by their labels, fields or
+	// namespaces, e.g. ExcludeHelmReleases for Secrets. They are added to Label
+	// and Field, so they are applied by the API server.
+	//
+	// A nil value allows to default this to Options.DefaultExclusions, an empty
+	// slice prevents this.
+	Exclusions []Exclusion
+
 	// Transform is a transformer function for the object which gets applied
 	// when objects of the transformation are about to be committed to the cache.
 	//
@@ -297,6 +465,19 @@ type ByObject struct {
 	// and for updated objects.
 	Transform toolscache.TransformFunc
 
+	// CELFilter is a CEL expression that is evaluated for every object the informer
+	// of the object lists or watches, with the object as the "object" variable, e.g.
+	// `object.spec.nodeName == "node-a"` or `has(object.metadata.ownerReferences)`.
+	// Objects for which it is false are dropped before they are stored or passed to
+	// event handlers, objects that stop matching are deleted from the cache. This
+	// allows to cache less than label and field selectors can express, but unlike
+	// them it doesn't reduce what is sent by the API server.
+	//
+	// The expression is evaluated before the Transform. Objects for which it fails,
+	// e.g. because a field doesn't exist, are kept; use has() to check for optional
+	// fields. An invalid expression fails the creation of the cache.
+	CELFilter string
+
 	// UnsafeDisableDeepCopy indicates not to deep copy objects during get or
 	// list objects per GVK at the specified object.
 	// Be very careful with this, when enabled you must DeepCopy any object before mutating it,
@@ -312,40 +493,49 @@ type ByObject struct {
 	// Defaults to true.
 	EnableWatchBookmarks *bool
 
-	// SyncPeriod determines the minimum frequency at which watched resources are
-	// reconciled. A lower period will correct entropy more quickly, but reduce
-	// responsiveness to change if there are many watched resources. Change this
-	// value only if you know what you are doing. Defaults to 10 hours if unset.
-	// there will a 10 percent jitter between the SyncPeriod of all controllers
-	// so that all controllers will not send list requests simultaneously.
+	// EnableWatchList makes the informer of the object sync initially with a
+	// streaming list, see Options.DefaultEnableWatchList.
 	//
-	// This applies to all controllers.
+	// Defaults to true.
+	EnableWatchList *bool
+
+	// WatchErrorHandler is called whenever ListAndWatch of the informer of the
+	// object drops the connection with an error, e.g. because the object is
+	// forbidden. It allows to surface persistent errors, e.g. as a failing
+	// readiness check or by shutting down, instead of only logging them.
 	//
-	// A period sync happens for two reasons:
-	// 1. To insure against a bug in the controller that causes an object to not
-	// be requeued, when it otherwise should be requeued.
-	// 2. To insure against an unknown bug in controller-runtime, or its dependencies,
-	// that causes an object to not be requeued, when it otherwise should be
-	// requeued, or to be removed from the queue, when it otherwise should not
-	// be removed.
+	// After calling this handler, the informer will backoff and retry.
+	//
+	// Defaults to DefaultWatchErrorHandler.
+	WatchErrorHandler toolscache.WatchErrorHandlerWithContext
+
+	// SyncPeriod is the period at which the informer of the object is resynced,
+	// see Options.SyncPeriod. It allows resyncing objects that drift often, e.g.
+	// because they are also managed outside of the cluster, more frequently than
+	// other objects without running a separate cache for them.
+	//
+	// If Namespaces is set, it is the default for the namespaces whose Config
+	// doesn't set a SyncPeriod.
 	//
 	// If you want
 	// 1. to insure against missed watch events, or
 	// 2. to poll services that cannot be watched,
-	// then we recommend that, instead of changing the default period, the
-	// controller requeue, with a constant duration `t`, whenever the controller
-	// is "done" with an object, and would otherwise not requeue it, i.e., we
-	// recommend the `Reconcile` function return `reconcile.Result{RequeueAfter: t}`,
-	// instead of `reconcile.Result{}`.
+	// then we recommend that, instead of changing the period, the controller
+	// requeue, with a constant duration `t`, whenever the controller is "done"
+	// with an object, and would otherwise not requeue it, i.e., we recommend the
+	// `Reconcile` function return `reconcile.Result{RequeueAfter: t}`, instead of
+	// `reconcile.Result{}`.
 	//
 	// SyncPeriod will locally trigger an artificial Update event with the same
-	// object in both ObjectOld and ObjectNew for everything that is in the
-	// cache.
+	// object in both ObjectOld and ObjectNew for every object of the type that is
+	// in the cache.
 	//
 	// Predicates or Handlers that expect ObjectOld and ObjectNew to be different
 	// (such as GenerationChangedPredicate) will filter out this event, preventing
 	// it from triggering a reconciliation.
 	// SyncPeriod does not sync between the local cache and the server.
+	//
+	// Defaults to Options.SyncPeriod, which defaults to 10 hours.
 	SyncPeriod *time.Duration
 }
 
@@ -363,6 +553,12 @@ type Config struct {
 	// Set to fields.Everything() if you don't want this defaulted.
 	FieldSelector fields.Selector
 
+	// Exclusions specifies objects to exclude in addition to the selectors,
+	// see ByObject.Exclusions. A nil value allows to default this.
+	//
+	// Set to an empty slice if you don't want this defaulted.
+	Exclusions []Exclusion
+
 	// Transform specifies a transform func. A nil value allows to default
 	// this.
 	//
@@ -370,6 +566,10 @@ type Config struct {
 	// func(in interface{}) (interface{}, error) { return in, nil }
 	Transform toolscache.TransformFunc
 
+	// CELFilter specifies a CEL expression that drops the objects it is false
+	// for, see ByObject.CELFilter. An empty value allows to default this.
+	CELFilter string
+
 	// UnsafeDisableDeepCopy specifies if List and Get requests against the
 	// cache should not DeepCopy. A nil value allows to default this.
 	UnsafeDisableDeepCopy *bool
@@ -383,6 +583,14 @@ type Config struct {
 	// Defaults to true.
 	EnableWatchBookmarks *bool
 
+	// EnableWatchList makes the informers sync initially with a streaming list,
+	// see Options.DefaultEnableWatchList. A nil value allows to default this.
+	EnableWatchList *bool
+
+	// WatchErrorHandler is called whenever ListAndWatch drops the connection
+	// with an error. A nil value allows to default this.
+	WatchErrorHandler toolscache.WatchErrorHandlerWithContext
+
 	// SyncPeriod determines the minimum frequency at which watched resources are
 	// reconciled. A lower period will correct entropy more quickly, but reduce
 	// responsiveness to change if there are many watched resources. Change this
@@ -425,6 +633,17 @@ type NewCacheFunc func(config *rest.Config, opts Options) (Cache, error)
 
 // New initializes and returns a new Cache.
 func New(cfg *rest.Config, opts Options) (Cache, error) {
+	// Types whose ByObject.Namespaces are unset follow DefaultNamespaces, also when
+	// namespaces are added or removed at runtime.
+	followDefaultNamespaces := map[client.Object]bool{}
+	for obj, byObject := range opts.ByObject {
+		followDefaultNamespaces[obj] = byObject.Namespaces == nil
+	}
+	groupFollowsDefaultNamespaces := map[string]bool{}
+	for group, byGroup := range opts.ByGroup {
+		groupFollowsDefaultNamespaces[group] = byGroup.Namespaces == nil
+	}
+
 	opts, err := defaultOpts(cfg, opts)
 	if err != nil {
 		return nil, err
@@ -435,19 +654,22 @@ func New(cfg *rest.Config, opts Options) (Cache, error) {
 	var defaultCache Cache
 	if len(opts.DefaultNamespaces) > 0 {
 		defaultConfig := optionDefaultsToConfig(&opts)
-		defaultCache = newMultiNamespaceCache(newCacheFunc, opts.Scheme, opts.Mapper, opts.DefaultNamespaces, &defaultConfig)
+		defaultCache = newMultiNamespaceCache(newCacheFunc, opts.Scheme, opts.Mapper, opts.DefaultNamespaces, &defaultConfig, defaultConfig, opts.DefaultSortBy)
 	} else {
 		defaultCache = newCacheFunc(optionDefaultsToConfig(&opts), corev1.NamespaceAll)
 	}
 
-	if len(opts.ByObject) == 0 {
+	if len(opts.ByObject) == 0 && len(opts.ByGroup) == 0 {
 		return defaultCache, nil
 	}
 
 	delegating := &delegatingByGVKCache{
-		scheme:       opts.Scheme,
-		caches:       make(map[schema.GroupVersionKind]Cache, len(opts.ByObject)),
-		defaultCache: defaultCache,
+		scheme:                        opts.Scheme,
+		caches:                        make(map[schema.GroupVersionKind]Cache, len(opts.ByObject)),
+		groupCaches:                   make(map[string]Cache, len(opts.ByGroup)),
+		defaultCache:                  defaultCache,
+		followDefaultNamespaces:       map[schema.GroupVersionKind]Config{},
+		groupsFollowDefaultNamespaces: map[string]Config{},
 	}
 
 	for obj, config := range opts.ByObject {
@@ -457,13 +679,34 @@ func New(cfg *rest.Config, opts Options) (Cache, error) {
 		}
 		var cache Cache
 		if len(config.Namespaces) > 0 {
-			cache = newMultiNamespaceCache(newCacheFunc, opts.Scheme, opts.Mapper, config.Namespaces, nil)
+			cache = newMultiNamespaceCache(newCacheFunc, opts.Scheme, opts.Mapper, config.Namespaces, nil, optionDefaultsToConfig(&opts), opts.DefaultSortBy)
+			// Types only follow DefaultNamespaces if they inherit them.
+			_, hasByGroup := opts.ByGroup[gvk.Group]
+			if followDefaultNamespaces[obj] && (!hasByGroup || groupFollowsDefaultNamespaces[gvk.Group]) {
+				delegating.followDefaultNamespaces[gvk] = byObjectToConfig(config)
+			}
 		} else {
 			cache = newCacheFunc(byObjectToConfig(config), corev1.NamespaceAll)
 		}
 		delegating.caches[gvk] = cache
 	}
 
+	for group, byGroup := range opts.ByGroup {
+		groupConfig := byObjectToConfig(byGroup)
+		var cache Cache
+		if len(byGroup.Namespaces) > 0 {
+			// A group may have cluster-scoped types, which are cached with the
+			// group-level config.
+			cache = newMultiNamespaceCache(newCacheFunc, opts.Scheme, opts.Mapper, byGroup.Namespaces, &groupConfig, optionDefaultsToConfig(&opts), opts.DefaultSortBy)
+			if groupFollowsDefaultNamespaces[group] {
+				delegating.groupsFollowDefaultNamespaces[group] = groupConfig
+			}
+		} else {
+			cache = newCacheFunc(groupConfig, corev1.NamespaceAll)
+		}
+		delegating.groupCaches[group] = cache
+	}
+
 	return delegating, nil
 }
 
@@ -485,20 +728,42 @@ func optionDefaultsToConfig(opts *Options) Config {
 	return Config{
 		LabelSelector:         opts.DefaultLabelSelector,
 		FieldSelector:         opts.DefaultFieldSelector,
+		Exclusions:            opts.DefaultExclusions,
 		Transform:             opts.DefaultTransform,
 		UnsafeDisableDeepCopy: opts.DefaultUnsafeDisableDeepCopy,
 		EnableWatchBookmarks:  opts.DefaultEnableWatchBookmarks,
+		EnableWatchList:       opts.DefaultEnableWatchList,
+		WatchErrorHandler:     opts.DefaultWatchErrorHandler,
 		SyncPeriod:            opts.SyncPeriod,
 	}
 }
 
+// byObjectWithConfig returns byObject with the settings of config.
+func byObjectWithConfig(byObject ByObject, config Config) ByObject {
+	byObject.Label = config.LabelSelector
+	byObject.Field = config.FieldSelector
+	byObject.Exclusions = config.Exclusions
+	byObject.Transform = config.Transform
+	byObject.CELFilter = config.CELFilter
+	byObject.UnsafeDisableDeepCopy = config.UnsafeDisableDeepCopy
+	byObject.EnableWatchBookmarks = config.EnableWatchBookmarks
+	byObject.EnableWatchList = config.EnableWatchList
+	byObject.WatchErrorHandler = config.WatchErrorHandler
+	byObject.SyncPeriod = config.SyncPeriod
+	return byObject
+}
+
 func byObjectToConfig(byObject ByObject) Config {
 	return Config{
 		LabelSelector:         byObject.Label,
 		FieldSelector:         byObject.Field,
+		Exclusions:            byObject.Exclusions,
 		Transform:             byObject.Transform,
+		CELFilter:             byObject.CELFilter,
 		UnsafeDisableDeepCopy: byObject.UnsafeDisableDeepCopy,
 		EnableWatchBookmarks:  byObject.EnableWatchBookmarks,
+		EnableWatchList:       byObject.EnableWatchList,
+		WatchErrorHandler:     byObject.WatchErrorHandler,
 		SyncPeriod:            byObject.SyncPeriod,
 	}
 }
@@ -506,26 +771,44 @@ func byObjectToConfig(byObject ByObject) Config {
 type newCacheFunc func(config Config, namespace string) Cache
 
 func newCache(restConfig *rest.Config, opts Options) newCacheFunc {
+	startSchedule := internal.NewStartSchedule(opts.InitialListInterval)
 	return func(config Config, namespace string) Cache {
+		// All configs are validated by defaultOpts and AddNamespace.
+		filter, err := newCELFilter(config.CELFilter)
+		if err != nil {
+			celFilterLog.Error(err, "Ignoring invalid CEL filter", "namespace", namespace)
+		}
+		selector, err := selectorFor(config)
+		if err != nil {
+			exclusionLog.Error(err, "Ignoring invalid exclusions", "namespace", namespace)
+			selector = internal.Selector{Label: config.LabelSelector, Field: config.FieldSelector}
+		}
 		return &informerCache{
 			scheme: opts.Scheme,
 			Informers: internal.NewInformers(restConfig, &internal.InformersOpts{
-				HTTPClient:   opts.HTTPClient,
-				Scheme:       opts.Scheme,
-				Mapper:       opts.Mapper,
-				ResyncPeriod: ptr.Deref(config.SyncPeriod, defaultSyncPeriod),
-				Namespace:    namespace,
-				Selector: internal.Selector{
-					Label: config.LabelSelector,
-					Field: config.FieldSelector,
-				},
+				HTTPClient:            opts.HTTPClient,
+				Scheme:                opts.Scheme,
+				Mapper:                opts.Mapper,
+				ResyncPeriod:          ptr.Deref(config.SyncPeriod, defaultSyncPeriod),
+				Namespace:             namespace,
+				Selector:              selector,
 				Transform:             config.Transform,
-				WatchErrorHandler:     opts.DefaultWatchErrorHandler,
+				WatchErrorHandler:     config.WatchErrorHandler,
 				UnsafeDisableDeepCopy: ptr.Deref(config.UnsafeDisableDeepCopy, false),
 				EnableWatchBookmarks:  ptr.Deref(config.EnableWatchBookmarks, true),
+				EnableWatchList:       ptr.Deref(config.EnableWatchList, true),
 				NewInformer:           opts.NewInformer,
+				ObjectMetrics:         opts.EnableObjectMetrics,
+				MaxWatchSilence:       opts.MaxWatchSilence,
+				IdleTTL:               opts.IdleInformerTTL,
+				ResumeStore:           opts.ResumeStore,
+				ResumeInterval:        opts.ResumeCheckpointInterval,
+				InitialListPageSize:   opts.InitialListPageSize,
+				StartSchedule:         startSchedule,
+				Filter:                filter,
 			}),
 			readerFailOnMissingInformer: opts.ReaderFailOnMissingInformer,
+			defaultSortBy:               opts.DefaultSortBy,
 		}
 	}
 }
@@ -543,6 +826,7 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 		if err != nil {
 			return Options{}, fmt.Errorf("could not create HTTP client from config: %w", err)
 		}
+		opts.HTTPClient = apiwarnings.WrapClient(opts.HTTPClient)
 	}
 
 	// Use the default Kubernetes Scheme if unset
@@ -569,10 +853,19 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 		if !isNamespaced && byObject.Namespaces != nil {
 			return opts, fmt.Errorf("type %T is not namespaced, but its ByObject.Namespaces setting is not nil", obj)
 		}
+		gvk, err := apiutil.GVKForObject(obj, opts.Scheme)
+		if err != nil {
+			return opts, fmt.Errorf("failed to get GVK for type %T: %w", obj, err)
+		}
+		// The undefaulted config of the group of the type, which is empty if there is none.
+		byGroup := opts.ByGroup[gvk.Group]
 
-		if isNamespaced && byObject.Namespaces == nil {
+		switch {
+		case isNamespaced && byObject.Namespaces == nil && byGroup.Namespaces != nil:
+			byObject.Namespaces = maps.Clone(byGroup.Namespaces)
+		case isNamespaced && byObject.Namespaces == nil:
 			byObject.Namespaces = maps.Clone(opts.DefaultNamespaces)
-		} else {
+		default:
 			byObject.Namespaces = maps.Clone(byObject.Namespaces)
 		}
 
@@ -581,13 +874,19 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 		for namespace, config := range byObject.Namespaces {
 			// 1. Default from the undefaulted type-level config
 			config = defaultConfig(config, byObjectToConfig(byObject))
-			// 2. Default from the namespace-level config. This was defaulted from the global default config earlier, but
+			// 2. Default from the undefaulted config of the group, first for the namespace, then
+			//    for the whole group.
+			if groupNamespaceSettings, hasGroupNamespace := byGroup.Namespaces[namespace]; hasGroupNamespace {
+				config = defaultConfig(config, groupNamespaceSettings)
+			}
+			config = defaultConfig(config, byObjectToConfig(byGroup))
+			// 3. Default from the namespace-level config. This was defaulted from the global default config earlier, but
 			//    might not have an entry for the current namespace.
 			if defaultNamespaceSettings, hasDefaultNamespace := opts.DefaultNamespaces[namespace]; hasDefaultNamespace {
 				config = defaultConfig(config, defaultNamespaceSettings)
 			}
 
-			// 3. Default from the global defaults
+			// 4. Default from the global defaults
 			config = defaultConfig(config, optionDefaultsToConfig(&opts))
 
 			if namespace == metav1.NamespaceAll {
@@ -605,18 +904,45 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 		// Only default ByObject iself if it isn't namespaced or has no namespaces configured, as only
 		// then any of this will be honored.
 		if !isNamespaced || len(byObject.Namespaces) == 0 {
-			defaultedConfig := defaultConfig(byObjectToConfig(byObject), optionDefaultsToConfig(&opts))
-			byObject.Label = defaultedConfig.LabelSelector
-			byObject.Field = defaultedConfig.FieldSelector
-			byObject.Transform = defaultedConfig.Transform
-			byObject.UnsafeDisableDeepCopy = defaultedConfig.UnsafeDisableDeepCopy
-			byObject.EnableWatchBookmarks = defaultedConfig.EnableWatchBookmarks
-			byObject.SyncPeriod = defaultedConfig.SyncPeriod
+			defaultedConfig := defaultConfig(byObjectToConfig(byObject), byObjectToConfig(byGroup))
+			defaultedConfig = defaultConfig(defaultedConfig, optionDefaultsToConfig(&opts))
+			byObject = byObjectWithConfig(byObject, defaultedConfig)
 		}
 
 		opts.ByObject[obj] = byObject
 	}
 
+	// Default groups after byObject has been defaulted, as the types of a group need
+	// the undefaulted config of the group to fall through to DefaultNamespaces.
+	opts.ByGroup = maps.Clone(opts.ByGroup)
+	for group, byGroup := range opts.ByGroup {
+		if byGroup.Namespaces == nil {
+			byGroup.Namespaces = maps.Clone(opts.DefaultNamespaces)
+		} else {
+			byGroup.Namespaces = maps.Clone(byGroup.Namespaces)
+		}
+		for namespace, config := range byGroup.Namespaces {
+			config = defaultConfig(config, byObjectToConfig(byGroup))
+			if defaultNamespaceSettings, hasDefaultNamespace := opts.DefaultNamespaces[namespace]; hasDefaultNamespace {
+				config = defaultConfig(config, defaultNamespaceSettings)
+			}
+			config = defaultConfig(config, optionDefaultsToConfig(&opts))
+			if namespace == metav1.NamespaceAll {
+				config.FieldSelector = fields.AndSelectors(
+					appendIfNotNil(
+						namespaceAllSelector(slices.Collect(maps.Keys(byGroup.Namespaces))),
+						config.FieldSelector,
+					)...,
+				)
+			}
+			byGroup.Namespaces[namespace] = config
+		}
+
+		// The group-level config is always defaulted, as it is used for the cluster-scoped
+		// types of the group.
+		opts.ByGroup[group] = byObjectWithConfig(byGroup, defaultConfig(byObjectToConfig(byGroup), optionDefaultsToConfig(&opts)))
+	}
+
 	// Default namespaces after byObject has been defaulted, otherwise a namespace without selectors
 	// will get the `Default` selectors, then get copied to byObject and then not get defaulted from
 	// byObject, as it already has selectors.
@@ -633,9 +959,52 @@ func defaultOpts(config *rest.Config, opts Options) (Options, error) {
 		opts.DefaultNamespaces[namespace] = cfg
 	}
 
+	if err := validateConfigs(opts); err != nil {
+		return opts, err
+	}
+
 	return opts, nil
 }
 
+// validateConfigs checks that the CEL filters and exclusions of all configs are valid,
+// so that invalid ones fail the creation of the cache.
+func validateConfigs(opts Options) error {
+	for obj, byObject := range opts.ByObject {
+		if err := validateConfig(byObjectToConfig(byObject)); err != nil {
+			return fmt.Errorf("type %T: %w", obj, err)
+		}
+		for namespace, config := range byObject.Namespaces {
+			if err := validateConfig(config); err != nil {
+				return fmt.Errorf("type %T in namespace %q: %w", obj, namespace, err)
+			}
+		}
+	}
+	for group, byGroup := range opts.ByGroup {
+		if err := validateConfig(byObjectToConfig(byGroup)); err != nil {
+			return fmt.Errorf("group %q: %w", group, err)
+		}
+		for namespace, config := range byGroup.Namespaces {
+			if err := validateConfig(config); err != nil {
+				return fmt.Errorf("group %q in namespace %q: %w", group, namespace, err)
+			}
+		}
+	}
+	for namespace, config := range opts.DefaultNamespaces {
+		if err := validateConfig(config); err != nil {
+			return fmt.Errorf("namespace %q: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+func validateConfig(config Config) error {
+	if _, err := newCELFilter(config.CELFilter); err != nil {
+		return err
+	}
+	_, err := selectorFor(config)
+	return err
+}
+
 func defaultConfig(toDefault, defaultFrom Config) Config {
 	if toDefault.LabelSelector == nil {
 		toDefault.LabelSelector = defaultFrom.LabelSelector
@@ -643,15 +1012,27 @@ func defaultConfig(toDefault, defaultFrom Config) Config {
 	if toDefault.FieldSelector == nil {
 		toDefault.FieldSelector = defaultFrom.FieldSelector
 	}
+	if toDefault.Exclusions == nil {
+		toDefault.Exclusions = defaultFrom.Exclusions
+	}
 	if toDefault.Transform == nil {
 		toDefault.Transform = defaultFrom.Transform
 	}
+	if toDefault.CELFilter == "" {
+		toDefault.CELFilter = defaultFrom.CELFilter
+	}
 	if toDefault.UnsafeDisableDeepCopy == nil {
 		toDefault.UnsafeDisableDeepCopy = defaultFrom.UnsafeDisableDeepCopy
 	}
 	if toDefault.EnableWatchBookmarks == nil {
 		toDefault.EnableWatchBookmarks = defaultFrom.EnableWatchBookmarks
 	}
+	if toDefault.EnableWatchList == nil {
+		toDefault.EnableWatchList = defaultFrom.EnableWatchList
+	}
+	if toDefault.WatchErrorHandler == nil {
+		toDefault.WatchErrorHandler = defaultFrom.WatchErrorHandler
+	}
 	if toDefault.SyncPeriod == nil {
 		toDefault.SyncPeriod = defaultFrom.SyncPeriod
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var celFilterLog = logf.RuntimeLog.WithName("cache").WithName("cel-filter")
+
+// newCELFilter compiles a CEL filter, see ByObject.CELFilter. It returns nil for an
+// empty expression.
+func newCELFilter(expression string) (func(runtime.Object) bool, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("invalid CEL filter %q: %w", expression, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("CEL filter %q must evaluate to a bool, not %s", expression, ast.OutputType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEL filter %q: %w", expression, err)
+	}
+
+	return func(obj runtime.Object) bool {
+		var content map[string]any
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			content = u.Object
+		} else if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			celFilterLog.Error(err, "Failed to convert object for CEL filter, keeping it", "type", fmt.Sprintf("%T", obj))
+			return true
+		}
+		out, _, err := program.Eval(map[string]any{"object": content})
+		if err != nil {
+			celFilterLog.V(1).Info("Failed to evaluate CEL filter, keeping the object", "filter", expression, "error", err.Error())
+			return true
+		}
+		matches, ok := out.Value().(bool)
+		return !ok || matches
+	}, nil
+}
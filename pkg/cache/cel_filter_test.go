@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCELFilter(t *testing.T) {
+	t.Parallel()
+
+	scheduled := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduled", Labels: map[string]string{"app": "a"}},
+		Spec:       corev1.PodSpec{NodeName: "node-a"},
+	}
+	pending := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pending"}}
+	unstructuredPod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "unstructured"},
+		"spec":       map[string]any{"nodeName": "node-a"},
+	}}
+
+	testCases := []struct {
+		name       string
+		expression string
+		obj        runtime.Object
+		expected   bool
+	}{
+		{name: "typed object matches", expression: `object.spec.nodeName == "node-a"`, obj: scheduled, expected: true},
+		{name: "typed object doesn't match", expression: `has(object.spec.nodeName)`, obj: pending, expected: false},
+		{name: "unstructured object matches", expression: `object.spec.nodeName == "node-a"`, obj: unstructuredPod, expected: true},
+		{name: "metadata is available", expression: `object.metadata.labels.app == "a"`, obj: scheduled, expected: true},
+		{name: "objects are kept if the evaluation fails", expression: `object.metadata.labels.app == "a"`, obj: pending, expected: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newCELFilter(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches := filter(tc.obj); matches != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, matches)
+			}
+		})
+	}
+
+	t.Run("empty expression", func(t *testing.T) {
+		filter, err := newCELFilter("")
+		if err != nil || filter != nil {
+			t.Errorf("expected no filter, got a filter: %t, error: %v", filter != nil, err)
+		}
+	})
+
+	for expression, expectedErr := range map[string]string{
+		`object.spec.nodeName ==`: "invalid CEL filter",
+		`"not a bool"`:            "must evaluate to a bool",
+	} {
+		t.Run("invalid expression "+expression, func(t *testing.T) {
+			if _, err := newCELFilter(expression); err == nil || !strings.Contains(err.Error(), expectedErr) {
+				t.Errorf("expected error containing %q, got %v", expectedErr, err)
+			}
+		})
+	}
+
+	t.Run("invalid expression fails the defaulting", func(t *testing.T) {
+		_, err := defaultOpts(&rest.Config{}, Options{
+			Mapper:   &fakeRESTMapper{},
+			ByObject: map[client.Object]ByObject{&corev1.Pod{}: {CELFilter: "object.spec.nodeName =="}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "invalid CEL filter") {
+			t.Errorf("expected error containing %q, got %v", "invalid CEL filter", err)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podsCache lists the given Pods of a namespace in reverse order, unless a SortBy
+// option is passed.
+type podsCache struct {
+	Cache
+	names []string
+	ns    string
+}
+
+func (c *podsCache) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	pods := list.(*corev1.PodList)
+	pods.Items = nil
+	for _, name := range slices.Backward(c.names) {
+		pods.Items = append(pods.Items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: c.ns, Name: name}})
+	}
+	if listOpts.SortBy != nil {
+		slices.SortStableFunc(pods.Items, func(a, b corev1.Pod) int {
+			return listOpts.SortBy(&a, &b)
+		})
+	}
+	return nil
+}
+
+var _ = Describe("multiNamespaceCache with a default SortBy", func() {
+	var c Cache
+
+	BeforeEach(func() {
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("PodList"), apimeta.RESTScopeNamespace)
+		newCache := func(_ Config, namespace string) Cache {
+			return &podsCache{ns: namespace, names: []string{"a", "b", "c"}}
+		}
+		namespaces := map[string]Config{"ns-2": {}, "ns-1": {}, "ns-3": {}}
+		c = newMultiNamespaceCache(newCache, scheme.Scheme, mapper, namespaces, nil, Config{}, client.SortByName)
+	})
+
+	keys := func(pods *corev1.PodList) []string {
+		var keys []string
+		for _, pod := range pods.Items {
+			keys = append(keys, pod.Namespace+"/"+pod.Name)
+		}
+		return keys
+	}
+
+	It("should sort the objects of all namespaces", func() {
+		pods := &corev1.PodList{}
+		Expect(c.List(context.Background(), pods)).To(Succeed())
+		Expect(keys(pods)).To(Equal([]string{
+			"ns-1/a", "ns-1/b", "ns-1/c",
+			"ns-2/a", "ns-2/b", "ns-2/c",
+			"ns-3/a", "ns-3/b", "ns-3/c",
+		}))
+	})
+
+	It("should sort the objects of a single namespace", func() {
+		pods := &corev1.PodList{}
+		Expect(c.List(context.Background(), pods, client.InNamespace("ns-2"))).To(Succeed())
+		Expect(keys(pods)).To(Equal([]string{"ns-2/a", "ns-2/b", "ns-2/c"}))
+	})
+
+	It("should prefer a SortBy option", func() {
+		byNameDescending := client.SortBy(func(a, b client.Object) int {
+			return -client.SortByName(a, b)
+		})
+		pods := &corev1.PodList{}
+		Expect(c.List(context.Background(), pods, byNameDescending, client.Limit(2))).To(Succeed())
+		Expect(keys(pods)).To(Equal([]string{"ns-3/c", "ns-3/b"}))
+	})
+})
+
+var _ = Describe("multiNamespaceCache without a default SortBy", func() {
+	It("should page through the objects of all namespaces exactly once", func() {
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("PodList"), apimeta.RESTScopeNamespace)
+		newCache := func(_ Config, namespace string) Cache {
+			return &podsCache{ns: namespace, names: []string{"a", "b", "c", "d"}}
+		}
+		namespaces := map[string]Config{"ns-2": {}, "ns-1": {}, "ns-3": {}}
+		c := newMultiNamespaceCache(newCache, scheme.Scheme, mapper, namespaces, nil, Config{}, nil)
+
+		seen := map[string]int{}
+		for offset := int64(0); ; offset += 5 {
+			pods := &corev1.PodList{}
+			Expect(c.List(context.Background(), pods, client.ListOffset(offset), client.Limit(5))).To(Succeed())
+			if len(pods.Items) == 0 {
+				break
+			}
+			for _, pod := range pods.Items {
+				seen[pod.Namespace+"/"+pod.Name]++
+			}
+		}
+		Expect(seen).To(HaveLen(12))
+		for key, count := range seen {
+			Expect(count).To(Equal(1), "%s was listed %d times", key, count)
+		}
+	})
+})
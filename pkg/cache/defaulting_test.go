@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	"context"
 	"reflect"
 	"sync"
 	"testing"
@@ -25,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	fuzz "github.com/google/gofuzz"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
@@ -236,6 +238,51 @@ func TestDefaultOpts(t *testing.T) {
 				return cmp.Diff(expected, o.ByObject[pod].EnableWatchBookmarks)
 			},
 		},
+		{
+			name: "ByObject.EnableWatchList gets defaulted from DefaultEnableWatchList",
+			in: Options{
+				ByObject:               map[client.Object]ByObject{pod: {}},
+				DefaultEnableWatchList: new(false),
+			},
+
+			verification: func(o Options) string {
+				expected := new(false)
+				return cmp.Diff(expected, o.ByObject[pod].EnableWatchList)
+			},
+		},
+		{
+			name: "ByObject.Namespaces get the CELFilter of ByObject",
+			in: Options{
+				ByObject:          map[client.Object]ByObject{pod: {CELFilter: "has(object.spec)"}},
+				DefaultNamespaces: map[string]Config{"default": {}},
+			},
+
+			verification: func(o Options) string {
+				return cmp.Diff("has(object.spec)", o.ByObject[pod].Namespaces["default"].CELFilter)
+			},
+		},
+		{
+			name: "ByObject.Exclusions get defaulted from DefaultExclusions",
+			in: Options{
+				ByObject:          map[client.Object]ByObject{pod: {}},
+				DefaultExclusions: RecommendedExclusions(),
+			},
+
+			verification: func(o Options) string {
+				return cmp.Diff(RecommendedExclusions(), o.ByObject[pod].Exclusions)
+			},
+		},
+		{
+			name: "ByObject.Exclusions doesn't get defaulted when set to an empty slice",
+			in: Options{
+				ByObject:          map[client.Object]ByObject{pod: {Exclusions: []Exclusion{}}},
+				DefaultExclusions: RecommendedExclusions(),
+			},
+
+			verification: func(o Options) string {
+				return cmp.Diff([]Exclusion{}, o.ByObject[pod].Exclusions)
+			},
+		},
 		{
 			name: "ByObject.EnableWatchBookmarks doesn't get defaulted when set",
 			in: Options{
@@ -248,6 +295,20 @@ func TestDefaultOpts(t *testing.T) {
 				return cmp.Diff(expected, o.ByObject[pod].EnableWatchBookmarks)
 			},
 		},
+		{
+			name: "ByObject.WatchErrorHandler gets defaulted from DefaultWatchErrorHandler",
+			in: Options{
+				ByObject:                 map[client.Object]ByObject{pod: {}},
+				DefaultWatchErrorHandler: func(context.Context, *cache.Reflector, error) {},
+			},
+
+			verification: func(o Options) string {
+				if o.ByObject[pod].WatchErrorHandler == nil {
+					return "expected WatchErrorHandler to be defaulted"
+				}
+				return ""
+			},
+		},
 		{
 			name: "ByObject.SyncPeriod gets defaulted from SyncPeriod",
 			in: Options{
@@ -438,6 +499,70 @@ func TestDefaultOpts(t *testing.T) {
 				return compare(expected, o)
 			},
 		},
+		{
+			name: "ByObject gets defaulted from ByGroup",
+			in: Options{
+				ByObject: map[client.Object]ByObject{pod: {}},
+				ByGroup: map[string]ByObject{"": {
+					Namespaces: map[string]Config{"default": {}},
+					Label:      labels.SelectorFromSet(map[string]string{"from": "by-group"}),
+				}},
+				DefaultLabelSelector: labels.SelectorFromSet(map[string]string{"from": "default-label-selector"}),
+			},
+			verification: func(o Options) string {
+				expected := map[string]Config{
+					"default": {LabelSelector: labels.SelectorFromSet(map[string]string{"from": "by-group"})},
+				}
+				return cmp.Diff(expected, o.ByObject[pod].Namespaces)
+			},
+		},
+		{
+			name: "ByObject takes precedence over ByGroup.Namespaces, which takes precedence over ByGroup",
+			in: Options{
+				ByObject: map[client.Object]ByObject{pod: {
+					Label: labels.SelectorFromSet(map[string]string{"from": "by-object"}),
+				}},
+				ByGroup: map[string]ByObject{"": {
+					Namespaces: map[string]Config{"default": {
+						FieldSelector: fields.OneTermEqualSelector("metadata.name", "by-group-namespace"),
+					}},
+					Label: labels.SelectorFromSet(map[string]string{"from": "by-group"}),
+					Field: fields.OneTermEqualSelector("metadata.name", "by-group"),
+				}},
+			},
+			verification: func(o Options) string {
+				expected := map[string]Config{
+					"default": {
+						LabelSelector: labels.SelectorFromSet(map[string]string{"from": "by-object"}),
+						FieldSelector: fields.OneTermEqualSelector("metadata.name", "by-group-namespace"),
+					},
+				}
+				return compare(expected, o.ByObject[pod].Namespaces)
+			},
+		},
+		{
+			name: "ByGroup gets defaulted from DefaultNamespaces and the Default* settings",
+			in: Options{
+				ByGroup: map[string]ByObject{"apps": {
+					Field: fields.OneTermEqualSelector("metadata.name", "by-group"),
+				}},
+				DefaultNamespaces: map[string]Config{"default": {
+					LabelSelector: labels.SelectorFromSet(map[string]string{"from": "default-namespaces"}),
+				}},
+				DefaultLabelSelector: labels.SelectorFromSet(map[string]string{"from": "default-label-selector"}),
+			},
+			verification: func(o Options) string {
+				expected := ByObject{
+					Namespaces: map[string]Config{"default": {
+						LabelSelector: labels.SelectorFromSet(map[string]string{"from": "default-namespaces"}),
+						FieldSelector: fields.OneTermEqualSelector("metadata.name", "by-group"),
+					}},
+					Label: labels.SelectorFromSet(map[string]string{"from": "default-label-selector"}),
+					Field: fields.OneTermEqualSelector("metadata.name", "by-group"),
+				}
+				return compare(expected, o.ByGroup["apps"])
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -456,6 +581,65 @@ func TestDefaultOpts(t *testing.T) {
 	}
 }
 
+func TestEffectiveConfigs(t *testing.T) {
+	t.Parallel()
+
+	byGroupLabel := labels.SelectorFromSet(map[string]string{"from": "by-group"})
+	configs, err := EffectiveConfigs(&rest.Config{}, Options{
+		Mapper: &fakeRESTMapper{},
+		ByObject: map[client.Object]ByObject{
+			&corev1.Pod{}: {Label: labels.SelectorFromSet(map[string]string{"from": "by-object"})},
+		},
+		ByGroup: map[string]ByObject{
+			"apps": {Label: byGroupLabel},
+		},
+		DefaultNamespaces: map[string]Config{"default": {}},
+	}, &corev1.Pod{}, &appsv1.Deployment{}, &corev1.ConfigMap{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := map[string]ConfigSource{}
+	for gvk, config := range configs {
+		sources[gvk.Kind] = config.Source
+	}
+	if diff := cmp.Diff(map[string]ConfigSource{
+		"Pod":        ConfigSourceByObject,
+		"Deployment": ConfigSourceByGroup,
+		"ConfigMap":  ConfigSourceDefault,
+	}, sources); diff != "" {
+		t.Errorf("expected sources differ from actual: %s", diff)
+	}
+
+	deployment := configs[appsv1.SchemeGroupVersion.WithKind("Deployment")]
+	if diff := cmp.Diff(map[string]Config{"default": {LabelSelector: byGroupLabel}}, deployment.Namespaces); diff != "" {
+		t.Errorf("expected namespaces of Deployments differ from actual: %s", diff)
+	}
+}
+
+func TestNewUsesTheCacheOfTheGroup(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(&rest.Config{}, Options{
+		Mapper:   &fakeRESTMapper{},
+		ByObject: map[client.Object]ByObject{&appsv1.Deployment{}: {}},
+		ByGroup:  map[string]ByObject{"apps": {}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegating := c.(*delegatingByGVKCache)
+	if delegating.cacheForGVK(appsv1.SchemeGroupVersion.WithKind("Deployment")) != delegating.caches[appsv1.SchemeGroupVersion.WithKind("Deployment")] {
+		t.Error("expected Deployments to use the cache of their ByObject")
+	}
+	if delegating.cacheForGVK(appsv1.SchemeGroupVersion.WithKind("StatefulSet")) != delegating.groupCaches["apps"] {
+		t.Error("expected StatefulSets to use the cache of their group")
+	}
+	if delegating.cacheForGVK(corev1.SchemeGroupVersion.WithKind("Pod")) != delegating.defaultCache {
+		t.Error("expected Pods to use the default cache")
+	}
+}
+
 func TestDefaultOptsRace(t *testing.T) {
 	opts := Options{
 		Mapper: &fakeRESTMapper{},
@@ -504,6 +688,9 @@ func TestDefaultConfigConsidersAllFields(t *testing.T) {
 		func(tf *cache.TransformFunc, _ fuzz.Continue) {
 			// never default this, as functions can not be compared so we fail down the line
 		},
+		func(h *cache.WatchErrorHandlerWithContext, _ fuzz.Continue) {
+			// never default this, as functions can not be compared so we fail down the line
+		},
 	)
 
 	for range 100 {
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"maps"
 	"slices"
 	"strings"
@@ -25,18 +26,59 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-// delegatingByGVKCache delegates to a type-specific cache if present
-// and uses the defaultCache otherwise.
+// delegatingByGVKCache delegates to a type-specific cache if present, to the cache of
+// the group of the type if present and uses the defaultCache otherwise.
 type delegatingByGVKCache struct {
 	scheme       *runtime.Scheme
 	caches       map[schema.GroupVersionKind]Cache
+	groupCaches  map[string]Cache
 	defaultCache Cache
+
+	// followDefaultNamespaces holds the type-level ByObject config of the types whose
+	// caches follow DefaultNamespaces, so that namespaces added at runtime are added to
+	// them, too.
+	followDefaultNamespaces map[schema.GroupVersionKind]Config
+
+	// groupsFollowDefaultNamespaces holds the group-level ByGroup config of the groups
+	// whose caches follow DefaultNamespaces.
+	groupsFollowDefaultNamespaces map[string]Config
+}
+
+// allCaches returns the type-specific, group and default caches.
+func (dbt *delegatingByGVKCache) allCaches() []Cache {
+	caches := slices.Collect(maps.Values(dbt.caches))
+	caches = slices.AppendSeq(caches, maps.Values(dbt.groupCaches))
+	return append(caches, dbt.defaultCache)
+}
+
+// namespaceFollower is a cache that follows DefaultNamespaces.
+type namespaceFollower struct {
+	name   string
+	cache  namespaceSetter
+	config Config
 }
 
+// namespaceFollowers returns the caches that follow DefaultNamespaces along with
+// their type- or group-level config.
+func (dbt *delegatingByGVKCache) namespaceFollowers() []namespaceFollower {
+	followers := make([]namespaceFollower, 0, len(dbt.followDefaultNamespaces)+len(dbt.groupsFollowDefaultNamespaces))
+	for gvk, config := range dbt.followDefaultNamespaces {
+		followers = append(followers, namespaceFollower{name: gvk.String(), cache: dbt.caches[gvk].(namespaceSetter), config: config})
+	}
+	for group, config := range dbt.groupsFollowDefaultNamespaces {
+		followers = append(followers, namespaceFollower{name: fmt.Sprintf("group %q", group), cache: dbt.groupCaches[group].(namespaceSetter), config: config})
+	}
+	return followers
+}
+
+var _ namespaceSetter = &delegatingByGVKCache{}
+
 func (dbt *delegatingByGVKCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	cache, err := dbt.cacheForObject(obj)
 	if err != nil {
@@ -74,8 +116,7 @@ func (dbt *delegatingByGVKCache) GetInformerForKind(ctx context.Context, gvk sch
 }
 
 func (dbt *delegatingByGVKCache) Start(ctx context.Context) error {
-	allCaches := slices.Collect(maps.Values(dbt.caches))
-	allCaches = append(allCaches, dbt.defaultCache)
+	allCaches := dbt.allCaches()
 
 	wg := &sync.WaitGroup{}
 	errs := make(chan error)
@@ -99,7 +140,7 @@ func (dbt *delegatingByGVKCache) Start(ctx context.Context) error {
 
 func (dbt *delegatingByGVKCache) WaitForCacheSync(ctx context.Context) bool {
 	synced := true
-	for _, cache := range append(slices.Collect(maps.Values(dbt.caches)), dbt.defaultCache) {
+	for _, cache := range dbt.allCaches() {
 		if !cache.WaitForCacheSync(ctx) {
 			synced = false
 		}
@@ -116,6 +157,89 @@ func (dbt *delegatingByGVKCache) IndexField(ctx context.Context, obj client.Obje
 	return cache.IndexField(ctx, obj, field, extractValue)
 }
 
+func (dbt *delegatingByGVKCache) syncStatuses() []InformerSyncStatus {
+	var statuses []InformerSyncStatus
+	for _, cache := range dbt.allCaches() {
+		if s, ok := cache.(syncStatuser); ok {
+			statuses = append(statuses, s.syncStatuses()...)
+		}
+	}
+	return statuses
+}
+
+func (dbt *delegatingByGVKCache) contents() []internal.InformerContents {
+	var contents []internal.InformerContents
+	for _, cache := range dbt.allCaches() {
+		if d, ok := cache.(contentsDumper); ok {
+			contents = append(contents, d.contents()...)
+		}
+	}
+	return contents
+}
+
+func (dbt *delegatingByGVKCache) snapshotScheme() *runtime.Scheme {
+	return dbt.scheme
+}
+
+func (dbt *delegatingByGVKCache) snapshotCache(ctx context.Context, list client.ObjectList) (*internal.Cache, error) {
+	cache, err := dbt.cacheForObject(list)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := cache.(snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("cache %T does not support snapshots", cache)
+	}
+	return s.snapshotCache(ctx, list)
+}
+
+func (dbt *delegatingByGVKCache) addNamespace(ctx context.Context, namespace string, config Config) error {
+	s, ok := dbt.defaultCache.(namespaceSetter)
+	if !ok {
+		return fmt.Errorf("cache %T does not support adding namespaces", dbt.defaultCache)
+	}
+	if err := s.addNamespace(ctx, namespace, config); err != nil {
+		return err
+	}
+	added := []namespaceSetter{s}
+	for _, follower := range dbt.namespaceFollowers() {
+		// The type-level ByObject config takes precedence over the config of the namespace,
+		// like it does for DefaultNamespaces.
+		if err := follower.cache.addNamespace(ctx, namespace, defaultConfig(follower.config, config)); err != nil {
+			errs := []error{fmt.Errorf("failed to add namespace %s to the cache for %s: %w", namespace, follower.name, err)}
+			// Roll back the caches the namespace was already added to, so that adding it
+			// can be retried.
+			for _, s := range added {
+				if err := s.removeNamespace(ctx, namespace); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return kerrors.NewAggregate(errs)
+		}
+		added = append(added, follower.cache)
+	}
+	return nil
+}
+
+func (dbt *delegatingByGVKCache) removeNamespace(ctx context.Context, namespace string) error {
+	s, ok := dbt.defaultCache.(namespaceSetter)
+	if !ok {
+		return fmt.Errorf("cache %T does not support removing namespaces", dbt.defaultCache)
+	}
+	// The namespace is removed from every cache even if one of them fails, so that the
+	// caches don't get out of sync.
+	var errs []error
+	if err := s.removeNamespace(ctx, namespace); err != nil {
+		errs = append(errs, err)
+	}
+	for _, follower := range dbt.namespaceFollowers() {
+		if err := follower.cache.removeNamespace(ctx, namespace); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove namespace %s from the cache for %s: %w", namespace, follower.name, err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
 func (dbt *delegatingByGVKCache) cacheForObject(o runtime.Object) (Cache, error) {
 	gvk, err := apiutil.GVKForObject(o, dbt.scheme)
 	if err != nil {
@@ -129,6 +253,9 @@ func (dbt *delegatingByGVKCache) cacheForGVK(gvk schema.GroupVersionKind) Cache
 	if specific, hasSpecific := dbt.caches[gvk]; hasSpecific {
 		return specific
 	}
+	if group, hasGroup := dbt.groupCaches[gvk.Group]; hasGroup {
+		return group
+	}
 
 	return dbt.defaultCache
 }
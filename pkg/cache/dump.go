@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// contentsDumper is implemented by caches that can dump the contents of their informers.
+type contentsDumper interface {
+	contents() []internal.InformerContents
+}
+
+// DumpOptions are the optional arguments for Dump.
+type DumpOptions struct {
+	// Objects includes the objects in the dump in addition to their keys and
+	// resourceVersions. They are sanitized before they are written, see Sanitize.
+	Objects bool
+
+	// Sanitize is called with a copy of every object that is dumped and may strip or
+	// redact any fields of it.
+	//
+	// Defaults to SanitizeForDump.
+	Sanitize func(client.Object)
+}
+
+// DumpedInformer is the contents of the informer of a GVK as written by Dump.
+type DumpedInformer struct {
+	// GVK is the group, version and kind of the informer.
+	GVK string `json:"gvk"`
+
+	// Namespace is the namespace the informer is restricted to, it is empty if the
+	// informer isn't restricted to a namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Synced is true once the informer synced.
+	Synced bool `json:"synced"`
+
+	// ResourceVersion is the resourceVersion the informer last applied.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Objects are the objects of the informer, sorted by their key.
+	Objects []DumpedObject `json:"objects"`
+}
+
+// DumpedObject is an object in an informer as written by Dump.
+type DumpedObject struct {
+	// Key is the key of the object, i.e. <namespace>/<name> or <name>.
+	Key string `json:"key"`
+
+	// ResourceVersion is the resourceVersion of the object.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Object is the sanitized object, it is only set if DumpOptions.Objects is set.
+	Object client.Object `json:"object,omitempty"`
+}
+
+// Dump writes the keys and resourceVersions, and optionally the objects, held by every
+// informer of the cache to w. It writes one DumpedInformer as JSON per line, sorted by
+// GVK and namespace, so that dumps can be diffed against each other or against the
+// objects listed from the API server when investigating missed objects.
+//
+// Dump is supported by caches created with New. The objects are read from the stores
+// of the informers while they keep running, so a dump isn't consistent across informers.
+func Dump(w io.Writer, c Cache, opts DumpOptions) error {
+	d, ok := c.(contentsDumper)
+	if !ok {
+		return fmt.Errorf("cache %T does not support dumping its contents", c)
+	}
+	if opts.Sanitize == nil {
+		opts.Sanitize = SanitizeForDump
+	}
+
+	contents := d.contents()
+	slices.SortFunc(contents, func(a, b internal.InformerContents) int {
+		if c := strings.Compare(a.GVK.String(), b.GVK.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	encoder := json.NewEncoder(w)
+	for _, informer := range contents {
+		dumped := DumpedInformer{
+			GVK:             informer.GVK.String(),
+			Namespace:       informer.Namespace,
+			Synced:          informer.Synced,
+			ResourceVersion: informer.ResourceVersion,
+			Objects:         make([]DumpedObject, 0, len(informer.Objects)),
+		}
+		for _, item := range informer.Objects {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			key, err := toolscache.MetaNamespaceKeyFunc(obj)
+			if err != nil {
+				return err
+			}
+			object := DumpedObject{Key: key, ResourceVersion: obj.GetResourceVersion()}
+			if opts.Objects {
+				object.Object = obj.DeepCopyObject().(client.Object)
+				opts.Sanitize(object.Object)
+			}
+			dumped.Objects = append(dumped.Objects, object)
+		}
+		slices.SortFunc(dumped.Objects, func(a, b DumpedObject) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+		if err := encoder.Encode(dumped); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SanitizeForDump strips the managed fields and the last applied configuration of obj,
+// and redacts the values of the data of Secrets, keeping their keys.
+func SanitizeForDump(obj client.Object) {
+	obj.SetManagedFields(nil)
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		obj.SetAnnotations(annotations)
+	}
+
+	switch obj := obj.(type) {
+	case *corev1.Secret:
+		for key := range obj.Data {
+			obj.Data[key] = nil
+		}
+		for key := range obj.StringData {
+			obj.StringData[key] = ""
+		}
+	case *unstructured.Unstructured:
+		if gvk := obj.GroupVersionKind(); gvk.Group != "" || gvk.Kind != "Secret" {
+			return
+		}
+		for _, field := range []string{"data", "stringData"} {
+			if data, ok := obj.Object[field].(map[string]any); ok {
+				for key := range data {
+					data[key] = ""
+				}
+			}
+		}
+	}
+}
+
+// DumpHandler returns a handler that serves Dump of the cache. The objects are only
+// included if the objects query parameter is true, e.g. /debug/cache?objects=true.
+func DumpHandler(c Cache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var opts DumpOptions
+		if objects := r.URL.Query().Get("objects"); objects != "" {
+			var err error
+			if opts.Objects, err = strconv.ParseBool(objects); err != nil {
+				http.Error(w, fmt.Sprintf("invalid objects query parameter: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		if _, ok := c.(contentsDumper); !ok {
+			http.Error(w, fmt.Sprintf("cache %T does not support dumping its contents", c), http.StatusNotImplemented)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		if err := Dump(w, c, opts); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Dump", func() {
+	var informers cache.Cache
+
+	BeforeEach(func(specCtx SpecContext) {
+		server := &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods": {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+		}}
+		for _, name := range []string{"b", "a"} {
+			server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace:     "default",
+				Name:          name,
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "test"}},
+			}})
+		}
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+
+		var err error
+		informers, err = cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper: mapper,
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = informers.GetInformer(specCtx, &corev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informers.Start(ctx)).To(Succeed())
+		}()
+		Expect(informers.WaitForCacheSync(specCtx)).To(BeTrue())
+	})
+
+	decode := func(data []byte) []map[string]any {
+		var dumped []map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		for decoder.More() {
+			informer := map[string]any{}
+			Expect(decoder.Decode(&informer)).To(Succeed())
+			dumped = append(dumped, informer)
+		}
+		return dumped
+	}
+
+	It("should dump the keys of the objects of every informer", func() {
+		out := &bytes.Buffer{}
+		Expect(cache.Dump(out, informers, cache.DumpOptions{})).To(Succeed())
+
+		dumped := decode(out.Bytes())
+		Expect(dumped).To(HaveLen(1))
+		Expect(dumped[0]).To(HaveKeyWithValue("gvk", "/v1, Kind=Pod"))
+		Expect(dumped[0]).To(HaveKeyWithValue("synced", true))
+		objects := dumped[0]["objects"].([]any)
+		Expect(objects).To(HaveLen(2))
+		Expect(objects[0]).To(HaveKeyWithValue("key", "default/a"))
+		Expect(objects[0]).NotTo(HaveKey("object"))
+		Expect(objects[1]).To(HaveKeyWithValue("key", "default/b"))
+	})
+
+	It("should serve the sanitized objects", func() {
+		recorder := httptest.NewRecorder()
+		cache.DumpHandler(informers).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/cache?objects=true", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		dumped := decode(recorder.Body.Bytes())
+		Expect(dumped).To(HaveLen(1))
+		object := dumped[0]["objects"].([]any)[0].(map[string]any)["object"].(map[string]any)
+		Expect(object["metadata"]).To(HaveKeyWithValue("name", "a"))
+		Expect(object["metadata"]).NotTo(HaveKey("managedFields"))
+	})
+
+	It("should reject an invalid objects query parameter", func() {
+		recorder := httptest.NewRecorder()
+		cache.DumpHandler(informers).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/cache?objects=maybe", nil))
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+})
+
+var _ = Describe("SanitizeForDump", func() {
+	It("should redact the data of Secrets", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+		cache.SanitizeForDump(secret)
+		Expect(secret.Data).To(Equal(map[string][]byte{"password": nil}))
+		Expect(secret.Annotations).To(BeEmpty())
+	})
+})
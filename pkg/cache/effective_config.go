@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ConfigSource is the setting of Options that the configuration of a type comes from.
+type ConfigSource string
+
+const (
+	// ConfigSourceByObject means that the type is configured by its ByObject.
+	ConfigSourceByObject ConfigSource = "ByObject"
+
+	// ConfigSourceByGroup means that the type is configured by the ByGroup of its group.
+	ConfigSourceByGroup ConfigSource = "ByGroup"
+
+	// ConfigSourceDefault means that the type is configured by DefaultNamespaces and
+	// the Default* settings.
+	ConfigSourceDefault ConfigSource = "Default"
+)
+
+// EffectiveConfig is the configuration the cache uses for a type, after all settings
+// were inherited.
+type EffectiveConfig struct {
+	// Source is the setting the type is configured by. Settings that are unset in it
+	// are inherited from the less specific settings, see Options.ByGroup.
+	Source ConfigSource
+
+	// Namespaces maps the namespaces the type is cached in to their config. It is
+	// empty if the type is cached in all namespaces with Config, which is always the
+	// case for cluster-scoped types.
+	Namespaces map[string]Config
+
+	// Config is the config of the type if Namespaces is empty, it is empty otherwise.
+	Config Config
+}
+
+// EffectiveConfigs validates opts like New does and returns the configuration the
+// cache would use for each of the given objects by their GroupVersionKind. It allows
+// to check which settings apply to a type in complex configurations, e.g. in tests.
+func EffectiveConfigs(config *rest.Config, opts Options, objs ...client.Object) (map[schema.GroupVersionKind]EffectiveConfig, error) {
+	opts, err := defaultOpts(config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byGVK := make(map[schema.GroupVersionKind]ByObject, len(opts.ByObject))
+	for obj, byObject := range opts.ByObject {
+		gvk, err := apiutil.GVKForObject(obj, opts.Scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GVK for type %T: %w", obj, err)
+		}
+		byGVK[gvk] = byObject
+	}
+
+	configs := make(map[schema.GroupVersionKind]EffectiveConfig, len(objs))
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, opts.Scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GVK for type %T: %w", obj, err)
+		}
+		isNamespaced, err := apiutil.IsGVKNamespaced(gvk, opts.Mapper)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine if %T is namespaced: %w", obj, err)
+		}
+
+		var effective EffectiveConfig
+		if byObject, ok := byGVK[gvk]; ok {
+			effective = EffectiveConfig{Source: ConfigSourceByObject, Namespaces: byObject.Namespaces, Config: byObjectToConfig(byObject)}
+		} else if byGroup, ok := opts.ByGroup[gvk.Group]; ok {
+			effective = EffectiveConfig{Source: ConfigSourceByGroup, Namespaces: byGroup.Namespaces, Config: byObjectToConfig(byGroup)}
+		} else {
+			effective = EffectiveConfig{Source: ConfigSourceDefault, Namespaces: opts.DefaultNamespaces, Config: optionDefaultsToConfig(&opts)}
+		}
+		if !isNamespaced || len(effective.Namespaces) == 0 {
+			effective.Namespaces = nil
+		} else {
+			effective.Config = Config{}
+		}
+		configs[gvk] = effective
+	}
+	return configs, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var exclusionLog = logf.RuntimeLog.WithName("cache").WithName("exclusions")
+
+// Exclusion excludes objects from the cache. Exclusions are sent to the API server as
+// negated label and field selectors, so unlike with a CELFilter, excluded objects are
+// never transferred, decoded or stored.
+type Exclusion struct {
+	// Labels excludes the objects that have any of the given labels with the given value.
+	Labels map[string]string
+
+	// Fields excludes the objects that have any of the given fields with the given value,
+	// e.g. {"type": "kubernetes.io/service-account-token"} for Secrets. The fields must be
+	// supported as field selectors by the type of the object.
+	Fields map[string]string
+
+	// Namespaces excludes the objects in any of the given namespaces. It is ignored for
+	// cluster-scoped objects.
+	Namespaces []string
+}
+
+// ExcludeHelmReleases excludes the Secrets in which Helm stores its releases. They hold
+// the compressed manifests of every revision of every release and are often the majority
+// of the bytes of the Secrets in a cluster, while operators rarely need to read them.
+func ExcludeHelmReleases() Exclusion {
+	return Exclusion{Labels: map[string]string{"owner": "helm"}}
+}
+
+// ExcludeSystemNamespaces excludes the objects in the kube-system, kube-public and
+// kube-node-lease namespaces.
+func ExcludeSystemNamespaces() Exclusion {
+	return Exclusion{Namespaces: []string{metav1.NamespaceSystem, metav1.NamespacePublic, corev1.NamespaceNodeLease}}
+}
+
+// RecommendedExclusions returns the exclusions that are safe to use for operators that
+// neither manage Helm releases nor objects of the control plane, i.e. ExcludeHelmReleases
+// and ExcludeSystemNamespaces. It is meant to be used as Options.DefaultExclusions:
+//
+//	cache.Options{DefaultExclusions: cache.RecommendedExclusions()}
+func RecommendedExclusions() []Exclusion {
+	return []Exclusion{ExcludeHelmReleases(), ExcludeSystemNamespaces()}
+}
+
+// selectorFor returns the selector of the informers of the given config, i.e. its label
+// and field selector together with its exclusions.
+func selectorFor(config Config) (internal.Selector, error) {
+	selector := internal.Selector{
+		Label: config.LabelSelector,
+		Field: config.FieldSelector,
+	}
+	var requirements []labels.Requirement
+	var fieldSelectors []fields.Selector
+	for _, exclusion := range config.Exclusions {
+		for _, key := range slices.Sorted(maps.Keys(exclusion.Labels)) {
+			requirement, err := labels.NewRequirement(key, selection.NotEquals, []string{exclusion.Labels[key]})
+			if err != nil {
+				return internal.Selector{}, fmt.Errorf("invalid label exclusion: %w", err)
+			}
+			requirements = append(requirements, *requirement)
+		}
+		for _, key := range slices.Sorted(maps.Keys(exclusion.Fields)) {
+			fieldSelectors = append(fieldSelectors, fields.OneTermNotEqualSelector(key, exclusion.Fields[key]))
+		}
+		selector.ExcludedNamespaces = append(selector.ExcludedNamespaces, exclusion.Namespaces...)
+	}
+
+	if len(requirements) > 0 {
+		if selector.Label == nil {
+			selector.Label = labels.NewSelector()
+		}
+		selector.Label = selector.Label.Add(requirements...)
+	}
+	if len(fieldSelectors) > 0 {
+		selector.Field = fields.AndSelectors(append(appendIfNotNil(nil, selector.Field), fieldSelectors...)...)
+	}
+	return selector, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestSelectorFor(t *testing.T) {
+	for _, tc := range []struct {
+		name               string
+		config             Config
+		expectedLabel      string
+		expectedField      string
+		expectedNamespaces []string
+		expectErr          bool
+	}{
+		{
+			name: "no exclusions",
+			config: Config{
+				LabelSelector: labels.SelectorFromSet(labels.Set{"app": "a"}),
+			},
+			expectedLabel: "app=a",
+		},
+		{
+			name: "exclusions without selectors",
+			config: Config{
+				Exclusions: []Exclusion{ExcludeHelmReleases(), {Fields: map[string]string{"type": "kubernetes.io/service-account-token"}}},
+			},
+			expectedLabel: "owner!=helm",
+			expectedField: "type!=kubernetes.io/service-account-token",
+		},
+		{
+			name: "exclusions are added to the selectors",
+			config: Config{
+				LabelSelector: labels.SelectorFromSet(labels.Set{"app": "a"}),
+				FieldSelector: fields.OneTermEqualSelector("spec.nodeName", "node-a"),
+				Exclusions: []Exclusion{
+					{Labels: map[string]string{"tier": "test", "owner": "helm"}, Fields: map[string]string{"status.phase": "Failed"}},
+					ExcludeSystemNamespaces(),
+				},
+			},
+			expectedLabel:      "app=a,owner!=helm,tier!=test",
+			expectedField:      "spec.nodeName=node-a,status.phase!=Failed",
+			expectedNamespaces: []string{"kube-system", "kube-public", "kube-node-lease"},
+		},
+		{
+			name:      "invalid label",
+			config:    Config{Exclusions: []Exclusion{{Labels: map[string]string{"in valid": "a"}}}},
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := selectorFor(tc.config)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var label, field string
+			if selector.Label != nil {
+				label = selector.Label.String()
+			}
+			if selector.Field != nil {
+				field = selector.Field.String()
+			}
+			if label != tc.expectedLabel {
+				t.Errorf("expected label selector %q, got %q", tc.expectedLabel, label)
+			}
+			if field != tc.expectedField {
+				t.Errorf("expected field selector %q, got %q", tc.expectedField, field)
+			}
+			if len(selector.ExcludedNamespaces) != len(tc.expectedNamespaces) {
+				t.Fatalf("expected excluded namespaces %v, got %v", tc.expectedNamespaces, selector.ExcludedNamespaces)
+			}
+			for i := range tc.expectedNamespaces {
+				if selector.ExcludedNamespaces[i] != tc.expectedNamespaces[i] {
+					t.Fatalf("expected excluded namespaces %v, got %v", tc.expectedNamespaces, selector.ExcludedNamespaces)
+				}
+			}
+		})
+	}
+}
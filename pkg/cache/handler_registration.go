@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EventHandlerRegistration is an event handler that was added to an informer with
+// AddEventHandler. Unlike the registration returned by Informer.AddEventHandler, it
+// references its informer, so that whoever holds it can remove the handler, e.g. when
+// a dynamically started controller is torn down.
+type EventHandlerRegistration interface {
+	toolscache.ResourceEventHandlerRegistration
+
+	// Remove removes the event handler from its informer. It is idempotent and safe
+	// to call concurrently.
+	Remove() error
+}
+
+// AddEventHandler adds handler to the informer for obj, creating the informer if it
+// doesn't exist yet, and returns a registration that removes the handler again. It
+// doesn't wait for the informer to sync, HasSynced of the registration reports when the
+// handler received the initial objects. The informer stays in the cache after the handler was removed, use RemoveInformer to
+// stop it once it isn't needed anymore.
+func AddEventHandler(ctx context.Context, informers Informers, obj client.Object, handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (EventHandlerRegistration, error) {
+	informer, err := informers.GetInformer(ctx, obj, BlockUntilSynced(false))
+	if err != nil {
+		return nil, err
+	}
+	registration, err := informer.AddEventHandlerWithOptions(handler, options)
+	if err != nil {
+		return nil, err
+	}
+	return &eventHandlerRegistration{ResourceEventHandlerRegistration: registration, informer: informer}, nil
+}
+
+type eventHandlerRegistration struct {
+	toolscache.ResourceEventHandlerRegistration
+	informer Informer
+}
+
+func (r *eventHandlerRegistration) Remove() error {
+	return r.informer.RemoveEventHandler(r.ResourceEventHandlerRegistration)
+}
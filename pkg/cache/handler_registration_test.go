@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AddEventHandler", func() {
+	It("should return a registration that removes the handler", func(specCtx SpecContext) {
+		server := &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods": {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+		}}
+		server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}})
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+
+		informers, err := cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper: mapper,
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var mu sync.Mutex
+		var added []string
+		registration, err := cache.AddEventHandler(specCtx, informers, &corev1.Pod{}, toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				mu.Lock()
+				defer mu.Unlock()
+				added = append(added, obj.(*corev1.Pod).Name)
+			},
+		}, toolscache.HandlerOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		addedPods := func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), added...)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informers.Start(ctx)).To(Succeed())
+		}()
+		Eventually(registration.HasSynced).Should(BeTrue())
+		Expect(addedPods()).To(ConsistOf("a"))
+
+		Expect(registration.Remove()).To(Succeed())
+		Expect(registration.Remove()).To(Succeed())
+		server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}})
+		Eventually(func(g Gomega) {
+			pods := &corev1.PodList{}
+			g.Expect(informers.List(specCtx, pods)).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(2))
+		}).Should(Succeed())
+		Consistently(addedPods, 200*time.Millisecond).Should(ConsistOf("a"))
+	})
+})
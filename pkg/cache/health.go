@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// HealthChecker returns a healthz.Checker that fails if an informer of the cache
+// stopped running, if its list or watch has been failing for longer than maxStaleness,
+// or if its watch didn't receive any event, including bookmarks, for longer than
+// maxStaleness. A list or watch is failing from its first error until its watch
+// receives an event again, so watches that keep being dropped by the API server
+// right after they were established are reported as well. It surfaces watches that
+// keep erroring or silently died as readiness failures:
+//
+//	mgr.AddReadyzCheck("cache", cache.HealthChecker(mgr.GetCache(), 5*time.Minute))
+//
+// The API server sends a bookmark about every minute to watches that requested them,
+// which informers do unless EnableWatchBookmarks is false. maxStaleness needs to be
+// well above that, and informers without bookmarks may be reported as stale if their
+// objects rarely change. Informers that didn't sync yet are not reported as stale, as
+// WaitForCacheSync covers them, but they are reported if their list has been failing.
+//
+// The checker always passes for caches that don't report the sync status of their
+// informers, see SyncStatus.
+func HealthChecker(c Cache, maxStaleness time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		statuses, ok := SyncStatus(c)
+		if !ok {
+			return nil
+		}
+		if unhealthy := unhealthyInformers(statuses, time.Now(), maxStaleness); len(unhealthy) > 0 {
+			return fmt.Errorf("unhealthy informers: %s", strings.Join(unhealthy, ", "))
+		}
+		return nil
+	}
+}
+
+// unhealthyInformers describes the informers that are unhealthy at now.
+func unhealthyInformers(statuses []InformerSyncStatus, now time.Time, maxStaleness time.Duration) []string {
+	var unhealthy []string
+	for _, status := range statuses {
+		// Informers that were not started yet can't be unhealthy.
+		if status.Elapsed == 0 {
+			continue
+		}
+		name := status.GVK.String()
+		if status.Namespace != "" {
+			name += " in namespace " + status.Namespace
+		}
+		switch {
+		case status.Stopped:
+			unhealthy = append(unhealthy, name+" stopped")
+		case !status.WatchFailingSince.IsZero() && now.Sub(status.WatchFailingSince) > maxStaleness:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s failing for %s", name, now.Sub(status.WatchFailingSince).Round(time.Second)))
+		case status.Synced && !status.LastWatchActivity.IsZero() && now.Sub(status.LastWatchActivity) > maxStaleness:
+			unhealthy = append(unhealthy, fmt.Sprintf("%s without events for %s", name, now.Sub(status.LastWatchActivity).Round(time.Second)))
+		}
+	}
+	slices.Sort(unhealthy)
+	return unhealthy
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeSyncStatusCache struct {
+	Cache
+	statuses []InformerSyncStatus
+}
+
+func (c *fakeSyncStatusCache) syncStatuses() []InformerSyncStatus {
+	return c.statuses
+}
+
+func TestUnhealthyInformers(t *testing.T) {
+	now := time.Now()
+	podGVK := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	maxStaleness := 5 * time.Minute
+
+	testCases := []struct {
+		name     string
+		status   InformerSyncStatus
+		expected []string
+	}{
+		{
+			name:   "not started",
+			status: InformerSyncStatus{GVK: podGVK, Stopped: true},
+		},
+		{
+			name:   "recent events",
+			status: InformerSyncStatus{GVK: podGVK, Synced: true, Elapsed: time.Second, LastWatchActivity: now.Add(-time.Minute)},
+		},
+		{
+			name:     "stopped",
+			status:   InformerSyncStatus{GVK: podGVK, Namespace: "default", Synced: true, Elapsed: time.Second, Stopped: true},
+			expected: []string{"/v1, Kind=Pod in namespace default stopped"},
+		},
+		{
+			name:     "stale watch",
+			status:   InformerSyncStatus{GVK: podGVK, Synced: true, Elapsed: time.Second, LastWatchActivity: now.Add(-10 * time.Minute)},
+			expected: []string{"/v1, Kind=Pod without events for 10m0s"},
+		},
+		{
+			name:   "stale but not synced",
+			status: InformerSyncStatus{GVK: podGVK, Elapsed: time.Second, LastWatchActivity: now.Add(-10 * time.Minute)},
+		},
+		{
+			name:   "failing shortly",
+			status: InformerSyncStatus{GVK: podGVK, Synced: true, Elapsed: time.Second, LastWatchActivity: now, WatchFailingSince: now.Add(-time.Minute)},
+		},
+		{
+			name:     "failing list",
+			status:   InformerSyncStatus{GVK: podGVK, Elapsed: time.Hour, WatchFailingSince: now.Add(-time.Hour)},
+			expected: []string{"/v1, Kind=Pod failing for 1h0m0s"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unhealthy := unhealthyInformers([]InformerSyncStatus{tc.status}, now, maxStaleness)
+			if diff := cmp.Diff(tc.expected, unhealthy); diff != "" {
+				t.Errorf("unexpected unhealthy informers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthChecker(t *testing.T) {
+	if err := HealthChecker(&struct{ Cache }{}, time.Minute)(nil); err != nil {
+		t.Errorf("expected caches that don't report their sync status to be healthy, got %v", err)
+	}
+
+	c := &fakeSyncStatusCache{}
+	check := HealthChecker(c, time.Minute)
+	if err := check(nil); err != nil {
+		t.Errorf("expected a cache without informers to be healthy, got %v", err)
+	}
+	c.statuses = []InformerSyncStatus{
+		{GVK: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, Elapsed: time.Second, Stopped: true},
+		{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, Elapsed: time.Second, Stopped: true},
+	}
+	err := check(nil)
+	if err == nil {
+		t.Fatal("expected a cache with stopped informers to be unhealthy")
+	}
+	if expected := "unhealthy informers: /v1, Kind=Pod stopped, apps/v1, Kind=Deployment stopped"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
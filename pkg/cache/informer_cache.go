@@ -39,6 +39,19 @@ var (
 	_ Cache         = &informerCache{}
 )
 
+// informer is the Informer returned by an informerCache, which notifies when it stopped.
+type informer struct {
+	cache.SharedIndexInformer
+	stopped <-chan struct{}
+}
+
+var _ StoppedNotifier = &informer{}
+
+// Stopped implements StoppedNotifier.
+func (i *informer) Stopped() <-chan struct{} {
+	return i.stopped
+}
+
 // ErrCacheNotStarted is returned when trying to read from the cache that wasn't started.
 type ErrCacheNotStarted struct{}
 
@@ -59,6 +72,8 @@ type informerCache struct {
 	scheme *runtime.Scheme
 	*internal.Informers
 	readerFailOnMissingInformer bool
+	// defaultSortBy sorts the objects of Lists without a SortBy option.
+	defaultSortBy client.SortBy
 }
 
 // Get implements Reader.
@@ -95,6 +110,9 @@ func (ic *informerCache) List(ctx context.Context, out client.ObjectList, opts .
 		return &ErrCacheNotStarted{}
 	}
 
+	if ic.defaultSortBy != nil {
+		opts = append([]client.ListOption{ic.defaultSortBy}, opts...)
+	}
 	return cache.Reader.List(ctx, out, opts...)
 }
 
@@ -152,7 +170,8 @@ func (ic *informerCache) GetInformerForKind(ctx context.Context, gvk schema.Grou
 	if err != nil {
 		return nil, err
 	}
-	return i.Informer, nil
+	i.Pin()
+	return &informer{SharedIndexInformer: i.Informer, stopped: i.Stopped()}, nil
 }
 
 // GetInformer returns the informer for the obj. If no informer exists, one will be started.
@@ -166,7 +185,8 @@ func (ic *informerCache) GetInformer(ctx context.Context, obj client.Object, opt
 	if err != nil {
 		return nil, err
 	}
-	return i.Informer, nil
+	i.Pin()
+	return &informer{SharedIndexInformer: i.Informer, stopped: i.Stopped()}, nil
 }
 
 func (ic *informerCache) getInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, obj runtime.Object) (bool, *internal.Cache, error) {
@@ -174,18 +194,56 @@ func (ic *informerCache) getInformerForKind(ctx context.Context, gvk schema.Grou
 	if err != nil {
 		return false, nil, err
 	}
+	cache.MarkUsed()
 	return started, cache, nil
 }
 
-// RemoveInformer deactivates and removes the informer from the cache.
-func (ic *informerCache) RemoveInformer(_ context.Context, obj client.Object) error {
+// RemoveInformer deactivates and removes the informer from the cache, and waits
+// for it to stop.
+func (ic *informerCache) RemoveInformer(ctx context.Context, obj client.Object) error {
 	gvk, err := apiutil.GVKForObject(obj, ic.scheme)
 	if err != nil {
 		return err
 	}
 
-	ic.Informers.Remove(gvk, obj)
-	return nil
+	done := ic.Informers.Remove(gvk, obj)
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed waiting for the informer for %s to stop: %w", gvk, ctx.Err())
+	}
+}
+
+func (ic *informerCache) syncStatuses() []InformerSyncStatus {
+	return ic.Informers.SyncStatuses()
+}
+
+func (ic *informerCache) contents() []internal.InformerContents {
+	return ic.Informers.Contents()
+}
+
+func (ic *informerCache) snapshotScheme() *runtime.Scheme {
+	return ic.scheme
+}
+
+func (ic *informerCache) snapshotCache(ctx context.Context, list client.ObjectList) (*internal.Cache, error) {
+	gvk, cacheTypeObj, err := ic.objectTypeForListObject(list)
+	if err != nil {
+		return nil, err
+	}
+
+	started, cache, err := ic.getInformerForKind(ctx, *gvk, cacheTypeObj)
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, &ErrCacheNotStarted{}
+	}
+	return cache, nil
 }
 
 // NeedLeaderElection implements the LeaderElectionRunnable interface
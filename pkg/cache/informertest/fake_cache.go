@@ -83,6 +83,11 @@ func (c *FakeInformers) RemoveInformer(ctx context.Context, obj client.Object) e
 		return err
 	}
 	gvk := gvks[0]
+	if i, ok := c.InformersByGVK[gvk]; ok {
+		if fake, ok := i.(*controllertest.FakeInformer); ok {
+			fake.Stop()
+		}
+	}
 	delete(c.InformersByGVK, gvk)
 	return nil
 }
@@ -119,6 +119,7 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 	if listOpts.Continue != "" {
 		return fmt.Errorf("continue list option is not supported by the cache")
 	}
+	DefaultPageSortBy(&listOpts)
 
 	switch {
 	case listOpts.FieldSelector != nil:
@@ -143,30 +144,28 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 		labelSel = listOpts.LabelSelector
 	}
 
-	limitSet := listOpts.Limit > 0
-
-	runtimeObjs := make([]runtime.Object, 0, len(objs))
+	matching := make([]client.Object, 0, len(objs))
 	for _, item := range objs {
-		// if the Limit option is set and the number of items
-		// listed exceeds this limit, then stop reading.
-		if limitSet && int64(len(runtimeObjs)) >= listOpts.Limit {
-			break
-		}
-		obj, isObj := item.(runtime.Object)
+		obj, isObj := item.(client.Object)
 		if !isObj {
 			return fmt.Errorf("cache contained %T, which is not an Object", item)
 		}
-		meta, err := apimeta.Accessor(obj)
-		if err != nil {
-			return err
-		}
 		if labelSel != nil {
-			lbls := labels.Set(meta.GetLabels())
+			lbls := labels.Set(obj.GetLabels())
 			if !labelSel.Matches(lbls) {
 				continue
 			}
 		}
+		matching = append(matching, obj)
+	}
 
+	if listOpts.SortBy != nil {
+		slices.SortStableFunc(matching, listOpts.SortBy)
+	}
+	matching = Page(matching, listOpts.Offset, listOpts.Limit)
+
+	runtimeObjs := make([]runtime.Object, 0, len(matching))
+	for _, obj := range matching {
 		var outObj runtime.Object
 		if c.disableDeepCopy || (listOpts.UnsafeDisableDeepCopy != nil && *listOpts.UnsafeDisableDeepCopy) {
 			// skip deep copy which might be unsafe
@@ -187,6 +186,28 @@ func (c *CacheReader) List(_ context.Context, out client.ObjectList, opts ...cli
 	return nil
 }
 
+// DefaultPageSortBy sorts the objects of a List by namespace and name if a page of
+// them is requested with an Offset or Limit but without a SortBy. The objects of the
+// indexer are in no particular order, so consecutive pages could overlap otherwise.
+func DefaultPageSortBy(listOpts *client.ListOptions) {
+	if listOpts.SortBy == nil && (listOpts.Offset > 0 || listOpts.Limit > 0) {
+		listOpts.SortBy = client.SortByName
+	}
+}
+
+// Page returns the objects selected by the given offset and limit. A limit
+// of zero selects all objects after the offset.
+func Page[T any](objs []T, offset, limit int64) []T {
+	if offset >= int64(len(objs)) {
+		return nil
+	}
+	objs = objs[max(offset, 0):]
+	if limit > 0 && limit < int64(len(objs)) {
+		objs = objs[:limit]
+	}
+	return objs
+}
+
 func byIndexes(indexer cache.Indexer, requires fields.Requirements, namespace string) ([]any, error) {
 	var (
 		err  error
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func benchmarkCacheReader(b *testing.B, pods int) *CacheReader {
+	b.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := range pods {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: fmt.Sprintf("ns-%d", i%10),
+			Name:      fmt.Sprintf("pod-%d", i),
+		}}
+		if err := indexer.Add(pod); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return &CacheReader{indexer: indexer, groupVersionKind: corev1.SchemeGroupVersion.WithKind("Pod")}
+}
+
+// BenchmarkCacheReaderListSorted compares the cost of listing with SortByName with
+// the cost of listing in no particular order, with and without deep copies.
+func BenchmarkCacheReaderListSorted(b *testing.B) {
+	for _, pods := range []int{100, 1000, 10000} {
+		reader := benchmarkCacheReader(b, pods)
+		for _, bm := range []struct {
+			name string
+			opts []client.ListOption
+		}{
+			{name: "unsorted", opts: nil},
+			{name: "sorted", opts: []client.ListOption{client.SortByName}},
+			{name: "unsorted-nocopy", opts: []client.ListOption{client.UnsafeDisableDeepCopy}},
+			{name: "sorted-nocopy", opts: []client.ListOption{client.SortByName, client.UnsafeDisableDeepCopy}},
+		} {
+			b.Run(fmt.Sprintf("pods=%d/%s", pods, bm.name), func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					if err := reader.List(context.Background(), &corev1.PodList{}, bm.opts...); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CacheReader", func() {
+	var reader *CacheReader
+
+	BeforeEach(func() {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		now := time.Now()
+		for i, name := range []string{"c", "a", "d", "b"} {
+			Expect(indexer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+			}})).To(Succeed())
+		}
+		reader = &CacheReader{indexer: indexer, groupVersionKind: corev1.SchemeGroupVersion.WithKind("Pod")}
+	})
+
+	names := func(pods *corev1.PodList) []string {
+		var names []string
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	It("should sort the listed objects", func() {
+		pods := &corev1.PodList{}
+		Expect(reader.List(context.Background(), pods, client.SortByName)).To(Succeed())
+		Expect(names(pods)).To(Equal([]string{"a", "b", "c", "d"}))
+
+		Expect(reader.List(context.Background(), pods, client.SortByCreationTimestamp)).To(Succeed())
+		Expect(names(pods)).To(Equal([]string{"c", "a", "d", "b"}))
+	})
+
+	It("should apply offset and limit after sorting", func() {
+		pods := &corev1.PodList{}
+		Expect(reader.List(context.Background(), pods, client.SortByName, client.ListOffset(1), client.Limit(2))).To(Succeed())
+		Expect(names(pods)).To(Equal([]string{"b", "c"}))
+
+		Expect(reader.List(context.Background(), pods, client.SortByName, client.ListOffset(3), client.Limit(2))).To(Succeed())
+		Expect(names(pods)).To(Equal([]string{"d"}))
+
+		Expect(reader.List(context.Background(), pods, client.SortByName, client.ListOffset(4))).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+	})
+
+	It("should page through all objects exactly once without SortBy", func() {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for i := range 50 {
+			Expect(indexer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: fmt.Sprintf("ns-%d", i%3),
+				Name:      fmt.Sprintf("pod-%d", i),
+			}})).To(Succeed())
+		}
+		reader := &CacheReader{indexer: indexer, groupVersionKind: corev1.SchemeGroupVersion.WithKind("Pod")}
+
+		seen := map[string]int{}
+		for offset := int64(0); ; offset += 7 {
+			pods := &corev1.PodList{}
+			Expect(reader.List(context.Background(), pods, client.ListOffset(offset), client.Limit(7))).To(Succeed())
+			if len(pods.Items) == 0 {
+				break
+			}
+			for _, pod := range pods.Items {
+				seen[pod.Namespace+"/"+pod.Name]++
+			}
+		}
+		Expect(seen).To(HaveLen(50))
+		for key, count := range seen {
+			Expect(count).To(Equal(1), "%s was listed %d times", key, count)
+		}
+	})
+
+	It("should sort with a typed comparison function", func() {
+		pods := &corev1.PodList{}
+		byNameDescending := client.SortByFunc(func(a, b *corev1.Pod) int {
+			return -client.SortByName(a, b)
+		})
+		Expect(reader.List(context.Background(), pods, byNameDescending, client.Limit(1))).To(Succeed())
+		Expect(names(pods)).To(Equal([]string{"d"}))
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	"sigs.k8s.io/controller-runtime/pkg/internal/syncs"
@@ -71,7 +73,16 @@ type InformersOpts struct {
 	Transform             cache.TransformFunc
 	UnsafeDisableDeepCopy bool
 	EnableWatchBookmarks  bool
+	EnableWatchList       bool
 	WatchErrorHandler     cache.WatchErrorHandlerWithContext
+	ObjectMetrics         bool
+	MaxWatchSilence       time.Duration
+	Filter                func(runtime.Object) bool
+	IdleTTL               time.Duration
+	ResumeStore           ResumeStore
+	ResumeInterval        time.Duration
+	InitialListPageSize   int64
+	StartSchedule         *StartSchedule
 }
 
 // NewInformers creates a new InformersMap that can create informers under the hood.
@@ -99,8 +110,17 @@ func NewInformers(config *rest.Config, options *InformersOpts) *Informers {
 		transform:             options.Transform,
 		unsafeDisableDeepCopy: options.UnsafeDisableDeepCopy,
 		enableWatchBookmarks:  options.EnableWatchBookmarks,
+		enableWatchList:       options.EnableWatchList,
 		newInformer:           newInformer,
 		watchErrorHandler:     options.WatchErrorHandler,
+		objectMetrics:         options.ObjectMetrics,
+		maxWatchSilence:       options.MaxWatchSilence,
+		filter:                options.Filter,
+		idleTTL:               options.IdleTTL,
+		resumeStore:           options.ResumeStore,
+		resumeInterval:        options.ResumeInterval,
+		initialListPageSize:   options.InitialListPageSize,
+		startSchedule:         options.StartSchedule,
 	}
 }
 
@@ -114,6 +134,49 @@ type Cache struct {
 
 	// Stop can be used to stop this individual informer.
 	stop chan struct{}
+
+	// done is closed once Start returned.
+	done chan struct{}
+
+	// metrics keeps the object metrics of the informer up to date, it is nil unless
+	// object metrics are enabled.
+	metrics *objectMetrics
+
+	// pinned is true for informers that were requested with GetInformer, which are
+	// never evicted for being idle.
+	pinned atomic.Bool
+
+	// lastUsed is the time in unix nanoseconds the informer was last read from.
+	lastUsed atomic.Int64
+
+	// resume configures saving the state of the informer, it is nil unless the
+	// informers have a ResumeStore.
+	resume *resumeConfig
+
+	// health tracks the activity and the errors of the watch of the informer.
+	health *watchHealth
+
+	// syncMu guards startedAt and syncedAt.
+	syncMu    sync.Mutex
+	startedAt time.Time
+	syncedAt  time.Time
+}
+
+// Stopped returns a channel that is closed once the informer stopped, e.g. because it
+// was removed.
+func (c *Cache) Stopped() <-chan struct{} {
+	return c.done
+}
+
+// Pin prevents the informer from being evicted for being idle. It is meant for
+// informers that are used for more than reads, e.g. by event handlers.
+func (c *Cache) Pin() {
+	c.pinned.Store(true)
+}
+
+// MarkUsed records that the informer was read from, which restarts its idle TTL.
+func (c *Cache) MarkUsed() {
+	c.lastUsed.Store(time.Now().UnixNano())
 }
 
 // Start starts the informer managed by a MapEntry.
@@ -121,11 +184,122 @@ type Cache struct {
 // either individually (via the entry's stop channel) or globally
 // via the provided stop argument.
 func (c *Cache) Start(stop <-chan struct{}) {
+	defer close(c.done)
 	// Stop on either the whole map stopping or just this informer being removed.
 	internalStop, cancel := syncs.MergeChans(stop, c.stop)
 	defer cancel()
+	c.recordSync(internalStop)
 	// Convert the stop channel to a context and then add the logger.
 	c.Informer.RunWithContext(logr.NewContext(wait.ContextForChannel(internalStop), log))
+	if c.metrics != nil {
+		c.metrics.stop()
+	}
+	if c.health != nil {
+		c.health.stop()
+	}
+
+	// Drop the objects of an informer that was removed, so that they are freed
+	// even if the informer itself is still referenced, e.g. by a reader.
+	select {
+	case <-c.stop:
+		if err := c.Informer.GetStore().Replace(nil, ""); err != nil {
+			log.Error(err, "Failed to clear the store of a removed informer")
+		}
+	default:
+	}
+}
+
+// recordSync records when the informer was started and when it synced.
+func (c *Cache) recordSync(stop <-chan struct{}) {
+	c.syncMu.Lock()
+	c.startedAt = time.Now()
+	c.syncMu.Unlock()
+
+	go func() {
+		select {
+		case <-c.Informer.HasSyncedChecker().Done():
+			c.syncMu.Lock()
+			c.syncedAt = time.Now()
+			c.syncMu.Unlock()
+		case <-stop:
+		}
+	}()
+}
+
+// SyncStatus returns whether the informer synced, and how long it took to sync or
+// how long it has been syncing. Informers that were not started yet are not synced
+// and have no elapsed time.
+func (c *Cache) SyncStatus() (synced bool, elapsed time.Duration) {
+	c.syncMu.Lock()
+	defer c.syncMu.Unlock()
+	switch {
+	case c.startedAt.IsZero():
+		return false, 0
+	case !c.syncedAt.IsZero():
+		return true, c.syncedAt.Sub(c.startedAt)
+	default:
+		return false, time.Since(c.startedAt)
+	}
+}
+
+// syncStatus returns the SyncStatus of the informer.
+func (c *Cache) syncStatus(gvk schema.GroupVersionKind, namespace string) SyncStatus {
+	synced, elapsed := c.SyncStatus()
+	status := SyncStatus{
+		GVK:       gvk,
+		Namespace: namespace,
+		Synced:    synced,
+		Elapsed:   elapsed,
+		Stopped:   elapsed > 0 && c.Informer.IsStopped(),
+	}
+	if c.health != nil {
+		status.LastWatchActivity, status.WatchFailingSince = c.health.status()
+	}
+	return status
+}
+
+// AppliedResourceVersion returns the resourceVersion up to which the changes observed
+// by the informer have been applied to the cache, including watch bookmarks. Unlike
+// the LastSyncResourceVersion of the informer, which is updated as soon as a change is
+// received, it is only updated once the change can be read from the cache. It is empty
+// if the store of the informer doesn't track it, which requires the AtomicFIFO feature
+// of client-go.
+func (c *Cache) AppliedResourceVersion() string {
+	return c.Reader.indexer.LastStoreSyncResourceVersion()
+}
+
+// Snapshot copies the objects currently in the cache and returns a reader for the copy,
+// along with the resourceVersion up to which changes had been applied to the cache when
+// copying them. The resourceVersion is empty if a change was applied while copying.
+func (c *Cache) Snapshot() (*CacheReader, string, error) {
+	resourceVersion := c.AppliedResourceVersion()
+	objs := c.Reader.indexer.List()
+	if c.AppliedResourceVersion() != resourceVersion {
+		resourceVersion = ""
+	}
+	reader, err := c.NewSnapshotReader(objs)
+	return reader, resourceVersion, err
+}
+
+// NewSnapshotReader returns a reader for copies of the given objects, which must be of
+// the type of the cache. The reader uses the indexers of the cache, so that field
+// selectors supported by the cache are supported by the reader as well.
+func (c *Cache) NewSnapshotReader(objs []any) (*CacheReader, error) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, c.Reader.indexer.GetIndexers())
+	for _, obj := range objs {
+		runtimeObj, ok := obj.(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("cache contained %T, which is not an Object", obj)
+		}
+		if err := indexer.Add(runtimeObj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	return &CacheReader{
+		indexer:          indexer,
+		groupVersionKind: c.Reader.groupVersionKind,
+		scopeName:        c.Reader.scopeName,
+	}, nil
 }
 
 type tracker struct {
@@ -198,6 +372,10 @@ type Informers struct {
 	unsafeDisableDeepCopy bool
 	enableWatchBookmarks  bool
 
+	// enableWatchList makes the informers sync initially with a streaming list
+	// if the WatchListClient feature gate of client-go is enabled.
+	enableWatchList bool
+
 	// NewInformer allows overriding of the shared index informer constructor for testing.
 	newInformer func(cache.ListerWatcher, runtime.Object, time.Duration, cache.Indexers) cache.SharedIndexInformer
 
@@ -205,6 +383,44 @@ type Informers struct {
 	// watchErrorHandler to be set by overriding the options
 	// or to use the default watchErrorHandler
 	watchErrorHandler cache.WatchErrorHandlerWithContext
+
+	// objectMetrics enables the metrics of the objects in the cache.
+	objectMetrics bool
+
+	// maxWatchSilence is the duration after which watches that didn't receive
+	// any events are restarted. Zero disables restarting them.
+	maxWatchSilence time.Duration
+
+	// filter drops the objects it returns false for before they are stored, see
+	// objectFilter. Nil keeps all objects.
+	filter func(runtime.Object) bool
+
+	// idleTTL is the duration after which informers that are not pinned and weren't
+	// read from are stopped and removed. Zero disables evicting informers.
+	idleTTL time.Duration
+
+	// resumeStore saves the state of the informers when they stop, so that they resume
+	// from it when they are started again. Nil disables saving and resuming.
+	resumeStore ResumeStore
+
+	// resumeInterval is the interval the state of the informers is additionally saved
+	// in. Zero only saves it when the informers stop.
+	resumeInterval time.Duration
+
+	// initialListPageSize is the page size of the lists of the informers that are
+	// paginated. Zero uses the default page size of client-go.
+	initialListPageSize int64
+
+	// startSchedule delays the start of informers, so that their initial lists are
+	// spread over time. Nil starts them right away.
+	startSchedule *StartSchedule
+}
+
+// resumeConfig is the configuration of an informer for saving its state.
+type resumeConfig struct {
+	store    ResumeStore
+	key      string
+	selector string
 }
 
 // Start calls Run on each of the informers and sets started to true. Blocks on the context.
@@ -236,6 +452,17 @@ func (ip *Informers) Start(ctx context.Context) error {
 		ip.started = true
 		close(ip.startWait)
 
+		if ip.idleTTL > 0 {
+			ip.waitGroup.Go(func() {
+				ip.evictIdleInformers(ctx)
+			})
+		}
+		if ip.resumeStore != nil && ip.resumeInterval > 0 {
+			ip.waitGroup.Go(func() {
+				ip.checkpointResumeStates(ctx)
+			})
+		}
+
 		return nil
 	}(); err != nil {
 		return err
@@ -245,6 +472,11 @@ func (ip *Informers) Start(ctx context.Context) error {
 	ip.stopped = true // Set stopped to true so we don't start any new informers
 	ip.mu.Unlock()
 	ip.waitGroup.Wait() // Block until all informers have stopped
+	if ip.resumeStore != nil {
+		saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resumeSaveTimeout)
+		defer cancel()
+		ip.saveResumeStates(saveCtx)
+	}
 	return nil
 }
 
@@ -256,7 +488,19 @@ func (ip *Informers) startInformerLocked(cacheEntry *Cache) {
 		return
 	}
 
+	delay := ip.startSchedule.delay()
 	ip.waitGroup.Go(func() {
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			// Start returns right away if the informers stop or the informer is
+			// removed in the meantime.
+			select {
+			case <-timer.C:
+			case <-ip.ctx.Done():
+			case <-cacheEntry.stop:
+			}
+		}
 		cacheEntry.Start(ip.ctx.Done())
 	})
 }
@@ -290,6 +534,82 @@ func (ip *Informers) getHasSyncedFuncs() []cache.InformerSynced {
 	return res
 }
 
+// SyncStatus is the sync status of the informer of a GVK.
+type SyncStatus struct {
+	// GVK is the GroupVersionKind of the informer. Informers for the structured,
+	// unstructured and metadata-only form of a GVK are reported separately.
+	GVK schema.GroupVersionKind
+
+	// Namespace is the namespace the informer is restricted to, it is empty if the
+	// informer isn't restricted to a namespace.
+	Namespace string
+
+	// Synced is true once the informer synced.
+	Synced bool
+
+	// Elapsed is the duration the informer took to sync, or the duration since it
+	// was started if it didn't sync yet. It is zero for informers that were not
+	// started yet.
+	Elapsed time.Duration
+
+	// Stopped is true if the informer was started and stopped running.
+	Stopped bool
+
+	// LastWatchActivity is the time the watch of the informer was last established
+	// or last received an event, including bookmarks. It is zero until the informer
+	// watched.
+	LastWatchActivity time.Time
+
+	// WatchFailingSince is the time since which the list or watch of the informer
+	// has been failing, i.e. since its first error after the watch last received an
+	// event. It is zero if it isn't failing.
+	WatchFailingSince time.Time
+}
+
+// SyncStatuses returns the sync status of all the informers in this map.
+func (ip *Informers) SyncStatuses() []SyncStatus {
+	ip.mu.RLock()
+	defer ip.mu.RUnlock()
+
+	var res []SyncStatus
+	for _, informers := range []map[schema.GroupVersionKind]*Cache{ip.tracker.Structured, ip.tracker.Unstructured, ip.tracker.Metadata} {
+		for gvk, i := range informers {
+			res = append(res, i.syncStatus(gvk, ip.namespace))
+		}
+	}
+	return res
+}
+
+// InformerContents are the objects in the store of the informer of a GVK.
+type InformerContents struct {
+	SyncStatus
+
+	// ResourceVersion is the resourceVersion the informer last applied, see
+	// Cache.AppliedResourceVersion.
+	ResourceVersion string
+
+	// Objects are the objects in the store of the informer. They must not be mutated.
+	Objects []any
+}
+
+// Contents returns the objects in the stores of all the informers in this map.
+func (ip *Informers) Contents() []InformerContents {
+	ip.mu.RLock()
+	defer ip.mu.RUnlock()
+
+	var res []InformerContents
+	for _, informers := range []map[schema.GroupVersionKind]*Cache{ip.tracker.Structured, ip.tracker.Unstructured, ip.tracker.Metadata} {
+		for gvk, i := range informers {
+			res = append(res, InformerContents{
+				SyncStatus:      i.syncStatus(gvk, ip.namespace),
+				ResourceVersion: i.AppliedResourceVersion(),
+				Objects:         i.Reader.indexer.List(),
+			})
+		}
+	}
+	return res
+}
+
 // WaitForCacheSync waits until all the caches have been started and synced.
 func (ip *Informers) WaitForCacheSync(ctx context.Context) bool {
 	if !ip.waitForStarted(ctx) {
@@ -336,8 +656,39 @@ func (ip *Informers) Get(ctx context.Context, gvk schema.GroupVersionKind, obj r
 	return started, i, nil
 }
 
-// Remove removes an informer entry and stops it if it was running.
-func (ip *Informers) Remove(gvk schema.GroupVersionKind, obj runtime.Object) {
+// evictIdleInformers stops and removes the informers that are not pinned and weren't
+// read from for longer than the idle TTL, until ctx is done.
+func (ip *Informers) evictIdleInformers(ctx context.Context) {
+	ticker := time.NewTicker(ip.idleTTL / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ip.evictIdleInformersOnce(now)
+		}
+	}
+}
+
+func (ip *Informers) evictIdleInformersOnce(now time.Time) {
+	ip.mu.Lock()
+	defer ip.mu.Unlock()
+
+	for _, informers := range []map[schema.GroupVersionKind]*Cache{ip.tracker.Structured, ip.tracker.Unstructured, ip.tracker.Metadata} {
+		for gvk, i := range informers {
+			if i.pinned.Load() || now.Sub(time.Unix(0, i.lastUsed.Load())) < ip.idleTTL {
+				continue
+			}
+			close(i.stop)
+			delete(informers, gvk)
+		}
+	}
+}
+
+// Remove removes an informer entry and stops it if it was running. It returns a
+// channel that is closed once the informer stopped, or nil if it wasn't running.
+func (ip *Informers) Remove(gvk schema.GroupVersionKind, obj runtime.Object) <-chan struct{} {
 	ip.mu.Lock()
 	defer ip.mu.Unlock()
 
@@ -345,10 +696,14 @@ func (ip *Informers) Remove(gvk schema.GroupVersionKind, obj runtime.Object) {
 
 	entry, ok := informerMap[gvk]
 	if !ok {
-		return
+		return nil
 	}
 	close(entry.stop)
 	delete(informerMap, gvk)
+	if !ip.started || ip.stopped {
+		return nil
+	}
+	return entry.done
 }
 
 func (ip *Informers) informersByType(obj runtime.Object) map[schema.GroupVersionKind]*Cache {
@@ -379,27 +734,83 @@ func (ip *Informers) addInformerToMap(gvk schema.GroupVersionKind, obj runtime.O
 	if err != nil {
 		return nil, false, err
 	}
-	sharedIndexInformer := ip.newInformer(&cache.ListWatch{
+	mapping, err := ip.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	selector := ip.selector.forScope(mapping.Scope.Name())
+	selectorOpts := metav1.ListOptions{}
+	selector.ApplyToList(&selectorOpts)
+	health := newWatchHealth(newWatchID(gvk, obj, ip.namespace, selectorOpts), ip.maxWatchSilence)
+	var filter *objectFilter
+	if ip.filter != nil {
+		filter = newObjectFilter(ip.filter)
+	}
+	var resume *resumeConfig
+	if ip.resumeStore != nil {
+		resume = &resumeConfig{
+			store:    ip.resumeStore,
+			key:      resumeKey(gvk, obj, ip.namespace),
+			selector: resumeSelector(selectorOpts, ip.namespace),
+		}
+	}
+	resumed := &atomic.Bool{}
+	lw := &cache.ListWatch{
 		ListWithContextFunc: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
-			ip.selector.ApplyToList(&opts)
-			return listWatcher.ListWithContextFunc(ctx, opts)
+			// Only the initial list is served from the saved state, lists after an
+			// expired watch need to get the current objects.
+			if resume != nil && resumed.CompareAndSwap(false, true) {
+				list, err := resumedList(ctx, resume.store, resume.key, resume.selector, obj)
+				switch {
+				case err != nil:
+					log.Error(err, "Failed to load the saved state of the informer, listing the objects instead", "gvk", gvk, "namespace", ip.namespace)
+				case list != nil:
+					log.V(1).Info("Resuming the informer from its saved state", "gvk", gvk, "namespace", ip.namespace, "resourceVersion", list.ResourceVersion)
+					return list, nil
+				}
+			}
+			selector.ApplyToList(&opts)
+			if ip.initialListPageSize > 0 && opts.Limit > 0 {
+				opts.Limit = ip.initialListPageSize
+			}
+			list, err := listWatcher.ListWithContextFunc(ctx, opts)
+			if err != nil || filter == nil {
+				return list, err
+			}
+			return list, filter.list(list)
 		},
 		WatchFuncWithContext: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 			opts.Watch = true // Watch needs to be set to true separately
-			opts.AllowWatchBookmarks = ip.enableWatchBookmarks
-
-			ip.selector.ApplyToList(&opts)
-			return listWatcher.WatchFuncWithContext(ctx, opts)
+			// Streaming lists end their initial events with a bookmark, so they need them.
+			if opts.SendInitialEvents == nil {
+				opts.AllowWatchBookmarks = ip.enableWatchBookmarks
+			}
+
+			selector.ApplyToList(&opts)
+			watcher, err := listWatcher.WatchFuncWithContext(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			watcher = health.track(watcher)
+			if filter != nil {
+				watcher = filter.watch(watcher, ptr.Deref(opts.SendInitialEvents, false))
+			}
+			return watcher, nil
 		},
-	}, obj, calculateResyncPeriod(ip.resync), cache.Indexers{
+	}
+	var informerListWatcher cache.ListerWatcher = lw
+	// Informers that resume from a saved state need to sync with a List, which returns it.
+	if !ip.enableWatchList || resume != nil {
+		informerListWatcher = cache.ToListWatcherWithWatchListSemantics(lw, watchListUnsupported{})
+	}
+	sharedIndexInformer := ip.newInformer(informerListWatcher, obj, calculateResyncPeriod(ip.resync), cache.Indexers{
 		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
 	})
 
-	// Set WatchErrorHandler on SharedIndexInformer if set
-	if ip.watchErrorHandler != nil {
-		if err := sharedIndexInformer.SetWatchErrorHandlerWithContext(ip.watchErrorHandler); err != nil {
-			return nil, false, err
-		}
+	// Set WatchErrorHandler on SharedIndexInformer, counting the errors before
+	// passing them on to the configured or the default handler.
+	if err := sharedIndexInformer.SetWatchErrorHandlerWithContext(health.errorHandler(ip.watchErrorHandler)); err != nil {
+		return nil, false, err
 	}
 
 	// Check to see if there is a transformer for this gvk
@@ -407,11 +818,6 @@ func (ip *Informers) addInformerToMap(gvk schema.GroupVersionKind, obj runtime.O
 		return nil, false, err
 	}
 
-	mapping, err := ip.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-	if err != nil {
-		return nil, false, err
-	}
-
 	// Create the new entry and set it in the map.
 	i := &Cache{
 		Informer: sharedIndexInformer,
@@ -421,7 +827,17 @@ func (ip *Informers) addInformerToMap(gvk schema.GroupVersionKind, obj runtime.O
 			scopeName:        mapping.Scope.Name(),
 			disableDeepCopy:  ip.unsafeDisableDeepCopy,
 		},
-		stop: make(chan struct{}),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		resume: resume,
+		health: health,
+	}
+	i.MarkUsed()
+	if ip.objectMetrics {
+		i.metrics = newObjectMetrics(gvk)
+		if _, err := sharedIndexInformer.AddEventHandler(i.metrics); err != nil {
+			return nil, false, err
+		}
 	}
 	ip.informersByType(obj)[gvk] = i
 
@@ -629,3 +1045,11 @@ func restrictNamespaceBySelector(namespaceOpt string, s Selector) string {
 	}
 	return ""
 }
+
+// watchListUnsupported makes the reflector of an informer list the objects instead
+// of streaming them, see watchlist.DoesClientNotSupportWatchListSemantics.
+type watchListUnsupported struct{}
+
+func (watchListUnsupported) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
@@ -17,14 +17,26 @@ limitations under the License.
 package internal
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/watchlist"
+	"k8s.io/utils/ptr"
 )
 
 // Test that gvkFixupWatcher behaves like watch.FakeWatcher
@@ -92,3 +104,294 @@ var _ = Describe("gvkFixupWatcher", func() {
 		consumer(gvkfw)
 	})
 })
+
+var _ = Describe("Informers", func() {
+	It("should stop a removed informer and drop the objects of its store", func(ctx SpecContext) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", ResourceVersion: "1"}}
+
+		ip := NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient: http.DefaultClient,
+			Scheme:     scheme.Scheme,
+			Mapper:     mapper,
+			NewInformer: func(_ cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				return cache.NewSharedIndexInformer(podListWatch(pod), obj, resync, indexers)
+			},
+		})
+		informersCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(ip.Start(informersCtx)).To(Succeed())
+		}()
+		Expect(ip.WaitForCacheSync(ctx)).To(BeTrue())
+
+		_, entry, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Informer.GetStore().ListKeys()).To(ConsistOf("default/a"))
+		Expect(entry.Stopped()).NotTo(BeClosed())
+
+		done := ip.Remove(podGVK, &corev1.Pod{})
+		Expect(done).NotTo(BeNil())
+		Eventually(done).Should(BeClosed())
+		Expect(entry.Informer.IsStopped()).To(BeTrue())
+		Expect(entry.Stopped()).To(BeClosed())
+		Expect(entry.Informer.GetStore().ListKeys()).To(BeEmpty())
+
+		_, _, found := ip.Peek(podGVK, &corev1.Pod{})
+		Expect(found).To(BeFalse())
+		Expect(ip.Remove(podGVK, &corev1.Pod{})).To(BeNil())
+	})
+
+	It("should report the sync status of its informers", func(ctx SpecContext) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", ResourceVersion: "1"}}
+
+		ip := NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient: http.DefaultClient,
+			Scheme:     scheme.Scheme,
+			Mapper:     mapper,
+			Namespace:  "default",
+			NewInformer: func(_ cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				return cache.NewSharedIndexInformer(podListWatch(pod), obj, resync, indexers)
+			},
+		})
+		_, _, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ip.SyncStatuses()).To(ConsistOf(SyncStatus{GVK: podGVK, Namespace: "default"}))
+
+		informersCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(ip.Start(informersCtx)).To(Succeed())
+		}()
+		Expect(ip.WaitForCacheSync(ctx)).To(BeTrue())
+
+		Eventually(func(g Gomega) {
+			statuses := ip.SyncStatuses()
+			g.Expect(statuses).To(HaveLen(1))
+			g.Expect(statuses[0].GVK).To(Equal(podGVK))
+			g.Expect(statuses[0].Synced).To(BeTrue())
+			g.Expect(statuses[0].Elapsed).To(BeNumerically(">", 0))
+		}).Should(Succeed())
+	})
+
+	It("should evict idle informers unless they are pinned", func(ctx SpecContext) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", ResourceVersion: "1"}}
+
+		ip := NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient: http.DefaultClient,
+			Scheme:     scheme.Scheme,
+			Mapper:     mapper,
+			IdleTTL:    200 * time.Millisecond,
+			NewInformer: func(_ cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				return cache.NewSharedIndexInformer(podListWatch(pod), obj, resync, indexers)
+			},
+		})
+		informersCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(ip.Start(informersCtx)).To(Succeed())
+		}()
+		Expect(ip.WaitForCacheSync(ctx)).To(BeTrue())
+
+		By("evicting an informer that isn't read from")
+		_, entry, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			_, _, found := ip.Peek(podGVK, &corev1.Pod{})
+			return found
+		}).Should(BeFalse())
+		Eventually(entry.Informer.IsStopped).Should(BeTrue())
+
+		By("keeping an informer that is pinned")
+		_, entry, err = ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		entry.Pin()
+		Consistently(func() bool {
+			_, _, found := ip.Peek(podGVK, &corev1.Pod{})
+			return found
+		}, time.Second).Should(BeTrue())
+		Expect(entry.Informer.IsStopped()).To(BeFalse())
+	})
+
+	It("should resume informers from the state they saved when they stopped", func(ctx SpecContext) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", ResourceVersion: "1"}}
+		store := &memResumeStore{states: map[string][]byte{}}
+
+		By("saving the state when the informers stop")
+		ip := NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient:  http.DefaultClient,
+			Scheme:      scheme.Scheme,
+			Mapper:      mapper,
+			ResumeStore: store,
+			NewInformer: func(_ cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				return cache.NewSharedIndexInformer(podListWatch(pod), obj, resync, indexers)
+			},
+		})
+		_, _, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		informersCtx, cancel := context.WithCancel(ctx)
+		stopped := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(stopped)
+			Expect(ip.Start(informersCtx)).To(Succeed())
+		}()
+		Expect(ip.WaitForCacheSync(ctx)).To(BeTrue())
+		cancel()
+		Eventually(stopped).Should(BeClosed())
+		Expect(store.states).To(HaveKey("structured.Pod.v1."))
+
+		By("resuming from the saved state instead of listing")
+		watchResourceVersions := make(chan string, 1)
+		ip = NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient:  http.DefaultClient,
+			Scheme:      scheme.Scheme,
+			Mapper:      mapper,
+			ResumeStore: store,
+			NewInformer: func(lw cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				return cache.NewSharedIndexInformer(cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+					ListWithContextFunc: cache.ToListerWatcherWithContext(lw).ListWithContext,
+					WatchFuncWithContext: func(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+						watchResourceVersions <- opts.ResourceVersion
+						return watch.NewFake(), nil
+					},
+				}, lw), obj, resync, indexers)
+			},
+		})
+		_, entry, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			Expect(ip.Start(ctx)).To(Succeed())
+		}()
+		Expect(ip.WaitForCacheSync(ctx)).To(BeTrue())
+		Expect(entry.Informer.GetStore().ListKeys()).To(ConsistOf("default/a"))
+		Eventually(watchResourceVersions).Should(Receive(Equal("1")))
+	})
+
+	It("should request the configured page size for paginated lists", func(ctx SpecContext) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+
+		limits := make(chan string, 2)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limits <- r.URL.Query().Get("limit")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"PodList","metadata":{"resourceVersion":"1"},"items":[]}`))
+		}))
+		defer server.Close()
+
+		var lw cache.ListerWatcherWithContext
+		ip := NewInformers(&rest.Config{Host: server.URL}, &InformersOpts{
+			HTTPClient:          http.DefaultClient,
+			Scheme:              scheme.Scheme,
+			Mapper:              mapper,
+			InitialListPageSize: 10,
+			NewInformer: func(informerLW cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				lw = cache.ToListerWatcherWithContext(informerLW)
+				return cache.NewSharedIndexInformer(informerLW, obj, resync, indexers)
+			},
+		})
+		_, _, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = lw.ListWithContext(ctx, metav1.ListOptions{Limit: 500})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits).To(Receive(Equal("10")))
+
+		By("not paginating lists that aren't paginated")
+		_, err = lw.ListWithContext(ctx, metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limits).To(Receive(BeEmpty()))
+	})
+
+	It("should spread the start of informers with a StartSchedule", func() {
+		Expect(NewStartSchedule(0)).To(BeNil())
+		Expect(NewStartSchedule(0).delay()).To(BeZero())
+
+		schedule := NewStartSchedule(time.Minute)
+		Expect(schedule.delay()).To(BeZero())
+		Expect(schedule.delay()).To(BeNumerically("~", time.Minute, time.Second))
+		Expect(schedule.delay()).To(BeNumerically("~", 2*time.Minute, time.Second))
+	})
+
+	DescribeTable("should sync with streaming lists unless they are disabled", func(ctx SpecContext, enableWatchList bool) {
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+
+		var supportsWatchList bool
+		ip := NewInformers(&rest.Config{Host: "http://127.0.0.1:1"}, &InformersOpts{
+			HTTPClient:      http.DefaultClient,
+			Scheme:          scheme.Scheme,
+			Mapper:          mapper,
+			EnableWatchList: enableWatchList,
+			NewInformer: func(lw cache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+				supportsWatchList = !watchlist.DoesClientNotSupportWatchListSemantics(lw)
+				return cache.NewSharedIndexInformer(lw, obj, resync, indexers)
+			},
+		})
+		_, _, err := ip.Get(ctx, podGVK, &corev1.Pod{}, false, &GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(supportsWatchList).To(Equal(enableWatchList))
+	},
+		Entry("enabled", true),
+		Entry("disabled", false),
+	)
+})
+
+// memResumeStore is a ResumeStore that keeps the states in memory.
+type memResumeStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+func (s *memResumeStore) Load(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[key], nil
+}
+
+func (s *memResumeStore) Save(_ context.Context, key string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[key] = state
+	return nil
+}
+
+// podListWatch returns a ListWatch that lists pod and serves watches without events.
+func podListWatch(pod *corev1.Pod) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListWithContextFunc: func(context.Context, metav1.ListOptions) (runtime.Object, error) {
+			return &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: "1"}, Items: []corev1.Pod{*pod}}, nil
+		},
+		WatchFuncWithContext: func(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			if !ptr.Deref(opts.SendInitialEvents, false) {
+				return watch.NewFake(), nil
+			}
+			// Serve the initial events of a watch list request.
+			w := watch.NewFakeWithChanSize(2, false)
+			w.Add(pod.DeepCopy())
+			w.Action(watch.Bookmark, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: "1",
+				Annotations:     map[string]string{metav1.InitialEventsAnnotationKey: "true"},
+			}})
+			return w, nil
+		},
+	}
+}
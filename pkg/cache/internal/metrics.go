@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// cachedObjects is a prometheus gauge metric which holds the number of objects
+	// in the cache per GVK and namespace.
+	cachedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_cache_objects",
+		Help: "Number of objects in the cache per group, version, kind and namespace",
+	}, []string{"group", "version", "kind", "namespace"})
+
+	// cachedObjectsSize is a prometheus gauge metric which holds the estimated size
+	// of the objects in the cache per GVK and namespace.
+	cachedObjectsSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_cache_objects_size_bytes",
+		Help: "Estimated size of the objects in the cache per group, version, kind and namespace, based on their serialized size",
+	}, []string{"group", "version", "kind", "namespace"})
+
+	// watchLastEvent is a prometheus gauge metric which holds the time at which the
+	// watch of an informer received its last event.
+	watchLastEvent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_watch_last_event_timestamp_seconds",
+		Help: "Time at which a watch of the cache received its last event, including bookmarks, per watch",
+	}, watchLabels)
+
+	// watchFailing is a prometheus gauge metric which is 1 while the list or watch of
+	// an informer is failing and 0 otherwise.
+	watchFailing = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_watch_failing",
+		Help: "Whether the list or watch of an informer of the cache is failing, i.e. it errored and didn't receive any event since, per watch",
+	}, watchLabels)
+
+	// watchErrors is a prometheus counter metric which holds the number of errors
+	// the watch of an informer dropped its connection with.
+	watchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_watch_errors_total",
+		Help: "Total number of errors of the watches of the cache per watch",
+	}, watchLabels)
+
+	// watchRestarts is a prometheus counter metric which holds the number of times
+	// the watch of an informer was restarted because it was silent.
+	watchRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_watch_restarts_total",
+		Help: "Total number of times a watch of the cache was restarted because it didn't receive any events, per watch",
+	}, watchLabels)
+)
+
+// watchLabels are the labels of the watch metrics, which identify a watch by the
+// group, version and kind, the form, i.e. structured, unstructured or metadata, the
+// namespace and the label and field selector of its informer.
+var watchLabels = []string{"group", "version", "kind", "form", "namespace", "selector"}
+
+func init() {
+	metrics.Registry.MustRegister(cachedObjects, cachedObjectsSize, watchLastEvent, watchFailing, watchErrors, watchRestarts)
+}
+
+// objectMetrics is an event handler that keeps the object metrics of the informer it
+// is added to up to date. Objects of the same GVK that are cached by multiple
+// informers, e.g. per namespace or as metadata and as structured objects, are summed up.
+type objectMetrics struct {
+	gvk schema.GroupVersionKind
+
+	mu      sync.Mutex
+	stopped bool
+	// sizes holds the estimated size of every counted object by its key, so that
+	// updated and deleted objects don't need to be serialized again.
+	sizes map[string]int
+}
+
+var _ cache.ResourceEventHandler = &objectMetrics{}
+
+func newObjectMetrics(gvk schema.GroupVersionKind) *objectMetrics {
+	return &objectMetrics{gvk: gvk, sizes: map[string]int{}}
+}
+
+// OnAdd implements cache.ResourceEventHandler.
+func (m *objectMetrics) OnAdd(obj any, _ bool) {
+	m.set(obj)
+}
+
+// OnUpdate implements cache.ResourceEventHandler.
+func (m *objectMetrics) OnUpdate(_, obj any) {
+	m.set(obj)
+}
+
+// OnDelete implements cache.ResourceEventHandler.
+func (m *objectMetrics) OnDelete(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	size, ok := m.sizes[key]
+	if m.stopped || !ok {
+		return
+	}
+	delete(m.sizes, key)
+	m.add(key, -1, -size)
+}
+
+func (m *objectMetrics) set(obj any) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	size := estimateObjectSize(obj)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return
+	}
+	oldSize, exists := m.sizes[key]
+	m.sizes[key] = size
+	if exists {
+		m.add(key, 0, size-oldSize)
+		return
+	}
+	m.add(key, 1, size)
+}
+
+// stop removes the objects counted so far from the metrics and ignores all further
+// events. It is called once the informer stopped.
+func (m *objectMetrics) stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return
+	}
+	m.stopped = true
+	for key, size := range m.sizes {
+		m.add(key, -1, -size)
+	}
+	m.sizes = nil
+}
+
+func (m *objectMetrics) add(key string, count, size int) {
+	namespace, _, _ := cache.SplitMetaNamespaceKey(key)
+	labels := []string{m.gvk.Group, m.gvk.Version, m.gvk.Kind, namespace}
+	cachedObjects.WithLabelValues(labels...).Add(float64(count))
+	cachedObjectsSize.WithLabelValues(labels...).Add(float64(size))
+}
+
+// estimateObjectSize estimates the size of a cached object by its serialized size. It
+// uses the protobuf size of objects that provide it, like the built-in types, and the
+// size of the JSON encoding of other objects, like unstructured objects.
+func estimateObjectSize(obj any) int {
+	if sizer, ok := obj.(interface{ Size() int }); ok {
+		return sizer.Size()
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("objectMetrics", func() {
+	It("keeps the number and size of the objects per GVK and namespace up to date", func() {
+		podGVK := schema.GroupVersionKind{Group: "metrics.example.com", Version: "v1", Kind: "Pod"}
+		count := func(namespace string) float64 {
+			return testutil.ToFloat64(cachedObjects.WithLabelValues(podGVK.Group, podGVK.Version, podGVK.Kind, namespace))
+		}
+		size := func(namespace string) float64 {
+			return testutil.ToFloat64(cachedObjectsSize.WithLabelValues(podGVK.Group, podGVK.Version, podGVK.Kind, namespace))
+		}
+
+		a := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}
+		b := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}}
+		c := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "c"}}
+
+		m := newObjectMetrics(podGVK)
+		m.OnAdd(a, true)
+		m.OnAdd(b, true)
+		m.OnAdd(c, true)
+		Expect(count("default")).To(Equal(2.0))
+		Expect(count("other")).To(Equal(1.0))
+		Expect(size("default")).To(Equal(float64(a.Size() + b.Size())))
+
+		By("updating an object")
+		updated := a.DeepCopy()
+		updated.Labels = map[string]string{"foo": "bar"}
+		m.OnUpdate(a, updated)
+		Expect(count("default")).To(Equal(2.0))
+		Expect(size("default")).To(Equal(float64(updated.Size() + b.Size())))
+
+		By("deleting objects")
+		m.OnDelete(b)
+		m.OnDelete(cache.DeletedFinalStateUnknown{Key: "other/c", Obj: c})
+		Expect(count("default")).To(Equal(1.0))
+		Expect(count("other")).To(BeZero())
+		Expect(size("default")).To(Equal(float64(updated.Size())))
+
+		By("stopping the informer")
+		m.stop()
+		Expect(count("default")).To(BeZero())
+		Expect(size("default")).To(BeZero())
+		m.OnAdd(b, false)
+		Expect(count("default")).To(BeZero())
+	})
+
+	It("estimates the size of objects by their serialized size", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}
+		Expect(estimateObjectSize(pod)).To(Equal(pod.Size()))
+
+		obj := &unstructured.Unstructured{Object: map[string]any{"kind": "Widget"}}
+		Expect(estimateObjectSize(obj)).To(Equal(len(`{"kind":"Widget"}`)))
+	})
+})
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// objectFilter drops the objects of the lists and watches of an informer that don't
+// match its filter, so that they are neither stored nor passed to the event handlers
+// of the informer. Objects that stop matching are deleted from the informer.
+type objectFilter struct {
+	filter func(runtime.Object) bool
+
+	// mu guards matching, which holds the keys of the objects that matched the filter
+	// when they were last listed or watched.
+	mu       sync.Mutex
+	matching sets.Set[string]
+}
+
+func newObjectFilter(filter func(runtime.Object) bool) *objectFilter {
+	return &objectFilter{filter: filter, matching: sets.New[string]()}
+}
+
+// list drops the items of list that don't match the filter.
+func (f *objectFilter) list(list runtime.Object) error {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	matching := sets.New[string]()
+	kept := items[:0]
+	for _, item := range items {
+		if !f.filter(item) {
+			continue
+		}
+		key, err := cache.MetaNamespaceKeyFunc(item)
+		if err != nil {
+			return err
+		}
+		matching.Insert(key)
+		kept = append(kept, item)
+	}
+	if err := meta.SetList(list, kept); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.matching = matching
+	return nil
+}
+
+// watch drops the events of w for objects that don't match the filter, and turns the
+// events of objects that stop matching into deletions. Watches that send the initial
+// events of a streaming list replace the objects of the informer, like lists do.
+func (f *objectFilter) watch(w watch.Interface, sendInitialEvents bool) watch.Interface {
+	if sendInitialEvents {
+		f.mu.Lock()
+		f.matching = sets.New[string]()
+		f.mu.Unlock()
+	}
+	return watch.Filter(w, f.filterEvent)
+}
+
+func (f *objectFilter) filterEvent(event watch.Event) (watch.Event, bool) {
+	switch event.Type {
+	case watch.Added, watch.Modified, watch.Deleted:
+	default:
+		return event, true
+	}
+	key, err := cache.MetaNamespaceKeyFunc(event.Object)
+	if err != nil {
+		return event, true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	wasMatching := f.matching.Has(key)
+	switch {
+	case event.Type == watch.Deleted:
+		f.matching.Delete(key)
+		return event, wasMatching
+	case f.filter(event.Object):
+		f.matching.Insert(key)
+		return event, true
+	case wasMatching:
+		f.matching.Delete(key)
+		return watch.Event{Type: watch.Deleted, Object: event.Object}, true
+	}
+	return event, false
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var _ = Describe("objectFilter", func() {
+	var filter *objectFilter
+
+	newPod := func(name, phase string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status:     corev1.PodStatus{Phase: corev1.PodPhase(phase)},
+		}
+	}
+
+	BeforeEach(func() {
+		filter = newObjectFilter(func(obj runtime.Object) bool {
+			return obj.(*corev1.Pod).Status.Phase == corev1.PodRunning
+		})
+	})
+
+	It("should drop the items of lists that don't match", func() {
+		list := &corev1.PodList{Items: []corev1.Pod{*newPod("a", "Running"), *newPod("b", "Pending"), *newPod("c", "Running")}}
+		Expect(filter.list(list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].Name).To(Equal("a"))
+		Expect(list.Items[1].Name).To(Equal("c"))
+	})
+
+	It("should only pass on the watch events of matching objects", func() {
+		Expect(filter.list(&corev1.PodList{Items: []corev1.Pod{*newPod("listed", "Running")}})).To(Succeed())
+		fake := watch.NewFakeWithChanSize(10, false)
+		w := filter.watch(fake, false)
+		defer w.Stop()
+
+		fake.Add(newPod("a", "Pending"))
+		fake.Modify(newPod("a", "Running"))
+		fake.Modify(newPod("a", "Running"))
+		fake.Modify(newPod("a", "Pending"))
+		fake.Delete(newPod("a", "Pending"))
+		fake.Delete(newPod("listed", "Running"))
+		fake.Action(watch.Bookmark, newPod("", ""))
+
+		expected := []watch.Event{
+			{Type: watch.Modified, Object: newPod("a", "Running")},
+			{Type: watch.Modified, Object: newPod("a", "Running")},
+			{Type: watch.Deleted, Object: newPod("a", "Pending")},
+			{Type: watch.Deleted, Object: newPod("listed", "Running")},
+			{Type: watch.Bookmark, Object: newPod("", "")},
+		}
+		for _, event := range expected {
+			Eventually(w.ResultChan()).Should(Receive(Equal(event)))
+		}
+		Consistently(w.ResultChan()).ShouldNot(Receive())
+	})
+
+	It("should forget the matching objects when a watch sends the initial events", func() {
+		Expect(filter.list(&corev1.PodList{Items: []corev1.Pod{*newPod("listed", "Running")}})).To(Succeed())
+		fake := watch.NewFakeWithChanSize(10, false)
+		w := filter.watch(fake, true)
+		defer w.Stop()
+
+		fake.Delete(newPod("listed", "Running"))
+		fake.Add(newPod("a", "Running"))
+		Eventually(w.ResultChan()).Should(Receive(Equal(watch.Event{Type: watch.Added, Object: newPod("a", "Running")})))
+		Consistently(w.ResultChan()).ShouldNot(Receive())
+	})
+})
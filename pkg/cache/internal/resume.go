@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resumeSaveTimeout bounds saving the state of the informers when they stop.
+const resumeSaveTimeout = 30 * time.Second
+
+// ResumeStore persists the state of informers across restarts, see resumeState.
+type ResumeStore interface {
+	// Load returns the state saved under the given key, or nil if there is none.
+	Load(ctx context.Context, key string) ([]byte, error)
+
+	// Save saves the state under the given key, replacing the previous one.
+	Save(ctx context.Context, key string, state []byte) error
+}
+
+// resumeState is the state of an informer that is saved in a ResumeStore. An informer
+// that finds a state for its key returns it instead of listing the objects from the API
+// server when it syncs initially, and starts watching from its resourceVersion. If the
+// API server doesn't have the changes since then anymore, the informer lists the objects
+// again, as it does after any expired watch.
+type resumeState struct {
+	// Selector identifies the namespace and selectors the objects were listed with, a
+	// state that was saved with other ones is ignored.
+	Selector string `json:"selector"`
+
+	// ResourceVersion is the resourceVersion up to which the changes were applied to the
+	// objects.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Objects are the objects in the store of the informer.
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// resumeKey returns the key the state of the informer of gvk in the given form, i.e.
+// structured, unstructured or metadata, and namespace is saved under.
+func resumeKey(gvk schema.GroupVersionKind, obj runtime.Object, namespace string) string {
+	key := fmt.Sprintf("%s.%s.%s.%s", objectForm(obj), gvk.Kind, gvk.Version, gvk.Group)
+	if namespace != "" {
+		key += "." + namespace
+	}
+	return key
+}
+
+// objectForm returns whether obj is a structured, an unstructured or a metadata object.
+func objectForm(obj runtime.Object) string {
+	switch obj.(type) {
+	case runtime.Unstructured:
+		return "unstructured"
+	case *metav1.PartialObjectMetadata, *metav1.PartialObjectMetadataList:
+		return "metadata"
+	default:
+		return "structured"
+	}
+}
+
+// resumeSelector returns the Selector of a resumeState for the given options of a List.
+func resumeSelector(opts metav1.ListOptions, namespace string) string {
+	return fmt.Sprintf("namespace=%s;labels=%s;fields=%s", namespace, opts.LabelSelector, opts.FieldSelector)
+}
+
+// resumedList loads the state of an informer from the store and returns it as a list, or
+// nil if there is no state for the informer.
+func resumedList(ctx context.Context, store ResumeStore, key, selector string, obj runtime.Object) (*metav1.List, error) {
+	data, err := store.Load(ctx, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	state := &resumeState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Selector != selector || state.ResourceVersion == "" {
+		return nil, nil
+	}
+
+	objType := reflect.TypeOf(obj).Elem()
+	list := &metav1.List{
+		ListMeta: metav1.ListMeta{ResourceVersion: state.ResourceVersion},
+		Items:    make([]runtime.RawExtension, 0, len(state.Objects)),
+	}
+	for _, raw := range state.Objects {
+		item, ok := reflect.New(objType).Interface().(runtime.Object)
+		if !ok {
+			return nil, fmt.Errorf("%T is not an Object", item)
+		}
+		if err := json.Unmarshal(raw, item); err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, runtime.RawExtension{Object: item})
+	}
+	return list, nil
+}
+
+// saveResumeState saves the objects of the informer and the resourceVersion up to which
+// they are current. Nothing is saved if the informer didn't sync or doesn't track the
+// resourceVersion it applied, see AppliedResourceVersion.
+func (c *Cache) saveResumeState(ctx context.Context) error {
+	if c.resume == nil || !c.Informer.HasSynced() {
+		return nil
+	}
+	resourceVersion := c.AppliedResourceVersion()
+	objs := c.Reader.indexer.List()
+	if resourceVersion == "" || c.AppliedResourceVersion() != resourceVersion {
+		return nil
+	}
+
+	state := resumeState{
+		Selector:        c.resume.selector,
+		ResourceVersion: resourceVersion,
+		Objects:         make([]json.RawMessage, 0, len(objs)),
+	}
+	for _, obj := range objs {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		state.Objects = append(state.Objects, raw)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.resume.store.Save(ctx, c.resume.key, data)
+}
+
+// saveResumeStates saves the state of all the informers in this map.
+func (ip *Informers) saveResumeStates(ctx context.Context) {
+	ip.mu.RLock()
+	defer ip.mu.RUnlock()
+
+	for _, informers := range []map[schema.GroupVersionKind]*Cache{ip.tracker.Structured, ip.tracker.Unstructured, ip.tracker.Metadata} {
+		for gvk, i := range informers {
+			if err := i.saveResumeState(ctx); err != nil {
+				log.Error(err, "Failed to save the state of the informer", "gvk", gvk, "namespace", ip.namespace)
+			}
+		}
+	}
+}
+
+// checkpointResumeStates saves the state of all the informers in this map every
+// resumeInterval until the context is done.
+func (ip *Informers) checkpointResumeStates(ctx context.Context) {
+	ticker := time.NewTicker(ip.resumeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ip.saveResumeStates(ctx)
+		}
+	}
+}
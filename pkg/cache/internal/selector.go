@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -26,6 +27,28 @@ import (
 type Selector struct {
 	Label labels.Selector
 	Field fields.Selector
+
+	// ExcludedNamespaces are the namespaces whose objects are excluded. They are only
+	// applied to namespaced objects, as cluster-scoped objects don't support field
+	// selectors for metadata.namespace.
+	ExcludedNamespaces []string
+}
+
+// forScope returns the selector for objects of the given scope, with the excluded
+// namespaces added to the field selector of namespaced objects.
+func (s Selector) forScope(scope meta.RESTScopeName) Selector {
+	if scope != meta.RESTScopeNameNamespace || len(s.ExcludedNamespaces) == 0 {
+		return s
+	}
+	selectors := make([]fields.Selector, 0, len(s.ExcludedNamespaces)+1)
+	if s.Field != nil {
+		selectors = append(selectors, s.Field)
+	}
+	for _, namespace := range s.ExcludedNamespaces {
+		selectors = append(selectors, fields.OneTermNotEqualSelector("metadata.namespace", namespace))
+	}
+	s.Field = fields.AndSelectors(selectors...)
+	return s
 }
 
 // ApplyToList fill in ListOptions LabelSelector and FieldSelector if needed.
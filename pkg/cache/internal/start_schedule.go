@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// StartSchedule spreads the start of informers over time, so that their initial lists
+// don't reach the API server all at once. It is shared by all Informers of a cache.
+type StartSchedule struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewStartSchedule returns a StartSchedule that starts informers at least interval
+// apart. It returns nil if interval isn't positive, which starts informers right away.
+func NewStartSchedule(interval time.Duration) *StartSchedule {
+	if interval <= 0 {
+		return nil
+	}
+	return &StartSchedule{interval: interval}
+}
+
+// delay reserves the next start and returns how long the informer has to wait for it.
+func (s *StartSchedule) delay() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	start := s.next
+	if start.Before(now) {
+		start = now
+	}
+	s.next = start.Add(s.interval)
+	return start.Sub(now)
+}
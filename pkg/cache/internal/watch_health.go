@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchID identifies the watch of an informer. Informers of the same GVK can run in
+// different forms, namespaces or with different selectors, each with its own watch.
+type watchID struct {
+	gvk schema.GroupVersionKind
+	// form is structured, unstructured or metadata.
+	form      string
+	namespace string
+	selector  string
+}
+
+// newWatchID returns the watchID of the informer of gvk in the form of obj that lists
+// and watches namespace with opts.
+func newWatchID(gvk schema.GroupVersionKind, obj runtime.Object, namespace string, opts metav1.ListOptions) watchID {
+	var selector []string
+	if opts.LabelSelector != "" {
+		selector = append(selector, "labels="+opts.LabelSelector)
+	}
+	if opts.FieldSelector != "" {
+		selector = append(selector, "fields="+opts.FieldSelector)
+	}
+	return watchID{gvk: gvk, form: objectForm(obj), namespace: namespace, selector: strings.Join(selector, ";")}
+}
+
+// labels returns the values of the labels of the watch metrics for the watch.
+func (id watchID) labels() []string {
+	return []string{id.gvk.Group, id.gvk.Version, id.gvk.Kind, id.form, id.namespace, id.selector}
+}
+
+// watchHealth keeps the watch metrics of an informer up to date and restarts its
+// watch if it didn't receive any event, including bookmarks, for maxSilence. This
+// guards against watches that silently died, e.g. because the connection to the
+// API server went stale, which would leave the informer without updates.
+//
+// The list or watch of the informer is failing from its first error until its watch
+// receives an event again. A watch that is established but fails again before it
+// receives any event, e.g. because the API server keeps dropping it, keeps failing.
+type watchHealth struct {
+	id watchID
+
+	// maxSilence is the duration without events after which the watch is restarted.
+	// Zero disables restarting silent watches.
+	maxSilence time.Duration
+
+	// lastActivity is the time in unix nanoseconds the watch was last established or
+	// last received an event.
+	lastActivity atomic.Int64
+
+	// failingSince is the time in unix nanoseconds since which the list or watch of
+	// the informer has been failing, zero if it isn't failing.
+	failingSince atomic.Int64
+}
+
+func newWatchHealth(id watchID, maxSilence time.Duration) *watchHealth {
+	return &watchHealth{id: id, maxSilence: maxSilence}
+}
+
+// track returns a watch that passes on the events of w and records them.
+func (h *watchHealth) track(w watch.Interface) watch.Interface {
+	h.lastActivity.Store(time.Now().UnixNano())
+	tw := &trackedWatch{
+		health:   h,
+		incoming: w,
+		result:   make(chan watch.Event),
+		done:     make(chan struct{}),
+	}
+	go tw.loop()
+	return tw
+}
+
+// errorHandler returns a watch error handler that counts the errors of the watch
+// before it passes them on to handler, or to the default handler if it is nil.
+func (h *watchHealth) errorHandler(handler cache.WatchErrorHandlerWithContext) cache.WatchErrorHandlerWithContext {
+	if handler == nil {
+		handler = cache.DefaultWatchErrorHandler
+	}
+	return func(ctx context.Context, r *cache.Reflector, err error) {
+		watchErrors.WithLabelValues(h.id.labels()...).Inc()
+		if h.failingSince.CompareAndSwap(0, time.Now().UnixNano()) {
+			watchFailing.WithLabelValues(h.id.labels()...).Set(1)
+		}
+		handler(ctx, r, err)
+	}
+}
+
+// received records that the watch received an event, which ends a failure.
+func (h *watchHealth) received() {
+	watchLastEvent.WithLabelValues(h.id.labels()...).SetToCurrentTime()
+	h.lastActivity.Store(time.Now().UnixNano())
+	if h.failingSince.Swap(0) != 0 {
+		watchFailing.WithLabelValues(h.id.labels()...).Set(0)
+	}
+}
+
+// stop removes the gauges of the watch from the metrics. It is called once the
+// informer stopped.
+func (h *watchHealth) stop() {
+	watchLastEvent.DeleteLabelValues(h.id.labels()...)
+	watchFailing.DeleteLabelValues(h.id.labels()...)
+}
+
+// status returns when the watch was last established or received an event, and since
+// when it has been failing. Both are zero if they didn't happen.
+func (h *watchHealth) status() (lastActivity, failingSince time.Time) {
+	return unixNanoTime(h.lastActivity.Load()), unixNanoTime(h.failingSince.Load())
+}
+
+func unixNanoTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// trackedWatch passes on the events of the incoming watch. Once the incoming watch
+// is silent for too long, it is stopped and the result channel is closed, so that
+// the reflector of the informer watches again from the last resource version.
+type trackedWatch struct {
+	health   *watchHealth
+	incoming watch.Interface
+	result   chan watch.Event
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// ResultChan implements watch.Interface.
+func (w *trackedWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop implements watch.Interface.
+func (w *trackedWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		w.incoming.Stop()
+	})
+}
+
+func (w *trackedWatch) loop() {
+	defer close(w.result)
+
+	id := w.health.id
+	var (
+		silence *time.Timer
+		silent  <-chan time.Time
+	)
+	if w.health.maxSilence > 0 {
+		silence = time.NewTimer(w.health.maxSilence)
+		defer silence.Stop()
+		silent = silence.C
+	}
+
+	for {
+		select {
+		case event, ok := <-w.incoming.ResultChan():
+			if !ok {
+				return
+			}
+			w.health.received()
+			if silence != nil {
+				silence.Reset(w.health.maxSilence)
+			}
+			select {
+			case w.result <- event:
+			case <-w.done:
+				return
+			}
+		case <-silent:
+			log.Info("Restarting watch that didn't receive any events", "group", id.gvk.Group,
+				"version", id.gvk.Version, "kind", id.gvk.Kind, "form", id.form, "namespace", id.namespace,
+				"selector", id.selector, "silence", w.health.maxSilence)
+			watchRestarts.WithLabelValues(id.labels()...).Inc()
+			w.Stop()
+			return
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+func testWatchID(kind, labelSelector string) watchID {
+	gvk := schema.GroupVersionKind{Group: "watch.example.com", Version: "v1", Kind: kind}
+	return newWatchID(gvk, &corev1.Pod{}, "default", metav1.ListOptions{LabelSelector: labelSelector})
+}
+
+var _ = Describe("watchHealth", func() {
+	It("should pass on the events of the watch and record them", func() {
+		id := testWatchID("Events", "")
+		fake := watch.NewFake()
+		w := newWatchHealth(id, 0).track(fake)
+		defer w.Stop()
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}
+		go fake.Add(pod)
+		Eventually(w.ResultChan()).Should(Receive(Equal(watch.Event{Type: watch.Added, Object: pod})))
+		Expect(testutil.ToFloat64(watchLastEvent.WithLabelValues(id.labels()...))).
+			To(BeNumerically("~", float64(time.Now().Unix()), 5))
+	})
+
+	It("should restart watches that are silent for too long", func() {
+		id := testWatchID("Silent", "")
+		fake := watch.NewFake()
+		w := newWatchHealth(id, 100*time.Millisecond).track(fake)
+
+		Eventually(w.ResultChan()).Should(BeClosed())
+		Expect(fake.IsStopped()).To(BeTrue())
+		Expect(testutil.ToFloat64(watchRestarts.WithLabelValues(id.labels()...))).To(Equal(1.0))
+	})
+
+	It("should close the result channel once stopped", func() {
+		id := testWatchID("Stopped", "")
+		fake := watch.NewFake()
+		w := newWatchHealth(id, time.Hour).track(fake)
+
+		w.Stop()
+		w.Stop()
+		Eventually(w.ResultChan()).Should(BeClosed())
+		Expect(fake.IsStopped()).To(BeTrue())
+		Expect(testutil.ToFloat64(watchRestarts.WithLabelValues(id.labels()...))).To(BeZero())
+	})
+
+	It("should count watch errors and pass them on", func(ctx SpecContext) {
+		id := testWatchID("Errors", "")
+		var handled []error
+		handler := newWatchHealth(id, 0).errorHandler(func(_ context.Context, _ *cache.Reflector, err error) {
+			handled = append(handled, err)
+		})
+
+		expectedErr := errors.New("expected error")
+		handler(ctx, nil, expectedErr)
+		Expect(handled).To(ConsistOf(expectedErr))
+		Expect(testutil.ToFloat64(watchErrors.WithLabelValues(id.labels()...))).To(Equal(1.0))
+	})
+
+	It("should record the activity of the watch and since when it is failing", func(ctx SpecContext) {
+		id := testWatchID("Status", "")
+		health := newWatchHealth(id, 0)
+		lastActivity, failingSince := health.status()
+		Expect(lastActivity.IsZero()).To(BeTrue())
+		Expect(failingSince.IsZero()).To(BeTrue())
+
+		handler := health.errorHandler(func(context.Context, *cache.Reflector, error) {})
+		handler(ctx, nil, errors.New("expected error"))
+		_, failingSince = health.status()
+		Expect(failingSince).To(BeTemporally("~", time.Now(), 5*time.Second))
+		handler(ctx, nil, errors.New("expected error"))
+		_, stillFailingSince := health.status()
+		Expect(stillFailingSince).To(Equal(failingSince))
+
+		By("keeping the failure while the watch is established without events")
+		fake := watch.NewFake()
+		w := health.track(fake)
+		defer w.Stop()
+		lastActivity, failingSince = health.status()
+		Expect(failingSince).To(Equal(stillFailingSince))
+		Expect(lastActivity).To(BeTemporally("~", time.Now(), 5*time.Second))
+		Expect(testutil.ToFloat64(watchFailing.WithLabelValues(id.labels()...))).To(Equal(1.0))
+
+		By("clearing the failure once the watch receives an event")
+		go fake.Add(&corev1.Pod{})
+		Eventually(w.ResultChan()).Should(Receive())
+		Eventually(func() time.Time {
+			_, failingSince := health.status()
+			return failingSince
+		}).Should(BeZero())
+		Expect(testutil.ToFloat64(watchFailing.WithLabelValues(id.labels()...))).To(BeZero())
+		lastActivity, _ = health.status()
+		Expect(lastActivity).To(BeTemporally("~", time.Now(), 5*time.Second))
+	})
+
+	It("should keep the metrics of watches of the same GVK apart", func(ctx SpecContext) {
+		labeled := newWatchHealth(testWatchID("Selectors", "app=a"), 0)
+		unlabeled := newWatchHealth(testWatchID("Selectors", ""), 0)
+
+		labeled.errorHandler(func(context.Context, *cache.Reflector, error) {})(ctx, nil, errors.New("expected error"))
+		Expect(testutil.ToFloat64(watchErrors.WithLabelValues(labeled.id.labels()...))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(watchFailing.WithLabelValues(labeled.id.labels()...))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(watchErrors.WithLabelValues(unlabeled.id.labels()...))).To(BeZero())
+		_, failingSince := unlabeled.status()
+		Expect(failingSince.IsZero()).To(BeTrue())
+
+		By("removing the gauges of a stopped watch")
+		labeled.stop()
+		Expect(watchFailing.DeleteLabelValues(labeled.id.labels()...)).To(BeFalse())
+	})
+})
@@ -18,8 +18,12 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -28,7 +32,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
@@ -42,6 +48,8 @@ func newMultiNamespaceCache(
 	restMapper apimeta.RESTMapper,
 	namespaces map[string]Config,
 	globalConfig *Config, // may be nil in which case no cache for cluster-scoped objects will be created
+	namespaceDefaults Config,
+	defaultSortBy client.SortBy,
 ) Cache {
 	// Create every namespace cache.
 	caches := map[string]Cache{}
@@ -56,10 +64,15 @@ func newMultiNamespaceCache(
 	}
 
 	return &multiNamespaceCache{
-		namespaceToCache: caches,
-		Scheme:           scheme,
-		RESTMapper:       restMapper,
-		clusterCache:     clusterCache,
+		namespaceToCache:  caches,
+		Scheme:            scheme,
+		RESTMapper:        restMapper,
+		clusterCache:      clusterCache,
+		newCache:          newCache,
+		namespaceDefaults: namespaceDefaults,
+		defaultSortBy:     defaultSortBy,
+		running:           map[string]*namespaceRun{},
+		informers:         map[snapshotKey]*multiNamespaceInformer{},
 	}
 }
 
@@ -68,13 +81,56 @@ func newMultiNamespaceCache(
 // operator to a list of namespaces instead of watching every namespace
 // in the cluster.
 type multiNamespaceCache struct {
-	Scheme           *runtime.Scheme
-	RESTMapper       apimeta.RESTMapper
+	Scheme       *runtime.Scheme
+	RESTMapper   apimeta.RESTMapper
+	clusterCache Cache
+
+	// newCache and namespaceDefaults are used to create the caches of namespaces
+	// that are added at runtime, see addNamespace.
+	newCache          newCacheFunc
+	namespaceDefaults Config
+
+	// defaultSortBy sorts the merged objects of Lists without a SortBy option.
+	defaultSortBy client.SortBy
+
+	// mu guards the fields below, which change when namespaces are added or removed.
+	mu               sync.RWMutex
 	namespaceToCache map[string]Cache
-	clusterCache     Cache
+	// ctx is the context the cache was started with, it is nil until then.
+	ctx     context.Context
+	errs    chan error
+	running map[string]*namespaceRun
+	// informers holds the informers that were handed out, so that the informers of
+	// namespaces that are added at runtime can be added to them.
+	informers map[snapshotKey]*multiNamespaceInformer
+	// indexes holds the field indexes, so that they can be added to the caches of
+	// namespaces that are added at runtime.
+	indexes []fieldIndex
+}
+
+// namespaceRun is the running cache of a namespace.
+type namespaceRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type fieldIndex struct {
+	obj          client.Object
+	field        string
+	extractValue client.IndexerFunc
 }
 
-var _ Cache = &multiNamespaceCache{}
+var (
+	_ Cache           = &multiNamespaceCache{}
+	_ namespaceSetter = &multiNamespaceCache{}
+)
+
+// caches returns the caches of the namespaces that are currently cached.
+func (c *multiNamespaceCache) caches() map[string]Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return maps.Clone(c.namespaceToCache)
+}
 
 // Methods for multiNamespaceCache to conform to the Informers interface.
 
@@ -96,16 +152,56 @@ func (c *multiNamespaceCache) GetInformer(ctx context.Context, obj client.Object
 		}), nil
 	}
 
-	namespaceToInformer := map[string]Informer{}
-	for ns, cache := range c.namespaceToCache {
-		informer, err := cache.GetInformer(ctx, obj, opts...)
-		if err != nil {
-			return nil, err
+	key, err := snapshotKeyFor(obj, c.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.getNamespacedInformer(ctx, key, func(ctx context.Context, cache Cache, opts ...InformerGetOption) (Informer, error) {
+		return cache.GetInformer(ctx, obj, opts...)
+	}, opts...)
+}
+
+// getNamespacedInformer returns the informer for the key, which is created with get from
+// the cache of every namespace, also of namespaces that are added later on.
+func (c *multiNamespaceCache) getNamespacedInformer(
+	ctx context.Context,
+	key snapshotKey,
+	get func(ctx context.Context, cache Cache, opts ...InformerGetOption) (Informer, error),
+	opts ...InformerGetOption,
+) (Informer, error) {
+	// Don't block on the informers while holding the lock, wait for them afterwards.
+	informer, caches, err := func() (*multiNamespaceInformer, map[string]Cache, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if informer, ok := c.informers[key]; ok {
+			return informer, maps.Clone(c.namespaceToCache), nil
 		}
-		namespaceToInformer[ns] = informer
+
+		namespaceToInformer := map[string]Informer{}
+		for ns, cache := range c.namespaceToCache {
+			informer, err := get(ctx, cache, BlockUntilSynced(false))
+			if err != nil {
+				return nil, nil, err
+			}
+			namespaceToInformer[ns] = informer
+		}
+		informer := newMultiNamespaceInformer(namespaceToInformer)
+		informer.get = get
+		c.informers[key] = informer
+		return informer, maps.Clone(c.namespaceToCache), nil
+	}()
+	if err != nil {
+		return nil, err
 	}
 
-	return newMultiNamespaceInformer(namespaceToInformer), nil
+	if ptr.Deref(applyGetOptions(opts...).BlockUntilSynced, true) {
+		for _, cache := range caches {
+			if _, err := get(ctx, cache, opts...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return informer, nil
 }
 
 func (c *multiNamespaceCache) RemoveInformer(ctx context.Context, obj client.Object) error {
@@ -119,6 +215,13 @@ func (c *multiNamespaceCache) RemoveInformer(ctx context.Context, obj client.Obj
 		return c.clusterCache.RemoveInformer(ctx, obj)
 	}
 
+	key, err := snapshotKeyFor(obj, c.Scheme)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.informers, key)
 	for _, cache := range c.namespaceToCache {
 		err := cache.RemoveInformer(ctx, obj)
 		if err != nil {
@@ -147,38 +250,36 @@ func (c *multiNamespaceCache) GetInformerForKind(ctx context.Context, gvk schema
 		}), nil
 	}
 
-	namespaceToInformer := map[string]Informer{}
-	for ns, cache := range c.namespaceToCache {
-		informer, err := cache.GetInformerForKind(ctx, gvk, opts...)
-		if err != nil {
-			return nil, err
-		}
-		namespaceToInformer[ns] = informer
-	}
-
-	return newMultiNamespaceInformer(namespaceToInformer), nil
+	key := snapshotKey{gvk: gvk, kind: snapshotKindStructured}
+	return c.getNamespacedInformer(ctx, key, func(ctx context.Context, cache Cache, opts ...InformerGetOption) (Informer, error) {
+		return cache.GetInformerForKind(ctx, gvk, opts...)
+	}, opts...)
 }
 
 func (c *multiNamespaceCache) Start(ctx context.Context) error {
-	errs := make(chan error)
+	c.mu.Lock()
+	c.ctx = ctx
+	c.errs = make(chan error)
+	errs := c.errs
 	// start global cache
 	if c.clusterCache != nil {
 		go func() {
 			err := c.clusterCache.Start(ctx)
 			if err != nil {
-				errs <- fmt.Errorf("failed to start cluster-scoped cache: %w", err)
+				select {
+				case errs <- fmt.Errorf("failed to start cluster-scoped cache: %w", err):
+				case <-ctx.Done():
+				}
 			}
 		}()
 	}
 
 	// start namespaced caches
 	for ns, cache := range c.namespaceToCache {
-		go func(ns string, cache Cache) {
-			if err := cache.Start(ctx); err != nil {
-				errs <- fmt.Errorf("failed to start cache for namespace %s: %w", ns, err)
-			}
-		}(ns, cache)
+		c.startNamespaceLocked(ns, cache)
 	}
+	c.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return nil
@@ -187,9 +288,137 @@ func (c *multiNamespaceCache) Start(ctx context.Context) error {
 	}
 }
 
+// startNamespaceLocked starts the cache of a namespace with a context that is cancelled
+// when the namespace is removed.
+func (c *multiNamespaceCache) startNamespaceLocked(ns string, cache Cache) {
+	ctx, cancel := context.WithCancel(c.ctx)
+	run := &namespaceRun{cancel: cancel, done: make(chan struct{})}
+	c.running[ns] = run
+	errs := c.errs
+	go func() {
+		defer close(run.done)
+		if err := cache.Start(ctx); err != nil {
+			select {
+			case errs <- fmt.Errorf("failed to start cache for namespace %s: %w", ns, err):
+			case <-ctx.Done():
+			}
+		}
+	}()
+}
+
+func (c *multiNamespaceCache) addNamespace(ctx context.Context, namespace string, config Config) error {
+	if namespace == metav1.NamespaceAll {
+		return errors.New("can not add all namespaces, only specific namespaces can be added")
+	}
+
+	cache, err := func() (Cache, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if _, ok := c.namespaceToCache[namespace]; ok {
+			return nil, fmt.Errorf("namespace %s is already cached", namespace)
+		}
+		if _, ok := c.namespaceToCache[metav1.NamespaceAll]; ok {
+			return nil, errors.New("namespaces can not be added to a cache that caches all namespaces")
+		}
+
+		cache := c.newCache(defaultConfig(config, c.namespaceDefaults), namespace)
+		for _, index := range c.indexes {
+			if err := cache.IndexField(ctx, index.obj, index.field, index.extractValue); err != nil {
+				return nil, fmt.Errorf("failed to index field %s: %w", index.field, err)
+			}
+		}
+		var added []*multiNamespaceInformer
+		for _, informer := range c.informers {
+			nsInformer, err := informer.get(ctx, cache, BlockUntilSynced(false))
+			if err == nil {
+				err = informer.addNamespace(namespace, nsInformer)
+			}
+			if err != nil {
+				// Roll back, so that adding the namespace can be retried.
+				for _, informer := range added {
+					informer.removeNamespace(namespace)
+				}
+				return nil, err
+			}
+			added = append(added, informer)
+		}
+		c.namespaceToCache[namespace] = cache
+		if c.ctx != nil {
+			c.startNamespaceLocked(namespace, cache)
+		}
+		return cache, nil
+	}()
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	started := c.ctx != nil
+	c.mu.RUnlock()
+	if started && !cache.WaitForCacheSync(ctx) {
+		// Roll back, so that adding the namespace can be retried. The cache of the namespace
+		// is stopped in the background when ctx is already done.
+		_ = c.removeNamespace(ctx, namespace)
+		return fmt.Errorf("failed waiting for the cache of namespace %s to sync", namespace)
+	}
+	return nil
+}
+
+func (c *multiNamespaceCache) removeNamespace(ctx context.Context, namespace string) error {
+	c.mu.Lock()
+	if _, ok := c.namespaceToCache[namespace]; !ok {
+		c.mu.Unlock()
+		return fmt.Errorf("namespace %s is not cached", namespace)
+	}
+	delete(c.namespaceToCache, namespace)
+	for _, informer := range c.informers {
+		informer.removeNamespace(namespace)
+	}
+	run := c.running[namespace]
+	delete(c.running, namespace)
+	c.mu.Unlock()
+
+	if run == nil {
+		return nil
+	}
+	run.cancel()
+	select {
+	case <-run.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed waiting for the cache of namespace %s to stop: %w", namespace, ctx.Err())
+	}
+}
+
+func (c *multiNamespaceCache) contents() []internal.InformerContents {
+	var contents []internal.InformerContents
+	for _, cache := range c.caches() {
+		if d, ok := cache.(contentsDumper); ok {
+			contents = append(contents, d.contents()...)
+		}
+	}
+	if d, ok := c.clusterCache.(contentsDumper); ok {
+		contents = append(contents, d.contents()...)
+	}
+	return contents
+}
+
+func (c *multiNamespaceCache) syncStatuses() []InformerSyncStatus {
+	var statuses []InformerSyncStatus
+	for _, cache := range c.caches() {
+		if s, ok := cache.(syncStatuser); ok {
+			statuses = append(statuses, s.syncStatuses()...)
+		}
+	}
+	if s, ok := c.clusterCache.(syncStatuser); ok {
+		statuses = append(statuses, s.syncStatuses()...)
+	}
+	return statuses
+}
+
 func (c *multiNamespaceCache) WaitForCacheSync(ctx context.Context) bool {
 	synced := true
-	for _, cache := range c.namespaceToCache {
+	for _, cache := range c.caches() {
 		if !cache.WaitForCacheSync(ctx) {
 			synced = false
 		}
@@ -212,11 +441,14 @@ func (c *multiNamespaceCache) IndexField(ctx context.Context, obj client.Object,
 		return c.clusterCache.IndexField(ctx, obj, field, extractValue)
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	for _, cache := range c.namespaceToCache {
 		if err := cache.IndexField(ctx, obj, field, extractValue); err != nil {
 			return err
 		}
 	}
+	c.indexes = append(c.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
 	return nil
 }
 
@@ -231,9 +463,10 @@ func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj
 		return c.clusterCache.Get(ctx, key, obj)
 	}
 
-	cache, ok := c.namespaceToCache[key.Namespace]
+	caches := c.caches()
+	cache, ok := caches[key.Namespace]
 	if !ok {
-		if global, hasGlobal := c.namespaceToCache[metav1.NamespaceAll]; hasGlobal {
+		if global, hasGlobal := caches[metav1.NamespaceAll]; hasGlobal {
 			return global.Get(ctx, key, obj, opts...)
 		}
 		return fmt.Errorf("unable to get: %v because of unknown namespace for the cache", key)
@@ -243,12 +476,16 @@ func (c *multiNamespaceCache) Get(ctx context.Context, key client.ObjectKey, obj
 
 // List multi namespace cache will get all the objects in the namespaces that the cache is watching if asked for all namespaces.
 func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.defaultSortBy != nil {
+		opts = append([]client.ListOption{c.defaultSortBy}, opts...)
+	}
 	listOpts := client.ListOptions{}
 	listOpts.ApplyOptions(opts)
 
 	if listOpts.Continue != "" {
 		return fmt.Errorf("continue list option is not supported by the cache")
 	}
+	internal.DefaultPageSortBy(&listOpts)
 
 	isNamespaced, err := apiutil.IsObjectNamespaced(list, c.Scheme, c.RESTMapper)
 	if err != nil {
@@ -260,10 +497,11 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 		return c.clusterCache.List(ctx, list, opts...)
 	}
 
+	caches := c.caches()
 	if listOpts.Namespace != corev1.NamespaceAll {
-		cache, ok := c.namespaceToCache[listOpts.Namespace]
+		cache, ok := caches[listOpts.Namespace]
 		if !ok {
-			if global, hasGlobal := c.namespaceToCache[AllNamespaces]; hasGlobal {
+			if global, hasGlobal := caches[AllNamespaces]; hasGlobal {
 				return global.List(ctx, list, opts...)
 			}
 			return fmt.Errorf("unable to list: %v because of unknown namespace for the cache", listOpts.Namespace)
@@ -278,10 +516,17 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 
 	allItems := []runtime.Object{}
 
-	limitSet := listOpts.Limit > 0
+	// The offset applies to the merged objects of all namespaces, so every namespace
+	// is listed without it but with a limit large enough to fill the requested page.
+	offset, limit := listOpts.Offset, listOpts.Limit
+	listOpts.Offset = 0
+	if limit > 0 {
+		listOpts.Limit = offset + limit
+	}
+	// Pages are always sorted, so every namespace may contribute all objects of the page.
 
 	var resourceVersion string
-	for _, cache := range c.namespaceToCache {
+	for _, cache := range caches {
 		listObj := list.DeepCopyObject().(client.ObjectList)
 		err = cache.List(ctx, listObj, &listOpts)
 		if err != nil {
@@ -299,21 +544,16 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 
 		// The last list call should have the most correct resource version.
 		resourceVersion = accessor.GetResourceVersion()
-		if limitSet {
-			// decrement Limit by the number of items
-			// fetched from the current namespace.
-			listOpts.Limit -= int64(len(items))
-
-			// if a Limit was set and the number of
-			// items read has reached this set limit,
-			// then stop reading.
-			if listOpts.Limit == 0 {
-				break
-			}
-		}
 	}
 	listAccessor.SetResourceVersion(resourceVersion)
 
+	if listOpts.SortBy != nil {
+		slices.SortStableFunc(allItems, func(a, b runtime.Object) int {
+			return listOpts.SortBy(a.(client.Object), b.(client.Object))
+		})
+	}
+	allItems = internal.Page(allItems, offset, limit)
+
 	if err := apimeta.SetList(list, allItems); err != nil {
 		return err
 	}
@@ -322,33 +562,140 @@ func (c *multiNamespaceCache) List(ctx context.Context, list client.ObjectList,
 	return nil
 }
 
-func newMultiNamespaceInformer(namespaceToInformer map[string]Informer) Informer {
+func newMultiNamespaceInformer(namespaceToInformer map[string]Informer) *multiNamespaceInformer {
 	mni := &multiNamespaceInformer{
 		synced:              make(chan struct{}),
+		stopped:             make(chan struct{}),
 		namespaceToInformer: namespaceToInformer,
 	}
 	go func() {
-		for _, informer := range mni.namespaceToInformer {
+		for _, informer := range mni.informers() {
 			<-informer.HasSyncedChecker().Done()
 		}
 		close(mni.synced)
 	}()
+	go mni.notifyStopped()
 	return mni
 }
 
+// notifyStopped closes the stopped channel once the informers of all namespaces that
+// are cached at that time stopped. It gives up if one of them doesn't notify when it
+// stopped.
+func (i *multiNamespaceInformer) notifyStopped() {
+	for {
+		informers := i.informers()
+		if len(informers) == 0 {
+			return
+		}
+		for _, informer := range informers {
+			notifier, ok := informer.(StoppedNotifier)
+			if !ok {
+				return
+			}
+			<-notifier.Stopped()
+		}
+		// The informers of removed namespaces stop as well, so only the informers
+		// of the namespaces that are still cached count.
+		if i.IsStopped() {
+			close(i.stopped)
+			return
+		}
+	}
+}
+
 // multiNamespaceInformer knows how to handle interacting with the underlying informer across multiple namespaces.
 type multiNamespaceInformer struct {
-	synced              chan struct{}
+	synced chan struct{}
+	// stopped is closed once the informers of all namespaces stopped.
+	stopped chan struct{}
+
+	// get gets the informer of a namespace that is added later on from its cache.
+	get func(ctx context.Context, cache Cache, opts ...InformerGetOption) (Informer, error)
+
+	// mu guards the fields below, which change when namespaces are added or removed.
+	mu                  sync.RWMutex
 	namespaceToInformer map[string]Informer
+	// registrations and indexers are added to the informers of namespaces that are
+	// added later on.
+	registrations []*multiNamespaceInformerHandlerRegistration
+	indexers      toolscache.Indexers
+}
+
+// informers returns the informers of the namespaces that are currently cached.
+func (i *multiNamespaceInformer) informers() map[string]Informer {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return maps.Clone(i.namespaceToInformer)
+}
+
+// addNamespace adds the informer of a namespace with all event handlers and indexers
+// that were added to the informer before.
+func (i *multiNamespaceInformer) addNamespace(ns string, informer Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if len(i.indexers) > 0 {
+		if err := informer.AddIndexers(i.indexers); err != nil {
+			return err
+		}
+	}
+	for _, registration := range i.registrations {
+		handle, err := registration.add(informer)
+		if err != nil {
+			return err
+		}
+		registration.setHandle(ns, handle)
+	}
+	i.namespaceToInformer[ns] = informer
+	return nil
+}
+
+// removeNamespace removes the informer of a namespace and the event handlers that were
+// added to it.
+func (i *multiNamespaceInformer) removeNamespace(ns string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	informer, ok := i.namespaceToInformer[ns]
+	if !ok {
+		return
+	}
+	for _, registration := range i.registrations {
+		if handle := registration.removeHandle(ns); handle != nil {
+			// The informer is stopped with the cache of the namespace anyway.
+			_ = informer.RemoveEventHandler(handle)
+		}
+	}
+	delete(i.namespaceToInformer, ns)
+}
+
+// addEventHandler adds a handler to the informer of every namespace with add.
+func (i *multiNamespaceInformer) addEventHandler(add func(Informer) (toolscache.ResourceEventHandlerRegistration, error)) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	handles := make(map[string]toolscache.ResourceEventHandlerRegistration, len(i.namespaceToInformer))
+	for ns, informer := range i.namespaceToInformer {
+		registration, err := add(informer)
+		if err != nil {
+			return nil, err
+		}
+		handles[ns] = registration
+	}
+
+	registration := newMultiNamespaceInformerHandlerRegistration(handles)
+	registration.add = add
+	i.registrations = append(i.registrations, registration)
+	return registration, nil
 }
 
-func newMultiNamespaceInformerHandlerRegistration(handles map[string]toolscache.ResourceEventHandlerRegistration) toolscache.ResourceEventHandlerRegistration {
+func newMultiNamespaceInformerHandlerRegistration(handles map[string]toolscache.ResourceEventHandlerRegistration) *multiNamespaceInformerHandlerRegistration {
 	hr := &multiNamespaceInformerHandlerRegistration{
 		synced:  make(chan struct{}),
 		handles: handles,
 	}
 	go func() {
-		for _, handle := range hr.handles {
+		for _, handle := range hr.currentHandles() {
 			<-handle.HasSyncedChecker().Done()
 		}
 		close(hr.synced)
@@ -357,13 +704,39 @@ func newMultiNamespaceInformerHandlerRegistration(handles map[string]toolscache.
 }
 
 type multiNamespaceInformerHandlerRegistration struct {
-	synced  chan struct{}
+	synced chan struct{}
+
+	// add adds the handler to the informer of a namespace that is added later on.
+	add func(Informer) (toolscache.ResourceEventHandlerRegistration, error)
+
+	// mu guards handles, which change when namespaces are added or removed.
+	mu      sync.RWMutex
 	handles map[string]toolscache.ResourceEventHandlerRegistration
 }
 
+func (h *multiNamespaceInformerHandlerRegistration) setHandle(ns string, handle toolscache.ResourceEventHandlerRegistration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handles[ns] = handle
+}
+
+func (h *multiNamespaceInformerHandlerRegistration) removeHandle(ns string) toolscache.ResourceEventHandlerRegistration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	handle := h.handles[ns]
+	delete(h.handles, ns)
+	return handle
+}
+
+func (h *multiNamespaceInformerHandlerRegistration) currentHandles() map[string]toolscache.ResourceEventHandlerRegistration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return maps.Clone(h.handles)
+}
+
 // HasSynced asserts that the handler has been called for the full initial state of the informer.
 func (h *multiNamespaceInformerHandlerRegistration) HasSynced() bool {
-	for _, h := range h.handles {
+	for _, h := range h.currentHandles() {
 		if !h.HasSynced() {
 			return false
 		}
@@ -376,8 +749,9 @@ func (h *multiNamespaceInformerHandlerRegistration) HasSyncedChecker() toolscach
 }
 
 func (h *multiNamespaceInformerHandlerRegistration) Name() string {
-	names := make([]string, 0, len(h.handles))
-	for ns, handle := range h.handles {
+	handles := h.currentHandles()
+	names := make([]string, 0, len(handles))
+	for ns, handle := range handles {
 		names = append(names, fmt.Sprintf("%s: %s", ns, handle.HasSyncedChecker().Name()))
 	}
 	return strings.Join(names, ", ")
@@ -387,51 +761,30 @@ func (h *multiNamespaceInformerHandlerRegistration) Done() <-chan struct{} {
 	return h.synced
 }
 
-var _ Informer = &multiNamespaceInformer{}
+var (
+	_ Informer        = &multiNamespaceInformer{}
+	_ StoppedNotifier = &multiNamespaceInformer{}
+)
 
 // AddEventHandler adds the handler to each informer.
 func (i *multiNamespaceInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
-	handles := make(map[string]toolscache.ResourceEventHandlerRegistration, len(i.namespaceToInformer))
-
-	for ns, informer := range i.namespaceToInformer {
-		registration, err := informer.AddEventHandler(handler)
-		if err != nil {
-			return nil, err
-		}
-		handles[ns] = registration
-	}
-
-	return newMultiNamespaceInformerHandlerRegistration(handles), nil
+	return i.addEventHandler(func(informer Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return informer.AddEventHandler(handler)
+	})
 }
 
 // AddEventHandlerWithResyncPeriod adds the handler with a resync period to each namespaced informer.
 func (i *multiNamespaceInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
-	handles := make(map[string]toolscache.ResourceEventHandlerRegistration, len(i.namespaceToInformer))
-
-	for ns, informer := range i.namespaceToInformer {
-		registration, err := informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
-		if err != nil {
-			return nil, err
-		}
-		handles[ns] = registration
-	}
-
-	return newMultiNamespaceInformerHandlerRegistration(handles), nil
+	return i.addEventHandler(func(informer Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	})
 }
 
 // AddEventHandlerWithOptions adds the handler with options to each namespaced informer.
 func (i *multiNamespaceInformer) AddEventHandlerWithOptions(handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
-	handles := make(map[string]toolscache.ResourceEventHandlerRegistration, len(i.namespaceToInformer))
-
-	for ns, informer := range i.namespaceToInformer {
-		registration, err := informer.AddEventHandlerWithOptions(handler, options)
-		if err != nil {
-			return nil, err
-		}
-		handles[ns] = registration
-	}
-
-	return newMultiNamespaceInformerHandlerRegistration(handles), nil
+	return i.addEventHandler(func(informer Informer) (toolscache.ResourceEventHandlerRegistration, error) {
+		return informer.AddEventHandlerWithOptions(handler, options)
+	})
 }
 
 // RemoveEventHandler removes a previously added event handler given by its registration handle.
@@ -440,8 +793,15 @@ func (i *multiNamespaceInformer) RemoveEventHandler(h toolscache.ResourceEventHa
 	if !ok {
 		return fmt.Errorf("registration is not a registration returned by multiNamespaceInformer")
 	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.registrations = slices.DeleteFunc(i.registrations, func(r *multiNamespaceInformerHandlerRegistration) bool {
+		return r == handles
+	})
+	current := handles.currentHandles()
 	for ns, informer := range i.namespaceToInformer {
-		registration, ok := handles.handles[ns]
+		registration, ok := current[ns]
 		if !ok {
 			continue
 		}
@@ -454,18 +814,25 @@ func (i *multiNamespaceInformer) RemoveEventHandler(h toolscache.ResourceEventHa
 
 // AddIndexers adds the indexers to each informer.
 func (i *multiNamespaceInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
 	for _, informer := range i.namespaceToInformer {
 		err := informer.AddIndexers(indexers)
 		if err != nil {
 			return err
 		}
 	}
+	if i.indexers == nil {
+		i.indexers = toolscache.Indexers{}
+	}
+	maps.Copy(i.indexers, indexers)
 	return nil
 }
 
 // HasSynced checks if each informer has synced.
 func (i *multiNamespaceInformer) HasSynced() bool {
-	for _, informer := range i.namespaceToInformer {
+	for _, informer := range i.informers() {
 		if !informer.HasSynced() {
 			return false
 		}
@@ -479,8 +846,9 @@ func (i *multiNamespaceInformer) HasSyncedChecker() toolscache.DoneChecker {
 }
 
 func (i *multiNamespaceInformer) Name() string {
-	names := make([]string, 0, len(i.namespaceToInformer))
-	for ns, informer := range i.namespaceToInformer {
+	informers := i.informers()
+	names := make([]string, 0, len(informers))
+	for ns, informer := range informers {
 		names = append(names, fmt.Sprintf("%s: %s", ns, informer.HasSyncedChecker().Name()))
 	}
 	return strings.Join(names, ", ")
@@ -490,9 +858,15 @@ func (i *multiNamespaceInformer) Done() <-chan struct{} {
 	return i.synced
 }
 
+// Stopped implements StoppedNotifier. The returned channel is closed once the informers
+// of all namespaces stopped.
+func (i *multiNamespaceInformer) Stopped() <-chan struct{} {
+	return i.stopped
+}
+
 // IsStopped checks if each namespaced informer has stopped, returns false if any are still running.
 func (i *multiNamespaceInformer) IsStopped() bool {
-	for _, informer := range i.namespaceToInformer {
+	for _, informer := range i.informers() {
 		if stopped := informer.IsStopped(); !stopped {
 			return false
 		}
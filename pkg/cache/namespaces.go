@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+)
+
+// namespaceSetter is implemented by caches whose namespaces can be changed at runtime.
+type namespaceSetter interface {
+	addNamespace(ctx context.Context, namespace string, config Config) error
+	removeNamespace(ctx context.Context, namespace string) error
+}
+
+// AddNamespace adds a namespace to the DefaultNamespaces of a cache created with New.
+// Unset fields of the config are defaulted like the ones of DefaultNamespaces. The
+// namespace is also added to the types whose ByObject.Namespaces are unset, while types
+// with explicit ByObject.Namespaces are not affected.
+//
+// The informers of the namespace are started for every informer that was requested
+// from the cache so far, including its event handlers and indexes. If the cache was
+// started, AddNamespace blocks until they are synced or ctx is done.
+//
+// Namespaces can not be added to a cache that caches all namespaces, i.e. one without
+// DefaultNamespaces or whose DefaultNamespaces include metav1.NamespaceAll.
+//
+// If AddNamespace fails, the namespace is not added to any of the caches, so it can be
+// retried.
+func AddNamespace(ctx context.Context, c Cache, namespace string, config Config) error {
+	s, ok := c.(namespaceSetter)
+	if !ok {
+		return fmt.Errorf("cache %T does not support adding namespaces", c)
+	}
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+	return s.addNamespace(ctx, namespace, config)
+}
+
+// RemoveNamespace removes a namespace that was configured in DefaultNamespaces or added
+// with AddNamespace from a cache created with New. Its informers are stopped and the
+// event handlers of the informers of the cache stop receiving events for it. It blocks
+// until the informers are stopped or ctx is done.
+//
+// The namespace is removed from every cache even if RemoveNamespace returns an error.
+func RemoveNamespace(ctx context.Context, c Cache, namespace string) error {
+	s, ok := c.(namespaceSetter)
+	if !ok {
+		return fmt.Errorf("cache %T does not support removing namespaces", c)
+	}
+	return s.removeNamespace(ctx, namespace)
+}
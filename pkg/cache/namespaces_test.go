@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AddNamespace and RemoveNamespace", func() {
+	var (
+		server  *namespacedConfigMapServer
+		mapper  apimeta.RESTMapper
+		restCfg *rest.Config
+	)
+
+	BeforeEach(func() {
+		server = &namespacedConfigMapServer{watches: map[string]int{}}
+		httpServer := httptest.NewServer(server)
+		DeferCleanup(httpServer.Close)
+		restCfg = &rest.Config{Host: httpServer.URL}
+
+		defaultMapper := apimeta.NewDefaultRESTMapper(nil)
+		defaultMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+		defaultMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMapList"), apimeta.RESTScopeNamespace)
+		mapper = defaultMapper
+	})
+
+	start := func(c cache.Cache) {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(c.Start(ctx)).To(Succeed())
+		}()
+	}
+
+	It("should start and stop the informers of namespaces at runtime", func(ctx SpecContext) {
+		c, err := cache.New(restCfg, cache.Options{
+			Mapper:            mapper,
+			DefaultNamespaces: map[string]cache.Config{"a": {}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.IndexField(ctx, &corev1.ConfigMap{}, "name", func(obj client.Object) []string {
+			return []string{obj.GetName()}
+		})).To(Succeed())
+
+		informer, err := c.GetInformer(ctx, &corev1.ConfigMap{}, cache.BlockUntilSynced(false))
+		Expect(err).NotTo(HaveOccurred())
+		var mu sync.Mutex
+		var added []string
+		_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj any) {
+				mu.Lock()
+				defer mu.Unlock()
+				added = append(added, obj.(*corev1.ConfigMap).Namespace)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		addedNamespaces := func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), added...)
+		}
+
+		start(c)
+		Expect(c.WaitForCacheSync(ctx)).To(BeTrue())
+		Eventually(addedNamespaces).Should(ConsistOf("a"))
+
+		By("adding a namespace")
+		Expect(cache.AddNamespace(ctx, c, "b", cache.Config{})).To(Succeed())
+		Expect(addedNamespaces()).To(ConsistOf("a", "b"))
+		Expect(server.activeWatches("b")).To(Equal(1))
+
+		configMaps := &corev1.ConfigMapList{}
+		Expect(c.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(2))
+		Expect(c.List(ctx, configMaps, client.InNamespace("b"), client.MatchingFields{"name": "config"})).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(1))
+
+		By("removing the namespace again")
+		Expect(cache.RemoveNamespace(ctx, c, "b")).To(Succeed())
+		Eventually(func() int { return server.activeWatches("b") }).Should(BeZero())
+		Expect(c.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(1))
+		Expect(configMaps.Items[0].Namespace).To(Equal("a"))
+
+		Expect(cache.RemoveNamespace(ctx, c, "b")).NotTo(Succeed())
+	})
+
+	It("should add namespaces to the types that follow DefaultNamespaces", func(ctx SpecContext) {
+		c, err := cache.New(restCfg, cache.Options{
+			Mapper:            mapper,
+			DefaultNamespaces: map[string]cache.Config{"a": {}},
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {UnsafeDisableDeepCopy: new(true)},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		start(c)
+		Expect(c.WaitForCacheSync(ctx)).To(BeTrue())
+		Expect(cache.AddNamespace(ctx, c, "b", cache.Config{})).To(Succeed())
+
+		configMaps := &corev1.ConfigMapList{}
+		Expect(c.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(2))
+	})
+
+	It("should not add namespaces to a cache for all namespaces", func(ctx SpecContext) {
+		c, err := cache.New(restCfg, cache.Options{Mapper: mapper})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.AddNamespace(ctx, c, "b", cache.Config{})).NotTo(Succeed())
+
+		c, err = cache.New(restCfg, cache.Options{
+			Mapper:            mapper,
+			DefaultNamespaces: map[string]cache.Config{"a": {}, metav1.NamespaceAll: {}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.AddNamespace(ctx, c, "b", cache.Config{})).NotTo(Succeed())
+	})
+})
+
+// namespacedConfigMapServer serves a ConfigMap named "config" in every namespace and
+// counts the active watches per namespace.
+type namespacedConfigMapServer struct {
+	mu      sync.Mutex
+	watches map[string]int
+}
+
+func (s *namespacedConfigMapServer) activeWatches(namespace string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watches[namespace]
+}
+
+func (s *namespacedConfigMapServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Paths look like /api/v1/namespaces/<namespace>/configmaps.
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 || parts[2] != "namespaces" || parts[4] != "configmaps" {
+		http.NotFound(w, r)
+		return
+	}
+	namespace := parts[3]
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Query().Get("watch") == "true" {
+		s.mu.Lock()
+		s.watches[namespace]++
+		s.mu.Unlock()
+		defer func() {
+			s.mu.Lock()
+			s.watches[namespace]--
+			s.mu.Unlock()
+		}()
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("sendInitialEvents") == "true" {
+			// Serve the initial events of a watch list request, terminated by a bookmark.
+			enc := json.NewEncoder(w)
+			_ = enc.Encode(&metav1.WatchEvent{
+				Type:   string(watch.Added),
+				Object: runtime.RawExtension{Object: configMap(namespace)},
+			})
+			_ = enc.Encode(&metav1.WatchEvent{
+				Type: string(watch.Bookmark),
+				Object: runtime.RawExtension{Object: &corev1.ConfigMap{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+					ObjectMeta: metav1.ObjectMeta{
+						ResourceVersion: "1",
+						Annotations:     map[string]string{metav1.InitialEventsAnnotationKey: "true"},
+					},
+				}},
+			})
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(&corev1.ConfigMapList{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMapList"},
+		ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+		Items:    []corev1.ConfigMap{*configMap(namespace)},
+	})
+}
+
+func configMap(namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "config", ResourceVersion: "1"},
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReadOnly is an object that was read from a cache without a DeepCopy, see GetReadOnly
+// and ListReadOnly. It shares its fields with the object in the cache, mutating it
+// corrupts the cache for every reader.
+//
+// The object is only reachable through UnsafeObject, so that linters can flag places
+// that use it, e.g. with a forbidigo rule for `\.UnsafeObject\(`, while the common
+// accessors and DeepCopy are safe to use anywhere.
+type ReadOnly[T client.Object] struct {
+	obj T
+}
+
+// UnsafeObject returns the object in the cache. It must not be mutated, nor passed to
+// anything that could mutate it, e.g. a client.Writer. Use DeepCopy instead if in doubt.
+func (r ReadOnly[T]) UnsafeObject() T {
+	return r.obj
+}
+
+// DeepCopy returns a copy of the object that is safe to mutate.
+func (r ReadOnly[T]) DeepCopy() T {
+	return r.obj.DeepCopyObject().(T)
+}
+
+// GetName returns the name of the object.
+func (r ReadOnly[T]) GetName() string {
+	return r.obj.GetName()
+}
+
+// GetNamespace returns the namespace of the object.
+func (r ReadOnly[T]) GetNamespace() string {
+	return r.obj.GetNamespace()
+}
+
+// GetResourceVersion returns the resource version of the object.
+func (r ReadOnly[T]) GetResourceVersion() string {
+	return r.obj.GetResourceVersion()
+}
+
+// GetLabel returns the value of the label with the given key, and whether the object has it.
+func (r ReadOnly[T]) GetLabel(key string) (string, bool) {
+	value, ok := r.obj.GetLabels()[key]
+	return value, ok
+}
+
+// GetAnnotation returns the value of the annotation with the given key, and whether the
+// object has it.
+func (r ReadOnly[T]) GetAnnotation(key string) (string, bool) {
+	value, ok := r.obj.GetAnnotations()[key]
+	return value, ok
+}
+
+// GetReadOnly reads the object with the given key from reader into obj without a DeepCopy,
+// and returns it as a ReadOnly. obj must not be used afterwards other than through the
+// returned ReadOnly. Readers that don't support client.UnsafeDisableDeepCopy, e.g. ones that
+// read from the API server, return a copy as usual.
+func GetReadOnly[T client.Object](ctx context.Context, reader client.Reader, key client.ObjectKey, obj T, opts ...client.GetOption) (ReadOnly[T], error) {
+	if err := reader.Get(ctx, key, obj, append(opts, client.UnsafeDisableDeepCopy)...); err != nil {
+		return ReadOnly[T]{}, err
+	}
+	return ReadOnly[T]{obj: obj}, nil
+}
+
+// ListReadOnly lists objects from reader into list without a DeepCopy of its items, and
+// returns them as ReadOnly. T must be the type of the items of list, e.g. *corev1.Pod for a
+// *corev1.PodList. list must not be used afterwards other than through the returned items.
+//
+// This avoids the DeepCopy of every listed object, which dominates the CPU usage of
+// controllers that list many objects per reconcile.
+func ListReadOnly[T client.Object](ctx context.Context, reader client.Reader, list client.ObjectList, opts ...client.ListOption) ([]ReadOnly[T], error) {
+	if err := reader.List(ctx, list, append(opts, client.UnsafeDisableDeepCopy)...); err != nil {
+		return nil, err
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ReadOnly[T], 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(T)
+		if !ok {
+			var want T
+			return nil, fmt.Errorf("list %T contains %T, expected %T", list, item, want)
+		}
+		result = append(result, ReadOnly[T]{obj: obj})
+	}
+	return result, nil
+}
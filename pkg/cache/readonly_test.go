@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ReadOnly", func() {
+	var informer cache.Cache
+
+	BeforeEach(func(specCtx SpecContext) {
+		server := &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods": {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+		}}
+		for _, name := range []string{"a", "b"} {
+			server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{"app": name},
+			}})
+		}
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+
+		var err error
+		informer, err = cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper: mapper,
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = informer.GetInformer(specCtx, &corev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informer.Start(ctx)).To(Succeed())
+		}()
+		Expect(informer.WaitForCacheSync(specCtx)).To(BeTrue())
+	})
+
+	It("should get an object without copying it", func(ctx SpecContext) {
+		key := client.ObjectKey{Namespace: "default", Name: "a"}
+		pod, err := cache.GetReadOnly(ctx, informer, key, &corev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pod.GetName()).To(Equal("a"))
+		value, ok := pod.GetLabel("app")
+		Expect(ok).To(BeTrue())
+		Expect(value).To(Equal("a"))
+
+		again, err := cache.GetReadOnly(ctx, informer, key, &corev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reflect.ValueOf(again.UnsafeObject().Labels).UnsafePointer()).To(Equal(reflect.ValueOf(pod.UnsafeObject().Labels).UnsafePointer()))
+
+		By("returning copies that are safe to mutate")
+		copied := pod.DeepCopy()
+		copied.Labels["app"] = "mutated"
+		Expect(pod.UnsafeObject().Labels).To(HaveKeyWithValue("app", "a"))
+	})
+
+	It("should list objects without copying them", func(ctx SpecContext) {
+		pods, err := cache.ListReadOnly[*corev1.Pod](ctx, informer, &corev1.PodList{}, client.InNamespace("default"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods).To(HaveLen(2))
+
+		again, err := cache.ListReadOnly[*corev1.Pod](ctx, informer, &corev1.PodList{}, client.MatchingLabels{"app": pods[0].GetName()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(HaveLen(1))
+		Expect(reflect.ValueOf(again[0].UnsafeObject().Labels).UnsafePointer()).To(Equal(reflect.ValueOf(pods[0].UnsafeObject().Labels).UnsafePointer()))
+	})
+
+	It("should fail if the items of the list are not of the requested type", func(ctx SpecContext) {
+		_, err := cache.ListReadOnly[*corev1.ConfigMap](ctx, informer, &corev1.PodList{})
+		Expect(err).To(MatchError(ContainSubstring("expected *v1.ConfigMap")))
+	})
+})
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
+)
+
+// ResumeStore persists the state of the informers of a cache across restarts, i.e. their
+// objects and the resourceVersion up to which they are current, see Options.ResumeStore.
+// The state is opaque to the store, it is saved per informer under a key that identifies
+// its type and namespace.
+type ResumeStore = internal.ResumeStore
+
+// NewFileResumeStore returns a ResumeStore that saves the state of every informer in a file
+// in the given directory, which is created if it doesn't exist. The directory needs to be
+// on a volume that outlives the process, e.g. a persistent volume, for the informers to
+// resume from it after a restart.
+func NewFileResumeStore(dir string) ResumeStore {
+	return &fileResumeStore{dir: dir}
+}
+
+type fileResumeStore struct {
+	dir string
+}
+
+func (s *fileResumeStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+// Load implements ResumeStore.
+func (s *fileResumeStore) Load(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save implements ResumeStore. The file is replaced atomically, so that a process that is
+// killed while saving leaves the previous state behind.
+func (s *fileResumeStore) Save(_ context.Context, key string, state []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, ".resume-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck // The file was renamed if saving succeeded.
+	if _, err := f.Write(state); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+var _ = Describe("NewFileResumeStore", func() {
+	It("should save and load states by key", func(ctx SpecContext) {
+		store := cache.NewFileResumeStore(GinkgoT().TempDir() + "/resume")
+
+		state, err := store.Load(ctx, "structured.Pod.v1.")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+
+		Expect(store.Save(ctx, "structured.Pod.v1.", []byte("first"))).To(Succeed())
+		Expect(store.Save(ctx, "structured.Deployment.v1.apps.default", []byte("other"))).To(Succeed())
+		Expect(store.Save(ctx, "structured.Pod.v1.", []byte("second"))).To(Succeed())
+
+		state, err = store.Load(ctx, "structured.Pod.v1.")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(state)).To(Equal("second"))
+		state, err = store.Load(ctx, "structured.Deployment.v1.apps.default")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(state)).To(Equal("other"))
+	})
+})
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// New returns a cache whose informers list and watch through the Server at serverURL
+// instead of the API server of config. The RESTMapper of the cache, unless set in opts,
+// discovers the types from the API server of config. The HTTPClient of opts is only
+// used for discovery, the cache talks to the Server with a client of its own.
+func New(config *rest.Config, serverURL string, opts cache.Options) (cache.Cache, error) {
+	if opts.Mapper == nil {
+		httpClient := opts.HTTPClient
+		if httpClient == nil {
+			var err error
+			httpClient, err = rest.HTTPClientFor(config)
+			if err != nil {
+				return nil, fmt.Errorf("could not create HTTP client from config: %w", err)
+			}
+		}
+		var err error
+		opts.Mapper, err = apiutil.NewDynamicRESTMapper(config, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("could not create RESTMapper from config: %w", err)
+		}
+	}
+
+	// The Server only speaks JSON.
+	serverConfig := &rest.Config{
+		Host:          serverURL,
+		UserAgent:     config.UserAgent,
+		ContentConfig: rest.ContentConfig{ContentType: runtime.ContentTypeJSON},
+	}
+	var err error
+	opts.HTTPClient, err = rest.HTTPClientFor(serverConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create HTTP client for the shared cache server: %w", err)
+	}
+	return cache.New(serverConfig, opts)
+}
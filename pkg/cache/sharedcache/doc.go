@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package sharedcache lets many processes share the watches of one cache, e.g. the
+replicas of an operator or several operators on a large cluster, so that the API
+server serves one watch per type instead of one per process.
+
+A Server serves the objects of the informers of a cache through the list and watch
+endpoints of the Kubernetes API. One process runs the Server and watches every
+requested type once:
+
+	srv := sharedcache.NewServer(mgr.GetCache(), sharedcache.ServerOptions{Mapper: mgr.GetRESTMapper()})
+	go http.ListenAndServe("127.0.0.1:8090", srv)
+
+The other processes create their cache with New, whose informers list and watch
+through the Server, while their RESTMapper still discovers the types from the API
+server. It can be used as the cache of a Manager:
+
+	mgr, err := manager.New(cfg, manager.Options{
+		NewCache: func(cfg *rest.Config, opts cache.Options) (cache.Cache, error) {
+			return sharedcache.New(cfg, "http://127.0.0.1:8090", opts)
+		},
+	})
+
+Clients only see the objects the cache of the Server has. Label selectors and
+field selectors on the name and namespace of objects are applied by the Server,
+but the selectors, transforms and filters of the cache of the Server apply first.
+
+The Server doesn't authenticate or authorize requests, it serves every type its
+cache can read to everyone who can reach it. It must only be served on a trusted
+network, e.g. on localhost to the containers of a pod, or behind a handler that
+authenticates its clients.
+
+This package is experimental and subject to change.
+*/
+package sharedcache
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("sharedcache")
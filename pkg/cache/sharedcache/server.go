@@ -0,0 +1,410 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+const (
+	defaultHistorySize      = 1000
+	defaultBookmarkInterval = time.Minute
+)
+
+// ServerOptions are the options of a Server.
+type ServerOptions struct {
+	// Mapper maps the resources of requests to their kinds. It is required.
+	Mapper meta.RESTMapper
+
+	// HistorySize is the number of changes per type that the Server keeps to serve
+	// watches that start at an older resourceVersion. Clients that watch from before
+	// the oldest kept change have to list again.
+	//
+	// Defaults to 1000.
+	HistorySize int
+
+	// BookmarkInterval is the interval in which the Server sends bookmarks to the
+	// watches that allow them.
+	//
+	// Defaults to one minute.
+	BookmarkInterval time.Duration
+}
+
+// Server serves the objects of the informers of a cache through the list and watch
+// endpoints of the Kubernetes API, see the package documentation.
+type Server struct {
+	informers cache.Informers
+	opts      ServerOptions
+
+	mu      sync.Mutex
+	streams map[schema.GroupVersionKind]*stream
+}
+
+var _ http.Handler = &Server{}
+
+// NewServer returns a Server that serves the objects of the given informers. It gets
+// the informer of a type when the type is requested for the first time, the informers
+// have to be started separately, e.g. by starting the cache they belong to.
+func NewServer(informers cache.Informers, opts ServerOptions) *Server {
+	if opts.HistorySize <= 0 {
+		opts.HistorySize = defaultHistorySize
+	}
+	if opts.BookmarkInterval <= 0 {
+		opts.BookmarkInterval = defaultBookmarkInterval
+	}
+	return &Server{
+		informers: informers,
+		opts:      opts,
+		streams:   map[schema.GroupVersionKind]*stream{},
+	}
+}
+
+// request is a list or watch request to the Server.
+type request struct {
+	gvk          schema.GroupVersionKind
+	namespace    string
+	labels       labels.Selector
+	fields       fields.Selector
+	metadataOnly bool
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, apierrors.NewMethodNotSupported(schema.GroupResource{}, r.Method))
+		return
+	}
+	gv, namespace, resource, ok := parsePath(r.URL.Path)
+	if !ok {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+		return
+	}
+	gvr := gv.WithResource(resource)
+	gvk, err := s.opts.Mapper.KindFor(gvr)
+	if err != nil {
+		writeError(w, apierrors.NewNotFound(gvr.GroupResource(), ""))
+		return
+	}
+
+	query := r.URL.Query()
+	req := request{
+		gvk:          gvk,
+		namespace:    namespace,
+		metadataOnly: strings.Contains(r.Header.Get("Accept"), "as=PartialObjectMetadata"),
+	}
+	if req.labels, err = labels.Parse(query.Get("labelSelector")); err != nil {
+		writeError(w, apierrors.NewBadRequest(fmt.Sprintf("invalid label selector: %v", err)))
+		return
+	}
+	if req.fields, err = parseFieldSelector(query.Get("fieldSelector")); err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	st, err := s.streamFor(r.Context(), gvk)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if watchParam := query.Get("watch"); watchParam == "true" || watchParam == "1" {
+		s.serveWatch(w, r, st, req)
+		return
+	}
+	s.serveList(w, st, req)
+}
+
+// parsePath returns the group version, namespace and resource of the path of a list
+// or watch request.
+func parsePath(path string) (gv schema.GroupVersion, namespace, resource string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		gv, parts = schema.GroupVersion{Version: parts[1]}, parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		gv, parts = schema.GroupVersion{Group: parts[1], Version: parts[2]}, parts[3:]
+	default:
+		return schema.GroupVersion{}, "", "", false
+	}
+	switch {
+	case len(parts) == 1:
+		return gv, "", parts[0], true
+	case len(parts) == 3 && parts[0] == "namespaces":
+		return gv, parts[1], parts[2], true
+	default:
+		return schema.GroupVersion{}, "", "", false
+	}
+}
+
+// parseFieldSelector parses a field selector, only the name and namespace of objects
+// are supported.
+func parseFieldSelector(selector string) (fields.Selector, error) {
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector: %w", err)
+	}
+	for _, requirement := range parsed.Requirements() {
+		if requirement.Field != "metadata.name" && requirement.Field != "metadata.namespace" {
+			return nil, fmt.Errorf("field selector on %q is not supported, only metadata.name and metadata.namespace are", requirement.Field)
+		}
+	}
+	return parsed, nil
+}
+
+// streamFor returns the stream of the given kind, creating it if it doesn't exist yet,
+// and waits until it has the objects of the informer.
+func (s *Server) streamFor(ctx context.Context, gvk schema.GroupVersionKind) (*stream, error) {
+	s.mu.Lock()
+	st, ok := s.streams[gvk]
+	if !ok {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		st = newStream(s.opts.HistorySize)
+		registration, err := cache.AddEventHandler(ctx, s.informers, obj, st, toolscache.HandlerOptions{})
+		if err != nil {
+			s.mu.Unlock()
+			return nil, apierrors.NewInternalError(fmt.Errorf("failed to get informer for %s: %w", gvk, err))
+		}
+		st.registration = registration
+		s.streams[gvk] = st
+		log.V(1).Info("Serving informer", "gvk", gvk)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-st.registration.HasSyncedChecker().Done():
+		return st, nil
+	case <-ctx.Done():
+		return nil, apierrors.NewServiceUnavailable(fmt.Sprintf("informer for %s has not synced yet", gvk))
+	}
+}
+
+// matches returns whether obj matches the namespace and selectors of the request.
+func (req request) matches(obj *unstructured.Unstructured) bool {
+	if req.namespace != "" && obj.GetNamespace() != req.namespace {
+		return false
+	}
+	if !req.labels.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	return req.fields.Matches(fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	})
+}
+
+// object returns the representation of obj that is sent to the client.
+func (req request) object(obj *unstructured.Unstructured) map[string]any {
+	if !req.metadataOnly {
+		return obj.Object
+	}
+	return map[string]any{
+		"apiVersion": metav1.SchemeGroupVersion.String(),
+		"kind":       "PartialObjectMetadata",
+		"metadata":   obj.Object["metadata"],
+	}
+}
+
+// bookmark returns the object of a bookmark at the given resourceVersion.
+func (req request) bookmark(resourceVersion uint64, annotations map[string]any) map[string]any {
+	apiVersion, kind := req.gvk.ToAPIVersionAndKind()
+	if req.metadataOnly {
+		apiVersion, kind = metav1.SchemeGroupVersion.String(), "PartialObjectMetadata"
+	}
+	metadata := map[string]any{"resourceVersion": strconv.FormatUint(resourceVersion, 10)}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return map[string]any{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}
+}
+
+// serveList serves the objects that match the request. The Server always returns all
+// objects at once, limit and continue are ignored.
+func (s *Server) serveList(w http.ResponseWriter, st *stream, req request) {
+	objs, resourceVersion := st.list(req.matches)
+
+	items := make([]any, 0, len(objs))
+	for _, obj := range objs {
+		items = append(items, req.object(obj))
+	}
+	apiVersion, kind := req.gvk.ToAPIVersionAndKind()
+	kind += "List"
+	if req.metadataOnly {
+		apiVersion, kind = metav1.SchemeGroupVersion.String(), "PartialObjectMetadataList"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"resourceVersion": strconv.FormatUint(resourceVersion, 10)},
+		"items":      items,
+	}); err != nil {
+		log.V(1).Info("Failed to write list", "gvk", req.gvk, "error", err)
+	}
+}
+
+// watchEvent is the wire format of an event of a watch.
+type watchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object any             `json:"object"`
+}
+
+// serveWatch serves the changes of the objects that match the request.
+func (s *Server) serveWatch(w http.ResponseWriter, r *http.Request, st *stream, req request) {
+	query := r.URL.Query()
+	ctx := r.Context()
+	if timeout := query.Get("timeoutSeconds"); timeout != "" {
+		seconds, err := strconv.ParseInt(timeout, 10, 64)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest(fmt.Sprintf("invalid timeoutSeconds: %v", err)))
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(seconds)*time.Second)
+		defer cancel()
+	}
+
+	var (
+		watcher *watcher
+		events  []event
+		// initialEventsEnd is the resourceVersion of the bookmark that ends the
+		// initial events of a watch that requested them.
+		initialEventsEnd  uint64
+		sendInitialEvents = query.Get("sendInitialEvents") == "true"
+	)
+	switch resourceVersion := query.Get("resourceVersion"); {
+	case sendInitialEvents:
+		watcher, events, initialEventsEnd = st.watchAll(req.matches)
+	case resourceVersion == "" || resourceVersion == "0":
+		watcher, events, _ = st.watchAll(req.matches)
+	default:
+		from, err := strconv.ParseUint(resourceVersion, 10, 64)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest(fmt.Sprintf("invalid resourceVersion: %v", err)))
+			return
+		}
+		var ok bool
+		if watcher, events, ok = st.watchFrom(from, req.matches); !ok {
+			// Like the API server, a watch whose resourceVersion is too old gets an
+			// error event that makes the client list again.
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			status := apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d", from)).ErrStatus
+			status.APIVersion, status.Kind = "v1", "Status"
+			_ = json.NewEncoder(w).Encode(watchEvent{Type: watch.Error, Object: status})
+			return
+		}
+	}
+	defer st.stopWatch(watcher)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	send := func(e watchEvent) bool {
+		if err := encoder.Encode(e); err != nil {
+			log.V(1).Info("Failed to write watch event", "gvk", req.gvk, "error", err)
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+
+	for _, e := range events {
+		if !send(watchEvent{Type: e.Type, Object: req.object(e.Object)}) {
+			return
+		}
+	}
+	if sendInitialEvents {
+		bookmark := req.bookmark(initialEventsEnd, map[string]any{metav1.InitialEventsAnnotationKey: "true"})
+		if !send(watchEvent{Type: watch.Bookmark, Object: bookmark}) {
+			return
+		}
+	}
+
+	var bookmarks <-chan time.Time
+	if query.Get("allowWatchBookmarks") == "true" {
+		ticker := time.NewTicker(s.opts.BookmarkInterval)
+		defer ticker.Stop()
+		bookmarks = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-watcher.events:
+			if !ok {
+				// The watch didn't keep up, the client watches again from the
+				// last resourceVersion it received.
+				return
+			}
+			if !send(watchEvent{Type: e.Type, Object: req.object(e.Object)}) {
+				return
+			}
+		case <-bookmarks:
+			// Events that are still buffered may be older than the current
+			// resourceVersion, the bookmark waits until they are sent.
+			resourceVersion := st.currentResourceVersion()
+			if len(watcher.events) > 0 {
+				continue
+			}
+			if !send(watchEvent{Type: watch.Bookmark, Object: req.bookmark(resourceVersion, nil)}) {
+				return
+			}
+		}
+	}
+}
+
+// writeError writes err as a Status.
+func writeError(w http.ResponseWriter, err error) {
+	var status metav1.Status
+	if apiStatus, ok := err.(apierrors.APIStatus); ok {
+		status = apiStatus.Status()
+	} else {
+		status = apierrors.NewInternalError(err).ErrStatus
+	}
+	status.APIVersion, status.Kind = "v1", "Status"
+	code := int(status.Code)
+	if code == 0 {
+		code = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/cache/sharedcache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+var podGVK = corev1.SchemeGroupVersion.WithKind("Pod")
+
+func unstructuredPod(name, resourceVersion, app string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetGroupVersionKind(podGVK)
+	pod.SetNamespace("default")
+	pod.SetName(name)
+	pod.SetResourceVersion(resourceVersion)
+	pod.SetLabels(map[string]string{"app": app})
+	return pod
+}
+
+var _ = Describe("Server", func() {
+	var (
+		ctx      context.Context
+		informer *controllertest.FakeInformer
+		server   *httptest.Server
+		consumer cache.Cache
+	)
+
+	BeforeEach(func() {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, meta.RESTScopeNamespace)
+
+		informers := &informertest.FakeInformers{}
+		var err error
+		informer, err = informers.FakeInformerForKind(context.Background(), podGVK)
+		Expect(err).NotTo(HaveOccurred())
+
+		server = httptest.NewServer(sharedcache.NewServer(informers, sharedcache.ServerOptions{Mapper: mapper, HistorySize: 2}))
+		DeferCleanup(server.Close)
+
+		// Cleanups run in reverse order, the consumer stops its watches before the
+		// server is closed.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		consumer, err = sharedcache.New(&rest.Config{}, server.URL, cache.Options{Mapper: mapper})
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			Expect(consumer.Start(ctx)).To(Succeed())
+		}()
+		Expect(consumer.WaitForCacheSync(ctx)).To(BeTrue())
+	})
+
+	appOf := func(obj client.Object) func() (string, error) {
+		return func() (string, error) {
+			err := consumer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, obj)
+			return obj.GetLabels()["app"], err
+		}
+	}
+
+	It("should serve the objects and changes of its informers to the cache of another process", func() {
+		// Starts the informer of the consumer, so that the Server serves the informer.
+		pods := &corev1.PodList{}
+		Expect(consumer.List(ctx, pods)).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+
+		By("adding an object")
+		informer.Add(unstructuredPod("a", "1", "one"))
+		Eventually(appOf(&corev1.Pod{})).Should(Equal("one"))
+
+		By("updating the object")
+		informer.Update(unstructuredPod("a", "1", "one"), unstructuredPod("a", "2", "two"))
+		Eventually(appOf(&corev1.Pod{})).Should(Equal("two"))
+
+		By("deleting the object")
+		informer.Delete(unstructuredPod("a", "3", "two"))
+		Eventually(func() error {
+			return consumer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.Pod{})
+		}).Should(Satisfy(func(err error) bool { return err != nil }))
+	})
+
+	It("should serve the metadata of objects to metadata-only informers", func() {
+		metadata := &metav1.PartialObjectMetadata{}
+		metadata.SetGroupVersionKind(podGVK)
+		Expect(consumer.List(ctx, &metav1.PartialObjectMetadataList{TypeMeta: metadata.TypeMeta})).To(Succeed())
+
+		informer.Add(unstructuredPod("a", "1", "one"))
+		Eventually(appOf(metadata)).Should(Equal("one"))
+	})
+
+	It("should filter lists by label and field selectors", func() {
+		// The first request registers the Server with the informer.
+		resp, err := http.Get(server.URL + "/api/v1/pods")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+
+		informer.Add(unstructuredPod("a", "1", "one"))
+		informer.Add(unstructuredPod("b", "2", "two"))
+		informer.Add(unstructuredPod("c", "3", "two"))
+
+		list := func(query string) []string {
+			resp, err := http.Get(server.URL + "/api/v1/namespaces/default/pods?" + query)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			pods := &corev1.PodList{}
+			Expect(json.NewDecoder(resp.Body).Decode(pods)).To(Succeed())
+			Expect(pods.ResourceVersion).To(Equal("3"))
+			var names []string
+			for _, pod := range pods.Items {
+				names = append(names, pod.Name)
+			}
+			return names
+		}
+		Expect(list("labelSelector=app%3Dtwo")).To(ConsistOf("b", "c"))
+		Expect(list("fieldSelector=metadata.name%3Db")).To(ConsistOf("b"))
+
+		resp, err = http.Get(server.URL + "/api/v1/pods?fieldSelector=spec.nodeName%3Dnode")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should make watches from before its history list again", func() {
+		resp, err := http.Get(server.URL + "/api/v1/pods")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+
+		for i := 1; i <= 4; i++ {
+			informer.Add(unstructuredPod(strconv.Itoa(i), strconv.Itoa(i), "one"))
+		}
+
+		resp, err = http.Get(server.URL + "/api/v1/pods?watch=true&resourceVersion=1")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		event := &metav1.WatchEvent{}
+		Expect(json.NewDecoder(resp.Body).Decode(event)).To(Succeed())
+		Expect(event.Type).To(Equal(string(watch.Error)))
+		status := &metav1.Status{}
+		Expect(json.Unmarshal(event.Object.Raw, status)).To(Succeed())
+		Expect(status.Code).To(BeEquivalentTo(http.StatusGone))
+	})
+})
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedcache
+
+import (
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// watcherBufferSize is the number of events that are buffered per watch. A watch
+// whose client doesn't keep up is closed, the client watches again from the last
+// resourceVersion it received.
+const watcherBufferSize = 100
+
+// event is a change of an object of a stream.
+type event struct {
+	Type watch.EventType
+
+	// Object is the object after the change, or before it was deleted.
+	Object *unstructured.Unstructured
+
+	// Old is the object before the change, it is nil for added objects.
+	Old *unstructured.Unstructured
+
+	// resourceVersion is the resourceVersion of the stream after the change.
+	resourceVersion uint64
+}
+
+// forFilter returns the event a watch with the given filter gets for e, objects that
+// start or stop matching the filter are added or deleted. It returns false if the
+// watch doesn't get an event.
+func (e event) forFilter(filter func(*unstructured.Unstructured) bool) (event, bool) {
+	if e.Type == watch.Deleted {
+		return e, filter(e.Object)
+	}
+	oldMatches := e.Old != nil && filter(e.Old)
+	switch matches := filter(e.Object); {
+	case matches && oldMatches:
+		return event{Type: watch.Modified, Object: e.Object}, true
+	case matches:
+		return event{Type: watch.Added, Object: e.Object}, true
+	case oldMatches:
+		return event{Type: watch.Deleted, Object: e.Object}, true
+	default:
+		return event{}, false
+	}
+}
+
+// watcher is a watch of a stream.
+type watcher struct {
+	filter func(*unstructured.Unstructured) bool
+	events chan event
+}
+
+// stream keeps the objects of an informer of the cache of a Server, and the latest
+// changes of them to serve watches from a resourceVersion. It is registered as the
+// event handler of the informer.
+type stream struct {
+	historySize  int
+	registration toolscache.ResourceEventHandlerRegistration
+
+	mu sync.Mutex
+
+	// objects are the objects of the informer by namespace/name.
+	objects map[string]*unstructured.Unstructured
+
+	// resourceVersion is the highest resourceVersion of an object of the informer.
+	resourceVersion uint64
+
+	// history are the latest changes in the order they happened.
+	history []event
+
+	// historyStart is the resourceVersion from which on history has all changes.
+	historyStart uint64
+
+	watchers map[*watcher]struct{}
+}
+
+func newStream(historySize int) *stream {
+	return &stream{
+		historySize: historySize,
+		objects:     map[string]*unstructured.Unstructured{},
+		watchers:    map[*watcher]struct{}{},
+	}
+}
+
+// OnAdd implements toolscache.ResourceEventHandler.
+func (s *stream) OnAdd(obj any, isInInitialList bool) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		s.apply(watch.Added, u, !isInInitialList)
+	}
+}
+
+// OnUpdate implements toolscache.ResourceEventHandler.
+func (s *stream) OnUpdate(oldObj, newObj any) {
+	oldU, ok := oldObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	newU, ok := newObj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	// Resyncs and relists of the informer update objects that didn't change.
+	s.apply(watch.Modified, newU, oldU.GetResourceVersion() != newU.GetResourceVersion())
+}
+
+// OnDelete implements toolscache.ResourceEventHandler.
+func (s *stream) OnDelete(obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		if u, ok := tombstone.Obj.(*unstructured.Unstructured); ok {
+			s.applyTombstone(u)
+		}
+		return
+	}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		s.apply(watch.Deleted, u, true)
+	}
+}
+
+// apply applies a change to the objects and, if record is set, adds it to the history
+// and sends it to the watches. Changes of the initial list of the informer and changes
+// that don't change the resourceVersion of an object are not recorded.
+func (s *stream) apply(eventType watch.EventType, obj *unstructured.Unstructured, record bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := toolscache.MetaObjectToName(obj).String()
+	old := s.objects[key]
+	if eventType == watch.Deleted {
+		delete(s.objects, key)
+	} else {
+		s.objects[key] = obj
+	}
+	if resourceVersion, err := strconv.ParseUint(obj.GetResourceVersion(), 10, 64); err == nil && resourceVersion > s.resourceVersion {
+		s.resourceVersion = resourceVersion
+	}
+	if !record {
+		s.historyStart = s.resourceVersion
+		return
+	}
+
+	e := event{Type: eventType, Object: obj, Old: old, resourceVersion: s.resourceVersion}
+	s.history = append(s.history, e)
+	if len(s.history) > s.historySize {
+		s.historyStart = s.history[0].resourceVersion
+		s.history = s.history[1:]
+	}
+	s.sendLocked(e)
+}
+
+// applyTombstone applies the deletion of an object whose deletion the informer missed.
+// Its resourceVersion is unknown, so the history is dropped, watches that start before
+// it have to list again.
+func (s *stream) applyTombstone(obj *unstructured.Unstructured) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := toolscache.MetaObjectToName(obj).String()
+	if _, ok := s.objects[key]; !ok {
+		return
+	}
+	delete(s.objects, key)
+	s.history = nil
+	s.historyStart = s.resourceVersion
+	s.sendLocked(event{Type: watch.Deleted, Object: obj, resourceVersion: s.resourceVersion})
+}
+
+// sendLocked sends e to all watches, closing those that don't keep up.
+func (s *stream) sendLocked(e event) {
+	for w := range s.watchers {
+		filtered, ok := e.forFilter(w.filter)
+		if !ok {
+			continue
+		}
+		select {
+		case w.events <- filtered:
+		default:
+			s.closeLocked(w)
+		}
+	}
+}
+
+// list returns the objects that match filter and the resourceVersion they are current at.
+func (s *stream) list(filter func(*unstructured.Unstructured) bool) ([]*unstructured.Unstructured, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listLocked(filter), s.resourceVersion
+}
+
+func (s *stream) listLocked(filter func(*unstructured.Unstructured) bool) []*unstructured.Unstructured {
+	var objs []*unstructured.Unstructured
+	for _, obj := range s.objects {
+		if filter(obj) {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// watchFrom starts a watch of the changes after the given resourceVersion, and returns
+// them along with the watch. It returns false if the history doesn't reach back to the
+// resourceVersion.
+func (s *stream) watchFrom(resourceVersion uint64, filter func(*unstructured.Unstructured) bool) (*watcher, []event, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if resourceVersion < s.historyStart {
+		return nil, nil, false
+	}
+	var events []event
+	for _, e := range s.history {
+		if e.resourceVersion <= resourceVersion {
+			continue
+		}
+		if filtered, ok := e.forFilter(filter); ok {
+			events = append(events, filtered)
+		}
+	}
+	return s.addWatcherLocked(filter), events, true
+}
+
+// watchAll starts a watch and returns the objects that match filter as added events
+// along with it, and the resourceVersion they are current at.
+func (s *stream) watchAll(filter func(*unstructured.Unstructured) bool) (*watcher, []event, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objs := s.listLocked(filter)
+	events := make([]event, 0, len(objs))
+	for _, obj := range objs {
+		events = append(events, event{Type: watch.Added, Object: obj})
+	}
+	return s.addWatcherLocked(filter), events, s.resourceVersion
+}
+
+func (s *stream) addWatcherLocked(filter func(*unstructured.Unstructured) bool) *watcher {
+	w := &watcher{filter: filter, events: make(chan event, watcherBufferSize)}
+	s.watchers[w] = struct{}{}
+	return w
+}
+
+// currentResourceVersion returns the resourceVersion of the stream.
+func (s *stream) currentResourceVersion() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resourceVersion
+}
+
+// stopWatch stops a watch, it is idempotent.
+func (s *stream) stopWatch(w *watcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked(w)
+}
+
+func (s *stream) closeLocked(w *watcher) {
+	if _, ok := s.watchers[w]; ok {
+		delete(s.watchers, w)
+		close(w.events)
+	}
+}
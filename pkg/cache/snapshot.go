@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	defaultSnapshotTimeout      = 10 * time.Second
+	snapshotPollInterval        = 100 * time.Millisecond
+	snapshotKindStructured      = "structured"
+	snapshotKindUnstructured    = "unstructured"
+	snapshotKindPartialMetadata = "metadata"
+)
+
+// ErrInconsistentSnapshot is returned by TakeSnapshot if no consistent snapshot
+// could be taken from the cache and no APIReader was configured to fall back to.
+var ErrInconsistentSnapshot = errors.New("unable to take a consistent snapshot of the cache")
+
+// SnapshotOptions are the optional arguments for TakeSnapshot.
+type SnapshotOptions struct {
+	// Timeout is the maximum duration to wait for all informers to reach a common
+	// resourceVersion horizon before falling back to the APIReader.
+	// Defaults to 10 seconds.
+	Timeout *time.Duration
+
+	// APIReader is used to list types that didn't change recently from the API server
+	// at the horizon, and to list all types from the API server at a single
+	// resourceVersion if no consistent snapshot can be taken from the cache.
+	// If unset, TakeSnapshot waits for the informers of types that didn't change
+	// recently to observe a watch bookmark, and returns ErrInconsistentSnapshot if no
+	// consistent snapshot can be taken from the cache.
+	APIReader client.Reader
+}
+
+// Snapshot is a read-only copy of the objects of several types, taken at a common
+// resourceVersion horizon. It implements client.Reader for the types it was taken of.
+// Objects read from a Snapshot are always deep copies.
+type Snapshot struct {
+	scheme          *runtime.Scheme
+	resourceVersion string
+	fromAPIServer   bool
+	readers         map[snapshotKey]client.Reader
+}
+
+type snapshotKey struct {
+	gvk  schema.GroupVersionKind
+	kind string
+}
+
+// snapshotter is implemented by caches that support taking snapshots.
+type snapshotter interface {
+	snapshotScheme() *runtime.Scheme
+	snapshotCache(ctx context.Context, list client.ObjectList) (*internal.Cache, error)
+}
+
+// TakeSnapshot copies the objects of the types of the given lists out of the cache,
+// so that reconcilers that compute invariants across several types see all of them at
+// the same resourceVersion horizon instead of whatever each informer observed at the
+// time it was read. Informers for types that are not cached yet are created and
+// synced first, as with any read from the cache.
+//
+// The horizon is the highest resourceVersion up to which any of the informers has
+// applied changes to the cache when the snapshot is requested. TakeSnapshot waits
+// until the changes up to the horizon have been applied for every type, so that the
+// snapshot contains every change up to the horizon. As informers progress
+// independently, an informer may have applied changes after the horizon by then. If
+// any copied object was changed after the horizon, the horizon is raised to its
+// resourceVersion and all informers are waited for again. Objects deleted after the
+// horizon can not be told apart from bookmarks and may be missing from the snapshot.
+//
+// Informers of types without recent changes only learn that nothing changed up to the
+// horizon with the next watch bookmark, which the API server sends roughly every
+// minute. If an APIReader is configured, such types are listed from the API server at
+// the horizon instead of waiting, so that snapshots usually take as long as applying
+// the changes that were already received. Without an APIReader, taking a snapshot can
+// take up to a minute and fails if that is longer than Timeout.
+//
+// This relies on resourceVersions being comparable integers, which holds for API
+// servers backed by etcd, but isn't guaranteed by the API, and on the informers
+// tracking the resourceVersion they applied, which requires the AtomicFIFO feature of
+// client-go. If the horizon isn't reached within Timeout or the resourceVersions of
+// the cache can't be compared, all types are listed through the APIReader at the
+// resourceVersion of the first list instead, which is exactly consistent but puts load
+// on the API server. If no APIReader is configured, ErrInconsistentSnapshot is returned
+// in these cases.
+//
+// Snapshots are supported by caches created with New, unless they are restricted to
+// more than one namespace.
+func TakeSnapshot(ctx context.Context, c Cache, lists []client.ObjectList, opts SnapshotOptions) (*Snapshot, error) {
+	if len(lists) == 0 {
+		return nil, errors.New("must specify at least one list type to take a snapshot of")
+	}
+	if opts.Timeout == nil {
+		opts.Timeout = new(defaultSnapshotTimeout)
+	}
+
+	s, ok := c.(snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("cache %T does not support snapshots", c)
+	}
+
+	caches := make([]*internal.Cache, 0, len(lists))
+	for _, list := range lists {
+		cache, err := s.snapshotCache(ctx, list)
+		if err != nil {
+			return nil, err
+		}
+		caches = append(caches, cache)
+	}
+
+	snapshot, err := snapshotFromCache(ctx, s.snapshotScheme(), caches, lists, opts)
+	if err != nil {
+		return fallbackSnapshot(ctx, s.snapshotScheme(), caches, lists, opts, err)
+	}
+	return snapshot, nil
+}
+
+func snapshotFromCache(ctx context.Context, scheme *runtime.Scheme, caches []*internal.Cache, lists []client.ObjectList, opts SnapshotOptions) (*Snapshot, error) {
+	var horizon uint64
+	for _, cache := range caches {
+		applied, err := appliedResourceVersion(cache)
+		if err != nil {
+			return nil, err
+		}
+		horizon = max(horizon, applied)
+	}
+
+	var snapshot *Snapshot
+	err := wait.PollUntilContextTimeout(ctx, snapshotPollInterval, *opts.Timeout, true, func(ctx context.Context) (bool, error) {
+		readers := make(map[snapshotKey]client.Reader, len(caches))
+		var unchanged []int
+		newest := horizon
+		for i, cache := range caches {
+			applied, err := appliedResourceVersion(cache)
+			if err != nil {
+				return false, err
+			}
+			if applied < horizon {
+				received, err := parseResourceVersion(cache.Informer.LastSyncResourceVersion())
+				if err != nil {
+					return false, err
+				}
+				if applied < received || opts.APIReader == nil {
+					// Wait for the changes that were received to be applied, or for a
+					// bookmark if there is no APIReader.
+					return false, nil
+				}
+				// The informer applied everything it received, but doesn't know yet
+				// whether anything changed up to the horizon.
+				unchanged = append(unchanged, i)
+				continue
+			}
+
+			reader, resourceVersion, err := cache.Snapshot()
+			if err != nil {
+				return false, err
+			}
+			if resourceVersion == "" {
+				// A change was applied while copying, try again.
+				return false, nil
+			}
+			objectsNewest, err := newestResourceVersion(ctx, reader, lists[i])
+			if err != nil {
+				return false, err
+			}
+			newest = max(newest, objectsNewest)
+
+			key, err := snapshotKeyFor(lists[i], scheme)
+			if err != nil {
+				return false, err
+			}
+			readers[key] = reader
+		}
+		if newest > horizon {
+			// Some objects already changed after the horizon, the other types need to
+			// catch up with them.
+			horizon = newest
+			return false, nil
+		}
+
+		resourceVersion := strconv.FormatUint(horizon, 10)
+		for _, i := range unchanged {
+			reader, _, err := listSnapshotReader(ctx, opts.APIReader, caches[i], lists[i], resourceVersion)
+			if err != nil {
+				return false, err
+			}
+			key, err := snapshotKeyFor(lists[i], scheme)
+			if err != nil {
+				return false, err
+			}
+			readers[key] = reader
+		}
+
+		snapshot = &Snapshot{
+			scheme:          scheme,
+			resourceVersion: resourceVersion,
+			readers:         readers,
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("informers did not reach resourceVersion %d: %w", horizon, err)
+	}
+	return snapshot, nil
+}
+
+// appliedResourceVersion returns the resourceVersion up to which the informer of the
+// cache applied changes.
+func appliedResourceVersion(cache *internal.Cache) (uint64, error) {
+	resourceVersion := cache.AppliedResourceVersion()
+	if resourceVersion == "" {
+		return 0, errors.New("informer does not track the resourceVersion it applied, the AtomicFIFO feature of client-go is required")
+	}
+	return parseResourceVersion(resourceVersion)
+}
+
+// listSnapshotReader lists the objects of the type of the list through the APIReader at
+// exactly the given resourceVersion and returns a reader for them. An empty
+// resourceVersion lists the most recent objects.
+func listSnapshotReader(ctx context.Context, apiReader client.Reader, cache *internal.Cache, list client.ObjectList, resourceVersion string) (*internal.CacheReader, string, error) {
+	list = list.DeepCopyObject().(client.ObjectList)
+	var listOpts []client.ListOption
+	if resourceVersion != "" {
+		listOpts = append(listOpts, &client.ListOptions{Raw: &metav1.ListOptions{
+			ResourceVersion:      resourceVersion,
+			ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+		}})
+	}
+	if err := apiReader.List(ctx, list, listOpts...); err != nil {
+		return nil, "", fmt.Errorf("failed to list %T for snapshot: %w", list, err)
+	}
+
+	items, err := apimeta.ExtractListWithAlloc(list)
+	if err != nil {
+		return nil, "", err
+	}
+	objs := make([]any, 0, len(items))
+	for _, item := range items {
+		objs = append(objs, item)
+	}
+	reader, err := cache.NewSnapshotReader(objs)
+	if err != nil {
+		return nil, "", err
+	}
+	return reader, list.GetResourceVersion(), nil
+}
+
+// fallbackSnapshot lists all types through the APIReader at a single resourceVersion.
+func fallbackSnapshot(ctx context.Context, scheme *runtime.Scheme, caches []*internal.Cache, lists []client.ObjectList, opts SnapshotOptions, cause error) (*Snapshot, error) {
+	if opts.APIReader == nil {
+		return nil, fmt.Errorf("%w: %w", ErrInconsistentSnapshot, cause)
+	}
+
+	snapshot := &Snapshot{
+		scheme:        scheme,
+		fromAPIServer: true,
+		readers:       make(map[snapshotKey]client.Reader, len(lists)),
+	}
+	for i, list := range lists {
+		reader, resourceVersion, err := listSnapshotReader(ctx, opts.APIReader, caches[i], list, snapshot.resourceVersion)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			snapshot.resourceVersion = resourceVersion
+		}
+		key, err := snapshotKeyFor(list, scheme)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.readers[key] = reader
+	}
+	return snapshot, nil
+}
+
+// ResourceVersion returns the resourceVersion horizon of the snapshot.
+func (s *Snapshot) ResourceVersion() string {
+	return s.resourceVersion
+}
+
+// FromAPIServer returns true if all types of the snapshot were listed from the API
+// server because no consistent snapshot could be taken from the cache. Types without
+// recent changes may be listed from the API server even if it returns false.
+func (s *Snapshot) FromAPIServer() bool {
+	return s.fromAPIServer
+}
+
+// Get implements client.Reader.
+func (s *Snapshot) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	reader, err := s.readerFor(obj)
+	if err != nil {
+		return err
+	}
+	return reader.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Reader.
+func (s *Snapshot) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	reader, err := s.readerFor(list)
+	if err != nil {
+		return err
+	}
+	return reader.List(ctx, list, opts...)
+}
+
+var _ client.Reader = &Snapshot{}
+
+func (s *Snapshot) readerFor(obj runtime.Object) (client.Reader, error) {
+	key, err := snapshotKeyFor(obj, s.scheme)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := s.readers[key]
+	if !ok {
+		return nil, &ErrResourceNotCached{GVK: key.gvk}
+	}
+	return reader, nil
+}
+
+func snapshotKeyFor(obj runtime.Object, scheme *runtime.Scheme) (snapshotKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return snapshotKey{}, err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	key := snapshotKey{gvk: gvk, kind: snapshotKindStructured}
+	switch obj.(type) {
+	case runtime.Unstructured:
+		key.kind = snapshotKindUnstructured
+	case *metav1.PartialObjectMetadata, *metav1.PartialObjectMetadataList:
+		key.kind = snapshotKindPartialMetadata
+	}
+	return key, nil
+}
+
+// newestResourceVersion returns the highest resourceVersion of the objects of the reader.
+func newestResourceVersion(ctx context.Context, reader client.Reader, list client.ObjectList) (uint64, error) {
+	list = list.DeepCopyObject().(client.ObjectList)
+	if err := reader.List(ctx, list, client.UnsafeDisableDeepCopy); err != nil {
+		return 0, err
+	}
+	var newest uint64
+	err := apimeta.EachListItem(list, func(o runtime.Object) error {
+		obj, err := apimeta.Accessor(o)
+		if err != nil {
+			return err
+		}
+		resourceVersion, err := parseResourceVersion(obj.GetResourceVersion())
+		if err != nil {
+			return err
+		}
+		newest = max(newest, resourceVersion)
+		return nil
+	})
+	return newest, err
+}
+
+func parseResourceVersion(resourceVersion string) (uint64, error) {
+	if resourceVersion == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("resourceVersion %q is not comparable: %w", resourceVersion, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("TakeSnapshot", func() {
+	var (
+		server   *fakeResourceServer
+		informer cache.Cache
+	)
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	lists := func() []client.ObjectList {
+		return []client.ObjectList{&corev1.PodList{}, &corev1.ConfigMapList{}}
+	}
+
+	BeforeEach(func(specCtx SpecContext) {
+		server = &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods":       {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+			"configmaps": {newObject: func() client.Object { return &corev1.ConfigMap{} }, newList: func() client.ObjectList { return &corev1.ConfigMapList{} }},
+		}}
+		server.create("pods", newPod("a"))
+		server.create("configmaps", newConfigMap("a"))
+
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+
+		var err error
+		informer, err = cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper: mapper,
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// The context of BeforeEach is cancelled once it returns, the cache has to
+		// keep running for the spec.
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informer.Start(ctx)).To(Succeed())
+		}()
+		Expect(informer.WaitForCacheSync(specCtx)).To(BeTrue())
+	})
+
+	It("should wait for all informers to observe the horizon", func(ctx SpecContext) {
+		// Make sure the informers are created before changing anything.
+		_, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		server.create("pods", newPod("b"))
+		Eventually(func() error {
+			return informer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.Pod{})
+		}).Should(Succeed())
+
+		snapshotDone := make(chan *cache.Snapshot)
+		go func() {
+			defer GinkgoRecover()
+			snapshot, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{Timeout: new(time.Minute)})
+			Expect(err).NotTo(HaveOccurred())
+			snapshotDone <- snapshot
+		}()
+		Consistently(snapshotDone).ShouldNot(Receive())
+
+		By("advancing the idle informer with a bookmark")
+		server.bookmark("configmaps")
+		var snapshot *cache.Snapshot
+		Eventually(snapshotDone).Should(Receive(&snapshot))
+		Expect(snapshot.ResourceVersion()).To(Equal("3"))
+		Expect(snapshot.FromAPIServer()).To(BeFalse())
+
+		pods := &corev1.PodList{}
+		Expect(snapshot.List(ctx, pods)).To(Succeed())
+		Expect(pods.Items).To(HaveLen(2))
+		Expect(snapshot.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})).To(Succeed())
+
+		By("not reflecting changes after the snapshot was taken")
+		server.create("configmaps", newConfigMap("b"))
+		Eventually(func() error {
+			return informer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.ConfigMap{})
+		}).Should(Succeed())
+		configMaps := &corev1.ConfigMapList{}
+		Expect(snapshot.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(1))
+
+		By("refusing types that are not part of the snapshot")
+		err = snapshot.List(ctx, &corev1.SecretList{})
+		Expect(err).To(BeAssignableToTypeOf(&cache.ErrResourceNotCached{}))
+	})
+
+	It("should fail if the horizon isn't reached and there is no APIReader", func(ctx SpecContext) {
+		_, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		server.create("pods", newPod("b"))
+		Eventually(func() error {
+			return informer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.Pod{})
+		}).Should(Succeed())
+		_, err = cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{Timeout: new(200 * time.Millisecond)})
+		Expect(err).To(MatchError(cache.ErrInconsistentSnapshot))
+	})
+
+	It("should list types without recent changes from the APIReader at the horizon", func(ctx SpecContext) {
+		_, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		server.create("pods", newPod("b"))
+		Eventually(func() error {
+			return informer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.Pod{})
+		}).Should(Succeed())
+
+		apiReader := fake.NewClientBuilder().WithObjects(newPod("a"), newPod("b"), newPod("c"), newConfigMap("a"), newConfigMap("b")).Build()
+		snapshot, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{
+			Timeout:   new(time.Minute),
+			APIReader: apiReader,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.ResourceVersion()).To(Equal("3"))
+		Expect(snapshot.FromAPIServer()).To(BeFalse())
+
+		pods := &corev1.PodList{}
+		Expect(snapshot.List(ctx, pods)).To(Succeed())
+		Expect(pods.Items).To(HaveLen(2))
+		configMaps := &corev1.ConfigMapList{}
+		Expect(snapshot.List(ctx, configMaps)).To(Succeed())
+		Expect(configMaps.Items).To(HaveLen(2))
+	})
+
+	It("should fall back to the APIReader if resourceVersions can't be compared", func(ctx SpecContext) {
+		_, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		server.resourceVersionPrefix = "rv-"
+		server.create("pods", newPod("b"))
+		Eventually(func() error {
+			return informer.Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.Pod{})
+		}).Should(Succeed())
+
+		_, err = cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{})
+		Expect(err).To(MatchError(cache.ErrInconsistentSnapshot))
+
+		apiReader := fake.NewClientBuilder().WithObjects(newPod("a"), newPod("b"), newPod("c"), newConfigMap("a")).Build()
+		snapshot, err := cache.TakeSnapshot(ctx, informer, lists(), cache.SnapshotOptions{
+			APIReader: apiReader,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.FromAPIServer()).To(BeTrue())
+
+		pods := &corev1.PodList{}
+		Expect(snapshot.List(ctx, pods)).To(Succeed())
+		Expect(pods.Items).To(HaveLen(3))
+	})
+})
+
+// fakeResourceServer serves several resources from a single revision counter, like
+// an API server backed by etcd.
+type fakeResourceServer struct {
+	mu        sync.Mutex
+	revision  int
+	resources map[string]*fakeResource
+
+	// resourceVersionPrefix makes the resourceVersions of created objects uncomparable.
+	resourceVersionPrefix string
+}
+
+type fakeResource struct {
+	newObject func() client.Object
+	newList   func() client.ObjectList
+	objects   []client.Object
+	watchers  []*watch.FakeWatcher
+}
+
+func (s *fakeResourceServer) create(resource string, obj client.Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.revision++
+	obj.SetResourceVersion(s.resourceVersionPrefix + strconv.Itoa(s.revision))
+	r := s.resources[resource]
+	r.objects = append(r.objects, obj)
+	for _, w := range r.watchers {
+		w.Add(obj.DeepCopyObject())
+	}
+}
+
+func (s *fakeResourceServer) bookmark(resource string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.resources[resource]
+	obj := r.newObject()
+	obj.SetResourceVersion(strconv.Itoa(s.revision))
+	for _, w := range r.watchers {
+		w.Action(watch.Bookmark, obj)
+	}
+}
+
+func (s *fakeResourceServer) listerWatcher(obj runtime.Object) toolscache.ListerWatcher {
+	resource := "configmaps"
+	if _, isPod := obj.(*corev1.Pod); isPod {
+		resource = "pods"
+	}
+	return listWatchWithoutWatchList{&toolscache.ListWatch{
+		ListFunc: func(metav1.ListOptions) (runtime.Object, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			r := s.resources[resource]
+			list := r.newList()
+			items := make([]runtime.Object, 0, len(r.objects))
+			for _, obj := range r.objects {
+				items = append(items, obj.DeepCopyObject())
+			}
+			if err := apimeta.SetList(list, items); err != nil {
+				return nil, err
+			}
+			list.SetResourceVersion(strconv.Itoa(s.revision))
+			return list, nil
+		},
+		WatchFunc: func(metav1.ListOptions) (watch.Interface, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			w := watch.NewFakeWithChanSize(100, false)
+			s.resources[resource].watchers = append(s.resources[resource].watchers, w)
+			return w, nil
+		},
+	}}
+}
+
+// listWatchWithoutWatchList makes the reflector list and watch instead of streaming
+// the initial list through the watch, which the fake watchers don't support.
+type listWatchWithoutWatchList struct {
+	*toolscache.ListWatch
+}
+
+func (listWatchWithoutWatchList) IsWatchListSemanticsUnSupported() bool {
+	return true
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/internal"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var syncedLog = logf.RuntimeLog.WithName("cache").WithName("synced")
+
+// InformerSyncStatus is the sync status of the informer of a GVK, see SyncStatus.
+type InformerSyncStatus = internal.SyncStatus
+
+// syncStatuser is implemented by caches that report the sync status of their informers.
+type syncStatuser interface {
+	syncStatuses() []InformerSyncStatus
+}
+
+// SyncStatus returns the sync status of every informer of the cache, so that e.g.
+// readiness checks can tell which informers are not synced yet. It returns false
+// if the cache doesn't report the sync status of its informers, which is the case
+// for caches that were not created with New.
+func SyncStatus(c Cache) ([]InformerSyncStatus, bool) {
+	s, ok := c.(syncStatuser)
+	if !ok {
+		return nil, false
+	}
+	return s.syncStatuses(), true
+}
+
+// WaitForCacheSyncWithProgress is like WaitForCacheSync, but calls report with the
+// sync status of every informer of the cache every interval while it waits, and once
+// more when all informers synced or ctx is done. It allows to log which informers
+// are slow to sync.
+//
+// If the cache doesn't report the sync status of its informers, see SyncStatus, it
+// waits for the cache to sync without calling report.
+func WaitForCacheSyncWithProgress(ctx context.Context, c Cache, interval time.Duration, report func([]InformerSyncStatus)) bool {
+	if _, ok := c.(syncStatuser); !ok {
+		return c.WaitForCacheSync(ctx)
+	}
+
+	synced := make(chan bool, 1)
+	go func() {
+		synced <- c.WaitForCacheSync(ctx)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ok := <-synced:
+			statuses, _ := SyncStatus(c)
+			report(statuses)
+			return ok
+		case <-ticker.C:
+			statuses, _ := SyncStatus(c)
+			report(statuses)
+		}
+	}
+}
+
+// SyncedFunc is called by OnSynced once the informer of a type synced, with the number
+// of objects the informer had when it synced.
+type SyncedFunc func(ctx context.Context, count int)
+
+// OnSynced calls f once the informer for obj synced, creating the informer if it
+// doesn't exist yet, and calls it right away if the informer synced already. It allows
+// to defer initializing a component until the objects of the types it needs are in the
+// cache, e.g. to build lookup tables, without waiting for the whole cache to sync.
+//
+// OnSynced doesn't block, f is called in its own goroutine. It isn't called if ctx is
+// done before the informer synced.
+func OnSynced(ctx context.Context, informers Informers, obj client.Object, f SyncedFunc) error {
+	var count atomic.Int64
+	registration, err := AddEventHandler(ctx, informers, obj, toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ any, isInInitialList bool) {
+			if isInInitialList {
+				count.Add(1)
+			}
+		},
+	}, toolscache.HandlerOptions{})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer func() {
+			if err := registration.Remove(); err != nil {
+				syncedLog.Error(err, "Failed to remove the event handler that counted the objects of the informer")
+			}
+		}()
+		select {
+		case <-registration.HasSyncedChecker().Done():
+			f(ctx, int(count.Load()))
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("WaitForCacheSyncWithProgress", func() {
+	It("should report the sync status of the informers of every namespace", func(ctx SpecContext) {
+		server := &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods": {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+		}}
+		podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(podGVK, apimeta.RESTScopeNamespace)
+
+		c, err := cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper:            mapper,
+			DefaultNamespaces: map[string]cache.Config{"default": {}, "kube-system": {}},
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.GetInformer(ctx, &corev1.Pod{})
+		Expect(err).NotTo(HaveOccurred())
+
+		statuses, ok := cache.SyncStatus(c)
+		Expect(ok).To(BeTrue())
+		Expect(statuses).To(ConsistOf(
+			cache.InformerSyncStatus{GVK: podGVK, Namespace: "default"},
+			cache.InformerSyncStatus{GVK: podGVK, Namespace: "kube-system"},
+		))
+
+		cacheCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(c.Start(cacheCtx)).To(Succeed())
+		}()
+
+		var reports [][]cache.InformerSyncStatus
+		Expect(cache.WaitForCacheSyncWithProgress(ctx, c, 10*time.Millisecond, func(statuses []cache.InformerSyncStatus) {
+			reports = append(reports, statuses)
+		})).To(BeTrue())
+		Expect(reports).NotTo(BeEmpty())
+		Expect(reports[len(reports)-1]).To(HaveLen(2))
+		for _, status := range reports[len(reports)-1] {
+			Expect(status.Synced).To(BeTrue())
+		}
+	})
+
+	It("should wait for caches that don't report their sync status", func(ctx SpecContext) {
+		c := &informertest.FakeInformers{}
+		_, ok := cache.SyncStatus(c)
+		Expect(ok).To(BeFalse())
+		Expect(cache.WaitForCacheSyncWithProgress(ctx, c, time.Millisecond, func([]cache.InformerSyncStatus) {
+			Fail("Did not expect the sync status to be reported")
+		})).To(BeTrue())
+	})
+})
+
+var _ = Describe("OnSynced", func() {
+	It("should call the function with the number of objects once the informer synced", func(specCtx SpecContext) {
+		server := &fakeResourceServer{resources: map[string]*fakeResource{
+			"pods":       {newObject: func() client.Object { return &corev1.Pod{} }, newList: func() client.ObjectList { return &corev1.PodList{} }},
+			"configmaps": {newObject: func() client.Object { return &corev1.ConfigMap{} }, newList: func() client.ObjectList { return &corev1.ConfigMapList{} }},
+		}}
+		server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}})
+		server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}})
+		mapper := apimeta.NewDefaultRESTMapper(nil)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+		mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+
+		informers, err := cache.New(&rest.Config{Host: "http://127.0.0.1:1"}, cache.Options{
+			Mapper: mapper,
+			NewInformer: func(_ toolscache.ListerWatcher, obj runtime.Object, resync time.Duration, indexers toolscache.Indexers) toolscache.SharedIndexInformer {
+				return toolscache.NewSharedIndexInformer(server.listerWatcher(obj), obj, resync, indexers)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		podsSynced := make(chan int, 1)
+		Expect(cache.OnSynced(specCtx, informers, &corev1.Pod{}, func(_ context.Context, count int) {
+			podsSynced <- count
+		})).To(Succeed())
+		cancelledCtx, cancel := context.WithCancel(specCtx)
+		cancel()
+		configMapsSynced := make(chan int, 1)
+		Expect(cache.OnSynced(cancelledCtx, informers, &corev1.ConfigMap{}, func(_ context.Context, count int) {
+			configMapsSynced <- count
+		})).To(Succeed())
+		Consistently(podsSynced, 100*time.Millisecond).ShouldNot(Receive())
+
+		ctx, stop := context.WithCancel(context.Background())
+		DeferCleanup(stop)
+		go func() {
+			defer GinkgoRecover()
+			Expect(informers.Start(ctx)).To(Succeed())
+		}()
+		Eventually(podsSynced).Should(Receive(Equal(2)))
+		Expect(informers.WaitForCacheSync(specCtx)).To(BeTrue())
+		Consistently(configMapsSynced, 100*time.Millisecond).ShouldNot(Receive())
+
+		By("calling the function right away if the informer synced already")
+		server.create("pods", &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "c"}})
+		Eventually(func(g Gomega) {
+			pods := &corev1.PodList{}
+			g.Expect(informers.List(specCtx, pods)).To(Succeed())
+			g.Expect(pods.Items).To(HaveLen(3))
+		}).Should(Succeed())
+		Expect(cache.OnSynced(specCtx, informers, &corev1.Pod{}, func(_ context.Context, count int) {
+			podsSynced <- count
+		})).To(Succeed())
+		Eventually(podsSynced).Should(Receive(Equal(3)))
+	})
+})
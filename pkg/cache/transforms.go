@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// TransformChain returns a transform that applies the given transforms in order, e.g.
+// to strip the managed fields and the last applied configuration of all objects with
+// a single DefaultTransform. Nil transforms are skipped.
+func TransformChain(transforms ...toolscache.TransformFunc) toolscache.TransformFunc {
+	return func(in any) (any, error) {
+		var err error
+		for _, transform := range transforms {
+			if transform == nil {
+				continue
+			}
+			if in, err = transform(in); err != nil {
+				return nil, err
+			}
+		}
+		return in, nil
+	}
+}
+
+// TransformStripLastAppliedConfiguration strips the kubectl.kubernetes.io/last-applied-configuration
+// annotation of an object before it is committed to the cache. The annotation holds a copy of the
+// whole object for objects that were applied with kubectl, which often doubles their size.
+func TransformStripLastAppliedConfiguration() toolscache.TransformFunc {
+	return func(in any) (any, error) {
+		if obj, err := meta.Accessor(in); err == nil {
+			if annotations := obj.GetAnnotations(); annotations != nil {
+				if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+					delete(annotations, corev1.LastAppliedConfigAnnotation)
+					obj.SetAnnotations(annotations)
+				}
+			}
+		}
+
+		return in, nil
+	}
+}
+
+// TransformKeepSpecAndMetadata drops all fields of an object except its metadata and its spec
+// before it is committed to the cache, e.g. the status of objects whose status is never read.
+// Objects without a spec, like ConfigMaps, are reduced to their metadata.
+func TransformKeepSpecAndMetadata() toolscache.TransformFunc {
+	return TransformProjectFields("spec")
+}
+
+// TransformProjectFields drops all fields of an object except the given ones before it is
+// committed to the cache. Fields are given as dot-separated paths like "spec.replicas" or
+// "status.conditions". The type meta and the metadata of the object are always kept, as
+// the cache needs them.
+//
+// Typed objects are converted to unstructured to project them and converted back, the
+// fields that are not kept are zero afterwards.
+func TransformProjectFields(paths ...string) toolscache.TransformFunc {
+	fields := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		fields = append(fields, strings.Split(path, "."))
+	}
+
+	return func(in any) (any, error) {
+		switch obj := in.(type) {
+		case *unstructured.Unstructured:
+			obj.Object = projectFields(obj.Object, fields)
+			return obj, nil
+		case runtime.Object:
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert %T to unstructured: %w", obj, err)
+			}
+			out := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(projectFields(content, fields), out); err != nil {
+				return nil, fmt.Errorf("failed to convert unstructured to %T: %w", obj, err)
+			}
+			return out, nil
+		}
+
+		return in, nil
+	}
+}
+
+// projectFields returns the given fields of content, together with its type meta and metadata.
+func projectFields(content map[string]any, fields [][]string) map[string]any {
+	projected := make(map[string]any, len(fields)+3)
+	for _, key := range []string{"apiVersion", "kind", "metadata"} {
+		if value, ok := content[key]; ok {
+			projected[key] = value
+		}
+	}
+	for _, field := range fields {
+		value, found, err := unstructured.NestedFieldNoCopy(content, field...)
+		if err != nil || !found {
+			continue
+		}
+		setNestedField(projected, value, field)
+	}
+	return projected
+}
+
+// setNestedField sets the field at the given path of content to value without copying it,
+// creating the maps on the way as needed.
+func setNestedField(content map[string]any, value any, field []string) {
+	for _, key := range field[:len(field)-1] {
+		next, ok := content[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			content[key] = next
+		}
+		content = next
+	}
+	content[field[len(field)-1]] = value
+}
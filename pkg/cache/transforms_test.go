@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+var _ = Describe("Transforms", func() {
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: "{}",
+					"keep":                             "me",
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "foo"}},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: new(int32(3)),
+				Paused:   true,
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 3},
+		}
+	}
+
+	Describe("TransformStripLastAppliedConfiguration", func() {
+		It("should strip the last applied configuration", func() {
+			transformed, err := cache.TransformStripLastAppliedConfiguration()(newDeployment())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transformed.(*appsv1.Deployment).Annotations).To(Equal(map[string]string{"keep": "me"}))
+		})
+
+		It("should not trip over an unexpected object", func() {
+			transformed, err := cache.TransformStripLastAppliedConfiguration()("foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transformed).To(Equal("foo"))
+		})
+	})
+
+	Describe("TransformKeepSpecAndMetadata", func() {
+		It("should drop the status of typed objects", func() {
+			expected := newDeployment()
+			expected.Status = appsv1.DeploymentStatus{}
+
+			transformed, err := cache.TransformKeepSpecAndMetadata()(newDeployment())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transformed).To(Equal(expected))
+		})
+
+		It("should drop everything but the spec and metadata of unstructured objects", func() {
+			obj := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.com/v1",
+				"kind":       "Example",
+				"metadata":   map[string]any{"name": "test"},
+				"spec":       map[string]any{"size": int64(1)},
+				"status":     map[string]any{"ready": true},
+				"data":       map[string]any{"key": "value"},
+			}}
+
+			transformed, err := cache.TransformKeepSpecAndMetadata()(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transformed.(*unstructured.Unstructured).Object).To(Equal(map[string]any{
+				"apiVersion": "example.com/v1",
+				"kind":       "Example",
+				"metadata":   map[string]any{"name": "test"},
+				"spec":       map[string]any{"size": int64(1)},
+			}))
+		})
+	})
+
+	Describe("TransformProjectFields", func() {
+		It("should keep only the given fields and the metadata", func() {
+			transformed, err := cache.TransformProjectFields("spec.replicas", "status.readyReplicas", "spec.missing.field")(newDeployment())
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := newDeployment()
+			expected.Spec = appsv1.DeploymentSpec{Replicas: new(int32(3))}
+			Expect(transformed).To(Equal(expected))
+		})
+
+		It("should not trip over an unexpected object", func() {
+			transformed, err := cache.TransformProjectFields("spec")("foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transformed).To(Equal("foo"))
+		})
+	})
+
+	Describe("TransformChain", func() {
+		It("should apply all transforms in order", func() {
+			transformed, err := cache.TransformChain(
+				cache.TransformStripManagedFields(),
+				nil,
+				cache.TransformStripLastAppliedConfiguration(),
+			)(newDeployment())
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := newDeployment()
+			expected.ManagedFields = nil
+			expected.Annotations = map[string]string{"keep": "me"}
+			Expect(transformed).To(Equal(expected))
+		})
+
+		It("should stop at the first error", func() {
+			expectedErr := errors.New("expected error")
+			_, err := cache.TransformChain(
+				func(any) (any, error) { return nil, expectedErr },
+				func(any) (any, error) {
+					Fail("Did not expect the second transform to be called")
+					return nil, nil
+				},
+			)(newDeployment())
+			Expect(err).To(MatchError(expectedErr))
+		})
+	})
+})
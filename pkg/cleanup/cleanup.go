@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ErrNoScope is returned by Add if the context isn't scoped by NewContext, e.g. because it
+// isn't the context of a reconciliation.
+var ErrNoScope = errors.New("context has no cleanup scope")
+
+// Func is a cleanup function, see Add.
+type Func func(ctx context.Context) error
+
+// scopeKey is a context.Context Value key. Its associated value is a *scopeContext.
+type scopeKey struct{}
+
+// scopeContext holds the cleanup functions of a scope. It is the context of the scope
+// itself, so that scoping a context only allocates once.
+type scopeContext struct {
+	context.Context
+
+	mu  sync.Mutex
+	fns []Func
+	ran bool
+}
+
+func (s *scopeContext) Value(key any) any {
+	if key == (scopeKey{}) {
+		return s
+	}
+	return s.Context.Value(key)
+}
+
+// NewContext returns a context with a new cleanup scope, and a function that runs the cleanup
+// functions that were added to it. Controllers call it for every reconciliation, it only has
+// to be used directly to scope cleanups to something else.
+//
+// The returned function must be called once the scope ends, it runs the cleanup functions in
+// the reverse order of their registration and returns their joined errors. Functions that are
+// added afterwards run immediately.
+func NewContext(ctx context.Context) (context.Context, func() error) {
+	s := &scopeContext{Context: ctx}
+	return s, s.run
+}
+
+func (s *scopeContext) run() error {
+	s.mu.Lock()
+	fns := s.fns
+	s.fns = nil
+	s.ran = true
+	s.mu.Unlock()
+
+	ctx := context.WithoutCancel(s.Context)
+	var errs []error
+	for _, fn := range slices.Backward(fns) {
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Add registers fn to run when the scope of ctx ends, e.g. once the current reconciliation
+// returned. It returns ErrNoScope if ctx has no cleanup scope.
+func Add(ctx context.Context, fn Func) error {
+	s, ok := ctx.Value(scopeKey{}).(*scopeContext)
+	if !ok {
+		return ErrNoScope
+	}
+
+	s.mu.Lock()
+	if !s.ran {
+		s.fns = append(s.fns, fn)
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+
+	// The scope ended already, e.g. because a goroutine of the reconciliation
+	// outlived it, don't leak the resource.
+	if err := fn(context.WithoutCancel(ctx)); err != nil {
+		logf.FromContext(ctx).Error(err, "Cleanup failed")
+	}
+	return nil
+}
+
+// MkdirTemp creates a new temporary directory like os.MkdirTemp in the default directory
+// for temporary files, and removes it with all its contents when the scope of ctx ends.
+func MkdirTemp(ctx context.Context, pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	if err := Add(ctx, func(context.Context) error { return os.RemoveAll(dir) }); err != nil {
+		return "", errors.Join(err, os.RemoveAll(dir))
+	}
+	return dir, nil
+}
+
+// CreateTemp creates a new temporary file like os.CreateTemp in the default directory for
+// temporary files, and closes and removes it when the scope of ctx ends.
+func CreateTemp(ctx context.Context, pattern string) (*os.File, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+	remove := func(context.Context) error {
+		// The file may have been closed by the caller already.
+		if err := f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			return errors.Join(err, os.Remove(f.Name()))
+		}
+		return os.Remove(f.Name())
+	}
+	if err := Add(ctx, remove); err != nil {
+		return nil, errors.Join(err, remove(ctx))
+	}
+	return f, nil
+}
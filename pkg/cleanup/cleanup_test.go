@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cleanup", func() {
+	It("should run the cleanup functions in reverse order once the scope ends", func(specCtx SpecContext) {
+		ctx, run := NewContext(specCtx)
+		var ran []int
+		for i := range 3 {
+			Expect(Add(ctx, func(context.Context) error {
+				ran = append(ran, i)
+				return nil
+			})).To(Succeed())
+		}
+		Expect(ran).To(BeEmpty())
+
+		Expect(run()).To(Succeed())
+		Expect(ran).To(Equal([]int{2, 1, 0}))
+	})
+
+	It("should run all cleanup functions and return their errors", func(specCtx SpecContext) {
+		ctx, run := NewContext(specCtx)
+		errA, errB := errors.New("a"), errors.New("b")
+		ran := false
+		Expect(Add(ctx, func(context.Context) error { return errA })).To(Succeed())
+		Expect(Add(ctx, func(context.Context) error { ran = true; return nil })).To(Succeed())
+		Expect(Add(ctx, func(context.Context) error { return errB })).To(Succeed())
+
+		err := run()
+		Expect(err).To(MatchError(errA))
+		Expect(err).To(MatchError(errB))
+		Expect(ran).To(BeTrue())
+	})
+
+	It("should run the cleanup functions with a context that isn't cancelled", func(specCtx SpecContext) {
+		cancelled, cancel := context.WithCancel(specCtx)
+		ctx, run := NewContext(cancelled)
+		Expect(Add(ctx, func(ctx context.Context) error { return ctx.Err() })).To(Succeed())
+
+		cancel()
+		Expect(run()).To(Succeed())
+	})
+
+	It("should run cleanup functions that are added after the scope ended immediately", func(specCtx SpecContext) {
+		ctx, run := NewContext(specCtx)
+		Expect(run()).To(Succeed())
+
+		ran := false
+		Expect(Add(ctx, func(context.Context) error { ran = true; return nil })).To(Succeed())
+		Expect(ran).To(BeTrue())
+	})
+
+	It("should fail to add cleanup functions without a scope", func(ctx SpecContext) {
+		Expect(Add(ctx, func(context.Context) error { return nil })).To(MatchError(ErrNoScope))
+
+		_, err := MkdirTemp(ctx, "cleanup-")
+		Expect(err).To(MatchError(ErrNoScope))
+	})
+
+	It("should remove temporary directories and files once the scope ends", func(specCtx SpecContext) {
+		ctx, run := NewContext(specCtx)
+		dir, err := MkdirTemp(ctx, "cleanup-")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0o600)).To(Succeed())
+
+		f, err := CreateTemp(ctx, "cleanup-")
+		Expect(err).NotTo(HaveOccurred())
+		closed, err := CreateTemp(ctx, "cleanup-")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(closed.Close()).To(Succeed())
+
+		Expect(run()).To(Succeed())
+		Expect(dir).NotTo(BeADirectory())
+		Expect(f.Name()).NotTo(BeAnExistingFile())
+		Expect(closed.Name()).NotTo(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cleanup runs cleanup functions at the end of a reconciliation, also if the Reconciler
+returns early because of an error or panics. It is meant for reconcilers that shell out or
+render files, which otherwise leak temporary files and directories on every failed attempt.
+
+Controllers of controller-runtime scope a registry to the context of every reconciliation.
+Reconcilers register cleanup functions on it:
+
+	dir, err := cleanup.MkdirTemp(ctx, "render-")
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// dir is removed once Reconcile returned.
+
+	if err := cleanup.Add(ctx, func(ctx context.Context) error {
+		return releaseLease(ctx)
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+Cleanup functions run in the reverse order of their registration, with a context that is not
+cancelled when the reconciliation times out. Their errors are logged with the logger of the
+reconciliation.
+*/
+package cleanup
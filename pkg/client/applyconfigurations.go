@@ -17,12 +17,22 @@ limitations under the License.
 package client
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	clientgoapplyconfigurations "k8s.io/client-go/applyconfigurations"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/typed"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
 type unstructuredApplyConfiguration struct {
@@ -39,6 +49,137 @@ func ApplyConfigurationFromUnstructured(u *unstructured.Unstructured) runtime.Ap
 	return &unstructuredApplyConfiguration{Unstructured: u}
 }
 
+// ApplyConfigurationFromObject creates a runtime.ApplyConfiguration from a typed or
+// unstructured object, so that the fields it sets can be applied with server-side apply
+// without hand-writing an unstructured apply configuration. Its apiVersion and kind are
+// looked up in scheme.
+//
+// The apply configuration contains every field of obj that is serialized, which for
+// typed objects includes the zero values of fields without omitempty. The field manager
+// of the apply takes ownership of all of them, so obj should be built from scratch with
+// only the fields the field manager cares about, never read from the API server. Its
+// status and the metadata that is set by the API server, e.g. its resourceVersion, uid
+// and managedFields, are dropped.
+func ApplyConfigurationFromObject(scheme *runtime.Scheme, obj Object) (runtime.ApplyConfiguration, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]any
+	if u, ok := obj.(runtime.Unstructured); ok {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %T to unstructured: %w", obj, err)
+		}
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	delete(u.Object, "status")
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	return &unstructuredApplyConfiguration{Unstructured: u}, nil
+}
+
+// ApplyObject applies the fields obj sets with server-side apply, see
+// ApplyConfigurationFromObject, and updates obj with the object returned by the API
+// server. A FieldManager has to be passed in opts unless the client has a default one,
+// see WithFieldOwner.
+func ApplyObject(ctx context.Context, c Client, obj Object, opts ...ApplyOption) error {
+	ac, err := ApplyConfigurationFromObject(c.Scheme(), obj)
+	if err != nil {
+		return err
+	}
+	if err := c.Apply(ctx, ac, opts...); err != nil {
+		return err
+	}
+	applied := ac.(*unstructuredApplyConfiguration).Unstructured
+	if u, ok := obj.(runtime.Unstructured); ok {
+		u.SetUnstructuredContent(applied.UnstructuredContent())
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(applied.UnstructuredContent(), obj)
+}
+
+// defaultTypeConverters are the type converters ExtractApplyConfiguration uses by default.
+var defaultTypeConverters = sync.OnceValue(func() []managedfields.TypeConverter {
+	// The converter of client-go needs a scheme with only its types, so that it
+	// errors for other types instead of using the wrong schema.
+	clientGoScheme := runtime.NewScheme()
+	if err := scheme.AddToScheme(clientGoScheme); err != nil {
+		panic(fmt.Sprintf("failed to construct client-go scheme: %v", err))
+	}
+	return []managedfields.TypeConverter{
+		clientgoapplyconfigurations.NewTypeConverter(clientGoScheme),
+		managedfields.NewDeducedTypeConverter(),
+	}
+})
+
+// ExtractApplyConfiguration extracts the fields fieldManager applied to obj, e.g. an
+// object read from the cache, into an apply configuration. It is the generic
+// counterpart of the Extract functions of the generated apply configurations: the
+// result can be modified and applied again by the same field manager, which keeps
+// ownership of the fields it doesn't change and releases the ones it removes. The
+// apply configuration has the name and namespace of obj, and nothing else if
+// fieldManager didn't apply anything to obj yet. obj must have managedFields, which
+// caches that strip them, e.g. with a transform, don't have.
+//
+// The first of typeConverters that can convert obj is used to find the structure of
+// its fields. They default to the type converter of the built-in types of client-go
+// followed by a type converter that deduces the structure of the fields, which treats
+// lists as atomic and isn't accurate for custom resources with merged lists. Type
+// converters for custom resources are generated along with their apply configurations.
+func ExtractApplyConfiguration(scheme *runtime.Scheme, obj Object, fieldManager string, typeConverters ...managedfields.TypeConverter) (runtime.ApplyConfiguration, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	if len(typeConverters) == 0 {
+		typeConverters = defaultTypeConverters()
+	}
+
+	// The type converters need the GVK of obj, which typed objects usually don't have.
+	withGVK := obj.DeepCopyObject().(Object)
+	withGVK.GetObjectKind().SetGroupVersionKind(gvk)
+	var (
+		value   *typed.TypedValue
+		convErr error
+	)
+	for _, typeConverter := range typeConverters {
+		value, convErr = typeConverter.ObjectToTyped(withGVK, typed.AllowDuplicates)
+		if convErr == nil {
+			break
+		}
+	}
+	if convErr != nil {
+		return nil, fmt.Errorf("failed to convert %s to a typed value: %w", gvk, convErr)
+	}
+
+	u := &unstructured.Unstructured{Object: map[string]any{}}
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fieldset := &fieldpath.Set{}
+		if err := fieldset.FromJSON(entry.FieldsV1.GetRawReader()); err != nil {
+			return nil, fmt.Errorf("failed to parse the managed fields of %s: %w", fieldManager, err)
+		}
+		extracted, ok := value.ExtractItems(fieldset.Leaves()).AsValue().Unstructured().(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("failed to extract the fields of %s from %s", fieldManager, gvk)
+		}
+		u.Object = extracted
+		break
+	}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(obj.GetName())
+	u.SetNamespace(obj.GetNamespace())
+	return &unstructuredApplyConfiguration{Unstructured: u}, nil
+}
+
 func gvkFromApplyConfiguration(ac applyConfiguration) (schema.GroupVersionKind, error) {
 	var gvk schema.GroupVersionKind
 	gv, err := schema.ParseGroupVersion(ptr.Deref(ac.GetAPIVersion(), ""))
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func desiredDeployment(replicas int32, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app", Labels: map[string]string{"app": "app"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "app"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			},
+		},
+	}
+}
+
+func TestApplyConfigurationFromObject(t *testing.T) {
+	deployment := desiredDeployment(1, "app:v1")
+	deployment.ResourceVersion = "5"
+	deployment.UID = "uid"
+	deployment.Status.Replicas = 1
+
+	ac, err := client.ApplyConfigurationFromObject(scheme.Scheme, deployment)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u := ac.(interface{ UnstructuredContent() map[string]any }).UnstructuredContent()
+	if u["apiVersion"] != "apps/v1" || u["kind"] != "Deployment" {
+		t.Errorf("expected the apply configuration to have the apiVersion and kind of a Deployment, got %v, %v", u["apiVersion"], u["kind"])
+	}
+	if _, ok := u["status"]; ok {
+		t.Error("expected the status to be dropped")
+	}
+	metadata := u["metadata"].(map[string]any)
+	for _, field := range []string{"resourceVersion", "uid", "creationTimestamp"} {
+		if _, ok := metadata[field]; ok {
+			t.Errorf("expected metadata.%s to be dropped", field)
+		}
+	}
+	if deployment.ResourceVersion != "5" {
+		t.Error("expected the object not to be modified")
+	}
+}
+
+func TestApplyObjectAndExtractApplyConfiguration(t *testing.T) {
+	ctx := t.Context()
+	c := fake.NewClientBuilder().WithReturnManagedFields().Build()
+
+	deployment := desiredDeployment(2, "app:v1")
+	if err := client.ApplyObject(ctx, c, deployment, client.FieldOwner("controller")); err != nil {
+		t.Fatalf("failed to apply: %v", err)
+	}
+	if deployment.ResourceVersion == "" {
+		t.Error("expected the deployment to be updated with the applied object")
+	}
+
+	patch := client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"annotations":{"owner":"other"}}}`))
+	if err := c.Patch(ctx, deployment.DeepCopy(), patch, client.FieldOwner("other")); err != nil {
+		t.Fatalf("failed to patch as another field manager: %v", err)
+	}
+
+	cached := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(deployment), cached); err != nil {
+		t.Fatal(err)
+	}
+	ac, err := client.ExtractApplyConfiguration(c.Scheme(), cached, "controller")
+	if err != nil {
+		t.Fatalf("failed to extract: %v", err)
+	}
+	extracted := ac.(interface{ UnstructuredContent() map[string]any }).UnstructuredContent()
+	if diff := cmp.Diff(map[string]any{"app": "app"}, extracted["metadata"].(map[string]any)["labels"]); diff != "" {
+		t.Errorf("unexpected labels (-want +got):\n%s", diff)
+	}
+	if _, ok := extracted["metadata"].(map[string]any)["annotations"]; ok {
+		t.Error("expected the annotations of another field manager not to be extracted")
+	}
+	spec := extracted["spec"].(map[string]any)
+	if spec["replicas"] != int64(2) {
+		t.Errorf("expected 2 replicas, got %v", spec["replicas"])
+	}
+	// Typed objects serialize empty structs, e.g. the resources of containers, so the
+	// field manager owns them as well.
+	expectedContainers := []any{map[string]any{"name": "app", "image": "app:v1", "resources": map[string]any{}}}
+	if diff := cmp.Diff(expectedContainers, spec["template"].(map[string]any)["spec"].(map[string]any)["containers"]); diff != "" {
+		t.Errorf("unexpected containers (-want +got):\n%s", diff)
+	}
+
+	expectedContainers[0].(map[string]any)["image"] = "app:v2"
+	if err := unstructured.SetNestedSlice(extracted, expectedContainers, "spec", "template", "spec", "containers"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Apply(ctx, ac, client.FieldOwner("controller")); err != nil {
+		t.Fatalf("failed to apply the extracted apply configuration: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(deployment), cached); err != nil {
+		t.Fatal(err)
+	}
+	if image := cached.Spec.Template.Spec.Containers[0].Image; image != "app:v2" {
+		t.Errorf("expected the image to be updated, got %s", image)
+	}
+	if owner := cached.Annotations["owner"]; owner != "other" {
+		t.Errorf("expected the annotation of another field manager to be kept, got %q", owner)
+	}
+
+	none, err := client.ExtractApplyConfiguration(c.Scheme(), cached, "nobody")
+	if err != nil {
+		t.Fatalf("failed to extract: %v", err)
+	}
+	expectedNone := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"namespace": "default", "name": "app"},
+	}
+	if diff := cmp.Diff(expectedNone, none.(interface{ UnstructuredContent() map[string]any }).UnstructuredContent()); diff != "" {
+		t.Errorf("unexpected apply configuration of a field manager without fields (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	defaultGetBatchingWindow  = 10 * time.Millisecond
+	defaultGetBatchingTimeout = 30 * time.Second
+)
+
+// GetBatchingOptions configures WithGetBatching.
+type GetBatchingOptions struct {
+	// Window is the duration for which Gets for objects of the same kind are
+	// collected before they are sent. Defaults to 10 milliseconds.
+	Window time.Duration
+
+	// ListThreshold is the number of distinct objects of the same kind and
+	// namespace that have to be requested within a window for them to be read
+	// with a single List of the namespace instead of a Get each. Zero disables
+	// reading objects with a List.
+	ListThreshold int
+
+	// Timeout bounds the reads of a batch, which are not cancelled when the Gets
+	// waiting for them give up. They are bound by the latest deadline of these Gets
+	// as well. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// WithGetBatching wraps a Client and coalesces the Gets for objects of the same kind
+// that are issued concurrently, e.g. by the workers of a controller that all read a
+// shared ConfigMap. Gets are collected for a short window, after which every distinct
+// object is read once and handed to all the Gets that requested it. If enough distinct
+// objects of the same namespace are requested within a window, they are read with a
+// single List instead, see GetBatchingOptions.ListThreshold.
+//
+// This is meant for clients that read from the API server, e.g. the APIReader of a
+// Manager, reads from a cache are cheap enough already. Gets with options are not
+// batched, and all other methods are passed to c as they are. Gets can return an
+// object that is up to one window older than with c, and each Get waits for up to
+// one window longer.
+func WithGetBatching(c Client, opts GetBatchingOptions) Client {
+	if opts.Window <= 0 {
+		opts.Window = defaultGetBatchingWindow
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultGetBatchingTimeout
+	}
+	return &clientWithGetBatching{
+		Client:  c,
+		opts:    opts,
+		batches: map[getBatchKey]*getBatch{},
+	}
+}
+
+type clientWithGetBatching struct {
+	Client
+	opts GetBatchingOptions
+
+	// mu guards batches, which holds the batches that are still collecting Gets.
+	mu      sync.Mutex
+	batches map[getBatchKey]*getBatch
+}
+
+// getBatchKey identifies the Gets that can be batched together, i.e. the ones for
+// objects of the same kind and namespace that are read in the same form.
+type getBatchKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	objType   reflect.Type
+}
+
+type getBatch struct {
+	key getBatchKey
+	// ctx is the context of the first Get of the batch without its cancellation,
+	// so that Gets that give up waiting don't fail the Gets that wait for the
+	// same objects. It is bound by deadline and GetBatchingOptions.Timeout when
+	// the batch is sent.
+	ctx context.Context
+	// deadline is the latest deadline of the Gets of the batch, or zero if one of
+	// them has none.
+	deadline time.Time
+	// results holds the result of every requested object by name, it must only
+	// be read once done is closed.
+	results map[string]*getResult
+	done    chan struct{}
+}
+
+type getResult struct {
+	obj runtime.Object
+	err error
+}
+
+func (c *clientWithGetBatching) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
+	if len(opts) > 0 {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+
+	batch := c.addToBatch(ctx, getBatchKey{gvk: gvk, namespace: key.Namespace, objType: reflect.TypeOf(obj)}, key.Name, obj)
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	result := batch.results[key.Name]
+	if result.err != nil {
+		return result.err
+	}
+	copyInto(obj, result.obj.DeepCopyObject().(Object))
+	return nil
+}
+
+// addToBatch adds a Get for the object with the given name to the batch of key,
+// and starts a batch if there is none.
+func (c *clientWithGetBatching) addToBatch(ctx context.Context, key getBatchKey, name string, obj Object) *getBatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline, hasDeadline := ctx.Deadline()
+	batch, ok := c.batches[key]
+	if !ok {
+		batch = &getBatch{
+			key:      key,
+			ctx:      context.WithoutCancel(ctx),
+			deadline: deadline,
+			results:  map[string]*getResult{},
+			done:     make(chan struct{}),
+		}
+		c.batches[key] = batch
+		template := obj.DeepCopyObject().(Object)
+		time.AfterFunc(c.opts.Window, func() {
+			c.mu.Lock()
+			delete(c.batches, key)
+			c.mu.Unlock()
+			c.send(batch, template)
+		})
+	}
+	switch {
+	case !hasDeadline:
+		batch.deadline = time.Time{}
+	case !batch.deadline.IsZero() && deadline.After(batch.deadline):
+		batch.deadline = deadline
+	}
+	batch.results[name] = nil
+	return batch
+}
+
+// send reads the objects of the batch and hands them to the Gets waiting for them.
+func (c *clientWithGetBatching) send(batch *getBatch, template Object) {
+	defer close(batch.done)
+
+	var cancel context.CancelFunc
+	batch.ctx, cancel = context.WithTimeout(batch.ctx, c.opts.Timeout)
+	defer cancel()
+	if !batch.deadline.IsZero() {
+		batch.ctx, cancel = context.WithDeadline(batch.ctx, batch.deadline)
+		defer cancel()
+	}
+
+	if c.opts.ListThreshold > 0 && len(batch.results) >= c.opts.ListThreshold {
+		if err := c.sendList(batch, template); err == nil {
+			return
+		}
+		// Fall back to reading the objects one by one, e.g. because the
+		// kind can't be listed.
+	}
+
+	results := make([]getResult, len(batch.results))
+	names := slices.Collect(maps.Keys(batch.results))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Go(func() {
+			obj := template.DeepCopyObject().(Object)
+			err := c.Client.Get(batch.ctx, ObjectKey{Namespace: batch.key.namespace, Name: name}, obj)
+			results[i] = getResult{obj: obj, err: err}
+		})
+	}
+	wg.Wait()
+	for i, name := range names {
+		batch.results[name] = &results[i]
+	}
+}
+
+// sendList reads the objects of the batch with a List of their namespace.
+func (c *clientWithGetBatching) sendList(batch *getBatch, template Object) error {
+	list, err := c.newList(batch.key.gvk, template)
+	if err != nil {
+		return err
+	}
+	if err := c.Client.List(batch.ctx, list, InNamespace(batch.key.namespace)); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]runtime.Object, len(items))
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		// Lists with an empty namespace are across all namespaces, so they can
+		// contain objects of the same name in other namespaces.
+		if accessor.GetNamespace() != batch.key.namespace {
+			continue
+		}
+		byName[accessor.GetName()] = item
+	}
+	for name := range batch.results {
+		item, ok := byName[name]
+		if !ok {
+			batch.results[name] = &getResult{err: apierrors.NewNotFound(c.groupResource(batch.key.gvk), name)}
+			continue
+		}
+		// Items of typed lists lack their TypeMeta, set it as a Get would.
+		item.GetObjectKind().SetGroupVersionKind(batch.key.gvk)
+		batch.results[name] = &getResult{obj: item}
+	}
+	return nil
+}
+
+// groupResource returns the resource of the given kind for NotFound errors.
+func (c *clientWithGetBatching) groupResource(gvk schema.GroupVersionKind) schema.GroupResource {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		return plural.GroupResource()
+	}
+	return mapping.Resource.GroupResource()
+}
+
+// newList returns an empty list for objects of the given kind in the form of template.
+func (c *clientWithGetBatching) newList(gvk schema.GroupVersionKind, template Object) (ObjectList, error) {
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	switch template.(type) {
+	case *unstructured.Unstructured:
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(listGVK)
+		return list, nil
+	case *metav1.PartialObjectMetadata:
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(listGVK)
+		return list, nil
+	}
+	obj, err := c.Scheme().New(listGVK)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := obj.(ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.ObjectList", obj)
+	}
+	return list, nil
+}
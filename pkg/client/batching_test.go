@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func batchingTestClient(gets, lists *atomic.Int32) client.Client {
+	return fake.NewClientBuilder().
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}, Data: map[string]string{"key": "a"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}, Data: map[string]string{"key": "b"}},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				gets.Add(1)
+				return c.Get(ctx, key, obj, opts...)
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists.Add(1)
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+}
+
+func TestWithGetBatchingCoalescesGets(t *testing.T) {
+	var gets, lists atomic.Int32
+	c := client.WithGetBatching(batchingTestClient(&gets, &lists), client.GetBatchingOptions{Window: 50 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			cm := &corev1.ConfigMap{}
+			if err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "a"}, cm); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if cm.Data["key"] != "a" {
+				t.Errorf("expected ConfigMap a, got %v", cm.Data)
+			}
+			// Mutating the object must not affect the other Gets.
+			cm.Data["key"] = "changed"
+		})
+	}
+	wg.Wait()
+
+	if got := gets.Load(); got != 1 {
+		t.Fatalf("expected the Gets to be coalesced into a single Get, got %d", got)
+	}
+	if got := lists.Load(); got != 0 {
+		t.Fatalf("expected no List, got %d", got)
+	}
+}
+
+func TestWithGetBatchingListsNamespace(t *testing.T) {
+	var gets, lists atomic.Int32
+	c := client.WithGetBatching(batchingTestClient(&gets, &lists), client.GetBatchingOptions{Window: 50 * time.Millisecond, ListThreshold: 2})
+
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b", "missing"} {
+		wg.Go(func() {
+			cm := &corev1.ConfigMap{}
+			err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: name}, cm)
+			if err == nil && cm.Data["key"] != name {
+				t.Errorf("expected ConfigMap %s, got %v", name, cm.Data)
+			}
+			mu.Lock()
+			errs[name] = err
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+
+	if got := lists.Load(); got != 1 {
+		t.Fatalf("expected the Gets to be served by a single List, got %d", got)
+	}
+	if got := gets.Load(); got != 0 {
+		t.Fatalf("expected no Get, got %d", got)
+	}
+	if errs["a"] != nil || errs["b"] != nil {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !apierrors.IsNotFound(errs["missing"]) {
+		t.Fatalf("expected a NotFound error for the missing ConfigMap, got %v", errs["missing"])
+	}
+}
+
+func TestWithGetBatchingPassesGetsWithOptions(t *testing.T) {
+	var gets, lists atomic.Int32
+	c := client.WithGetBatching(batchingTestClient(&gets, &lists), client.GetBatchingOptions{Window: time.Hour})
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "a"}, cm, &client.GetOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := gets.Load(); got != 1 {
+		t.Fatalf("expected the Get to be passed on, got %d Gets", got)
+	}
+}
+
+func TestWithGetBatchingListMatchesNamespace(t *testing.T) {
+	var lists atomic.Int32
+	base := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "a"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "b"}},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists.Add(1)
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	c := client.WithGetBatching(base, client.GetBatchingOptions{Window: 50 * time.Millisecond, ListThreshold: 2})
+
+	// A List without a namespace is across all namespaces, the objects of other
+	// namespaces must not be returned for Gets without a namespace.
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b"} {
+		wg.Go(func() {
+			cm := &corev1.ConfigMap{}
+			err := c.Get(t.Context(), client.ObjectKey{Name: name}, cm)
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("expected NotFound for %s, got %v with %s/%s", name, err, cm.Namespace, cm.Name)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := lists.Load(); got != 1 {
+		t.Fatalf("expected the Gets to be served by a single List, got %d", got)
+	}
+}
+
+func TestWithGetBatchingBoundsReads(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		timeout time.Duration
+		ctx     func(context.Context) (context.Context, context.CancelFunc)
+	}{
+		{
+			name:    "deadline of the Get",
+			timeout: time.Hour,
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(ctx, 50*time.Millisecond)
+			},
+		},
+		{
+			name:    "timeout",
+			timeout: 50 * time.Millisecond,
+			ctx:     context.WithCancel,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			readDone := make(chan struct{})
+			base := fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+						defer close(readDone)
+						<-ctx.Done()
+						return ctx.Err()
+					},
+				}).
+				Build()
+			c := client.WithGetBatching(base, client.GetBatchingOptions{Window: time.Millisecond, Timeout: tc.timeout})
+
+			ctx, cancel := tc.ctx(t.Context())
+			defer cancel()
+			err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected the Get to exceed its deadline, got %v", err)
+			}
+			select {
+			case <-readDone:
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected the read of the batch to be bound")
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCacheBypassRules(t *testing.T) {
+	// The API server and the cache serve the same ConfigMap, labeled with where it
+	// was read from.
+	newConfigMap := func(namespace, source string) corev1.ConfigMap {
+		return corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "test", Labels: map[string]string{"source": source}},
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /api/v1/namespaces/<namespace>/configmaps[/<name>]
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/")
+		namespace := ""
+		if len(parts) > 2 && parts[0] == "namespaces" {
+			namespace = parts[1]
+		}
+		var body any = &corev1.ConfigMapList{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMapList"},
+			Items:    []corev1.ConfigMap{newConfigMap(namespace, "api")},
+		}
+		if len(parts) == 4 {
+			cm := newConfigMap(namespace, "api")
+			body = &cm
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+	cacheConfigMaps := []corev1.ConfigMap{newConfigMap("a", "cache"), newConfigMap("b", "cache")}
+	cache := fake.NewClientBuilder().WithObjects(&cacheConfigMaps[0], &cacheConfigMaps[1]).Build()
+
+	c, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+		Mapper: mapper,
+		Cache: &client.CacheOptions{
+			Reader: cache,
+			BypassRules: []client.CacheBypassRule{{
+				Object:     &corev1.ConfigMap{},
+				Namespaces: []string{"a"},
+				Verbs:      []client.CacheBypassVerb{client.CacheBypassList},
+			}, {
+				Object:     &corev1.ConfigMap{},
+				Namespaces: []string{"b"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		list      bool
+		namespace string
+		expected  string
+	}{
+		{name: "Get in namespace with List rule", namespace: "a", expected: "cache"},
+		{name: "List in namespace with List rule", list: true, namespace: "a", expected: "api"},
+		{name: "Get in namespace with rule for all verbs", namespace: "b", expected: "api"},
+		{name: "List in namespace with rule for all verbs", list: true, namespace: "b", expected: "api"},
+		{name: "List across all namespaces", list: true, expected: "cache"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var source string
+			if tc.list {
+				list := &corev1.ConfigMapList{}
+				if err := c.List(t.Context(), list, client.InNamespace(tc.namespace)); err != nil {
+					t.Fatalf("failed to list: %v", err)
+				}
+				source = list.Items[0].Labels["source"]
+			} else {
+				cm := &corev1.ConfigMap{}
+				if err := c.Get(t.Context(), client.ObjectKey{Namespace: tc.namespace, Name: "test"}, cm); err != nil {
+					t.Fatalf("failed to get: %v", err)
+				}
+				source = cm.Labels["source"]
+			}
+			if source != tc.expected {
+				t.Fatalf("expected the read to be served from %s, got %s", tc.expected, source)
+			}
+		})
+	}
+}
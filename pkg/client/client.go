@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -28,11 +29,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/internal/apiwarnings"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -71,8 +74,35 @@ type Options struct {
 	//  - FieldValidationStrict
 	// For more details, see: https://kubernetes.io/docs/reference/using-api/api-concepts/#field-validation
 	FieldValidation string
+
+	// MetadataStamp, if provided, sets its labels and annotations on all objects
+	// created, updated or applied by this client, e.g. to centrally set the version
+	// of an operator or the managed-by label. See WithMetadataStamp for details.
+	MetadataStamp *MetadataStamp
+
+	// Middlewares wrap the client, e.g. to log, mutate or measure its requests, see
+	// interceptor.Middleware. The first middleware is the outermost one. They wrap
+	// the client after the other options were applied, so they see requests before
+	// e.g. the FieldOwner is set on them.
+	Middlewares []Middleware
+
+	// RateLimits limit the rate of subsets of the requests of the client, e.g. the
+	// writes to an expensive custom resource, in addition to the QPS and Burst of the
+	// rest.Config. Reads from the Cache are not limited. See WithRateLimits for
+	// details.
+	RateLimits []RateLimit
+
+	// EnableMetrics records the latency and the result code classes of the requests of
+	// the client to the API server by verb and GroupVersionKind, see WithMetrics. Reads
+	// from the Cache are not recorded. The time requests wait for RateLimits isn't part
+	// of their latency.
+	EnableMetrics bool
 }
 
+// Middleware wraps a client. It must return a client that passes on the requests it
+// doesn't handle itself to the wrapped client.
+type Middleware func(Client) Client
+
 // CacheOptions are options for creating a cache-backed client.
 type CacheOptions struct {
 	// Reader is a cache-backed reader that will be used to read objects from the cache.
@@ -81,12 +111,58 @@ type CacheOptions struct {
 	// DisableFor is a list of objects that should never be read from the cache.
 	// Objects configured here always result in a live lookup.
 	DisableFor []Object
+	// BypassRules configures reads of objects that should not be read from the cache
+	// more finely than DisableFor, e.g. only in some namespaces or only for Lists. A read
+	// results in a live lookup if any rule or DisableFor matches it.
+	BypassRules []CacheBypassRule
 	// Unstructured is a flag that indicates whether the cache-backed client should
 	// read unstructured objects or lists from the cache.
 	// If false, unstructured objects will always result in a live lookup.
 	Unstructured bool
 }
 
+// CacheBypassVerb is a read that can bypass the cache, see CacheBypassRule.
+type CacheBypassVerb string
+
+const (
+	// CacheBypassGet makes Gets bypass the cache.
+	CacheBypassGet CacheBypassVerb = "get"
+	// CacheBypassList makes Lists bypass the cache.
+	CacheBypassList CacheBypassVerb = "list"
+)
+
+// CacheBypassRule configures reads of objects of a kind that should not be read from the
+// cache, to trade the load on the API server for consistency where it matters. E.g. a rule
+// with Verbs CacheBypassList reads Lists from the API server, so that they are not stale,
+// while Gets are still read from the cache.
+type CacheBypassRule struct {
+	// Object is the kind of objects the rule applies to. Lists of it match too.
+	// +required
+	Object Object
+	// Namespaces limits the rule to reads in the given namespaces. Lists across all
+	// namespaces are only matched by rules without Namespaces.
+	// Defaults to all namespaces.
+	Namespaces []string
+	// Verbs limits the rule to the given reads.
+	// Defaults to all reads.
+	Verbs []CacheBypassVerb
+}
+
+// cacheBypassRule is a CacheBypassRule of a resolved kind.
+type cacheBypassRule struct {
+	// namespaces is nil if the rule applies to all namespaces.
+	namespaces sets.Set[string]
+	// verbs is nil if the rule applies to all reads.
+	verbs sets.Set[CacheBypassVerb]
+}
+
+func (r cacheBypassRule) matches(verb CacheBypassVerb, namespace string) bool {
+	if r.verbs != nil && !r.verbs.Has(verb) {
+		return false
+	}
+	return r.namespaces == nil || r.namespaces.Has(namespace)
+}
+
 // NewClientFunc allows a user to define how to create a client.
 type NewClientFunc func(config *rest.Config, options Options) (Client, error)
 
@@ -104,7 +180,8 @@ type NewClientFunc func(config *rest.Config, options Options) (Client, error)
 // If both Options.Cache and Options.Cache.Reader are non-nil,
 // the client reads from a local cache. However, specific
 // resources can still be configured to bypass the cache based
-// on Options.Cache.Unstructured and Options.Cache.DisableFor.
+// on Options.Cache.Unstructured, Options.Cache.DisableFor and
+// Options.Cache.BypassRules.
 // Write operations are always performed directly on the API server.
 //
 // The client understands how to work with normal types (both custom resources
@@ -115,6 +192,12 @@ type NewClientFunc func(config *rest.Config, options Options) (Client, error)
 // from the corresponding fields on the object.
 func New(config *rest.Config, options Options) (c Client, err error) {
 	c, err = newClient(config, options)
+	if err == nil && options.EnableMetrics {
+		c = WithMetrics(c)
+	}
+	if err == nil && len(options.RateLimits) > 0 {
+		c = WithRateLimits(c, options.RateLimits...)
+	}
 	if err == nil && options.DryRun != nil && *options.DryRun {
 		c = NewDryRunClient(c)
 	}
@@ -124,6 +207,14 @@ func New(config *rest.Config, options Options) (c Client, err error) {
 	if fv := options.FieldValidation; fv != "" {
 		c = WithFieldValidation(c, FieldValidation(fv))
 	}
+	if ms := options.MetadataStamp; ms != nil {
+		c = WithMetadataStamp(c, *ms)
+	}
+	if err == nil {
+		for _, middleware := range slices.Backward(options.Middlewares) {
+			c = middleware(c)
+		}
+	}
 
 	return c, err
 }
@@ -154,6 +245,7 @@ func newClient(config *rest.Config, options Options) (*client, error) {
 		if err != nil {
 			return nil, err
 		}
+		options.HTTPClient = apiwarnings.WrapClient(options.HTTPClient)
 	}
 
 	// Init a scheme if none provided
@@ -211,13 +303,30 @@ func newClient(config *rest.Config, options Options) (*client, error) {
 
 	// Load uncached GVKs.
 	c.cacheUnstructured = options.Cache.Unstructured
-	c.uncachedGVKs = map[schema.GroupVersionKind]struct{}{}
+	c.cacheBypassRules = map[schema.GroupVersionKind][]cacheBypassRule{}
 	for _, obj := range options.Cache.DisableFor {
 		gvk, err := c.GroupVersionKindFor(obj)
 		if err != nil {
 			return nil, err
 		}
-		c.uncachedGVKs[gvk] = struct{}{}
+		c.cacheBypassRules[gvk] = append(c.cacheBypassRules[gvk], cacheBypassRule{})
+	}
+	for _, rule := range options.Cache.BypassRules {
+		if rule.Object == nil {
+			return nil, errors.New("cache bypass rule without an object")
+		}
+		gvk, err := c.GroupVersionKindFor(rule.Object)
+		if err != nil {
+			return nil, err
+		}
+		var compiled cacheBypassRule
+		if len(rule.Namespaces) > 0 {
+			compiled.namespaces = sets.New(rule.Namespaces...)
+		}
+		if len(rule.Verbs) > 0 {
+			compiled.verbs = sets.New(rule.Verbs...)
+		}
+		c.cacheBypassRules[gvk] = append(c.cacheBypassRules[gvk], compiled)
 	}
 	return c, nil
 }
@@ -235,11 +344,11 @@ type client struct {
 	mapper             meta.RESTMapper
 
 	cache             Reader
-	uncachedGVKs      map[schema.GroupVersionKind]struct{}
+	cacheBypassRules  map[schema.GroupVersionKind][]cacheBypassRule
 	cacheUnstructured bool
 }
 
-func (c *client) shouldBypassCache(obj runtime.Object) (bool, error) {
+func (c *client) shouldBypassCache(obj runtime.Object, verb CacheBypassVerb, namespace string) (bool, error) {
 	if c.cache == nil {
 		return true, nil
 	}
@@ -253,8 +362,10 @@ func (c *client) shouldBypassCache(obj runtime.Object) (bool, error) {
 	if meta.IsListType(obj) {
 		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
 	}
-	if _, isUncached := c.uncachedGVKs[gvk]; isUncached {
-		return true, nil
+	for _, rule := range c.cacheBypassRules[gvk] {
+		if rule.matches(verb, namespace) {
+			return true, nil
+		}
 	}
 	if !c.cacheUnstructured {
 		_, isUnstructured := obj.(runtime.Unstructured)
@@ -263,6 +374,12 @@ func (c *client) shouldBypassCache(obj runtime.Object) (bool, error) {
 	return false, nil
 }
 
+// readsFromCache returns whether a read of verb for obj in namespace is served by the cache.
+func (c *client) readsFromCache(obj runtime.Object, verb CacheBypassVerb, namespace string) bool {
+	bypass, err := c.shouldBypassCache(obj, verb, namespace)
+	return err == nil && !bypass
+}
+
 // resetGroupVersionKind is a helper function to restore and preserve GroupVersionKind on an object.
 func (c *client) resetGroupVersionKind(obj runtime.Object, gvk schema.GroupVersionKind) {
 	if gvk != schema.EmptyObjectKind.GroupVersionKind() {
@@ -366,7 +483,7 @@ func (c *client) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts
 
 // Get implements client.Client.
 func (c *client) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
-	if isUncached, err := c.shouldBypassCache(obj); err != nil {
+	if isUncached, err := c.shouldBypassCache(obj, CacheBypassGet, key.Namespace); err != nil {
 		return err
 	} else if !isUncached {
 		// Attempt to get from the cache.
@@ -388,7 +505,9 @@ func (c *client) Get(ctx context.Context, key ObjectKey, obj Object, opts ...Get
 
 // List implements client.Client.
 func (c *client) List(ctx context.Context, obj ObjectList, opts ...ListOption) error {
-	if isUncached, err := c.shouldBypassCache(obj); err != nil {
+	listOpts := ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if isUncached, err := c.shouldBypassCache(obj, CacheBypassList, listOpts.Namespace); err != nil {
 		return err
 	} else if !isUncached {
 		// Attempt to get from the cache.
@@ -2607,6 +2607,15 @@ U5wwSivyi7vmegHKmblOzNVKA5qPO8zWzqBC
 				Expect(hasDep).To(BeTrue())
 			})
 
+			It("should fail to list with options that are only supported by the cache", func(ctx SpecContext) {
+				cl, err := client.New(cfg, client.Options{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cl.List(ctx, &appsv1.DeploymentList{}, client.SortByName)).NotTo(Succeed())
+				Expect(cl.List(ctx, &appsv1.DeploymentList{}, client.ListOffset(1))).NotTo(Succeed())
+				Expect(cl.List(ctx, &metav1.PartialObjectMetadataList{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DeploymentList"}}, client.ListOffset(1))).NotTo(Succeed())
+			})
+
 			It("should fetch unstructured collection of objects", func(ctx SpecContext) {
 				By("create an initial object")
 				_, err := clientset.AppsV1().Deployments(ns).Create(ctx, dep, metav1.CreateOptions{})
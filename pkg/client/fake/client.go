@@ -95,6 +95,9 @@ type fakeClient struct {
 	indexesLock sync.RWMutex
 
 	returnManagedFields bool
+
+	// actions records the requests made through the client, see Inspector.
+	actions *actionRecorder
 }
 
 var _ client.WithWatch = &fakeClient{}
@@ -131,6 +134,7 @@ type ClientBuilder struct {
 	typeConverters        []managedfields.TypeConverter
 	returnManagedFields   bool
 	isBuilt               bool
+	built                 *fakeClient
 
 	// indexes maps each GroupVersionKind (GVK) to the indexes registered for that GVK.
 	// The inner map maps from index name to IndexerFunc.
@@ -324,15 +328,18 @@ func (f *ClientBuilder) Build() client.WithWatch {
 		}
 	}
 
-	var result client.WithWatch = &fakeClient{
+	f.built = &fakeClient{
 		tracker:               tracker,
 		scheme:                f.scheme,
 		restMapper:            f.restMapper,
 		indexes:               f.indexes,
 		withStatusSubresource: withStatusSubResource,
 		returnManagedFields:   f.returnManagedFields,
+		actions:               &actionRecorder{},
 	}
 
+	var result client.WithWatch = f.built
+
 	if f.interceptorFuncs != nil {
 		result = interceptor.NewClient(result, *f.interceptorFuncs)
 	}
@@ -378,6 +385,12 @@ func convertFromUnstructuredIfNecessary(s *runtime.Scheme, o runtime.Object) (ru
 }
 
 func (c *fakeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.get(key, obj, opts...)
+	c.recordAction(ActionGet, "", obj, key, err)
+	return err
+}
+
+func (c *fakeClient) get(key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(obj); err != nil {
 		return err
 	}
@@ -425,6 +438,12 @@ func (c *fakeClient) Get(ctx context.Context, key client.ObjectKey, obj client.O
 }
 
 func (c *fakeClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	w, err := c.watch(list, opts...)
+	c.recordAction(ActionWatch, "", list, listKey(opts...), err)
+	return w, err
+}
+
+func (c *fakeClient) watch(list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(list); err != nil {
 		return nil, err
 	}
@@ -447,6 +466,19 @@ func (c *fakeClient) Watch(ctx context.Context, list client.ObjectList, opts ...
 }
 
 func (c *fakeClient) List(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+	err := c.list(obj, opts...)
+	c.recordAction(ActionList, "", obj, listKey(opts...), err)
+	return err
+}
+
+// listKey returns the key of list and watch requests, which only has the namespace set.
+func listKey(opts ...client.ListOption) client.ObjectKey {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	return client.ObjectKey{Namespace: listOpts.Namespace}
+}
+
+func (c *fakeClient) list(obj client.ObjectList, opts ...client.ListOption) error {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(obj); err != nil {
 		return err
 	}
@@ -510,18 +542,27 @@ func (c *fakeClient) List(ctx context.Context, obj client.ObjectList, opts ...cl
 		}
 	}
 
-	if listOpts.LabelSelector == nil && listOpts.FieldSelector == nil {
-		return meta.SetList(obj, objs)
+	if listOpts.LabelSelector != nil || listOpts.FieldSelector != nil {
+		// If we're here, either a label or field selector are specified (or both), so before we return
+		// the list we must filter it. If both selectors are set, they are ANDed.
+		objs, err = c.filterList(objs, gvk, listOpts.LabelSelector, listOpts.FieldSelector)
+		if err != nil {
+			return err
+		}
 	}
 
-	// If we're here, either a label or field selector are specified (or both), so before we return
-	// the list we must filter it. If both selectors are set, they are ANDed.
-	filteredList, err := c.filterList(objs, gvk, listOpts.LabelSelector, listOpts.FieldSelector)
-	if err != nil {
-		return err
+	// SortBy and Offset are applied like the cache does, so that code listing
+	// from the cache can be tested with the fake client.
+	if listOpts.SortBy != nil {
+		slices.SortStableFunc(objs, func(a, b runtime.Object) int {
+			return listOpts.SortBy(a.(client.Object), b.(client.Object))
+		})
+	}
+	if listOpts.Offset > 0 {
+		objs = objs[min(listOpts.Offset, int64(len(objs))):]
 	}
 
-	return meta.SetList(obj, filteredList)
+	return meta.SetList(obj, objs)
 }
 
 func (c *fakeClient) filterList(list []runtime.Object, gvk schema.GroupVersionKind, ls labels.Selector, fs fields.Selector) ([]runtime.Object, error) {
@@ -610,6 +651,12 @@ func (c *fakeClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
 }
 
 func (c *fakeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.create(obj, opts...)
+	c.recordAction(ActionCreate, "", obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (c *fakeClient) create(obj client.Object, opts ...client.CreateOption) error {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(obj); err != nil {
 		return err
 	}
@@ -678,6 +725,12 @@ func (c *fakeClient) Create(ctx context.Context, obj client.Object, opts ...clie
 }
 
 func (c *fakeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.delete(obj, opts...)
+	c.recordAction(ActionDelete, "", obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (c *fakeClient) delete(obj client.Object, opts ...client.DeleteOption) error {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(obj); err != nil {
 		return err
 	}
@@ -728,6 +781,14 @@ func (c *fakeClient) Delete(ctx context.Context, obj client.Object, opts ...clie
 }
 
 func (c *fakeClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := c.deleteAllOf(obj, opts...)
+	dcOptions := client.DeleteAllOfOptions{}
+	dcOptions.ApplyOptions(opts)
+	c.recordAction(ActionDeleteAllOf, "", obj, client.ObjectKey{Namespace: dcOptions.Namespace}, err)
+	return err
+}
+
+func (c *fakeClient) deleteAllOf(obj client.Object, opts ...client.DeleteAllOfOption) error {
 	if err := c.addToSchemeIfUnknownAndUnstructuredOrPartial(obj); err != nil {
 		return err
 	}
@@ -778,7 +839,9 @@ func (c *fakeClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ..
 }
 
 func (c *fakeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
-	return c.update(obj, false, opts...)
+	err := c.update(obj, false, opts...)
+	c.recordAction(ActionUpdate, "", obj, client.ObjectKeyFromObject(obj), err)
+	return err
 }
 
 func (c *fakeClient) update(obj client.Object, isStatus bool, opts ...client.UpdateOption) error {
@@ -832,21 +895,29 @@ func (c *fakeClient) update(obj client.Object, isStatus bool, opts ...client.Upd
 }
 
 func (c *fakeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
-	return c.patch(obj, patch, opts...)
+	err := c.patch(obj, patch, opts...)
+	c.recordAction(ActionPatch, "", obj, client.ObjectKeyFromObject(obj), err)
+	return err
 }
 
 func (c *fakeClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	u, err := c.apply(obj, opts...)
+	c.recordAction(ActionApply, "", u, client.ObjectKeyFromObject(u), err)
+	return err
+}
+
+func (c *fakeClient) apply(obj runtime.ApplyConfiguration, opts ...client.ApplyOption) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
 	applyOpts := &client.ApplyOptions{}
 	applyOpts.ApplyOptions(opts)
 
 	data, err := json.Marshal(obj)
 	if err != nil {
-		return fmt.Errorf("failed to marshal apply configuration: %w", err)
+		return u, fmt.Errorf("failed to marshal apply configuration: %w", err)
 	}
 
-	u := &unstructured.Unstructured{}
 	if err := json.Unmarshal(data, u); err != nil {
-		return fmt.Errorf("failed to unmarshal apply configuration: %w", err)
+		return u, fmt.Errorf("failed to unmarshal apply configuration: %w", err)
 	}
 
 	applyPatch := &fakeApplyPatch{}
@@ -855,12 +926,12 @@ func (c *fakeClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration,
 	patchOpts.Raw = applyOpts.AsPatchOptions()
 
 	if err := c.patch(u, applyPatch, patchOpts); err != nil {
-		return err
+		return u, err
 	}
 
 	acJSON, err := json.Marshal(u)
 	if err != nil {
-		return fmt.Errorf("failed to marshal patched object: %w", err)
+		return u, fmt.Errorf("failed to marshal patched object: %w", err)
 	}
 
 	// We have to zero the object in case it contained a status and there is a
@@ -874,10 +945,10 @@ func (c *fakeClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration,
 		zero(obj)
 	}
 	if err := json.Unmarshal(acJSON, obj); err != nil {
-		return fmt.Errorf("failed to unmarshal patched object: %w", err)
+		return u, fmt.Errorf("failed to unmarshal patched object: %w", err)
 	}
 
-	return nil
+	return u, nil
 }
 
 type fakeApplyPatch struct{}
@@ -1224,11 +1295,17 @@ type fakeSubResourceClient struct {
 }
 
 func (sw *fakeSubResourceClient) Get(ctx context.Context, obj, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	err := sw.get(obj, subResource)
+	sw.client.recordAction(ActionGet, sw.subResource, obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (sw *fakeSubResourceClient) get(obj, subResource client.Object) error {
 	switch sw.subResource {
 	case subResourceScale:
 		// Actual client looks up resource, then extracts the scale sub-resource:
 		// https://github.com/kubernetes/kubernetes/blob/fb6bbc9781d11a87688c398778525c4e1dcb0f08/pkg/registry/apps/deployment/storage/storage.go#L307
-		if err := sw.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if err := sw.client.get(client.ObjectKeyFromObject(obj), obj); err != nil {
 			return err
 		}
 		scale, isScale := subResource.(*autoscalingv1.Scale)
@@ -1247,6 +1324,12 @@ func (sw *fakeSubResourceClient) Get(ctx context.Context, obj, subResource clien
 }
 
 func (sw *fakeSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	err := sw.create(obj, subResource)
+	sw.client.recordAction(ActionCreate, sw.subResource, obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (sw *fakeSubResourceClient) create(obj client.Object, subResource client.Object) error {
 	switch sw.subResource {
 	case "eviction":
 		_, isEviction := subResource.(*policyv1beta1.Eviction)
@@ -1260,7 +1343,7 @@ func (sw *fakeSubResourceClient) Create(ctx context.Context, obj client.Object,
 			return apierrors.NewNotFound(schema.GroupResource{}, "")
 		}
 
-		return sw.client.Delete(ctx, obj)
+		return sw.client.delete(obj)
 	case "token":
 		tokenRequest, isTokenRequest := subResource.(*authenticationv1.TokenRequest)
 		if !isTokenRequest {
@@ -1273,19 +1356,25 @@ func (sw *fakeSubResourceClient) Create(ctx context.Context, obj client.Object,
 		tokenRequest.Status.Token = "fake-token"
 		tokenRequest.Status.ExpirationTimestamp = metav1.Date(6041, 1, 1, 0, 0, 0, 0, time.UTC)
 
-		return sw.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		return sw.client.get(client.ObjectKeyFromObject(obj), obj)
 	default:
 		return fmt.Errorf("fakeSubResourceWriter does not support create for %s", sw.subResource)
 	}
 }
 
 func (sw *fakeSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	err := sw.update(obj, opts...)
+	sw.client.recordAction(ActionUpdate, sw.subResource, obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (sw *fakeSubResourceClient) update(obj client.Object, opts ...client.SubResourceUpdateOption) error {
 	updateOptions := client.SubResourceUpdateOptions{}
 	updateOptions.ApplyOptions(opts)
 
 	switch sw.subResource {
 	case subResourceScale:
-		if err := sw.client.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err != nil {
+		if err := sw.client.get(client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err != nil {
 			return err
 		}
 		if updateOptions.SubResourceBody == nil {
@@ -1310,6 +1399,12 @@ func (sw *fakeSubResourceClient) Update(ctx context.Context, obj client.Object,
 }
 
 func (sw *fakeSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	err := sw.patch(obj, patch, opts...)
+	sw.client.recordAction(ActionPatch, sw.subResource, obj, client.ObjectKeyFromObject(obj), err)
+	return err
+}
+
+func (sw *fakeSubResourceClient) patch(obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
 	patchOptions := client.SubResourcePatchOptions{}
 	patchOptions.ApplyOptions(opts)
 
@@ -1363,7 +1458,9 @@ func (sw *fakeSubResourceClient) Apply(ctx context.Context, obj runtime.ApplyCon
 		patchOpts.SubResourceBody = subResourceBody
 	}
 
-	return sw.Patch(ctx, u, &fakeApplyPatch{}, patchOpts)
+	err = sw.patch(u, &fakeApplyPatch{}, patchOpts)
+	sw.client.recordAction(ActionApply, sw.subResource, u, client.ObjectKeyFromObject(u), err)
+	return err
 }
 
 func allowsUnconditionalUpdate(gvk schema.GroupVersionKind) bool {
@@ -142,6 +142,21 @@ var _ = Describe("Fake client", func() {
 			Expect(list.Items).To(ConsistOf(*dep, *dep2))
 		})
 
+		It("should be able to List sorted and with an offset", func(ctx SpecContext) {
+			byNameDescending := func(a, b client.Object) int {
+				return -client.SortByName(a, b)
+			}
+			list := &appsv1.DeploymentList{}
+			Expect(cl.List(ctx, list, client.InNamespace("ns1"), client.SortBy(byNameDescending))).To(Succeed())
+			Expect(list.Items).To(Equal([]appsv1.Deployment{*dep2, *dep}))
+
+			Expect(cl.List(ctx, list, client.InNamespace("ns1"), client.SortByName, client.ListOffset(1))).To(Succeed())
+			Expect(list.Items).To(Equal([]appsv1.Deployment{*dep2}))
+
+			Expect(cl.List(ctx, list, client.InNamespace("ns1"), client.ListOffset(3))).To(Succeed())
+			Expect(list.Items).To(BeEmpty())
+		})
+
 		It("should be able to List using unstructured list", func(ctx SpecContext) {
 			By("Listing all deployments in a namespace")
 			list := &unstructured.UnstructuredList{}
@@ -24,6 +24,11 @@ You can create a fake client with optional objects.
 
 You can invoke the methods defined in the Client interface.
 
+The requests made through the client and the stored objects can be inspected with an
+Inspector, e.g. to assert on the order of writes:
+
+	writes := Inspect(client).Writes()
+
 When in doubt, it's almost always better not to use this package and instead use
 envtest.Environment with a real client and API server.
 
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ActionVerb is the verb of a request made through a fake client.
+type ActionVerb string
+
+const (
+	// ActionGet is the verb of Get requests.
+	ActionGet ActionVerb = "get"
+	// ActionList is the verb of List requests.
+	ActionList ActionVerb = "list"
+	// ActionWatch is the verb of Watch requests.
+	ActionWatch ActionVerb = "watch"
+	// ActionCreate is the verb of Create requests.
+	ActionCreate ActionVerb = "create"
+	// ActionUpdate is the verb of Update requests.
+	ActionUpdate ActionVerb = "update"
+	// ActionPatch is the verb of Patch requests.
+	ActionPatch ActionVerb = "patch"
+	// ActionApply is the verb of Apply requests.
+	ActionApply ActionVerb = "apply"
+	// ActionDelete is the verb of Delete requests.
+	ActionDelete ActionVerb = "delete"
+	// ActionDeleteAllOf is the verb of DeleteAllOf requests.
+	ActionDeleteAllOf ActionVerb = "deletecollection"
+)
+
+// IsWrite returns whether requests of the verb modify objects.
+func (v ActionVerb) IsWrite() bool {
+	switch v {
+	case ActionCreate, ActionUpdate, ActionPatch, ActionApply, ActionDelete, ActionDeleteAllOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// Action is a request made through a fake client.
+type Action struct {
+	// Verb is the verb of the request.
+	Verb ActionVerb
+
+	// GroupVersionKind is the kind of the object of the request. For list and
+	// watch requests, it is the kind of the items rather than of the list.
+	GroupVersionKind schema.GroupVersionKind
+
+	// Subresource is the subresource of the request, e.g. "status", if any.
+	Subresource string
+
+	// Key is the key of the object of the request. Only the namespace is set for
+	// list, watch and deletecollection requests.
+	Key client.ObjectKey
+
+	// Object is a copy of the object as returned by a successful get, create,
+	// update, patch or apply request, nil otherwise.
+	Object client.Object
+
+	// Err is the error returned by the request.
+	Err error
+}
+
+// actionRecorder records the actions of a fake client.
+type actionRecorder struct {
+	lock    sync.Mutex
+	actions []Action
+}
+
+// recordAction records a request made through the client.
+func (c *fakeClient) recordAction(verb ActionVerb, subresource string, obj runtime.Object, key client.ObjectKey, err error) {
+	action := Action{
+		Verb:        verb,
+		Subresource: subresource,
+		Key:         key,
+		Err:         err,
+	}
+
+	c.schemeLock.RLock()
+	if gvk, gvkErr := apiutil.GVKForObject(obj, c.scheme); gvkErr == nil {
+		if meta.IsListType(obj) {
+			gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+		}
+		action.GroupVersionKind = gvk
+	}
+	c.schemeLock.RUnlock()
+
+	if o, ok := obj.(client.Object); ok && err == nil && verb != ActionDelete && verb != ActionDeleteAllOf {
+		action.Object = o.DeepCopyObject().(client.Object)
+	}
+
+	c.actions.lock.Lock()
+	defer c.actions.lock.Unlock()
+	c.actions.actions = append(c.actions.actions, action)
+}
+
+// Inspector gives tests typed access to the objects stored by a fake client and to
+// the requests made through it, e.g. to assert on the exact order of writes without
+// intercepting every verb.
+type Inspector struct {
+	client *fakeClient
+}
+
+// Inspect returns the Inspector of a fake client. It will panic if used with a client that is not
+// a fake client. Use ClientBuilder.Inspector for clients built with interceptor funcs.
+func Inspect(c client.Client) *Inspector {
+	fakeClient, isFakeClient := c.(*fakeClient)
+	if !isFakeClient {
+		panic("Inspect can only be used with a fake client")
+	}
+	return &Inspector{client: fakeClient}
+}
+
+// Inspector returns the Inspector of the client built by the builder, which also covers
+// the requests that passed the interceptor funcs. It will panic if Build wasn't called.
+func (f *ClientBuilder) Inspector() *Inspector {
+	if f.built == nil {
+		panic("Inspector() must be called after Build()")
+	}
+	return &Inspector{client: f.built}
+}
+
+// Actions returns the requests made through the client in the order they were made.
+func (i *Inspector) Actions() []Action {
+	i.client.actions.lock.Lock()
+	defer i.client.actions.lock.Unlock()
+	return slices.Clone(i.client.actions.actions)
+}
+
+// Writes returns the successful requests that modified objects in the order they were made.
+func (i *Inspector) Writes() []Action {
+	return slices.DeleteFunc(i.Actions(), func(action Action) bool {
+		return !action.Verb.IsWrite() || action.Err != nil
+	})
+}
+
+// ClearActions forgets the requests made through the client so far, e.g. after
+// setting up the objects of a test.
+func (i *Inspector) ClearActions() {
+	i.client.actions.lock.Lock()
+	defer i.client.actions.lock.Unlock()
+	i.client.actions.actions = nil
+}
+
+// Objects returns the objects of the given kind stored by the client in all namespaces,
+// sorted by namespace and name.
+func (i *Inspector) Objects(gvk schema.GroupVersionKind) ([]client.Object, error) {
+	c := i.client
+	c.schemeLock.RLock()
+	defer c.schemeLock.RUnlock()
+
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	list, err := c.tracker.List(gvr, gvk, "")
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	objs := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.DeepCopyObject().(client.Object)
+		if !ok {
+			continue
+		}
+		if !c.returnManagedFields {
+			obj.SetManagedFields(nil)
+		}
+		if err := ensureTypeMeta(obj, gvk); err != nil {
+			return nil, err
+		}
+		objs = append(objs, obj)
+	}
+	slices.SortFunc(objs, func(a, b client.Object) int {
+		return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+	})
+	return objs, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	gomegatypes "github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Inspector", func() {
+	configMapGVK := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	matchAction := func(verb ActionVerb, gvk schema.GroupVersionKind, key client.ObjectKey) gomegatypes.GomegaMatcher {
+		return MatchFields(IgnoreExtras, Fields{
+			"Verb":             Equal(verb),
+			"GroupVersionKind": Equal(gvk),
+			"Key":              Equal(key),
+		})
+	}
+
+	newConfigMap := func(namespace, name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	It("should record the requests in order", func(ctx SpecContext) {
+		cl := NewClientBuilder().WithObjects(newConfigMap("ns", "existing")).Build()
+		inspector := Inspect(cl)
+
+		cm := newConfigMap("ns", "created")
+		Expect(cl.Create(ctx, cm)).To(Succeed())
+		Expect(cl.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "missing"}, &corev1.ConfigMap{})).To(Satisfy(apierrors.IsNotFound))
+		Expect(cl.List(ctx, &corev1.ConfigMapList{}, client.InNamespace("ns"))).To(Succeed())
+		cm.Data = map[string]string{"key": "value"}
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+		Expect(cl.Delete(ctx, newConfigMap("ns", "existing"))).To(Succeed())
+
+		actions := inspector.Actions()
+		Expect(actions).To(HaveLen(5))
+		Expect(actions[0]).To(matchAction(ActionCreate, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "created"}))
+		Expect(actions[1]).To(matchAction(ActionGet, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "missing"}))
+		Expect(actions[1].Err).To(Satisfy(apierrors.IsNotFound))
+		Expect(actions[1].Object).To(BeNil())
+		Expect(actions[2]).To(matchAction(ActionList, configMapGVK, client.ObjectKey{Namespace: "ns"}))
+		Expect(actions[3]).To(matchAction(ActionUpdate, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "created"}))
+		Expect(actions[3].Object.(*corev1.ConfigMap).Data).To(HaveKeyWithValue("key", "value"))
+		Expect(actions[4]).To(matchAction(ActionDelete, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "existing"}))
+		Expect(actions[4].Object).To(BeNil())
+
+		inspector.ClearActions()
+		Expect(inspector.Actions()).To(BeEmpty())
+	})
+
+	It("should only return successful writes", func(ctx SpecContext) {
+		cl := NewClientBuilder().WithObjects(newConfigMap("ns", "existing")).Build()
+
+		Expect(cl.Create(ctx, newConfigMap("ns", "existing"))).To(Satisfy(apierrors.IsAlreadyExists))
+		Expect(cl.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "existing"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(cl.Create(ctx, newConfigMap("ns", "first"))).To(Succeed())
+		Expect(cl.Patch(ctx, newConfigMap("ns", "existing"), client.MergeFrom(newConfigMap("ns", "existing")))).To(Succeed())
+
+		writes := Inspect(cl).Writes()
+		Expect(writes).To(HaveLen(2))
+		Expect(writes[0]).To(matchAction(ActionCreate, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "first"}))
+		Expect(writes[1]).To(matchAction(ActionPatch, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "existing"}))
+	})
+
+	It("should record subresource requests once", func(ctx SpecContext) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pod"}}
+		cl := NewClientBuilder().WithObjects(pod).WithStatusSubresource(pod).Build()
+
+		pod.Status.Phase = corev1.PodRunning
+		Expect(cl.Status().Update(ctx, pod)).To(Succeed())
+		Expect(cl.SubResource("eviction").Create(ctx, pod, &corev1.Pod{})).To(HaveOccurred())
+
+		actions := Inspect(cl).Actions()
+		Expect(actions).To(HaveLen(2))
+		Expect(actions[0].Verb).To(Equal(ActionUpdate))
+		Expect(actions[0].Subresource).To(Equal("status"))
+		Expect(actions[1].Verb).To(Equal(ActionCreate))
+		Expect(actions[1].Subresource).To(Equal("eviction"))
+	})
+
+	It("should return the stored objects of a kind sorted by namespace and name", func() {
+		cl := NewClientBuilder().WithObjects(
+			newConfigMap("b", "a"),
+			newConfigMap("a", "b"),
+			newConfigMap("a", "a"),
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "secret"}},
+		).Build()
+
+		objs, err := Inspect(cl).Objects(configMapGVK)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(3))
+		for i, key := range []client.ObjectKey{{Namespace: "a", Name: "a"}, {Namespace: "a", Name: "b"}, {Namespace: "b", Name: "a"}} {
+			Expect(objs[i]).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+			Expect(client.ObjectKeyFromObject(objs[i])).To(Equal(key))
+		}
+	})
+
+	It("should record the requests that passed the interceptor funcs", func(ctx SpecContext) {
+		builder := NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetName() == "rejected" {
+					return errors.New("rejected")
+				}
+				return client.Create(ctx, obj, opts...)
+			},
+		})
+		cl := builder.Build()
+
+		Expect(cl.Create(ctx, newConfigMap("ns", "rejected"))).NotTo(Succeed())
+		Expect(cl.Create(ctx, newConfigMap("ns", "accepted"))).To(Succeed())
+
+		actions := builder.Inspector().Actions()
+		Expect(actions).To(HaveLen(1))
+		Expect(actions[0]).To(matchAction(ActionCreate, configMapGVK, client.ObjectKey{Namespace: "ns", Name: "accepted"}))
+	})
+})
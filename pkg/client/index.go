@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// compoundIndexSeparator separates the values of compound index keys. It is a character
+// that doesn't occur in the values of Kubernetes objects in practice.
+const compoundIndexSeparator = "\x00"
+
+// Index is a field index over objects of type T, e.g. an index of Pods by the node they
+// are scheduled to and their phase:
+//
+//	podsByNodeAndPhase := client.NewCompoundIndex("spec.nodeName+status.phase",
+//		func(pod *corev1.Pod) []string { return []string{pod.Spec.NodeName} },
+//		func(pod *corev1.Pod) []string { return []string{string(pod.Status.Phase)} },
+//	)
+//	if err := podsByNodeAndPhase.Register(ctx, mgr.GetFieldIndexer(), &corev1.Pod{}); err != nil {
+//		return err
+//	}
+//	...
+//	pods, err := podsByNodeAndPhase.Lookup(ctx, c, &corev1.PodList{}, []string{"node-a", "Running"})
+//
+// Lookups are served by the index of the cache, instead of listing all objects and
+// filtering them.
+type Index[T Object] struct {
+	field      string
+	extractors []func(T) []string
+}
+
+// NewIndex returns an index with the given field name whose values are extracted with
+// extract. An object is indexed under every value extract returns.
+func NewIndex[T Object](field string, extract func(T) []string) *Index[T] {
+	return NewCompoundIndex(field, extract)
+}
+
+// NewCompoundIndex returns an index with the given field name over the combination of the
+// values of the given extractors. An object is indexed under every combination of the
+// values of the extractors, and isn't indexed if any extractor returns no values.
+func NewCompoundIndex[T Object](field string, extractors ...func(T) []string) *Index[T] {
+	return &Index[T]{field: field, extractors: extractors}
+}
+
+// Field returns the field name of the index.
+func (i *Index[T]) Field() string {
+	return i.field
+}
+
+// IndexerFunc returns the function that extracts the keys of an object for the index,
+// e.g. for fake.ClientBuilder.WithIndex.
+func (i *Index[T]) IndexerFunc() IndexerFunc {
+	return func(obj Object) []string {
+		typed, ok := obj.(T)
+		if !ok {
+			return nil
+		}
+		keys := []string{""}
+		for n, extract := range i.extractors {
+			values := extract(typed)
+			combined := make([]string, 0, len(keys)*len(values))
+			for _, key := range keys {
+				for _, value := range values {
+					if n > 0 {
+						value = key + compoundIndexSeparator + value
+					}
+					combined = append(combined, value)
+				}
+			}
+			keys = combined
+		}
+		return keys
+	}
+}
+
+// Register adds the index to indexer for objects of the type of obj.
+func (i *Index[T]) Register(ctx context.Context, indexer FieldIndexer, obj T) error {
+	return indexer.IndexField(ctx, obj, i.field, i.IndexerFunc())
+}
+
+// Key returns the key of the given values, one per extractor of the index.
+func (i *Index[T]) Key(values ...string) string {
+	return strings.Join(values, compoundIndexSeparator)
+}
+
+// Matching returns a ListOption that selects the objects indexed under the given values,
+// one per extractor of the index.
+func (i *Index[T]) Matching(values ...string) MatchingFields {
+	return MatchingFields{i.field: i.Key(values...)}
+}
+
+// Lookup lists the objects indexed under the given values, one per extractor of the
+// index, into list and returns its items. list must be the list type of T, e.g. a
+// *corev1.PodList for an index of *corev1.Pod.
+func (i *Index[T]) Lookup(ctx context.Context, reader Reader, list ObjectList, values []string, opts ...ListOption) ([]T, error) {
+	if len(values) != len(i.extractors) {
+		return nil, fmt.Errorf("index %s has %d values, got %d", i.field, len(i.extractors), len(values))
+	}
+	if err := reader.List(ctx, list, append(opts, i.Matching(values...))...); err != nil {
+		return nil, err
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(T)
+		if !ok {
+			var want T
+			return nil, fmt.Errorf("list %T contains %T, expected %T", list, item, want)
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
+
+// NestedFieldValues returns an extractor for an index that returns the values of the
+// field at the given dot-separated path of an object, e.g. "spec.containers.image".
+// Lists on the path are traversed, so the extractor returns the values of the field of
+// every item. Values that are not strings are formatted with fmt.
+//
+// It converts every object to unstructured, which makes indexing considerably slower
+// than with an extractor that reads the fields of typed objects.
+func NestedFieldValues[T Object](path string) func(T) []string {
+	fields := strings.Split(path, ".")
+	return func(obj T) []string {
+		var content any
+		if u, ok := any(obj).(runtime.Unstructured); ok {
+			content = u.UnstructuredContent()
+		} else {
+			var err error
+			if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+				return nil
+			}
+		}
+		return nestedFieldValues(content, fields, nil)
+	}
+}
+
+func nestedFieldValues(content any, fields []string, values []string) []string {
+	switch content := content.(type) {
+	case nil:
+		return values
+	case []any:
+		for _, item := range content {
+			values = nestedFieldValues(item, fields, values)
+		}
+		return values
+	case map[string]any:
+		if len(fields) == 0 {
+			return values
+		}
+		return nestedFieldValues(content[fields[0]], fields[1:], values)
+	case string:
+		if len(fields) == 0 {
+			return append(values, content)
+		}
+	default:
+		if len(fields) == 0 {
+			return append(values, fmt.Sprint(content))
+		}
+	}
+	return values
+}
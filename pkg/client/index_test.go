@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCompoundIndex(t *testing.T) {
+	newPod := func(name, node string, phase corev1.PodPhase, images ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		for _, image := range images {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Image: image})
+		}
+		return pod
+	}
+	byNodeAndPhase := client.NewCompoundIndex("spec.nodeName+status.phase",
+		func(pod *corev1.Pod) []string { return []string{pod.Spec.NodeName} },
+		func(pod *corev1.Pod) []string { return []string{string(pod.Status.Phase)} },
+	)
+	byImage := client.NewIndex("spec.containers.image", client.NestedFieldValues[*corev1.Pod]("spec.containers.image"))
+
+	c := fake.NewClientBuilder().
+		WithObjects(
+			newPod("a", "node-a", corev1.PodRunning, "nginx", "envoy"),
+			newPod("b", "node-a", corev1.PodPending, "nginx"),
+			newPod("c", "node-b", corev1.PodRunning, "redis"),
+		).
+		WithIndex(&corev1.Pod{}, byNodeAndPhase.Field(), byNodeAndPhase.IndexerFunc()).
+		WithIndex(&corev1.Pod{}, byImage.Field(), byImage.IndexerFunc()).
+		Build()
+
+	for _, tc := range []struct {
+		name     string
+		index    *client.Index[*corev1.Pod]
+		values   []string
+		expected []string
+	}{
+		{name: "compound", index: byNodeAndPhase, values: []string{"node-a", "Running"}, expected: []string{"a"}},
+		{name: "compound without match", index: byNodeAndPhase, values: []string{"node-b", "Pending"}},
+		{name: "nested multi-value", index: byImage, values: []string{"nginx"}, expected: []string{"a", "b"}},
+		{name: "nested single value", index: byImage, values: []string{"envoy"}, expected: []string{"a"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pods, err := tc.index.Lookup(t.Context(), c, &corev1.PodList{}, tc.values)
+			if err != nil {
+				t.Fatalf("lookup failed: %v", err)
+			}
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, names)
+			}
+		})
+	}
+
+	if _, err := byNodeAndPhase.Lookup(t.Context(), c, &corev1.PodList{}, []string{"node-a"}); err == nil {
+		t.Fatal("expected an error for a lookup with too few values")
+	}
+}
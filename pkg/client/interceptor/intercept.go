@@ -2,6 +2,7 @@ package interceptor
 
 import (
 	"context"
+	"errors"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +30,42 @@ type Funcs struct {
 	SubResourceApply  func(ctx context.Context, client client.Client, subResourceName string, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error
 }
 
+// Middleware returns a client.Middleware that intercepts the requests of a client
+// with funcs, e.g. to configure interceptors for the clients of a manager through
+// client.Options.Middlewares:
+//
+//	mgr, err := manager.New(cfg, manager.Options{
+//		Client: client.Options{
+//			Middlewares: []client.Middleware{interceptor.Middleware(interceptor.Funcs{
+//				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+//					log.FromContext(ctx).Info("Creating object", "object", obj)
+//					return c.Create(ctx, obj, opts...)
+//				},
+//			})},
+//		},
+//	})
+//
+// The wrapped client is passed to funcs as a client.WithWatch. Its Watch fails if it
+// doesn't support watches.
+func Middleware(funcs Funcs) client.Middleware {
+	return func(c client.Client) client.Client {
+		withWatch, ok := c.(client.WithWatch)
+		if !ok {
+			withWatch = withoutWatch{Client: c}
+		}
+		return NewClient(withWatch, funcs)
+	}
+}
+
+// withoutWatch is a client.WithWatch for a client that doesn't support watches.
+type withoutWatch struct {
+	client.Client
+}
+
+func (c withoutWatch) Watch(context.Context, client.ObjectList, ...client.ListOption) (watch.Interface, error) {
+	return nil, errors.New("the intercepted client doesn't support watches")
+}
+
 // NewClient returns a new interceptor client that calls the functions in funcs instead of the underlying client's methods, if they are not nil.
 func NewClient(interceptedClient client.WithWatch, funcs Funcs) client.WithWatch {
 	return interceptor{
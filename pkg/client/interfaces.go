@@ -129,6 +129,9 @@ type SubResourceClientConstructor interface {
 	//     dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"}}
 	//     scale := &autoscalingv1.Scale{Spec: autoscalingv1.ScaleSpec{Replicas: 2}}
 	//     c.SubResource("scale").Update(ctx, dep, client.WithSubResourceBody(scale))
+	//
+	// Any other subresource, e.g. of an aggregated API or a CRD, can be used the same way.
+	// See NewTypedSubResourceClient for a client that is typed for the subresource.
 	SubResource(subResource string) SubResourceClient
 }
 
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultListPageSize = 500
+
+	// continueNotSupported is the continue token set by the cache on the lists it
+	// returns, which are not paged by the API server.
+	continueNotSupported = "continue-not-supported"
+)
+
+// ErrStopIteration can be returned by the callback of ListIterator.ForEach or
+// ListIterator.ForEachPage to stop the iteration early without an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// ListIterator lists objects page by page with the limit and continue options,
+// so that a controller can process a large number of objects without holding
+// all of them in memory at once.
+//
+// When the Reader is a cache, which does not support continue tokens, the
+// objects are paged with the ListOffset option instead. The cache sorts them
+// by namespace and name, unless Options contain a SortBy, so that pages don't
+// overlap.
+type ListIterator struct {
+	// Reader is used to list the objects.
+	Reader Reader
+
+	// PageSize is the number of objects listed per page. Defaults to 500.
+	PageSize int64
+
+	// Options are passed to every List. Limit, Continue and Offset options are
+	// overridden by the iterator.
+	Options []ListOption
+}
+
+// ForEachPage lists the objects into list one page at a time and calls fn with
+// each page. list is reused for all pages, its items must be copied if they are
+// needed after fn returned.
+//
+// If a continue token expires while iterating, the error of the List is returned
+// and the iteration must be restarted.
+func (it *ListIterator) ForEachPage(ctx context.Context, list ObjectList, fn func(ObjectList) error) error {
+	pageSize := it.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+
+	var cont string
+	var offset int64
+	for {
+		opts := append(append([]ListOption(nil), it.Options...), Limit(pageSize))
+		if offset > 0 {
+			opts = append(opts, ListOffset(offset))
+		} else if cont != "" {
+			opts = append(opts, Continue(cont))
+		}
+		if err := it.Reader.List(ctx, list, opts...); err != nil {
+			return err
+		}
+
+		var more bool
+		if cont = list.GetContinue(); cont == continueNotSupported {
+			// The cache sorts the pages it lists, so that they can be listed with
+			// offsets instead of continue tokens.
+			n := int64(meta.LenList(list))
+			if n == 0 && offset > 0 {
+				return nil
+			}
+			offset += n
+			more = n >= pageSize
+		} else {
+			more = cont != ""
+		}
+
+		if err := fn(list); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
+// ForEach lists the objects into list one page at a time and calls fn with each
+// object. The objects are items of list, which is reused for all pages, they
+// must be copied if they are needed after fn returned.
+func (it *ListIterator) ForEach(ctx context.Context, list ObjectList, fn func(Object) error) error {
+	return it.ForEachPage(ctx, list, func(page ObjectList) error {
+		return meta.EachListItem(page, func(item runtime.Object) error {
+			return fn(item.(Object))
+		})
+	})
+}
+
+// ForEach lists the objects matching opts with r one page at a time and calls fn
+// with each object, see ListIterator.
+func ForEach(ctx context.Context, r Reader, list ObjectList, fn func(Object) error, opts ...ListOption) error {
+	it := &ListIterator{Reader: r, Options: opts}
+	return it.ForEach(ctx, list, fn)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// pagingReader pages ConfigMaps like the API server, with the index of the next
+// object as continue token.
+type pagingReader struct {
+	client.Reader
+	lists int
+}
+
+func (r *pagingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.lists++
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if listOpts.SortBy != nil || listOpts.Offset != 0 {
+		return errors.New("the SortBy and Offset list options are only supported when listing from the cache")
+	}
+	cms := list.(*corev1.ConfigMapList)
+	if err := r.Reader.List(ctx, cms, client.InNamespace(listOpts.Namespace)); err != nil {
+		return err
+	}
+	start := 0
+	if listOpts.Continue != "" {
+		start, _ = strconv.Atoi(listOpts.Continue)
+	}
+	total := len(cms.Items)
+	end := min(start+int(listOpts.Limit), total)
+	cms.Items = cms.Items[start:end]
+	cms.Continue = ""
+	if end < total {
+		cms.Continue = strconv.Itoa(end)
+	}
+	return nil
+}
+
+// cacheLikeReader pages ConfigMaps like the cache, which sorts pages by namespace
+// and name by default and doesn't support continue tokens.
+type cacheLikeReader struct {
+	client.Reader
+	lists int
+}
+
+func (r *cacheLikeReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.lists++
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	cms := list.(*corev1.ConfigMapList)
+	if err := r.Reader.List(ctx, cms); err != nil {
+		return err
+	}
+	if listOpts.SortBy == nil && (listOpts.Limit > 0 || listOpts.Offset > 0) {
+		listOpts.SortBy = client.SortByName
+	}
+	if listOpts.SortBy != nil {
+		slices.SortStableFunc(cms.Items, func(a, b corev1.ConfigMap) int {
+			return listOpts.SortBy(&a, &b)
+		})
+	} else {
+		slices.Reverse(cms.Items)
+	}
+	start := min(int(listOpts.Offset), len(cms.Items))
+	end := len(cms.Items)
+	if listOpts.Limit > 0 {
+		end = min(start+int(listOpts.Limit), end)
+	}
+	cms.Items = cms.Items[start:end]
+	cms.Continue = "continue-not-supported"
+	return nil
+}
+
+func configMapsClient(t *testing.T, n int) client.Client {
+	t.Helper()
+	c := fake.NewClientBuilder().Build()
+	for i := range n {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("cm-%03d", i)}}
+		if err := c.Create(t.Context(), cm); err != nil {
+			t.Fatalf("failed to create ConfigMap: %v", err)
+		}
+	}
+	return c
+}
+
+func iterateNames(t *testing.T, it *client.ListIterator) []string {
+	t.Helper()
+	var names []string
+	err := it.ForEach(t.Context(), &corev1.ConfigMapList{}, func(obj client.Object) error {
+		names = append(names, obj.GetName())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to iterate: %v", err)
+	}
+	return names
+}
+
+func expectAllNames(t *testing.T, names []string, n int) {
+	t.Helper()
+	if len(names) != n {
+		t.Fatalf("expected %d objects, got %d: %v", n, len(names), names)
+	}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			t.Fatalf("object %s was listed twice: %v", name, names)
+		}
+		seen[name] = true
+	}
+}
+
+func TestListIteratorPagesWithContinue(t *testing.T) {
+	r := &pagingReader{Reader: configMapsClient(t, 25)}
+	names := iterateNames(t, &client.ListIterator{Reader: r, PageSize: 10})
+	expectAllNames(t, names, 25)
+	if r.lists != 3 {
+		t.Fatalf("expected 3 pages to be listed, got %d", r.lists)
+	}
+}
+
+func TestListIteratorForEachPage(t *testing.T) {
+	r := &pagingReader{Reader: configMapsClient(t, 25)}
+	it := &client.ListIterator{Reader: r, PageSize: 10}
+	var sizes []int
+	err := it.ForEachPage(t.Context(), &corev1.ConfigMapList{}, func(page client.ObjectList) error {
+		sizes = append(sizes, len(page.(*corev1.ConfigMapList).Items))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to iterate: %v", err)
+	}
+	if !slices.Equal(sizes, []int{10, 10, 5}) {
+		t.Fatalf("expected pages of 10, 10 and 5 objects, got %v", sizes)
+	}
+}
+
+func TestListIteratorStopIteration(t *testing.T) {
+	r := &pagingReader{Reader: configMapsClient(t, 25)}
+	var names []string
+	err := client.ForEach(t.Context(), r, &corev1.ConfigMapList{}, func(obj client.Object) error {
+		names = append(names, obj.GetName())
+		if len(names) == 3 {
+			return client.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(names) != 3 || r.lists != 1 {
+		t.Fatalf("expected iteration to stop after 3 objects in 1 page, got %v in %d pages", names, r.lists)
+	}
+}
+
+func TestListIteratorReturnsCallbackError(t *testing.T) {
+	r := &pagingReader{Reader: configMapsClient(t, 5)}
+	errBroken := errors.New("broken")
+	err := client.ForEach(t.Context(), r, &corev1.ConfigMapList{}, func(client.Object) error {
+		return errBroken
+	})
+	if !errors.Is(err, errBroken) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
+
+func TestListIteratorPagesCacheWithOffset(t *testing.T) {
+	r := &cacheLikeReader{Reader: configMapsClient(t, 25)}
+	names := iterateNames(t, &client.ListIterator{Reader: r, PageSize: 10})
+	expectAllNames(t, names, 25)
+	if !slices.IsSorted(names) {
+		t.Fatalf("expected objects to be sorted by name, got %v", names)
+	}
+	if r.lists != 3 {
+		t.Fatalf("expected 3 lists, got %d", r.lists)
+	}
+}
+
+func TestListIteratorSinglePageFromCache(t *testing.T) {
+	r := &cacheLikeReader{Reader: configMapsClient(t, 5)}
+	names := iterateNames(t, &client.ListIterator{Reader: r, PageSize: 10})
+	expectAllNames(t, names, 5)
+	if r.lists != 1 {
+		t.Fatalf("expected 1 list, got %d", r.lists)
+	}
+}
@@ -153,6 +153,9 @@ func (mc *metadataClient) List(ctx context.Context, obj ObjectList, opts ...List
 
 	listOpts := ListOptions{}
 	listOpts.ApplyOptions(opts)
+	if err := listOpts.errCacheOnlyListOptions(); err != nil {
+		return err
+	}
 
 	resInt, err := mc.getResourceInterface(gvk, listOpts.Namespace)
 	if err != nil {
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+)
+
+// MetadataStamp are labels and annotations that are set on every object
+// written by a client, e.g. the version of an operator or the managed-by label.
+type MetadataStamp struct {
+	// Labels are set on every created, updated, patched or applied object,
+	// overriding existing values of the same keys.
+	Labels map[string]string
+
+	// Annotations are set on every created, updated, patched or applied object,
+	// overriding existing values of the same keys.
+	Annotations map[string]string
+}
+
+// WithMetadataStamp wraps a Client and sets the labels and annotations of stamp on
+// all objects passed to Create, Update, Patch and Apply. The stamp is set on a copy,
+// the objects passed in are only updated with the response of the API server.
+//
+// Merge, strategic merge and apply patches are stamped by adding the labels and
+// annotations to the patch. JSON patches are sent as they are, as they can't be
+// extended without knowing the current labels and annotations of the object.
+// Apply configurations must be unstructured or provide WithLabels and WithAnnotations
+// methods, as the ones generated by applyconfiguration-gen do, Apply returns an error
+// for other ones.
+func WithMetadataStamp(c Client, stamp MetadataStamp) Client {
+	return &clientWithMetadataStamp{
+		stamp:  stamp,
+		c:      c,
+		Reader: c,
+	}
+}
+
+type clientWithMetadataStamp struct {
+	stamp MetadataStamp
+	c     Client
+	Reader
+}
+
+func (s *clientWithMetadataStamp) Create(ctx context.Context, obj Object, opts ...CreateOption) error {
+	stamped := s.stampObject(obj)
+	if err := s.c.Create(ctx, stamped, opts...); err != nil {
+		return err
+	}
+	copyInto(obj, stamped)
+	return nil
+}
+
+func (s *clientWithMetadataStamp) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	stamped := s.stampObject(obj)
+	if err := s.c.Update(ctx, stamped, opts...); err != nil {
+		return err
+	}
+	copyInto(obj, stamped)
+	return nil
+}
+
+func (s *clientWithMetadataStamp) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	return s.c.Patch(ctx, obj, &stampedPatch{Patch: patch, stamp: s.stamp}, opts...)
+}
+
+func (s *clientWithMetadataStamp) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...ApplyOption) error {
+	stamped, err := s.stampApplyConfiguration(obj)
+	if err != nil {
+		return err
+	}
+	if err := s.c.Apply(ctx, stamped, opts...); err != nil {
+		return err
+	}
+	if u, ok := obj.(*unstructuredApplyConfiguration); ok {
+		copyInto(u.Unstructured, stamped.(*unstructuredApplyConfiguration).Unstructured)
+		return nil
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(stamped).Elem())
+	return nil
+}
+
+func (s *clientWithMetadataStamp) Delete(ctx context.Context, obj Object, opts ...DeleteOption) error {
+	return s.c.Delete(ctx, obj, opts...)
+}
+
+func (s *clientWithMetadataStamp) DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error {
+	return s.c.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (s *clientWithMetadataStamp) Scheme() *runtime.Scheme     { return s.c.Scheme() }
+func (s *clientWithMetadataStamp) RESTMapper() meta.RESTMapper { return s.c.RESTMapper() }
+func (s *clientWithMetadataStamp) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return s.c.GroupVersionKindFor(obj)
+}
+func (s *clientWithMetadataStamp) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return s.c.IsObjectNamespaced(obj)
+}
+
+// Status implements client.StatusClient. Status writes don't change labels and
+// annotations, so they are not stamped.
+func (s *clientWithMetadataStamp) Status() SubResourceWriter {
+	return s.c.Status()
+}
+
+// SubResource implements client.SubResourceClientConstructor. Subresource writes
+// don't change labels and annotations, so they are not stamped.
+func (s *clientWithMetadataStamp) SubResource(subresource string) SubResourceClient {
+	return s.c.SubResource(subresource)
+}
+
+// stampObject returns a copy of obj with the stamp set.
+func (s *clientWithMetadataStamp) stampObject(obj Object) Object {
+	stamped := obj.DeepCopyObject().(Object)
+	if len(s.stamp.Labels) > 0 {
+		labels := stamped.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(s.stamp.Labels))
+		}
+		maps.Copy(labels, s.stamp.Labels)
+		stamped.SetLabels(labels)
+	}
+	if len(s.stamp.Annotations) > 0 {
+		annotations := stamped.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, len(s.stamp.Annotations))
+		}
+		maps.Copy(annotations, s.stamp.Annotations)
+		stamped.SetAnnotations(annotations)
+	}
+	return stamped
+}
+
+// stampApplyConfiguration returns a copy of obj with the stamp set.
+func (s *clientWithMetadataStamp) stampApplyConfiguration(obj runtime.ApplyConfiguration) (runtime.ApplyConfiguration, error) {
+	if u, ok := obj.(*unstructuredApplyConfiguration); ok {
+		return &unstructuredApplyConfiguration{Unstructured: s.stampObject(u.Unstructured).(*unstructured.Unstructured)}, nil
+	}
+
+	// Generated apply configurations are plain structs that can be copied through
+	// their JSON representation.
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot stamp apply configuration %T, it must be a pointer to a struct", obj)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy apply configuration %T: %w", obj, err)
+	}
+	stamped := reflect.New(v.Elem().Type())
+	if err := json.Unmarshal(data, stamped.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to copy apply configuration %T: %w", obj, err)
+	}
+
+	// Generated apply configurations return their own type from the With methods,
+	// so they can only be called through reflection.
+	for method, values := range map[string]map[string]string{
+		"WithLabels":      s.stamp.Labels,
+		"WithAnnotations": s.stamp.Annotations,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		m := stamped.MethodByName(method)
+		if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().In(0) != reflect.TypeOf(values) {
+			return nil, fmt.Errorf("cannot stamp apply configuration %T, it has no %s(map[string]string) method", obj, method)
+		}
+		m.Call([]reflect.Value{reflect.ValueOf(values)})
+	}
+	return stamped.Interface().(runtime.ApplyConfiguration), nil
+}
+
+// copyInto sets dst to src, which must be objects of the same type.
+func copyInto(dst, src Object) {
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+}
+
+// stampedPatch adds the labels and annotations of a stamp to the data of a patch.
+type stampedPatch struct {
+	Patch
+	stamp MetadataStamp
+}
+
+func (p *stampedPatch) Data(obj Object) ([]byte, error) {
+	data, err := p.Patch.Data(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.stamp.Labels) == 0 && len(p.stamp.Annotations) == 0 {
+		return data, nil
+	}
+
+	switch p.Type() {
+	case types.JSONPatchType:
+		return data, nil
+	case types.MergePatchType, types.StrategicMergePatchType:
+	case types.ApplyYAMLPatchType:
+		// Apply patches may be YAML, the API server accepts JSON as well.
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("failed to stamp apply patch: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("cannot stamp patch of type %q", p.Type())
+	}
+
+	patch := map[string]any{}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, fmt.Errorf("failed to stamp patch: %w", err)
+	}
+	metadata, ok := patch["metadata"].(map[string]any)
+	if !ok {
+		if patch["metadata"] != nil {
+			return nil, fmt.Errorf("cannot stamp patch with metadata of type %T", patch["metadata"])
+		}
+		metadata = map[string]any{}
+		patch["metadata"] = metadata
+	}
+	for field, values := range map[string]map[string]string{
+		"labels":      p.stamp.Labels,
+		"annotations": p.stamp.Annotations,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		existing, ok := metadata[field].(map[string]any)
+		if !ok {
+			existing = make(map[string]any, len(values))
+			metadata[field] = existing
+		}
+		for k, v := range values {
+			existing[k] = v
+		}
+	}
+	return json.Marshal(patch)
+}
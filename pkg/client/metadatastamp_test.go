@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1applyconfigurations "k8s.io/client-go/applyconfigurations/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestWithMetadataStamp(t *testing.T) {
+	stamp := client.MetadataStamp{
+		Labels:      map[string]string{"app.kubernetes.io/managed-by": "operator"},
+		Annotations: map[string]string{"example.com/version": "v1.2.3"},
+	}
+	expectedLabels := map[string]string{"app.kubernetes.io/managed-by": "operator", "existing": "label"}
+	expectedAnnotations := map[string]string{"example.com/version": "v1.2.3"}
+
+	checkObject := func(method string, obj metav1.Object) {
+		t.Helper()
+		if got := obj.GetLabels(); !maps.Equal(got, expectedLabels) {
+			t.Fatalf("%s: wrong labels: expected=%v; got=%v", method, expectedLabels, got)
+		}
+		if got := obj.GetAnnotations(); !maps.Equal(got, expectedAnnotations) {
+			t.Fatalf("%s: wrong annotations: expected=%v; got=%v", method, expectedAnnotations, got)
+		}
+	}
+
+	calls := 0
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			calls++
+			checkObject("Create", obj)
+			return nil
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			calls++
+			checkObject("Update", obj)
+			return nil
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			calls++
+			data, err := patch.Data(obj)
+			if err != nil {
+				t.Fatalf("Patch: failed to get patch data: %v", err)
+			}
+			expected := `{"metadata":{"annotations":{"example.com/version":"v1.2.3"},"labels":{"app.kubernetes.io/managed-by":"operator","new":"label"}}}`
+			if string(data) != expected {
+				t.Fatalf("Patch: wrong patch: expected=%s; got=%s", expected, data)
+			}
+			return nil
+		},
+		Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			calls++
+			switch obj := obj.(type) {
+			case *corev1applyconfigurations.ConfigMapApplyConfiguration:
+				if !maps.Equal(obj.Labels, expectedLabels) || !maps.Equal(obj.Annotations, expectedAnnotations) {
+					t.Fatalf("Apply: wrong metadata: labels=%v; annotations=%v", obj.Labels, obj.Annotations)
+				}
+			case interface{ GetLabels() map[string]string }:
+				if got := obj.GetLabels(); !maps.Equal(got, expectedLabels) {
+					t.Fatalf("Apply: wrong labels: expected=%v; got=%v", expectedLabels, got)
+				}
+			default:
+				t.Fatalf("Apply: unexpected apply configuration %T", obj)
+			}
+			return nil
+		},
+	}).Build()
+	wrappedClient := client.WithMetadataStamp(fakeClient, stamp)
+
+	ctx := t.Context()
+	newObj := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: map[string]string{"existing": "label"}}}
+	}
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName("foo")
+	u.SetLabels(map[string]string{"existing": "label"})
+
+	_ = wrappedClient.Create(ctx, newObj())
+	_ = wrappedClient.Update(ctx, newObj())
+	patched := newObj()
+	patched.Labels["new"] = "label"
+	_ = wrappedClient.Patch(ctx, patched, client.MergeFrom(newObj()))
+	_ = wrappedClient.Apply(ctx, corev1applyconfigurations.ConfigMap("foo", "").WithLabels(map[string]string{"existing": "label"}))
+	_ = wrappedClient.Apply(ctx, client.ApplyConfigurationFromUnstructured(u))
+
+	if expectedCalls := 5; calls != expectedCalls {
+		t.Fatalf("wrong number of calls to assertions: expected=%d; got=%d", expectedCalls, calls)
+	}
+}
+
+func TestWithMetadataStampDoesNotModifyObjects(t *testing.T) {
+	stamp := client.MetadataStamp{Labels: map[string]string{"app.kubernetes.io/managed-by": "operator"}}
+	failing := true
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if failing {
+				return errors.New("boom")
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	wrappedClient := client.WithMetadataStamp(fakeClient, stamp)
+
+	labels := map[string]string{"existing": "label"}
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Labels: labels}}
+	if err := wrappedClient.Create(t.Context(), obj); err == nil {
+		t.Fatal("expected Create to fail")
+	}
+	if len(labels) != 1 || len(obj.Labels) != 1 {
+		t.Fatalf("expected the object not to be stamped after a failed Create, got labels %v", obj.Labels)
+	}
+
+	failing = false
+	if err := wrappedClient.Create(t.Context(), obj); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if len(labels) != 1 {
+		t.Fatalf("expected the labels of the caller not to be modified, got %v", labels)
+	}
+	if obj.Labels["app.kubernetes.io/managed-by"] != "operator" || obj.ResourceVersion == "" {
+		t.Fatalf("expected the object to be updated with the response, got %+v", obj.ObjectMeta)
+	}
+}
+
+type unsupportedApplyConfiguration struct {
+	Name *string `json:"name,omitempty"`
+}
+
+func (*unsupportedApplyConfiguration) IsApplyConfiguration() {}
+
+func TestWithMetadataStampRejectsUnsupportedApplyConfigurations(t *testing.T) {
+	stamp := client.MetadataStamp{Labels: map[string]string{"app.kubernetes.io/managed-by": "operator"}}
+	wrappedClient := client.WithMetadataStamp(fake.NewClientBuilder().Build(), stamp)
+
+	err := wrappedClient.Apply(t.Context(), &unsupportedApplyConfiguration{})
+	if err == nil || !strings.Contains(err.Error(), "has no WithLabels(map[string]string) method") {
+		t.Fatalf("expected an error for an unsupported apply configuration, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+)
+
+func requestLabelValues(verb string, gvk schema.GroupVersionKind) []string {
+	return []string{verb, gvk.Group, gvk.Version, gvk.Kind}
+}
+
+// codeClass returns the class of the HTTP status code of the result of a request,
+// e.g. 2xx or 4xx, or "error" if the request failed without a status.
+func codeClass(err error) string {
+	if err == nil {
+		return "2xx"
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code > 0 {
+		return fmt.Sprintf("%dxx", status.Status().Code/100)
+	}
+	return "error"
+}
+
+// WithMetrics wraps a Client and records the latency of its requests and the class of
+// their result codes by verb and GroupVersionKind in the
+// controller_runtime_client_request_duration_seconds and
+// controller_runtime_client_requests_total metrics. Reads that c serves from a cache
+// are not recorded, so that the metrics only show the requests to the API server. The verbs are the ones of RateLimit.Verbs. Requests that fail without a
+// response from the API server, e.g. because their context is done, have the code
+// class "error".
+func WithMetrics(c Client) Client {
+	return &clientWithMetrics{c: c}
+}
+
+type clientWithMetrics struct {
+	c Client
+}
+
+func (m *clientWithMetrics) observe(verb string, gvk schema.GroupVersionKind, start time.Time, err error) error {
+	labels := requestLabelValues(verb, gvk)
+	clientmetrics.RequestLatency.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	clientmetrics.RequestResults.WithLabelValues(append([]string{codeClass(err)}, labels...)...).Inc()
+	return err
+}
+
+func (m *clientWithMetrics) readsFromCache(obj runtime.Object, verb CacheBypassVerb, namespace string) bool {
+	return readsFromCache(m.c, obj, verb, namespace)
+}
+
+func (m *clientWithMetrics) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
+	if readsFromCache(m.c, obj, CacheBypassGet, key.Namespace) {
+		return m.c.Get(ctx, key, obj, opts...)
+	}
+	start := time.Now()
+	return m.observe("get", requestGVK(m.c, "get", obj), start, m.c.Get(ctx, key, obj, opts...))
+}
+
+func (m *clientWithMetrics) List(ctx context.Context, list ObjectList, opts ...ListOption) error {
+	if readsFromCache(m.c, list, CacheBypassList, (&ListOptions{}).ApplyOptions(opts).Namespace) {
+		return m.c.List(ctx, list, opts...)
+	}
+	start := time.Now()
+	return m.observe("list", requestGVK(m.c, "list", list), start, m.c.List(ctx, list, opts...))
+}
+
+func (m *clientWithMetrics) Create(ctx context.Context, obj Object, opts ...CreateOption) error {
+	start := time.Now()
+	return m.observe("create", requestGVK(m.c, "create", obj), start, m.c.Create(ctx, obj, opts...))
+}
+
+func (m *clientWithMetrics) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	start := time.Now()
+	return m.observe("update", requestGVK(m.c, "update", obj), start, m.c.Update(ctx, obj, opts...))
+}
+
+func (m *clientWithMetrics) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	start := time.Now()
+	return m.observe("patch", requestGVK(m.c, "patch", obj), start, m.c.Patch(ctx, obj, patch, opts...))
+}
+
+func (m *clientWithMetrics) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...ApplyOption) error {
+	start := time.Now()
+	return m.observe("patch", gvkForApplyConfiguration(obj), start, m.c.Apply(ctx, obj, opts...))
+}
+
+func (m *clientWithMetrics) Delete(ctx context.Context, obj Object, opts ...DeleteOption) error {
+	start := time.Now()
+	return m.observe("delete", requestGVK(m.c, "delete", obj), start, m.c.Delete(ctx, obj, opts...))
+}
+
+func (m *clientWithMetrics) DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error {
+	start := time.Now()
+	return m.observe("deletecollection", requestGVK(m.c, "deletecollection", obj), start, m.c.DeleteAllOf(ctx, obj, opts...))
+}
+
+func (m *clientWithMetrics) Scheme() *runtime.Scheme     { return m.c.Scheme() }
+func (m *clientWithMetrics) RESTMapper() meta.RESTMapper { return m.c.RESTMapper() }
+func (m *clientWithMetrics) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return m.c.GroupVersionKindFor(obj)
+}
+func (m *clientWithMetrics) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return m.c.IsObjectNamespaced(obj)
+}
+
+func (m *clientWithMetrics) Status() StatusWriter {
+	return &subResourceClientWithMetrics{
+		metrics: m,
+		writer:  m.c.Status(),
+	}
+}
+
+func (m *clientWithMetrics) SubResource(subResource string) SubResourceClient {
+	c := m.c.SubResource(subResource)
+	return &subResourceClientWithMetrics{
+		metrics: m,
+		reader:  c,
+		writer:  c,
+	}
+}
+
+type subResourceClientWithMetrics struct {
+	metrics *clientWithMetrics
+	reader  SubResourceReader
+	writer  SubResourceWriter
+}
+
+func (s *subResourceClientWithMetrics) Get(ctx context.Context, obj Object, subResource Object, opts ...SubResourceGetOption) error {
+	start := time.Now()
+	return s.metrics.observe("get", requestGVK(s.metrics.c, "get", obj), start, s.reader.Get(ctx, obj, subResource, opts...))
+}
+
+func (s *subResourceClientWithMetrics) Create(ctx context.Context, obj Object, subResource Object, opts ...SubResourceCreateOption) error {
+	start := time.Now()
+	return s.metrics.observe("create", requestGVK(s.metrics.c, "create", obj), start, s.writer.Create(ctx, obj, subResource, opts...))
+}
+
+func (s *subResourceClientWithMetrics) Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error {
+	start := time.Now()
+	return s.metrics.observe("update", requestGVK(s.metrics.c, "update", obj), start, s.writer.Update(ctx, obj, opts...))
+}
+
+func (s *subResourceClientWithMetrics) Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error {
+	start := time.Now()
+	return s.metrics.observe("patch", requestGVK(s.metrics.c, "patch", obj), start, s.writer.Patch(ctx, obj, patch, opts...))
+}
+
+func (s *subResourceClientWithMetrics) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...SubResourceApplyOption) error {
+	start := time.Now()
+	return s.metrics.observe("patch", gvkForApplyConfiguration(obj), start, s.writer.Apply(ctx, obj, opts...))
+}
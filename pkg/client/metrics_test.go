@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestWithMetrics(t *testing.T) {
+	c := client.WithMetrics(fake.NewClientBuilder().Build())
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics"}}
+	if err := c.Create(t.Context(), secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Create(t.Context(), secret.DeepCopy()); err == nil {
+		t.Fatal("expected creating the Secret again to fail")
+	}
+	if err := c.List(t.Context(), &corev1.SecretList{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `
+# HELP controller_runtime_client_requests_total Total number of client requests per verb, kind and result code class
+# TYPE controller_runtime_client_requests_total counter
+controller_runtime_client_requests_total{code="2xx",group="",kind="Secret",verb="create",version="v1"} 1
+controller_runtime_client_requests_total{code="2xx",group="",kind="Secret",verb="list",version="v1"} 1
+controller_runtime_client_requests_total{code="4xx",group="",kind="Secret",verb="create",version="v1"} 1
+`
+	if err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(expected), "controller_runtime_client_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := testutil.GatherAndCount(metrics.Registry, "controller_runtime_client_request_duration_seconds"); err != nil || count != 2 {
+		t.Fatalf("expected latencies for two verbs, got %d: %v", count, err)
+	}
+}
+
+func TestWithMetricsSkipsCacheReads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "api", Name: "test"},
+		})
+	}))
+	defer server.Close()
+
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), apimeta.RESTScopeNamespace)
+	cache := fake.NewClientBuilder().WithObjects(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "cache", Name: "test"}}).Build()
+	c, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+		Mapper: mapper,
+		Cache: &client.CacheOptions{
+			Reader:      cache,
+			BypassRules: []client.CacheBypassRule{{Object: &corev1.Pod{}, Namespaces: []string{"api"}}},
+		},
+		EnableMetrics: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for range 3 {
+		if err := c.Get(t.Context(), client.ObjectKey{Namespace: "cache", Name: "test"}, &corev1.Pod{}); err != nil {
+			t.Fatalf("failed to get Pod from the cache: %v", err)
+		}
+	}
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "api", Name: "test"}, &corev1.Pod{}); err != nil {
+		t.Fatalf("failed to get Pod from the API server: %v", err)
+	}
+
+	if count := testutil.ToFloat64(clientmetrics.RequestResults.WithLabelValues("2xx", "get", "", "v1", "Pod")); count != 1 {
+		t.Fatalf("expected only the Get from the API server to be recorded, got %v", count)
+	}
+}
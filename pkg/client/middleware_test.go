@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestMiddlewares(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/namespaces/default/configmaps/a" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"},
+		})
+	}))
+	defer server.Close()
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	var calls []string
+	recordingMiddleware := func(name string) client.Middleware {
+		return interceptor.Middleware(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				calls = append(calls, name)
+				err := c.Get(ctx, key, obj, opts...)
+				obj.SetLabels(map[string]string{"intercepted-by": name})
+				return err
+			},
+		})
+	}
+	c, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+		HTTPClient:  server.Client(),
+		Mapper:      mapper,
+		Middlewares: []client.Middleware{recordingMiddleware("outer"), recordingMiddleware("inner")},
+	})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "a"}, cm); err != nil {
+		t.Fatalf("failed to get the ConfigMap: %v", err)
+	}
+	if expected := []string{"outer", "inner"}; !slices.Equal(calls, expected) {
+		t.Errorf("expected the middlewares to be called in order %v, got %v", expected, calls)
+	}
+	if cm.Name != "a" || cm.Labels["intercepted-by"] != "outer" {
+		t.Errorf("expected the object to be read and mutated by the outer middleware last, got %v", cm.ObjectMeta)
+	}
+
+	if _, err := c.(client.WithWatch).Watch(t.Context(), &corev1.ConfigMapList{}); err == nil {
+		t.Error("expected Watch to fail for a client that doesn't support watches")
+	}
+}
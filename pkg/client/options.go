@@ -17,6 +17,9 @@ limitations under the License.
 package client
 
 import (
+	"errors"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -524,7 +527,8 @@ type ListOptions struct {
 	// Limit specifies the maximum number of results to return from the server. The server may
 	// not support this field on all resource types, but if it does and more results remain it
 	// will set the continue field on the returned list object. This field is not supported if watch
-	// is true in the Raw ListOptions.
+	// is true in the Raw ListOptions. The cache sorts the objects by namespace and name
+	// before the limit is applied, unless SortBy is set.
 	Limit int64
 	// Continue is a token returned by the server that lets a client retrieve chunks of results
 	// from the server by specifying limit. The server may reject requests for continuation tokens
@@ -538,6 +542,18 @@ type ListOptions struct {
 	// +optional
 	UnsafeDisableDeepCopy *bool
 
+	// SortBy sorts the listed objects with the given comparison function before
+	// Offset and Limit are applied. It is only supported by the cache, listing
+	// from the API server with it fails.
+	// +optional
+	SortBy SortBy
+	// Offset skips the given number of objects before Limit is applied. It is only
+	// supported by the cache, listing from the API server with it fails.
+	// Without SortBy, the objects are sorted by namespace and name, so that consecutive
+	// pages don't overlap.
+	// +optional
+	Offset int64
+
 	// Raw represents raw ListOptions, as passed to the API server.  Note
 	// that these may not be respected by all implementations of interface,
 	// and the LabelSelector, FieldSelector, Limit and Continue fields are ignored.
@@ -546,6 +562,15 @@ type ListOptions struct {
 
 var _ ListOption = &ListOptions{}
 
+// errCacheOnlyListOptions returns an error if the options contain SortBy or Offset,
+// which the API server doesn't support.
+func (o *ListOptions) errCacheOnlyListOptions() error {
+	if o.SortBy != nil || o.Offset != 0 {
+		return errors.New("the SortBy and Offset list options are only supported when listing from the cache")
+	}
+	return nil
+}
+
 // ApplyToList implements ListOption for ListOptions.
 func (o *ListOptions) ApplyToList(lo *ListOptions) {
 	if o.LabelSelector != nil {
@@ -569,6 +594,12 @@ func (o *ListOptions) ApplyToList(lo *ListOptions) {
 	if o.UnsafeDisableDeepCopy != nil {
 		lo.UnsafeDisableDeepCopy = o.UnsafeDisableDeepCopy
 	}
+	if o.SortBy != nil {
+		lo.SortBy = o.SortBy
+	}
+	if o.Offset > 0 {
+		lo.Offset = o.Offset
+	}
 }
 
 // AsListOptions returns these options as a flattened metav1.ListOptions.
@@ -761,6 +792,56 @@ func (c Continue) ApplyToList(opts *ListOptions) {
 	opts.Continue = string(c)
 }
 
+// SortBy sorts the objects listed from the cache with the given comparison function,
+// which returns a negative number if a sorts before b, a positive number if a sorts
+// after b and zero if their order doesn't matter. Objects that compare equal keep
+// their order. Listing from the API server with it fails.
+type SortBy func(a, b Object) int
+
+// ApplyToList applies this configuration to the given an List options.
+func (s SortBy) ApplyToList(opts *ListOptions) {
+	opts.SortBy = s
+}
+
+// SortByName sorts objects by namespace and name.
+var SortByName SortBy = func(a, b Object) int {
+	if c := strings.Compare(a.GetNamespace(), b.GetNamespace()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.GetName(), b.GetName())
+}
+
+// SortByCreationTimestamp sorts objects from the oldest to the newest, objects
+// created at the same time are sorted by namespace and name.
+var SortByCreationTimestamp SortBy = func(a, b Object) int {
+	if c := a.GetCreationTimestamp().Time.Compare(b.GetCreationTimestamp().Time); c != 0 {
+		return c
+	}
+	return SortByName(a, b)
+}
+
+// SortByFunc returns a SortBy that compares objects of type T with the given function.
+// Objects that are not of type T, e.g. because they are listed as unstructured or
+// metadata-only objects, are sorted by namespace and name instead.
+func SortByFunc[T Object](cmp func(a, b T) int) SortBy {
+	return func(a, b Object) int {
+		typedA, okA := a.(T)
+		typedB, okB := b.(T)
+		if !okA || !okB {
+			return SortByName(a, b)
+		}
+		return cmp(typedA, typedB)
+	}
+}
+
+// ListOffset skips the given number of objects listed from the cache, see ListOptions.Offset.
+type ListOffset int64
+
+// ApplyToList applies this configuration to the given an List options.
+func (o ListOffset) ApplyToList(opts *ListOptions) {
+	opts.Offset = int64(o)
+}
+
 // }}}
 
 // {{{ Update Options
@@ -17,9 +17,13 @@ limitations under the License.
 package client_test
 
 import (
+	"cmp"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
@@ -104,6 +108,47 @@ var _ = Describe("ListOptions", func() {
 		Expect(listOpts.UnsafeDisableDeepCopy).ToNot(BeNil())
 		Expect(*listOpts.UnsafeDisableDeepCopy).To(BeTrue())
 	})
+	It("Should set Offset", func() {
+		o := &client.ListOptions{Offset: int64(2)}
+		newListOpts := &client.ListOptions{}
+		o.ApplyToList(newListOpts)
+		Expect(newListOpts).To(Equal(o))
+	})
+	It("Should set SortBy through option", func() {
+		listOpts := &client.ListOptions{}
+		client.SortByName.ApplyToList(listOpts)
+		Expect(listOpts.SortBy).NotTo(BeNil())
+		Expect(listOpts.SortBy(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Name: "b"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "b", Name: "a"}},
+		)).To(BeNumerically("<", 0))
+	})
+	It("Should sort by creation timestamp and name", func() {
+		now := metav1.Now()
+		older := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "b", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))}}
+		newer := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "a", CreationTimestamp: now}}
+		sameTime := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "c", CreationTimestamp: now}}
+		Expect(client.SortByCreationTimestamp(older, newer)).To(BeNumerically("<", 0))
+		Expect(client.SortByCreationTimestamp(sameTime, newer)).To(BeNumerically(">", 0))
+	})
+	It("Should sort typed objects with SortByFunc", func() {
+		byPriority := client.SortByFunc(func(a, b *corev1.Pod) int {
+			return cmp.Compare(*a.Spec.Priority, *b.Spec.Priority)
+		})
+		Expect(byPriority(
+			&corev1.Pod{Spec: corev1.PodSpec{Priority: new(int32(2))}},
+			&corev1.Pod{Spec: corev1.PodSpec{Priority: new(int32(1))}},
+		)).To(BeNumerically(">", 0))
+	})
+	It("Should sort objects of another type by name with SortByFunc", func() {
+		byPriority := client.SortByFunc(func(a, b *corev1.Pod) int {
+			return cmp.Compare(*a.Spec.Priority, *b.Spec.Priority)
+		})
+		Expect(byPriority(
+			&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+			&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		)).To(BeNumerically(">", 0))
+	})
 	It("Should not set anything", func() {
 		o := &client.ListOptions{}
 		newListOpts := &client.ListOptions{}
@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+)
+
+// RateLimit limits the rate of a subset of the requests of a client, in addition to
+// the QPS and Burst of the rest.Config, e.g. to cap the writes to an expensive
+// custom resource:
+//
+//	client.RateLimit{
+//		Verbs:      []string{"create", "update", "patch", "delete"},
+//		GroupKinds: []schema.GroupKind{{Group: "example.com", Kind: "Report"}},
+//		Limiter:    rate.NewLimiter(2, 5),
+//	}
+type RateLimit struct {
+	// Verbs are the verbs of the requests that are limited, one of get, list, create,
+	// update, patch, delete and deletecollection. Apply requests are patches. Requests
+	// of subresources have the verb of the request and the kind of the object. All
+	// verbs are limited if it is empty.
+	Verbs []string
+
+	// GroupKinds are the kinds of the objects whose requests are limited. The
+	// requests for all kinds are limited if it is empty.
+	GroupKinds []schema.GroupKind
+
+	// Limiter limits the requests. Requests wait until the Limiter allows them or
+	// their context is done. It can be shared by the RateLimits of several clients,
+	// e.g. of the client and the APIReader of a manager, to limit their requests
+	// together.
+	Limiter *rate.Limiter
+}
+
+func (l *RateLimit) matches(verb string, gk schema.GroupKind) bool {
+	return (len(l.Verbs) == 0 || slices.Contains(l.Verbs, verb)) &&
+		(len(l.GroupKinds) == 0 || slices.Contains(l.GroupKinds, gk))
+}
+
+// WithRateLimits wraps a Client and delays its requests according to limits. A request
+// that matches several limits waits until all of them allow it. Reads that c serves
+// from a cache are not limited, as they don't reach the API server. The delayed
+// requests are counted by the controller_runtime_client_rate_limited_requests_total
+// metric and their delay is reported by controller_runtime_client_rate_limit_delay_seconds.
+func WithRateLimits(c Client, limits ...RateLimit) Client {
+	return &clientWithRateLimits{
+		limits: limits,
+		c:      c,
+	}
+}
+
+type clientWithRateLimits struct {
+	limits []RateLimit
+	c      Client
+}
+
+// wait waits until the limits matching a request of verb for obj allow it. Requests
+// for objects whose kind can't be determined are only limited by limits for all kinds.
+func (r *clientWithRateLimits) wait(ctx context.Context, verb string, obj runtime.Object) error {
+	return r.waitFor(ctx, verb, requestGVK(r.c, verb, obj).GroupKind())
+}
+
+func (r *clientWithRateLimits) waitFor(ctx context.Context, verb string, gk schema.GroupKind) error {
+	var (
+		reservations []*rate.Reservation
+		delay        time.Duration
+	)
+	now := time.Now()
+	for i := range r.limits {
+		if !r.limits[i].matches(verb, gk) {
+			continue
+		}
+		reservation := r.limits[i].Limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		delay = max(delay, reservation.DelayFrom(now))
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	clientmetrics.RateLimitedRequests.WithLabelValues(verb, gk.Group, gk.Kind).Inc()
+	clientmetrics.RateLimitDelay.WithLabelValues(verb, gk.Group, gk.Kind).Observe(delay.Seconds())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		for _, reservation := range reservations {
+			reservation.Cancel()
+		}
+		return ctx.Err()
+	}
+}
+
+func (r *clientWithRateLimits) Get(ctx context.Context, key ObjectKey, obj Object, opts ...GetOption) error {
+	if !readsFromCache(r.c, obj, CacheBypassGet, key.Namespace) {
+		if err := r.wait(ctx, "get", obj); err != nil {
+			return err
+		}
+	}
+	return r.c.Get(ctx, key, obj, opts...)
+}
+
+func (r *clientWithRateLimits) List(ctx context.Context, list ObjectList, opts ...ListOption) error {
+	if !readsFromCache(r.c, list, CacheBypassList, (&ListOptions{}).ApplyOptions(opts).Namespace) {
+		if err := r.wait(ctx, "list", list); err != nil {
+			return err
+		}
+	}
+	return r.c.List(ctx, list, opts...)
+}
+
+func (r *clientWithRateLimits) Create(ctx context.Context, obj Object, opts ...CreateOption) error {
+	if err := r.wait(ctx, "create", obj); err != nil {
+		return err
+	}
+	return r.c.Create(ctx, obj, opts...)
+}
+
+func (r *clientWithRateLimits) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	if err := r.wait(ctx, "update", obj); err != nil {
+		return err
+	}
+	return r.c.Update(ctx, obj, opts...)
+}
+
+func (r *clientWithRateLimits) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	if err := r.wait(ctx, "patch", obj); err != nil {
+		return err
+	}
+	return r.c.Patch(ctx, obj, patch, opts...)
+}
+
+func (r *clientWithRateLimits) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...ApplyOption) error {
+	if err := r.waitFor(ctx, "patch", gvkForApplyConfiguration(obj).GroupKind()); err != nil {
+		return err
+	}
+	return r.c.Apply(ctx, obj, opts...)
+}
+
+func (r *clientWithRateLimits) Delete(ctx context.Context, obj Object, opts ...DeleteOption) error {
+	if err := r.wait(ctx, "delete", obj); err != nil {
+		return err
+	}
+	return r.c.Delete(ctx, obj, opts...)
+}
+
+func (r *clientWithRateLimits) DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error {
+	if err := r.wait(ctx, "deletecollection", obj); err != nil {
+		return err
+	}
+	return r.c.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (r *clientWithRateLimits) Scheme() *runtime.Scheme     { return r.c.Scheme() }
+func (r *clientWithRateLimits) RESTMapper() meta.RESTMapper { return r.c.RESTMapper() }
+func (r *clientWithRateLimits) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return r.c.GroupVersionKindFor(obj)
+}
+func (r *clientWithRateLimits) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return r.c.IsObjectNamespaced(obj)
+}
+
+func (r *clientWithRateLimits) readsFromCache(obj runtime.Object, verb CacheBypassVerb, namespace string) bool {
+	return readsFromCache(r.c, obj, verb, namespace)
+}
+
+func (r *clientWithRateLimits) Status() StatusWriter {
+	return &subResourceClientWithRateLimits{
+		limiter: r,
+		writer:  r.c.Status(),
+	}
+}
+
+func (r *clientWithRateLimits) SubResource(subResource string) SubResourceClient {
+	c := r.c.SubResource(subResource)
+	return &subResourceClientWithRateLimits{
+		limiter: r,
+		reader:  c,
+		writer:  c,
+	}
+}
+
+// cachedReader is implemented by clients that serve some reads from a cache, and by the
+// wrappers that pass this on, so that wrappers can tell reads from the cache apart from
+// requests to the API server.
+type cachedReader interface {
+	readsFromCache(obj runtime.Object, verb CacheBypassVerb, namespace string) bool
+}
+
+// readsFromCache returns whether c serves a read of verb for obj in namespace from a cache.
+func readsFromCache(c Client, obj runtime.Object, verb CacheBypassVerb, namespace string) bool {
+	r, ok := c.(cachedReader)
+	return ok && r.readsFromCache(obj, verb, namespace)
+}
+
+// requestGVK returns the GroupVersionKind of the objects a request of verb for obj is
+// for, i.e. the kind of the items for lists, or the empty GroupVersionKind if it can't
+// be determined.
+func requestGVK(c Client, verb string, obj runtime.Object) schema.GroupVersionKind {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}
+	}
+	if verb == "list" {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	return gvk
+}
+
+// gvkForApplyConfiguration returns the GroupVersionKind of an apply configuration, or
+// the empty GroupVersionKind if it can't be determined.
+func gvkForApplyConfiguration(obj runtime.ApplyConfiguration) schema.GroupVersionKind {
+	switch o := obj.(type) {
+	case *unstructuredApplyConfiguration:
+		return o.GroupVersionKind()
+	case applyConfiguration:
+		if gvk, err := gvkFromApplyConfiguration(o); err == nil {
+			return gvk
+		}
+	}
+	return schema.GroupVersionKind{}
+}
+
+type subResourceClientWithRateLimits struct {
+	limiter *clientWithRateLimits
+	reader  SubResourceReader
+	writer  SubResourceWriter
+}
+
+func (s *subResourceClientWithRateLimits) Get(ctx context.Context, obj Object, subResource Object, opts ...SubResourceGetOption) error {
+	if err := s.limiter.wait(ctx, "get", obj); err != nil {
+		return err
+	}
+	return s.reader.Get(ctx, obj, subResource, opts...)
+}
+
+func (s *subResourceClientWithRateLimits) Create(ctx context.Context, obj Object, subResource Object, opts ...SubResourceCreateOption) error {
+	if err := s.limiter.wait(ctx, "create", obj); err != nil {
+		return err
+	}
+	return s.writer.Create(ctx, obj, subResource, opts...)
+}
+
+func (s *subResourceClientWithRateLimits) Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error {
+	if err := s.limiter.wait(ctx, "update", obj); err != nil {
+		return err
+	}
+	return s.writer.Update(ctx, obj, opts...)
+}
+
+func (s *subResourceClientWithRateLimits) Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error {
+	if err := s.limiter.wait(ctx, "patch", obj); err != nil {
+		return err
+	}
+	return s.writer.Patch(ctx, obj, patch, opts...)
+}
+
+func (s *subResourceClientWithRateLimits) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...SubResourceApplyOption) error {
+	if err := s.limiter.waitFor(ctx, "patch", gvkForApplyConfiguration(obj).GroupKind()); err != nil {
+		return err
+	}
+	return s.writer.Apply(ctx, obj, opts...)
+}
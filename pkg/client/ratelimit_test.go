@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestWithRateLimits(t *testing.T) {
+	c := client.WithRateLimits(fake.NewClientBuilder().Build(), client.RateLimit{
+		Verbs:      []string{"create", "update"},
+		GroupKinds: []schema.GroupKind{{Kind: "ConfigMap"}},
+		Limiter:    rate.NewLimiter(rate.Every(time.Hour), 1),
+	})
+
+	if err := c.Create(t.Context(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	err := c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second create to be delayed until the context is done, got %v", err)
+	}
+
+	// Other verbs and kinds are not limited.
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Create(t.Context(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.List(t.Context(), &corev1.ConfigMapList{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count, err := testutil.GatherAndCount(metrics.Registry, "controller_runtime_client_rate_limited_requests_total"); err != nil || count != 1 {
+		t.Fatalf("expected one series of delayed requests, got %d: %v", count, err)
+	}
+}
+
+func TestWithRateLimitsList(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	c := client.WithRateLimits(fake.NewClientBuilder().Build(), client.RateLimit{
+		Verbs:      []string{"list"},
+		GroupKinds: []schema.GroupKind{{Kind: "ConfigMap"}},
+		Limiter:    limiter,
+	})
+
+	if err := c.List(t.Context(), &corev1.ConfigMapList{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens := limiter.Tokens(); tokens >= 1 {
+		t.Fatalf("expected the list to be limited by the kind of its items, the limiter has %f tokens", tokens)
+	}
+}
+
+func TestWithRateLimitsSkipsCacheReads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "api", Name: "test"},
+		})
+	}))
+	defer server.Close()
+
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), apimeta.RESTScopeNamespace)
+	cache := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "cache", Name: "test"}}).Build()
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	c, err := client.New(&rest.Config{Host: server.URL}, client.Options{
+		Mapper: mapper,
+		Cache: &client.CacheOptions{
+			Reader:      cache,
+			BypassRules: []client.CacheBypassRule{{Object: &corev1.ConfigMap{}, Namespaces: []string{"api"}}},
+		},
+		RateLimits:    []client.RateLimit{{Verbs: []string{"get", "list"}, Limiter: limiter}},
+		EnableMetrics: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	for range 3 {
+		if err := c.Get(t.Context(), client.ObjectKey{Namespace: "cache", Name: "test"}, &corev1.ConfigMap{}); err != nil {
+			t.Fatalf("failed to get ConfigMap from the cache: %v", err)
+		}
+		if err := c.List(t.Context(), &corev1.ConfigMapList{}, client.InNamespace("cache")); err != nil {
+			t.Fatalf("failed to list ConfigMaps from the cache: %v", err)
+		}
+	}
+	if tokens := limiter.Tokens(); tokens < 1 {
+		t.Fatalf("expected reads from the cache not to be limited, the limiter has %f tokens", tokens)
+	}
+
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "api", Name: "test"}, &corev1.ConfigMap{}); err != nil {
+		t.Fatalf("failed to get ConfigMap from the API server: %v", err)
+	}
+	if tokens := limiter.Tokens(); tokens >= 1 {
+		t.Fatalf("expected reads from the API server to be limited, the limiter has %f tokens", tokens)
+	}
+}
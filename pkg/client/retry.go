@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+)
+
+// RetryOptions are the options of a client created with WithRetry.
+type RetryOptions struct {
+	// Backoff is used to retry a write after a conflict. Defaults to retry.DefaultRetry.
+	Backoff *wait.Backoff
+
+	// Retriable returns whether a failed write is retried. Defaults to
+	// apierrors.IsConflict.
+	Retriable func(error) bool
+}
+
+// MutateOnConflict is an option of Update and Patch, and of the updates and patches
+// of the status subresource, that makes a client created with WithRetry retry the
+// write after a conflict. The object is read again and passed to the function,
+// which has to make the same changes to it again, e.g.
+//
+//	mutate := func(obj client.Object) error {
+//		obj.(*appsv1.Deployment).Spec.Replicas = ptr.To(int32(3))
+//		return nil
+//	}
+//	// deploy was mutated already for the first attempt.
+//	err := c.Update(ctx, deploy, client.MutateOnConflict(mutate))
+//
+// The option does nothing for clients that weren't created with WithRetry.
+type MutateOnConflict func(obj Object) error
+
+// ApplyToUpdate implements UpdateOption.
+func (MutateOnConflict) ApplyToUpdate(*UpdateOptions) {}
+
+// ApplyToPatch implements PatchOption.
+func (MutateOnConflict) ApplyToPatch(*PatchOptions) {}
+
+// ApplyToSubResourceUpdate implements SubResourceUpdateOption.
+func (MutateOnConflict) ApplyToSubResourceUpdate(*SubResourceUpdateOptions) {}
+
+// ApplyToSubResourcePatch implements SubResourcePatchOption.
+func (MutateOnConflict) ApplyToSubResourcePatch(*SubResourcePatchOptions) {}
+
+// WithRetry wraps a Client and retries Update and Patch, and the updates and patches
+// of the status subresource, if they fail with a conflict and a MutateOnConflict
+// option is passed. Before each retry the object is read again through the client,
+// and the mutation of the option is applied to it. This replaces wrapping writes
+// in retry.RetryOnConflict.
+//
+// Merge patches created with MergeFrom, MergeFromWithOptions and StrategicMergeFrom
+// are computed again from the object that was read, with the same options, so
+// patches with an optimistic lock use its new resourceVersion. Patches that aren't
+// computed from the object, e.g. the ones created with RawPatch, are not retried.
+//
+// Writes without a MutateOnConflict option and writes of other subresources are
+// passed to the wrapped client as they are. Retries are counted by the
+// controller_runtime_client_request_retries_total metric.
+func WithRetry(c Client, opts RetryOptions) Client {
+	if opts.Backoff == nil {
+		opts.Backoff = &retry.DefaultRetry
+	}
+	if opts.Retriable == nil {
+		opts.Retriable = apierrors.IsConflict
+	}
+	return &clientWithRetry{
+		opts:   opts,
+		c:      c,
+		Reader: c,
+	}
+}
+
+type clientWithRetry struct {
+	opts RetryOptions
+	c    Client
+	Reader
+}
+
+func (r *clientWithRetry) Create(ctx context.Context, obj Object, opts ...CreateOption) error {
+	return r.c.Create(ctx, obj, opts...)
+}
+
+func (r *clientWithRetry) Update(ctx context.Context, obj Object, opts ...UpdateOption) error {
+	mutate, ok := mutationFrom(opts)
+	if !ok {
+		return r.c.Update(ctx, obj, opts...)
+	}
+	return r.retry(ctx, "update", obj, mutate, func() error {
+		return r.c.Update(ctx, obj, opts...)
+	})
+}
+
+func (r *clientWithRetry) Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error {
+	mutate, ok := mutationFrom(opts)
+	if !ok || !retriablePatch(patch) {
+		return r.c.Patch(ctx, obj, patch, opts...)
+	}
+	return r.retryPatch(ctx, obj, patch, mutate, func(patch Patch) error {
+		return r.c.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (r *clientWithRetry) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...ApplyOption) error {
+	return r.c.Apply(ctx, obj, opts...)
+}
+
+func (r *clientWithRetry) Delete(ctx context.Context, obj Object, opts ...DeleteOption) error {
+	return r.c.Delete(ctx, obj, opts...)
+}
+
+func (r *clientWithRetry) DeleteAllOf(ctx context.Context, obj Object, opts ...DeleteAllOfOption) error {
+	return r.c.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (r *clientWithRetry) Scheme() *runtime.Scheme     { return r.c.Scheme() }
+func (r *clientWithRetry) RESTMapper() meta.RESTMapper { return r.c.RESTMapper() }
+func (r *clientWithRetry) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	return r.c.GroupVersionKindFor(obj)
+}
+func (r *clientWithRetry) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	return r.c.IsObjectNamespaced(obj)
+}
+
+func (r *clientWithRetry) Status() StatusWriter {
+	return &statusClientWithRetry{
+		retrier:           r,
+		subresourceWriter: r.c.Status(),
+	}
+}
+
+func (r *clientWithRetry) SubResource(subresource string) SubResourceClient {
+	c := r.c.SubResource(subresource)
+	if subresource != "status" {
+		return c
+	}
+	return &statusClientWithRetry{
+		retrier:           r,
+		subresourceWriter: c,
+		SubResourceReader: c,
+	}
+}
+
+// retry runs write and, as long as it fails with a retriable error, reads obj again,
+// applies mutate to it and runs write again.
+func (r *clientWithRetry) retry(ctx context.Context, verb string, obj Object, mutate MutateOnConflict, write func() error) error {
+	first := true
+	return retry.OnError(*r.opts.Backoff, r.opts.Retriable, func() error {
+		if !first {
+			r.countRetry(verb, obj)
+			if err := r.refresh(ctx, obj); err != nil {
+				return err
+			}
+			if err := mutate(obj); err != nil {
+				return err
+			}
+		}
+		first = false
+		return write()
+	})
+}
+
+// retryPatch is like retry, but computes merge patches again from the object that
+// was read before it is mutated.
+func (r *clientWithRetry) retryPatch(ctx context.Context, obj Object, patch Patch, mutate MutateOnConflict, write func(Patch) error) error {
+	first := true
+	return retry.OnError(*r.opts.Backoff, r.opts.Retriable, func() error {
+		if !first {
+			r.countRetry("patch", obj)
+			if err := r.refresh(ctx, obj); err != nil {
+				return err
+			}
+			if mergeFrom, ok := patch.(*mergeFromPatch); ok {
+				rebased := *mergeFrom
+				rebased.from = obj.DeepCopyObject().(Object)
+				patch = &rebased
+			}
+			if err := mutate(obj); err != nil {
+				return err
+			}
+		}
+		first = false
+		return write(patch)
+	})
+}
+
+func (r *clientWithRetry) countRetry(verb string, obj Object) {
+	clientmetrics.RequestRetries.WithLabelValues(requestLabelValues(verb, requestGVK(r.c, verb, obj))...).Inc()
+}
+
+// refresh reads obj again. It is read into a new object, so that fields that were
+// removed on the server don't survive in obj.
+func (r *clientWithRetry) refresh(ctx context.Context, obj Object) error {
+	latest := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(Object)
+	latest.GetObjectKind().SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := r.c.Get(ctx, ObjectKeyFromObject(obj), latest); err != nil {
+		return err
+	}
+	copyInto(obj, latest)
+	return nil
+}
+
+// mutationFrom returns the last MutateOnConflict option of opts.
+func mutationFrom[option any](opts []option) (MutateOnConflict, bool) {
+	var (
+		mutate MutateOnConflict
+		found  bool
+	)
+	for _, opt := range opts {
+		if m, ok := any(opt).(MutateOnConflict); ok && m != nil {
+			mutate, found = m, true
+		}
+	}
+	return mutate, found
+}
+
+// retriablePatch returns whether the data of patch is computed from the object, so
+// that it changes when the object is read again and mutated.
+func retriablePatch(patch Patch) bool {
+	switch patch.(type) {
+	case *mergeFromPatch, mergePatch, applyPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+type statusClientWithRetry struct {
+	retrier           *clientWithRetry
+	subresourceWriter SubResourceWriter
+	SubResourceReader
+}
+
+func (s *statusClientWithRetry) Create(ctx context.Context, obj Object, subresource Object, opts ...SubResourceCreateOption) error {
+	return s.subresourceWriter.Create(ctx, obj, subresource, opts...)
+}
+
+func (s *statusClientWithRetry) Update(ctx context.Context, obj Object, opts ...SubResourceUpdateOption) error {
+	mutate, ok := mutationFrom(opts)
+	if !ok {
+		return s.subresourceWriter.Update(ctx, obj, opts...)
+	}
+	return s.retrier.retry(ctx, "update", obj, mutate, func() error {
+		return s.subresourceWriter.Update(ctx, obj, opts...)
+	})
+}
+
+func (s *statusClientWithRetry) Patch(ctx context.Context, obj Object, patch Patch, opts ...SubResourcePatchOption) error {
+	mutate, ok := mutationFrom(opts)
+	if !ok || !retriablePatch(patch) {
+		return s.subresourceWriter.Patch(ctx, obj, patch, opts...)
+	}
+	return s.retrier.retryPatch(ctx, obj, patch, mutate, func(patch Patch) error {
+		return s.subresourceWriter.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (s *statusClientWithRetry) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...SubResourceApplyOption) error {
+	return s.subresourceWriter.Apply(ctx, obj, opts...)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// staleConfigMap creates a ConfigMap and returns a copy of it that is outdated,
+// because the ConfigMap was updated concurrently afterwards.
+func staleConfigMap(t *testing.T, c client.Client) *corev1.ConfigMap {
+	t.Helper()
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "config"},
+		Data:       map[string]string{"a": "1"},
+	}
+	if err := c.Create(t.Context(), cm); err != nil {
+		t.Fatalf("failed to create ConfigMap: %v", err)
+	}
+	stale := cm.DeepCopy()
+	cm.Data["b"] = "2"
+	if err := c.Update(t.Context(), cm); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	return stale
+}
+
+func setData(key, value string) client.MutateOnConflict {
+	return func(obj client.Object) error {
+		cm := obj.(*corev1.ConfigMap)
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = value
+		return nil
+	}
+}
+
+func expectData(t *testing.T, c client.Client, expected map[string]string) {
+	t.Helper()
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "config"}, cm); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if len(cm.Data) != len(expected) {
+		t.Fatalf("expected data %v, got %v", expected, cm.Data)
+	}
+	for k, v := range expected {
+		if cm.Data[k] != v {
+			t.Fatalf("expected data %v, got %v", expected, cm.Data)
+		}
+	}
+}
+
+func TestWithRetryUpdate(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	stale := staleConfigMap(t, c)
+	retrying := client.WithRetry(c, client.RetryOptions{})
+
+	mutate := setData("c", "3")
+	_ = mutate(stale)
+	if err := retrying.Update(t.Context(), stale, mutate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectData(t, c, map[string]string{"a": "1", "b": "2", "c": "3"})
+	if stale.Data["b"] != "2" {
+		t.Fatalf("expected the object to be updated with the response, got %v", stale.Data)
+	}
+}
+
+func TestWithRetryUpdateWithoutMutation(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	stale := staleConfigMap(t, c)
+	retrying := client.WithRetry(c, client.RetryOptions{})
+
+	stale.Data["c"] = "3"
+	if err := retrying.Update(t.Context(), stale); !apierrors.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+}
+
+func TestWithRetryPatchWithOptimisticLock(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	stale := staleConfigMap(t, c)
+	retrying := client.WithRetry(c, client.RetryOptions{})
+
+	patch := client.MergeFromWithOptions(stale.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	mutate := setData("c", "3")
+	_ = mutate(stale)
+	if err := retrying.Patch(t.Context(), stale, patch, mutate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectData(t, c, map[string]string{"a": "1", "b": "2", "c": "3"})
+}
+
+func TestWithRetryRawPatchIsNotRetried(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	stale := staleConfigMap(t, c)
+	retrying := client.WithRetry(c, client.RetryOptions{})
+
+	patch := client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"resourceVersion":"`+stale.ResourceVersion+`"},"data":{"c":"3"}}`))
+	if err := retrying.Patch(t.Context(), stale, patch, setData("c", "3")); !apierrors.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+}
+
+func TestWithRetryStatusUpdate(t *testing.T) {
+	c := fake.NewClientBuilder().WithStatusSubresource(&corev1.Pod{}).Build()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"}}
+	if err := c.Create(t.Context(), pod); err != nil {
+		t.Fatalf("failed to create Pod: %v", err)
+	}
+	stale := pod.DeepCopy()
+	pod.Labels = map[string]string{"app": "pod"}
+	if err := c.Update(t.Context(), pod); err != nil {
+		t.Fatalf("failed to update Pod: %v", err)
+	}
+
+	mutated := 0
+	mutate := client.MutateOnConflict(func(obj client.Object) error {
+		mutated++
+		obj.(*corev1.Pod).Status.Phase = corev1.PodRunning
+		return nil
+	})
+	_ = mutate(stale)
+	if err := client.WithRetry(c, client.RetryOptions{}).Status().Update(t.Context(), stale, mutate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mutated != 2 {
+		t.Fatalf("expected the mutation to be applied again once, got %d calls", mutated)
+	}
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(pod), pod); err != nil {
+		t.Fatalf("failed to get Pod: %v", err)
+	}
+	if pod.Status.Phase != corev1.PodRunning || pod.Labels["app"] != "pod" {
+		t.Fatalf("expected the status and the concurrent update to be kept, got %+v", pod)
+	}
+}
+
+func TestWithRetryCountsRetries(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	stale := staleConfigMap(t, c)
+	before := retries(t)
+
+	mutate := setData("c", "3")
+	_ = mutate(stale)
+	if err := client.WithRetry(c, client.RetryOptions{}).Update(t.Context(), stale, mutate); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if after := retries(t); after != before+1 {
+		t.Fatalf("expected one retry to be counted, got %v", after-before)
+	}
+}
+
+func retries(t *testing.T) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	var total float64
+	for _, family := range families {
+		if family.GetName() != "controller_runtime_client_request_retries_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			total += m.GetCounter().GetValue()
+		}
+	}
+	return total
+}
@@ -196,6 +196,9 @@ func (c *typedClient) List(ctx context.Context, obj ObjectList, opts ...ListOpti
 
 	listOpts := ListOptions{}
 	listOpts.ApplyOptions(opts)
+	if err := listOpts.errCacheOnlyListOptions(); err != nil {
+		return err
+	}
 
 	return r.Get().
 		NamespaceIfScoped(listOpts.Namespace, r.isNamespaced()).
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"reflect"
+)
+
+// TypedSubResourceClient reads and writes the named subresource of objects of type
+// object, whose body is of type subResourceObject. It works for any subresource the
+// API server serves, including the subresources of aggregated APIs and CRDs, e.g.
+//
+//	scale := client.NewTypedSubResourceClient[*appsv1.Deployment, *autoscalingv1.Scale](c, "scale")
+//	s, err := scale.Get(ctx, dep)
+//
+//	evict := client.NewTypedSubResourceClient[*corev1.Pod, *policyv1.Eviction](c, "eviction")
+//	err := evict.Create(ctx, pod, &policyv1.Eviction{})
+//
+// For subresources whose body is the object itself, e.g. "status" or the "approval"
+// of a CertificateSigningRequest, object and subResourceObject are the same type.
+// subResourceObject must be a pointer to a struct.
+type TypedSubResourceClient[object, subResourceObject Object] struct {
+	client SubResourceClient
+}
+
+// NewTypedSubResourceClient returns a TypedSubResourceClient for the named subresource.
+func NewTypedSubResourceClient[object, subResourceObject Object](c SubResourceClientConstructor, subResource string) *TypedSubResourceClient[object, subResourceObject] {
+	return &TypedSubResourceClient[object, subResourceObject]{client: c.SubResource(subResource)}
+}
+
+// Get reads the subresource of obj.
+func (c *TypedSubResourceClient[object, subResourceObject]) Get(ctx context.Context, obj object, opts ...SubResourceGetOption) (subResourceObject, error) {
+	subResource := reflect.New(reflect.TypeOf(*new(subResourceObject)).Elem()).Interface().(subResourceObject)
+	if err := c.client.Get(ctx, obj, subResource, opts...); err != nil {
+		return *new(subResourceObject), err
+	}
+	return subResource, nil
+}
+
+// Create creates the subresource of obj, subResource is updated with the response
+// of the API server.
+func (c *TypedSubResourceClient[object, subResourceObject]) Create(ctx context.Context, obj object, subResource subResourceObject, opts ...SubResourceCreateOption) error {
+	return c.client.Create(ctx, obj, subResource, opts...)
+}
+
+// Update updates the subresource of obj to subResource, which is updated with the
+// response of the API server.
+func (c *TypedSubResourceClient[object, subResourceObject]) Update(ctx context.Context, obj object, subResource subResourceObject, opts ...SubResourceUpdateOption) error {
+	return c.client.Update(ctx, obj, append(opts, WithSubResourceBody(subResource))...)
+}
+
+// Patch patches the subresource of obj. The patch is computed from subResource,
+// which is updated with the response of the API server.
+func (c *TypedSubResourceClient[object, subResourceObject]) Patch(ctx context.Context, obj object, subResource subResourceObject, patch Patch, opts ...SubResourcePatchOption) error {
+	return c.client.Patch(ctx, obj, patch, append(opts, WithSubResourceBody(subResource))...)
+}
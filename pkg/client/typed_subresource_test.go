@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTypedSubResourceClientScale(t *testing.T) {
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+		Spec:       appsv1.DeploymentSpec{Replicas: new(int32(2))},
+	}
+	c := fake.NewClientBuilder().WithObjects(dep).Build()
+	scale := client.NewTypedSubResourceClient[*appsv1.Deployment, *autoscalingv1.Scale](c, "scale")
+
+	s, err := scale.Get(t.Context(), dep)
+	if err != nil {
+		t.Fatalf("failed to get scale: %v", err)
+	}
+	if s.Spec.Replicas != 2 {
+		t.Fatalf("expected 2 replicas, got %d", s.Spec.Replicas)
+	}
+
+	s.Spec.Replicas = 5
+	if err := scale.Update(t.Context(), dep, s); err != nil {
+		t.Fatalf("failed to update scale: %v", err)
+	}
+	updated := &appsv1.Deployment{}
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(dep), updated); err != nil {
+		t.Fatalf("failed to get Deployment: %v", err)
+	}
+	if *updated.Spec.Replicas != 5 {
+		t.Fatalf("expected 5 replicas, got %d", *updated.Spec.Replicas)
+	}
+}
+
+func TestTypedSubResourceClientGetError(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	scale := client.NewTypedSubResourceClient[*appsv1.Deployment, *autoscalingv1.Scale](c, "scale")
+
+	s, err := scale.Get(t.Context(), &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"}})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if s != nil {
+		t.Fatalf("expected no scale, got %v", s)
+	}
+}
+
+func TestTypedSubResourceClientEviction(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"}}
+	c := fake.NewClientBuilder().WithObjects(pod).Build()
+	evict := client.NewTypedSubResourceClient[*corev1.Pod, *policyv1.Eviction](c, "eviction")
+
+	if err := evict.Create(t.Context(), pod, &policyv1.Eviction{}); err != nil {
+		t.Fatalf("failed to evict Pod: %v", err)
+	}
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected Pod to be evicted, got %v", err)
+	}
+}
+
+func TestTypedSubResourceClientStatusPatch(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"}}
+	c := fake.NewClientBuilder().WithObjects(pod).WithStatusSubresource(pod).Build()
+	status := client.NewTypedSubResourceClient[*corev1.Pod, *corev1.Pod](c, "status")
+
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(pod), pod); err != nil {
+		t.Fatalf("failed to get Pod: %v", err)
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	pod.Status.Phase = corev1.PodRunning
+	if err := status.Patch(t.Context(), pod, pod, patch); err != nil {
+		t.Fatalf("failed to patch status: %v", err)
+	}
+	patched := &corev1.Pod{}
+	if err := c.Get(t.Context(), client.ObjectKeyFromObject(pod), patched); err != nil {
+		t.Fatalf("failed to get Pod: %v", err)
+	}
+	if patched.Status.Phase != corev1.PodRunning {
+		t.Fatalf("expected phase Running, got %q", patched.Status.Phase)
+	}
+}
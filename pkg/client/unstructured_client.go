@@ -240,6 +240,9 @@ func (uc *unstructuredClient) List(ctx context.Context, obj ObjectList, opts ...
 
 	listOpts := ListOptions{}
 	listOpts.ApplyOptions(opts)
+	if err := listOpts.errCacheOnlyListOptions(); err != nil {
+		return err
+	}
 
 	return r.Get().
 		NamespaceIfScoped(listOpts.Namespace, r.isNamespaced()).
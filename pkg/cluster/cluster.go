@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/internal/apiwarnings"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	intrec "sigs.k8s.io/controller-runtime/pkg/internal/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/recorder"
@@ -95,6 +96,10 @@ type Options struct {
 	// to create the http client.
 	HTTPClient *http.Client
 
+	// ConfigReload makes the Cluster reload its rest.Config when credentials rotate,
+	// see ConfigReloadOptions. It is ignored if HTTPClient is set.
+	ConfigReload *ConfigReloadOptions
+
 	// Cache is the cache.Options that will be used to create the default Cache.
 	// By default, the cache will watch and list requested objects in all namespaces.
 	Cache cache.Options
@@ -111,6 +116,7 @@ type Options struct {
 
 	// Client is the client.Options that will be used to create the default Client.
 	// By default, the client will use the cache for reads and direct calls for writes.
+	// Its Middlewares, RateLimits and EnableMetrics also apply to the APIReader.
 	Client client.Options
 
 	// NewClient is the func that creates the client to be used by the manager.
@@ -137,6 +143,9 @@ type Options struct {
 	// stopped with the manager.
 	makeBroadcaster intrec.EventBroadcasterProducer
 
+	// configReloader reloads the rest.Config if ConfigReload is set.
+	configReloader *configReloader
+
 	// Dependency injection for testing
 	newRecorderProvider func(config *rest.Config, httpClient *http.Client, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster intrec.EventBroadcasterProducer) (*intrec.Provider, error)
 }
@@ -219,9 +228,12 @@ func New(config *rest.Config, opts ...Option) (Cluster, error) {
 
 	// Create the API Reader, a client with no cache.
 	clientReader, err := client.New(config, client.Options{
-		HTTPClient: options.HTTPClient,
-		Scheme:     options.Scheme,
-		Mapper:     mapper,
+		HTTPClient:    options.HTTPClient,
+		Scheme:        options.Scheme,
+		Mapper:        mapper,
+		Middlewares:   options.Client.Middlewares,
+		RateLimits:    options.Client.RateLimits,
+		EnableMetrics: options.Client.EnableMetrics,
 	})
 	if err != nil {
 		return nil, err
@@ -237,6 +249,7 @@ func New(config *rest.Config, opts ...Option) (Cluster, error) {
 
 	return &cluster{
 		config:           originalConfig,
+		configReloader:   options.configReloader,
 		httpClient:       options.HTTPClient,
 		scheme:           options.Scheme,
 		cache:            cache,
@@ -251,12 +264,24 @@ func New(config *rest.Config, opts ...Option) (Cluster, error) {
 
 // setOptionsDefaults set default values for Options fields.
 func setOptionsDefaults(options Options, config *rest.Config) (Options, error) {
+	if options.Logger.GetSink() == nil {
+		options.Logger = logf.RuntimeLog.WithName("cluster")
+	}
+
 	if options.HTTPClient == nil {
 		var err error
-		options.HTTPClient, err = rest.HTTPClientFor(config)
-		if err != nil {
-			return options, err
+		if options.ConfigReload != nil {
+			options.HTTPClient, options.configReloader, err = newReloadingHTTPClient(config, *options.ConfigReload, options.Logger)
+			if err != nil {
+				return options, fmt.Errorf("failed setting up config reload: %w", err)
+			}
+		} else {
+			options.HTTPClient, err = rest.HTTPClientFor(config)
+			if err != nil {
+				return options, err
+			}
 		}
+		options.HTTPClient = apiwarnings.WrapClient(options.HTTPClient)
 	}
 
 	// Use the Kubernetes client-go scheme if none is specified
@@ -304,9 +329,5 @@ func setOptionsDefaults(options Options, config *rest.Config) (Options, error) {
 		}
 	}
 
-	if options.Logger.GetSink() == nil {
-		options.Logger = logf.RuntimeLog.WithName("cluster")
-	}
-
 	return options, nil
 }
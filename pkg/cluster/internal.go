@@ -36,6 +36,10 @@ type cluster struct {
 	// config is the rest.config used to talk to the apiserver.  Required.
 	config *rest.Config
 
+	// configReloader reloads the config when its files change. It is nil
+	// unless config reload is enabled.
+	configReloader *configReloader
+
 	httpClient *http.Client
 	scheme     *runtime.Scheme
 	cache      cache.Cache
@@ -61,6 +65,11 @@ type cluster struct {
 }
 
 func (c *cluster) GetConfig() *rest.Config {
+	if c.configReloader != nil {
+		if config := c.configReloader.Config(); config != nil {
+			return config
+		}
+	}
 	return c.config
 }
 
@@ -106,5 +115,8 @@ func (c *cluster) GetLogger() logr.Logger {
 
 func (c *cluster) Start(ctx context.Context) error {
 	defer c.recorderProvider.Stop(ctx)
+	if c.configReloader != nil {
+		go c.configReloader.Start(ctx)
+	}
 	return c.cache.Start(ctx)
 }
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+)
+
+const defaultConfigReloadInterval = 10 * time.Second
+
+// ConfigReloadOptions configure reloading the rest.Config of a Cluster when its
+// credentials rotate, e.g. because a client certificate or the CA bundle of the
+// API server changed, instead of requiring a restart of the process.
+//
+// The Files are polled every Interval, so a rotation is picked up with a delay of
+// up to Interval. When one of the Files changes, the transport of the HTTP client
+// shared by the client, cache and API reader is rebuilt from the reloaded rest.Config.
+// New requests are sent with the new transport. Watches that are in flight with the
+// previous transport are cancelled, so that informers establish them again with the
+// new credentials, while other requests in flight are allowed to complete before the
+// connections of the previous transport are closed. Changes of the address of the
+// API server are not picked up.
+//
+// Bearer token files referenced by the rest.Config don't need to be watched, as
+// they are read again periodically by the transport.
+type ConfigReloadOptions struct {
+	// Files are the files whose changes trigger a reload, e.g. the kubeconfig or a
+	// CA bundle. Defaults to the CA, certificate and key files referenced by the
+	// TLSClientConfig of the rest.Config.
+	Files []string
+
+	// Load returns the rest.Config to use after one of the Files changed, e.g. by
+	// loading the kubeconfig again. Defaults to returning the initial rest.Config,
+	// which makes the transport read the files it references again.
+	Load func() (*rest.Config, error)
+
+	// Interval is the interval at which the Files are checked for changes, which
+	// bounds the delay until a rotation is picked up. Each check reads all Files.
+	// Defaults to 10 seconds.
+	Interval time.Duration
+}
+
+// configReloader watches the files of a ConfigReloadOptions and replaces the transport
+// of a reloadingTransport when they change.
+type configReloader struct {
+	files     []string
+	load      func() (*rest.Config, error)
+	interval  time.Duration
+	transport *reloadingTransport
+	logger    logr.Logger
+
+	// lock guards config, which is nil until the config was reloaded, and hashes.
+	lock   sync.RWMutex
+	config *rest.Config
+	hashes map[string][sha256.Size]byte
+}
+
+// newReloadingHTTPClient returns an HTTP client whose transport is rebuilt whenever
+// the files of the options change, along with the reloader that needs to be run.
+func newReloadingHTTPClient(config *rest.Config, options ConfigReloadOptions, logger logr.Logger) (*http.Client, *configReloader, error) {
+	if len(options.Files) == 0 {
+		for _, file := range []string{config.CAFile, config.CertFile, config.KeyFile} {
+			if file != "" {
+				options.Files = append(options.Files, file)
+			}
+		}
+	}
+	if len(options.Files) == 0 {
+		return nil, nil, errors.New("config reload requires Files as the rest.Config doesn't reference any CA, certificate or key file")
+	}
+	if options.Load == nil {
+		options.Load = func() (*rest.Config, error) {
+			return config, nil
+		}
+	}
+	if options.Interval <= 0 {
+		options.Interval = defaultConfigReloadInterval
+	}
+
+	hashes, err := hashFiles(options.Files)
+	if err != nil {
+		return nil, nil, err
+	}
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	reloader := &configReloader{
+		files:     options.Files,
+		load:      options.Load,
+		interval:  options.Interval,
+		transport: newReloadingTransport(transport),
+		logger:    logger.WithName("config-reloader"),
+		hashes:    hashes,
+	}
+	return &http.Client{Transport: reloader.transport, Timeout: config.Timeout}, reloader, nil
+}
+
+// Config returns the rest.Config that was loaded last, or nil if it wasn't reloaded yet.
+func (r *configReloader) Config() *rest.Config {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.config
+}
+
+// Start checks the files for changes until the context is cancelled.
+func (r *configReloader) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reloadIfChanged(); err != nil {
+				r.logger.Error(err, "Failed to reload the rest.Config, retrying", "files", r.files)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the rest.Config and replaces the transport if any of
+// the files changed. The files are only considered reloaded on success, so that
+// a failed reload is retried.
+func (r *configReloader) reloadIfChanged() error {
+	hashes, err := hashFiles(r.files)
+	if err != nil {
+		return err
+	}
+	r.lock.RLock()
+	changed := false
+	for file, hash := range hashes {
+		if r.hashes[file] != hash {
+			changed = true
+		}
+	}
+	r.lock.RUnlock()
+	if !changed {
+		return nil
+	}
+
+	config, err := r.load()
+	if err != nil {
+		return fmt.Errorf("failed to load rest.Config: %w", err)
+	}
+	config = rest.CopyConfig(config)
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	r.lock.Lock()
+	r.config = config
+	r.hashes = hashes
+	r.lock.Unlock()
+	r.transport.replace(transport)
+	r.logger.Info("Reloaded rest.Config after its files changed", "files", r.files)
+	return nil
+}
+
+func hashFiles(files []string) (map[string][sha256.Size]byte, error) {
+	hashes := make(map[string][sha256.Size]byte, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		hashes[file] = sha256.Sum256(bytes.TrimSpace(content))
+	}
+	return hashes, nil
+}
+
+// reloadingTransport sends requests through the transport built from the current
+// rest.Config. When the transport is replaced, the watches in flight with the previous
+// one are cancelled, so that they are established again with the new transport, and
+// its connections are closed once all other requests in flight with it completed.
+type reloadingTransport struct {
+	lock       sync.RWMutex
+	transport  http.RoundTripper
+	generation *transportGeneration
+}
+
+// transportGeneration tracks the requests in flight with a transport.
+type transportGeneration struct {
+	// watches is cancelled when the transport is replaced.
+	watches       context.Context
+	cancelWatches context.CancelFunc
+	// inFlight counts the requests whose response body wasn't closed yet.
+	inFlight sync.WaitGroup
+}
+
+func newTransportGeneration() *transportGeneration {
+	g := &transportGeneration{}
+	g.watches, g.cancelWatches = context.WithCancel(context.Background())
+	return g
+}
+
+func newReloadingTransport(transport http.RoundTripper) *reloadingTransport {
+	return &reloadingTransport{transport: transport, generation: newTransportGeneration()}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *reloadingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.lock.RLock()
+	transport, generation := t.transport, t.generation
+	// Add while holding the lock, so that replace can't wait for the requests
+	// of the generation before this one was added.
+	generation.inFlight.Add(1)
+	t.lock.RUnlock()
+
+	release := generation.inFlight.Done
+	if isWatch(req) {
+		ctx, cancel := context.WithCancel(req.Context())
+		stop := context.AfterFunc(generation.watches, cancel)
+		req = req.WithContext(ctx)
+		release = func() {
+			stop()
+			cancel()
+			generation.inFlight.Done()
+		}
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: sync.OnceFunc(release)}
+	return resp, nil
+}
+
+// replace replaces the transport, cancels the watches in flight with the previous one
+// and closes its connections once the other requests in flight with it completed.
+func (t *reloadingTransport) replace(transport http.RoundTripper) {
+	t.lock.Lock()
+	previous, previousGeneration := t.transport, t.generation
+	t.transport = transport
+	t.generation = newTransportGeneration()
+	t.lock.Unlock()
+
+	previousGeneration.cancelWatches()
+	go func() {
+		previousGeneration.inFlight.Wait()
+		utilnet.CloseIdleConnectionsFor(previous)
+	}()
+}
+
+// isWatch returns true for watch requests, which are long-running and need to be
+// established again with new credentials.
+func isWatch(req *http.Request) bool {
+	watch := req.URL.Query().Get("watch")
+	return watch == "true" || watch == "1"
+}
+
+// releasingBody releases the context of a request once its response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var _ = Describe("config reload", func() {
+	It("should cancel in-flight watches, drain other requests and use the new transport once replaced", func(ctx SpecContext) {
+		watchStarted, getStarted, finishGet := make(chan struct{}), make(chan struct{}), make(chan struct{})
+		previous := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("watch") != "true" {
+				close(getStarted)
+				<-finishGet
+				if err := req.Context().Err(); err != nil {
+					return nil, err
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("previous"))}, nil
+			}
+			close(watchStarted)
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+		transport := newReloadingTransport(previous)
+
+		watchErr := make(chan error, 1)
+		go func() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/api/v1/pods?watch=true", nil)
+			_, err := transport.RoundTrip(req)
+			watchErr <- err
+		}()
+		getErr := make(chan error, 1)
+		go func() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/api/v1/pods", nil)
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				err = resp.Body.Close()
+			}
+			getErr <- err
+		}()
+		<-watchStarted
+		<-getStarted
+
+		transport.replace(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("new"))}, nil
+		}))
+		Eventually(watchErr).Should(Receive(HaveOccurred()))
+		Consistently(getErr).ShouldNot(Receive())
+		close(finishGet)
+		Eventually(getErr).Should(Receive(Succeed()))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/get", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := transport.RoundTrip(req)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(string(body)).To(Equal("new"))
+	})
+
+	It("should reload the config once one of the files changed", func() {
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+		Expect(os.WriteFile(caFile, []byte("initial"), 0o600)).To(Succeed())
+
+		loads := 0
+		_, reloader, err := newReloadingHTTPClient(&rest.Config{Host: "https://example.com"}, ConfigReloadOptions{
+			Files: []string{caFile},
+			Load: func() (*rest.Config, error) {
+				loads++
+				return &rest.Config{Host: "https://example.com"}, nil
+			},
+		}, logr.Discard())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(reloader.reloadIfChanged()).To(Succeed())
+		Expect(loads).To(Equal(0))
+		Expect(reloader.Config()).To(BeNil())
+
+		Expect(os.WriteFile(caFile, []byte("rotated"), 0o600)).To(Succeed())
+		Expect(reloader.reloadIfChanged()).To(Succeed())
+		Expect(loads).To(Equal(1))
+		Expect(reloader.Config()).NotTo(BeNil())
+		Expect(reloader.Config().UserAgent).NotTo(BeEmpty())
+
+		Expect(reloader.reloadIfChanged()).To(Succeed())
+		Expect(loads).To(Equal(1))
+	})
+
+	It("should require files to watch", func() {
+		_, _, err := newReloadingHTTPClient(&rest.Config{Host: "https://example.com"}, ConfigReloadOptions{}, logr.Discard())
+		Expect(err).To(MatchError(ContainSubstring("requires Files")))
+	})
+})
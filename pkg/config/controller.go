@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Controller contains configuration options for controllers. It only includes options
@@ -96,4 +97,61 @@ type Controller struct {
 	// ReconciliationTimeout is used as the timeout passed to the context of each Reconcile call.
 	// By default, there is no timeout.
 	ReconciliationTimeout time.Duration
+
+	// ReconcileErrorLogging configures how errors returned by reconcilers are logged.
+	// Can be overwritten for a controller via the ReconcileErrorLogging setting on the controller.
+	// Defaults to logging all errors at error level.
+	ReconcileErrorLogging *ReconcileErrorLogging
+
+	// ReadyAfterInitialReconcile adds a readiness check for every controller that fails until
+	// all objects that existed when the controller was started have been reconciled once.
+	// The check of controllers that need leader election passes on replicas that are not the
+	// leader.
+	// Can be overwritten for a controller via the ReadyAfterInitialReconcile setting on the controller.
+	// Defaults to false.
+	ReadyAfterInitialReconcile *bool
+
+	// DeleteMetricsOnStop deletes the metrics of every controller and its queue when the
+	// controller stops, which keeps the cardinality of the metrics bounded in processes that
+	// create and stop controllers dynamically.
+	// Can be overwritten for a controller via the DeleteMetricsOnStop setting on the controller.
+	// Defaults to false.
+	DeleteMetricsOnStop *bool
+
+	// HealthEventTarget is the object every controller emits Events about its own health on,
+	// e.g. the Pod or Deployment of the operator, so that cluster admins see it with
+	// kubectl describe. See the HealthEventTarget setting on the controller.
+	// Can be overwritten for a controller via the HealthEventTarget setting on the controller.
+	// Defaults to nil, which disables the Events.
+	HealthEventTarget runtime.Object
+}
+
+// ReconcileErrorLogging configures how the "Reconciler error" log line is written.
+//
+// By default, errors returned by reconcilers are logged at error level, which makes loggers
+// like zap add a stack trace to them. Errors that are logged at a verbosity instead are
+// logged as info messages with the error in the "error" key, without a stack trace.
+type ReconcileErrorLogging struct {
+	// Verbosity is the verbosity at which reconcile errors are logged.
+	// Defaults to logging them at error level.
+	Verbosity *int
+
+	// ExpectedErrorVerbosity is the verbosity at which expected errors are logged, see
+	// IsExpectedError. Defaults to logging them like all other errors.
+	ExpectedErrorVerbosity *int
+
+	// IsExpectedError returns whether an error is expected during normal operation and
+	// is logged at ExpectedErrorVerbosity. Defaults to NotFound and Conflict errors of
+	// the Kubernetes API.
+	IsExpectedError func(err error) bool
+
+	// Stacktrace controls whether reconcile errors are logged with a stack trace.
+	//
+	// If true, errors that are logged at a verbosity get the stack trace of the
+	// reconciling goroutine added in the "stacktrace" key, errors logged at error level
+	// are left to the logger, which usually adds one itself.
+	// If false, errors are never logged at error level: errors without a verbosity are
+	// logged at verbosity 0 instead, so that loggers don't add a stack trace.
+	// Defaults to leaving stack traces to the logger.
+	Stacktrace *bool
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ControllerManagerConfiguration is the content of a YAML file that configures a Manager,
+// e.g. mounted from a ConfigMap. All fields except apiVersion and kind are optional.
+//
+//	apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+//	kind: ControllerManagerConfiguration
+//	controller:
+//	  maxConcurrentReconciles: 5
+//	cache:
+//	  namespaces: ["team-a", "team-b"]
+//	leaderElection:
+//	  leaderElect: true
+//	  resourceName: my-operator
+//	metrics:
+//	  bindAddress: ":8080"
+//	health:
+//	  healthProbeBindAddress: ":8081"
+type ControllerManagerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Controller     ControllerConfigurationSpec     `json:"controller,omitempty"`
+	Cache          CacheConfigurationSpec          `json:"cache,omitempty"`
+	LeaderElection LeaderElectionConfigurationSpec `json:"leaderElection,omitempty"`
+	Metrics        ControllerMetrics               `json:"metrics,omitempty"`
+	Health         ControllerHealth                `json:"health,omitempty"`
+}
+
+// ControllerConfigurationSpec configures the controllers, see config.Controller.
+type ControllerConfigurationSpec struct {
+	MaxConcurrentReconciles int              `json:"maxConcurrentReconciles,omitempty"`
+	GroupKindConcurrency    map[string]int   `json:"groupKindConcurrency,omitempty"`
+	CacheSyncTimeout        *metav1.Duration `json:"cacheSyncTimeout,omitempty"`
+	RecoverPanic            *bool            `json:"recoverPanic,omitempty"`
+}
+
+// CacheConfigurationSpec configures the cache, see cache.Options.
+type CacheConfigurationSpec struct {
+	// Namespaces restricts the cache to the given namespaces, see cache.Options.DefaultNamespaces.
+	Namespaces []string         `json:"namespaces,omitempty"`
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// LeaderElectionConfigurationSpec configures leader election.
+type LeaderElectionConfigurationSpec struct {
+	// LeaderElect enables leader election. Leader election that is enabled in code
+	// can't be disabled by the file, as false can't be told apart from unset there.
+	LeaderElect       *bool            `json:"leaderElect,omitempty"`
+	ResourceLock      string           `json:"resourceLock,omitempty"`
+	ResourceName      string           `json:"resourceName,omitempty"`
+	ResourceNamespace string           `json:"resourceNamespace,omitempty"`
+	LeaseDuration     *metav1.Duration `json:"leaseDuration,omitempty"`
+	RenewDeadline     *metav1.Duration `json:"renewDeadline,omitempty"`
+	RetryPeriod       *metav1.Duration `json:"retryPeriod,omitempty"`
+}
+
+// ControllerMetrics configures the metrics server.
+type ControllerMetrics struct {
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// ControllerHealth configures the health probe server.
+type ControllerHealth struct {
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+	ReadinessEndpointName  string `json:"readinessEndpointName,omitempty"`
+	LivenessEndpointName   string `json:"livenessEndpointName,omitempty"`
+}
@@ -18,16 +18,21 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/internal/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -80,6 +85,21 @@ type TypedOptions[request comparable] struct {
 	// Defaults to true, which means the controller will use leader election.
 	NeedLeaderElection *bool
 
+	// LeaderElectionGroup assigns the controller to a named leader election group. Each group
+	// uses its own lease, derived from the manager's LeaderElectionID, which allows different
+	// controllers in the same binary to be leaders on different replicas.
+	// Defaults to the empty group, which is the manager's own leader election.
+	//
+	// It is ignored if NeedLeaderElection is false, if leader election is not enabled on the
+	// manager or if the manager uses a custom LeaderElectionResourceLockInterface.
+	LeaderElectionGroup string
+
+	// Group assigns the controller to a named controller group, whose controllers can be
+	// stopped and started again together while the manager keeps running, e.g. to pause
+	// a subsystem during maintenance. See manager.ControllerGroupManager.
+	// Defaults to no group.
+	Group string
+
 	// Reconciler reconciles an object
 	Reconciler reconcile.TypedReconciler[request]
 
@@ -114,6 +134,23 @@ type TypedOptions[request comparable] struct {
 	// For more details, see: https://github.com/kubernetes-sigs/controller-runtime/issues/2374.
 	UsePriorityQueue *bool
 
+	// OnShutdownPendingRequests is called when the controller shuts down with all requests
+	// that were scheduled with a delay, e.G. through reconcile.Result.RequeueAfter, and that
+	// had not become ready yet. It allows to persist the next run time of these requests
+	// externally, as they would otherwise be lost across restarts. Requeues of reconciles
+	// that were still in flight when the controller was stopped are passed to it as well,
+	// so it may be called more than once, but calls are serialized.
+	//
+	// It is only used by the priority queue and ignored if a custom NewQueue is set.
+	OnShutdownPendingRequests func(requests []priorityqueue.PendingItem[request])
+
+	// FirePendingRequestsOnShutdown makes the requests that are passed to
+	// OnShutdownPendingRequests ready immediately, i.e. their ReadyAt is the time
+	// of the shutdown instead of the time they were scheduled for.
+	//
+	// It is only used by the priority queue and ignored if a custom NewQueue is set.
+	FirePendingRequestsOnShutdown bool
+
 	// EnableWarmup specifies whether the controller should start its sources when the manager is not
 	// the leader. This is useful for cases where sources take a long time to start, as it allows
 	// for the controller to warm up its caches even before it is elected as the leader. This
@@ -133,6 +170,59 @@ type TypedOptions[request comparable] struct {
 	// ReconciliationTimeout is used as the timeout passed to the context of each Reconcile call.
 	// By default, there is no timeout.
 	ReconciliationTimeout time.Duration
+
+	// ReconcileErrorLogging configures how errors returned by the Reconciler are logged, e.g.
+	// at a verbosity without stack traces, or expected errors like Conflicts at a higher verbosity.
+	// Defaults to the Controller.ReconcileErrorLogging setting from the Manager if unset.
+	// Defaults to logging all errors at error level if Controller.ReconcileErrorLogging
+	// setting from the Manager is also unset.
+	ReconcileErrorLogging *config.ReconcileErrorLogging
+
+	// ReadyAfterInitialReconcile adds a readiness check named "<controller name>-initial-reconcile"
+	// to the Manager that fails until all objects that existed when the sources of the controller
+	// synced have been reconciled at least once, regardless of the result of the reconciliation.
+	// This keeps a replica out of service, e.g. for admission requests, until it has caught up
+	// with the state of the cluster. The check passes for good once it passed, also if the
+	// controller is restarted later on.
+	//
+	// As controllers that need leader election are only started on the leader, the check
+	// passes on other replicas, so that standbys don't block rollouts. It fails once the
+	// replica becomes the leader, until the controller caught up.
+	//
+	// Defaults to the Controller.ReadyAfterInitialReconcile setting from the Manager if unset.
+	// Defaults to false if Controller.ReadyAfterInitialReconcile setting from the Manager is also unset.
+	//
+	// The check can only be added before the Manager is started. Use InitialReconcileChecker to
+	// get the check of a controller that isn't created with New.
+	ReadyAfterInitialReconcile *bool
+
+	// DeleteMetricsOnStop deletes the metrics of the controller and of its queue when the
+	// controller stops, so that controllers that are created and stopped dynamically, e.g.
+	// per cluster or per tenant, don't leave stale label values behind. The metrics are
+	// exported again if the controller is restarted. Remove deletes the metrics of a
+	// controller regardless of this setting.
+	// Defaults to the Controller.DeleteMetricsOnStop setting from the Manager if unset.
+	// Defaults to false if Controller.DeleteMetricsOnStop setting from the Manager is also unset.
+	DeleteMetricsOnStop *bool
+
+	// HealthEventTarget is the object the controller emits Events about its own health on,
+	// so that cluster admins see them with kubectl describe. It is usually the Pod or the
+	// Deployment of the operator, e.g. a *corev1.ObjectReference to the Pod that is built from
+	// the downward API. Warning Events are emitted when:
+	//
+	//  - the sources of the controller fail to sync, e.g. within the CacheSyncTimeout (SourceSyncFailed),
+	//  - a panic of the Reconciler is recovered (ReconcilePanic),
+	//  - a reconciliation exceeds the ReconciliationTimeout (ReconcileTimeout).
+	//
+	// Defaults to the Controller.HealthEventTarget setting from the Manager if unset.
+	// Defaults to nil, which disables the Events, if Controller.HealthEventTarget setting from
+	// the Manager is also unset.
+	HealthEventTarget runtime.Object
+
+	// HealthEventRecorder is used to emit the Events on the HealthEventTarget.
+	// Defaults to the Manager's event recorder for the name of the controller, it must be set
+	// for controllers that are created with NewUnmanaged.
+	HealthEventRecorder events.EventRecorder
 }
 
 // DefaultFromConfig defaults the config from a config.Controller
@@ -172,6 +262,22 @@ func (options *TypedOptions[request]) DefaultFromConfig(config config.Controller
 	if options.ReconciliationTimeout == 0 {
 		options.ReconciliationTimeout = config.ReconciliationTimeout
 	}
+
+	if options.ReconcileErrorLogging == nil {
+		options.ReconcileErrorLogging = config.ReconcileErrorLogging
+	}
+
+	if options.ReadyAfterInitialReconcile == nil {
+		options.ReadyAfterInitialReconcile = config.ReadyAfterInitialReconcile
+	}
+
+	if options.DeleteMetricsOnStop == nil {
+		options.DeleteMetricsOnStop = config.DeleteMetricsOnStop
+	}
+
+	if options.HealthEventTarget == nil {
+		options.HealthEventTarget = config.HealthEventTarget
+	}
 }
 
 // Controller implements an API. A Controller manages a work queue fed reconcile.Requests
@@ -190,6 +296,10 @@ type TypedController[request comparable] interface {
 
 	// Start starts the controller.  Start blocks until the context is closed or a
 	// controller has an error starting.
+	//
+	// Controllers created with New or NewUnmanaged can be started again after Start
+	// returned. They then create a new queue and start all their sources again, so that
+	// they can be paused and resumed without setting up their watches again.
 	Start(ctx context.Context) error
 
 	// GetLogger returns this controller logger prefilled with basic information.
@@ -208,11 +318,26 @@ func New(name string, mgr manager.Manager, options Options) (Controller, error)
 //
 // The name must be unique as it is used to identify the controller in metrics and logs.
 func NewTyped[request comparable](name string, mgr manager.Manager, options TypedOptions[request]) (TypedController[request], error) {
+	// Controllers that don't set their own concurrency follow changes of the
+	// manager's, e.g. when its config file is reloaded.
+	maxConcurrentReconcilesFromConfig := options.MaxConcurrentReconciles <= 0
 	options.DefaultFromConfig(mgr.GetControllerOptions())
+	if options.HealthEventTarget != nil && options.HealthEventRecorder == nil {
+		options.HealthEventRecorder = mgr.GetEventRecorder(name)
+	}
 	c, err := NewTypedUnmanaged(name, options)
 	if err != nil {
 		return nil, err
 	}
+	if ctrl, ok := c.(*controller.Controller[request]); ok {
+		ctrl.MaxConcurrentReconcilesFromConfig = maxConcurrentReconcilesFromConfig
+	}
+
+	if check := InitialReconcileChecker(c); check != nil {
+		if err := AddReadyzCheck(mgr, c, name+"-initial-reconcile", check); err != nil {
+			return nil, err
+		}
+	}
 
 	// Add the controller as a Manager components
 	return c, mgr.Add(c)
@@ -230,12 +355,8 @@ func NewUnmanaged(name string, options Options) (Controller, error) {
 //
 // The name must be unique as it is used to identify the controller in metrics and logs.
 func NewTypedUnmanaged[request comparable](name string, options TypedOptions[request]) (TypedController[request], error) {
-	if options.Reconciler == nil {
-		return nil, fmt.Errorf("must specify Reconciler")
-	}
-
-	if len(name) == 0 {
-		return nil, fmt.Errorf("must specify Name for Controller")
+	if err := ValidateOptions(name, options); err != nil {
+		return nil, err
 	}
 
 	if options.SkipNameValidation == nil || !*options.SkipNameValidation {
@@ -282,6 +403,8 @@ func NewTypedUnmanaged[request comparable](name string, options TypedOptions[req
 				return priorityqueue.New(controllerName, func(o *priorityqueue.Opts[request]) {
 					o.Log = options.Logger.WithValues("controller", controllerName)
 					o.RateLimiter = rateLimiter
+					o.OnShutdownPendingItems = options.OnShutdownPendingRequests
+					o.FirePendingItemsOnShutdown = options.FirePendingRequestsOnShutdown
 				})
 			}
 			return workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[request]{
@@ -301,10 +424,215 @@ func NewTypedUnmanaged[request comparable](name string, options TypedOptions[req
 		LogConstructor:          options.LogConstructor,
 		RecoverPanic:            options.RecoverPanic,
 		LeaderElected:           options.NeedLeaderElection,
+		LeaderElectionGroupName: options.LeaderElectionGroup,
+		ControllerGroupName:     options.Group,
 		EnableWarmup:            options.EnableWarmup,
 		ReconciliationTimeout:   options.ReconciliationTimeout,
+		ReconcileErrorLogging:   options.ReconcileErrorLogging,
+
+		ReadyAfterInitialReconcile: ptr.Deref(options.ReadyAfterInitialReconcile, false),
+		DeleteMetricsOnStop:        ptr.Deref(options.DeleteMetricsOnStop, false),
+		HealthEventTarget:          options.HealthEventTarget,
+		HealthEventRecorder:        options.HealthEventRecorder,
 	}), nil
 }
 
+// ValidateOptions validates the options of a controller with the given name like NewTyped and
+// NewTypedUnmanaged do, without creating the controller or reserving its name, and returns an
+// aggregate of all problems. This allows frameworks that build on controller-runtime to report
+// invalid configuration early. The options are validated as they are, call DefaultFromConfig
+// first to validate them with the defaults of a Manager.
+func ValidateOptions[request comparable](name string, options TypedOptions[request]) error {
+	var errs []error
+	if options.Reconciler == nil {
+		errs = append(errs, errors.New("must specify Reconciler"))
+	}
+
+	if len(name) == 0 {
+		errs = append(errs, errors.New("must specify Name for Controller"))
+	} else if !ptr.Deref(options.SkipNameValidation, false) {
+		if err := validateName(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if options.MaxConcurrentReconciles < 0 {
+		errs = append(errs, fmt.Errorf("MaxConcurrentReconciles must not be negative, got %d", options.MaxConcurrentReconciles))
+	}
+	if options.CacheSyncTimeout < 0 {
+		errs = append(errs, fmt.Errorf("CacheSyncTimeout must not be negative, got %s", options.CacheSyncTimeout))
+	}
+	if options.ReconciliationTimeout < 0 {
+		errs = append(errs, fmt.Errorf("ReconciliationTimeout must not be negative, got %s", options.ReconciliationTimeout))
+	}
+
+	// The shutdown options are only used by the priority queue. They are documented
+	// to be ignored with a custom queue, but disabling the priority queue explicitly
+	// while setting them is a configuration error.
+	if options.NewQueue == nil && !ptr.Deref(options.UsePriorityQueue, true) {
+		if options.OnShutdownPendingRequests != nil {
+			errs = append(errs, errors.New("OnShutdownPendingRequests requires the priority queue"))
+		}
+		if options.FirePendingRequestsOnShutdown {
+			errs = append(errs, errors.New("FirePendingRequestsOnShutdown requires the priority queue"))
+		}
+	}
+	if options.FirePendingRequestsOnShutdown && options.OnShutdownPendingRequests == nil {
+		errs = append(errs, errors.New("FirePendingRequestsOnShutdown requires OnShutdownPendingRequests"))
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// InitialReconcileChecker returns the readiness check of a controller that was created with
+// ReadyAfterInitialReconcile, see TypedOptions.ReadyAfterInitialReconcile. It returns nil for
+// other controllers.
+func InitialReconcileChecker[request comparable](c TypedController[request]) healthz.Checker {
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return nil
+	}
+	return ctrl.InitialReconcileCheck()
+}
+
+// ReadinessChecker returns a readiness check that fails while the controller is starting, i.e.
+// until its sources synced and its workers were started. Controllers that don't need leader
+// election also fail it until they are started. It returns nil for controllers that are not
+// created with New or NewUnmanaged. The builder adds it to the Manager if WithHealthChecks is
+// used.
+func ReadinessChecker[request comparable](c TypedController[request]) healthz.Checker {
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return nil
+	}
+	return ctrl.ReadinessCheck()
+}
+
+// LivenessChecker returns a liveness check that fails if the controller is running but none of
+// its workers is, or if one of its sources failed, e.g. because the informer of a Kind source
+// was removed from the cache. It returns nil for controllers that are not created with New or NewUnmanaged.
+// The builder adds it to the Manager if WithHealthChecks is used.
+func LivenessChecker[request comparable](c TypedController[request]) healthz.Checker {
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return nil
+	}
+	return ctrl.LivenessCheck()
+}
+
+// AddReadyzCheck adds a readiness check of the controller, e.g. its ReadinessChecker, to the
+// Manager. Unlike checks that are added with Manager.AddReadyzCheck, Remove removes it from the
+// Manager together with the controller.
+func AddReadyzCheck[request comparable](mgr manager.Manager, c TypedController[request], name string, check healthz.Checker) error {
+	if err := mgr.AddReadyzCheck(name, check); err != nil {
+		return err
+	}
+	if ctrl, ok := c.(*controller.Controller[request]); ok {
+		ctrl.AddedReadyzCheck(name)
+	}
+	return nil
+}
+
+// AddHealthzCheck adds a liveness check of the controller, e.g. its LivenessChecker, to the
+// Manager. Unlike checks that are added with Manager.AddHealthzCheck, Remove removes it from the
+// Manager together with the controller.
+func AddHealthzCheck[request comparable](mgr manager.Manager, c TypedController[request], name string, check healthz.Checker) error {
+	if err := mgr.AddHealthzCheck(name, check); err != nil {
+		return err
+	}
+	if ctrl, ok := c.(*controller.Controller[request]); ok {
+		ctrl.AddedHealthzCheck(name)
+	}
+	return nil
+}
+
+// Remove stops a controller that was added to the Manager, e.g. by New or the builder, and
+// removes it from the Manager. Together with creating controllers after the Manager was
+// started, this allows to spin controllers up and down at runtime, e.g. a controller per
+// configuration object of a meta-operator.
+//
+// Remove cancels the sources of the controller, which removes their event handlers from the
+// informers, shuts down its queue and waits for in-flight reconciliations to finish or for
+// ctx to be done. It then deletes the metrics of the controller, releases its name and
+// removes the health checks that were added with AddReadyzCheck and AddHealthzCheck, e.g. by
+// New and the builder, if the Manager implements manager.HealthCheckRemover. Otherwise, they
+// pass once the controller was removed.
+//
+// Informers that were started for the sources of the controller are not removed from the
+// cache of the Manager, as other controllers and clients may share them. Once nothing uses
+// them anymore, remove them with Cache.RemoveInformer to stop their watches.
+func Remove[request comparable](ctx context.Context, mgr manager.Manager, c TypedController[request]) error {
+	remover, ok := mgr.(manager.RunnableRemover)
+	if !ok {
+		return fmt.Errorf("manager of type %T doesn't support removing controllers", mgr)
+	}
+	if err := remover.RemoveRunnable(ctx, c); err != nil {
+		return err
+	}
+
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return nil
+	}
+	ctrl.MarkRemoved()
+	ctrl.DeleteMetrics()
+	releaseName(ctrl.Name)
+
+	checkRemover, ok := mgr.(manager.HealthCheckRemover)
+	if !ok {
+		return nil
+	}
+	var errs []error
+	readyzChecks, healthzChecks := ctrl.HealthChecks()
+	for _, name := range readyzChecks {
+		if err := checkRemover.RemoveReadyzCheck(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range healthzChecks {
+		if err := checkRemover.RemoveHealthzCheck(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
 // ReconcileIDFromContext gets the reconcileID from the current context.
 var ReconcileIDFromContext = controller.ReconcileIDFromContext
+
+// ControllerNameFromContext gets the name of the controller that runs the current
+// reconciliation from the context.
+var ControllerNameFromContext = controller.ControllerNameFromContext
+
+// ProvenanceFromContext gets the provenances that added the request of the current
+// reconciliation to the queue since it was reconciled last, e.g. a watch event and a
+// requeue, from the context. It returns nil if the queue of the controller doesn't track
+// the provenance of its items, which the default priority queue does.
+var ProvenanceFromContext = controller.ProvenanceFromContext
+
+// PendingRequests returns the requests that are pending in the queue of a running controller,
+// with the provenances that added them. The Manager serves them at /debug/manager/pending-requests
+// if ServeIntrospection is set. It returns nil for controllers that are not created with New or
+// NewUnmanaged or whose queue doesn't track the provenance of its items.
+func PendingRequests[request comparable](c TypedController[request]) []priorityqueue.PendingItem[request] {
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return nil
+	}
+	return ctrl.PendingRequests()
+}
+
+// EnqueueNow adds the request to the queue of a running controller with the maximum priority
+// and without rate limiting, so that it is reconciled as soon as a worker is free. It is meant
+// for reconciles that are triggered by an administrator, e.g. through an annotation or a debug
+// endpoint. A request that is already queued is not added twice, but moved to the front of the
+// queue. The request is reconciled with the priorityqueue.ProvenanceEnqueueNow provenance, the
+// reason is logged and the controller_runtime_reconcile_enqueue_now_total metric is incremented.
+// It returns an error for controllers that are not running or not created with New or NewUnmanaged.
+func EnqueueNow[request comparable](c TypedController[request], req request, reason string) error {
+	ctrl, ok := c.(*controller.Controller[request])
+	if !ok {
+		return fmt.Errorf("EnqueueNow is not supported by controllers of type %T", c)
+	}
+	return ctrl.EnqueueNow(req, reason)
+}
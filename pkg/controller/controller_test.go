@@ -24,8 +24,10 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/goleak"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -34,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	internalcontroller "sigs.k8s.io/controller-runtime/pkg/internal/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -43,6 +46,52 @@ var _ = Describe("controller.Controller", func() {
 		return reconcile.Result{}, nil
 	})
 
+	Describe("ValidateOptions", func() {
+		It("should accept valid options", func() {
+			Expect(controller.ValidateOptions("validate-valid", controller.Options{Reconciler: rec})).To(Succeed())
+		})
+
+		It("should aggregate all problems", func() {
+			err := controller.ValidateOptions("", controller.Options{
+				MaxConcurrentReconciles: -1,
+				CacheSyncTimeout:        -time.Second,
+				ReconciliationTimeout:   -time.Second,
+				UsePriorityQueue:        new(false),
+				OnShutdownPendingRequests: func([]priorityqueue.PendingItem[reconcile.Request]) {
+				},
+				FirePendingRequestsOnShutdown: true,
+			})
+			Expect(err).To(HaveOccurred())
+			for _, msg := range []string{
+				"must specify Reconciler",
+				"must specify Name for Controller",
+				"MaxConcurrentReconciles must not be negative",
+				"CacheSyncTimeout must not be negative",
+				"ReconciliationTimeout must not be negative",
+				"OnShutdownPendingRequests requires the priority queue",
+				"FirePendingRequestsOnShutdown requires the priority queue",
+			} {
+				Expect(err.Error()).To(ContainSubstring(msg))
+			}
+		})
+
+		It("should require OnShutdownPendingRequests to fire pending requests", func() {
+			err := controller.ValidateOptions("validate-fire", controller.Options{Reconciler: rec, FirePendingRequestsOnShutdown: true})
+			Expect(err).To(MatchError(ContainSubstring("FirePendingRequestsOnShutdown requires OnShutdownPendingRequests")))
+		})
+
+		It("should check the name without reserving it", func() {
+			Expect(controller.ValidateOptions("validate-name", controller.Options{Reconciler: rec})).To(Succeed())
+			c, err := controller.NewUnmanaged("validate-name", controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).NotTo(BeNil())
+
+			err = controller.ValidateOptions("validate-name", controller.Options{Reconciler: rec})
+			Expect(err).To(MatchError(ContainSubstring("controller with name validate-name already exists")))
+			Expect(controller.ValidateOptions("validate-name", controller.Options{Reconciler: rec, SkipNameValidation: new(true)})).To(Succeed())
+		})
+	})
+
 	Describe("New", func() {
 		It("should return an error if Name is not Specified", func() {
 			m, err := manager.New(cfg, manager.Options{})
@@ -426,6 +475,46 @@ var _ = Describe("controller.Controller", func() {
 			Expect(ctrl.NeedLeaderElection()).To(BeFalse())
 		})
 
+		It("should run the controller in its leader election group", func(specCtx SpecContext) {
+			m, err := manager.New(cfg, manager.Options{
+				LeaderElection:          true,
+				LeaderElectionNamespace: "default",
+				LeaderElectionID:        "controller-leader-election-group",
+				HealthProbeBindAddress:  "0",
+				Metrics:                 metricsserver.Options{BindAddress: "0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("new-controller-group", m, controller.Options{
+				LeaderElectionGroup: "shard-a",
+				Reconciler:          rec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.(manager.LeaderElectionGroupRunnable).LeaderElectionGroup()).To(Equal("shard-a"))
+
+			sourceStarted := make(chan struct{})
+			Expect(c.Watch(source.Func(func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+				close(sourceStarted)
+				return nil
+			}))).To(Succeed())
+
+			ctx, cancel := context.WithCancel(specCtx)
+			mgrDone := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+				close(mgrDone)
+			}()
+			Eventually(sourceStarted).Should(BeClosed())
+
+			By("acquiring the lease of the group")
+			_, err = clientset.CoordinationV1().Leases("default").Get(ctx, "controller-leader-election-group-shard-a", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cancel()
+			Eventually(mgrDone).Should(BeClosed())
+		})
+
 		It("should implement manager.LeaderElectionRunnable", func() {
 			m, err := manager.New(cfg, manager.Options{})
 			Expect(err).NotTo(HaveOccurred())
@@ -576,5 +665,114 @@ var _ = Describe("controller.Controller", func() {
 
 			Expect(ctrl.ReconciliationTimeout).To(Equal(time.Minute))
 		})
+
+		It("should default DeleteMetricsOnStop from the manager", func() {
+			m, err := manager.New(cfg, manager.Options{
+				Controller: config.Controller{DeleteMetricsOnStop: ptr.To(true)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("mgr-delete-metrics-on-stop", m, controller.Options{
+				Reconciler: rec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctrl, ok := c.(*internalcontroller.Controller[reconcile.Request])
+			Expect(ok).To(BeTrue())
+
+			Expect(ctrl.DeleteMetricsOnStop).To(BeTrue())
+		})
+
+		It("should default the HealthEventTarget from the manager and the recorder to the manager's", func() {
+			target := &corev1.ObjectReference{Kind: "Pod", Namespace: "operator", Name: "operator-0"}
+			m, err := manager.New(cfg, manager.Options{
+				Controller: config.Controller{HealthEventTarget: target},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("mgr-health-event-target", m, controller.Options{
+				Reconciler: rec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctrl, ok := c.(*internalcontroller.Controller[reconcile.Request])
+			Expect(ok).To(BeTrue())
+
+			Expect(ctrl.HealthEventTarget).To(BeIdenticalTo(target))
+			Expect(ctrl.HealthEventRecorder).NotTo(BeNil())
+		})
+	})
+
+	Describe("Remove", func() {
+		It("should stop the controller and release its name", func(specCtx SpecContext) {
+			m, err := manager.New(cfg, manager.Options{
+				HealthProbeBindAddress: "0",
+				Metrics:                metricsserver.Options{BindAddress: "0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+			Eventually(m.Elected()).Should(BeClosed())
+
+			c, err := controller.New("removed-controller", m, controller.Options{
+				Reconciler: rec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			sourceStopped := make(chan struct{})
+			Expect(c.Watch(source.Func(func(ctx context.Context, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+				go func() {
+					<-ctx.Done()
+					close(sourceStopped)
+				}()
+				return nil
+			}))).To(Succeed())
+			check := controller.ReadinessChecker(c)
+			Eventually(func() error { return check(nil) }).Should(Succeed())
+
+			Expect(controller.Remove(specCtx, m, c)).To(Succeed())
+			Eventually(sourceStopped).Should(BeClosed())
+			Expect(check(nil)).To(Succeed())
+
+			_, err = controller.New("removed-controller", m, controller.Options{
+				Reconciler: rec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should remove the health checks of the controller from the manager", func(specCtx SpecContext) {
+			m, err := manager.New(cfg, manager.Options{
+				HealthProbeBindAddress: "0",
+				Metrics:                metricsserver.Options{BindAddress: "0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("removed-checks-controller", m, controller.Options{
+				Reconciler:                 rec,
+				ReadyAfterInitialReconcile: ptr.To(true),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controller.AddHealthzCheck(m, c, "removed-checks-controller", controller.LivenessChecker(c))).To(Succeed())
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+			Eventually(m.Elected()).Should(BeClosed())
+
+			Expect(controller.Remove(specCtx, m, c)).To(Succeed())
+
+			remover, ok := m.(manager.HealthCheckRemover)
+			Expect(ok).To(BeTrue())
+			Expect(remover.RemoveReadyzCheck("removed-checks-controller-initial-reconcile")).NotTo(Succeed())
+			Expect(remover.RemoveHealthzCheck("removed-checks-controller")).NotTo(Succeed())
+		})
 	})
 })
@@ -18,6 +18,8 @@ package controllertest
 
 import (
 	"context"
+	"slices"
+	"sync"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,7 +59,11 @@ type FakeInformer struct {
 	// RunCount is incremented each time RunInformersAndControllers is called
 	RunCount int
 
-	handlers []cache.ResourceEventHandler
+	mu       sync.Mutex
+	handlers []*fakeHandlerRegistration
+	// stopped is closed by Stop. It is guarded by mu and created on first use, so
+	// that FakeInformers that were not created with NewFakeInformer can be stopped.
+	stopped chan struct{}
 }
 
 func NewFakeInformer(opts ...InformerOption) *FakeInformer {
@@ -78,6 +84,7 @@ func NewFakeInformer(opts ...InformerOption) *FakeInformer {
 // fakeHandlerRegistration implements cache.ResourceEventHandlerRegistration for testing.
 type fakeHandlerRegistration struct {
 	informer *FakeInformer
+	handler  cache.ResourceEventHandler
 }
 
 // HasSynced implements cache.ResourceEventHandlerRegistration.
@@ -141,22 +148,47 @@ func (f *FakeInformer) Done() <-chan struct{} {
 	return f.synced
 }
 
-// AddEventHandler implements the Informer interface. Adds an EventHandler to the fake Informers. TODO(community): Implement Registration.
+// AddEventHandler implements the Informer interface. Adds an EventHandler to the fake Informers.
 func (f *FakeInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
-	f.handlers = append(f.handlers, handler)
-	return &fakeHandlerRegistration{informer: f}, nil
+	return f.addHandler(handler), nil
 }
 
-// AddEventHandlerWithResyncPeriod implements the Informer interface. Adds an EventHandler to the fake Informers (ignores resyncPeriod). TODO(community): Implement Registration.
+// AddEventHandlerWithResyncPeriod implements the Informer interface. Adds an EventHandler to the fake Informers (ignores resyncPeriod).
 func (f *FakeInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, _ time.Duration) (cache.ResourceEventHandlerRegistration, error) {
-	f.handlers = append(f.handlers, handler)
-	return &fakeHandlerRegistration{informer: f}, nil
+	return f.addHandler(handler), nil
 }
 
-// AddEventHandlerWithOptions implements the Informer interface. Adds an EventHandler to the fake Informers (ignores options). TODO(community): Implement Registration.
+// AddEventHandlerWithOptions implements the Informer interface. Adds an EventHandler to the fake Informers (ignores options).
 func (f *FakeInformer) AddEventHandlerWithOptions(handler cache.ResourceEventHandler, _ cache.HandlerOptions) (cache.ResourceEventHandlerRegistration, error) {
-	f.handlers = append(f.handlers, handler)
-	return &fakeHandlerRegistration{informer: f}, nil
+	return f.addHandler(handler), nil
+}
+
+func (f *FakeInformer) addHandler(handler cache.ResourceEventHandler) *fakeHandlerRegistration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	registration := &fakeHandlerRegistration{informer: f, handler: handler}
+	f.handlers = append(f.handlers, registration)
+	return registration
+}
+
+// HandlerCount returns the number of event handlers that are currently registered.
+func (f *FakeInformer) HandlerCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.handlers)
+}
+
+func (f *FakeInformer) registeredHandlers() []cache.ResourceEventHandler {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	handlers := make([]cache.ResourceEventHandler, 0, len(f.handlers))
+	for _, registration := range f.handlers {
+		handlers = append(handlers, registration.handler)
+	}
+	return handlers
 }
 
 // Run implements the Informer interface.  Increments f.RunCount.
@@ -170,27 +202,33 @@ func (f *FakeInformer) RunWithContext(_ context.Context) {
 
 // Add fakes an Add event for obj.
 func (f *FakeInformer) Add(obj metav1.Object) {
-	for _, h := range f.handlers {
+	for _, h := range f.registeredHandlers() {
 		h.OnAdd(obj, false)
 	}
 }
 
 // Update fakes an Update event for obj.
 func (f *FakeInformer) Update(oldObj, newObj metav1.Object) {
-	for _, h := range f.handlers {
+	for _, h := range f.registeredHandlers() {
 		h.OnUpdate(oldObj, newObj)
 	}
 }
 
 // Delete fakes an Delete event for obj.
 func (f *FakeInformer) Delete(obj metav1.Object) {
-	for _, h := range f.handlers {
+	for _, h := range f.registeredHandlers() {
 		h.OnDelete(obj)
 	}
 }
 
-// RemoveEventHandler does nothing.  TODO(community): Implement this.
+// RemoveEventHandler implements the Informer interface. Removes an EventHandler added to the fake Informer.
 func (f *FakeInformer) RemoveEventHandler(handle cache.ResourceEventHandlerRegistration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.handlers = slices.DeleteFunc(f.handlers, func(registration *fakeHandlerRegistration) bool {
+		return registration == handle
+	})
 	return nil
 }
 
@@ -224,7 +262,39 @@ func (f *FakeInformer) SetTransform(t cache.TransformFunc) error {
 	return nil
 }
 
-// IsStopped does nothing.  TODO(community): Implement this.
+// Stop marks the FakeInformer as stopped, e.g. because it was removed from the cache.
+func (f *FakeInformer) Stop() {
+	stopped := f.stoppedChan()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	select {
+	case <-stopped:
+	default:
+		close(stopped)
+	}
+}
+
+// Stopped implements cache.StoppedNotifier. The returned channel is closed once Stop
+// was called.
+func (f *FakeInformer) Stopped() <-chan struct{} {
+	return f.stoppedChan()
+}
+
+// IsStopped implements the Informer interface. Returns true once Stop was called.
 func (f *FakeInformer) IsStopped() bool {
-	return false
+	select {
+	case <-f.stoppedChan():
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *FakeInformer) stoppedChan() chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stopped == nil {
+		f.stopped = make(chan struct{})
+	}
+	return f.stopped
 }
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BackoffAnnotation is the default annotation in which PersistentBackoff stores
+// the backoff state of an object.
+const BackoffAnnotation = "controller-runtime.sigs.k8s.io/backoff"
+
+const (
+	defaultBackoffBaseDelay = 5 * time.Second
+	defaultBackoffMaxDelay  = 30 * time.Minute
+)
+
+// BackoffState is the backoff state of an object that is persisted with the object.
+type BackoffState struct {
+	// Failures is the number of consecutive failures.
+	Failures int `json:"failures"`
+
+	// NextRetry is the earliest time at which the object is retried.
+	NextRetry metav1.Time `json:"nextRetry"`
+}
+
+// BackoffStore reads and writes the backoff state of an object, e.g. from an
+// annotation or a field of its status. Writes only change the object in memory,
+// persisting them is up to the caller.
+type BackoffStore interface {
+	// Get returns the backoff state of the object, or nil if it has none.
+	Get(obj client.Object) (*BackoffState, error)
+
+	// Set sets the backoff state of the object. A nil state removes it.
+	Set(obj client.Object, state *BackoffState) error
+}
+
+// AnnotationBackoffStore returns a BackoffStore that stores the backoff state of
+// objects as JSON in the given annotation.
+func AnnotationBackoffStore(annotation string) BackoffStore {
+	return annotationBackoffStore(annotation)
+}
+
+type annotationBackoffStore string
+
+func (s annotationBackoffStore) Get(obj client.Object) (*BackoffState, error) {
+	value, ok := obj.GetAnnotations()[string(s)]
+	if !ok {
+		return nil, nil
+	}
+	state := &BackoffState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		return nil, fmt.Errorf("failed to decode annotation %s: %w", string(s), err)
+	}
+	return state, nil
+}
+
+func (s annotationBackoffStore) Set(obj client.Object, state *BackoffState) error {
+	annotations := obj.GetAnnotations()
+	if state == nil {
+		if _, ok := annotations[string(s)]; ok {
+			delete(annotations, string(s))
+			obj.SetAnnotations(annotations)
+		}
+		return nil
+	}
+	value, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation %s: %w", string(s), err)
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[string(s)] = string(value)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// PersistentBackoff is an exponential per-object backoff whose state is stored
+// with the object, so that retries of operations with external side effects,
+// like provisioning cloud resources, keep backing off across restarts of the
+// controller instead of starting over with the short delays of the workqueue.
+//
+// A Reconciler checks Pending first and requeues the object after the remaining
+// delay, which also reschedules objects that were backing off when the controller
+// restarted. It records failures with Failure and successes with Reset, and
+// persists the object afterwards, e.g.
+//
+//	if delay, err := backoff.Pending(obj); err != nil || delay > 0 {
+//		return reconcile.Result{RequeueAfter: delay}, err
+//	}
+//	if err := provision(ctx, obj); err != nil {
+//		delay, _ := backoff.Failure(obj)
+//		return reconcile.Result{RequeueAfter: delay}, c.Update(ctx, obj)
+//	}
+//	_ = backoff.Reset(obj)
+//	return reconcile.Result{}, c.Update(ctx, obj)
+type PersistentBackoff struct {
+	// BaseDelay is the delay after the first failure. It doubles with every
+	// further failure. Defaults to 5 seconds.
+	BaseDelay time.Duration
+
+	// MaxDelay is the maximum delay. Defaults to 30 minutes.
+	MaxDelay time.Duration
+
+	// Store stores the backoff state. Defaults to the BackoffAnnotation.
+	Store BackoffStore
+
+	// Clock is used to determine the retry times. Defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+// Pending returns how long the object still has to wait until it is retried, or
+// zero if it can be retried now.
+func (b *PersistentBackoff) Pending(obj client.Object) (time.Duration, error) {
+	state, err := b.store().Get(obj)
+	if err != nil || state == nil {
+		return 0, err
+	}
+	return max(state.NextRetry.Sub(b.clock().Now()), 0), nil
+}
+
+// Failure records a failure on the object and returns the delay until it is
+// retried.
+func (b *PersistentBackoff) Failure(obj client.Object) (time.Duration, error) {
+	store := b.store()
+	state, err := store.Get(obj)
+	if err != nil {
+		return 0, err
+	}
+	if state == nil {
+		state = &BackoffState{}
+	}
+	state.Failures++
+	now := b.clock().Now()
+	// metav1.Time is serialized with a precision of seconds, round up so that
+	// the object isn't retried early after a restart.
+	next := now.Add(b.delay(state.Failures))
+	if truncated := next.Truncate(time.Second); !truncated.Equal(next) {
+		next = truncated.Add(time.Second)
+	}
+	state.NextRetry = metav1.NewTime(next)
+	return next.Sub(now), store.Set(obj, state)
+}
+
+// Reset removes the backoff state from the object, e.g. after a success.
+func (b *PersistentBackoff) Reset(obj client.Object) error {
+	return b.store().Set(obj, nil)
+}
+
+// State returns the backoff state of the object, or nil if it has none.
+func (b *PersistentBackoff) State(obj client.Object) (*BackoffState, error) {
+	return b.store().Get(obj)
+}
+
+func (b *PersistentBackoff) delay(failures int) time.Duration {
+	base, maxDelay := b.BaseDelay, b.MaxDelay
+	if base <= 0 {
+		base = defaultBackoffBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultBackoffMaxDelay
+	}
+	delay := base
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+func (b *PersistentBackoff) store() BackoffStore {
+	if b.Store == nil {
+		return AnnotationBackoffStore(BackoffAnnotation)
+	}
+	return b.Store
+}
+
+func (b *PersistentBackoff) clock() clock.PassiveClock {
+	if b.Clock == nil {
+		return clock.RealClock{}
+	}
+	return b.Clock
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("PersistentBackoff", func() {
+	var (
+		clock   *testingclock.FakeClock
+		backoff *controllerutil.PersistentBackoff
+		pod     *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		clock = testingclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		backoff = &controllerutil.PersistentBackoff{
+			BaseDelay: 10 * time.Second,
+			MaxDelay:  time.Minute,
+			Clock:     clock,
+		}
+		pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+	})
+
+	It("should not be pending without state", func() {
+		Expect(backoff.Pending(pod)).To(BeZero())
+		Expect(backoff.State(pod)).To(BeNil())
+	})
+
+	It("should back off exponentially up to the maximum delay", func() {
+		for _, expected := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+			Expect(backoff.Failure(pod)).To(Equal(expected))
+		}
+		state, err := backoff.State(pod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.Failures).To(Equal(5))
+		Expect(state.NextRetry.Time).To(BeTemporally("==", clock.Now().Add(time.Minute)))
+	})
+
+	It("should restore the pending delay from the persisted state", func() {
+		Expect(backoff.Failure(pod)).To(Equal(10 * time.Second))
+		Expect(pod.Annotations).To(HaveKey(controllerutil.BackoffAnnotation))
+
+		// A new backoff, e.g. after a restart, continues where the old one stopped.
+		restarted := &controllerutil.PersistentBackoff{BaseDelay: 10 * time.Second, MaxDelay: time.Minute, Clock: clock}
+		clock.Step(4 * time.Second)
+		Expect(restarted.Pending(pod)).To(Equal(6 * time.Second))
+		clock.Step(6 * time.Second)
+		Expect(restarted.Pending(pod)).To(BeZero())
+		Expect(restarted.Failure(pod)).To(Equal(20 * time.Second))
+	})
+
+	It("should round the retry time up to full seconds", func() {
+		clock.Step(500 * time.Millisecond)
+		Expect(backoff.Failure(pod)).To(Equal(10*time.Second + 500*time.Millisecond))
+	})
+
+	It("should remove the state on reset", func() {
+		pod.Annotations = map[string]string{"other": "value"}
+		Expect(backoff.Failure(pod)).To(Equal(10 * time.Second))
+		Expect(backoff.Reset(pod)).To(Succeed())
+		Expect(pod.Annotations).To(Equal(map[string]string{"other": "value"}))
+		Expect(backoff.Failure(pod)).To(Equal(10 * time.Second))
+	})
+
+	It("should use a custom store", func() {
+		backoff.Store = controllerutil.AnnotationBackoffStore("example.com/backoff")
+		Expect(backoff.Failure(pod)).To(Equal(10 * time.Second))
+		Expect(pod.Annotations).To(HaveKey("example.com/backoff"))
+		Expect(pod.Annotations).NotTo(HaveKey(controllerutil.BackoffAnnotation))
+	})
+
+	It("should fail on a malformed state", func() {
+		pod.Annotations = map[string]string{controllerutil.BackoffAnnotation: "{"}
+		_, err := backoff.Pending(pod)
+		Expect(err).To(HaveOccurred())
+		_, err = backoff.Failure(pod)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	metav1ac "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -68,19 +70,100 @@ func WithBlockOwnerDeletion(blockOwnerDeletion bool) OwnerReferenceOption {
 // Since only one OwnerReference can be a controller, it returns an error if
 // there is another OwnerReference with Controller flag set.
 func SetControllerReference(owner, controlled metav1.Object, scheme *runtime.Scheme, opts ...OwnerReferenceOption) error {
+	ref, err := newControllerRef(owner, controlled, scheme, "SetControllerReference", opts...)
+	if err != nil {
+		return err
+	}
+
+	// Return early with an error if the object is already controlled.
+	if existing := metav1.GetControllerOf(controlled); existing != nil && !referSameObject(*existing, ref) {
+		return newAlreadyOwnedError(controlled, *existing)
+	}
+
+	// Update owner references and return.
+	upsertOwnerRef(ref, controlled)
+	return nil
+}
+
+// TransferControllerReference hands control over controlled from previousOwner to newOwner,
+// e.g. when migrating objects between operators. The existing controller reference is
+// verified to point to previousOwner, including its UID, and is demoted to a plain owner
+// reference before a controller reference to newOwner is added. The object is thus still
+// garbage collected together with previousOwner; use RemoveOwnerReference to drop the
+// previous owner once the migration is complete.
+// It is a no-op returning nil if newOwner already controls the object, and behaves like
+// SetControllerReference if the object has no controller. An AlreadyOwnedError is returned
+// if the object is controlled by any other object, including a recreated previousOwner
+// with a different UID.
+func TransferControllerReference(previousOwner, newOwner, controlled metav1.Object, scheme *runtime.Scheme, opts ...OwnerReferenceOption) error {
+	ref, err := newControllerRef(newOwner, controlled, scheme, "TransferControllerReference", opts...)
+	if err != nil {
+		return err
+	}
+	ro, ok := previousOwner.(runtime.Object)
+	if !ok {
+		return fmt.Errorf("%T is not a runtime.Object, cannot call TransferControllerReference", previousOwner)
+	}
+	gvk, err := apiutil.GVKForObject(ro, scheme)
+	if err != nil {
+		return err
+	}
+	previousRef := metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       previousOwner.GetName(),
+		UID:        previousOwner.GetUID(),
+	}
+
+	existing := metav1.GetControllerOf(controlled)
+	switch {
+	case existing == nil:
+	case referSameObject(*existing, ref) && existing.UID == ref.UID:
+	case referSameObject(*existing, previousRef) && existing.UID == previousRef.UID:
+		demoted := *existing
+		demoted.Controller = nil
+		upsertOwnerRef(demoted, controlled)
+	default:
+		return newAlreadyOwnedError(controlled, *existing)
+	}
+
+	upsertOwnerRef(ref, controlled)
+	return nil
+}
+
+// ControllerReferenceApplyConfiguration returns a controller reference to owner for use with
+// server-side apply, e.g. through the WithOwnerReferences method of an apply configuration.
+// Owner references are merged by UID on apply, so applying the returned reference is
+// idempotent and leaves owner references managed by other field managers untouched.
+func ControllerReferenceApplyConfiguration(owner, controlled metav1.Object, scheme *runtime.Scheme, opts ...OwnerReferenceOption) (*metav1ac.OwnerReferenceApplyConfiguration, error) {
+	ref, err := newControllerRef(owner, controlled, scheme, "ControllerReferenceApplyConfiguration", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return metav1ac.OwnerReference().
+		WithAPIVersion(ref.APIVersion).
+		WithKind(ref.Kind).
+		WithName(ref.Name).
+		WithUID(ref.UID).
+		WithBlockOwnerDeletion(ptr.Deref(ref.BlockOwnerDeletion, false)).
+		WithController(true), nil
+}
+
+// newControllerRef validates owner and returns a controller reference to it.
+func newControllerRef(owner, controlled metav1.Object, scheme *runtime.Scheme, caller string, opts ...OwnerReferenceOption) (metav1.OwnerReference, error) {
 	// Validate the owner.
 	ro, ok := owner.(runtime.Object)
 	if !ok {
-		return fmt.Errorf("%T is not a runtime.Object, cannot call SetControllerReference", owner)
+		return metav1.OwnerReference{}, fmt.Errorf("%T is not a runtime.Object, cannot call %s", owner, caller)
 	}
 	if err := validateOwner(owner, controlled); err != nil {
-		return err
+		return metav1.OwnerReference{}, err
 	}
 
 	// Create a new controller ref.
 	gvk, err := apiutil.GVKForObject(ro, scheme)
 	if err != nil {
-		return err
+		return metav1.OwnerReference{}, err
 	}
 	ref := metav1.OwnerReference{
 		APIVersion:         gvk.GroupVersion().String(),
@@ -93,15 +176,7 @@ func SetControllerReference(owner, controlled metav1.Object, scheme *runtime.Sch
 	for _, opt := range opts {
 		opt(&ref)
 	}
-
-	// Return early with an error if the object is already controlled.
-	if existing := metav1.GetControllerOf(controlled); existing != nil && !referSameObject(*existing, ref) {
-		return newAlreadyOwnedError(controlled, *existing)
-	}
-
-	// Update owner references and return.
-	upsertOwnerRef(ref, controlled)
-	return nil
+	return ref, nil
 }
 
 // SetOwnerReference is a helper method to make sure the given object contains an object reference to the object provided.
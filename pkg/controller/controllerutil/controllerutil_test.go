@@ -447,6 +447,91 @@ var _ = Describe("Controllerutil", func() {
 		})
 	})
 
+	Describe("TransferControllerReference", func() {
+		var (
+			t        = true
+			f        = false
+			previous *appsv1.Deployment
+			next     *appsv1.StatefulSet
+		)
+
+		BeforeEach(func() {
+			previous = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default", UID: "old-uid"}}
+			next = &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default", UID: "new-uid"}}
+		})
+
+		It("should demote the controller reference of the previous owner", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+			Expect(controllerutil.SetControllerReference(previous, cm, scheme.Scheme)).To(Succeed())
+			Expect(controllerutil.SetOwnerReference(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", UID: "pod-uid"}}, cm, scheme.Scheme)).To(Succeed())
+
+			Expect(controllerutil.TransferControllerReference(previous, next, cm, scheme.Scheme)).To(Succeed())
+			Expect(cm.OwnerReferences).To(ConsistOf(
+				metav1.OwnerReference{Name: "old", Kind: "Deployment", APIVersion: "apps/v1", UID: "old-uid", BlockOwnerDeletion: &t},
+				metav1.OwnerReference{Name: "pod", Kind: "Pod", APIVersion: "v1", UID: "pod-uid"},
+				metav1.OwnerReference{Name: "new", Kind: "StatefulSet", APIVersion: "apps/v1", UID: "new-uid", Controller: &t, BlockOwnerDeletion: &t},
+			))
+
+			By("being idempotent once the new owner controls the object")
+			Expect(controllerutil.TransferControllerReference(previous, next, cm, scheme.Scheme, controllerutil.WithBlockOwnerDeletion(false))).To(Succeed())
+			Expect(cm.OwnerReferences).To(ContainElement(
+				metav1.OwnerReference{Name: "new", Kind: "StatefulSet", APIVersion: "apps/v1", UID: "new-uid", Controller: &t, BlockOwnerDeletion: &f},
+			))
+			Expect(cm.OwnerReferences).To(HaveLen(3))
+		})
+
+		It("should set the controller reference if the object has no controller", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+			Expect(controllerutil.TransferControllerReference(previous, next, cm, scheme.Scheme)).To(Succeed())
+			Expect(metav1.GetControllerOf(cm).UID).To(Equal(types.UID("new-uid")))
+		})
+
+		It("should refuse to take over an object controlled by another object", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+			other := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "other-uid"}}
+			Expect(controllerutil.SetControllerReference(other, cm, scheme.Scheme)).To(Succeed())
+
+			err := controllerutil.TransferControllerReference(previous, next, cm, scheme.Scheme)
+			Expect(err).To(BeAssignableToTypeOf(&controllerutil.AlreadyOwnedError{}))
+			Expect(metav1.GetControllerOf(cm).UID).To(Equal(types.UID("other-uid")))
+		})
+
+		It("should refuse to take over an object controlled by a previous owner with another UID", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+			Expect(controllerutil.SetControllerReference(previous, cm, scheme.Scheme)).To(Succeed())
+
+			recreated := previous.DeepCopy()
+			recreated.UID = "recreated-uid"
+			err := controllerutil.TransferControllerReference(recreated, next, cm, scheme.Scheme)
+			Expect(err).To(BeAssignableToTypeOf(&controllerutil.AlreadyOwnedError{}))
+			Expect(metav1.GetControllerOf(cm).UID).To(Equal(types.UID("old-uid")))
+		})
+	})
+
+	Describe("ControllerReferenceApplyConfiguration", func() {
+		It("should return a controller reference apply configuration", func() {
+			dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", UID: "foo-uid"}}
+			rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+			ref, err := controllerutil.ControllerReferenceApplyConfiguration(dep, rs, scheme.Scheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*ref.APIVersion).To(Equal("apps/v1"))
+			Expect(*ref.Kind).To(Equal("Deployment"))
+			Expect(*ref.Name).To(Equal("foo"))
+			Expect(*ref.UID).To(Equal(types.UID("foo-uid")))
+			Expect(*ref.Controller).To(BeTrue())
+			Expect(*ref.BlockOwnerDeletion).To(BeTrue())
+		})
+
+		It("should return an error if it's setting a cross-namespace owner reference", func() {
+			dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "namespace1", UID: "foo-uid"}}
+			rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "namespace2"}}
+
+			_, err := controllerutil.ControllerReferenceApplyConfiguration(dep, rs, scheme.Scheme)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("CreateOrUpdate", func() {
 		var deploy *appsv1.Deployment
 		var deplSpec appsv1.DeploymentSpec
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OperationsAnnotation is the default annotation in which an OperationLedger stores
+// the idempotency keys of the operations that were performed for an object.
+const OperationsAnnotation = "controller-runtime.sigs.k8s.io/performed-operations"
+
+const defaultOperationLedgerMaxEntries = 32
+
+// IdempotencyKey returns a key for a step of the reconciliation of obj, e.g. to pass as
+// idempotency key to an external API. It is derived from the UID and generation of obj
+// and the step, so it is the same for retries of a reconciliation, but differs for other
+// objects and for every change of the spec of obj.
+func IdempotencyKey(obj client.Object, step string) string {
+	sum := sha256.Sum256([]byte(string(obj.GetUID()) + "/" + strconv.FormatInt(obj.GetGeneration(), 10) + "/" + step))
+	return hex.EncodeToString(sum[:16])
+}
+
+// OperationStore reads and writes the idempotency keys of the operations that were
+// performed for an object.
+type OperationStore interface {
+	// Get returns the keys of the operations that were performed for obj.
+	Get(ctx context.Context, obj client.Object) ([]string, error)
+
+	// Set sets the keys of the operations that were performed for obj.
+	Set(ctx context.Context, obj client.Object, keys []string) error
+}
+
+// AnnotationOperationStore returns an OperationStore that stores the keys as JSON in the
+// given annotation of the object. Writes only change the object in memory, persisting
+// them is up to the caller.
+func AnnotationOperationStore(annotation string) OperationStore {
+	return annotationOperationStore(annotation)
+}
+
+type annotationOperationStore string
+
+func (s annotationOperationStore) Get(_ context.Context, obj client.Object) ([]string, error) {
+	value, ok := obj.GetAnnotations()[string(s)]
+	if !ok {
+		return nil, nil
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(value), &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode annotation %s: %w", string(s), err)
+	}
+	return keys, nil
+}
+
+func (s annotationOperationStore) Set(_ context.Context, obj client.Object, keys []string) error {
+	value, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to encode annotation %s: %w", string(s), err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[string(s)] = string(value)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// ConfigMapOperationStore returns an OperationStore that stores the keys as JSON in the
+// ConfigMap with the given key, under the UID of the object. Unlike the annotation store,
+// it writes the keys to the API server right away, which allows to record operations
+// without updating the object, e.g. for objects that are owned by another controller.
+// The ConfigMap is created if it doesn't exist. As a ConfigMap is limited to 1 MiB, it
+// should be used for a bounded number of objects.
+func ConfigMapOperationStore(c client.Client, key client.ObjectKey) OperationStore {
+	return &configMapOperationStore{client: c, key: key}
+}
+
+type configMapOperationStore struct {
+	client client.Client
+	key    client.ObjectKey
+}
+
+func (s *configMapOperationStore) Get(ctx context.Context, obj client.Object) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.client.Get(ctx, s.key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	value, ok := cm.Data[string(obj.GetUID())]
+	if !ok {
+		return nil, nil
+	}
+	var keys []string
+	if err := json.Unmarshal([]byte(value), &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode the operations of %s in ConfigMap %s: %w", obj.GetUID(), s.key, err)
+	}
+	return keys, nil
+}
+
+func (s *configMapOperationStore) Set(ctx context.Context, obj client.Object, keys []string) error {
+	value, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to encode the operations of %s: %w", obj.GetUID(), err)
+	}
+	cm := &corev1.ConfigMap{}
+	cm.Namespace, cm.Name = s.key.Namespace, s.key.Name
+	_, err = CreateOrUpdate(ctx, s.client, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[string(obj.GetUID())] = string(value)
+		return nil
+	})
+	return err
+}
+
+// OperationLedger records the operations with external side effects that were performed
+// for an object, so that retried reconciliations don't repeat operations that are not
+// idempotent, like sending notifications or creating cloud resources.
+//
+// Operations are identified by their IdempotencyKey, so they are performed once per
+// generation of the object. A Reconciler runs them with Do and, for the default
+// annotation store, persists the object afterwards, e.g.
+//
+//	err := ledger.Do(ctx, obj, "notify", func(ctx context.Context, key string) error {
+//		return notifier.Send(ctx, key, message)
+//	})
+//	if updateErr := c.Update(ctx, obj); err == nil {
+//		err = updateErr
+//	}
+//
+// An operation whose result couldn't be recorded, e.g. because the controller crashed,
+// is performed again, so operations should still pass the key on to external APIs that
+// support idempotency keys.
+type OperationLedger struct {
+	// Store stores the keys of the performed operations. Defaults to the
+	// OperationsAnnotation.
+	Store OperationStore
+
+	// MaxEntries is the number of keys that are kept per object, the oldest keys
+	// are dropped first. Defaults to 32.
+	MaxEntries int
+}
+
+// Performed returns whether the operation with the given key was performed for obj.
+func (l *OperationLedger) Performed(ctx context.Context, obj client.Object, key string) (bool, error) {
+	keys, err := l.store().Get(ctx, obj)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(keys, key), nil
+}
+
+// Record records that the operation with the given key was performed for obj.
+func (l *OperationLedger) Record(ctx context.Context, obj client.Object, key string) error {
+	store := l.store()
+	keys, err := store.Get(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(keys, key) {
+		return nil
+	}
+	keys = append(keys, key)
+	maxEntries := l.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultOperationLedgerMaxEntries
+	}
+	if len(keys) > maxEntries {
+		keys = keys[len(keys)-maxEntries:]
+	}
+	return store.Set(ctx, obj, keys)
+}
+
+// Do calls op with the IdempotencyKey of the given step of obj, unless the operation was
+// performed already, and records it if op succeeds.
+func (l *OperationLedger) Do(ctx context.Context, obj client.Object, step string, op func(ctx context.Context, key string) error) error {
+	key := IdempotencyKey(obj, step)
+	performed, err := l.Performed(ctx, obj, key)
+	if err != nil || performed {
+		return err
+	}
+	if err := op(ctx, key); err != nil {
+		return err
+	}
+	return l.Record(ctx, obj, key)
+}
+
+func (l *OperationLedger) store() OperationStore {
+	if l.Store == nil {
+		return AnnotationOperationStore(OperationsAnnotation)
+	}
+	return l.Store
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("IdempotencyKey", func() {
+	It("should only change with the UID, the generation and the step", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1}}
+		key := controllerutil.IdempotencyKey(pod, "notify")
+		Expect(key).To(HaveLen(32))
+
+		pod.Labels = map[string]string{"changed": "true"}
+		Expect(controllerutil.IdempotencyKey(pod, "notify")).To(Equal(key))
+		Expect(controllerutil.IdempotencyKey(pod, "provision")).NotTo(Equal(key))
+		pod.Generation = 2
+		Expect(controllerutil.IdempotencyKey(pod, "notify")).NotTo(Equal(key))
+		pod.Generation, pod.UID = 1, "other"
+		Expect(controllerutil.IdempotencyKey(pod, "notify")).NotTo(Equal(key))
+	})
+})
+
+var _ = Describe("OperationLedger", func() {
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo", UID: "uid", Generation: 1}}
+	})
+
+	countCalls := func(ledger *controllerutil.OperationLedger, step string, err error) (int, error) {
+		calls := 0
+		doErr := ledger.Do(context.Background(), pod, step, func(_ context.Context, key string) error {
+			Expect(key).To(Equal(controllerutil.IdempotencyKey(pod, step)))
+			calls++
+			return err
+		})
+		return calls, doErr
+	}
+
+	It("should perform an operation once per generation", func() {
+		ledger := &controllerutil.OperationLedger{}
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(1))
+		Expect(pod.Annotations).To(HaveKey(controllerutil.OperationsAnnotation))
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(0))
+		Expect(countCalls(ledger, "provision", nil)).To(Equal(1))
+
+		pod.Generation = 2
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(1))
+	})
+
+	It("should not record failed operations", func() {
+		ledger := &controllerutil.OperationLedger{}
+		expectedErr := errors.New("expected error")
+		calls, err := countCalls(ledger, "notify", expectedErr)
+		Expect(calls).To(Equal(1))
+		Expect(err).To(MatchError(expectedErr))
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(1))
+	})
+
+	It("should only keep the most recent keys", func() {
+		ledger := &controllerutil.OperationLedger{MaxEntries: 2}
+		for _, step := range []string{"a", "b", "c"} {
+			Expect(countCalls(ledger, step, nil)).To(Equal(1))
+		}
+		Expect(ledger.Performed(context.Background(), pod, controllerutil.IdempotencyKey(pod, "a"))).To(BeFalse())
+		Expect(ledger.Performed(context.Background(), pod, controllerutil.IdempotencyKey(pod, "c"))).To(BeTrue())
+	})
+
+	It("should store the keys in a ConfigMap", func() {
+		c := fake.NewClientBuilder().Build()
+		key := client.ObjectKey{Namespace: "default", Name: "operations"}
+		ledger := &controllerutil.OperationLedger{Store: controllerutil.ConfigMapOperationStore(c, key)}
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(1))
+		Expect(pod.Annotations).To(BeEmpty())
+
+		ledger = &controllerutil.OperationLedger{Store: controllerutil.ConfigMapOperationStore(c, key)}
+		Expect(countCalls(ledger, "notify", nil)).To(Equal(0))
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(context.Background(), key, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKey("uid"))
+	})
+})
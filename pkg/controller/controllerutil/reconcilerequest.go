@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileRequested returns the value of the reconcile.RequestedAtAnnotation of obj and whether
+// it requests a reconciliation that wasn't handled yet, i.e. whether it is set and differs from
+// lastHandled, the value that was last acknowledged with AcknowledgeReconcileRequest.
+func ReconcileRequested(obj metav1.Object, lastHandled string) (string, bool) {
+	requestedAt := obj.GetAnnotations()[reconcile.RequestedAtAnnotation]
+	return requestedAt, requestedAt != "" && requestedAt != lastHandled
+}
+
+// AcknowledgeReconcileRequest records that the reconciliation requested with the
+// reconcile.RequestedAtAnnotation of obj was handled by setting lastHandled, usually a
+// field in the status of obj, to the value of the annotation. It returns true if lastHandled
+// was changed, in which case the status of obj needs to be updated. This lets users wait for
+// their request to be handled with e.g.
+//
+//	kubectl wait --for=jsonpath='{.status.lastHandledReconcileAt}'="$requestedAt" foo/bar
+func AcknowledgeReconcileRequest(obj metav1.Object, lastHandled *string) bool {
+	requestedAt, requested := ReconcileRequested(obj, *lastHandled)
+	if !requested {
+		return false
+	}
+	*lastHandled = requestedAt
+	return true
+}
+
+// ClearReconcileRequest removes the reconcile.RequestedAtAnnotation from obj, for controllers
+// that don't record the handled requests in the status of their objects. It returns true if the
+// annotation was set, in which case obj needs to be updated.
+func ClearReconcileRequest(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[reconcile.RequestedAtAnnotation]; !ok {
+		return false
+	}
+	delete(annotations, reconcile.RequestedAtAnnotation)
+	obj.SetAnnotations(annotations)
+	return true
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconcile requests", func() {
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "foo",
+			Annotations: map[string]string{reconcile.RequestedAtAnnotation: "now"},
+		}}
+	})
+
+	It("should acknowledge a reconcile request once", func() {
+		var lastHandled string
+		requestedAt, requested := controllerutil.ReconcileRequested(pod, lastHandled)
+		Expect(requestedAt).To(Equal("now"))
+		Expect(requested).To(BeTrue())
+
+		Expect(controllerutil.AcknowledgeReconcileRequest(pod, &lastHandled)).To(BeTrue())
+		Expect(lastHandled).To(Equal("now"))
+		_, requested = controllerutil.ReconcileRequested(pod, lastHandled)
+		Expect(requested).To(BeFalse())
+		Expect(controllerutil.AcknowledgeReconcileRequest(pod, &lastHandled)).To(BeFalse())
+
+		pod.Annotations[reconcile.RequestedAtAnnotation] = "later"
+		Expect(controllerutil.AcknowledgeReconcileRequest(pod, &lastHandled)).To(BeTrue())
+		Expect(lastHandled).To(Equal("later"))
+	})
+
+	It("should not report a request for objects without the annotation", func() {
+		pod.Annotations = nil
+		lastHandled := "now"
+		_, requested := controllerutil.ReconcileRequested(pod, "")
+		Expect(requested).To(BeFalse())
+		Expect(controllerutil.AcknowledgeReconcileRequest(pod, &lastHandled)).To(BeFalse())
+		Expect(lastHandled).To(Equal("now"))
+	})
+
+	It("should clear the annotation", func() {
+		pod.Annotations["other"] = "value"
+		Expect(controllerutil.ClearReconcileRequest(pod)).To(BeTrue())
+		Expect(pod.Annotations).To(Equal(map[string]string{"other": "value"}))
+		Expect(controllerutil.ClearReconcileRequest(pod)).To(BeFalse())
+	})
+})
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultStatusUpdateConcurrency = 10
+
+// StatusMutateFn is a function which mutates the status of the given object.
+type StatusMutateFn func(obj client.Object) error
+
+// UpdateStatusesOptions are the options for UpdateStatuses.
+type UpdateStatusesOptions struct {
+	// MaxConcurrency is the maximum number of objects whose status is updated in
+	// parallel. Defaults to 10.
+	MaxConcurrency int
+
+	// Backoff is used to retry the update of an object after a conflict or a
+	// transient error. Defaults to retry.DefaultRetry.
+	Backoff *wait.Backoff
+}
+
+// UpdateStatuses applies f to each of the given objects and updates their status,
+// e.g. to mark all tenants as degraded during an outage. Objects whose status
+// isn't changed by f are not updated.
+//
+// If an update fails with a conflict or a transient error, i.e. if the request was
+// throttled, timed out or failed with an internal server error, the object is read
+// again through c and f is applied again, following the Backoff of the options. Objects that don't exist
+// anymore are skipped. The errors of all objects are returned as an aggregate,
+// a failing object doesn't prevent the others from being updated.
+//
+// The objects are updated in place with the response of the API server.
+func UpdateStatuses(ctx context.Context, c client.Client, objs []client.Object, f StatusMutateFn, opts UpdateStatusesOptions) error {
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = defaultStatusUpdateConcurrency
+	}
+	backoff := retry.DefaultRetry
+	if opts.Backoff != nil {
+		backoff = *opts.Backoff
+	}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, opts.MaxConcurrency)
+	for _, obj := range objs {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return kerrors.NewAggregate(append(errs, ctx.Err()))
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := updateStatus(ctx, c, obj, f, backoff); err != nil {
+				lock.Lock()
+				defer lock.Unlock()
+				errs = append(errs, fmt.Errorf("failed to update status of %s: %w", client.ObjectKeyFromObject(obj), err))
+			}
+		}()
+	}
+	wg.Wait()
+	return kerrors.NewAggregate(errs)
+}
+
+func updateStatus(ctx context.Context, c client.Client, obj client.Object, f StatusMutateFn, backoff wait.Backoff) error {
+	refresh := false
+	err := retry.OnError(backoff, isRetriableStatusUpdateError, func() error {
+		if refresh {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+		}
+		refresh = true
+
+		before := obj.DeepCopyObject()
+		if err := f(obj); err != nil {
+			return err
+		}
+		if equality.Semantic.DeepEqual(before, obj) {
+			return nil
+		}
+		return c.Status().Update(ctx, obj)
+	})
+	return client.IgnoreNotFound(err)
+}
+
+// isRetriableStatusUpdateError returns true for conflicts and errors that are
+// likely to go away when the request is sent again.
+func isRetriableStatusUpdateError(err error) bool {
+	return apierrors.IsConflict(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsUnexpectedServerError(err)
+}
+
+// SetStatusCondition returns a StatusMutateFn that sets the given condition on the
+// conditions of the object, which are returned by conditions, e.g.
+//
+//	func(obj client.Object) *[]metav1.Condition { return &obj.(*v1.Tenant).Status.Conditions }
+//
+// The ObservedGeneration of the condition defaults to the generation of the object.
+func SetStatusCondition(conditions func(obj client.Object) *[]metav1.Condition, condition metav1.Condition) StatusMutateFn {
+	return func(obj client.Object) error {
+		c := condition
+		if c.ObservedGeneration == 0 {
+			c.ObservedGeneration = obj.GetGeneration()
+		}
+		meta.SetStatusCondition(conditions(obj), c)
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("UpdateStatuses", func() {
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	setMessage := func(obj client.Object) error {
+		obj.(*corev1.Pod).Status.Message = "degraded"
+		return nil
+	}
+	message := func(ctx context.Context, c client.Client, name string) string {
+		p := &corev1.Pod{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, p)).To(Succeed())
+		return p.Status.Message
+	}
+
+	It("should update the status of all objects and skip unchanged and missing ones", func(ctx SpecContext) {
+		unchanged := pod("unchanged")
+		unchanged.Status.Message = "degraded"
+		var updates atomic.Int32
+		c := fake.NewClientBuilder().
+			WithObjects(pod("a"), pod("b"), unchanged).
+			WithStatusSubresource(&corev1.Pod{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					updates.Add(1)
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		objs := []client.Object{pod("a"), pod("b"), unchanged.DeepCopy(), pod("missing")}
+		for _, obj := range objs[:3] {
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		}
+		Expect(controllerutil.UpdateStatuses(ctx, c, objs, setMessage, controllerutil.UpdateStatusesOptions{MaxConcurrency: 2})).To(Succeed())
+
+		Expect(message(ctx, c, "a")).To(Equal("degraded"))
+		Expect(message(ctx, c, "b")).To(Equal("degraded"))
+		// The missing pod fails with a not found error, the unchanged pod isn't updated.
+		Expect(updates.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("should read the object again and retry on conflicts", func(ctx SpecContext) {
+		var conflicts atomic.Int32
+		c := fake.NewClientBuilder().
+			WithObjects(pod("a")).
+			WithStatusSubresource(&corev1.Pod{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if conflicts.Add(1) == 1 {
+						return apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, obj.GetName(), errors.New("conflict"))
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		objs := []client.Object{pod("a")}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(objs[0]), objs[0])).To(Succeed())
+		Expect(controllerutil.UpdateStatuses(ctx, c, objs, setMessage, controllerutil.UpdateStatusesOptions{})).To(Succeed())
+		Expect(conflicts.Load()).To(BeEquivalentTo(2))
+		Expect(message(ctx, c, "a")).To(Equal("degraded"))
+	})
+
+	It("should retry transient errors but not permanent ones", func(ctx SpecContext) {
+		var attempts atomic.Int32
+		c := fake.NewClientBuilder().
+			WithObjects(pod("a"), pod("forbidden")).
+			WithStatusSubresource(&corev1.Pod{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if obj.GetName() == "forbidden" {
+						return apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, obj.GetName(), errors.New("denied"))
+					}
+					switch attempts.Add(1) {
+					case 1:
+						return apierrors.NewTooManyRequests("throttled", 0)
+					case 2:
+						return apierrors.NewServiceUnavailable("unavailable")
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+
+		objs := []client.Object{pod("a"), pod("forbidden")}
+		for _, obj := range objs {
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		}
+		err := controllerutil.UpdateStatuses(ctx, c, objs, setMessage, controllerutil.UpdateStatusesOptions{})
+		Expect(err).To(MatchError(ContainSubstring("default/forbidden")))
+		Expect(attempts.Load()).To(BeEquivalentTo(3))
+		Expect(message(ctx, c, "a")).To(Equal("degraded"))
+	})
+
+	It("should aggregate the errors of all objects", func(ctx SpecContext) {
+		c := fake.NewClientBuilder().
+			WithObjects(pod("a"), pod("b"), pod("c")).
+			WithStatusSubresource(&corev1.Pod{}).
+			Build()
+
+		objs := []client.Object{pod("a"), pod("b"), pod("c")}
+		for _, obj := range objs {
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		}
+		err := controllerutil.UpdateStatuses(ctx, c, objs, func(obj client.Object) error {
+			if obj.GetName() != "b" {
+				return errors.New("boom")
+			}
+			return setMessage(obj)
+		}, controllerutil.UpdateStatusesOptions{})
+		Expect(err).To(MatchError(And(ContainSubstring("default/a"), ContainSubstring("default/c"), ContainSubstring("boom"))))
+		Expect(message(ctx, c, "b")).To(Equal("degraded"))
+	})
+})
+
+var _ = Describe("SetStatusCondition", func() {
+	It("should set the condition with the generation of the object", func() {
+		var conditions []metav1.Condition
+		obj := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+		f := controllerutil.SetStatusCondition(func(client.Object) *[]metav1.Condition { return &conditions }, metav1.Condition{
+			Type:   "Degraded",
+			Status: metav1.ConditionTrue,
+			Reason: "Outage",
+		})
+
+		Expect(f(obj)).To(Succeed())
+		Expect(conditions).To(ConsistOf(And(
+			HaveField("Type", "Degraded"),
+			HaveField("Status", metav1.ConditionTrue),
+			HaveField("ObservedGeneration", int64(3)),
+		)))
+	})
+})
@@ -29,15 +29,34 @@ var usedNames sets.Set[string]
 func checkName(name string) error {
 	nameLock.Lock()
 	defer nameLock.Unlock()
+	if err := validateNameLocked(name); err != nil {
+		return err
+	}
 	if usedNames == nil {
 		usedNames = sets.Set[string]{}
 	}
+	usedNames.Insert(name)
+
+	return nil
+}
+
+// releaseName allows the name to be used by another controller.
+func releaseName(name string) {
+	nameLock.Lock()
+	defer nameLock.Unlock()
+	usedNames.Delete(name)
+}
+
+// validateName checks that the name is not used yet without reserving it.
+func validateName(name string) error {
+	nameLock.Lock()
+	defer nameLock.Unlock()
+	return validateNameLocked(name)
+}
 
+func validateNameLocked(name string) error {
 	if usedNames.Has(name) {
 		return fmt.Errorf("controller with name %s already exists. Controller names must be unique to avoid multiple controllers reporting the same metric. This validation can be disabled via the SkipNameValidation option", name)
 	}
-
-	usedNames.Insert(name)
-
 	return nil
 }
@@ -0,0 +1,97 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The benchmarks in this file measure the throughput of the queue with many
+// concurrent producers and consumers, as seen by controllers that process tens
+// of thousands of events per second. Run them with different -cpu values to
+// observe how the queue scales, e.g.:
+//
+//	go test -run xxx -bench Parallel -cpu 1,4,16 ./pkg/controller/priorityqueue
+//
+// The add benchmarks include inserting the added items into the queue, which
+// happens asynchronously, by calling Len before the timer is stopped.
+
+func BenchmarkAddParallel(b *testing.B) {
+	q := New[int]("")
+	defer q.ShutDown()
+
+	var keys atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Add(int(keys.Add(1) % 100000))
+		}
+	})
+	q.Len()
+}
+
+func BenchmarkAddWithPriorityParallel(b *testing.B) {
+	q := New[int]("")
+	defer q.ShutDown()
+
+	var keys atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := keys.Add(1)
+			q.AddWithOpts(AddOpts{Priority: new(int(key % 10))}, int(key%100000))
+		}
+	})
+	q.Len()
+}
+
+func BenchmarkAddAfterParallel(b *testing.B) {
+	q := New[int]("")
+	defer q.ShutDown()
+
+	var keys atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			key := keys.Add(1)
+			q.AddAfter(int(key%100000), time.Duration(key%3600)*time.Second)
+		}
+	})
+	q.Len()
+}
+
+func BenchmarkAddGetDoneParallel(b *testing.B) {
+	for _, workers := range []int{1, 16, 256} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			q := New[int]("")
+
+			var processed atomic.Int64
+			var wg sync.WaitGroup
+			for range workers {
+				wg.Go(func() {
+					for {
+						item, shutdown := q.Get()
+						if shutdown {
+							return
+						}
+						processed.Add(1)
+						q.Done(item)
+					}
+				})
+			}
+
+			var keys atomic.Int64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					q.Add(int(keys.Add(1)))
+				}
+			})
+			for processed.Load() < keys.Load() {
+				time.Sleep(time.Millisecond)
+			}
+
+			b.StopTimer()
+			q.ShutDown()
+			wg.Wait()
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package priorityqueue
 
 import (
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -23,6 +24,9 @@ type AddOpts struct {
 	// indicate higher priority.
 	// Defaults to zero if unset.
 	Priority *int
+	// Provenance describes what adds the items, see ProvenanceQueue.
+	// Defaults to ProvenanceManual if unset.
+	Provenance Provenance
 }
 
 // PriorityQueue is a priority queue for a controller. It
@@ -49,7 +53,44 @@ type Opts[T comparable] struct {
 	RateLimiter    workqueue.TypedRateLimiter[T]
 	MetricProvider workqueue.MetricsProvider
 	Log            logr.Logger
-}
+
+	// OnShutdownPendingItems is called when the queue is shut down with all
+	// items that were added with a delay, e.G. through AddAfter or AddRateLimited,
+	// and had not become ready yet. These items would otherwise be lost, the
+	// callback allows to persist their next run time outside of the process so
+	// the delayed work can be picked up again after a restart.
+	// Items that are added with a delay after the queue was shut down, e.G. by
+	// reconciles that were still in flight, are handed to it as well, so it may
+	// be called more than once. Calls are serialized, so it must not add items
+	// to the queue itself. As the rate limiter isn't consulted anymore after
+	// shutdown, items that are added rate limited after shutdown are handed
+	// over with the delay they were added with, if any.
+	// It is not called if there are no pending items.
+	OnShutdownPendingItems func(items []PendingItem[T])
+
+	// FirePendingItemsOnShutdown compresses the delay of the pending items to
+	// zero: they are handed to OnShutdownPendingItems with a ReadyAt of the time
+	// the queue was shut down, or they were added at after that, instead of the
+	// time they would have become ready at. This allows to run the delayed work
+	// right away, e.G. by handing it to another process, rather than persisting
+	// it until it is due.
+	FirePendingItemsOnShutdown bool
+}
+
+// PendingItem is an item that was still waiting to become ready when
+// the queue was shut down.
+type PendingItem[T comparable] struct {
+	Key        T            `json:"key"`
+	Priority   int          `json:"priority"`
+	ReadyAt    time.Time    `json:"readyAt,omitzero"`
+	Provenance []Provenance `json:"provenance,omitempty"`
+}
+
+// getBufferSize is the number of items that can be handed out to the routines
+// blocked in Get without waiting for each of them to be scheduled. Items are only
+// sent to the buffer for routines that are blocked in Get and never after the
+// queue was shut down, so every item in it is taken by a Get.
+const getBufferSize = 64
 
 // Opt allows to configure a PriorityQueue.
 type Opt[T comparable] func(*Opts[T])
@@ -59,7 +100,7 @@ type bufferItem[T comparable] struct {
 	items []T
 }
 
-// New constructs a new PriorityQueue.
+// New constructs a new PriorityQueue. It implements ProvenanceQueue.
 func New[T comparable](name string, o ...Opt[T]) PriorityQueue[T] {
 	opts := &Opts[T]{}
 	for _, f := range o {
@@ -79,7 +120,6 @@ func New[T comparable](name string, o ...Opt[T]) PriorityQueue[T] {
 		itemAddedToAddBuffer: make(chan struct{}, 1),
 		items:                map[T]*item[T]{},
 		ready:                btree.New(32, lessReady[T]),
-		waiting:              btree.New(32, lessWaiting[T]),
 		metrics:              newQueueMetrics[T](opts.MetricProvider, name, clock.RealClock{}),
 		// readyItemOrWaiterAdded indicates that a ready item or
 		// waiter was added. It must be buffered, because
@@ -90,10 +130,13 @@ func New[T comparable](name string, o ...Opt[T]) PriorityQueue[T] {
 		rateLimiter:               opts.RateLimiter,
 		locked:                    sets.Set[T]{},
 		done:                      make(chan struct{}),
-		get:                       make(chan item[T]),
+		get:                       make(chan item[T], getBufferSize),
 		now:                       time.Now,
 		tick:                      time.Tick,
+		onShutdownPendingItems:    opts.OnShutdownPendingItems,
+		firePendingItems:          opts.FirePendingItemsOnShutdown,
 	}
+	pq.waiting = newTimingWheel[T](func() time.Time { return pq.now() })
 
 	go pq.handleAddBuffer()
 	go pq.handleReadyItems()
@@ -112,9 +155,12 @@ type priorityqueue[T comparable] struct {
 	addBufferLock        sync.Mutex
 	addBuffer            []bufferItem[T]
 	itemAddedToAddBuffer chan struct{}
+	// spareAddBuffer is swapped with addBuffer when flushing, so that the buffers
+	// are reused instead of allocating a new one for every flush. It is guarded by lock.
+	spareAddBuffer []bufferItem[T]
 
-	// lock has to be acquired for any access to any of items, ready, waiting,
-	// addedCounter or waiters.
+	// lock has to be acquired for any access to any of items, ready, waiting
+	// or addedCounter.
 	lock    sync.Mutex
 	items   map[T]*item[T]
 	ready   bTree[*item[T]]
@@ -139,11 +185,21 @@ type priorityqueue[T comparable] struct {
 	shutdown atomic.Bool
 	done     chan struct{}
 
+	// onShutdownPendingItems is handed all waiting items on shutdown if set.
+	// Calls are serialized through onShutdownPendingItemsLock.
+	onShutdownPendingItems     func(items []PendingItem[T])
+	onShutdownPendingItemsLock sync.Mutex
+	firePendingItems           bool
+
+	// get hands out items to the routines blocked in Get. It is buffered, so that
+	// handleReadyItems can hand out a batch of items without a context switch per
+	// item. Only as many items as there are waiters are sent.
 	get chan item[T]
 
 	// waiters is the number of routines blocked in Get, we use it to determine
-	// if we can push items. Every manipulation has to be protected with the lock.
-	waiters int64
+	// if we can push items. It is only decremented by handleReadyItems, so that
+	// Get doesn't need to acquire the lock.
+	waiters atomic.Int64
 
 	// Configurable for testing
 	now  func() time.Time
@@ -152,6 +208,7 @@ type priorityqueue[T comparable] struct {
 
 func (w *priorityqueue[T]) AddWithOpts(o AddOpts, items ...T) {
 	if w.shutdown.Load() {
+		w.handOverPendingItems(o, items...)
 		return
 	}
 
@@ -160,6 +217,13 @@ func (w *priorityqueue[T]) AddWithOpts(o AddOpts, items ...T) {
 	}
 
 	w.addBufferLock.Lock()
+	// ShutDown marks the queue as shut down while holding the addBufferLock, so
+	// anything added here is either flushed by ShutDown or handed over below.
+	if w.shutdown.Load() {
+		w.addBufferLock.Unlock()
+		w.handOverPendingItems(o, items...)
+		return
+	}
 	w.addBuffer = append(w.addBuffer, bufferItem[T]{
 		opts:  o,
 		items: items,
@@ -186,30 +250,38 @@ func (w *priorityqueue[T]) handleAddBuffer() {
 func (w *priorityqueue[T]) lockedFlushAddBuffer() {
 	w.addBufferLock.Lock()
 	buffer := w.addBuffer
-	w.addBuffer = make([]bufferItem[T], 0, len(buffer))
+	w.addBuffer = w.spareAddBuffer[:0]
 	w.addBufferLock.Unlock()
 
 	for _, v := range buffer {
 		w.lockedAddWithOpts(v.opts, v.items...)
 	}
+	// Drop the references to the added items before reusing the buffer.
+	clear(buffer)
+	w.spareAddBuffer = buffer
 }
 
 func (w *priorityqueue[T]) lockedAddWithOpts(o AddOpts, items ...T) {
 	if w.shutdown.Load() {
 		return
 	}
+	w.lockedInsert(o, items...)
+}
 
+// lockedInsert adds the items to the ready or waiting items. Unlike
+// lockedAddWithOpts, it doesn't check if the queue was shut down, so
+// that ShutDown can insert the items that are still buffered.
+func (w *priorityqueue[T]) lockedInsert(o AddOpts, items ...T) {
 	var readyItemAdded bool
 	var waitingItemAddedOrUpdated bool
 
+	provenance := o.Provenance
+	if provenance == "" {
+		provenance = ProvenanceManual
+	}
+
 	for _, key := range items {
-		after := o.After
-		if o.RateLimited {
-			rlAfter := w.rateLimiter.When(key)
-			if after == 0 || rlAfter < after {
-				after = rlAfter
-			}
-		}
+		after := w.delay(o, key, true)
 
 		var readyAt *time.Time
 		if after > 0 {
@@ -222,6 +294,7 @@ func (w *priorityqueue[T]) lockedAddWithOpts(o AddOpts, items ...T) {
 				AddedCounter: w.addedCounter,
 				Priority:     ptr.Deref(o.Priority, 0),
 				ReadyAt:      readyAt,
+				Provenance:   []Provenance{provenance},
 			}
 			w.addedCounter++
 			w.items[key] = item
@@ -242,6 +315,10 @@ func (w *priorityqueue[T]) lockedAddWithOpts(o AddOpts, items ...T) {
 			readyAt = w.items[key].ReadyAt
 		}
 
+		if !slices.Contains(w.items[key].Provenance, provenance) {
+			w.items[key].Provenance = append(w.items[key].Provenance, provenance)
+		}
+
 		priority := w.items[key].Priority
 		addedCounter := w.items[key].AddedCounter
 		if newPriority := ptr.Deref(o.Priority, 0); newPriority > w.items[key].Priority {
@@ -285,6 +362,20 @@ func (w *priorityqueue[T]) lockedAddWithOpts(o AddOpts, items ...T) {
 	}
 }
 
+// delay returns the duration after which an item added with the given options
+// becomes ready. The rate limiter is only consulted if consultRateLimiter is
+// set, as asking it for a delay records a failure of the item.
+func (w *priorityqueue[T]) delay(o AddOpts, key T, consultRateLimiter bool) time.Duration {
+	after := o.After
+	if o.RateLimited && consultRateLimiter {
+		rlAfter := w.rateLimiter.When(key)
+		if after == 0 || rlAfter < after {
+			after = rlAfter
+		}
+	}
+	return after
+}
+
 func (w *priorityqueue[T]) notifyItemAddedToAddBuffer() {
 	select {
 	case w.itemAddedToAddBuffer <- struct{}{}:
@@ -375,7 +466,9 @@ func (w *priorityqueue[T]) handleReadyItems() {
 			// but the cost is negligible.
 			w.lockedFlushAddBuffer()
 
-			if w.waiters == 0 {
+			// Don't hand out items after shutdown, GetWithPriority only takes
+			// the items that were handed out before.
+			if w.waiters.Load() == 0 || w.shutdown.Load() {
 				return
 			}
 
@@ -394,12 +487,12 @@ func (w *priorityqueue[T]) handleReadyItems() {
 
 				w.metrics.get(item.Key, item.Priority)
 				w.locked.Insert(item.Key)
-				w.waiters--
+				w.waiters.Add(-1)
 				delete(w.items, item.Key)
 				toDelete = append(toDelete, item)
 				w.get <- *item
 
-				return w.waiters > 0
+				return w.waiters.Load() > 0
 			})
 
 			for _, item := range toDelete {
@@ -422,15 +515,17 @@ func (w *priorityqueue[T]) AddRateLimited(item T) {
 }
 
 func (w *priorityqueue[T]) GetWithPriority() (_ T, priority int, shutdown bool) {
+	key, priority, _, shutdown := w.GetWithProvenance()
+	return key, priority, shutdown
+}
+
+func (w *priorityqueue[T]) GetWithProvenance() (_ T, priority int, provenance []Provenance, shutdown bool) {
 	if w.shutdown.Load() {
 		var zero T
-		return zero, 0, true
+		return zero, 0, nil, true
 	}
 
-	w.lock.Lock()
-	w.waiters++
-	w.lock.Unlock()
-
+	w.waiters.Add(1)
 	w.notifyReadyItemOrWaiterAdded()
 
 	select {
@@ -440,10 +535,17 @@ func (w *priorityqueue[T]) GetWithPriority() (_ T, priority int, shutdown bool)
 		// GetWithPriority is blocking the workers if there are no items in the queue.
 		// If the controller and accordingly the queue is then shut down, without this code
 		// branch the controller workers remain blocked here and are unable to shut down.
+		// An item that was handed out before the shutdown is returned rather than left
+		// behind in the buffer of get.
+		select {
+		case item := <-w.get:
+			return item.Key, item.Priority, item.Provenance, true
+		default:
+		}
 		var zero T
-		return zero, 0, true
+		return zero, 0, nil, true
 	case item := <-w.get:
-		return item.Key, item.Priority, w.shutdown.Load()
+		return item.Key, item.Priority, item.Provenance, w.shutdown.Load()
 	}
 }
 
@@ -473,8 +575,91 @@ func (w *priorityqueue[T]) Done(item T) {
 }
 
 func (w *priorityqueue[T]) ShutDown() {
+	if w.onShutdownPendingItems == nil {
+		// Mark the queue as shut down while holding the lock, so that handleReadyItems
+		// doesn't hand out items anymore once done is closed.
+		w.lock.Lock()
+		w.shutdown.Store(true)
+		w.lock.Unlock()
+		close(w.done)
+		return
+	}
+
+	// Mark the queue as shut down while holding the addBufferLock, so that every
+	// item added before is either in the add buffer or already inserted, and
+	// insert the buffered items before collecting the waiting ones. This ensures
+	// no item that was added before ShutDown was called gets lost.
+	w.lock.Lock()
+	w.addBufferLock.Lock()
 	w.shutdown.Store(true)
+	buffer := w.addBuffer
+	w.addBuffer = nil
+	w.addBufferLock.Unlock()
+	for _, v := range buffer {
+		w.lockedInsert(v.opts, v.items...)
+	}
+	shutdownAt := w.now()
+	pending := make([]PendingItem[T], 0, w.waiting.Len())
+	w.waiting.Ascend(func(item *item[T]) bool {
+		readyAt := *item.ReadyAt
+		if w.firePendingItems {
+			readyAt = shutdownAt
+		}
+		pending = append(pending, PendingItem[T]{
+			Key:        item.Key,
+			Priority:   item.Priority,
+			ReadyAt:    readyAt,
+			Provenance: item.Provenance,
+		})
+		return true
+	})
+	w.lock.Unlock()
+
 	close(w.done)
+
+	w.callOnShutdownPendingItems(pending)
+}
+
+// handOverPendingItems passes the items that are added with a delay after the
+// queue was shut down to the onShutdownPendingItems callback. Items that would
+// be ready immediately are skipped.
+func (w *priorityqueue[T]) handOverPendingItems(o AddOpts, items ...T) {
+	if w.onShutdownPendingItems == nil {
+		return
+	}
+
+	var pending []PendingItem[T]
+	now := w.now()
+	for _, key := range items {
+		after := w.delay(o, key, false)
+		if after <= 0 && !o.RateLimited {
+			continue
+		}
+		readyAt := now
+		if !w.firePendingItems && after > 0 {
+			readyAt = now.Add(after)
+		}
+		provenance := o.Provenance
+		if provenance == "" {
+			provenance = ProvenanceManual
+		}
+		pending = append(pending, PendingItem[T]{
+			Key:        key,
+			Priority:   ptr.Deref(o.Priority, 0),
+			ReadyAt:    readyAt,
+			Provenance: []Provenance{provenance},
+		})
+	}
+	w.callOnShutdownPendingItems(pending)
+}
+
+func (w *priorityqueue[T]) callOnShutdownPendingItems(pending []PendingItem[T]) {
+	if len(pending) == 0 {
+		return
+	}
+	w.onShutdownPendingItemsLock.Lock()
+	defer w.onShutdownPendingItemsLock.Unlock()
+	w.onShutdownPendingItems(pending)
 }
 
 // ShutDownWithDrain just calls ShutDown, as the draining
@@ -497,6 +682,62 @@ func (w *priorityqueue[T]) Len() int {
 	return w.ready.Len()
 }
 
+// LenAtPriority returns the number of items that are ready to be
+// picked up and have at least the given priority.
+func (w *priorityqueue[T]) LenAtPriority(minPriority int) int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lockedFlushAddBuffer()
+
+	var n int
+	w.ready.Ascend(func(item *item[T]) bool {
+		if item.Priority < minPriority {
+			// Ready items are ordered by descending priority.
+			return false
+		}
+		n++
+		return true
+	})
+	return n
+}
+
+// LenAtPriority returns the number of items of q that are ready to be picked up and
+// have at least the given priority, so that producers can back off while enough items
+// that are processed before their own are waiting, see source.WaitForQueueCapacity.
+// It returns q.Len() if q doesn't track the priority of its items.
+func LenAtPriority[T comparable](q workqueue.TypedRateLimitingInterface[T], minPriority int) int {
+	if pq, ok := q.(provenanceQueue[T]); ok {
+		q = pq.PriorityQueue
+	}
+	if pq, ok := q.(interface{ LenAtPriority(int) int }); ok {
+		return pq.LenAtPriority(minPriority)
+	}
+	return q.Len()
+}
+
+func (w *priorityqueue[T]) PendingItems() []PendingItem[T] {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lockedFlushAddBuffer()
+
+	pending := make([]PendingItem[T], 0, len(w.items))
+	appendItem := func(item *item[T]) bool {
+		pendingItem := PendingItem[T]{
+			Key:        item.Key,
+			Priority:   item.Priority,
+			Provenance: slices.Clone(item.Provenance),
+		}
+		if item.ReadyAt != nil {
+			pendingItem.ReadyAt = *item.ReadyAt
+		}
+		pending = append(pending, pendingItem)
+		return true
+	}
+	w.ready.Ascend(appendItem)
+	w.waiting.Ascend(appendItem)
+	return pending
+}
+
 func (w *priorityqueue[T]) logState() {
 	t := time.Tick(10 * time.Second)
 	for {
@@ -543,10 +784,11 @@ func lessReady[T comparable](a, b *item[T]) bool {
 }
 
 type item[T comparable] struct {
-	Key          T          `json:"key"`
-	AddedCounter uint64     `json:"addedCounter"`
-	Priority     int        `json:"priority"`
-	ReadyAt      *time.Time `json:"readyAt,omitempty"`
+	Key          T            `json:"key"`
+	AddedCounter uint64       `json:"addedCounter"`
+	Priority     int          `json:"priority"`
+	ReadyAt      *time.Time   `json:"readyAt,omitempty"`
+	Provenance   []Provenance `json:"provenance,omitempty"`
 }
 
 func (w *priorityqueue[T]) updateUnfinishedWorkLoop() {
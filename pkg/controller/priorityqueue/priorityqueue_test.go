@@ -231,6 +231,157 @@ var _ = Describe("Controllerworkqueue", func() {
 		Expect(isShutDown).To(BeTrue())
 	})
 
+	It("hands pending items with after to the shutdown callback", func() {
+		q, _ := newQueue()
+
+		var pending []PendingItem[string]
+		q.onShutdownPendingItems = func(items []PendingItem[string]) {
+			pending = items
+		}
+
+		q.AddWithOpts(AddOpts{}, "ready")
+		q.AddWithOpts(AddOpts{After: time.Hour}, "later")
+		q.AddWithOpts(AddOpts{After: time.Minute, Priority: ptr.To(1)}, "sooner")
+
+		before := time.Now()
+		q.ShutDown()
+
+		Expect(pending).To(HaveLen(2))
+		Expect(pending[0].Key).To(Equal("sooner"))
+		Expect(pending[0].Priority).To(Equal(1))
+		Expect(pending[0].ReadyAt).To(BeTemporally("~", before.Add(time.Minute), time.Second))
+		Expect(pending[1].Key).To(Equal("later"))
+		Expect(pending[1].ReadyAt).To(BeTemporally("~", before.Add(time.Hour), time.Second))
+	})
+
+	It("hands items added with after after shutdown to the shutdown callback", func() {
+		q, _ := newQueue()
+
+		var lock sync.Mutex
+		var pending []PendingItem[string]
+		q.onShutdownPendingItems = func(items []PendingItem[string]) {
+			lock.Lock()
+			defer lock.Unlock()
+			pending = append(pending, items...)
+		}
+
+		q.ShutDown()
+		before := time.Now()
+		q.AddWithOpts(AddOpts{}, "ready")
+		q.AddWithOpts(AddOpts{After: time.Minute}, "later")
+		q.AddRateLimited("rate-limited")
+
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(pending).To(HaveLen(2))
+		Expect(pending[0].Key).To(Equal("later"))
+		Expect(pending[0].ReadyAt).To(BeTemporally("~", before.Add(time.Minute), time.Second))
+		Expect(pending[1].Key).To(Equal("rate-limited"))
+		Expect(pending[1].ReadyAt).To(BeTemporally("~", before, time.Second))
+		Expect(q.NumRequeues("rate-limited")).To(Equal(0))
+	})
+
+	It("hands pending items to the shutdown callback with their delay compressed to zero if configured", func() {
+		q, _ := newQueue()
+		q.firePendingItems = true
+
+		var lock sync.Mutex
+		var pending []PendingItem[string]
+		q.onShutdownPendingItems = func(items []PendingItem[string]) {
+			lock.Lock()
+			defer lock.Unlock()
+			pending = append(pending, items...)
+		}
+
+		q.AddWithOpts(AddOpts{}, "ready")
+		q.AddWithOpts(AddOpts{After: time.Hour}, "later")
+
+		before := time.Now()
+		q.ShutDown()
+		q.AddWithOpts(AddOpts{After: time.Minute, Priority: ptr.To(1)}, "after-shutdown")
+
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(pending).To(HaveLen(2))
+		Expect(pending[0].Key).To(Equal("later"))
+		Expect(pending[0].ReadyAt).To(BeTemporally("~", before, time.Second))
+		Expect(pending[1].Key).To(Equal("after-shutdown"))
+		Expect(pending[1].Priority).To(Equal(1))
+		Expect(pending[1].ReadyAt).To(BeTemporally("~", before, time.Second))
+	})
+
+	It("tracks the provenance of the pending instance of an item", func() {
+		q, _ := newQueue()
+		defer q.ShutDown()
+
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceWatch}, "foo")
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceRequeue}, "foo")
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceWatch}, "foo")
+		q.Add("foo")
+
+		item, _, provenance, _ := q.GetWithProvenance()
+		Expect(item).To(Equal("foo"))
+		Expect(provenance).To(Equal([]Provenance{ProvenanceWatch, ProvenanceRequeue, ProvenanceManual}))
+
+		By("Resetting the provenance once the item was handed out")
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceResync}, "foo")
+		q.Done("foo")
+		_, _, provenance, _ = q.GetWithProvenance()
+		Expect(provenance).To(Equal([]Provenance{ProvenanceResync}))
+	})
+
+	It("returns the pending items with their provenance", func() {
+		q, _ := newQueue()
+		defer q.ShutDown()
+
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceWatch, Priority: ptr.To(1)}, "ready")
+		q.AddWithOpts(AddOpts{Provenance: ProvenanceRequeue, After: time.Hour}, "later")
+
+		pending := q.PendingItems()
+		Expect(pending).To(HaveLen(2))
+		Expect(pending[0]).To(Equal(PendingItem[string]{Key: "ready", Priority: 1, Provenance: []Provenance{ProvenanceWatch}}))
+		Expect(pending[1].Key).To(Equal("later"))
+		Expect(pending[1].ReadyAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+		Expect(pending[1].Provenance).To(Equal([]Provenance{ProvenanceRequeue}))
+	})
+
+	It("adds items with the provenance of WithProvenance", func() {
+		q, _ := newQueue()
+		defer q.ShutDown()
+
+		watchQueue := WithProvenance[string](q, ProvenanceWatch)
+		Expect(watchQueue).To(BeAssignableToTypeOf(provenanceQueue[string]{}))
+		watchQueue.Add("foo")
+		watchQueue.(PriorityQueue[string]).AddWithOpts(AddOpts{Provenance: ProvenanceResync}, "foo")
+
+		_, _, provenance, _ := q.GetWithProvenance()
+		Expect(provenance).To(Equal([]Provenance{ProvenanceWatch, ProvenanceResync}))
+
+		plainQueue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+		defer plainQueue.ShutDown()
+		Expect(WithProvenance(plainQueue, ProvenanceWatch)).To(BeIdenticalTo(plainQueue))
+	})
+
+	It("counts the ready items with at least a priority", func() {
+		q, _ := newQueue()
+		defer q.ShutDown()
+
+		q.AddWithOpts(AddOpts{Priority: new(1)}, "high")
+		q.AddWithOpts(AddOpts{}, "default")
+		q.AddWithOpts(AddOpts{Priority: new(-100)}, "low")
+		q.AddWithOpts(AddOpts{After: time.Hour}, "waiting")
+
+		Expect(LenAtPriority[string](q, 1)).To(Equal(1))
+		Expect(LenAtPriority[string](q, 0)).To(Equal(2))
+		Expect(LenAtPriority[string](q, -100)).To(Equal(3))
+		Expect(LenAtPriority(WithProvenance[string](q, ProvenanceWatch), 0)).To(Equal(2))
+
+		plainQueue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+		defer plainQueue.ShutDown()
+		plainQueue.Add("foo")
+		Expect(LenAtPriority(plainQueue, 1)).To(Equal(1))
+	})
+
 	It("returns many items", func() {
 		// This test ensures the queue is able to drain a large queue without panic'ing.
 		// In a previous version of the code we were calling queue.Delete within q.Ascend
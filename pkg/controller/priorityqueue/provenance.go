@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Provenance describes what added an item to the queue.
+type Provenance string
+
+const (
+	// ProvenanceWatch is the provenance of items that are added for a watch event,
+	// including the events for the initial list of an informer.
+	ProvenanceWatch Provenance = "Watch"
+
+	// ProvenanceResync is the provenance of items that are added for an update event
+	// of an unchanged object, i.e. for a resync of an informer.
+	ProvenanceResync Provenance = "Resync"
+
+	// ProvenanceRequeue is the provenance of items that a controller adds again after
+	// their reconciliation, because it failed or it asked to be requeued.
+	ProvenanceRequeue Provenance = "Requeue"
+
+	// ProvenanceManual is the provenance of items that are added without a provenance,
+	// e.g. by a custom source or by calling the queue directly.
+	ProvenanceManual Provenance = "Manual"
+
+	// ProvenanceEnqueueNow is the provenance of items that are added with the maximum
+	// priority on request of a user, e.g. with controller.EnqueueNow.
+	ProvenanceEnqueueNow Provenance = "EnqueueNow"
+)
+
+// ProvenanceQueue is a PriorityQueue that tracks which provenances contributed the
+// pending instance of each item. The provenance of an item is reset when it is handed
+// out, so that it only describes what added the item since it was reconciled last.
+// The queue returned by New implements it.
+type ProvenanceQueue[T comparable] interface {
+	PriorityQueue[T]
+
+	// GetWithProvenance is GetWithPriority that also returns the provenances that
+	// contributed the item, in the order they first added it.
+	GetWithProvenance() (item T, priority int, provenance []Provenance, shutdown bool)
+
+	// PendingItems returns the items that are waiting to become ready or to be handed
+	// out, with their provenances. The ReadyAt of the items that are ready is zero.
+	PendingItems() []PendingItem[T]
+}
+
+var _ ProvenanceQueue[int] = &priorityqueue[int]{}
+
+// WithProvenance returns a queue that adds items with the given provenance, unless they
+// are added with AddOpts that set one. It returns q if q isn't a PriorityQueue, as only
+// those track the provenance of their items.
+func WithProvenance[T comparable](q workqueue.TypedRateLimitingInterface[T], provenance Provenance) workqueue.TypedRateLimitingInterface[T] {
+	priorityQueue, isPriorityQueue := q.(PriorityQueue[T])
+	if !isPriorityQueue {
+		return q
+	}
+	return provenanceQueue[T]{PriorityQueue: priorityQueue, provenance: provenance}
+}
+
+type provenanceQueue[T comparable] struct {
+	PriorityQueue[T]
+	provenance Provenance
+}
+
+func (q provenanceQueue[T]) Add(item T) {
+	q.PriorityQueue.AddWithOpts(AddOpts{Provenance: q.provenance}, item)
+}
+
+func (q provenanceQueue[T]) AddAfter(item T, after time.Duration) {
+	q.PriorityQueue.AddWithOpts(AddOpts{After: after, Provenance: q.provenance}, item)
+}
+
+func (q provenanceQueue[T]) AddRateLimited(item T) {
+	q.PriorityQueue.AddWithOpts(AddOpts{RateLimited: true, Provenance: q.provenance}, item)
+}
+
+func (q provenanceQueue[T]) AddWithOpts(o AddOpts, items ...T) {
+	if o.Provenance == "" {
+		o.Provenance = q.provenance
+	}
+	q.PriorityQueue.AddWithOpts(o, items...)
+}
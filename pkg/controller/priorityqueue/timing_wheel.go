@@ -0,0 +1,230 @@
+package priorityqueue
+
+import (
+	"slices"
+	"time"
+
+	"k8s.io/utils/third_party/forked/golang/btree"
+)
+
+const (
+	// wheelResolution is the duration covered by a slot of the lowest level.
+	wheelResolution = time.Millisecond
+	// wheelBits is the number of bits of a tick that select the slot of a level.
+	wheelBits  = 6
+	wheelSlots = 1 << wheelBits
+	// wheelLevels levels of 64 slots cover 2^48 milliseconds, which is well
+	// beyond any delay an item is added with.
+	wheelLevels = 8
+)
+
+// timingWheel is a hierarchical timing wheel that holds the waiting items of the
+// queue. Unlike a btree, adding and removing an item doesn't depend on the number
+// of waiting items, which matters for controllers that schedule hundreds of thousands
+// of requeues.
+//
+// Time is split into ticks of wheelResolution. Every level has 64 slots, a slot of
+// level n covers 64^n ticks. An item is stored at the lowest level whose slots cover
+// the tick it is ready at, as seen from the cursor, and is moved down the levels as
+// the cursor approaches it. The cursor follows the current time, items that are ready
+// already are all kept in the slot of the cursor.
+//
+// It implements bTree, Ascend visits the items in the order of lessWaiting.
+type timingWheel[T comparable] struct {
+	now func() time.Time
+
+	// cursor is the tick the slots are relative to, it is never behind the ticks of
+	// the items except for the items of the cursor slot, which are ready already.
+	cursor int64
+	levels [wheelLevels][wheelSlots]wheelSlot[T]
+
+	// locations holds the location of every item in the wheel.
+	locations map[*item[T]]wheelLocation
+}
+
+type wheelLocation struct {
+	level int
+	slot  int
+}
+
+type wheelSlot[T comparable] struct {
+	items map[*item[T]]struct{}
+	// first caches the item of the slot that is first in the order of lessWaiting,
+	// so that looking up the next ready item doesn't require a scan of the slot.
+	// It is nil if unknown.
+	first *item[T]
+}
+
+func newTimingWheel[T comparable](now func() time.Time) *timingWheel[T] {
+	return &timingWheel[T]{
+		now:       now,
+		cursor:    tickOf(now()),
+		locations: map[*item[T]]wheelLocation{},
+	}
+}
+
+func tickOf(t time.Time) int64 {
+	return t.UnixNano() / int64(wheelResolution)
+}
+
+// digit returns the slot that tick falls into at the given level.
+func digit(tick int64, level int) int {
+	return int(tick>>(wheelBits*level)) & (wheelSlots - 1)
+}
+
+// locate returns the location of an item that is ready at the given tick.
+func (w *timingWheel[T]) locate(tick int64) wheelLocation {
+	tick = max(tick, w.cursor)
+	for level := range wheelLevels {
+		shift := wheelBits * (level + 1)
+		if tick>>shift == w.cursor>>shift {
+			return wheelLocation{level: level, slot: digit(tick, level)}
+		}
+	}
+	return wheelLocation{level: wheelLevels - 1, slot: wheelSlots - 1}
+}
+
+func (w *timingWheel[T]) ReplaceOrInsert(it *item[T]) (*item[T], bool) {
+	if _, exists := w.locations[it]; exists {
+		return it, true
+	}
+	w.insert(it)
+	return nil, false
+}
+
+func (w *timingWheel[T]) insert(it *item[T]) {
+	loc := w.locate(tickOf(*it.ReadyAt))
+	slot := &w.levels[loc.level][loc.slot]
+	if slot.items == nil {
+		slot.items = map[*item[T]]struct{}{}
+	}
+	if len(slot.items) == 0 || (slot.first != nil && lessWaiting(it, slot.first)) {
+		slot.first = it
+	}
+	slot.items[it] = struct{}{}
+	w.locations[it] = loc
+}
+
+func (w *timingWheel[T]) Delete(it *item[T]) (*item[T], bool) {
+	loc, exists := w.locations[it]
+	if !exists {
+		return nil, false
+	}
+	delete(w.locations, it)
+	slot := &w.levels[loc.level][loc.slot]
+	delete(slot.items, it)
+	if slot.first == it {
+		slot.first = nil
+	}
+	return it, true
+}
+
+func (w *timingWheel[T]) Len() int {
+	return len(w.locations)
+}
+
+// Ascend calls the iterator for the items in the order of lessWaiting,
+// until it returns false.
+func (w *timingWheel[T]) Ascend(iterator btree.ItemIterator[*item[T]]) {
+	w.advance(tickOf(w.now()))
+
+	for level := range wheelLevels {
+		// The slot of the cursor is empty on all levels but the lowest one,
+		// as its items are stored on the lower levels.
+		for slot := digit(w.cursor, level); slot < wheelSlots; slot++ {
+			if !w.levels[level][slot].ascend(iterator) {
+				return
+			}
+		}
+	}
+}
+
+// ascend calls the iterator for the items of the slot in the order of lessWaiting.
+// The first item is handed out before the slot is sorted, as the iterator usually
+// stops at the first item that isn't ready yet.
+func (s *wheelSlot[T]) ascend(iterator btree.ItemIterator[*item[T]]) bool {
+	if len(s.items) == 0 {
+		return true
+	}
+	if s.first == nil {
+		for it := range s.items {
+			if s.first == nil || lessWaiting(it, s.first) {
+				s.first = it
+			}
+		}
+	}
+	if !iterator(s.first) {
+		return false
+	}
+
+	items := make([]*item[T], 0, len(s.items))
+	for it := range s.items {
+		items = append(items, it)
+	}
+	slices.SortFunc(items, func(a, b *item[T]) int {
+		switch {
+		case lessWaiting(a, b):
+			return -1
+		case lessWaiting(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	for _, it := range items {
+		if it == s.first {
+			continue
+		}
+		if !iterator(it) {
+			return false
+		}
+	}
+	return true
+}
+
+// advance moves the cursor to the given tick. The items that are ready by then
+// are moved to the slot of the new cursor, the items of the slot the new cursor
+// enters on higher levels are moved down to the lower levels.
+func (w *timingWheel[T]) advance(target int64) {
+	if target <= w.cursor {
+		return
+	}
+
+	// Find the highest level on which the slot of the cursor changes.
+	highest := wheelLevels - 1
+	for level := range wheelLevels {
+		if target>>(wheelBits*(level+1)) == w.cursor>>(wheelBits*(level+1)) {
+			highest = level
+			break
+		}
+	}
+
+	// The items of the lower levels and of the slots on the highest level that the
+	// cursor skips are ready, the items of the slot the cursor enters are moved down.
+	var moved []*item[T]
+	for level := range highest {
+		for slot := range wheelSlots {
+			moved = w.takeSlot(level, slot, moved)
+		}
+	}
+	for slot := digit(w.cursor, highest); slot <= digit(target, highest); slot++ {
+		moved = w.takeSlot(highest, slot, moved)
+	}
+
+	w.cursor = target
+	for _, it := range moved {
+		w.insert(it)
+	}
+}
+
+// takeSlot removes all items from the slot and appends them to items.
+func (w *timingWheel[T]) takeSlot(level, slot int, items []*item[T]) []*item[T] {
+	s := &w.levels[level][slot]
+	for it := range s.items {
+		items = append(items, it)
+		delete(w.locations, it)
+	}
+	clear(s.items)
+	s.first = nil
+	return items
+}
@@ -0,0 +1,101 @@
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/third_party/forked/golang/btree"
+)
+
+// TestTimingWheelOrdersLikeBTree validates that the timing wheel visits the
+// items in the same order as a btree, while the current time advances and
+// items are added and removed.
+func TestTimingWheelOrdersLikeBTree(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %d", seed)
+	r := rand.New(rand.NewPCG(uint64(seed), 0))
+
+	now := time.Now()
+	wheel := newTimingWheel[int](func() time.Time { return now })
+	tree := btree.New(32, lessWaiting[int])
+	var items []*item[int]
+
+	for round := range 200 {
+		for range r.IntN(50) {
+			// Mix items that are ready already with items that are ready in
+			// anything from milliseconds to days.
+			readyAt := now.Add(time.Duration(r.Int64N(int64(time.Second))) - 100*time.Millisecond)
+			if r.IntN(2) == 0 {
+				readyAt = now.Add(time.Duration(r.Int64N(int64(72 * time.Hour))))
+			}
+			it := &item[int]{Key: len(items), AddedCounter: uint64(len(items)), Priority: r.IntN(3), ReadyAt: &readyAt}
+			items = append(items, it)
+			_, existed := wheel.ReplaceOrInsert(it)
+			g.Expect(existed).To(BeFalse())
+			tree.ReplaceOrInsert(it)
+		}
+		for range r.IntN(10) {
+			if tree.Len() == 0 {
+				break
+			}
+			it := items[r.IntN(len(items))]
+			_, inTree := tree.Delete(it)
+			_, inWheel := wheel.Delete(it)
+			g.Expect(inWheel).To(Equal(inTree))
+		}
+
+		now = now.Add(time.Duration(r.Int64N(int64(time.Duration(round+1) * time.Minute))))
+
+		var want, got []int
+		tree.Ascend(func(it *item[int]) bool {
+			want = append(want, it.Key)
+			return true
+		})
+		wheel.Ascend(func(it *item[int]) bool {
+			got = append(got, it.Key)
+			return true
+		})
+		g.Expect(got).To(Equal(want))
+		g.Expect(wheel.Len()).To(Equal(tree.Len()))
+	}
+}
+
+func TestTimingWheelAscendStopsEarly(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	now := time.Now()
+	wheel := newTimingWheel[int](func() time.Time { return now })
+	for i := range 10 {
+		readyAt := now.Add(time.Duration(10-i) * time.Hour)
+		wheel.ReplaceOrInsert(&item[int]{Key: i, AddedCounter: uint64(i), ReadyAt: &readyAt})
+	}
+
+	var visited []int
+	wheel.Ascend(func(it *item[int]) bool {
+		visited = append(visited, it.Key)
+		return len(visited) < 3
+	})
+	g.Expect(visited).To(Equal([]int{9, 8, 7}))
+}
+
+func BenchmarkAddAfterLarge(b *testing.B) {
+	q := New[int]("")
+	defer q.ShutDown()
+
+	const items = 100000
+	for i := range items {
+		q.AddAfter(i, time.Duration(i%3600)*time.Second+time.Hour)
+	}
+
+	var i int
+	for b.Loop() {
+		q.AddAfter(items+i%items, time.Duration(i%3600)*time.Second+time.Hour)
+		i++
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package dependency lets reconcilers register objects they depend on but that their
+controller doesn't statically watch, e.g. a Secret referenced by name from the spec
+of the reconciled object, so that they are reconciled again when these objects change.
+
+A Tracker is added to a controller as a source, e.g. through
+builder.WatchesRawSource. Reconcilers then call Tracker.Track with the request they
+are reconciling and the objects it depends on. The Tracker watches each
+GroupVersionKind only as long as at least one dependency of it is tracked, and
+enqueues all requests that depend on an object when that object changes.
+
+Reconcilers are expected to call Tracker.Untrack once they don't depend on an object
+anymore and Tracker.Forget once the reconciled object is gone, so that watches that
+are no longer needed are removed.
+*/
+package dependency
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("dependency")
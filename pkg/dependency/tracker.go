@@ -0,0 +1,434 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ErrNotStarted is returned when dependencies are tracked before the Tracker was
+// started by its controller, or after the controller was stopped.
+var ErrNotStarted = errors.New("dependency tracker is not started")
+
+// Options are the arguments for creating a new Tracker.
+type Options struct {
+	// Scheme is used to look up the GroupVersionKind of the tracked objects.
+	// Defaults to the Kubernetes client-go scheme.Scheme.
+	Scheme *runtime.Scheme
+
+	// RemoveUnusedInformers makes the Tracker remove the informer of a GroupVersionKind
+	// from the cache once no dependency of it is tracked anymore. By default, only the
+	// event handler of the Tracker is removed and the informer keeps running.
+	//
+	// Only set this if nothing else uses the informers of the tracked types, e.g. the
+	// client of the manager when reading these objects.
+	RemoveUnusedInformers bool
+}
+
+// Tracker is a source.Source that enqueues requests when objects they depend on
+// change. The dependencies are registered at runtime by the reconciler, see Track.
+type Tracker struct {
+	cache                 cache.Cache
+	scheme                *runtime.Scheme
+	removeUnusedInformers bool
+
+	// mu guards run and serializes changes to the watches. It isn't acquired by
+	// event handlers and isn't held while waiting for an informer, so that neither
+	// events nor the tracking of other dependencies are blocked by it.
+	mu  sync.Mutex
+	run *trackerRun
+}
+
+// trackerRun is the state of the Tracker between Start and the cancellation of
+// the context it was started with.
+type trackerRun struct {
+	queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+
+	// watches holds the watch of each GroupVersionKind that has tracked dependencies.
+	watches map[schema.GroupVersionKind]*watch
+
+	// lock guards dependents and dependencies, which are read by event handlers.
+	lock         sync.RWMutex
+	dependents   map[objectKey]sets.Set[reconcile.Request]
+	dependencies map[reconcile.Request]sets.Set[objectKey]
+}
+
+// objectKey identifies a dependency.
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	types.NamespacedName
+}
+
+// watch is the event handler registered with the informer of a GroupVersionKind,
+// reference counted by the number of tracked dependencies of that GroupVersionKind.
+// All fields but run, gvk, obj and synced are guarded by Tracker.mu.
+type watch struct {
+	run  *trackerRun
+	gvk  schema.GroupVersionKind
+	obj  client.Object
+	refs int
+
+	// pending is true while the informer is being fetched by the Track call that
+	// created the watch. synced is closed once that is done, err is set if it failed.
+	pending bool
+	synced  chan struct{}
+	err     error
+
+	informer     cache.Informer
+	registration toolscache.ResourceEventHandlerRegistration
+}
+
+// New returns a new Tracker that watches dependencies through the given cache.
+func New(c cache.Cache, opts Options) *Tracker {
+	if opts.Scheme == nil {
+		opts.Scheme = scheme.Scheme
+	}
+	return &Tracker{
+		cache:                 c,
+		scheme:                opts.Scheme,
+		removeUnusedInformers: opts.RemoveUnusedInformers,
+	}
+}
+
+var _ source.Source = &Tracker{}
+
+func (t *Tracker) String() string {
+	return "dependency tracker"
+}
+
+// Start implements source.Source. Dependencies tracked by a previous run are
+// dropped once its context is cancelled, the reconciler is expected to track
+// them again when reconciling after a restart.
+func (t *Tracker) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.run != nil {
+		return errors.New("dependency tracker is already started")
+	}
+
+	run := &trackerRun{
+		queue:        queue,
+		watches:      map[schema.GroupVersionKind]*watch{},
+		dependents:   map[objectKey]sets.Set[reconcile.Request]{},
+		dependencies: map[reconcile.Request]sets.Set[objectKey]{},
+	}
+	t.run = run
+	go func() {
+		<-ctx.Done()
+		t.stop(run)
+	}()
+	return nil
+}
+
+// stop removes all watches of the run and drops its dependencies.
+func (t *Tracker) stop(run *trackerRun) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.run != run {
+		return
+	}
+	t.run = nil
+	for _, w := range run.watches {
+		t.removeWatch(context.Background(), w)
+	}
+}
+
+// Track registers that req depends on the given objects, so that it is enqueued
+// whenever one of them is created, updated or deleted. Only the name, namespace
+// and type of the objects are used. The first object of a GroupVersionKind also
+// determines whether it is watched as typed, unstructured or metadata-only object.
+//
+// Track blocks until the informer for a GroupVersionKind that wasn't watched yet
+// is synced. Changes made before Track returned might not be observed, so the
+// reconciler should call it before reading the objects.
+func (t *Tracker) Track(ctx context.Context, req reconcile.Request, objs ...client.Object) error {
+	for _, obj := range objs {
+		key, err := t.objectKeyFor(obj)
+		if err != nil {
+			return err
+		}
+		if err := t.track(ctx, req, key, obj); err != nil {
+			return fmt.Errorf("failed to watch dependency %s %s: %w", key.gvk.Kind, key.NamespacedName, err)
+		}
+	}
+	return nil
+}
+
+// track adds the dependency of req on key and waits until its watch is synced.
+// The informer is fetched without holding mu, so that tracking dependencies of
+// other requests isn't blocked until it is synced.
+func (t *Tracker) track(ctx context.Context, req reconcile.Request, key objectKey, obj client.Object) error {
+	for {
+		t.mu.Lock()
+		run := t.run
+		if run == nil {
+			t.mu.Unlock()
+			return ErrNotStarted
+		}
+		if !run.add(req, key) {
+			t.mu.Unlock()
+			return nil
+		}
+		w, exists := run.watches[key.gvk]
+		if !exists {
+			w = &watch{run: run, gvk: key.gvk, obj: obj.DeepCopyObject().(client.Object), pending: true, synced: make(chan struct{})}
+			run.watches[key.gvk] = w
+		}
+		w.refs++
+		t.mu.Unlock()
+
+		if !exists {
+			t.startWatch(ctx, w)
+		}
+
+		var err error
+		select {
+		case <-w.synced:
+			err = w.err
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err == nil {
+			return nil
+		}
+
+		t.mu.Lock()
+		if t.run == run && run.remove(req, key) {
+			t.removeRef(ctx, key.gvk)
+		}
+		t.mu.Unlock()
+
+		// The informer was fetched with the context of another request, which was
+		// cancelled. Try again with ours.
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			if ctx.Err() == nil {
+				continue
+			}
+		}
+		return err
+	}
+}
+
+// startWatch fetches the informer of the pending watch and registers the watch
+// with it. The watch is removed again if it was removed from its run meanwhile.
+func (t *Tracker) startWatch(ctx context.Context, w *watch) {
+	informer, err := t.cache.GetInformer(ctx, w.obj)
+	var registration toolscache.ResourceEventHandlerRegistration
+	if err == nil {
+		registration, err = informer.AddEventHandler(w)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer close(w.synced)
+	w.pending = false
+	w.informer, w.registration, w.err = informer, registration, err
+	if err != nil {
+		return
+	}
+	if t.run != w.run || w.run.watches[w.gvk] != w {
+		t.removeWatch(ctx, w)
+		return
+	}
+	log.V(1).Info("Started watching dependencies", "gvk", w.gvk)
+}
+
+// Untrack removes the dependencies of req on the given objects. Watches that are
+// not needed anymore are removed.
+func (t *Tracker) Untrack(ctx context.Context, req reconcile.Request, objs ...client.Object) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.run == nil {
+		return nil
+	}
+
+	for _, obj := range objs {
+		key, err := t.objectKeyFor(obj)
+		if err != nil {
+			return err
+		}
+		if t.run.remove(req, key) {
+			t.removeRef(ctx, key.gvk)
+		}
+	}
+	return nil
+}
+
+// Forget removes all dependencies of req, e.g. once the reconciled object was
+// deleted. Watches that are not needed anymore are removed.
+func (t *Tracker) Forget(ctx context.Context, req reconcile.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.run == nil {
+		return
+	}
+
+	t.run.lock.RLock()
+	keys := t.run.dependencies[req].UnsortedList()
+	t.run.lock.RUnlock()
+	for _, key := range keys {
+		if t.run.remove(req, key) {
+			t.removeRef(ctx, key.gvk)
+		}
+	}
+}
+
+// Dependencies returns the number of objects req depends on.
+func (t *Tracker) Dependencies(req reconcile.Request) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.run == nil {
+		return 0
+	}
+
+	t.run.lock.RLock()
+	defer t.run.lock.RUnlock()
+	return t.run.dependencies[req].Len()
+}
+
+func (t *Tracker) objectKeyFor(obj client.Object) (objectKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, t.scheme)
+	if err != nil {
+		return objectKey{}, err
+	}
+	return objectKey{gvk: gvk, NamespacedName: client.ObjectKeyFromObject(obj)}, nil
+}
+
+// removeRef removes a reference to the watch of the GroupVersionKind, removing the
+// watch once it isn't referenced anymore. It must be called with mu held.
+func (t *Tracker) removeRef(ctx context.Context, gvk schema.GroupVersionKind) {
+	w, ok := t.run.watches[gvk]
+	if !ok {
+		return
+	}
+	w.refs--
+	if w.refs > 0 {
+		return
+	}
+	delete(t.run.watches, gvk)
+	t.removeWatch(ctx, w)
+}
+
+// removeWatch removes the event handler of the watch. Pending watches are removed
+// by startWatch once their informer was fetched. It must be called with mu held.
+func (t *Tracker) removeWatch(ctx context.Context, w *watch) {
+	if w.pending || w.err != nil {
+		return
+	}
+	if err := w.informer.RemoveEventHandler(w.registration); err != nil {
+		log.Error(err, "Failed to remove event handler", "gvk", w.gvk)
+	}
+	if t.removeUnusedInformers {
+		if err := t.cache.RemoveInformer(ctx, w.obj); err != nil {
+			log.Error(err, "Failed to remove informer", "gvk", w.gvk)
+		}
+	}
+	log.V(1).Info("Stopped watching dependencies", "gvk", w.gvk)
+}
+
+// add records that req depends on key and returns whether it didn't before.
+func (r *trackerRun) add(req reconcile.Request, key objectKey) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.dependencies[req].Has(key) {
+		return false
+	}
+	if r.dependencies[req] == nil {
+		r.dependencies[req] = sets.New[objectKey]()
+	}
+	r.dependencies[req].Insert(key)
+	if r.dependents[key] == nil {
+		r.dependents[key] = sets.New[reconcile.Request]()
+	}
+	r.dependents[key].Insert(req)
+	return true
+}
+
+// remove removes the dependency of req on key and returns whether it existed.
+func (r *trackerRun) remove(req reconcile.Request, key objectKey) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.dependencies[req].Has(key) {
+		return false
+	}
+	r.dependencies[req].Delete(key)
+	if r.dependencies[req].Len() == 0 {
+		delete(r.dependencies, req)
+	}
+	r.dependents[key].Delete(req)
+	if r.dependents[key].Len() == 0 {
+		delete(r.dependents, key)
+	}
+	return true
+}
+
+// OnAdd implements toolscache.ResourceEventHandler. Objects of the initial list are
+// ignored, the requests depending on them are being reconciled already.
+func (w *watch) OnAdd(obj any, isInInitialList bool) {
+	if !isInInitialList {
+		w.enqueueDependents(obj)
+	}
+}
+
+// OnUpdate implements toolscache.ResourceEventHandler.
+func (w *watch) OnUpdate(_, newObj any) {
+	w.enqueueDependents(newObj)
+}
+
+// OnDelete implements toolscache.ResourceEventHandler.
+func (w *watch) OnDelete(obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	w.enqueueDependents(obj)
+}
+
+func (w *watch) enqueueDependents(obj any) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		log.Error(err, "Failed to get object metadata", "gvk", w.gvk)
+		return
+	}
+	key := objectKey{gvk: w.gvk, NamespacedName: types.NamespacedName{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}}
+
+	w.run.lock.RLock()
+	requests := w.run.dependents[key].UnsortedList()
+	w.run.lock.RUnlock()
+	for _, req := range requests {
+		w.run.queue.Add(req)
+	}
+}
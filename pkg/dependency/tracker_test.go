@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Tracker", func() {
+	var (
+		informers *informertest.FakeInformers
+		queue     workqueue.TypedRateLimitingInterface[reconcile.Request]
+	)
+
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+	}
+
+	BeforeEach(func() {
+		informers = &informertest.FakeInformers{}
+		queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(queue.ShutDown)
+	})
+
+	It("should refuse to track dependencies before it was started", func(ctx SpecContext) {
+		tracker := New(informers, Options{})
+		Expect(tracker.Track(ctx, request("a"), secret("s"))).To(MatchError(ErrNotStarted))
+	})
+
+	It("should enqueue the requests depending on an object when it changes", func(ctx SpecContext) {
+		tracker := New(informers, Options{})
+		Expect(tracker.Start(ctx, queue)).To(Succeed())
+
+		Expect(tracker.Track(ctx, request("a"), secret("shared"), secret("only-a"))).To(Succeed())
+		Expect(tracker.Track(ctx, request("b"), secret("shared"))).To(Succeed())
+		Expect(tracker.Dependencies(request("a"))).To(Equal(2))
+
+		informer, err := informers.FakeInformerFor(ctx, &corev1.Secret{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(informer.HandlerCount()).To(Equal(1))
+
+		informer.Update(secret("shared"), secret("shared"))
+		Expect(queue.Len()).To(Equal(2))
+		for range 2 {
+			req, _ := queue.Get()
+			Expect(req).To(BeElementOf(request("a"), request("b")))
+			queue.Done(req)
+		}
+
+		informer.Delete(secret("only-a"))
+		Expect(queue.Len()).To(Equal(1))
+		req, _ := queue.Get()
+		Expect(req).To(Equal(request("a")))
+		queue.Done(req)
+
+		informer.Add(secret("unrelated"))
+		Expect(queue.Len()).To(Equal(0))
+	})
+
+	It("should not block tracking other kinds while waiting for an informer", func(ctx SpecContext) {
+		unblock := make(chan struct{})
+		tracker := New(&blockingInformers{FakeInformers: informers, unblock: unblock}, Options{})
+		Expect(tracker.Start(ctx, queue)).To(Succeed())
+
+		done := make(chan error)
+		go func() {
+			done <- tracker.Track(ctx, request("a"), secret("s"))
+		}()
+		Eventually(func() int { return tracker.Dependencies(request("a")) }).Should(Equal(1))
+
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}}
+		Expect(tracker.Track(ctx, request("b"), cm)).To(Succeed())
+		Consistently(done).ShouldNot(Receive())
+
+		close(unblock)
+		Eventually(done).Should(Receive(Succeed()))
+		informer, err := informers.FakeInformerFor(ctx, &corev1.Secret{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(informer.HandlerCount()).To(Equal(1))
+	})
+
+	It("should remove the watch once no dependency of its kind is tracked", func(ctx SpecContext) {
+		tracker := New(informers, Options{RemoveUnusedInformers: true})
+		Expect(tracker.Start(ctx, queue)).To(Succeed())
+
+		Expect(tracker.Track(ctx, request("a"), secret("s"))).To(Succeed())
+		Expect(tracker.Track(ctx, request("b"), secret("s"))).To(Succeed())
+		informer, err := informers.FakeInformerFor(ctx, &corev1.Secret{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tracker.Untrack(ctx, request("a"), secret("s"))).To(Succeed())
+		Expect(informer.HandlerCount()).To(Equal(1))
+		Expect(informers.InformersByGVK).To(HaveLen(1))
+
+		tracker.Forget(ctx, request("b"))
+		Expect(tracker.Dependencies(request("b"))).To(Equal(0))
+		Expect(informer.HandlerCount()).To(Equal(0))
+		Expect(informers.InformersByGVK).To(BeEmpty())
+	})
+
+	It("should drop all dependencies when stopped and allow to be started again", func(specCtx SpecContext) {
+		tracker := New(informers, Options{})
+		ctx, cancel := context.WithCancel(specCtx)
+		Expect(tracker.Start(ctx, queue)).To(Succeed())
+		Expect(tracker.Track(ctx, request("a"), secret("s"))).To(Succeed())
+		informer, err := informers.FakeInformerFor(ctx, &corev1.Secret{})
+		Expect(err).NotTo(HaveOccurred())
+
+		cancel()
+		Eventually(informer.HandlerCount).Should(Equal(0))
+		Expect(tracker.Track(specCtx, request("a"), secret("s"))).To(MatchError(ErrNotStarted))
+
+		Expect(tracker.Start(specCtx, queue)).To(Succeed())
+		Expect(tracker.Dependencies(request("a"))).To(Equal(0))
+		Expect(tracker.Track(specCtx, request("a"), secret("s"))).To(Succeed())
+		Expect(informer.HandlerCount()).To(Equal(1))
+	})
+})
+
+// blockingInformers blocks getting the informer of Secrets until unblock is closed.
+type blockingInformers struct {
+	*informertest.FakeInformers
+	unblock chan struct{}
+}
+
+func (b *blockingInformers) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	if _, ok := obj.(*corev1.Secret); ok {
+		select {
+		case <-b.unblock:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return b.FakeInformers.GetInformer(ctx, obj, opts...)
+}
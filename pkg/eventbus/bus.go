@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/internal/syncs"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// MaxPendingEvents is the number of published events that are queued for a
+// subscriber that didn't consume them yet. Once it is reached, the oldest pending
+// event of the subscriber is dropped for every newly published one.
+const MaxPendingEvents = 1000
+
+// Bus delivers the objects published to a topic to all subscribers of the topic.
+// It is safe for concurrent use.
+type Bus struct {
+	mu            sync.Mutex
+	subscriptions map[string][]*subscription
+}
+
+// New returns a new Bus.
+func New() *Bus {
+	return &Bus{subscriptions: map[string][]*subscription{}}
+}
+
+// Publish delivers obj to all current subscribers of topic. It never blocks, the
+// event is queued for subscribers that didn't consume their previous events yet,
+// up to MaxPendingEvents per subscriber.
+func (b *Bus) Publish(topic string, obj client.Object) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, s := range b.subscriptions[topic] {
+		s.queue.Push(event.GenericEvent{Object: obj})
+	}
+}
+
+// Subscribe returns a channel that receives the objects published to topic in order,
+// until the returned function is called, which closes the channel. Objects that were
+// published before Subscribe was called are not delivered.
+func (b *Bus) Subscribe(topic string) (<-chan event.GenericEvent, func()) {
+	s := &subscription{
+		ch:   make(chan event.GenericEvent),
+		done: make(chan struct{}),
+	}
+	s.queue = syncs.NewQueue(s.deliver, MaxPendingEvents)
+	b.mu.Lock()
+	b.subscriptions[topic] = append(b.subscriptions[topic], s)
+	b.mu.Unlock()
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.subscriptions[topic] = slices.DeleteFunc(b.subscriptions[topic], func(other *subscription) bool {
+				return other == s
+			})
+			if len(b.subscriptions[topic]) == 0 {
+				delete(b.subscriptions, topic)
+			}
+			b.mu.Unlock()
+			s.stop()
+		})
+	}
+}
+
+// Source returns a source that passes the objects published to topic to the handler,
+// bridging the bus into a source.Channel. Every start of the source subscribes to the
+// topic until the context it was started with is cancelled.
+func (b *Bus) Source(topic string, h handler.EventHandler, opts ...source.ChannelOpt[client.Object, reconcile.Request]) source.Source {
+	return &busSource{bus: b, topic: topic, handler: h, opts: opts}
+}
+
+type busSource struct {
+	bus     *Bus
+	topic   string
+	handler handler.EventHandler
+	opts    []source.ChannelOpt[client.Object, reconcile.Request]
+}
+
+func (s *busSource) String() string {
+	return fmt.Sprintf("event bus source: %s", s.topic)
+}
+
+// Start implements source.Source.
+func (s *busSource) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	ch, unsubscribe := s.bus.Subscribe(s.topic)
+	if err := source.Channel(ch, s.handler, s.opts...).Start(ctx, queue); err != nil {
+		unsubscribe()
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	return nil
+}
+
+// subscription queues the events of a subscriber and delivers them to its channel
+// from the goroutine of its queue.
+type subscription struct {
+	ch    chan event.GenericEvent
+	done  chan struct{}
+	queue *syncs.Queue[event.GenericEvent]
+}
+
+func (s *subscription) deliver(evt event.GenericEvent) {
+	select {
+	case <-s.done:
+	case s.ch <- evt:
+	}
+}
+
+// stop stops the queue, unblocking a pending delivery, and closes the channel once
+// the queue doesn't deliver anymore.
+func (s *subscription) stop() {
+	close(s.done)
+	s.queue.Stop()
+	<-s.queue.Done()
+	close(s.ch)
+}
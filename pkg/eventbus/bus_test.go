@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventbus
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Bus", func() {
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	It("should deliver the published objects in order to all subscribers of the topic", func() {
+		bus := New()
+		first, unsubscribeFirst := bus.Subscribe("topic")
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe("topic")
+		defer unsubscribeSecond()
+		other, unsubscribeOther := bus.Subscribe("other")
+		defer unsubscribeOther()
+
+		// Publishing doesn't block although nobody consumes the events yet.
+		for _, name := range []string{"a", "b", "c"} {
+			bus.Publish("topic", newConfigMap(name))
+		}
+
+		for _, ch := range []<-chan event.GenericEvent{first, second} {
+			for _, name := range []string{"a", "b", "c"} {
+				var evt event.GenericEvent
+				Eventually(ch).Should(Receive(&evt))
+				Expect(evt.Object.GetName()).To(Equal(name))
+			}
+		}
+		Consistently(other).ShouldNot(Receive())
+	})
+
+	It("should not deliver objects that were published before subscribing", func() {
+		bus := New()
+		bus.Publish("topic", newConfigMap("before"))
+
+		ch, unsubscribe := bus.Subscribe("topic")
+		defer unsubscribe()
+		bus.Publish("topic", newConfigMap("after"))
+
+		var evt event.GenericEvent
+		Eventually(ch).Should(Receive(&evt))
+		Expect(evt.Object.GetName()).To(Equal("after"))
+	})
+
+	It("should close the channel once unsubscribed", func() {
+		bus := New()
+		ch, unsubscribe := bus.Subscribe("topic")
+		bus.Publish("topic", newConfigMap("dropped"))
+		unsubscribe()
+		unsubscribe()
+
+		Eventually(func() bool {
+			_, open := <-ch
+			return open
+		}).Should(BeFalse())
+		bus.Publish("topic", newConfigMap("ignored"))
+		Expect(bus.subscriptions).To(BeEmpty())
+	})
+
+	It("should enqueue requests for the published objects through a source", func(specCtx SpecContext) {
+		bus := New()
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+
+		ctx, cancel := context.WithCancel(specCtx)
+		src := bus.Source("topic", &handler.EnqueueRequestForObject{})
+		Expect(src.Start(ctx, q)).To(Succeed())
+
+		bus.Publish("topic", newConfigMap("foo"))
+		Eventually(q.Len).Should(Equal(1))
+		req, _ := q.Get()
+		Expect(req).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}))
+		q.Done(req)
+
+		cancel()
+		Eventually(func() int {
+			bus.mu.Lock()
+			defer bus.mu.Unlock()
+			return len(bus.subscriptions["topic"])
+		}).Should(BeZero())
+	})
+})
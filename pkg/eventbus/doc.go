@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package eventbus provides a lightweight in-process publish/subscribe bus that
+controllers use to signal each other, e.g. to reconcile the objects of another
+controller once a shared resource changed.
+
+Objects are published to a topic and delivered to all subscribers of that topic.
+Controllers subscribe through Bus.Source, which triggers a reconcile for every
+published object:
+
+	bus := mgr.(manager.EventBusProvider).GetEventBus()
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&v1.Foo{}).
+		WatchesRawSource(bus.Source("foo-refresh", &handler.EnqueueRequestForObject{})).
+		Complete(r)
+
+	// In another reconciler:
+	bus.Publish("foo-refresh", foo)
+
+Publishing never blocks: events are queued per subscriber until they are consumed,
+so that publishers are not held up by controllers that are not started yet, e.g.
+because they wait for leader election. Up to MaxPendingEvents events are queued per
+subscriber, after that the oldest pending event is dropped for every published one.
+*/
+package eventbus
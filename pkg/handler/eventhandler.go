@@ -189,6 +189,36 @@ func WithLowPriorityWhenUnchanged[object client.Object, request comparable](u Ty
 	}
 }
 
+// HighPriority is the priority set by WithHighPriorityWhenReconcileRequested
+const HighPriority = 100
+
+// WithHighPriorityWhenReconcileRequested raises the priority of update events in which the
+// reconcile.RequestedAtAnnotation of the object was set or changed, so that a reconciliation that a
+// user requested isn't queued behind the backlog of the controller. It does nothing if no
+// priorityqueue.PriorityQueue is used. See predicate.ReconcileRequestedPredicate to filter for
+// these events.
+func WithHighPriorityWhenReconcileRequested[object client.Object, request comparable](u TypedEventHandler[object, request]) TypedEventHandler[object, request] {
+	return TypedFuncs[object, request]{
+		CreateFunc:  u.Create,
+		DeleteFunc:  u.Delete,
+		GenericFunc: u.Generic,
+		UpdateFunc: func(ctx context.Context, evt event.TypedUpdateEvent[object], q workqueue.TypedRateLimitingInterface[request]) {
+			if priorityQueue, isPriorityQueue := q.(priorityqueue.PriorityQueue[request]); isPriorityQueue && reconcileRequested(evt) {
+				q = workqueueWithDefaultPriority[request]{PriorityQueue: priorityQueue, priority: new(HighPriority)}
+			}
+			u.Update(ctx, evt, q)
+		},
+	}
+}
+
+func reconcileRequested[object client.Object](evt event.TypedUpdateEvent[object]) bool {
+	if isNil(evt.ObjectOld) || isNil(evt.ObjectNew) {
+		return false
+	}
+	requestedAt := evt.ObjectNew.GetAnnotations()[reconcile.RequestedAtAnnotation]
+	return requestedAt != "" && requestedAt != evt.ObjectOld.GetAnnotations()[reconcile.RequestedAtAnnotation]
+}
+
 type workqueueWithDefaultPriority[request comparable] struct {
 	priorityqueue.PriorityQueue[request]
 	priority *int
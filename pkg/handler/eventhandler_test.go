@@ -1119,6 +1119,51 @@ var _ = Describe("Eventhandler", func() {
 			})
 		}
 	})
+
+	Describe("WithHighPriorityWhenReconcileRequested", func() {
+		podWithRequest := func(rv, requestedAt string) *corev1.Pod {
+			p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "my-pod", ResourceVersion: rv}}
+			if requestedAt != "" {
+				p.Annotations = map[string]string{reconcile.RequestedAtAnnotation: requestedAt}
+			}
+			return p
+		}
+
+		DescribeTable("should set the priority of update requests",
+			func(ctx SpecContext, oldObj, newObj *corev1.Pod, expectedPriority *int) {
+				actualOpts := priorityqueue.AddOpts{}
+				var actualRequests []reconcile.Request
+				wq := &fakePriorityQueue{
+					addWithOpts: func(o priorityqueue.AddOpts, items ...reconcile.Request) {
+						actualOpts = o
+						actualRequests = items
+					},
+				}
+
+				handler.WithHighPriorityWhenReconcileRequested(handler.EventHandler(&handler.EnqueueRequestForObject{})).Update(ctx, event.UpdateEvent{
+					ObjectOld: oldObj,
+					ObjectNew: newObj,
+				}, wq)
+
+				Expect(actualOpts).To(Equal(priorityqueue.AddOpts{Priority: expectedPriority}))
+				Expect(actualRequests).To(Equal([]reconcile.Request{{NamespacedName: types.NamespacedName{Name: "my-pod"}}}))
+			},
+			Entry("when the annotation is set", podWithRequest("1", ""), podWithRequest("2", "now"), new(handler.HighPriority)),
+			Entry("when the annotation is changed", podWithRequest("1", "now"), podWithRequest("2", "later"), new(handler.HighPriority)),
+			Entry("not when the annotation is unchanged", podWithRequest("1", "now"), podWithRequest("2", "now"), nil),
+			Entry("not when the annotation is removed", podWithRequest("1", "now"), podWithRequest("2", ""), nil),
+			Entry("not on resyncs", podWithRequest("1", "now"), podWithRequest("1", "now"), new(handler.LowPriority)),
+		)
+
+		It("should not change the requests if no priority queue is used", func(ctx SpecContext) {
+			q := &controllertest.Queue{TypedInterface: workqueue.NewTyped[reconcile.Request]()}
+			handler.WithHighPriorityWhenReconcileRequested(handler.EventHandler(&handler.EnqueueRequestForObject{})).Update(ctx, event.UpdateEvent{
+				ObjectOld: podWithRequest("1", ""),
+				ObjectNew: podWithRequest("2", "now"),
+			}, q)
+			Expect(q.Len()).To(Equal(1))
+		})
+	})
 })
 
 type fakePriorityQueue struct {
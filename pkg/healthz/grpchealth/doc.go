@@ -0,0 +1,28 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package grpchealth serves the checks of the healthz package through the
+// standard grpc.health.v1 Health service.
+//
+// It is kept separate from the healthz package so that only managers that
+// serve the gRPC health protocol depend on gRPC. Wire it into a manager with
+// HealthProbeServerHook:
+//
+//	mgr, err := manager.New(cfg, manager.Options{
+//		HealthProbeBindAddress: ":8081",
+//		HealthProbeServerHook:  grpchealth.HealthProbeServerHook(nil),
+//	})
+package grpchealth
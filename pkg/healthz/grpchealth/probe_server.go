@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpchealth
+
+import (
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// HealthProbeServerHook returns a manager.Options.HealthProbeServerHook that makes
+// the health probe server also serve the grpc.health.v1 Health service over
+// unencrypted HTTP/2, next to the liveness and readiness endpoints.
+//
+// The empty service name and the readiness endpoint name, e.g. "readyz", report the
+// readiness checks, the liveness endpoint name reports the liveness checks. Individual
+// checks are reported as "<endpoint name>/<check name>". An endpoint name that is empty
+// once the leading slash is removed is not served under its own name, so that it doesn't
+// replace the overall status of the empty service name.
+//
+// registerServices registers additional services with the gRPC server, it may be nil.
+//
+// The gRPC server is stopped when the health probe server is shut down, together with
+// the HTTP endpoints.
+func HealthProbeServerHook(registerServices func(grpc.ServiceRegistrar)) func(*http.Server, manager.HealthProbes) error {
+	return func(srv *http.Server, probes manager.HealthProbes) error {
+		services := map[string]*healthz.Handler{"": probes.Readiness}
+		if name := strings.TrimPrefix(probes.ReadinessEndpointName, "/"); name != "" {
+			services[name] = probes.Readiness
+		}
+		if name := strings.TrimPrefix(probes.LivenessEndpointName, "/"); name != "" {
+			services[name] = probes.Liveness
+		}
+
+		grpcServer := grpc.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, &Server{Services: services})
+		if registerServices != nil {
+			registerServices(grpcServer)
+		}
+
+		next := srv.Handler
+		srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+				grpcServer.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+		if srv.Protocols == nil {
+			srv.Protocols = new(http.Protocols)
+			srv.Protocols.SetHTTP1(true)
+		}
+		srv.Protocols.SetUnencryptedHTTP2(true)
+
+		// Streams like the ones of Watch keep the connections busy, which would block
+		// the graceful shutdown of the server until its timeout.
+		srv.RegisterOnShutdown(grpcServer.Stop)
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpchealth_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/healthz/grpchealth"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ = Describe("HealthProbeServerHook", func() {
+	var (
+		ready  error
+		probes manager.HealthProbes
+	)
+
+	BeforeEach(func() {
+		ready = errors.New("not ready yet")
+		probes = manager.HealthProbes{
+			ReadinessEndpointName: "/readyz",
+			Readiness: &healthz.Handler{Checks: map[string]healthz.Checker{
+				"check": func(*http.Request) error { return ready },
+			}},
+			LivenessEndpointName: "/healthz",
+			Liveness:             &healthz.Handler{Checks: map[string]healthz.Checker{"ping": healthz.Ping}},
+		}
+	})
+
+	// serve serves the probes with the hook applied and returns a client for the
+	// Health service of the server.
+	serve := func(registerServices func(grpc.ServiceRegistrar)) (*http.Server, net.Listener, healthpb.HealthClient) {
+		mux := http.NewServeMux()
+		mux.Handle(probes.ReadinessEndpointName, http.StripPrefix(probes.ReadinessEndpointName, probes.Readiness))
+		srv := &http.Server{Handler: mux}
+		Expect(grpchealth.HealthProbeServerHook(registerServices)(srv, probes)).To(Succeed())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			_ = srv.Serve(listener)
+		}()
+		DeferCleanup(srv.Close)
+
+		conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+		return srv, listener, healthpb.NewHealthClient(conn)
+	}
+
+	check := func(ctx context.Context, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		Expect(err).NotTo(HaveOccurred())
+		return resp.GetStatus()
+	}
+
+	It("should serve the probes over gRPC next to the HTTP endpoints", func(ctx SpecContext) {
+		var registered bool
+		_, listener, client := serve(func(grpc.ServiceRegistrar) { registered = true })
+		Expect(registered).To(BeTrue())
+
+		Expect(check(ctx, client, "")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(check(ctx, client, "readyz/check")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(check(ctx, client, "healthz")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+
+		ready = nil
+		Expect(check(ctx, client, "")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+		Expect(check(ctx, client, "readyz")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+
+		resp, err := http.Get("http://" + listener.Addr().String() + "/readyz")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should not replace the overall status with an empty endpoint name", func(ctx SpecContext) {
+		probes.LivenessEndpointName = "/"
+		_, _, client := serve(nil)
+
+		Expect(check(ctx, client, "")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	})
+
+	It("should stop the gRPC server when the HTTP server is shut down", func(ctx SpecContext) {
+		srv, _, client := serve(nil)
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "healthz"})
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.GetStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
+
+		Expect(srv.Shutdown(ctx)).To(Succeed())
+		_, err = stream.Recv()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpchealth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("grpchealth")
+
+const defaultWatchInterval = 5 * time.Second
+
+// Server serves the checks of healthz Handlers through the standard grpc.health.v1
+// Health service, for load balancers and probes that only speak gRPC.
+//
+// The service names map to the Handlers the same way the paths of an HTTP server
+// would: a service name reports the aggregated status of all checks of its Handler,
+// and "<service>/<check>" reports the status of an individual check.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	// Services maps service names to the Handler whose checks determine their status.
+	// The empty service name is used by clients to ask for the overall status.
+	Services map[string]*healthz.Handler
+
+	// WatchInterval is the interval at which the checks are run for Watch calls.
+	// Defaults to 5 seconds.
+	WatchInterval time.Duration
+}
+
+var _ healthpb.HealthServer = &Server{}
+
+// Check implements healthpb.HealthServer.
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus, known := s.status(ctx, req.GetService())
+	if !known {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// List implements healthpb.HealthServer. It lists the aggregated status of all services.
+func (s *Server) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	resp := &healthpb.HealthListResponse{Statuses: make(map[string]*healthpb.HealthCheckResponse, len(s.Services))}
+	for service := range s.Services {
+		servingStatus, _ := s.status(ctx, service)
+		resp.Statuses[service] = &healthpb.HealthCheckResponse{Status: servingStatus}
+	}
+	return resp, nil
+}
+
+// Watch implements healthpb.HealthServer. It runs the checks every WatchInterval and
+// sends the status whenever it changed. Unknown services are reported as SERVICE_UNKNOWN,
+// as required by the protocol.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	interval := s.WatchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		servingStatus, known := s.status(stream.Context(), req.GetService())
+		if !known {
+			servingStatus = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if servingStatus != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			last = servingStatus
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// status runs the checks of the given service and returns whether the service is known.
+func (s *Server) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	name, checkName, isCheck := strings.Cut(service, "/")
+	handler, known := s.Services[name]
+	if !known {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+
+	// The checkers get a request for the path they are served at over HTTP.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/"+service, nil)
+	if err != nil {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+
+	var checks map[string]healthz.Checker
+	if handler != nil {
+		checks = handler.CurrentChecks()
+	}
+	if isCheck {
+		checker, known := checks[checkName]
+		if !known {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+		checks = map[string]healthz.Checker{checkName: checker}
+	}
+
+	for checkName, check := range checks {
+		if err := check(req); err != nil {
+			log.V(1).Info("healthz check failed", "service", service, "checker", checkName, "error", err)
+			return healthpb.HealthCheckResponse_NOT_SERVING, true
+		}
+	}
+	return healthpb.HealthCheckResponse_SERVING, true
+}
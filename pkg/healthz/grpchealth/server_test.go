@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpchealth_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/healthz/grpchealth"
+)
+
+var _ = Describe("gRPC Health Server", func() {
+	var server *grpchealth.Server
+
+	BeforeEach(func() {
+		server = &grpchealth.Server{Services: map[string]*healthz.Handler{
+			"": {Checks: map[string]healthz.Checker{"ok": healthz.Ping}},
+			"readyz": {Checks: map[string]healthz.Checker{
+				"ok": healthz.Ping,
+				"bad": func(req *http.Request) error {
+					return errors.New("blech")
+				},
+			}},
+			"nil": nil,
+		}}
+	})
+
+	check := func(ctx SpecContext, service string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+		resp, err := server.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		return resp.GetStatus(), err
+	}
+
+	It("should report SERVING if all checks of the service succeed", func(ctx SpecContext) {
+		Expect(check(ctx, "")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	It("should report NOT_SERVING if at least one check of the service fails", func(ctx SpecContext) {
+		Expect(check(ctx, "readyz")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	})
+
+	It("should report the status of individual checks", func(ctx SpecContext) {
+		Expect(check(ctx, "readyz/ok")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+		Expect(check(ctx, "readyz/bad")).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+	})
+
+	It("should report services without a handler as SERVING", func(ctx SpecContext) {
+		Expect(check(ctx, "nil")).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+
+	It("should return NotFound for unknown services and checks", func(ctx SpecContext) {
+		_, err := check(ctx, "livez")
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+		_, err = check(ctx, "readyz/unknown")
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("should list the status of all services", func(ctx SpecContext) {
+		resp, err := server.List(ctx, &healthpb.HealthListRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.GetStatuses()).To(HaveLen(3))
+		Expect(resp.GetStatuses()[""].GetStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
+		Expect(resp.GetStatuses()["readyz"].GetStatus()).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+		Expect(resp.GetStatuses()["nil"].GetStatus()).To(Equal(healthpb.HealthCheckResponse_SERVING))
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"path"
 	"slices"
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -30,9 +31,39 @@ import (
 // checkers to the root path, and supports calling individual checkers on
 // subpaths of the name of the checker.
 //
-// Adding checks on the fly is *not* threadsafe -- use a wrapper.
+// Adding checks on the fly is *not* threadsafe -- use a wrapper. Checks can be
+// removed while the handler is served with RemoveCheck.
 type Handler struct {
 	Checks map[string]Checker
+
+	// mu guards Checks against RemoveCheck while the handler is served.
+	mu sync.RWMutex
+}
+
+// RemoveCheck removes the check with the given name. It is safe to call while the
+// handler is served. It returns false if there is no check with the name.
+func (h *Handler) RemoveCheck(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.Checks[name]; !ok {
+		return false
+	}
+	delete(h.Checks, name)
+	return true
+}
+
+// CurrentChecks returns a copy of the checks of the handler, which is safe to use
+// while checks are removed with RemoveCheck.
+func (h *Handler) CurrentChecks() map[string]Checker {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	checks := make(map[string]Checker, len(h.Checks))
+	for name, check := range h.Checks {
+		checks[name] = check
+	}
+	return checks
 }
 
 // checkStatus holds the output of a particular check.
@@ -45,11 +76,12 @@ type checkStatus struct {
 func (h *Handler) serveAggregated(resp http.ResponseWriter, req *http.Request) {
 	failed := false
 	excluded := getExcludedChecks(req)
+	checks := h.CurrentChecks()
 
-	parts := make([]checkStatus, 0, len(h.Checks))
+	parts := make([]checkStatus, 0, len(checks))
 
 	// calculate the results...
-	for checkName, check := range h.Checks {
+	for checkName, check := range checks {
 		// no-op the check if we've specified we want to exclude the check
 		if excluded.Has(checkName) {
 			excluded.Delete(checkName)
@@ -66,7 +98,7 @@ func (h *Handler) serveAggregated(resp http.ResponseWriter, req *http.Request) {
 	}
 
 	// ...default a check if none is present...
-	if len(h.Checks) == 0 {
+	if len(checks) == 0 {
 		parts = append(parts, checkStatus{name: "ping", healthy: true})
 	}
 
@@ -150,14 +182,15 @@ func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 
 	// ...the default check (if nothing else is present)...
-	if len(h.Checks) == 0 && reqPath[1:] == "ping" {
+	checks := h.CurrentChecks()
+	if len(checks) == 0 && reqPath[1:] == "ping" {
 		CheckHandler{Checker: Ping}.ServeHTTP(resp, req)
 		return
 	}
 
 	// ...or an individual checker
 	checkName := reqPath[1:] // ignore the leading slash
-	checker, known := h.Checks[checkName]
+	checker, known := checks[checkName]
 	if !known {
 		http.NotFoundHandler().ServeHTTP(resp, req)
 		return
@@ -200,4 +200,24 @@ var _ = Describe("Healthz Handler", func() {
 			Expect(resp.Code).To(Equal(http.StatusOK))
 		})
 	})
+
+	Describe("removing checks", func() {
+		It("should stop serving a removed check", func() {
+			handler := &healthz.Handler{Checks: map[string]healthz.Checker{
+				"failcheck": func(req *http.Request) error {
+					return errors.New("blech")
+				},
+				"okcheck": healthz.Ping,
+			}}
+
+			Expect(handler.RemoveCheck("failcheck")).To(BeTrue())
+			Expect(handler.RemoveCheck("failcheck")).To(BeFalse())
+
+			resp := requestTo(handler, "/failcheck")
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+
+			resp = requestTo(handler, "/")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
 })
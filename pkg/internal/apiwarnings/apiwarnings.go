@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apiwarnings records the warnings about deprecated APIs that the API server
+// returns in the Warning headers of its responses.
+package apiwarnings
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var (
+	log = logf.RuntimeLog.WithName("deprecated-apis")
+
+	// logged holds the warnings that were logged already, so that every warning is
+	// only logged once per group, version and resource.
+	logged sync.Map
+)
+
+// WrapClient wraps the transport of client to record the deprecation warnings of its
+// responses and returns client.
+func WrapClient(client *http.Client) *http.Client {
+	if _, ok := client.Transport.(*roundTripper); ok {
+		return client
+	}
+	client.Transport = &roundTripper{delegate: client.Transport}
+	return client
+}
+
+type roundTripper struct {
+	delegate http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	delegate := rt.delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+	resp, err := delegate.RoundTrip(req)
+	if err != nil || len(resp.Header.Values("Warning")) == 0 {
+		return resp, err
+	}
+
+	warnings, _ := utilnet.ParseWarningHeaders(resp.Header.Values("Warning"))
+	for _, warning := range warnings {
+		if warning.Code != 299 || !isDeprecation(warning.Text) {
+			continue
+		}
+		group, version, resource := parsePath(req.URL.Path)
+		clientmetrics.DeprecatedAPIRequests.WithLabelValues(group, version, resource).Inc()
+		if _, loaded := logged.LoadOrStore(group+"/"+version+"/"+resource+"\n"+warning.Text, struct{}{}); !loaded {
+			log.Info("Request to deprecated API", "group", group, "version", version, "resource", resource, "message", warning.Text)
+		}
+	}
+	return resp, err
+}
+
+// isDeprecation returns whether text is a warning of the API server about a deprecated
+// API, e.g. "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use
+// batch/v1 CronJob".
+func isDeprecation(text string) bool {
+	return strings.Contains(text, " is deprecated")
+}
+
+// parsePath returns the group, version and resource of a request path like
+// /api/v1/namespaces/default/pods/name or /apis/apps/v1/deployments.
+func parsePath(path string) (group, version, resource string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		version, parts = parts[1], parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group, version, parts = parts[1], parts[2], parts[3:]
+	default:
+		return "", "", ""
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		return group, version, parts[2]
+	}
+	if len(parts) > 0 {
+		resource = parts[0]
+	}
+	return group, version, resource
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiwarnings
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	clientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+)
+
+func TestParsePath(t *testing.T) {
+	for _, tc := range []struct {
+		path                     string
+		group, version, resource string
+	}{
+		{path: "/api/v1/pods", version: "v1", resource: "pods"},
+		{path: "/api/v1/namespaces", version: "v1", resource: "namespaces"},
+		{path: "/api/v1/namespaces/default", version: "v1", resource: "namespaces"},
+		{path: "/api/v1/namespaces/default/pods/name/status", version: "v1", resource: "pods"},
+		{path: "/apis/batch/v1beta1/namespaces/default/cronjobs", group: "batch", version: "v1beta1", resource: "cronjobs"},
+		{path: "/apis/policy/v1beta1/podsecuritypolicies/name", group: "policy", version: "v1beta1", resource: "podsecuritypolicies"},
+		{path: "/version"},
+	} {
+		group, version, resource := parsePath(tc.path)
+		if group != tc.group || version != tc.version || resource != tc.resource {
+			t.Errorf("expected %s to be parsed to %q, %q, %q, got %q, %q, %q", tc.path, tc.group, tc.version, tc.resource, group, version, resource)
+		}
+	}
+}
+
+func TestWrapClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", `299 - "batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob"`)
+		w.Header().Add("Warning", `299 - "unknown field \"spec.foo\""`)
+	}))
+	defer server.Close()
+
+	client := WrapClient(&http.Client{})
+	if WrapClient(client).Transport.(*roundTripper).delegate != nil {
+		t.Fatal("expected the transport not to be wrapped twice")
+	}
+	for range 2 {
+		resp, err := client.Get(server.URL + "/apis/batch/v1beta1/namespaces/default/cronjobs")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if count := testutil.ToFloat64(clientmetrics.DeprecatedAPIRequests.WithLabelValues("batch", "v1beta1", "cronjobs")); count != 2 {
+		t.Fatalf("expected 2 requests to deprecated APIs, got %v", count)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the metrics of the clients of pkg/client. They are registered
+// with the controller-runtime metrics registry by pkg/metrics, so that creating a
+// client doesn't import it and doesn't register any metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var requestLabels = []string{"verb", "group", "version", "kind"}
+
+var (
+	// RequestLatency is a prometheus histogram metric which holds the latency of the
+	// requests of clients with metrics, by verb and kind.
+	RequestLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:                            "controller_runtime_client_request_duration_seconds",
+		Help:                            "Length of time per client request per verb and kind",
+		Buckets:                         []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1.0, 2.0, 4.0, 8.0, 15.0, 30.0, 60.0},
+		NativeHistogramBucketFactor:     1.1,
+		NativeHistogramMaxBucketNumber:  100,
+		NativeHistogramMinResetDuration: 1 * time.Hour,
+	}, requestLabels)
+
+	// RequestResults is a prometheus counter metric which holds the total number of
+	// requests of clients with metrics, by verb, kind and class of the result code.
+	RequestResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_client_requests_total",
+		Help: "Total number of client requests per verb, kind and result code class",
+	}, append([]string{"code"}, requestLabels...))
+
+	// RequestRetries is a prometheus counter metric which holds the total number of
+	// retries of writes by clients created with client.WithRetry, by verb and kind.
+	RequestRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_client_request_retries_total",
+		Help: "Total number of retried client requests per verb and kind",
+	}, requestLabels)
+
+	// DeprecatedAPIRequests is a prometheus counter metric which holds the number of
+	// requests to deprecated APIs per group, version and resource.
+	DeprecatedAPIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_deprecated_api_requests_total",
+		Help: "Total number of requests to deprecated APIs per group, version and resource",
+	}, []string{"group", "version", "resource"})
+
+	// RateLimitedRequests is a prometheus counter metric which holds the total number
+	// of requests that were delayed by a client.RateLimit, by verb and kind.
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_client_rate_limited_requests_total",
+		Help: "Total number of requests delayed by a client-side rate limit per verb and kind",
+	}, []string{"verb", "group", "kind"})
+
+	// RateLimitDelay is a prometheus histogram metric which holds the time requests
+	// were delayed by a client.RateLimit, by verb and kind.
+	RateLimitDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:                            "controller_runtime_client_rate_limit_delay_seconds",
+		Help:                            "Length of time requests were delayed by a client-side rate limit per verb and kind",
+		Buckets:                         []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1.0, 2.0, 4.0, 8.0, 15.0, 30.0, 60.0},
+		NativeHistogramBucketFactor:     1.1,
+		NativeHistogramMaxBucketNumber:  100,
+		NativeHistogramMinResetDuration: 1 * time.Hour,
+	}, []string{"verb", "group", "kind"})
+)
+
+// Collectors returns the metrics of the clients.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{RequestLatency, RequestResults, RequestRetries, RateLimitedRequests, RateLimitDelay, DeprecatedAPIRequests}
+}
@@ -20,19 +20,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 
+	"sigs.k8s.io/controller-runtime/pkg/cleanup"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/internal/controller/metrics"
+	internalmetrics "sigs.k8s.io/controller-runtime/pkg/internal/metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -81,6 +95,12 @@ type Options[request comparable] struct {
 	// LeaderElected indicates whether the controller is leader elected or always running.
 	LeaderElected *bool
 
+	// LeaderElectionGroupName is the name of the leader election group the controller belongs to.
+	LeaderElectionGroupName string
+
+	// ControllerGroupName is the name of the controller group the controller belongs to.
+	ControllerGroupName string
+
 	// EnableWarmup specifies whether the controller should start its sources
 	// when the manager is not the leader.
 	// Defaults to false, which means that the controller will wait for leader election to start
@@ -90,6 +110,21 @@ type Options[request comparable] struct {
 	// ReconciliationTimeout is used as the timeout passed to the context of each Reconcile call.
 	// By default, there is no timeout.
 	ReconciliationTimeout time.Duration
+
+	// ReconcileErrorLogging configures how errors returned by the Reconciler are logged.
+	// By default, they are logged at error level.
+	ReconcileErrorLogging *config.ReconcileErrorLogging
+
+	// ReadyAfterInitialReconcile enables the InitialReconcileCheck of the controller.
+	ReadyAfterInitialReconcile bool
+
+	// DeleteMetricsOnStop deletes the metrics of the controller and its queue when it stops.
+	DeleteMetricsOnStop bool
+
+	// HealthEventTarget is the object Events about the health of the controller are emitted
+	// on with HealthEventRecorder. No Events are emitted if either is nil.
+	HealthEventTarget   runtime.Object
+	HealthEventRecorder events.EventRecorder
 }
 
 // Controller implements controller.Controller.
@@ -118,12 +153,25 @@ type Controller[request comparable] struct {
 	// the Queue for processing
 	Queue priorityqueue.PriorityQueue[request]
 
+	// provenanceQueue holds the Queue while the controller is running if it tracks the
+	// provenance of its items. It is not guarded by mu, so that the pending requests
+	// can be described while the sources of the controller sync.
+	provenanceQueue atomic.Pointer[priorityqueue.ProvenanceQueue[request]]
+
+	// runningQueue holds the Queue while the controller is running, so that EnqueueNow
+	// can add requests without waiting for the sources of the controller to sync.
+	runningQueue atomic.Pointer[priorityqueue.PriorityQueue[request]]
+
 	// mu is used to synchronize Controller setup
 	mu sync.Mutex
 
 	// Started is true if the Controller has been Started
 	Started bool
 
+	// stopped is true once Start returned, so that the next call to Start restarts
+	// the Controller, see PrepareRestart.
+	stopped bool
+
 	// ctx is the context that was passed to Start() and used when starting watches.
 	//
 	// According to the docs, contexts should not be stored in a struct: https://golang.org/pkg/context,
@@ -146,6 +194,17 @@ type Controller[request comparable] struct {
 	// didStartEventSourcesOnce is used to ensure that the event sources are only started once.
 	didStartEventSourcesOnce sync.Once
 
+	// watches holds all sources of the controller, so they can be started again when the
+	// controller is restarted, e.g. when the manager re-acquires leadership. This keeps the
+	// caches backing the sources referenced, which is fine as the controller keeps using them
+	// after it was restarted. Sources must support being started again after the context of
+	// their previous Start was cancelled, which all sources of the source package do.
+	watches []source.TypedSource[request]
+
+	// stopSourcesAndQueue stops the sources and shuts down the Queue. It can be called
+	// multiple times.
+	stopSourcesAndQueue func()
+
 	// LogConstructor is used to construct a logger to then log messages to users during reconciliation,
 	// or for example when a watch is started.
 	// Note: LogConstructor has to be able to handle nil requests as we are also using it
@@ -159,6 +218,14 @@ type Controller[request comparable] struct {
 	// LeaderElected indicates whether the controller is leader elected or always running.
 	LeaderElected *bool
 
+	// LeaderElectionGroupName is the name of the leader election group the controller belongs to.
+	// The empty group is the manager's own leader election.
+	LeaderElectionGroupName string
+
+	// ControllerGroupName is the name of the controller group the controller belongs to.
+	// The empty name is no group.
+	ControllerGroupName string
+
 	// EnableWarmup specifies whether the controller should start its sources when the manager is not
 	// the leader. This is useful for cases where sources take a long time to start, as it allows
 	// for the controller to warm up its caches even before it is elected as the leader. This
@@ -173,11 +240,62 @@ type Controller[request comparable] struct {
 	EnableWarmup *bool
 
 	ReconciliationTimeout time.Duration
+
+	// ReconcileErrorLogging configures how errors returned by the Reconciler are logged.
+	// By default, they are logged at error level.
+	ReconcileErrorLogging *config.ReconcileErrorLogging
+
+	// DeleteMetricsOnStop deletes the metrics of the controller and its queue when Start
+	// returns, see DeleteMetrics. They are exported again if the controller is restarted.
+	DeleteMetricsOnStop bool
+
+	// HealthEventTarget is the object Events about the health of the controller are emitted
+	// on with HealthEventRecorder, see emitHealthEvent. No Events are emitted if either is nil.
+	HealthEventTarget   runtime.Object
+	HealthEventRecorder events.EventRecorder
+
+	// MaxConcurrentReconcilesFromConfig indicates that MaxConcurrentReconciles was defaulted
+	// from the controller configuration of the manager, so that it follows its changes.
+	MaxConcurrentReconcilesFromConfig bool
+
+	// workers holds a channel per running worker that is closed to stop it, and
+	// workerGroup tracks them until Start returns. Both are guarded by mu and are
+	// only set while the Controller is running.
+	workers     []chan struct{}
+	workerGroup *sync.WaitGroup
+
+	// metrics holds the metrics of the controller, resolved once so that they don't
+	// have to be looked up by their labels for every reconciliation.
+	metrics atomic.Pointer[controllerMetrics]
+
+	// initialReconcile tracks whether all objects that existed when the controller was
+	// started have been reconciled. It is nil unless ReadyAfterInitialReconcile is set.
+	initialReconcile *initialReconcileTracker[request]
+
+	// starting and running track the state of the controller for its health checks, and
+	// runningWorkers counts the worker goroutines. They are not guarded by mu because mu
+	// is held while the sources of the controller sync.
+	starting       atomic.Bool
+	running        atomic.Bool
+	runningWorkers atomic.Int32
+
+	// removed is set once the controller was removed from its manager, see MarkRemoved.
+	removed atomic.Bool
+
+	// failedSource holds the error of a source that failed after it started, e.g.
+	// because its informer was stopped. It is reset when the sources are started again.
+	failedSource atomic.Pointer[error]
+
+	// readyzChecks and healthzChecks are the names of the checks that were added to the
+	// manager for the controller, see AddedReadyzCheck. They are guarded by checksMu.
+	checksMu      sync.Mutex
+	readyzChecks  []string
+	healthzChecks []string
 }
 
 // New returns a new Controller configured with the given options.
 func New[request comparable](options Options[request]) *Controller[request] {
-	return &Controller[request]{
+	c := &Controller[request]{
 		Do:                      options.Do,
 		RateLimiter:             options.RateLimiter,
 		NewQueue:                options.NewQueue,
@@ -187,8 +305,136 @@ func New[request comparable](options Options[request]) *Controller[request] {
 		LogConstructor:          options.LogConstructor,
 		RecoverPanic:            options.RecoverPanic,
 		LeaderElected:           options.LeaderElected,
+		LeaderElectionGroupName: options.LeaderElectionGroupName,
+		ControllerGroupName:     options.ControllerGroupName,
 		EnableWarmup:            options.EnableWarmup,
 		ReconciliationTimeout:   options.ReconciliationTimeout,
+		ReconcileErrorLogging:   options.ReconcileErrorLogging,
+		DeleteMetricsOnStop:     options.DeleteMetricsOnStop,
+		HealthEventTarget:       options.HealthEventTarget,
+		HealthEventRecorder:     options.HealthEventRecorder,
+	}
+	if options.ReadyAfterInitialReconcile {
+		c.initialReconcile = newInitialReconcileTracker[request]()
+	}
+	return c
+}
+
+// InitialReconcileCheck returns a healthz.Checker that fails until all objects that existed
+// when the sources of the controller synced have been reconciled at least once. Controllers
+// that need leader election pass it while they are not started, i.e. while the replica is
+// not the leader. It returns nil if ReadyAfterInitialReconcile is not set.
+func (c *Controller[request]) InitialReconcileCheck() healthz.Checker {
+	if c.initialReconcile == nil {
+		return nil
+	}
+	return func(req *http.Request) error {
+		if c.removed.Load() {
+			return nil
+		}
+		if c.NeedLeaderElection() && !c.starting.Load() && !c.running.Load() {
+			return nil
+		}
+		return c.initialReconcile.check(req)
+	}
+}
+
+// MarkRemoved marks the controller as removed from the manager it was added to. Its health
+// checks pass from then on, in case they can't be removed from the manager.
+func (c *Controller[request]) MarkRemoved() {
+	c.removed.Store(true)
+}
+
+// AddedReadyzCheck records that a readiness check with the given name was added to the
+// manager for the controller, so that it can be removed together with the controller.
+func (c *Controller[request]) AddedReadyzCheck(name string) {
+	c.checksMu.Lock()
+	defer c.checksMu.Unlock()
+	c.readyzChecks = append(c.readyzChecks, name)
+}
+
+// AddedHealthzCheck records that a liveness check with the given name was added to the
+// manager for the controller, so that it can be removed together with the controller.
+func (c *Controller[request]) AddedHealthzCheck(name string) {
+	c.checksMu.Lock()
+	defer c.checksMu.Unlock()
+	c.healthzChecks = append(c.healthzChecks, name)
+}
+
+// HealthChecks returns the names of the readiness and liveness checks that were added to
+// the manager for the controller.
+func (c *Controller[request]) HealthChecks() (readyzChecks, healthzChecks []string) {
+	c.checksMu.Lock()
+	defer c.checksMu.Unlock()
+	return slices.Clone(c.readyzChecks), slices.Clone(c.healthzChecks)
+}
+
+// PendingRequests returns the requests that are pending in the queue of the controller,
+// with the provenances that added them. It returns nil if the controller isn't running
+// or its queue doesn't track the provenance of its items.
+func (c *Controller[request]) PendingRequests() []priorityqueue.PendingItem[request] {
+	queue := c.provenanceQueue.Load()
+	if queue == nil {
+		return nil
+	}
+	return (*queue).PendingItems()
+}
+
+// EnqueueNow adds the request to the queue of the controller with the maximum priority
+// and without rate limiting, so that it is reconciled as soon as a worker is free. A
+// request that is already in the queue is not added twice, but it is moved to the front
+// of the queue. The reason is recorded in an audit log entry. It returns an error if the
+// queue of the controller isn't running.
+func (c *Controller[request]) EnqueueNow(req request, reason string) error {
+	queue := c.runningQueue.Load()
+	if queue == nil {
+		return fmt.Errorf("controller %s is not running", c.Name)
+	}
+	(*queue).AddWithOpts(priorityqueue.AddOpts{Priority: new(math.MaxInt), Provenance: priorityqueue.ProvenanceEnqueueNow}, req)
+	c.getMetrics().enqueueNow.Inc()
+	c.LogConstructor(&req).Info("Enqueued request with maximum priority", "reason", reason)
+	return nil
+}
+
+// DescribePendingRequests returns the PendingRequests of the controller for the
+// introspection endpoints of the Manager.
+func (c *Controller[request]) DescribePendingRequests() any {
+	return c.PendingRequests()
+}
+
+// ReadinessCheck returns a healthz.Checker that fails while the controller is starting,
+// i.e. until its sources synced and its workers were started. Controllers that don't need
+// leader election also fail it until they are started, controllers that need leader
+// election pass it while they wait to be started on the leader.
+func (c *Controller[request]) ReadinessCheck() healthz.Checker {
+	return func(_ *http.Request) error {
+		switch {
+		case c.running.Load(), c.removed.Load():
+			return nil
+		case c.starting.Load():
+			return errors.New("controller is starting, its sources have not synced yet")
+		case !c.NeedLeaderElection():
+			return errors.New("controller has not been started yet")
+		}
+		return nil
+	}
+}
+
+// LivenessCheck returns a healthz.Checker that fails if the controller is running but none
+// of its workers is, e.g. because they exited unexpectedly, or if one of its sources failed,
+// e.g. because the informer of a Kind source was stopped.
+func (c *Controller[request]) LivenessCheck() healthz.Checker {
+	return func(_ *http.Request) error {
+		if !c.running.Load() || c.removed.Load() {
+			return nil
+		}
+		if c.runningWorkers.Load() == 0 {
+			return errors.New("controller is running without workers")
+		}
+		if err := c.failedSource.Load(); err != nil {
+			return *err
+		}
+		return nil
 	}
 }
 
@@ -196,7 +442,8 @@ func New[request comparable](options Options[request]) *Controller[request] {
 func (c *Controller[request]) Reconcile(ctx context.Context, req request) (_ reconcile.Result, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Inc()
+			c.getMetrics().reconcilePanics.Inc()
+			c.emitHealthEvent("ReconcilePanic", "Reconcile", "Reconciliation of %v panicked: %v", req, r)
 
 			if c.RecoverPanic == nil || *c.RecoverPanic {
 				for _, fn := range utilruntime.PanicHandlers {
@@ -212,6 +459,15 @@ func (c *Controller[request]) Reconcile(ctx context.Context, req request) (_ rec
 		}
 	}()
 
+	// Run the cleanups of the reconciliation before a panic is recovered, so that
+	// they also run if it isn't.
+	ctx, runCleanups := cleanup.NewContext(ctx)
+	defer func() {
+		if err := runCleanups(); err != nil {
+			logf.FromContext(ctx).Error(err, "Cleanup after reconciliation failed")
+		}
+	}()
+
 	var timeoutCause error
 	if c.ReconciliationTimeout > 0 {
 		timeoutCause = errReconciliationTimeout
@@ -228,17 +484,29 @@ func (c *Controller[request]) Reconcile(ctx context.Context, req request) (_ rec
 	// our specific timeout cause. This prevents false positives from parent context cancellations
 	// or other timeout scenarios.
 	if timeoutCause != nil && ctx.Err() == context.DeadlineExceeded && errors.Is(context.Cause(ctx), timeoutCause) {
-		ctrlmetrics.ReconcileTimeouts.WithLabelValues(c.Name).Inc()
+		c.getMetrics().reconcileTimeouts.Inc()
+		c.emitHealthEvent("ReconcileTimeout", "Reconcile", "Reconciliation of %v timed out after %s", req, c.ReconciliationTimeout)
 	}
 
 	return res, err
 }
 
+// emitHealthEvent emits a Warning Event about the health of the controller on the
+// HealthEventTarget, if one is configured.
+func (c *Controller[request]) emitHealthEvent(reason, action, note string, args ...any) {
+	if c.HealthEventTarget == nil || c.HealthEventRecorder == nil {
+		return
+	}
+	c.HealthEventRecorder.Eventf(c.HealthEventTarget, nil, corev1.EventTypeWarning, reason, action, note, args...)
+}
+
 // Watch implements controller.Controller.
 func (c *Controller[request]) Watch(src source.TypedSource[request]) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.watches = append(c.watches, src)
+
 	// Sources weren't started yet, store the watches locally and return.
 	// These sources are going to be held until either Warmup() or Start(...) is called.
 	if !c.startedEventSourcesAndQueue {
@@ -250,6 +518,18 @@ func (c *Controller[request]) Watch(src source.TypedSource[request]) error {
 	return src.Start(c.ctx, c.Queue)
 }
 
+// RemoveWatch removes a source that was added with Watch, so that it isn't started
+// when the Controller is started or restarted. The source must be comparable, e.g. a
+// pointer. Sources that were started already have to be stopped by the caller.
+func (c *Controller[request]) RemoveWatch(src source.TypedSource[request]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	isSrc := func(s source.TypedSource[request]) bool { return s == src }
+	c.watches = slices.DeleteFunc(c.watches, isSrc)
+	c.startWatches = slices.DeleteFunc(c.startWatches, isSrc)
+}
+
 // NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
 func (c *Controller[request]) NeedLeaderElection() bool {
 	if c.LeaderElected == nil {
@@ -258,6 +538,22 @@ func (c *Controller[request]) NeedLeaderElection() bool {
 	return *c.LeaderElected
 }
 
+// LeaderElectionGroup implements the manager.LeaderElectionGroupRunnable interface.
+func (c *Controller[request]) LeaderElectionGroup() string {
+	return c.LeaderElectionGroupName
+}
+
+// ControllerGroup implements the manager.ControllerGroupRunnable interface.
+func (c *Controller[request]) ControllerGroup() string {
+	return c.ControllerGroupName
+}
+
+// ControllerName returns the name of the controller, it is used by the manager
+// to describe its runnables.
+func (c *Controller[request]) ControllerName() string {
+	return c.Name
+}
+
 // Warmup implements the manager.WarmupRunnable interface.
 func (c *Controller[request]) Warmup(ctx context.Context) error {
 	if c.EnableWarmup == nil || !*c.EnableWarmup {
@@ -278,7 +574,11 @@ func (c *Controller[request]) Start(ctx context.Context) error {
 	// use an IIFE to get proper lock handling
 	// but lock outside to get proper handling of the queue shutdown
 	c.mu.Lock()
+	if c.stopped {
+		c.prepareRestartLocked()
+	}
 	if c.Started {
+		c.mu.Unlock()
 		return errors.New("controller was started more than once. This is likely to be caused by being added to a manager multiple times")
 	}
 
@@ -287,6 +587,9 @@ func (c *Controller[request]) Start(ctx context.Context) error {
 	// Set the internal context.
 	c.ctx = ctx
 
+	c.starting.Store(true)
+	defer c.starting.Store(false)
+
 	wg := &sync.WaitGroup{}
 	err := func() error {
 		defer c.mu.Unlock()
@@ -305,31 +608,137 @@ func (c *Controller[request]) Start(ctx context.Context) error {
 
 		// Launch workers to process resources
 		c.LogConstructor(nil).Info("Starting workers", "worker count", c.MaxConcurrentReconciles)
-		wg.Add(c.MaxConcurrentReconciles)
-		for i := 0; i < c.MaxConcurrentReconciles; i++ {
-			go func() {
-				defer wg.Done()
-				// Run a worker thread that just dequeues items, processes them, and marks them done.
-				// It enforces that the reconcileHandler is never invoked concurrently with the same object.
-				for c.processNextWorkItem(ctx) {
-				}
-			}()
-		}
+		c.workerGroup = wg
+		c.scaleWorkersLocked(ctx, c.MaxConcurrentReconciles)
 
 		c.Started = true
+		c.running.Store(true)
 		return nil
 	}()
 	if err != nil {
+		c.mu.Lock()
+		c.stopped = true
+		c.mu.Unlock()
 		return err
 	}
+	c.starting.Store(false)
 
 	<-ctx.Done()
+	c.running.Store(false)
+	c.provenanceQueue.Store(nil)
+	c.runningQueue.Store(nil)
 	c.LogConstructor(nil).Info("Shutdown signal received, waiting for all workers to finish")
+	// The sources and queue might have been started by Warmup with a context that outlives
+	// the one passed to Start, e.g. when leadership is lost, stop them to stop the workers
+	// and to remove the event handlers of the sources before the Controller is restarted.
+	c.mu.Lock()
+	stopSourcesAndQueue := c.stopSourcesAndQueue
+	// Prevent SetMaxConcurrentReconciles from starting workers while waiting for them.
+	c.workers = nil
+	c.workerGroup = nil
+	c.mu.Unlock()
+	if stopSourcesAndQueue != nil {
+		stopSourcesAndQueue()
+	}
 	wg.Wait()
 	c.LogConstructor(nil).Info("All workers finished")
+	if c.DeleteMetricsOnStop {
+		c.DeleteMetrics()
+	}
+	c.mu.Lock()
+	c.stopped = true
+	c.mu.Unlock()
 	return nil
 }
 
+// scaleWorkersLocked starts or stops workers until n workers are running. Stopped workers
+// finish the item they are processing, or the next one they get, before they return.
+func (c *Controller[request]) scaleWorkersLocked(ctx context.Context, n int) {
+	for len(c.workers) < n {
+		stop := make(chan struct{})
+		c.workers = append(c.workers, stop)
+		wg := c.workerGroup
+		wg.Add(1)
+		c.runningWorkers.Add(1)
+		go func() {
+			defer wg.Done()
+			defer c.runningWorkers.Add(-1)
+			// Run a worker thread that just dequeues items, processes them, and marks them done.
+			// It enforces that the reconcileHandler is never invoked concurrently with the same object.
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if !c.processNextWorkItem(ctx) {
+					return
+				}
+			}
+		}()
+	}
+	for len(c.workers) > n {
+		close(c.workers[len(c.workers)-1])
+		c.workers = c.workers[:len(c.workers)-1]
+	}
+}
+
+// SetMaxConcurrentReconciles changes the number of concurrent reconciles. If the
+// Controller is running, workers are started or stopped accordingly.
+func (c *Controller[request]) SetMaxConcurrentReconciles(n int) {
+	if n <= 0 {
+		n = 1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n == c.MaxConcurrentReconciles {
+		return
+	}
+	c.MaxConcurrentReconciles = n
+	c.getMetrics().workerCount.Set(float64(n))
+	if c.workerGroup != nil {
+		c.LogConstructor(nil).Info("Changing worker count", "worker count", n)
+		c.scaleWorkersLocked(c.ctx, n)
+		c.setInfoMetric()
+	}
+}
+
+// ReloadControllerConfig applies changes of the controller configuration of the manager
+// to the running Controller.
+func (c *Controller[request]) ReloadControllerConfig(cfg config.Controller) {
+	if c.MaxConcurrentReconcilesFromConfig {
+		c.SetMaxConcurrentReconciles(cfg.MaxConcurrentReconciles)
+	}
+}
+
+// PrepareRestart implements the manager.RestartableRunnable interface. It resets the
+// Controller after Start returned, so that the next call to Start creates a new queue
+// and starts all sources again. Start does this on its own if it is called again after
+// it returned.
+func (c *Controller[request]) PrepareRestart() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prepareRestartLocked()
+}
+
+func (c *Controller[request]) prepareRestartLocked() {
+	// Stop the sources and the queue in case Start failed before it stopped them.
+	if c.stopSourcesAndQueue != nil {
+		c.stopSourcesAndQueue()
+	}
+	c.Started = false
+	c.stopped = false
+	c.startedEventSourcesAndQueue = false
+	c.didStartEventSourcesOnce = sync.Once{}
+	c.startWatches = slices.Clone(c.watches)
+	c.stopSourcesAndQueue = nil
+	c.failedSource.Store(nil)
+	if c.initialReconcile != nil {
+		c.initialReconcile.reset()
+	}
+}
+
 // startEventSourcesAndQueueLocked launches all the sources registered with this controller and waits
 // for them to sync. It returns an error if any of the sources fail to start or sync.
 func (c *Controller[request]) startEventSourcesAndQueueLocked(ctx context.Context) error {
@@ -342,9 +751,25 @@ func (c *Controller[request]) startEventSourcesAndQueueLocked(ctx context.Contex
 		} else {
 			c.Queue = &priorityQueueWrapper[request]{TypedRateLimitingInterface: queue}
 		}
+		if provenanceQueue, ok := c.Queue.(priorityqueue.ProvenanceQueue[request]); ok {
+			c.provenanceQueue.Store(&provenanceQueue)
+		}
+		if c.initialReconcile != nil && !c.initialReconcile.done.Load() {
+			c.Queue = &initialReconcileQueue[request]{PriorityQueue: c.Queue, tracker: c.initialReconcile}
+		}
+		runningQueue := c.Queue
+		c.runningQueue.Store(&runningQueue)
+		var stopSources context.CancelFunc
+		ctx, stopSources = context.WithCancel(ctx)
+		shutDownQueue := c.Queue.ShutDown
+		stopSourcesAndQueue := sync.OnceFunc(func() {
+			stopSources()
+			shutDownQueue()
+		})
+		c.stopSourcesAndQueue = stopSourcesAndQueue
 		go func() {
 			<-ctx.Done()
-			c.Queue.ShutDown()
+			stopSourcesAndQueue()
 		}()
 
 		errGroup := &errgroup.Group{}
@@ -374,6 +799,9 @@ func (c *Controller[request]) startEventSourcesAndQueueLocked(ctx context.Contex
 						sourceStartErrChan <- err
 						return
 					}
+					if failing, ok := watch.(failingSource); ok {
+						go c.watchSourceFailure(ctx, failing.Failed(), watch)
+					}
 					syncingSource, ok := watch.(source.TypedSyncingSource[request])
 					if !ok {
 						return
@@ -401,11 +829,19 @@ func (c *Controller[request]) startEventSourcesAndQueueLocked(ctx context.Contex
 			})
 		}
 		retErr = errGroup.Wait()
+		if retErr != nil {
+			c.emitHealthEvent("SourceSyncFailed", "StartSources", "Sources of controller %s failed to sync: %v", c.Name, retErr)
+		}
+		if retErr == nil && c.initialReconcile != nil {
+			c.initialReconcile.sourcesSynced()
+		}
 
 		// All the watches have been started, we can reset the local slice.
 		//
 		// We should never hold watches more than necessary, each watch source can hold a backing cache,
-		// which won't be garbage collected if we hold a reference to it.
+		// which won't be garbage collected if we hold a reference to it. The only exception are the
+		// watches of controllers that need leader election, which are kept in c.watches for as long as
+		// the controller exists so that they can be started again after leadership was re-acquired.
 		c.startWatches = nil
 
 		// Mark event sources as started after resetting the startWatches slice so that watches from
@@ -416,10 +852,40 @@ func (c *Controller[request]) startEventSourcesAndQueueLocked(ctx context.Contex
 	return retErr
 }
 
+// failingSource is implemented by sources that can fail after they started, e.g. Kind
+// sources whose informer was stopped.
+type failingSource interface {
+	// Failed returns a channel that receives an error once the source failed.
+	Failed() <-chan error
+}
+
+// watchSourceFailure records the error that a started source fails with until ctx is
+// done, so that the liveness check of the controller fails.
+func (c *Controller[request]) watchSourceFailure(ctx context.Context, failed <-chan error, src source.TypedSource[request]) {
+	select {
+	case err := <-failed:
+		err = fmt.Errorf("source %v failed: %w", src, err)
+		c.failedSource.Store(&err)
+		c.LogConstructor(nil).Error(err, "Source failed, the controller won't receive its events anymore")
+		c.emitHealthEvent("SourceFailed", "RunSources", "Source of controller %s failed: %v", c.Name, err)
+	case <-ctx.Done():
+	}
+}
+
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the reconcileHandler.
 func (c *Controller[request]) processNextWorkItem(ctx context.Context) bool {
-	obj, priority, shutdown := c.Queue.GetWithPriority()
+	var (
+		obj        request
+		priority   int
+		provenance []priorityqueue.Provenance
+		shutdown   bool
+	)
+	if provenanceQueue := c.provenanceQueue.Load(); provenanceQueue != nil {
+		obj, priority, provenance, shutdown = (*provenanceQueue).GetWithProvenance()
+	} else {
+		obj, priority, shutdown = c.Queue.GetWithPriority()
+	}
 	if shutdown {
 		// Stop working
 		return false
@@ -433,10 +899,11 @@ func (c *Controller[request]) processNextWorkItem(ctx context.Context) bool {
 	// period.
 	defer c.Queue.Done(obj)
 
-	ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Add(1)
-	defer ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Add(-1)
+	activeWorkers := c.getMetrics().activeWorkers
+	activeWorkers.Inc()
+	defer activeWorkers.Dec()
 
-	c.reconcileHandler(ctx, obj, priority)
+	c.reconcileHandler(ctx, obj, priority, provenance)
 	return true
 }
 
@@ -447,24 +914,106 @@ const (
 	labelSuccess      = "success"
 )
 
+// controllerMetrics are the metrics of a controller with its labels already resolved.
+type controllerMetrics struct {
+	reconcileTotalError        prometheus.Counter
+	reconcileTotalRequeueAfter prometheus.Counter
+	reconcileTotalRequeue      prometheus.Counter
+	reconcileTotalSuccess      prometheus.Counter
+	reconcileErrors            prometheus.Counter
+	terminalReconcileErrors    prometheus.Counter
+	reconcilePanics            prometheus.Counter
+	reconcileTimeouts          prometheus.Counter
+	enqueueNow                 prometheus.Counter
+	reconcileNoOp              prometheus.Counter
+	reconcileTime              prometheus.Observer
+	workerCount                prometheus.Gauge
+	activeWorkers              prometheus.Gauge
+}
+
+func newControllerMetrics(name string) *controllerMetrics {
+	return &controllerMetrics{
+		reconcileTotalError:        ctrlmetrics.ReconcileTotal.WithLabelValues(name, labelError),
+		reconcileTotalRequeueAfter: ctrlmetrics.ReconcileTotal.WithLabelValues(name, labelRequeueAfter),
+		reconcileTotalRequeue:      ctrlmetrics.ReconcileTotal.WithLabelValues(name, labelRequeue),
+		reconcileTotalSuccess:      ctrlmetrics.ReconcileTotal.WithLabelValues(name, labelSuccess),
+		reconcileErrors:            ctrlmetrics.ReconcileErrors.WithLabelValues(name),
+		terminalReconcileErrors:    ctrlmetrics.TerminalReconcileErrors.WithLabelValues(name),
+		reconcilePanics:            ctrlmetrics.ReconcilePanics.WithLabelValues(name),
+		reconcileTimeouts:          ctrlmetrics.ReconcileTimeouts.WithLabelValues(name),
+		enqueueNow:                 ctrlmetrics.ReconcileEnqueueNowTotal.WithLabelValues(name),
+		reconcileNoOp:              ctrlmetrics.ReconcileNoOpTotal.WithLabelValues(name),
+		reconcileTime:              ctrlmetrics.ReconcileTime.WithLabelValues(name),
+		workerCount:                ctrlmetrics.WorkerCount.WithLabelValues(name),
+		activeWorkers:              ctrlmetrics.ActiveWorkers.WithLabelValues(name),
+	}
+}
+
+// getMetrics returns the metrics of the controller. They are resolved on first use if
+// Reconcile is called without the controller being started.
+func (c *Controller[request]) getMetrics() *controllerMetrics {
+	if m := c.metrics.Load(); m != nil {
+		return m
+	}
+	m := newControllerMetrics(c.Name)
+	if !c.metrics.CompareAndSwap(nil, m) {
+		return c.metrics.Load()
+	}
+	return m
+}
+
+// initMetrics resolves the metrics of the controller and initializes them, so that
+// they are exported before the first reconciliation.
 func (c *Controller[request]) initMetrics() {
-	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Add(0)
-	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeueAfter).Add(0)
-	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeue).Add(0)
-	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelSuccess).Add(0)
-	ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Add(0)
-	ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Add(0)
-	ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Add(0)
-	ctrlmetrics.ReconcileTimeouts.WithLabelValues(c.Name).Add(0)
-	ctrlmetrics.WorkerCount.WithLabelValues(c.Name).Set(float64(c.MaxConcurrentReconciles))
-	ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Set(0)
-}
-
-func (c *Controller[request]) reconcileHandler(ctx context.Context, req request, priority int) {
+	m := newControllerMetrics(c.Name)
+	c.metrics.Store(m)
+
+	m.reconcileTotalError.Add(0)
+	m.reconcileTotalRequeueAfter.Add(0)
+	m.reconcileTotalRequeue.Add(0)
+	m.reconcileTotalSuccess.Add(0)
+	m.reconcileErrors.Add(0)
+	m.terminalReconcileErrors.Add(0)
+	m.reconcilePanics.Add(0)
+	m.reconcileTimeouts.Add(0)
+	m.enqueueNow.Add(0)
+	m.reconcileNoOp.Add(0)
+	m.workerCount.Set(float64(c.MaxConcurrentReconciles))
+	m.activeWorkers.Set(0)
+	c.setInfoMetric()
+}
+
+// setInfoMetric exports the configuration of the controller as the labels of its info
+// metric, replacing the series of a previous configuration.
+func (c *Controller[request]) setInfoMetric() {
+	ctrlmetrics.ControllerInfo.DeletePartialMatch(prometheus.Labels{"controller": c.Name})
+	ctrlmetrics.ControllerInfo.WithLabelValues(
+		c.Name,
+		strconv.Itoa(c.MaxConcurrentReconciles),
+		c.ReconciliationTimeout.String(),
+		c.CacheSyncTimeout.String(),
+		strconv.FormatBool(c.EnableWarmup != nil && *c.EnableWarmup),
+		strconv.FormatBool(c.NeedLeaderElection()),
+	).Set(1)
+}
+
+// DeleteMetrics deletes the metrics of the controller and its queue, so that a stopped
+// controller doesn't keep exporting them. They are resolved again if the controller
+// reconciles or is started afterwards.
+func (c *Controller[request]) DeleteMetrics() {
+	c.metrics.Store(nil)
+	ctrlmetrics.DeleteControllerMetrics(c.Name)
+	// The queue of the controller is named after the controller.
+	internalmetrics.DeleteWorkqueueMetrics(c.Name)
+}
+
+func (c *Controller[request]) reconcileHandler(ctx context.Context, req request, priority int, provenance []priorityqueue.Provenance) {
+	metrics := c.getMetrics()
+
 	// Update metrics after processing each item
 	reconcileStartTS := time.Now()
 	defer func() {
-		c.updateMetrics(time.Since(reconcileStartTS))
+		metrics.reconcileTime.Observe(time.Since(reconcileStartTS).Seconds())
 	}()
 
 	log := c.LogConstructor(&req)
@@ -472,60 +1021,103 @@ func (c *Controller[request]) reconcileHandler(ctx context.Context, req request,
 
 	log = log.WithValues("reconcileID", reconcileID)
 	ctx = logf.IntoContext(ctx, log)
-	ctx = addReconcileID(ctx, reconcileID)
+	ctx = addReconcileID(ctx, reconcileID, c.Name, provenance)
 
 	// RunInformersAndControllers the syncHandler, passing it the Namespace/Name string of the
 	// resource to be synced.
 	log.V(5).Info("Reconciling")
 	result, err := c.Reconcile(ctx, req)
+	if c.initialReconcile != nil {
+		c.initialReconcile.reconciled(req)
+	}
 	if result.Priority != nil {
 		priority = *result.Priority
 	}
+	if err == nil && result.NoOp {
+		metrics.reconcileNoOp.Inc()
+	}
 	switch {
 	case err != nil:
 		if errors.Is(err, reconcile.TerminalError(nil)) {
-			ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Inc()
+			metrics.terminalReconcileErrors.Inc()
 		} else {
-			c.Queue.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: new(priority)}, req)
+			c.Queue.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: new(priority), Provenance: priorityqueue.ProvenanceRequeue}, req)
 		}
-		ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Inc()
-		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Inc()
+		metrics.reconcileErrors.Inc()
+		metrics.reconcileTotalError.Inc()
 		if result.RequeueAfter > 0 || result.Requeue { //nolint: staticcheck // We have to handle Requeue until it is removed
 			log.Info("Warning: Reconciler returned both a result with either RequeueAfter or Requeue set and a non-nil error. RequeueAfter and Requeue will always be ignored if the error is non-nil. For more details, see: https://pkg.go.dev/sigs.k8s.io/controller-runtime/pkg/reconcile#Reconciler")
 		}
-		log.Error(err, "Reconciler error")
+		c.logReconcileError(log, err)
 	case result.RequeueAfter > 0:
-		log.V(5).Info(fmt.Sprintf("Reconcile done, requeueing after %s", result.RequeueAfter))
+		// Formatting the message allocates, skip it if it isn't logged.
+		if log := log.V(5); log.Enabled() {
+			log.Info(fmt.Sprintf("Reconcile done, requeueing after %s", result.RequeueAfter))
+		}
 		// The result.RequeueAfter request will be lost, if it is returned
 		// along with a non-nil error. But this is intended as
 		// We need to drive to stable reconcile loops before queuing due
 		// to result.RequestAfter
 		c.Queue.Forget(req)
-		c.Queue.AddWithOpts(priorityqueue.AddOpts{After: result.RequeueAfter, Priority: new(priority)}, req)
-		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeueAfter).Inc()
+		c.Queue.AddWithOpts(priorityqueue.AddOpts{After: result.RequeueAfter, Priority: new(priority), Provenance: priorityqueue.ProvenanceRequeue}, req)
+		metrics.reconcileTotalRequeueAfter.Inc()
 	case result.Requeue: //nolint: staticcheck // We have to handle it until it is removed
 		log.V(5).Info("Reconcile done, requeueing")
-		c.Queue.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: new(priority)}, req)
-		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeue).Inc()
+		c.Queue.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: new(priority), Provenance: priorityqueue.ProvenanceRequeue}, req)
+		metrics.reconcileTotalRequeue.Inc()
 	default:
 		log.V(5).Info("Reconcile successful")
 		// Finally, if no error occurs we Forget this item so it does not
 		// get queued again until another change happens.
 		c.Queue.Forget(req)
-		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelSuccess).Inc()
+		metrics.reconcileTotalSuccess.Inc()
 	}
 }
 
+// logReconcileError logs an error returned by the Reconciler as configured by ReconcileErrorLogging.
+func (c *Controller[request]) logReconcileError(log logr.Logger, err error) {
+	cfg := c.ReconcileErrorLogging
+	if cfg == nil {
+		log.Error(err, "Reconciler error")
+		return
+	}
+
+	verbosity := cfg.Verbosity
+	if cfg.ExpectedErrorVerbosity != nil {
+		isExpectedError := cfg.IsExpectedError
+		if isExpectedError == nil {
+			isExpectedError = isExpectedReconcileError
+		}
+		if isExpectedError(err) {
+			verbosity = cfg.ExpectedErrorVerbosity
+		}
+	}
+	if verbosity == nil {
+		if cfg.Stacktrace == nil || *cfg.Stacktrace {
+			log.Error(err, "Reconciler error")
+			return
+		}
+		verbosity = new(0)
+	}
+	if log := log.V(*verbosity); log.Enabled() {
+		if cfg.Stacktrace != nil && *cfg.Stacktrace {
+			log.Info("Reconciler error", "error", err, "stacktrace", string(debug.Stack()))
+			return
+		}
+		log.Info("Reconciler error", "error", err)
+	}
+}
+
+// isExpectedReconcileError is the default of ReconcileErrorLogging.IsExpectedError.
+func isExpectedReconcileError(err error) bool {
+	return apierrors.IsNotFound(err) || apierrors.IsConflict(err)
+}
+
 // GetLogger returns this controller's logger.
 func (c *Controller[request]) GetLogger() logr.Logger {
 	return c.LogConstructor(nil)
 }
 
-// updateMetrics updates prometheus metrics within the controller.
-func (c *Controller[request]) updateMetrics(reconcileTime time.Duration) {
-	ctrlmetrics.ReconcileTime.WithLabelValues(c.Name).Observe(reconcileTime.Seconds())
-}
-
 // ReconcileIDFromContext gets the reconcileID from the current context.
 func ReconcileIDFromContext(ctx context.Context) types.UID {
 	r, ok := ctx.Value(reconcileIDKey{}).(types.UID)
@@ -536,12 +1128,57 @@ func ReconcileIDFromContext(ctx context.Context) types.UID {
 	return r
 }
 
+// ControllerNameFromContext returns the name of the controller that runs the current
+// reconciliation, or the empty string if ctx isn't the context of a reconciliation.
+func ControllerNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(controllerNameKey{}).(string)
+	return name
+}
+
+// ProvenanceFromContext returns the provenances that added the request of the current
+// reconciliation to the queue since it was reconciled last. It returns nil if the queue
+// of the controller doesn't track the provenance of its items.
+func ProvenanceFromContext(ctx context.Context) []priorityqueue.Provenance {
+	p, _ := ctx.Value(provenanceKey{}).([]priorityqueue.Provenance)
+	return p
+}
+
 // reconcileIDKey is a context.Context Value key. Its associated value should
 // be a types.UID.
 type reconcileIDKey struct{}
 
-func addReconcileID(ctx context.Context, reconcileID types.UID) context.Context {
-	return context.WithValue(ctx, reconcileIDKey{}, reconcileID)
+// provenanceKey is a context.Context Value key. Its associated value should
+// be a []priorityqueue.Provenance.
+type provenanceKey struct{}
+
+// controllerNameKey is a context.Context Value key. Its associated value should
+// be a string.
+type controllerNameKey struct{}
+
+func addReconcileID(ctx context.Context, reconcileID types.UID, controllerName string, provenance []priorityqueue.Provenance) context.Context {
+	return &reconcileIDContext{Context: ctx, reconcileID: reconcileID, controllerName: controllerName, provenance: provenance}
+}
+
+// reconcileIDContext carries the reconcileID, the controller name and the provenance of a
+// reconciliation. Unlike context.WithValue, it doesn't have to box the reconcileID, which
+// saves an allocation per reconciliation.
+type reconcileIDContext struct {
+	context.Context
+	reconcileID    types.UID
+	controllerName string
+	provenance     []priorityqueue.Provenance
+}
+
+func (c *reconcileIDContext) Value(key any) any {
+	switch key {
+	case reconcileIDKey{}:
+		return c.reconcileID
+	case controllerNameKey{}:
+		return c.controllerName
+	case provenanceKey{}:
+		return c.provenance
+	}
+	return c.Context.Value(key)
 }
 
 type priorityQueueWrapper[request comparable] struct {
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// benchmarkQueue is a queue that drops all items, so that benchmarks only measure
+// the controller.
+type benchmarkQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+}
+
+func (benchmarkQueue) AddWithOpts(priorityqueue.AddOpts, ...reconcile.Request) {}
+func (benchmarkQueue) Forget(reconcile.Request)                                {}
+
+func BenchmarkReconcileHandler(b *testing.B) {
+	results := []struct {
+		name   string
+		result reconcile.Result
+		err    error
+	}{
+		{name: "success"},
+		{name: "requeue_after", result: reconcile.Result{RequeueAfter: time.Minute}},
+		{name: "error", err: errors.New("boom")},
+	}
+	for _, tc := range results {
+		b.Run(tc.name, func(b *testing.B) {
+			c := New(Options[reconcile.Request]{
+				Name: "benchmark-" + tc.name,
+				Do: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					return tc.result, tc.err
+				}),
+				LogConstructor: func(*reconcile.Request) logr.Logger {
+					return logr.Discard()
+				},
+			})
+			c.Queue = benchmarkQueue{}
+			c.initMetrics()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "benchmark"}}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				c.reconcileHandler(b.Context(), req, 0, nil)
+			}
+		})
+	}
+}
@@ -27,20 +27,27 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
 	"go.uber.org/goleak"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/cleanup"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -145,6 +152,17 @@ var _ = Describe("controller", func() {
 			Expect(err.Error()).To(ContainSubstring("[recovered]"))
 		})
 
+		It("should run the cleanups of a reconciliation also if it panicked", func(ctx SpecContext) {
+			ran := 0
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				Expect(cleanup.Add(ctx, func(context.Context) error { ran++; return nil })).To(Succeed())
+				panic("expected panic")
+			})
+			_, err := ctrl.Reconcile(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Expect(ran).To(Equal(1))
+		})
+
 		It("should time out if ReconciliationTimeout is set", func(ctx SpecContext) {
 			ctrl.ReconciliationTimeout = time.Duration(1) // One nanosecond
 			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
@@ -291,9 +309,147 @@ var _ = Describe("controller", func() {
 				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "bar"}})
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("health events", func() {
+			var recorder *events.FakeRecorder
+
+			BeforeEach(func() {
+				recorder = events.NewFakeRecorder(10)
+				ctrl.HealthEventRecorder = recorder
+				ctrl.HealthEventTarget = &corev1.ObjectReference{Kind: "Pod", Namespace: "operator", Name: "operator-0"}
+			})
+
+			It("should emit an Event when a panic is recovered", func(ctx SpecContext) {
+				ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					panic("expected panic")
+				})
+				_, err := ctrl.Reconcile(ctx, request)
+				Expect(err).To(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal("Warning ReconcilePanic Reconciliation of foo/bar panicked: expected panic")))
+			})
+
+			It("should emit an Event when a reconciliation times out", func(ctx SpecContext) {
+				ctrl.ReconciliationTimeout = time.Duration(1)
+				ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					<-ctx.Done()
+					return reconcile.Result{}, ctx.Err()
+				})
+				_, err := ctrl.Reconcile(ctx, request)
+				Expect(err).To(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal("Warning ReconcileTimeout Reconciliation of foo/bar timed out after 1ns")))
+			})
+
+			It("should emit an Event when the sources fail to sync", func(ctx SpecContext) {
+				ctrl.CacheSyncTimeout = time.Second
+				ctrl.startWatches = []source.TypedSource[reconcile.Request]{
+					source.Kind(&informertest.FakeInformers{Synced: new(false)}, &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}),
+				}
+				ctrl.Name = testControllerName
+				Expect(ctrl.Start(ctx)).NotTo(Succeed())
+				Expect(recorder.Events).To(Receive(HavePrefix("Warning SourceSyncFailed Sources of controller testcontroller failed to sync: ")))
+			})
+
+			It("should not emit Events without a target", func(ctx SpecContext) {
+				ctrl.HealthEventTarget = nil
+				ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					panic("expected panic")
+				})
+				_, err := ctrl.Reconcile(ctx, request)
+				Expect(err).To(HaveOccurred())
+				Expect(recorder.Events).NotTo(Receive())
+			})
+		})
 	})
 
 	Describe("Start", func() {
+		It("should change the number of workers while running", func(specCtx SpecContext) {
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			var running, maxRunning atomic.Int32
+			release := make(chan struct{})
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					current := maxRunning.Load()
+					if n <= current || maxRunning.CompareAndSwap(current, n) {
+						break
+					}
+				}
+				<-release
+				return reconcile.Result{}, nil
+			})
+			ctrl.CacheSyncTimeout = time.Second
+
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+
+			for i := range 3 {
+				queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: strconv.Itoa(i)}})
+			}
+			Eventually(running.Load).Should(Equal(int32(1)))
+			Consistently(running.Load, 100*time.Millisecond).Should(Equal(int32(1)))
+
+			By("Scaling up the workers")
+			ctrl.SetMaxConcurrentReconciles(3)
+			Eventually(running.Load).Should(Equal(int32(3)))
+
+			By("Scaling down the workers")
+			ctrl.SetMaxConcurrentReconciles(1)
+			close(release)
+			Eventually(running.Load).Should(Equal(int32(0)))
+			Expect(maxRunning.Load()).To(Equal(int32(3)))
+
+			cancel()
+			Eventually(stopped).Should(BeClosed())
+		})
+
+		It("should follow the concurrency of the controller configuration only if it is defaulted from it", func() {
+			ctrl.ReloadControllerConfig(config.Controller{MaxConcurrentReconciles: 5})
+			Expect(ctrl.MaxConcurrentReconciles).To(Equal(1))
+
+			ctrl.MaxConcurrentReconcilesFromConfig = true
+			ctrl.ReloadControllerConfig(config.Controller{MaxConcurrentReconciles: 5})
+			Expect(ctrl.MaxConcurrentReconciles).To(Equal(5))
+		})
+
+		It("should export its configuration as info metric while running", func(specCtx SpecContext) {
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			ctrl.Name = "info-metric"
+			ctrl.CacheSyncTimeout = time.Second
+			ctrl.ReconciliationTimeout = time.Minute
+			ctrl.LeaderElected = new(false)
+
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+
+			info := func(maxConcurrentReconciles string) func() float64 {
+				return func() float64 {
+					return testutil.ToFloat64(ctrlmetrics.ControllerInfo.WithLabelValues(ctrl.Name, maxConcurrentReconciles, "1m0s", "1s", "false", "false"))
+				}
+			}
+			Eventually(info("1")).Should(Equal(1.0))
+
+			By("Changing the number of workers")
+			ctrl.SetMaxConcurrentReconciles(2)
+			Expect(info("2")()).To(Equal(1.0))
+			Expect(ctrlmetrics.ControllerInfo.DeletePartialMatch(prometheus.Labels{"controller": ctrl.Name})).To(Equal(1))
+
+			cancel()
+			Eventually(stopped).Should(BeClosed())
+		})
+
 		It("should return an error if there is an error waiting for the informers", func(ctx SpecContext) {
 			ctrl.CacheSyncTimeout = time.Second
 			f := false
@@ -558,14 +714,191 @@ var _ = Describe("controller", func() {
 			Expect(ctrl.Start(ctx)).To(Equal(err))
 		})
 
-		It("should return an error if it gets started more than once", func(specCtx SpecContext) {
-			// Use a cancelled context so Start doesn't block
+		It("should return an error if it gets started while it is running", func(specCtx SpecContext) {
 			ctx, cancel := context.WithCancel(specCtx)
-			cancel()
-			Expect(ctrl.Start(ctx)).To(Succeed())
+			done := make(chan error)
+			go func() { done <- ctrl.Start(ctx) }()
+			Eventually(ctrl.running.Load).Should(BeTrue())
+
 			err := ctrl.Start(ctx)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("controller was started more than once. This is likely to be caused by being added to a manager multiple times"))
+
+			cancel()
+			Eventually(done).Should(Receive(Succeed()))
+		})
+
+		It("should restart with all sources when it gets started again after Start returned", func(specCtx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			ctrl.LeaderElected = new(false)
+			var starts atomic.Int32
+			Expect(ctrl.Watch(source.Func(func(ctx context.Context, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+				starts.Add(1)
+				return nil
+			}))).To(Succeed())
+
+			for i := int32(1); i <= 2; i++ {
+				ctx, cancel := context.WithCancel(specCtx)
+				done := make(chan error)
+				go func() { done <- ctrl.Start(ctx) }()
+				Eventually(starts.Load).Should(Equal(i))
+				Eventually(ctrl.running.Load).Should(BeTrue())
+				cancel()
+				Eventually(done).Should(Receive(Succeed()))
+			}
+		})
+
+		It("should start all sources again after PrepareRestart", func(specCtx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			var starts atomic.Int32
+			src := source.Func(func(ctx context.Context, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+				starts.Add(1)
+				return nil
+			})
+			Expect(ctrl.Watch(src)).To(Succeed())
+
+			run := func(expectedStarts int32) {
+				ctx, cancel := context.WithCancel(specCtx)
+				done := make(chan error)
+				go func() { done <- ctrl.Start(ctx) }()
+				Eventually(starts.Load).Should(Equal(expectedStarts))
+				cancel()
+				Eventually(done).Should(Receive(Succeed()))
+			}
+
+			run(1)
+			ctrl.PrepareRestart()
+			run(2)
+		})
+
+		It("should pass the initial reconcile check only once all objects present at startup were reconciled", func(ctx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			ctrl.LeaderElected = new(false)
+			ctrl.initialReconcile = newInitialReconcileTracker[reconcile.Request]()
+			check := ctrl.InitialReconcileCheck()
+			Expect(check(nil)).To(MatchError(ContainSubstring("sources have not synced yet")))
+
+			other := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "baz"}}
+			Expect(ctrl.Watch(source.Func(func(_ context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+				q.Add(request)
+				q.Add(other)
+				return nil
+			}))).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+
+			By("Counting failed reconciliations as well")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.TerminalError(errors.New("expected error")))
+			<-reconciled
+			Eventually(func() error { return check(nil) }).Should(MatchError("1 objects have not been reconciled yet"))
+
+			By("Ignoring objects that are added after the sources synced")
+			ctrl.Queue.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "new"}})
+			fakeReconcile.AddResult(reconcile.Result{}, nil)
+			<-reconciled
+			Eventually(func() error { return check(nil) }).Should(Succeed())
+			fakeReconcile.AddResult(reconcile.Result{}, nil)
+			<-reconciled
+		})
+
+		It("should pass the initial reconcile check of controllers that need leader election while they are not started", func() {
+			ctrl.initialReconcile = newInitialReconcileTracker[reconcile.Request]()
+			check := ctrl.InitialReconcileCheck()
+			Expect(check(nil)).To(Succeed())
+
+			ctrl.starting.Store(true)
+			Expect(check(nil)).To(MatchError(ContainSubstring("sources have not synced yet")))
+		})
+
+		It("should fail the readiness check until the sources synced", func(specCtx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			ctrl.LeaderElected = new(false)
+			ready := ctrl.ReadinessCheck()
+			Expect(ready(nil)).To(MatchError("controller has not been started yet"))
+
+			src := &bisignallingSource[reconcile.Request]{
+				startCall: make(chan workqueue.TypedRateLimitingInterface[reconcile.Request]),
+				startDone: make(chan error, 1),
+				waitCall:  make(chan struct{}),
+				waitDone:  make(chan error, 1),
+			}
+			Expect(ctrl.Watch(src)).To(Succeed())
+
+			ctx, cancel := context.WithCancel(specCtx)
+			done := make(chan error)
+			go func() { done <- ctrl.Start(ctx) }()
+			Eventually(src.startCall).Should(Receive())
+			src.startDone <- nil
+			Eventually(src.waitCall).Should(BeClosed())
+			Expect(ready(nil)).To(MatchError(ContainSubstring("controller is starting")))
+
+			src.waitDone <- nil
+			Eventually(func() error { return ready(nil) }).Should(Succeed())
+			Expect(ctrl.LivenessCheck()(nil)).To(Succeed())
+
+			cancel()
+			Eventually(done).Should(Receive(Succeed()))
+			Expect(ready(nil)).To(HaveOccurred())
+		})
+
+		It("should pass the readiness check of controllers that need leader election before they are started", func() {
+			Expect(ctrl.ReadinessCheck()(nil)).To(Succeed())
+		})
+
+		It("should fail the liveness check if the controller is running without workers", func() {
+			live := ctrl.LivenessCheck()
+			Expect(live(nil)).To(Succeed())
+
+			ctrl.running.Store(true)
+			Expect(live(nil)).To(MatchError("controller is running without workers"))
+			ctrl.runningWorkers.Add(1)
+			Expect(live(nil)).To(Succeed())
+		})
+
+		It("should remove the event handlers of Kind sources when stopped so a restart doesn't leak them", func(specCtx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			informers := &informertest.FakeInformers{}
+			informer, err := informers.FakeInformerFor(specCtx, &corev1.Pod{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ctrl.Watch(source.Kind(informers, &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}))).To(Succeed())
+
+			run := func() {
+				ctx, cancel := context.WithCancel(specCtx)
+				done := make(chan error)
+				go func() { done <- ctrl.Start(ctx) }()
+				Eventually(informer.HandlerCount).Should(Equal(1))
+				cancel()
+				Eventually(done).Should(Receive(Succeed()))
+				Eventually(informer.HandlerCount).Should(Equal(0))
+			}
+
+			run()
+			ctrl.PrepareRestart()
+			run()
+		})
+
+		It("should fail the liveness check once the informer of a Kind source was removed", func(specCtx SpecContext) {
+			ctrl.CacheSyncTimeout = 10 * time.Second
+			informers := &informertest.FakeInformers{}
+			Expect(ctrl.Watch(source.Kind(informers, &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}))).To(Succeed())
+
+			ctx, cancel := context.WithCancel(specCtx)
+			done := make(chan error)
+			go func() { done <- ctrl.Start(ctx) }()
+			live := ctrl.LivenessCheck()
+			Eventually(ctrl.running.Load).Should(BeTrue())
+			Eventually(func() error { return live(nil) }).Should(Succeed())
+
+			Expect(informers.RemoveInformer(specCtx, &corev1.Pod{})).To(Succeed())
+			Eventually(func() error { return live(nil) }).Should(MatchError(ContainSubstring("was stopped")))
+
+			cancel()
+			Eventually(done).Should(Receive(Succeed()))
+			ctrl.PrepareRestart()
+			Expect(ctrl.failedSource.Load()).To(BeNil())
 		})
 
 		It("should check for correct TypedSyncingSource if custom types are used", func(specCtx SpecContext) {
@@ -952,6 +1285,7 @@ var _ = Describe("controller", func() {
 				AddOpts: priorityqueue.AddOpts{
 					RateLimited: true,
 					Priority:    new(10),
+					Provenance:  priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -981,6 +1315,7 @@ var _ = Describe("controller", func() {
 				AddOpts: priorityqueue.AddOpts{
 					RateLimited: true,
 					Priority:    new(99),
+					Provenance:  priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -1016,6 +1351,113 @@ var _ = Describe("controller", func() {
 			Eventually(func() int { return dq.NumRequeues(request) }).Should(Equal(0))
 		})
 
+		It("should hand a RequeueAfter of a reconcile that is in flight at shutdown to OnShutdownPendingItems", func(specCtx SpecContext) {
+			pending := make(chan []priorityqueue.PendingItem[reconcile.Request], 1)
+			q := priorityqueue.New[reconcile.Request]("controller1", func(o *priorityqueue.Opts[reconcile.Request]) {
+				o.OnShutdownPendingItems = func(items []priorityqueue.PendingItem[reconcile.Request]) {
+					pending <- items
+				}
+			})
+			ctrl.NewQueue = func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return q
+			}
+
+			reconcileStarted := make(chan struct{})
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				close(reconcileStarted)
+				<-ctx.Done()
+				return reconcile.Result{RequeueAfter: time.Hour}, nil
+			})
+
+			ctx, cancel := context.WithCancel(specCtx)
+			done := make(chan error)
+			go func() { done <- ctrl.Start(ctx) }()
+
+			q.Add(request)
+			Eventually(reconcileStarted).Should(BeClosed())
+
+			By("Stopping the controller while the reconcile is in flight")
+			before := time.Now()
+			cancel()
+			Eventually(done).Should(Receive(Succeed()))
+
+			var items []priorityqueue.PendingItem[reconcile.Request]
+			Eventually(pending).Should(Receive(&items))
+			Expect(items).To(HaveLen(1))
+			Expect(items[0].Key).To(Equal(request))
+			Expect(items[0].ReadyAt).To(BeTemporally("~", before.Add(time.Hour), time.Second))
+		})
+
+		It("should pass the provenance of requests to the reconciler", func(ctx SpecContext) {
+			q := priorityqueue.New[reconcile.Request]("controller1")
+			ctrl.NewQueue = func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return q
+			}
+			provenances := make(chan []priorityqueue.Provenance, 2)
+			var reconciles atomic.Int32
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				provenances <- ProvenanceFromContext(ctx)
+				if reconciles.Add(1) == 1 {
+					return reconcile.Result{RequeueAfter: time.Millisecond}, nil
+				}
+				return reconcile.Result{}, nil
+			})
+			Expect(ctrl.PendingRequests()).To(BeNil())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+			Eventually(func() bool { return ctrl.provenanceQueue.Load() != nil }).Should(BeTrue())
+
+			priorityqueue.WithProvenance[reconcile.Request](q, priorityqueue.ProvenanceWatch).Add(request)
+			Eventually(provenances).Should(Receive(Equal([]priorityqueue.Provenance{priorityqueue.ProvenanceWatch})))
+			Eventually(provenances).Should(Receive(Equal([]priorityqueue.Provenance{priorityqueue.ProvenanceRequeue})))
+		})
+
+		It("should return the pending requests of a running controller", func(ctx SpecContext) {
+			q := priorityqueue.New[reconcile.Request]("controller1")
+			ctrl.NewQueue = func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return q
+			}
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+
+			q.AddWithOpts(priorityqueue.AddOpts{After: time.Hour, Provenance: priorityqueue.ProvenanceWatch}, request)
+			Eventually(ctrl.PendingRequests).Should(ConsistOf(And(
+				HaveField("Key", request),
+				HaveField("Provenance", []priorityqueue.Provenance{priorityqueue.ProvenanceWatch}),
+			)))
+			Expect(ctrl.DescribePendingRequests()).To(HaveLen(1))
+		})
+
+		It("should reconcile a delayed request right away with EnqueueNow", func(ctx SpecContext) {
+			q := priorityqueue.New[reconcile.Request]("controller1")
+			ctrl.NewQueue = func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return q
+			}
+			provenances := make(chan []priorityqueue.Provenance, 1)
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				provenances <- ProvenanceFromContext(ctx)
+				return reconcile.Result{}, nil
+			})
+			Expect(ctrl.EnqueueNow(request, "test")).NotTo(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+			Eventually(func() bool { return ctrl.runningQueue.Load() != nil }).Should(BeTrue())
+
+			q.AddWithOpts(priorityqueue.AddOpts{After: time.Hour, Provenance: priorityqueue.ProvenanceWatch}, request)
+			Expect(ctrl.EnqueueNow(request, "test")).To(Succeed())
+			Eventually(provenances).Should(Receive(ConsistOf(priorityqueue.ProvenanceWatch, priorityqueue.ProvenanceEnqueueNow)))
+			Expect(q.Len()).To(Equal(0))
+		})
+
 		It("should retain the priority with RequeueAfter", func(ctx SpecContext) {
 			q := &fakePriorityQueue{PriorityQueue: priorityqueue.New[reconcile.Request]("controller1")}
 			ctrl.NewQueue = func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
@@ -1038,8 +1480,9 @@ var _ = Describe("controller", func() {
 				return q.added
 			}).Should(Equal([]priorityQueueAddition{{
 				AddOpts: priorityqueue.AddOpts{
-					After:    time.Millisecond * 100,
-					Priority: new(10),
+					After:      time.Millisecond * 100,
+					Priority:   new(10),
+					Provenance: priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -1067,8 +1510,9 @@ var _ = Describe("controller", func() {
 				return q.added
 			}).Should(Equal([]priorityQueueAddition{{
 				AddOpts: priorityqueue.AddOpts{
-					After:    time.Millisecond * 100,
-					Priority: new(99),
+					After:      time.Millisecond * 100,
+					Priority:   new(99),
+					Provenance: priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -1127,6 +1571,7 @@ var _ = Describe("controller", func() {
 				AddOpts: priorityqueue.AddOpts{
 					RateLimited: true,
 					Priority:    new(10),
+					Provenance:  priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -1156,6 +1601,7 @@ var _ = Describe("controller", func() {
 				AddOpts: priorityqueue.AddOpts{
 					RateLimited: true,
 					Priority:    new(99),
+					Provenance:  priorityqueue.ProvenanceRequeue,
 				},
 				items: []reconcile.Request{request},
 			}}))
@@ -1173,6 +1619,37 @@ var _ = Describe("controller", func() {
 			// TODO(community): write this test
 		})
 
+		Context("prometheus metric reconcile_noop_total", func() {
+			BeforeEach(func() {
+				ctrlmetrics.ReconcileNoOpTotal.Reset()
+			})
+
+			It("should count successful reconciliations that didn't change anything", func(ctx SpecContext) {
+				go func() {
+					defer GinkgoRecover()
+					Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+				}()
+
+				By("Invoking Reconciler which reports a no-op")
+				queue.Add(request)
+				fakeReconcile.AddResult(reconcile.Result{NoOp: true}, nil)
+				Expect(<-reconciled).To(Equal(request))
+				Eventually(func() float64 {
+					return testutil.ToFloat64(ctrlmetrics.ReconcileNoOpTotal.WithLabelValues(ctrl.Name))
+				}).Should(Equal(1.0))
+
+				By("Invoking Reconciler which reports a no-op with an error")
+				queue.Add(request)
+				fakeReconcile.AddResult(reconcile.Result{NoOp: true}, errors.New("expected error: reconcile"))
+				Expect(<-reconciled).To(Equal(request))
+				fakeReconcile.AddResult(reconcile.Result{}, nil)
+				Expect(<-reconciled).To(Equal(request))
+				Consistently(func() float64 {
+					return testutil.ToFloat64(ctrlmetrics.ReconcileNoOpTotal.WithLabelValues(ctrl.Name))
+				}, 100*time.Millisecond).Should(Equal(1.0))
+			})
+		})
+
 		Context("prometheus metric reconcile_total", func() {
 			var reconcileTotal dto.Metric
 
@@ -1892,6 +2369,77 @@ var _ = Describe("controller", func() {
 	})
 })
 
+var _ = Describe("logReconcileError", func() {
+	var ctrl *Controller[reconcile.Request]
+	var lines []string
+	var testLog logr.Logger
+
+	BeforeEach(func() {
+		ctrl = New[reconcile.Request](Options[reconcile.Request]{Name: testControllerName})
+		lines = nil
+		testLog = funcr.New(func(prefix, args string) {
+			lines = append(lines, args)
+		}, funcr.Options{Verbosity: 2})
+	})
+
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "foo", errors.New("object was modified"))
+
+	It("should log errors at error level by default", func() {
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(ContainSubstring(`"msg"="Reconciler error"`), ContainSubstring(`"error"="boom"`), Not(ContainSubstring(`"level"`)))))
+	})
+
+	It("should log errors at the configured verbosity", func() {
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{Verbosity: new(1)}
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(ContainSubstring(`"level"=1`), ContainSubstring(`"error"="boom"`))))
+	})
+
+	It("should log expected errors at their own verbosity", func() {
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{ExpectedErrorVerbosity: new(3)}
+
+		ctrl.logReconcileError(testLog, conflict)
+		Expect(lines).To(BeEmpty())
+
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(ContainSubstring(`"error"="boom"`), Not(ContainSubstring(`"level"`)))))
+	})
+
+	It("should use IsExpectedError to detect expected errors", func() {
+		errExpected := errors.New("expected")
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{
+			ExpectedErrorVerbosity: new(2),
+			IsExpectedError: func(err error) bool {
+				return errors.Is(err, errExpected)
+			},
+		}
+
+		ctrl.logReconcileError(testLog, fmt.Errorf("wrapped: %w", errExpected))
+		ctrl.logReconcileError(testLog, conflict)
+		Expect(lines).To(HaveExactElements(
+			And(ContainSubstring(`"level"=2`), ContainSubstring(`"error"="wrapped: expected"`)),
+			Not(ContainSubstring(`"level"`)),
+		))
+	})
+
+	It("should not log errors at error level with stack traces disabled", func() {
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{Stacktrace: new(false)}
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(ContainSubstring(`"level"=0`), ContainSubstring(`"error"="boom"`), Not(ContainSubstring(`"stacktrace"`)))))
+	})
+
+	It("should add stack traces to errors logged at a verbosity with stack traces enabled", func() {
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{Verbosity: new(1), Stacktrace: new(true)}
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(ContainSubstring(`"level"=1`), ContainSubstring(`"stacktrace"="goroutine`))))
+
+		lines = nil
+		ctrl.ReconcileErrorLogging = &config.ReconcileErrorLogging{Stacktrace: new(true)}
+		ctrl.logReconcileError(testLog, errors.New("boom"))
+		Expect(lines).To(ConsistOf(And(Not(ContainSubstring(`"level"`)), Not(ContainSubstring(`"stacktrace"`)))))
+	})
+})
+
 var _ = Describe("ReconcileIDFromContext function", func() {
 	It("should return an empty string if there is nothing in the context", func(ctx SpecContext) {
 		reconcileID := ReconcileIDFromContext(ctx)
@@ -1901,10 +2449,22 @@ var _ = Describe("ReconcileIDFromContext function", func() {
 
 	It("should return the correct reconcileID from context", func(specContext SpecContext) {
 		const expectedReconcileID = types.UID("uuid")
-		ctx := addReconcileID(specContext, expectedReconcileID)
+		ctx := addReconcileID(specContext, expectedReconcileID, "foo", nil)
 		reconcileID := ReconcileIDFromContext(ctx)
 
 		Expect(reconcileID).To(Equal(expectedReconcileID))
+		Expect(ControllerNameFromContext(ctx)).To(Equal("foo"))
+	})
+})
+
+var _ = Describe("ProvenanceFromContext function", func() {
+	It("should return nil if there is nothing in the context", func(ctx SpecContext) {
+		Expect(ProvenanceFromContext(ctx)).To(BeNil())
+	})
+
+	It("should return the provenance from context", func(specContext SpecContext) {
+		ctx := addReconcileID(specContext, "uuid", "foo", []priorityqueue.Provenance{priorityqueue.ProvenanceWatch})
+		Expect(ProvenanceFromContext(ctx)).To(Equal([]priorityqueue.Provenance{priorityqueue.ProvenanceWatch}))
 	})
 })
 
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+// initialReconcileTracker records the requests that are added to the queue until the
+// sources of a controller have synced, i.e. one request per object that existed when the
+// controller was started, and reports whether all of them were reconciled since.
+type initialReconcileTracker[request comparable] struct {
+	mu      sync.Mutex
+	synced  bool
+	pending sets.Set[request]
+
+	// done is set once all recorded requests were reconciled. The tracker stays
+	// done, also if the controller is restarted.
+	done atomic.Bool
+}
+
+func newInitialReconcileTracker[request comparable]() *initialReconcileTracker[request] {
+	return &initialReconcileTracker[request]{pending: sets.New[request]()}
+}
+
+// add records requests that are added to the queue before the sources synced.
+func (t *initialReconcileTracker[request]) add(items ...request) {
+	if t.done.Load() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.synced {
+		t.pending.Insert(items...)
+	}
+}
+
+// sourcesSynced stops recording requests, all objects that existed when the
+// sources were started have been added to the queue by now.
+func (t *initialReconcileTracker[request]) sourcesSynced() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.synced = true
+	t.updateDoneLocked()
+}
+
+// reconciled marks a request as reconciled, regardless of the result of the
+// reconciliation, so that objects that keep failing don't block readiness.
+func (t *initialReconcileTracker[request]) reconciled(req request) {
+	if t.done.Load() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending.Delete(req)
+	t.updateDoneLocked()
+}
+
+// reset starts recording again if the controller is restarted before it was done,
+// as its new sources add all objects to the new queue again.
+func (t *initialReconcileTracker[request]) reset() {
+	if t.done.Load() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.synced = false
+	t.pending = sets.New[request]()
+}
+
+func (t *initialReconcileTracker[request]) updateDoneLocked() {
+	if t.synced && t.pending.Len() == 0 {
+		t.pending = nil
+		t.done.Store(true)
+	}
+}
+
+// check implements healthz.Checker.
+func (t *initialReconcileTracker[request]) check(_ *http.Request) error {
+	if t.done.Load() {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.synced {
+		return errors.New("sources have not synced yet")
+	}
+	return fmt.Errorf("%d objects have not been reconciled yet", t.pending.Len())
+}
+
+// initialReconcileQueue records all requests that are added to the queue with the
+// initialReconcileTracker.
+type initialReconcileQueue[request comparable] struct {
+	priorityqueue.PriorityQueue[request]
+	tracker *initialReconcileTracker[request]
+}
+
+func (q *initialReconcileQueue[request]) Add(item request) {
+	q.tracker.add(item)
+	q.PriorityQueue.Add(item)
+}
+
+func (q *initialReconcileQueue[request]) AddAfter(item request, duration time.Duration) {
+	q.tracker.add(item)
+	q.PriorityQueue.AddAfter(item, duration)
+}
+
+func (q *initialReconcileQueue[request]) AddRateLimited(item request) {
+	q.tracker.add(item)
+	q.PriorityQueue.AddRateLimited(item)
+}
+
+func (q *initialReconcileQueue[request]) AddWithOpts(o priorityqueue.AddOpts, items ...request) {
+	q.tracker.add(items...)
+	q.PriorityQueue.AddWithOpts(o, items...)
+}
@@ -89,6 +89,29 @@ var (
 		Name: "controller_runtime_reconcile_timeouts_total",
 		Help: "Total number of reconciliation timeouts per controller",
 	}, []string{"controller"})
+
+	// ReconcileEnqueueNowTotal is a prometheus counter metric which holds the total
+	// number of requests that were enqueued with EnqueueNow per controller.
+	ReconcileEnqueueNowTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_reconcile_enqueue_now_total",
+		Help: "Total number of requests enqueued with maximum priority per controller",
+	}, []string{"controller"})
+
+	// ReconcileNoOpTotal is a prometheus counter metric which holds the total number
+	// of successful reconciliations that reported that they didn't change anything,
+	// see reconcile.Result.NoOp, per controller.
+	ReconcileNoOpTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_reconcile_noop_total",
+		Help: "Total number of successful reconciliations that didn't change anything per controller",
+	}, []string{"controller"})
+
+	// ControllerInfo is a prometheus info metric which holds the configuration of
+	// each started controller in its labels, its value is always 1. It allows to
+	// compare the configuration of many deployments of an operator.
+	ControllerInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_controller_info",
+		Help: "Configuration of the controller, the value is always 1",
+	}, []string{"controller", "max_concurrent_reconciles", "reconciliation_timeout", "cache_sync_timeout", "warmup", "leader_elected"})
 )
 
 func init() {
@@ -101,9 +124,29 @@ func init() {
 		WorkerCount,
 		ActiveWorkers,
 		ReconcileTimeouts,
+		ReconcileEnqueueNowTotal,
+		ReconcileNoOpTotal,
+		ControllerInfo,
 		// expose process metrics like CPU, Memory, file descriptor usage etc.
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		// expose all Go runtime metrics like GC stats, memory stats etc.
 		collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(collectors.MetricsAll)),
 	)
 }
+
+// DeleteControllerMetrics deletes all series of the metrics above that have the
+// given controller name as label value.
+func DeleteControllerMetrics(name string) {
+	labels := prometheus.Labels{"controller": name}
+	ReconcileTotal.DeletePartialMatch(labels)
+	ReconcileErrors.DeletePartialMatch(labels)
+	TerminalReconcileErrors.DeletePartialMatch(labels)
+	ReconcilePanics.DeletePartialMatch(labels)
+	ReconcileTime.DeletePartialMatch(labels)
+	WorkerCount.DeletePartialMatch(labels)
+	ActiveWorkers.DeletePartialMatch(labels)
+	ReconcileTimeouts.DeletePartialMatch(labels)
+	ReconcileEnqueueNowTotal.DeletePartialMatch(labels)
+	ReconcileNoOpTotal.DeletePartialMatch(labels)
+	ControllerInfo.DeletePartialMatch(labels)
+}
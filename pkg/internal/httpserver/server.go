@@ -17,3 +17,12 @@ func New(ctx context.Context, handler http.Handler) *http.Server {
 		ReadHeaderTimeout: 32 * time.Second,
 	}
 }
+
+// WithMiddlewares wraps handler with the middlewares. The first middleware is the
+// outermost one, i.e. it sees a request first.
+func WithMiddlewares(handler http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
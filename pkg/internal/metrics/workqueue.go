@@ -111,6 +111,19 @@ func init() {
 	workqueue.SetProvider(WorkqueueMetricsProvider{})
 }
 
+// DeleteWorkqueueMetrics deletes all series of the workqueue metrics that have the
+// given queue name as label value.
+func DeleteWorkqueueMetrics(name string) {
+	labels := prometheus.Labels{"name": name}
+	depth.DeletePartialMatch(labels)
+	adds.DeletePartialMatch(labels)
+	latency.DeletePartialMatch(labels)
+	workDuration.DeletePartialMatch(labels)
+	unfinished.DeletePartialMatch(labels)
+	longestRunningProcessor.DeletePartialMatch(labels)
+	retries.DeletePartialMatch(labels)
+}
+
 type WorkqueueMetricsProvider struct{}
 
 func (WorkqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -130,3 +131,22 @@ var _ = Describe("depthWithPriorityMetric", func() {
 		Expect(fakeVec.gauges["test|test|1|"]).To(Equal(200))
 	})
 })
+
+var _ = Describe("DeleteWorkqueueMetrics", func() {
+	It("deletes the series of the given queue only", func() {
+		provider := WorkqueueMetricsProvider{}
+		provider.NewAddsMetric("delete-queue").Inc()
+		provider.NewRetriesMetric("delete-queue").Inc()
+		provider.NewDepthMetricWithPriority("delete-queue").Inc(10)
+		provider.NewAddsMetric("keep-queue").Inc()
+		provider.NewDepthMetricWithPriority("keep-queue").Inc(10)
+
+		DeleteWorkqueueMetrics("delete-queue")
+
+		Expect(adds.DeleteLabelValues("delete-queue", "delete-queue")).To(BeFalse())
+		Expect(retries.DeleteLabelValues("delete-queue", "delete-queue")).To(BeFalse())
+		Expect(depth.DeleteLabelValues("delete-queue", "delete-queue", "10")).To(BeFalse())
+		Expect(testutil.ToFloat64(adds.WithLabelValues("keep-queue", "keep-queue"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(depth.WithLabelValues("keep-queue", "keep-queue", "10"))).To(Equal(1.0))
+	})
+})
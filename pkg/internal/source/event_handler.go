@@ -23,6 +23,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
@@ -41,10 +42,11 @@ func NewEventHandler[object any, request comparable](
 	handler handler.TypedEventHandler[object, request],
 	predicates []predicate.TypedPredicate[object]) *EventHandler[object, request] {
 	return &EventHandler[object, request]{
-		ctx:        ctx,
-		handler:    handler,
-		queue:      queue,
-		predicates: predicates,
+		ctx:         ctx,
+		handler:     handler,
+		queue:       priorityqueue.WithProvenance(queue, priorityqueue.ProvenanceWatch),
+		resyncQueue: priorityqueue.WithProvenance(queue, priorityqueue.ProvenanceResync),
+		predicates:  predicates,
 	}
 }
 
@@ -54,9 +56,12 @@ type EventHandler[object any, request comparable] struct {
 	// that is used to propagate cancellation signals to each handler function.
 	ctx context.Context
 
-	handler    handler.TypedEventHandler[object, request]
-	queue      workqueue.TypedRateLimitingInterface[request]
-	predicates []predicate.TypedPredicate[object]
+	handler handler.TypedEventHandler[object, request]
+	// queue adds the items with the watch provenance and resyncQueue with the resync
+	// provenance, which is used for update events of unchanged objects.
+	queue       workqueue.TypedRateLimitingInterface[request]
+	resyncQueue workqueue.TypedRateLimitingInterface[request]
+	predicates  []predicate.TypedPredicate[object]
 }
 
 // OnAdd creates CreateEvent and calls Create on EventHandler.
@@ -113,10 +118,17 @@ func (e *EventHandler[object, request]) OnUpdate(oldObj, newObj any) {
 		}
 	}
 
+	queue := e.queue
+	if oldObj, ok := any(u.ObjectOld).(client.Object); ok {
+		if newObj, ok := any(u.ObjectNew).(client.Object); ok && oldObj.GetResourceVersion() == newObj.GetResourceVersion() {
+			queue = e.resyncQueue
+		}
+	}
+
 	// Invoke update handler
 	ctx, cancel := context.WithCancel(e.ctx)
 	defer cancel()
-	e.handler.Update(ctx, u, e.queue)
+	e.handler.Update(ctx, u, queue)
 }
 
 // OnDelete creates DeleteEvent and calls Delete on EventHandler.
@@ -18,12 +18,14 @@ package internal_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -35,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -100,6 +103,23 @@ var _ = Describe("Internal", func() {
 			instance.OnAdd(pod, false)
 		})
 
+		It("should add the requests with the provenance of the event", func(ctx SpecContext) {
+			q := priorityqueue.New[reconcile.Request]("test")
+			DeferCleanup(q.ShutDown)
+			pq := q.(priorityqueue.ProvenanceQueue[reconcile.Request])
+			instance = internal.NewEventHandler(ctx, q, &handler.EnqueueRequestForObject{}, nil)
+
+			newPod.ResourceVersion = "2"
+			instance.OnUpdate(pod, newPod)
+			_, _, provenance, _ := pq.GetWithProvenance()
+			Expect(provenance).To(Equal([]priorityqueue.Provenance{priorityqueue.ProvenanceWatch}))
+			q.Done(reconcile.Request{})
+
+			instance.OnUpdate(newPod, newPod)
+			_, _, provenance, _ = pq.GetWithProvenance()
+			Expect(provenance).To(Equal([]priorityqueue.Provenance{priorityqueue.ProvenanceResync}))
+		})
+
 		It("should used Predicates to filter CreateEvents", func(ctx SpecContext) {
 			instance = internal.NewEventHandler(ctx, &controllertest.Queue{}, setfuncs, []predicate.Predicate{
 				predicate.Funcs{CreateFunc: func(event.CreateEvent) bool { return false }},
@@ -316,6 +336,43 @@ var _ = Describe("Internal", func() {
 			}
 			Expect(kind.String()).Should(Equal("kind source: *v1.PartialObjectMetadata[apps/v1 Deployment]"))
 		})
+		It("should stop, fail and remove its handler once its informer was removed", func(ctx SpecContext) {
+			c := &informertest.FakeInformers{}
+			kind := &internal.Kind[client.Object, reconcile.Request]{
+				Type:    &corev1.Pod{},
+				Cache:   c,
+				Handler: &handler.EnqueueRequestForObject{},
+			}
+			Expect(kind.Start(ctx, &controllertest.Queue{})).To(Succeed())
+			Expect(kind.WaitForSync(ctx)).To(Succeed())
+
+			i, err := c.FakeInformerFor(ctx, &corev1.Pod{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(i.HandlerCount()).To(Equal(1))
+
+			Expect(c.RemoveInformer(ctx, &corev1.Pod{})).To(Succeed())
+			Eventually(kind.Failed()).Should(Receive(MatchError(ContainSubstring("was stopped"))))
+			Eventually(i.HandlerCount).Should(BeZero())
+		})
+
+		It("should not fail once it is stopped", func(ctx SpecContext) {
+			c := &informertest.FakeInformers{}
+			kind := &internal.Kind[client.Object, reconcile.Request]{
+				Type:    &corev1.Pod{},
+				Cache:   c,
+				Handler: &handler.EnqueueRequestForObject{},
+			}
+			sourceCtx, cancel := context.WithCancel(ctx)
+			Expect(kind.Start(sourceCtx, &controllertest.Queue{})).To(Succeed())
+			Expect(kind.WaitForSync(ctx)).To(Succeed())
+
+			i, err := c.FakeInformerFor(ctx, &corev1.Pod{})
+			Expect(err).NotTo(HaveOccurred())
+			cancel()
+			Eventually(i.HandlerCount).Should(BeZero())
+			i.Stop()
+			Consistently(kind.Failed(), 100*time.Millisecond).ShouldNot(Receive())
+		})
 	})
 })
 
@@ -40,6 +40,10 @@ type Kind[object client.Object, request comparable] struct {
 	// contain an error, startup and syncing finished.
 	startedErr  chan error
 	startCancel func()
+
+	// failed receives an error once the informer of the source stopped while the
+	// source was running, see Failed.
+	failed chan error
 }
 
 // Start is internal and should be called only by the Controller to register an EventHandler with the Informer
@@ -59,6 +63,8 @@ func (ks *Kind[object, request]) Start(ctx context.Context, queue workqueue.Type
 	// sync that informer (most commonly due to RBAC issues).
 	ctx, ks.startCancel = context.WithCancel(ctx)
 	ks.startedErr = make(chan error, 1) // Buffer chan to not leak goroutines if WaitForSync isn't called
+	ks.failed = make(chan error, 1)
+	failed := ks.failed
 	go func() {
 		var (
 			i       cache.Informer
@@ -100,6 +106,32 @@ func (ks *Kind[object, request]) Start(ctx context.Context, queue workqueue.Type
 			ks.startedErr <- err
 			return
 		}
+		// Remove the handler once the source is stopped, e.g. because its controller
+		// lost leadership, so that it doesn't keep feeding a queue that was shut down
+		// when the controller is started again. The source stops on its own and fails
+		// once its informer was stopped, e.g. because it was removed from the cache with
+		// RemoveInformer after its CRD was uninstalled, so that its handler doesn't
+		// keep the informer and its store alive. Informers that don't notify when they
+		// stopped are not watched.
+		var stopped <-chan struct{}
+		if notifier, ok := i.(cache.StoppedNotifier); ok {
+			stopped = notifier.Stopped()
+		}
+		go func() {
+			select {
+			case <-ctx.Done():
+			case <-stopped:
+				if ctx.Err() == nil {
+					err := fmt.Errorf("informer of %s was stopped", ks.String())
+					logKind.Error(err, "Stopping source")
+					failed <- err
+					ks.startCancel()
+				}
+			}
+			if err := i.RemoveEventHandler(handlerRegistration); err != nil {
+				logKind.Error(err, "failed to remove event handler", "source", ks.String())
+			}
+		}()
 		// First, wait for the cache to sync. For real caches this waits for startup.
 		// For fakes with Synced=false, this returns immediately allowing fast failure.
 		if !ks.Cache.WaitForCacheSync(ctx) {
@@ -132,6 +164,14 @@ func (ks *Kind[object, request]) String() string {
 	}
 }
 
+// Failed returns a channel that receives an error once the informer of the source
+// stopped while the source was running, e.g. because it was removed from the cache.
+// The source stops then and doesn't send any more events. It returns nil if the source
+// wasn't started.
+func (ks *Kind[object, request]) Failed() <-chan error {
+	return ks.failed
+}
+
 // WaitForSync implements SyncingSource to allow controllers to wait with starting
 // workers until the cache is synced.
 func (ks *Kind[object, request]) WaitForSync(ctx context.Context) error {
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncs
+
+import "sync"
+
+// Queue delivers items in order from its own goroutine, so that producers are
+// not held up by slow consumers. It holds up to a maximum number of pending items
+// and drops the oldest one when an item is pushed to a full queue.
+type Queue[T any] struct {
+	deliver    func(T)
+	maxPending int
+
+	// mu guards pending, stopped and the closing of notify.
+	mu      sync.Mutex
+	pending []T
+	stopped bool
+	notify  chan struct{}
+	done    chan struct{}
+}
+
+// NewQueue returns a Queue that calls deliver for every pushed item, and holds up
+// to maxPending items that were not delivered yet. It starts a goroutine that runs
+// until Stop is called.
+func NewQueue[T any](deliver func(T), maxPending int) *Queue[T] {
+	q := &Queue[T]{
+		deliver:    deliver,
+		maxPending: maxPending,
+		notify:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Push queues item for delivery. It never blocks. It returns false if the queue
+// was full and the oldest pending item was dropped to make room, or if the queue
+// was stopped.
+func (q *Queue[T]) Push(item T) bool {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return false
+	}
+	dropped := len(q.pending) >= q.maxPending
+	if dropped {
+		q.pending = q.pending[1:]
+	}
+	q.pending = append(q.pending, item)
+	// Signal while holding the lock, as Stop closes notify.
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	q.mu.Unlock()
+	return !dropped
+}
+
+// Stop discards the pending items and stops the goroutine of the queue once the
+// current delivery returned, see Done. It can be called multiple times.
+func (q *Queue[T]) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return
+	}
+	q.stopped = true
+	q.pending = nil
+	close(q.notify)
+}
+
+// Done returns a channel that is closed once the queue was stopped and its
+// goroutine returned, i.e. deliver is not called anymore.
+func (q *Queue[T]) Done() <-chan struct{} {
+	return q.done
+}
+
+func (q *Queue[T]) run() {
+	defer close(q.done)
+	for range q.notify {
+		for {
+			q.mu.Lock()
+			if q.stopped || len(q.pending) == 0 {
+				q.mu.Unlock()
+				break
+			}
+			item := q.pending[0]
+			q.pending = q.pending[1:]
+			q.mu.Unlock()
+			q.deliver(item)
+		}
+	}
+}
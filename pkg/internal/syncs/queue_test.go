@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncs
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueueDeliversInOrder(t *testing.T) {
+	delivered := make(chan int, 10)
+	q := NewQueue(func(i int) { delivered <- i }, 10)
+	defer q.Stop()
+
+	for i := range 5 {
+		if !q.Push(i) {
+			t.Fatalf("expected item %d to be queued", i)
+		}
+	}
+	for i := range 5 {
+		select {
+		case got := <-delivered:
+			if got != i {
+				t.Fatalf("expected item %d, got %d", i, got)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for item %d", i)
+		}
+	}
+}
+
+func TestQueueDropsOldestItemWhenFull(t *testing.T) {
+	block := make(chan struct{})
+	delivered := make(chan int, 10)
+	q := NewQueue(func(i int) {
+		<-block
+		delivered <- i
+	}, 2)
+	defer q.Stop()
+
+	// Wait for the first item to be picked up, so that the others stay pending.
+	q.Push(0)
+	time.Sleep(100 * time.Millisecond)
+	q.Push(1)
+	q.Push(2)
+	if q.Push(3) {
+		t.Fatal("expected the oldest pending item to be dropped")
+	}
+	close(block)
+
+	var got []int
+	for range 3 {
+		select {
+		case i := <-delivered:
+			got = append(got, i)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for items, got %v", got)
+		}
+	}
+	if !slices.Equal(got, []int{0, 2, 3}) {
+		t.Fatalf("expected items [0 2 3], got %v", got)
+	}
+}
+
+func TestQueueStop(t *testing.T) {
+	q := NewQueue(func(int) {}, 10)
+	q.Stop()
+	q.Stop()
+
+	select {
+	case <-q.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the queue to stop")
+	}
+	if q.Push(1) {
+		t.Fatal("expected pushing to a stopped queue to fail")
+	}
+}
@@ -19,6 +19,8 @@ Package leaderelection contains a constructor for a leader election resource loc
 This is used to ensure that multiple copies of a controller manager can be run with
 only one active set of controllers, for active-passive HA.
 
-It uses built-in Kubernetes leader election APIs.
+It uses built-in Kubernetes leader election APIs. Operators that run outside of the
+cluster they manage can store the lock in another system, like etcd, Consul or DynamoDB,
+by implementing a LockBackend and creating the lock with NewExternalLock.
 */
 package leaderelection
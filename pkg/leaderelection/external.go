@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("leader-election")
+
+var (
+	// ErrLockNotFound is returned by a LockBackend if the lock doesn't exist.
+	ErrLockNotFound = errors.New("leader election lock not found")
+
+	// ErrLockConflict is returned by a LockBackend if a lock that is created exists
+	// already, or if a lock that is updated was changed since it was read.
+	ErrLockConflict = errors.New("leader election lock was changed concurrently")
+)
+
+// LockBackend stores leader election records in a system other than Kubernetes,
+// e.g. etcd, Consul or DynamoDB, for operators that run outside of the cluster
+// they manage. Implementations must create and update records atomically, so that
+// only one replica wins if several try to acquire the lock at the same time.
+type LockBackend interface {
+	// Get returns the record of the lock with the given name and an opaque revision
+	// that changes with every update, or an error wrapping ErrLockNotFound.
+	Get(ctx context.Context, name string) (*resourcelock.LeaderElectionRecord, string, error)
+
+	// Create creates the lock with the given record and returns its revision, or an
+	// error wrapping ErrLockConflict if the lock exists already.
+	Create(ctx context.Context, name string, record resourcelock.LeaderElectionRecord) (string, error)
+
+	// Update replaces the record of the lock if its revision is still the given one
+	// and returns the new revision, or an error wrapping ErrLockConflict otherwise.
+	Update(ctx context.Context, name string, record resourcelock.LeaderElectionRecord, revision string) (string, error)
+}
+
+// ExternalLockOptions are the options of NewExternalLock.
+type ExternalLockOptions struct {
+	// Name is the name of the lock in the backend. Required.
+	Name string
+
+	// Identity is the unique identity of this replica. Defaults to the hostname
+	// followed by a random UUID, like the identity of Kubernetes resource locks.
+	Identity string
+}
+
+// NewExternalLock returns a resource lock for leader election that stores its record
+// in the given LockBackend instead of a Kubernetes Lease. It can be used with the
+// LeaderElectionLockBackend option of the Manager or with client-go's leaderelection
+// package directly.
+func NewExternalLock(backend LockBackend, options ExternalLockOptions) (resourcelock.Interface, error) {
+	if backend == nil {
+		return nil, errors.New("must specify a LockBackend")
+	}
+	if options.Name == "" {
+		return nil, errors.New("must specify the Name of the lock")
+	}
+	if options.Identity == "" {
+		id, err := newIdentity()
+		if err != nil {
+			return nil, err
+		}
+		options.Identity = id
+	}
+	return &externalLock{backend: backend, name: options.Name, identity: options.Identity}, nil
+}
+
+// newIdentity returns a unique identity for this replica.
+func newIdentity() (string, error) {
+	id, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return id + "_" + string(uuid.NewUUID()), nil
+}
+
+type externalLock struct {
+	backend  LockBackend
+	name     string
+	identity string
+
+	// mu guards revision, the revision of the record that was observed last.
+	mu       sync.Mutex
+	revision *string
+}
+
+var _ resourcelock.Interface = &externalLock{}
+
+// Get implements resourcelock.Interface. A missing lock is reported as a Kubernetes
+// NotFound error, which makes the leader elector create it.
+func (l *externalLock) Get(ctx context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	record, revision, err := l.backend.Get(ctx, l.name)
+	if errors.Is(err, ErrLockNotFound) {
+		return nil, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "externallocks"}, l.name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	l.setRevision(revision)
+	return record, raw, nil
+}
+
+// Create implements resourcelock.Interface.
+func (l *externalLock) Create(ctx context.Context, record resourcelock.LeaderElectionRecord) error {
+	revision, err := l.backend.Create(ctx, l.name, record)
+	if err != nil {
+		return err
+	}
+	l.setRevision(revision)
+	return nil
+}
+
+// Update implements resourcelock.Interface. It updates the record observed last.
+func (l *externalLock) Update(ctx context.Context, record resourcelock.LeaderElectionRecord) error {
+	l.mu.Lock()
+	revision := l.revision
+	l.mu.Unlock()
+	if revision == nil {
+		return errors.New("lock not initialized, call Get or Create first")
+	}
+
+	newRevision, err := l.backend.Update(ctx, l.name, record, *revision)
+	if err != nil {
+		return err
+	}
+	l.setRevision(newRevision)
+	return nil
+}
+
+func (l *externalLock) setRevision(revision string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.revision = &revision
+}
+
+// RecordEvent implements resourcelock.Interface. There is no object to record
+// events on, they are logged instead.
+func (l *externalLock) RecordEvent(event string) {
+	log.Info(event, "lock", l.Describe())
+}
+
+// Identity implements resourcelock.Interface.
+func (l *externalLock) Identity() string {
+	return l.identity
+}
+
+// Describe implements resourcelock.Interface.
+func (l *externalLock) Describe() string {
+	return fmt.Sprintf("external/%s", l.name)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientleaderelection "k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
+	"sigs.k8s.io/controller-runtime/pkg/leaderelection/fake"
+)
+
+var _ = Describe("NewExternalLock", func() {
+	var backend *fake.LockBackend
+
+	BeforeEach(func() {
+		backend = &fake.LockBackend{}
+	})
+
+	It("should validate its options and default the identity", func() {
+		_, err := leaderelection.NewExternalLock(nil, leaderelection.ExternalLockOptions{Name: "lock"})
+		Expect(err).To(HaveOccurred())
+		_, err = leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{})
+		Expect(err).To(HaveOccurred())
+
+		lock, err := leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{Name: "lock"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Identity()).NotTo(BeEmpty())
+		Expect(lock.Describe()).To(Equal("external/lock"))
+	})
+
+	It("should report missing locks as not found and update the observed revision", func(ctx SpecContext) {
+		lock, err := leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{Name: "lock", Identity: "a"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = lock.Get(ctx)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(lock.Update(ctx, record("a"))).NotTo(Succeed())
+
+		Expect(lock.Create(ctx, record("a"))).To(Succeed())
+		Expect(lock.Create(ctx, record("a"))).To(MatchError(leaderelection.ErrLockConflict))
+		Expect(lock.Update(ctx, record("a"))).To(Succeed())
+
+		got, raw, err := lock.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.HolderIdentity).To(Equal("a"))
+		Expect(raw).NotTo(BeEmpty())
+
+		// Another replica changes the lock, the stale revision conflicts.
+		other, err := leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{Name: "lock", Identity: "b"})
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = other.Get(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other.Update(ctx, record("b"))).To(Succeed())
+		Expect(lock.Update(ctx, record("a"))).To(MatchError(leaderelection.ErrLockConflict))
+	})
+
+	It("should elect a single leader", func(ctx SpecContext) {
+		leaders := make(chan string, 2)
+		for _, id := range []string{"a", "b"} {
+			lock, err := leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{Name: "lock", Identity: id})
+			Expect(err).NotTo(HaveOccurred())
+			elector, err := clientleaderelection.NewLeaderElector(clientleaderelection.LeaderElectionConfig{
+				Lock:          lock,
+				LeaseDuration: 2 * time.Second,
+				RenewDeadline: time.Second,
+				RetryPeriod:   100 * time.Millisecond,
+				Callbacks: clientleaderelection.LeaderCallbacks{
+					OnStartedLeading: func(context.Context) { leaders <- id },
+					OnStoppedLeading: func() {},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			go elector.Run(ctx)
+		}
+
+		var leader string
+		Eventually(leaders).Should(Receive(&leader))
+		Consistently(leaders, 500*time.Millisecond).ShouldNot(Receive())
+		Expect(backend.Holder("lock")).To(Equal(leader))
+	})
+})
+
+func record(holder string) resourcelock.LeaderElectionRecord {
+	now := metav1.Now()
+	return resourcelock.LeaderElectionRecord{
+		HolderIdentity:       holder,
+		LeaseDurationSeconds: 15,
+		AcquireTime:          now,
+		RenewTime:            now,
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
+)
+
+// LockBackend is an in-memory leaderelection.LockBackend for use in testing
+// leader election with external locks.
+type LockBackend struct {
+	mu    sync.Mutex
+	locks map[string]lockEntry
+}
+
+type lockEntry struct {
+	record   resourcelock.LeaderElectionRecord
+	revision int
+}
+
+var _ leaderelection.LockBackend = &LockBackend{}
+
+// Get implements leaderelection.LockBackend.
+func (b *LockBackend) Get(_ context.Context, name string) (*resourcelock.LeaderElectionRecord, string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.locks[name]
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", leaderelection.ErrLockNotFound, name)
+	}
+	record := entry.record
+	return &record, strconv.Itoa(entry.revision), nil
+}
+
+// Create implements leaderelection.LockBackend.
+func (b *LockBackend) Create(_ context.Context, name string, record resourcelock.LeaderElectionRecord) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.locks[name]; ok {
+		return "", fmt.Errorf("%w: %s exists already", leaderelection.ErrLockConflict, name)
+	}
+	if b.locks == nil {
+		b.locks = map[string]lockEntry{}
+	}
+	b.locks[name] = lockEntry{record: record, revision: 1}
+	return "1", nil
+}
+
+// Update implements leaderelection.LockBackend.
+func (b *LockBackend) Update(_ context.Context, name string, record resourcelock.LeaderElectionRecord, revision string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.locks[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", leaderelection.ErrLockNotFound, name)
+	}
+	if strconv.Itoa(entry.revision) != revision {
+		return "", fmt.Errorf("%w: %s has revision %d, not %s", leaderelection.ErrLockConflict, name, entry.revision, revision)
+	}
+	entry = lockEntry{record: record, revision: entry.revision + 1}
+	b.locks[name] = entry
+	return strconv.Itoa(entry.revision), nil
+}
+
+// Holder returns the identity of the holder of the lock with the given name, or
+// the empty string if it doesn't exist.
+func (b *LockBackend) Holder(name string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.locks[name].record.HolderIdentity
+}
@@ -19,10 +19,10 @@ package leaderelection
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/uuid"
 	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -57,9 +57,18 @@ type Options struct {
 	// in losing leadership.
 	RenewDeadline time.Duration
 
+	// Identity is the unique identity of this replica in the leader election.
+	// Defaults to the hostname followed by a random UUID.
+	Identity string
+
 	// LeaderLabels are an optional set of labels that will be set on the lease object
 	// when this replica becomes leader
 	LeaderLabels map[string]string
+
+	// HTTPClient is the HTTP client the resource lock talks to the API server with.
+	// Its Timeout is overridden as described for RenewDeadline. If not set, an HTTP
+	// client is created from the rest.Config.
+	HTTPClient *http.Client
 }
 
 // NewResourceLock creates a new resource lock for use in a leader election loop.
@@ -90,11 +99,13 @@ func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, op
 	}
 
 	// Leader id, needs to be unique
-	id, err := os.Hostname()
-	if err != nil {
-		return nil, err
+	var err error
+	id := options.Identity
+	if id == "" {
+		if id, err = newIdentity(); err != nil {
+			return nil, err
+		}
 	}
-	id = id + "_" + string(uuid.NewUUID())
 
 	// Construct config for leader election
 	config = rest.AddUserAgent(config, "leader-election")
@@ -107,13 +118,28 @@ func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, op
 		config.Timeout = timeout
 	}
 
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient, err = rest.HTTPClientFor(config)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		httpClient = &http.Client{
+			Transport:     httpClient.Transport,
+			CheckRedirect: httpClient.CheckRedirect,
+			Jar:           httpClient.Jar,
+			Timeout:       config.Timeout,
+		}
+	}
+
 	// Construct clients for leader election
-	corev1Client, err := corev1client.NewForConfig(config)
+	corev1Client, err := corev1client.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	coordinationClient, err := coordinationv1client.NewForConfig(config)
+	coordinationClient, err := coordinationv1client.NewForConfigAndClient(config, httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,37 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package limits bounds the number of concurrent calls to external dependencies, e.g. a
+rate-limited cloud API, across all reconcilers of a process.
+
+The limit of a dependency is set once, e.g. on startup:
+
+	limits.SetLimit("cloud-api", 5)
+
+Reconcilers acquire a slot of a dependency before calling it and release it afterwards:
+
+	release, err := limits.Acquire(ctx, "cloud-api")
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer release()
+
+Waiting for a slot stops when the context of the reconciliation is done, e.g. once the
+ReconciliationTimeout of the controller expired. The limit, the slots in use and the
+time spent waiting are exported as metrics per dependency.
+*/
+package limits
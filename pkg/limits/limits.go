@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultRegistry is the Registry used by SetLimit and Acquire.
+var DefaultRegistry = NewRegistry()
+
+// SetLimit sets the limit of the dependency in the DefaultRegistry, see Registry.SetLimit.
+func SetLimit(dependency string, limit int) {
+	DefaultRegistry.SetLimit(dependency, limit)
+}
+
+// Acquire acquires a slot of the dependency from the DefaultRegistry, see Registry.Acquire.
+func Acquire(ctx context.Context, dependency string) (release func(), err error) {
+	return DefaultRegistry.Acquire(ctx, dependency)
+}
+
+// Registry holds a semaphore per dependency, identified by its name.
+type Registry struct {
+	mu         sync.Mutex
+	semaphores map[string]*semaphore
+}
+
+// NewRegistry returns a new Registry.
+func NewRegistry() *Registry {
+	return &Registry{semaphores: map[string]*semaphore{}}
+}
+
+// Acquire waits until less slots of the dependency than its limit are in use and acquires
+// one. The returned release func has to be called once the call to the dependency finished,
+// it can be called multiple times. Slots are handed out in the order they were requested.
+//
+// An error is returned if ctx is done before a slot was acquired, or if no limit was set for
+// the dependency with SetLimit.
+func (r *Registry) Acquire(ctx context.Context, dependency string) (release func(), err error) {
+	r.mu.Lock()
+	s, ok := r.semaphores[dependency]
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no limit set for dependency %q", dependency)
+	}
+
+	start := time.Now()
+	waited, err := s.acquire(ctx)
+	if waited {
+		logf.FromContext(ctx).V(1).Info("Waited for a slot of dependency", "dependency", dependency, "duration", time.Since(start))
+	}
+	s.waitTime.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for a slot of dependency %q: %w", dependency, err)
+	}
+	return sync.OnceFunc(s.release), nil
+}
+
+// SetLimit sets the number of slots of the dependency that can be in use at the same time,
+// it is typically called once on startup. Raising the limit hands out slots to waiting
+// callers, lowering it lets the callers that hold a slot finish. A limit less than 1 is
+// treated as 1.
+func (r *Registry) SetLimit(dependency string, limit int) {
+	r.mu.Lock()
+	s, ok := r.semaphores[dependency]
+	if !ok {
+		s = &semaphore{
+			limitGauge: dependencyLimit.WithLabelValues(dependency),
+			inUseGauge: dependencyInUse.WithLabelValues(dependency),
+			waitTime:   dependencyWaitTime.WithLabelValues(dependency),
+		}
+		r.semaphores[dependency] = s
+	}
+	r.mu.Unlock()
+
+	s.setLimit(max(limit, 1))
+}
+
+// semaphore is a semaphore whose limit can be changed. Waiters are served in FIFO order.
+type semaphore struct {
+	mu      sync.Mutex
+	limit   int
+	inUse   int
+	waiters []chan struct{}
+
+	limitGauge prometheus.Gauge
+	inUseGauge prometheus.Gauge
+	waitTime   prometheus.Observer
+}
+
+func (s *semaphore) acquire(ctx context.Context) (waited bool, err error) {
+	s.mu.Lock()
+	if s.inUse < s.limit && len(s.waiters) == 0 {
+		s.inUse++
+		s.inUseGauge.Set(float64(s.inUse))
+		s.mu.Unlock()
+		return false, nil
+	}
+	ready := make(chan struct{})
+	s.waiters = append(s.waiters, ready)
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return true, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-ready:
+		// The slot was handed out meanwhile, pass it on.
+		s.inUse--
+		s.wakeLocked()
+	default:
+		s.waiters = slices.DeleteFunc(s.waiters, func(w chan struct{}) bool { return w == ready })
+	}
+	return true, ctx.Err()
+}
+
+func (s *semaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inUse--
+	s.wakeLocked()
+}
+
+func (s *semaphore) setLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limit == limit {
+		return
+	}
+	s.limit = limit
+	s.limitGauge.Set(float64(limit))
+	s.wakeLocked()
+}
+
+// wakeLocked hands out free slots to waiters and updates the in use metric.
+func (s *semaphore) wakeLocked() {
+	for s.inUse < s.limit && len(s.waiters) > 0 {
+		s.inUse++
+		close(s.waiters[0])
+		s.waiters = s.waiters[1:]
+	}
+	s.inUseGauge.Set(float64(s.inUse))
+}
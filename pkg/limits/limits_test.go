@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Registry", func() {
+	var r *Registry
+
+	BeforeEach(func() {
+		r = NewRegistry()
+	})
+
+	It("should bound the concurrent calls to a dependency", func(ctx SpecContext) {
+		r.SetLimit("bounded", 2)
+		release1, err := r.Acquire(ctx, "bounded")
+		Expect(err).NotTo(HaveOccurred())
+		release2, err := r.Acquire(ctx, "bounded")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(dependencyInUse.WithLabelValues("bounded"))).To(BeEquivalentTo(2))
+		Expect(testutil.ToFloat64(dependencyLimit.WithLabelValues("bounded"))).To(BeEquivalentTo(2))
+
+		acquired := make(chan func())
+		go func() {
+			defer GinkgoRecover()
+			release, err := r.Acquire(ctx, "bounded")
+			Expect(err).NotTo(HaveOccurred())
+			acquired <- release
+		}()
+		Consistently(acquired, 50*time.Millisecond).ShouldNot(Receive())
+
+		By("releasing a slot only once when release is called twice")
+		release1()
+		release1()
+		var release3 func()
+		Eventually(acquired).Should(Receive(&release3))
+		Expect(testutil.ToFloat64(dependencyInUse.WithLabelValues("bounded"))).To(BeEquivalentTo(2))
+
+		release2()
+		release3()
+		Expect(testutil.ToFloat64(dependencyInUse.WithLabelValues("bounded"))).To(BeEquivalentTo(0))
+	})
+
+	It("should not bound other dependencies", func(ctx SpecContext) {
+		r.SetLimit("a", 1)
+		r.SetLimit("b", 1)
+		release, err := r.Acquire(ctx, "a")
+		Expect(err).NotTo(HaveOccurred())
+		defer release()
+
+		otherRelease, err := r.Acquire(ctx, "b")
+		Expect(err).NotTo(HaveOccurred())
+		otherRelease()
+	})
+
+	It("should stop waiting once the context is done", func(specCtx SpecContext) {
+		r.SetLimit("cancelled", 1)
+		release, err := r.Acquire(specCtx, "cancelled")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithTimeout(specCtx, 10*time.Millisecond)
+		defer cancel()
+		_, err = r.Acquire(ctx, "cancelled")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		release()
+		release, err = r.Acquire(specCtx, "cancelled")
+		Expect(err).NotTo(HaveOccurred())
+		release()
+		Expect(testutil.ToFloat64(dependencyInUse.WithLabelValues("cancelled"))).To(BeEquivalentTo(0))
+	})
+
+	It("should hand out slots to waiters when the limit is raised", func(ctx SpecContext) {
+		r.SetLimit("raised", 1)
+		release, err := r.Acquire(ctx, "raised")
+		Expect(err).NotTo(HaveOccurred())
+		defer release()
+
+		acquired := make(chan func())
+		go func() {
+			defer GinkgoRecover()
+			release, err := r.Acquire(ctx, "raised")
+			Expect(err).NotTo(HaveOccurred())
+			acquired <- release
+		}()
+		Consistently(acquired, 50*time.Millisecond).ShouldNot(Receive())
+
+		r.SetLimit("raised", 2)
+		var otherRelease func()
+		Eventually(acquired).Should(Receive(&otherRelease))
+		otherRelease()
+	})
+
+	It("should fail for dependencies without a limit", func(ctx SpecContext) {
+		_, err := r.Acquire(ctx, "unknown")
+		Expect(err).To(MatchError(`no limit set for dependency "unknown"`))
+	})
+})
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limits
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// dependencyLimit is a prometheus gauge metric which holds the maximum number of
+	// concurrent calls per dependency.
+	dependencyLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_dependency_limit",
+		Help: "Maximum number of concurrent calls per dependency",
+	}, []string{"dependency"})
+
+	// dependencyInUse is a prometheus gauge metric which holds the number of
+	// acquired slots per dependency.
+	dependencyInUse = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "controller_runtime_dependency_in_use",
+		Help: "Number of concurrent calls in progress per dependency",
+	}, []string{"dependency"})
+
+	// dependencyWaitTime is a prometheus histogram metric which keeps track of the
+	// time spent waiting for a slot of a dependency.
+	dependencyWaitTime = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "controller_runtime_dependency_wait_time_seconds",
+		Help:    "Length of time spent waiting for a slot per dependency",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"dependency"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(dependencyLimit, dependencyInUse, dependencyWaitTime)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package lineage links objects in the cluster back to the reconciliations that wrote
+them, e.g. to find the logs of the reconciliation that produced a broken generation
+of an object.
+
+A client wrapped by the Middleware records the controller name and the reconcileID
+of the current reconciliation in the HistoryAnnotation of every object it creates,
+updates or patches:
+
+	mgr, err := manager.New(cfg, manager.Options{
+		Client: client.Options{
+			Middlewares: []client.Middleware{lineage.Middleware(lineage.Options{})},
+		},
+	})
+
+The history is read with History, and ProducerOf returns the write that produced a
+given generation of an object:
+
+	record, err := lineage.ProducerOf(deployment, deployment.Generation)
+	// Search the logs for record.ReconcileID.
+
+The history only contains the writes of clients with the Middleware, writes from
+outside of reconciliations and apply requests are not recorded.
+*/
+package lineage
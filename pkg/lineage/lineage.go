@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lineage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// HistoryAnnotation holds the history of the writes of an object as a JSON list of
+// records, the latest one last.
+const HistoryAnnotation = "lineage.controller-runtime.io/history"
+
+const defaultMaxHistory = 5
+
+// reconcileIDFromContext and controllerNameFromContext are variables so that tests can
+// record writes without running a controller.
+var (
+	reconcileIDFromContext    = controller.ReconcileIDFromContext
+	controllerNameFromContext = controller.ControllerNameFromContext
+)
+
+// Record is a write of an object by a reconciliation.
+type Record struct {
+	// Controller is the name of the controller that wrote the object.
+	Controller string `json:"controller"`
+
+	// ReconcileID is the reconcileID of the reconciliation that wrote the object,
+	// which is logged with all its messages.
+	ReconcileID types.UID `json:"reconcileID"`
+
+	// ObservedGeneration is the generation of the object the write was based on,
+	// zero for creations. The write produced the next generation if it changed the
+	// spec of the object.
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// Time is when the object was written.
+	Time metav1.Time `json:"time"`
+}
+
+// Options are the options of the Middleware.
+type Options struct {
+	// MaxHistory is the number of records kept in the HistoryAnnotation, the oldest
+	// records are dropped. Defaults to 5.
+	MaxHistory int
+}
+
+// Middleware returns a client.Middleware that records the writes of reconciliations
+// in the HistoryAnnotation, see NewClient.
+func Middleware(opts Options) client.Middleware {
+	return func(c client.Client) client.Client {
+		return NewClient(c, opts)
+	}
+}
+
+// NewClient wraps a client and adds a record to the HistoryAnnotation of the objects
+// that are created, updated or patched by it during reconciliations. The record is set
+// on the object that is passed in. Patches only record it if their data is computed
+// from the object, e.g. if they are created with client.MergeFrom. Repeated writes of
+// the same reconciliation, e.g. retries, update its record instead of adding another one.
+func NewClient(c client.Client, opts Options) client.Client {
+	if opts.MaxHistory <= 0 {
+		opts.MaxHistory = defaultMaxHistory
+	}
+	return &lineageClient{Client: c, opts: opts}
+}
+
+type lineageClient struct {
+	client.Client
+	opts Options
+}
+
+func (c *lineageClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.record(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *lineageClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.record(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *lineageClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.record(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// record adds a record for the current reconciliation to the history of obj.
+func (c *lineageClient) record(ctx context.Context, obj client.Object) error {
+	reconcileID := reconcileIDFromContext(ctx)
+	if reconcileID == "" {
+		return nil
+	}
+	history, err := History(obj)
+	if err != nil {
+		// A history that can't be decoded is started over.
+		history = nil
+	}
+	record := Record{
+		Controller:         controllerNameFromContext(ctx),
+		ReconcileID:        reconcileID,
+		ObservedGeneration: obj.GetGeneration(),
+		Time:               metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+	}
+	if obj.GetResourceVersion() == "" {
+		record.ObservedGeneration = 0
+	}
+	if n := len(history); n > 0 && history[n-1].ReconcileID == reconcileID {
+		history[n-1] = record
+	} else {
+		history = append(history, record)
+	}
+	if len(history) > c.opts.MaxHistory {
+		history = history[len(history)-c.opts.MaxHistory:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", HistoryAnnotation, err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[HistoryAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// History returns the records of the HistoryAnnotation of obj, the latest one last.
+func History(obj client.Object) ([]Record, error) {
+	data, ok := obj.GetAnnotations()[HistoryAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var history []Record
+	if err := json.Unmarshal([]byte(data), &history); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", HistoryAnnotation, err)
+	}
+	return history, nil
+}
+
+// ProducerOf returns the record of the write that produced the given generation of obj,
+// i.e. the latest write that was based on the previous generation, or nil if it isn't
+// in the history of obj. It relies on writes being based on the latest generation,
+// which conflicts ensure for updates.
+func ProducerOf(obj client.Object, generation int64) (*Record, error) {
+	history, err := History(obj)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ObservedGeneration == generation-1 {
+			return &history[i], nil
+		}
+	}
+	return nil, nil
+}
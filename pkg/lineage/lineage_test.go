@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lineage
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type reconcileKey struct{}
+
+// inReconcile returns a context of a reconciliation of the given controller.
+func inReconcile(ctx context.Context, controllerName string, reconcileID types.UID) context.Context {
+	return context.WithValue(ctx, reconcileKey{}, [2]string{controllerName, string(reconcileID)})
+}
+
+var _ = Describe("Lineage", func() {
+	BeforeEach(func() {
+		reconcileIDFromContext = func(ctx context.Context) types.UID {
+			v, _ := ctx.Value(reconcileKey{}).([2]string)
+			return types.UID(v[1])
+		}
+		controllerNameFromContext = func(ctx context.Context) string {
+			v, _ := ctx.Value(reconcileKey{}).([2]string)
+			return v[0]
+		}
+	})
+
+	ids := func(obj client.Object) []types.UID {
+		history, err := History(obj)
+		Expect(err).NotTo(HaveOccurred())
+		var ids []types.UID
+		for _, record := range history {
+			ids = append(ids, record.ReconcileID)
+		}
+		return ids
+	}
+
+	It("should record the writes of reconciliations", func(ctx SpecContext) {
+		c := NewClient(fake.NewClientBuilder().Build(), Options{MaxHistory: 3})
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+
+		Expect(c.Create(inReconcile(ctx, "configmaps", "one"), cm)).To(Succeed())
+		history, err := History(cm)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(HaveLen(1))
+		Expect(history[0].Controller).To(Equal("configmaps"))
+		Expect(history[0].ReconcileID).To(BeEquivalentTo("one"))
+
+		By("replacing the record of repeated writes of a reconciliation")
+		Expect(c.Update(inReconcile(ctx, "configmaps", "one"), cm)).To(Succeed())
+		Expect(ids(cm)).To(Equal([]types.UID{"one"}))
+
+		By("not recording writes from outside of reconciliations")
+		Expect(c.Update(ctx, cm)).To(Succeed())
+		Expect(ids(cm)).To(Equal([]types.UID{"one"}))
+
+		By("dropping the oldest entries")
+		for _, id := range []types.UID{"two", "three", "four"} {
+			base := cm.DeepCopy()
+			cm.Data = map[string]string{"id": string(id)}
+			Expect(c.Patch(inReconcile(ctx, "configmaps", id), cm, client.MergeFrom(base))).To(Succeed())
+		}
+		Expect(ids(cm)).To(Equal([]types.UID{"two", "three", "four"}))
+
+		stored := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(cm), stored)).To(Succeed())
+		Expect(ids(stored)).To(Equal([]types.UID{"two", "three", "four"}))
+	})
+
+	It("should return the producer of a generation", func() {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+		deployment.Annotations = map[string]string{HistoryAnnotation: `[
+			{"controller":"deployments","reconcileID":"create","observedGeneration":0},
+			{"controller":"deployments","reconcileID":"scale","observedGeneration":1},
+			{"controller":"deployments","reconcileID":"label","observedGeneration":2},
+			{"controller":"deployments","reconcileID":"image","observedGeneration":2},
+			{"controller":"deployments","reconcileID":"status","observedGeneration":3}
+		]`}
+
+		for generation, id := range map[int64]types.UID{1: "create", 2: "scale", 3: "image"} {
+			record, err := ProducerOf(deployment, generation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.ReconcileID).To(Equal(id))
+		}
+		record, err := ProducerOf(deployment, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record).To(BeNil())
+	})
+
+	It("should fail for invalid histories", func() {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{HistoryAnnotation: "{"}}}
+		_, err := History(cm)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
+)
+
+const defaultConfigFileReloadInterval = 10 * time.Second
+
+// ConfigFileOptions configure loading Options from a file that contains a
+// v1alpha1.ControllerManagerConfiguration, see Options.AndFrom for how the file
+// and the Options are combined.
+type ConfigFileOptions struct {
+	// Path is the path of the config file.
+	Path string
+
+	// Reload makes the Manager check the file for changes and re-apply the fields
+	// of the file that can be changed while the Manager is running:
+	//
+	// * controller.maxConcurrentReconciles is applied to all running controllers
+	//   that don't set their own MaxConcurrentReconciles, unless
+	//   Options.Controller.MaxConcurrentReconciles is set in code.
+	//
+	// All other fields only take effect after a restart.
+	Reload bool
+
+	// ReloadInterval is the interval at which the file is checked for changes.
+	// Defaults to 10 seconds.
+	ReloadInterval time.Duration
+}
+
+var (
+	configFileScheme = runtime.NewScheme()
+	configFileCodecs = serializer.NewCodecFactory(configFileScheme, serializer.EnableStrict)
+)
+
+func init() {
+	utilruntime.Must(v1alpha1.AddToScheme(configFileScheme))
+}
+
+// LoadConfigFile reads the config file at the given path, which must contain a
+// v1alpha1.ControllerManagerConfiguration. Unknown fields are rejected.
+func LoadConfigFile(path string) (*v1alpha1.ControllerManagerConfiguration, error) {
+	_, file, err := loadConfigFile(path)
+	return file, err
+}
+
+// loadConfigFile reads and decodes the config file at the given path and returns its content as well.
+func loadConfigFile(path string) ([]byte, *v1alpha1.ControllerManagerConfiguration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	file, err := parseConfigFile(content)
+	return content, file, err
+}
+
+func parseConfigFile(content []byte) (*v1alpha1.ControllerManagerConfiguration, error) {
+	// Decoding without an object to decode into requires apiVersion and kind to be set.
+	obj, err := runtime.Decode(configFileCodecs.UniversalDecoder(v1alpha1.GroupVersion), content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config file: %w", err)
+	}
+	file, ok := obj.(*v1alpha1.ControllerManagerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("config file contains %T instead of a ControllerManagerConfiguration", obj)
+	}
+	return file, nil
+}
+
+// AndFrom returns the Options with the fields that are not set yet taken from the file.
+// Fields that are set in the Options take precedence. LeaderElection is enabled if it is
+// enabled in either of them, so the file can't disable leader election that is enabled
+// in code.
+func (o Options) AndFrom(file *v1alpha1.ControllerManagerConfiguration) Options {
+	if o.Controller.MaxConcurrentReconciles <= 0 {
+		o.Controller.MaxConcurrentReconciles = file.Controller.MaxConcurrentReconciles
+	}
+	if o.Controller.GroupKindConcurrency == nil {
+		o.Controller.GroupKindConcurrency = file.Controller.GroupKindConcurrency
+	}
+	if o.Controller.CacheSyncTimeout == 0 && file.Controller.CacheSyncTimeout != nil {
+		o.Controller.CacheSyncTimeout = file.Controller.CacheSyncTimeout.Duration
+	}
+	if o.Controller.RecoverPanic == nil {
+		o.Controller.RecoverPanic = file.Controller.RecoverPanic
+	}
+
+	if o.Cache.DefaultNamespaces == nil && len(file.Cache.Namespaces) > 0 {
+		o.Cache.DefaultNamespaces = make(map[string]cache.Config, len(file.Cache.Namespaces))
+		for _, namespace := range file.Cache.Namespaces {
+			o.Cache.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+	if o.Cache.SyncPeriod == nil && file.Cache.SyncPeriod != nil {
+		o.Cache.SyncPeriod = &file.Cache.SyncPeriod.Duration
+	}
+
+	if !o.LeaderElection && file.LeaderElection.LeaderElect != nil {
+		o.LeaderElection = *file.LeaderElection.LeaderElect
+	}
+	if o.LeaderElectionResourceLock == "" {
+		o.LeaderElectionResourceLock = file.LeaderElection.ResourceLock
+	}
+	if o.LeaderElectionID == "" {
+		o.LeaderElectionID = file.LeaderElection.ResourceName
+	}
+	if o.LeaderElectionNamespace == "" {
+		o.LeaderElectionNamespace = file.LeaderElection.ResourceNamespace
+	}
+	if o.LeaseDuration == nil && file.LeaderElection.LeaseDuration != nil {
+		o.LeaseDuration = &file.LeaderElection.LeaseDuration.Duration
+	}
+	if o.RenewDeadline == nil && file.LeaderElection.RenewDeadline != nil {
+		o.RenewDeadline = &file.LeaderElection.RenewDeadline.Duration
+	}
+	if o.RetryPeriod == nil && file.LeaderElection.RetryPeriod != nil {
+		o.RetryPeriod = &file.LeaderElection.RetryPeriod.Duration
+	}
+
+	if o.Metrics.BindAddress == "" {
+		o.Metrics.BindAddress = file.Metrics.BindAddress
+	}
+
+	if o.HealthProbeBindAddress == "" {
+		o.HealthProbeBindAddress = file.Health.HealthProbeBindAddress
+	}
+	if o.ReadinessEndpointName == "" {
+		o.ReadinessEndpointName = file.Health.ReadinessEndpointName
+	}
+	if o.LivenessEndpointName == "" {
+		o.LivenessEndpointName = file.Health.LivenessEndpointName
+	}
+	return o
+}
+
+// controllerConfigReloader is implemented by controllers that apply changes of the
+// controller configuration while they are running.
+type controllerConfigReloader interface {
+	ReloadControllerConfig(config.Controller)
+}
+
+// configFileReloader checks the config file of the Manager for changes and re-applies
+// the fields that can be changed while the Manager is running.
+type configFileReloader struct {
+	cm       *controllerManager
+	path     string
+	interval time.Duration
+	logger   logr.Logger
+	hash     [sha256.Size]byte
+}
+
+// newConfigFileReloader returns a reloader for the config file, whose content was
+// loaded initially.
+func newConfigFileReloader(cm *controllerManager, options ConfigFileOptions, content []byte) *configFileReloader {
+	if options.ReloadInterval <= 0 {
+		options.ReloadInterval = defaultConfigFileReloadInterval
+	}
+	return &configFileReloader{
+		cm:       cm,
+		path:     options.Path,
+		interval: options.ReloadInterval,
+		logger:   cm.logger.WithName("config-file-reloader").WithValues("path", options.Path),
+		hash:     sha256.Sum256(bytes.TrimSpace(content)),
+	}
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable.
+func (r *configFileReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements Runnable.
+func (r *configFileReloader) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.reloadIfChanged(); err != nil {
+				r.logger.Error(err, "Failed to reload the config file, retrying")
+			}
+		}
+	}
+}
+
+// reloadIfChanged re-applies the config file if it changed. The file is only considered
+// reloaded on success, so that a file that failed to parse is retried.
+func (r *configFileReloader) reloadIfChanged() error {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	hash := sha256.Sum256(bytes.TrimSpace(content))
+	if hash == r.hash {
+		return nil
+	}
+	file, err := parseConfigFile(content)
+	if err != nil {
+		return err
+	}
+
+	r.cm.reloadControllerConfig(file.Controller.MaxConcurrentReconciles)
+	r.hash = hash
+	r.logger.Info("Reloaded config file", "maxConcurrentReconciles", file.Controller.MaxConcurrentReconciles)
+	return nil
+}
+
+// reloadControllerConfig updates the controller configuration of the Manager and
+// applies it to the controllers that are already added.
+func (cm *controllerManager) reloadControllerConfig(maxConcurrentReconciles int) {
+	cm.controllerConfigLock.Lock()
+	cm.controllerConfig.MaxConcurrentReconciles = maxConcurrentReconciles
+	cfg := cm.controllerConfig
+	cm.controllerConfigLock.Unlock()
+
+	groups := []*runnableGroup{
+		cm.runnables.HTTPServers,
+		cm.runnables.Webhooks,
+		cm.runnables.Caches,
+		cm.runnables.leaderElection(),
+		cm.runnables.Others,
+	}
+	cm.leaderElectionGroupsLock.Lock()
+	for _, group := range cm.leaderElectionGroups {
+		groups = append(groups, group.current())
+	}
+	cm.leaderElectionGroupsLock.Unlock()
+
+	for _, group := range groups {
+		for _, rn := range group.added() {
+			if reloader, ok := rn.Runnable.(controllerConfigReloader); ok {
+				reloader.ReloadControllerConfig(cfg)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
+)
+
+const configFileHeader = "apiVersion: controller-runtime.sigs.k8s.io/v1alpha1\nkind: ControllerManagerConfiguration\n"
+
+var _ = Describe("ConfigFile", func() {
+	writeFile := func(path, content string) {
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	It("should load a config file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeFile(path, `
+apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+kind: ControllerManagerConfiguration
+controller:
+  maxConcurrentReconciles: 5
+  cacheSyncTimeout: 30s
+cache:
+  namespaces: ["team-a", "team-b"]
+leaderElection:
+  leaderElect: true
+  resourceName: my-operator
+  leaseDuration: 20s
+metrics:
+  bindAddress: ":9090"
+health:
+  healthProbeBindAddress: ":9091"
+`)
+		file, err := LoadConfigFile(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		options := Options{}.AndFrom(file)
+		Expect(options.Controller.MaxConcurrentReconciles).To(Equal(5))
+		Expect(options.Controller.CacheSyncTimeout).To(Equal(30 * time.Second))
+		Expect(options.Cache.DefaultNamespaces).To(Equal(map[string]cache.Config{"team-a": {}, "team-b": {}}))
+		Expect(options.LeaderElection).To(BeTrue())
+		Expect(options.LeaderElectionID).To(Equal("my-operator"))
+		Expect(options.LeaseDuration).To(Equal(new(20 * time.Second)))
+		Expect(options.Metrics.BindAddress).To(Equal(":9090"))
+		Expect(options.HealthProbeBindAddress).To(Equal(":9091"))
+	})
+
+	It("should reject unknown fields", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeFile(path, configFileHeader+"controller:\n  maxConcurrentReconcile: 5\n")
+		_, err := LoadConfigFile(path)
+		Expect(err).To(MatchError(ContainSubstring("maxConcurrentReconcile")))
+	})
+
+	It("should reject files without a known apiVersion and kind", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeFile(path, "controller:\n  maxConcurrentReconciles: 5\n")
+		_, err := LoadConfigFile(path)
+		Expect(err).To(HaveOccurred())
+
+		writeFile(path, "apiVersion: controller-runtime.sigs.k8s.io/v1beta1\nkind: ControllerManagerConfiguration\n")
+		_, err = LoadConfigFile(path)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should prefer options set in code", func() {
+		file := &v1alpha1.ControllerManagerConfiguration{
+			Controller:     v1alpha1.ControllerConfigurationSpec{MaxConcurrentReconciles: 5},
+			Metrics:        v1alpha1.ControllerMetrics{BindAddress: ":9090"},
+			LeaderElection: v1alpha1.LeaderElectionConfigurationSpec{LeaderElect: new(false)},
+		}
+		options := Options{
+			Controller:     config.Controller{MaxConcurrentReconciles: 2},
+			LeaderElection: true,
+		}.AndFrom(file)
+		Expect(options.Controller.MaxConcurrentReconciles).To(Equal(2))
+		Expect(options.Metrics.BindAddress).To(Equal(":9090"))
+		Expect(options.LeaderElection).To(BeTrue())
+	})
+
+	It("should apply the concurrency of a reloaded config file to controllers", func(ctx SpecContext) {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeFile(path, configFileHeader+"controller:\n  maxConcurrentReconciles: 2\n")
+
+		m, err := New(cfg, Options{
+			ConfigFile: &ConfigFileOptions{Path: path, Reload: true, ReloadInterval: 10 * time.Millisecond},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.GetControllerOptions().MaxConcurrentReconciles).To(Equal(2))
+
+		controller := &reloadingController{}
+		Expect(m.Add(controller)).To(Succeed())
+
+		mgrCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			defer GinkgoRecover()
+			Expect(m.Start(mgrCtx)).To(Succeed())
+		}()
+
+		writeFile(path, configFileHeader+"controller:\n  maxConcurrentReconciles: 4\n")
+		Eventually(controller.maxConcurrentReconciles).Should(Equal(4))
+		Expect(m.GetControllerOptions().MaxConcurrentReconciles).To(Equal(4))
+
+		By("Keeping the last config if the file is invalid")
+		writeFile(path, configFileHeader+"controller: [")
+		Consistently(controller.maxConcurrentReconciles, 100*time.Millisecond).Should(Equal(4))
+	})
+})
+
+// reloadingController records the controller configurations it is given.
+type reloadingController struct {
+	mu  sync.Mutex
+	cfg config.Controller
+}
+
+func (c *reloadingController) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *reloadingController) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *reloadingController) ReloadControllerConfig(cfg config.Controller) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cfg = cfg
+}
+
+func (c *reloadingController) maxConcurrentReconciles() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cfg.MaxConcurrentReconciles
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// groupedRunnable is a Runnable of a controller group that counts its starts and
+// whether it is running.
+type groupedRunnable struct {
+	group   string
+	starts  atomic.Int32
+	running atomic.Bool
+}
+
+func (r *groupedRunnable) Start(ctx context.Context) error {
+	r.starts.Add(1)
+	r.running.Store(true)
+	defer r.running.Store(false)
+	<-ctx.Done()
+	return nil
+}
+
+func (r *groupedRunnable) NeedLeaderElection() bool { return false }
+
+func (r *groupedRunnable) ControllerGroup() string { return r.group }
+
+var _ = Describe("controller groups", func() {
+	var (
+		cm     *controllerManager
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		cm = &controllerManager{
+			runnables: newRunnables(defaultBaseContext, make(chan error, 10)),
+			logger:    logr.Discard(),
+		}
+		Expect(cm.runnables.Others.Start(ctx)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should stop and start the runnables of a group", func(ctx SpecContext) {
+		upgrade := &groupedRunnable{group: "upgrade"}
+		other := &groupedRunnable{group: "other"}
+		Expect(cm.Add(upgrade)).To(Succeed())
+		Expect(cm.Add(other)).To(Succeed())
+		Eventually(upgrade.running.Load).Should(BeTrue())
+		Eventually(other.running.Load).Should(BeTrue())
+
+		Expect(cm.StopGroup(ctx, "upgrade")).To(Succeed())
+		Expect(cm.GroupStopped("upgrade")).To(BeTrue())
+		Expect(upgrade.running.Load()).To(BeFalse())
+		Expect(other.running.Load()).To(BeTrue())
+
+		By("stopping the group again")
+		Expect(cm.StopGroup(ctx, "upgrade")).To(Succeed())
+
+		Expect(cm.StartGroup("upgrade")).To(Succeed())
+		Expect(cm.GroupStopped("upgrade")).To(BeFalse())
+		Eventually(upgrade.running.Load).Should(BeTrue())
+		Expect(upgrade.starts.Load()).To(BeEquivalentTo(2))
+
+		By("starting the group again")
+		Expect(cm.StartGroup("upgrade")).To(Succeed())
+		Consistently(upgrade.starts.Load).Should(BeEquivalentTo(2))
+	})
+
+	It("should only start runnables added to a stopped group once it is started", func(ctx SpecContext) {
+		first := &groupedRunnable{group: "upgrade"}
+		Expect(cm.Add(first)).To(Succeed())
+		Expect(cm.StopGroup(ctx, "upgrade")).To(Succeed())
+
+		second := &groupedRunnable{group: "upgrade"}
+		Expect(cm.Add(second)).To(Succeed())
+		Consistently(second.starts.Load).Should(BeZero())
+
+		Expect(cm.StartGroup("upgrade")).To(Succeed())
+		Eventually(first.running.Load).Should(BeTrue())
+		Eventually(second.running.Load).Should(BeTrue())
+	})
+
+	It("should forget runnables removed from a stopped group", func(ctx SpecContext) {
+		removed := &groupedRunnable{group: "upgrade"}
+		Expect(cm.Add(removed)).To(Succeed())
+		Expect(cm.StopGroup(ctx, "upgrade")).To(Succeed())
+
+		Expect(cm.RemoveRunnable(ctx, removed)).To(Succeed())
+		Expect(cm.StartGroup("upgrade")).To(Succeed())
+		Consistently(removed.starts.Load).Should(BeEquivalentTo(1))
+		Expect(cm.RemoveRunnable(ctx, removed)).NotTo(Succeed())
+	})
+
+	It("should fail for groups without runnables", func(ctx SpecContext) {
+		Expect(cm.StopGroup(ctx, "unknown")).To(MatchError(ContainSubstring(`controller group "unknown" doesn't exist`)))
+		Expect(cm.StartGroup("unknown")).To(MatchError(ContainSubstring(`controller group "unknown" doesn't exist`)))
+		Expect(cm.GroupStopped("unknown")).To(BeFalse())
+	})
+})
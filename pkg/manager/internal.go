@@ -23,14 +23,18 @@ import (
 	"net"
 	"net/http"
 	"net/http/pprof"
+	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -41,10 +45,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/eventbus"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/internal/httpserver"
 	intrec "sigs.k8s.io/controller-runtime/pkg/internal/recorder"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/profiling"
 	"sigs.k8s.io/controller-runtime/pkg/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -55,12 +61,30 @@ const (
 	defaultRenewDeadline          = 10 * time.Second
 	defaultRetryPeriod            = 2 * time.Second
 	defaultGracefulShutdownPeriod = 30 * time.Second
+	defaultShutdownHookTimeout    = 10 * time.Second
 
 	defaultReadinessEndpoint = "/readyz"
 	defaultLivenessEndpoint  = "/healthz"
+
+	// webhookRoutesEndpoint serves the routes of the webhook server on the metrics server.
+	webhookRoutesEndpoint = "/debug/webhook-routes"
+
+	// debugCacheEndpoint serves the dump of the cache on the metrics server.
+	debugCacheEndpoint = "/debug/cache"
 )
 
-var _ Runnable = &controllerManager{}
+var (
+	_ Runnable               = &controllerManager{}
+	_ ShutdownHookRegistrar  = &controllerManager{}
+	_ StepDowner             = &controllerManager{}
+	_ RunnableDescriber      = &controllerManager{}
+	_ RunnableRemover        = &controllerManager{}
+	_ HealthCheckRemover     = &controllerManager{}
+	_ LifecycleSubscriber    = &controllerManager{}
+	_ EventBusProvider       = &controllerManager{}
+	_ StartPhaseDescriber    = &controllerManager{}
+	_ ControllerGroupManager = &controllerManager{}
+)
 
 type controllerManager struct {
 	sync.Mutex
@@ -80,10 +104,54 @@ type controllerManager struct {
 	// resourceLock forms the basis for leader election
 	resourceLock resourcelock.Interface
 
+	// newGroupResourceLock creates the resource lock of a leader election group. It is nil
+	// if leader election groups are not supported, in which case all leader election
+	// runnables are part of the manager's leader election.
+	newGroupResourceLock func(group string) (resourcelock.Interface, error)
+
+	// leaderElectionGroups holds the leader election groups by name. It is guarded
+	// by leaderElectionGroupsLock.
+	leaderElectionGroups     map[string]*leaderElectionGroup
+	leaderElectionGroupsLock sync.Mutex
+
+	// controllerGroups holds the controller groups by name, it is guarded by the
+	// manager lock. controllerGroupsTransition serializes stopping and starting them.
+	controllerGroups           map[string]*controllerGroup
+	controllerGroupsTransition sync.Mutex
+
+	// leaderElectionCtx is the context leader election runs with. It is nil until
+	// leader election was started and guarded by leaderElectionGroupsLock.
+	leaderElectionCtx context.Context
+
+	// baseContext is the function that provides the Context for runnables.
+	baseContext BaseContextFunc
+
 	// leaderElectionReleaseOnCancel defines if the manager should step back from the leader lease
 	// on shutdown
 	leaderElectionReleaseOnCancel bool
 
+	// leaderElectionHandoverTarget returns the identity of the replica that the
+	// leader lease is handed over to when the manager stops.
+	leaderElectionHandoverTarget func(ctx context.Context) (string, error)
+
+	// leaderElectionReacquireOnLoss defines if the manager should stop the leader election
+	// runnables and campaign again when it loses leadership, rather than returning an error.
+	leaderElectionReacquireOnLoss bool
+
+	// leaderTermLock guards leading, leaderTermCancel and steppedDown.
+	leaderTermLock sync.Mutex
+	// leading is true while the manager holds the leader lease.
+	leading bool
+	// leaderTermCancel ends the current attempt to acquire and hold the leader lease.
+	// It is only set if leaderElectionReacquireOnLoss is.
+	leaderTermCancel context.CancelFunc
+	// steppedDown is set by StepDown until the current leader term ended.
+	steppedDown bool
+
+	// electedOnce makes sure elected is only closed once, as leadership
+	// might be acquired multiple times.
+	electedOnce sync.Once
+
 	// metricsServer is used to serve prometheus metrics
 	metricsServer metricsserver.Server
 
@@ -96,6 +164,9 @@ type controllerManager struct {
 	// Liveness probe endpoint name
 	livenessEndpointName string
 
+	// healthProbeServerHook is called with the health probe server before it is added.
+	healthProbeServerHook func(srv *http.Server, probes HealthProbes) error
+
 	// Readyz probe handler
 	readyzHandler *healthz.Handler
 
@@ -105,8 +176,19 @@ type controllerManager struct {
 	// pprofListener is used to serve pprof
 	pprofListener net.Listener
 
-	// controllerConfig are the global controller options.
-	controllerConfig config.Controller
+	// pprofSnapshotDir is the directory the pprof snapshot endpoint writes to.
+	pprofSnapshotDir string
+
+	// httpMiddlewares wrap the handlers of the health probe and pprof servers.
+	httpMiddlewares []func(http.Handler) http.Handler
+
+	// pprofContentionProfile is enabled while the manager is running.
+	pprofContentionProfile *profiling.ContentionProfile
+
+	// controllerConfig are the global controller options. They are guarded by
+	// controllerConfigLock, as they can be changed by reloading the config file.
+	controllerConfigLock sync.RWMutex
+	controllerConfig     config.Controller
 
 	// Logger is the logger that should be used by this manager.
 	// If none is set, it defaults to log.Log global logger.
@@ -148,13 +230,47 @@ type controllerManager struct {
 	retryPeriod time.Duration
 
 	// gracefulShutdownTimeout is the duration given to runnable to stop
-	// before the manager actually returns on stop.
-	gracefulShutdownTimeout time.Duration
+	// before the manager actually returns on stop. Once the manager was started it
+	// is guarded by gracefulShutdownTimeoutLock, as it is reset by leader electors
+	// that lost their lease.
+	gracefulShutdownTimeout     time.Duration
+	gracefulShutdownTimeoutLock sync.Mutex
+
+	// shutdownHooks are run in registration order during the stop procedure,
+	// before the caches and webhooks are stopped. It is guarded by shutdownHooksLock
+	// rather than the manager lock, as the stop procedure might be engaged while
+	// the manager lock is held.
+	shutdownHooks     []shutdownHook
+	shutdownHooksLock sync.Mutex
+
+	// shutdownHookTimeout is the maximum duration given to each shutdown hook.
+	shutdownHookTimeout time.Duration
+
+	// shutdownOrder is the order in which the groups of runnables are stopped,
+	// it lists every group exactly once.
+	shutdownOrder []ShutdownStep
 
 	// onStoppedLeading is callled when the leader election lease is lost.
 	// It can be overridden for tests.
 	onStoppedLeading func()
 
+	// startedLeadingCallback and stoppedLeadingCallback are the leader election
+	// callbacks set through the Options.
+	startedLeadingCallback func(context.Context)
+	stoppedLeadingCallback func()
+
+	// lifecycleEvents publishes the lifecycle events of the manager.
+	lifecycleEvents lifecycleEvents
+
+	// eventBus is the in-process event bus of the manager.
+	eventBus *eventbus.Bus
+
+	// startPhases tracks the phases of the start of the manager.
+	startPhases startPhases
+	// leaderElectionPhaseDone completes the leader election start phase once the
+	// manager became leader. It is set before the leader elector runs.
+	leaderElectionPhaseDone func()
+
 	// shutdownCtx is the context that can be used during shutdown. It will be cancelled
 	// after the gracefulShutdownTimeout ended. It must not be accessed before internalStop
 	// is closed because it will be nil.
@@ -168,6 +284,12 @@ type controllerManager struct {
 	internalProceduresStop chan struct{}
 }
 
+// shutdownHook is a named ShutdownHookFunc.
+type shutdownHook struct {
+	name string
+	fn   ShutdownHookFunc
+}
+
 type hasCache interface {
 	Runnable
 	GetCache() cache.Cache
@@ -181,9 +303,76 @@ func (cm *controllerManager) Add(r Runnable) error {
 }
 
 func (cm *controllerManager) add(r Runnable) error {
+	group := cm.controllerGroupFor(r)
+	if group != nil && group.stopped {
+		// The runnable is started once its controller group is started again.
+		group.runnables = append(group.runnables, r)
+		return nil
+	}
+	if err := cm.addRunnable(r); err != nil {
+		return err
+	}
+	if group != nil {
+		group.runnables = append(group.runnables, r)
+	}
+	return nil
+}
+
+// addRunnable adds r to the runnables of the manager or of its leader election group.
+func (cm *controllerManager) addRunnable(r Runnable) error {
+	if group := cm.leaderElectionGroupFor(r); group != "" {
+		return cm.addToLeaderElectionGroup(group, r)
+	}
 	return cm.runnables.Add(r)
 }
 
+// RemoveRunnable implements RunnableRemover.
+func (cm *controllerManager) RemoveRunnable(ctx context.Context, r Runnable) error {
+	if r == nil || !reflect.TypeOf(r).Comparable() {
+		return fmt.Errorf("runnable of type %T can't be removed as it isn't comparable", r)
+	}
+	cm.Lock()
+	inStoppedGroup := cm.untrackControllerGroupLocked(r)
+	cm.Unlock()
+	if inStoppedGroup {
+		// The runnable isn't running, it only needs to be forgotten.
+		return nil
+	}
+	return cm.removeRunnable(ctx, r)
+}
+
+// removeRunnable removes r from the runnables of the manager and its leader election
+// groups and waits for it to stop.
+func (cm *controllerManager) removeRunnable(ctx context.Context, r Runnable) error {
+	match := func(rn Runnable) bool {
+		if w, ok := rn.(*warmup); ok {
+			return any(w.runnable) == any(r)
+		}
+		return rn == r
+	}
+
+	cm.Lock()
+	done := cm.runnables.Remove(match)
+	cm.leaderElectionGroupsLock.Lock()
+	for _, group := range cm.leaderElectionGroups {
+		done = append(done, group.remove(match)...)
+	}
+	cm.leaderElectionGroupsLock.Unlock()
+	cm.Unlock()
+
+	if len(done) == 0 {
+		return fmt.Errorf("runnable %s was not added to the manager", runnableName(r))
+	}
+	for _, d := range done {
+		select {
+		case <-d:
+		case <-ctx.Done():
+			return fmt.Errorf("failed waiting for runnable %s to stop: %w", runnableName(r), ctx.Err())
+		}
+	}
+	return nil
+}
+
 // AddMetricsServerExtraHandler adds extra handler served on path to the http server that serves metrics.
 func (cm *controllerManager) AddMetricsServerExtraHandler(path string, handler http.Handler) error {
 	cm.Lock()
@@ -236,6 +425,52 @@ func (cm *controllerManager) AddReadyzCheck(name string, check healthz.Checker)
 	return nil
 }
 
+// RemoveHealthzCheck removes a Healthz checker.
+func (cm *controllerManager) RemoveHealthzCheck(name string) error {
+	cm.Lock()
+	defer cm.Unlock()
+
+	if cm.healthzHandler == nil || !cm.healthzHandler.RemoveCheck(name) {
+		return fmt.Errorf("healthz check %q was not added to the manager", name)
+	}
+	return nil
+}
+
+// RemoveReadyzCheck removes a Readyz checker.
+func (cm *controllerManager) RemoveReadyzCheck(name string) error {
+	cm.Lock()
+	defer cm.Unlock()
+
+	if cm.readyzHandler == nil || !cm.readyzHandler.RemoveCheck(name) {
+		return fmt.Errorf("readyz check %q was not added to the manager", name)
+	}
+	return nil
+}
+
+// AddShutdownHook allows you to add a hook that is run when the manager stops.
+func (cm *controllerManager) AddShutdownHook(name string, hook ShutdownHookFunc) error {
+	if name == "" {
+		return errors.New("shutdown hook name must not be empty")
+	}
+	if hook == nil {
+		return fmt.Errorf("shutdown hook %q must not be nil", name)
+	}
+	if atomic.LoadInt64(cm.stopProcedureEngaged) != 0 {
+		return fmt.Errorf("unable to add shutdown hook %q because stop procedure is already engaged", name)
+	}
+
+	cm.shutdownHooksLock.Lock()
+	defer cm.shutdownHooksLock.Unlock()
+
+	for _, existing := range cm.shutdownHooks {
+		if existing.name == name {
+			return fmt.Errorf("shutdown hook %q is already registered", name)
+		}
+	}
+	cm.shutdownHooks = append(cm.shutdownHooks, shutdownHook{name: name, fn: hook})
+	return nil
+}
+
 func (cm *controllerManager) GetHTTPClient() *http.Client {
 	return cm.cluster.GetHTTPClient()
 }
@@ -297,12 +532,14 @@ func (cm *controllerManager) GetLogger() logr.Logger {
 }
 
 func (cm *controllerManager) GetControllerOptions() config.Controller {
+	cm.controllerConfigLock.RLock()
+	defer cm.controllerConfigLock.RUnlock()
 	return cm.controllerConfig
 }
 
 func (cm *controllerManager) addHealthProbeServer() error {
 	mux := http.NewServeMux()
-	srv := httpserver.New(cm.internalCtx, mux)
+	srv := httpserver.New(cm.internalCtx, httpserver.WithMiddlewares(mux, cm.httpMiddlewares...))
 
 	if cm.readyzHandler != nil {
 		mux.Handle(cm.readinessEndpointName, http.StripPrefix(cm.readinessEndpointName, cm.readyzHandler))
@@ -315,6 +552,17 @@ func (cm *controllerManager) addHealthProbeServer() error {
 		mux.Handle(cm.livenessEndpointName+"/", http.StripPrefix(cm.livenessEndpointName, cm.healthzHandler))
 	}
 
+	if cm.healthProbeServerHook != nil {
+		if err := cm.healthProbeServerHook(srv, HealthProbes{
+			ReadinessEndpointName: cm.readinessEndpointName,
+			Readiness:             cm.readyzHandler,
+			LivenessEndpointName:  cm.livenessEndpointName,
+			Liveness:              cm.healthzHandler,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return cm.add(&Server{
 		Name:     "health probe",
 		Server:   srv,
@@ -324,13 +572,16 @@ func (cm *controllerManager) addHealthProbeServer() error {
 
 func (cm *controllerManager) addPprofServer() error {
 	mux := http.NewServeMux()
-	srv := httpserver.New(cm.internalCtx, mux)
+	srv := httpserver.New(cm.internalCtx, httpserver.WithMiddlewares(mux, cm.httpMiddlewares...))
 
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	if cm.pprofSnapshotDir != "" {
+		mux.Handle("/debug/pprof/snapshot", profiling.SnapshotHandler(cm.pprofSnapshotDir))
+	}
 
 	return cm.add(&Server{
 		Name:     "pprof",
@@ -351,6 +602,7 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 		return errors.New("manager already started")
 	}
 	cm.started = true
+	cm.setInfoMetric()
 
 	var ready bool
 	defer func() {
@@ -364,6 +616,12 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 	// Initialize the internal context.
 	cm.internalCtx, cm.internalCancel = context.WithCancel(ctx)
 
+	// Enable the contention profiles before any runnable starts and disable them
+	// again once all of them have stopped.
+	if cm.pprofContentionProfile != nil {
+		defer cm.pprofContentionProfile.Enable()()
+	}
+
 	// Leader elector must be created before defer that contains engageStopProcedure function
 	// https://github.com/kubernetes-sigs/controller-runtime/issues/2873
 	var leaderElector *leaderelection.LeaderElector
@@ -428,9 +686,11 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 	// WARNING: HTTPServers includes the health probes, which MUST start before any cache is populated, otherwise
 	// it would block conversion webhooks to be ready for serving which make the cache never get ready.
 	logCtx := logr.NewContext(cm.internalCtx, cm.logger)
+	phaseDone := cm.startPhases.begin(cm.logger, StartPhaseHTTPServers)
 	if err := cm.runnables.HTTPServers.Start(logCtx); err != nil {
 		return fmt.Errorf("failed to start HTTP servers: %w", err)
 	}
+	phaseDone()
 
 	// Start any webhook servers, which includes conversion, validation, and defaulting
 	// webhooks that are registered.
@@ -438,14 +698,19 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 	// WARNING: Webhooks MUST start before any cache is populated, otherwise there is a race condition
 	// between conversion webhooks and the cache sync (usually initial list) which causes the webhooks
 	// to never start because no cache can be populated.
+	phaseDone = cm.startPhases.begin(cm.logger, StartPhaseWebhooks)
 	if err := cm.runnables.Webhooks.Start(cm.internalCtx); err != nil {
 		return fmt.Errorf("failed to start webhooks: %w", err)
 	}
+	phaseDone()
 
 	// Start and wait for caches.
+	phaseDone = cm.startPhases.begin(cm.logger, StartPhaseCaches)
 	if err := cm.runnables.Caches.Start(cm.internalCtx); err != nil {
 		return fmt.Errorf("failed to start caches: %w", err)
 	}
+	phaseDone()
+	cm.lifecycleEvents.publish(LifecycleEvent{Type: CachesSynced})
 
 	// Start the non-leaderelection Runnables after the cache has synced.
 	if err := cm.runnables.Others.Start(cm.internalCtx); err != nil {
@@ -453,9 +718,11 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 	}
 
 	// Start WarmupRunnables and wait for warmup to complete.
+	phaseDone = cm.startPhases.begin(cm.logger, StartPhaseWarmup)
 	if err := cm.runnables.Warmup.Start(cm.internalCtx); err != nil {
 		return fmt.Errorf("failed to start warmup runnables: %w", err)
 	}
+	phaseDone()
 
 	// Start the leader election and all required runnables.
 	{
@@ -465,19 +732,25 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 		leaderCtx, cancel := context.WithCancel(baseCtx)
 		cm.leaderElectionCancel = cancel
 		if leaderElector != nil {
+			cm.leaderElectionPhaseDone = cm.startPhases.begin(cm.logger, StartPhaseLeaderElection)
 			// Start the leader elector process
 			go func() {
-				leaderElector.Run(leaderCtx)
+				cm.runLeaderElection(leaderCtx, leaderElector)
 				<-leaderCtx.Done()
 				close(cm.leaderElectionStopped)
 			}()
+			cm.startLeaderElectionGroups(leaderCtx)
 		} else {
 			go func() {
 				// Treat not having leader election enabled the same as being elected.
+				phaseDone := cm.startPhases.begin(cm.logger, StartPhaseLeaderElectionRunnables)
 				if err := cm.startLeaderElectionRunnables(); err != nil {
 					cm.errChan <- err
+				} else {
+					phaseDone()
 				}
-				close(cm.elected)
+				cm.electedOnce.Do(func() { close(cm.elected) })
+				cm.lifecycleEvents.publish(LifecycleEvent{Type: LeadershipAcquired})
 			}()
 		}
 	}
@@ -490,6 +763,7 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 		return nil
 	case err := <-cm.errChan:
 		// Error starting or running a runnable
+		cm.lifecycleEvents.publish(LifecycleEvent{Type: RunnableFailed, Err: err})
 		return err
 	}
 }
@@ -500,20 +774,28 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 	if !atomic.CompareAndSwapInt64(cm.stopProcedureEngaged, 0, 1) {
 		return errors.New("stop procedure already engaged")
 	}
+	cm.lifecycleEvents.publish(LifecycleEvent{Type: ShutdownInitiated})
 
 	// Populate the shutdown context, this operation MUST be done before
 	// closing the internalProceduresStop channel.
 	//
 	// The shutdown context immediately expires if the gracefulShutdownTimeout is not set.
+	gracefulShutdownTimeout := cm.getGracefulShutdownTimeout()
 	var shutdownCancel context.CancelFunc
-	if cm.gracefulShutdownTimeout < 0 {
+	if gracefulShutdownTimeout < 0 {
 		// We want to wait forever for the runnables to stop.
 		cm.shutdownCtx, shutdownCancel = context.WithCancel(context.Background())
 	} else {
-		cm.shutdownCtx, shutdownCancel = context.WithTimeout(context.Background(), cm.gracefulShutdownTimeout)
+		cm.shutdownCtx, shutdownCancel = context.WithTimeout(context.Background(), gracefulShutdownTimeout)
 	}
 	defer shutdownCancel()
 
+	// shutdownHooksErr receives the result of the shutdown hooks once they ran.
+	// The hooks are skipped if graceful shutdown is disabled, which is also the
+	// case after the leader election lease was lost.
+	shutdownHooksErr := make(chan error, 1)
+	skipShutdownHooks := gracefulShutdownTimeout == 0
+
 	// Start draining the errors before acquiring the lock to make sure we don't deadlock
 	// if something that has the lock is blocked on trying to write into the unbuffered
 	// channel after something else already wrote into it.
@@ -531,6 +813,7 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 			case err := <-cm.errChan:
 				if !errors.Is(err, context.Canceled) {
 					cm.logger.Error(err, "error received after stop sequence was engaged")
+					cm.lifecycleEvents.publish(LifecycleEvent{Type: RunnableFailed, Err: err})
 				}
 			case <-stopComplete:
 				return
@@ -549,8 +832,18 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 			// we wait for the leader stopped channel to be closed, otherwise
 			// we might encounter race conditions between this code
 			// and the event recorder, which is used within leader election code.
+			cm.leaderTermLock.Lock()
+			wasLeading := cm.leading
+			cm.leaderTermLock.Unlock()
+
 			cm.leaderElectionCancel()
 			<-cm.leaderElectionStopped
+			cm.waitForLeaderElectionGroupsStopped()
+
+			// Hand over the lease only once the leader elector stopped renewing it.
+			if wasLeading && cm.leaderElectionHandoverTarget != nil {
+				cm.handOverLeadership()
+			}
 		}
 	}()
 
@@ -567,28 +860,21 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 			cm.runnables.Warmup.StopAndWait(cm.shutdownCtx)
 		}()
 
-		// First stop the non-leader election runnables.
-		cm.logger.Info("Stopping and waiting for non leader election runnables")
-		cm.runnables.Others.StopAndWait(cm.shutdownCtx)
-
-		// Stop all the leader election runnables, which includes reconcilers.
-		cm.logger.Info("Stopping and waiting for leader election runnables")
-		// Prevent leader election when shutting down a non-elected manager
-		cm.runnables.LeaderElection.startOnce.Do(func() {})
-		cm.runnables.LeaderElection.StopAndWait(cm.shutdownCtx)
-
-		// Stop the caches before the leader election runnables, this is an important
-		// step to make sure that we don't race with the reconcilers by receiving more events
-		// from the API servers and enqueueing them.
-		cm.logger.Info("Stopping and waiting for caches")
-		cm.runnables.Caches.StopAndWait(cm.shutdownCtx)
-
-		// Webhooks and internal HTTP servers should come last, as they might be still serving some requests.
-		cm.logger.Info("Stopping and waiting for webhooks")
-		cm.runnables.Webhooks.StopAndWait(cm.shutdownCtx)
-
-		cm.logger.Info("Stopping and waiting for HTTP servers")
-		cm.runnables.HTTPServers.StopAndWait(cm.shutdownCtx)
+		for _, step := range cm.shutdownOrder {
+			cm.stopRunnableGroup(step)
+
+			// Run the shutdown hooks while the remaining runnables are still available,
+			// now that no reconciler is running anymore. The hooks share the grace period
+			// with the runnables, so that they can't delay the shutdown beyond it.
+			if step.Group == RunnableGroupLeaderElection {
+				if skipShutdownHooks {
+					cm.skipShutdownHooks()
+					shutdownHooksErr <- nil
+				} else {
+					shutdownHooksErr <- cm.runShutdownHooks(cm.shutdownCtx)
+				}
+			}
+		}
 
 		// Proceed to close the manager and overall shutdown context.
 		cm.logger.Info("Wait completed, proceeding to shutdown the manager")
@@ -596,18 +882,168 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 	}()
 
 	<-cm.shutdownCtx.Done()
+
+	// The shutdown hooks ran before the shutdown context was cancelled, unless the
+	// grace period expired first, e.g. because a runnable didn't stop in time. Their
+	// result isn't awaited beyond the grace period then.
+	var hooksErr error
+	select {
+	case hooksErr = <-shutdownHooksErr:
+	default:
+		if !skipShutdownHooks {
+			hooksErr = fmt.Errorf("shutdown hooks did not complete within the grace period: %w", context.DeadlineExceeded)
+		}
+	}
 	if err := cm.shutdownCtx.Err(); err != nil && !errors.Is(err, context.Canceled) {
 		if errors.Is(err, context.DeadlineExceeded) {
-			if cm.gracefulShutdownTimeout > 0 {
-				return fmt.Errorf("failed waiting for all runnables to end within grace period of %s: %w", cm.gracefulShutdownTimeout, err)
+			if gracefulShutdownTimeout > 0 {
+				err = fmt.Errorf("failed waiting for all runnables to end within grace period of %s: %w", gracefulShutdownTimeout, err)
+				if hooksErr != nil {
+					return kerrors.NewAggregate([]error{err, hooksErr})
+				}
+				return err
 			}
-			return nil
+			return hooksErr
 		}
 		// For any other error, return the error.
+		if hooksErr != nil {
+			return kerrors.NewAggregate([]error{err, hooksErr})
+		}
 		return err
 	}
+	return hooksErr
+}
 
-	return nil
+// stopRunnableGroup stops the runnables of a group and waits for them to return,
+// at most for the grace period of the step.
+func (cm *controllerManager) stopRunnableGroup(step ShutdownStep) {
+	ctx := cm.shutdownCtx
+	if step.GracePeriod != nil && *step.GracePeriod >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *step.GracePeriod)
+		defer cancel()
+	}
+
+	switch step.Group {
+	case RunnableGroupOthers:
+		cm.logger.Info("Stopping and waiting for non leader election runnables")
+		cm.runnables.Others.StopAndWait(ctx)
+	case RunnableGroupLeaderElection:
+		// Stop all the leader election runnables, which includes reconcilers.
+		cm.logger.Info("Stopping and waiting for leader election runnables")
+		cm.runnables.stopLeaderElection(ctx)
+		cm.stopLeaderElectionGroupRunnables(ctx)
+	case RunnableGroupCaches:
+		// Stop the caches after the leader election runnables, this is an important
+		// step to make sure that we don't race with the reconcilers by receiving more events
+		// from the API servers and enqueueing them.
+		cm.logger.Info("Stopping and waiting for caches")
+		cm.runnables.Caches.StopAndWait(ctx)
+	case RunnableGroupWebhooks:
+		// Webhooks and internal HTTP servers should come last by default, as they might be
+		// still serving some requests.
+		cm.logger.Info("Stopping and waiting for webhooks")
+		cm.runnables.Webhooks.StopAndWait(ctx)
+	case RunnableGroupHTTPServers:
+		cm.logger.Info("Stopping and waiting for HTTP servers")
+		cm.runnables.HTTPServers.StopAndWait(ctx)
+	}
+}
+
+// completeShutdownOrder validates the given shutdown order and appends the groups
+// it doesn't list in the default order.
+func completeShutdownOrder(order []ShutdownStep) ([]ShutdownStep, error) {
+	complete := make([]ShutdownStep, 0, len(defaultShutdownOrder))
+	listed := sets.New[RunnableGroup]()
+	for _, step := range order {
+		if !slices.Contains(defaultShutdownOrder, step.Group) {
+			return nil, fmt.Errorf("unknown runnable group %q in shutdown order", step.Group)
+		}
+		if listed.Has(step.Group) {
+			return nil, fmt.Errorf("runnable group %q is listed more than once in shutdown order", step.Group)
+		}
+		listed.Insert(step.Group)
+		complete = append(complete, step)
+	}
+	for _, group := range defaultShutdownOrder {
+		if !listed.Has(group) {
+			complete = append(complete, ShutdownStep{Group: group})
+		}
+	}
+
+	// Reconcilers must not run without their caches, and webhooks may be called by
+	// the API server as long as reconcilers write to it.
+	groupIndex := func(group RunnableGroup) int {
+		return slices.IndexFunc(complete, func(step ShutdownStep) bool { return step.Group == group })
+	}
+	for _, group := range []RunnableGroup{RunnableGroupCaches, RunnableGroupWebhooks} {
+		if groupIndex(group) < groupIndex(RunnableGroupLeaderElection) {
+			return nil, fmt.Errorf("runnable group %q must be stopped before %q in shutdown order", RunnableGroupLeaderElection, group)
+		}
+	}
+	return complete, nil
+}
+
+// skipShutdownHooks logs that the registered shutdown hooks are not run.
+func (cm *controllerManager) skipShutdownHooks() {
+	cm.shutdownHooksLock.Lock()
+	hooks := len(cm.shutdownHooks)
+	cm.shutdownHooksLock.Unlock()
+
+	if hooks > 0 {
+		cm.logger.Info("Skipping shutdown hooks because graceful shutdown is disabled or the leader election lease was lost", "hooks", hooks)
+	}
+}
+
+// runShutdownHooks runs all registered shutdown hooks sequentially in registration
+// order, each bound by the shutdownHookTimeout and the given context, and returns the
+// aggregate of their errors. Hooks that didn't start before the context is done are
+// skipped and reported as failed.
+func (cm *controllerManager) runShutdownHooks(ctx context.Context) error {
+	cm.shutdownHooksLock.Lock()
+	hooks := cm.shutdownHooks
+	cm.shutdownHooksLock.Unlock()
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	cm.logger.Info("Running shutdown hooks")
+	var errs []error
+	for _, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			cm.logger.Info("Skipping shutdown hook because the graceful shutdown timeout expired", "name", hook.name)
+			errs = append(errs, fmt.Errorf("shutdown hook %q was skipped: %w", hook.name, err))
+			continue
+		}
+		cm.logger.V(1).Info("Running shutdown hook", "name", hook.name)
+		if err := cm.runShutdownHook(ctx, hook); err != nil {
+			cm.logger.Error(err, "Shutdown hook failed", "name", hook.name)
+			errs = append(errs, fmt.Errorf("shutdown hook %q failed: %w", hook.name, err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+func (cm *controllerManager) runShutdownHook(ctx context.Context, hook shutdownHook) error {
+	if cm.shutdownHookTimeout >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cm.shutdownHookTimeout)
+		defer cancel()
+	}
+
+	// Hooks that ignore the context must not block the shutdown beyond their timeout
+	// or the grace period, so they are abandoned once the context is done.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- hook.fn(ctx)
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return context.DeadlineExceeded
+	}
 }
 
 func (cm *controllerManager) initLeaderElector() (*leaderelection.LeaderElector, error) {
@@ -617,20 +1053,54 @@ func (cm *controllerManager) initLeaderElector() (*leaderelection.LeaderElector,
 		RenewDeadline: cm.renewDeadline,
 		RetryPeriod:   cm.retryPeriod,
 		Callbacks: leaderelection.LeaderCallbacks{
-			OnStartedLeading: func(_ context.Context) {
-				if err := cm.startLeaderElectionRunnables(); err != nil {
+			OnStartedLeading: func(ctx context.Context) {
+				cm.leaderTermLock.Lock()
+				if ctx.Err() != nil {
+					// Leadership was lost again already.
+					cm.leaderTermLock.Unlock()
+					return
+				}
+				cm.leading = true
+				leaderElectionRunnables := cm.runnables.leaderElection()
+				cm.leaderTermLock.Unlock()
+				if cm.leaderElectionPhaseDone != nil {
+					cm.leaderElectionPhaseDone()
+				}
+
+				phaseDone := cm.startPhases.begin(cm.logger, StartPhaseLeaderElectionRunnables)
+				if err := leaderElectionRunnables.Start(cm.internalCtx); err != nil {
 					cm.errChan <- err
 					return
 				}
-				close(cm.elected)
+				phaseDone()
+				cm.electedOnce.Do(func() { close(cm.elected) })
+				cm.lifecycleEvents.publish(LifecycleEvent{Type: LeadershipAcquired})
+				if cm.startedLeadingCallback != nil {
+					cm.startedLeadingCallback(ctx)
+				}
 			},
 			OnStoppedLeading: func() {
+				// The leader elector also calls this if leadership was never acquired.
+				cm.leaderTermLock.Lock()
+				wasLeading := cm.leading
+				cm.leading = false
+				cm.leaderTermLock.Unlock()
+				if wasLeading {
+					cm.lifecycleEvents.publish(LifecycleEvent{Type: LeadershipLost})
+					if cm.stoppedLeadingCallback != nil {
+						cm.stoppedLeadingCallback()
+					}
+				}
 				if cm.onStoppedLeading != nil {
 					cm.onStoppedLeading()
 				}
+				if cm.leaderElectionReacquireOnLoss {
+					cm.stopLeaderElectionRunnablesForReacquire()
+					return
+				}
 				// Make sure graceful shutdown is skipped if we lost the leader lock without
 				// intending to.
-				cm.gracefulShutdownTimeout = time.Duration(0)
+				cm.disableGracefulShutdown()
 				// Most implementations of leader election log.Fatal() here.
 				// Since Start is wrapped in log.Fatal when called, we can just return
 				// an error here which will cause the program to exit.
@@ -648,7 +1118,197 @@ func (cm *controllerManager) initLeaderElector() (*leaderelection.LeaderElector,
 }
 
 func (cm *controllerManager) startLeaderElectionRunnables() error {
-	return cm.runnables.LeaderElection.Start(cm.internalCtx)
+	return cm.runnables.leaderElection().Start(cm.internalCtx)
+}
+
+// getGracefulShutdownTimeout returns the graceful shutdown timeout, which is zero after
+// a leader election lease was lost.
+func (cm *controllerManager) getGracefulShutdownTimeout() time.Duration {
+	cm.gracefulShutdownTimeoutLock.Lock()
+	defer cm.gracefulShutdownTimeoutLock.Unlock()
+	return cm.gracefulShutdownTimeout
+}
+
+// disableGracefulShutdown makes the manager stop without waiting for its runnables.
+func (cm *controllerManager) disableGracefulShutdown() {
+	cm.gracefulShutdownTimeoutLock.Lock()
+	defer cm.gracefulShutdownTimeoutLock.Unlock()
+	cm.gracefulShutdownTimeout = time.Duration(0)
+}
+
+// runLeaderElection runs the leader elector until the context is cancelled. If leadership
+// should be re-acquired on loss, a new leader elector is run after leadership was lost,
+// and after LeaseDuration if the manager stepped down voluntarily.
+func (cm *controllerManager) runLeaderElection(ctx context.Context, leaderElector *leaderelection.LeaderElector) {
+	if !cm.leaderElectionReacquireOnLoss {
+		leaderElector.Run(ctx)
+		return
+	}
+
+	for first := true; ctx.Err() == nil; first = false {
+		// A LeaderElector must not be run again, create a new one for every term.
+		if !first {
+			var err error
+			leaderElector, err = cm.initLeaderElector()
+			if err != nil {
+				cm.errChan <- fmt.Errorf("failed during initialization leader election process: %w", err)
+				return
+			}
+		}
+
+		termCtx, cancel := context.WithCancel(ctx)
+		cm.leaderTermLock.Lock()
+		cm.leaderTermCancel = cancel
+		cm.leaderTermLock.Unlock()
+
+		leaderElector.Run(termCtx)
+		cancel()
+
+		cm.leaderTermLock.Lock()
+		steppedDown := cm.steppedDown
+		cm.steppedDown = false
+		cm.leaderTermCancel = nil
+		cm.leaderTermLock.Unlock()
+
+		if steppedDown {
+			// Give other replicas the chance to acquire the lease.
+			select {
+			case <-ctx.Done():
+			case <-time.After(cm.leaseDuration):
+			}
+		}
+	}
+}
+
+// stopLeaderElectionRunnablesForReacquire stops the leader election runnables after leadership
+// was lost and prepares them to be started again once leadership is re-acquired.
+func (cm *controllerManager) stopLeaderElectionRunnablesForReacquire() {
+	// The leader elector also returns when the manager is stopped, which takes
+	// care of stopping the runnables on its own.
+	if atomic.LoadInt64(cm.stopProcedureEngaged) != 0 {
+		return
+	}
+
+	// Replace the runnables while holding the lock, so that a concurrent
+	// OnStartedLeading of the ended term can't start the new group.
+	cm.leaderTermLock.Lock()
+	cm.leading = false
+	steppedDown := cm.steppedDown
+	previous := cm.runnables.resetLeaderElection()
+	cm.leaderTermLock.Unlock()
+	if previous == nil {
+		return
+	}
+
+	cm.logger.Info("Leadership lost, stopping leader election runnables until it is re-acquired", "steppedDown", steppedDown)
+	cm.stopRunnablesForReacquire(previous, steppedDown)
+}
+
+// stopRunnablesForReacquire stops the runnables of a leader election term that ended and
+// prepares them to be started again in the next term. Only a voluntary step down grants
+// them the graceful shutdown timeout: after an involuntary loss another replica might be
+// leading already, so they are stopped without grace just like when the manager exits.
+// Either way it waits for all of them to return, so that they never run twice at a time.
+func (cm *controllerManager) stopRunnablesForReacquire(previous *runnableGroup, graceful bool) {
+	timeout := time.Duration(0)
+	if graceful {
+		timeout = cm.getGracefulShutdownTimeout()
+	}
+	ctx := context.Background()
+	if timeout >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Don't start the runnables if leadership was lost before they were started.
+	previous.startOnce.Do(func() {})
+	previous.StopAndWait(ctx)
+	if ctx.Err() != nil {
+		cm.logger.Info("Waiting for the runnables of the previous leader election term to return before campaigning again")
+	}
+	previous.waitStopped()
+
+	for _, rn := range previous.added() {
+		if restartable, ok := rn.Runnable.(RestartableRunnable); ok {
+			restartable.PrepareRestart()
+		}
+	}
+}
+
+// handOverLeadership hands the leader lease over to the replica returned by the
+// handover target. The lease is written as if that replica renewed it, so that it
+// recognizes itself as the leader with its next retry, while the other replicas
+// keep waiting for the lease to expire.
+func (cm *controllerManager) handOverLeadership() {
+	ctx, cancel := context.WithTimeout(context.Background(), cm.renewDeadline)
+	defer cancel()
+
+	target, err := cm.leaderElectionHandoverTarget(ctx)
+	if err != nil {
+		cm.logger.Error(err, "Failed to determine the leader election handover target, skipping the handover")
+		return
+	}
+	if target == "" || target == cm.resourceLock.Identity() {
+		return
+	}
+
+	record, _, err := cm.resourceLock.Get(ctx)
+	if err != nil {
+		cm.logger.Error(err, "Failed to get the leader election lock for the handover", "target", target)
+		return
+	}
+	// The lease is empty if it was released on cancel. Don't overwrite it if
+	// another replica acquired it meanwhile.
+	if record.HolderIdentity != "" && record.HolderIdentity != cm.resourceLock.Identity() {
+		cm.logger.Info("Skipping the leader election handover, the lease is held by another replica", "holder", record.HolderIdentity, "target", target)
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	if err := cm.resourceLock.Update(ctx, resourcelock.LeaderElectionRecord{
+		HolderIdentity:       target,
+		LeaseDurationSeconds: int(cm.leaseDuration / time.Second),
+		AcquireTime:          now,
+		RenewTime:            now,
+		LeaderTransitions:    record.LeaderTransitions + 1,
+	}); err != nil {
+		cm.logger.Error(err, "Failed to hand over the leader election lease", "target", target)
+		return
+	}
+	cm.logger.Info("Handed over the leader election lease", "target", target)
+}
+
+// GetStartPhases implements StartPhaseDescriber.
+func (cm *controllerManager) GetStartPhases() []StartPhaseInfo {
+	return cm.startPhases.list()
+}
+
+// GetEventBus implements EventBusProvider.
+func (cm *controllerManager) GetEventBus() *eventbus.Bus {
+	return cm.eventBus
+}
+
+// Subscribe implements LifecycleSubscriber.
+func (cm *controllerManager) Subscribe(f func(LifecycleEvent), types ...LifecycleEventType) (unsubscribe func()) {
+	return cm.lifecycleEvents.subscribe(f, types...)
+}
+
+// StepDown implements StepDowner.
+func (cm *controllerManager) StepDown() error {
+	if !cm.leaderElectionReacquireOnLoss || cm.resourceLock == nil {
+		return errors.New("stepping down requires leader election with LeaderElectionReacquireOnLoss")
+	}
+
+	cm.leaderTermLock.Lock()
+	defer cm.leaderTermLock.Unlock()
+	if !cm.leading || cm.leaderTermCancel == nil {
+		return errors.New("manager is not the leader")
+	}
+	cm.logger.Info("Stepping down as leader")
+	cm.steppedDown = true
+	cm.leaderTermCancel()
+	return nil
 }
 
 func (cm *controllerManager) Elected() <-chan struct{} {
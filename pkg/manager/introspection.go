@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// debugManagerEndpoint serves the runnables of the manager on the metrics server.
+	debugManagerEndpoint = "/debug/manager"
+
+	// debugPendingRequestsEndpoint serves the pending requests of a controller on the
+	// metrics server.
+	debugPendingRequestsEndpoint = "/debug/manager/pending-requests"
+)
+
+// RunnableState is the state of a runnable registered with a Manager.
+type RunnableState string
+
+const (
+	// RunnableStatePending means that the runnable wasn't started yet, e.g. because
+	// the Manager isn't started or isn't the leader.
+	RunnableStatePending RunnableState = "Pending"
+
+	// RunnableStateRunning means that the runnable was started and didn't return yet.
+	RunnableStateRunning RunnableState = "Running"
+
+	// RunnableStateStopped means that the runnable returned.
+	RunnableStateStopped RunnableState = "Stopped"
+)
+
+// RunnableInfo describes a runnable registered with a Manager.
+type RunnableInfo struct {
+	// Name is the name of the controller, it is empty for other runnables.
+	Name string `json:"name,omitempty"`
+
+	// Type is the Go type of the runnable.
+	Type string `json:"type"`
+
+	// Group is the group of runnables the Manager starts and stops the runnable with.
+	// Runnables with a warmup are listed a second time with the Warmup group.
+	Group RunnableGroup `json:"group"`
+
+	// NeedLeaderElection is true if the runnable is only run while the Manager,
+	// or its leader election group, is the leader.
+	NeedLeaderElection bool `json:"needLeaderElection"`
+
+	// LeaderElectionGroup is the leader election group the runnable is part of.
+	// It is empty for runnables that are part of the Manager's leader election.
+	LeaderElectionGroup string `json:"leaderElectionGroup,omitempty"`
+
+	// State is the state of the runnable.
+	State RunnableState `json:"state"`
+}
+
+// controllerRunnable is implemented by controllers, so that they can be told apart
+// from other runnables.
+type controllerRunnable interface {
+	ControllerName() string
+}
+
+// pendingRequestsDescriber is implemented by controllers that can describe the
+// requests that are pending in their queue.
+type pendingRequestsDescriber interface {
+	ControllerName() string
+	DescribePendingRequests() any
+}
+
+// describedRunnableGroup is a group of runnables as described by GetRunnables.
+type describedRunnableGroup struct {
+	name                RunnableGroup
+	leaderElectionGroup string
+	runnables           *runnableGroup
+}
+
+// describedRunnableGroups returns the groups of runnables in the order GetRunnables
+// describes them.
+func (cm *controllerManager) describedRunnableGroups() []describedRunnableGroup {
+	groups := []describedRunnableGroup{
+		{name: RunnableGroupHTTPServers, runnables: cm.runnables.HTTPServers},
+		{name: RunnableGroupWebhooks, runnables: cm.runnables.Webhooks},
+		{name: RunnableGroupCaches, runnables: cm.runnables.Caches},
+		{name: RunnableGroupLeaderElection, runnables: cm.runnables.leaderElection()},
+		{name: RunnableGroupOthers, runnables: cm.runnables.Others},
+		{name: RunnableGroupWarmup, runnables: cm.runnables.Warmup},
+	}
+
+	cm.leaderElectionGroupsLock.Lock()
+	leaderElectionGroups := make([]*leaderElectionGroup, 0, len(cm.leaderElectionGroups))
+	for _, group := range cm.leaderElectionGroups {
+		leaderElectionGroups = append(leaderElectionGroups, group)
+	}
+	cm.leaderElectionGroupsLock.Unlock()
+	slices.SortFunc(leaderElectionGroups, func(a, b *leaderElectionGroup) int {
+		return strings.Compare(a.name, b.name)
+	})
+	for _, group := range leaderElectionGroups {
+		groups = append(groups, describedRunnableGroup{
+			name:                RunnableGroupLeaderElection,
+			leaderElectionGroup: group.name,
+			runnables:           group.current(),
+		})
+	}
+	return groups
+}
+
+// GetRunnables implements RunnableDescriber.
+func (cm *controllerManager) GetRunnables() []RunnableInfo {
+	var infos []RunnableInfo
+	for _, group := range cm.describedRunnableGroups() {
+		infos = append(infos, describeRunnables(group.name, group.leaderElectionGroup, group.runnables)...)
+	}
+	return infos
+}
+
+// GetControllers implements RunnableDescriber.
+func (cm *controllerManager) GetControllers() []RunnableInfo {
+	return slices.DeleteFunc(cm.GetRunnables(), func(info RunnableInfo) bool {
+		return info.Name == ""
+	})
+}
+
+func describeRunnables(group RunnableGroup, leaderElectionGroup string, runnables *runnableGroup) []RunnableInfo {
+	added := runnables.added()
+	infos := make([]RunnableInfo, 0, len(added))
+	for _, rn := range added {
+		// Describe the runnable that is warmed up rather than its warmup.
+		var runnable any = rn.Runnable
+		if w, ok := runnable.(*warmup); ok {
+			runnable = w.runnable
+		}
+		info := RunnableInfo{
+			Type:                fmt.Sprintf("%T", runnable),
+			Group:               group,
+			NeedLeaderElection:  group == RunnableGroupLeaderElection,
+			LeaderElectionGroup: leaderElectionGroup,
+			State:               rn.state(),
+		}
+		if controller, ok := runnable.(controllerRunnable); ok {
+			info.Name = controller.ControllerName()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// pendingRequests returns the pending requests of the controller with the given name, and
+// false if there is no such controller or it can't describe its pending requests.
+func (cm *controllerManager) pendingRequests(name string) (any, bool) {
+	for _, group := range cm.describedRunnableGroups() {
+		for _, rn := range group.runnables.added() {
+			if controller, ok := rn.Runnable.(pendingRequestsDescriber); ok && controller.ControllerName() == name {
+				return controller.DescribePendingRequests(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// pendingRequestsHandler serves the pending requests of the controller that is named by
+// the controller query parameter as JSON.
+func (cm *controllerManager) pendingRequestsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("controller")
+		if name == "" {
+			http.Error(w, "the controller query parameter is required", http.StatusBadRequest)
+			return
+		}
+		pending, ok := cm.pendingRequests(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("controller %q not found", name), http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(pending); err != nil {
+			cm.logger.Error(err, "unable to encode the pending requests of a controller", "controller", name)
+		}
+	})
+}
+
+// introspectionHandler serves the runnables of the Manager as JSON.
+func (cm *controllerManager) introspectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cm.GetRunnables()); err != nil {
+			cm.logger.Error(err, "unable to encode the runnables of the manager")
+		}
+	})
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// leaderElectionGroup is a named group of leader election runnables that is
+// elected through its own lease, independently of the manager's leader election.
+type leaderElectionGroup struct {
+	name string
+	lock resourcelock.Interface
+	// elector is the leader elector of the first campaign. A LeaderElector must not
+	// be run again, so a new one is created for every further campaign.
+	elector *leaderelection.LeaderElector
+
+	// mu guards the fields below. runnables is replaced by a new group holding the
+	// same runnables when the lease of the group was lost and is campaigned for again.
+	mu        sync.Mutex
+	runnables *runnableGroup
+	stopping  bool
+	// leading is set while the group holds its lease.
+	leading bool
+
+	// stopped is closed once the leader elector of the group returned.
+	// It is nil as long as the leader elector wasn't started.
+	stopped chan struct{}
+}
+
+// leaderElectionGroupID returns the ID of the lease used by the given leader election group.
+func leaderElectionGroupID(leaderElectionID, group string) string {
+	return leaderElectionID + "-" + group
+}
+
+// leaderElectionGroupFor returns the leader election group the runnable belongs to,
+// or the empty string if it is part of the manager's leader election.
+func (cm *controllerManager) leaderElectionGroupFor(r Runnable) string {
+	if cm.newGroupResourceLock == nil {
+		return ""
+	}
+	groupRunnable, ok := r.(LeaderElectionGroupRunnable)
+	if !ok {
+		return ""
+	}
+	if leaderElectionRunnable, ok := r.(LeaderElectionRunnable); ok && !leaderElectionRunnable.NeedLeaderElection() {
+		return ""
+	}
+	return groupRunnable.LeaderElectionGroup()
+}
+
+// addToLeaderElectionGroup adds the runnable to the given leader election group. The group
+// is created on first use, and its leader election is started right away if the manager
+// is already running leader election.
+func (cm *controllerManager) addToLeaderElectionGroup(name string, r Runnable) error {
+	if atomic.LoadInt64(cm.stopProcedureEngaged) != 0 {
+		return errRunnableGroupStopped
+	}
+
+	cm.leaderElectionGroupsLock.Lock()
+	defer cm.leaderElectionGroupsLock.Unlock()
+
+	group, ok := cm.leaderElectionGroups[name]
+	if !ok {
+		lock, err := cm.newGroupResourceLock(name)
+		if err != nil {
+			return fmt.Errorf("failed to create resource lock for leader election group %q: %w", name, err)
+		}
+		group = &leaderElectionGroup{name: name, lock: lock}
+		group.runnables = newRunnableGroup(cm.baseContext, cm.errChan)
+		group.runnables.withLogger(cm.logger)
+		group.runnables.withPanicRecovery(cm.runnables.panicRecovery)
+		group.elector, err = cm.initGroupLeaderElector(group)
+		if err != nil {
+			return fmt.Errorf("failed during initialization of leader election group %q: %w", name, err)
+		}
+		cm.leaderElectionGroups[name] = group
+
+		if cm.leaderElectionCtx != nil {
+			cm.startLeaderElectionGroup(cm.leaderElectionCtx, group)
+		}
+	}
+
+	if warmupRunnable, ok := r.(warmupRunnable); ok {
+		if err := cm.runnables.Warmup.Add(&warmup{runnable: warmupRunnable}, nil); err != nil {
+			return err
+		}
+	}
+	return group.add(r)
+}
+
+// add adds the runnable to the current runnables of the group.
+func (g *leaderElectionGroup) add(r Runnable) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.runnables.Add(r, nil)
+}
+
+// remove removes the runnables that match from the current runnables of the group,
+// see runnableGroup.remove.
+func (g *leaderElectionGroup) remove(match func(Runnable) bool) []<-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.runnables.remove(match)
+}
+
+// current returns the current runnables of the group.
+func (g *leaderElectionGroup) current() *runnableGroup {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.runnables
+}
+
+// reset replaces the runnables of the group by a new group that holds the same runnables
+// but isn't started yet, and returns the previous group which the caller has to stop.
+// It returns nil if the group is stopped for good.
+func (g *leaderElectionGroup) reset(cm *controllerManager) *runnableGroup {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.stopping {
+		return nil
+	}
+	previous := g.runnables
+	g.runnables = renewRunnableGroup(previous, cm.baseContext, cm.errChan, cm.logger)
+	return previous
+}
+
+// stop stops the runnables of the group for good and waits for them to return.
+func (g *leaderElectionGroup) stop(ctx context.Context) {
+	g.mu.Lock()
+	g.stopping = true
+	runnables := g.runnables
+	g.mu.Unlock()
+
+	// Prevent leader election when shutting down a group that wasn't elected.
+	runnables.startOnce.Do(func() {})
+	runnables.StopAndWait(ctx)
+}
+
+func (cm *controllerManager) initGroupLeaderElector(group *leaderElectionGroup) (*leaderelection.LeaderElector, error) {
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          group.lock,
+		LeaseDuration: cm.leaseDuration,
+		RenewDeadline: cm.renewDeadline,
+		RetryPeriod:   cm.retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				group.mu.Lock()
+				if ctx.Err() != nil {
+					// Leadership was lost again already.
+					group.mu.Unlock()
+					return
+				}
+				runnables := group.runnables
+				group.leading = true
+				group.mu.Unlock()
+
+				cm.logger.Info("Elected leader of leader election group", "group", group.name)
+				if err := runnables.Start(cm.internalCtx); err != nil {
+					cm.errChan <- err
+					return
+				}
+				cm.lifecycleEvents.publish(LifecycleEvent{Type: LeadershipAcquired, LeaderElectionGroup: group.name})
+			},
+			OnStoppedLeading: func() {
+				// The leader elector also calls this if leadership was never acquired.
+				group.mu.Lock()
+				wasLeading := group.leading
+				group.leading = false
+				group.mu.Unlock()
+				if wasLeading {
+					cm.lifecycleEvents.publish(LifecycleEvent{Type: LeadershipLost, LeaderElectionGroup: group.name})
+				}
+				if cm.onStoppedLeading != nil {
+					cm.onStoppedLeading()
+				}
+				if cm.leaderElectionReacquireOnLoss {
+					cm.stopLeaderElectionGroupForReacquire(group)
+					return
+				}
+				// Losing the lease of a group is as unsafe as losing the one of the manager,
+				// skip graceful shutdown for the same reasons.
+				cm.disableGracefulShutdown()
+				cm.errChan <- fmt.Errorf("leader election lost for leader election group %q", group.name)
+			},
+		},
+		ReleaseOnCancel: cm.leaderElectionReleaseOnCancel,
+		Name:            leaderElectionGroupID(cm.leaderElectionID, group.name),
+	})
+}
+
+// startLeaderElectionGroups starts the leader election of all groups known so far and makes
+// sure groups added later on are started as well.
+func (cm *controllerManager) startLeaderElectionGroups(ctx context.Context) {
+	cm.leaderElectionGroupsLock.Lock()
+	defer cm.leaderElectionGroupsLock.Unlock()
+
+	cm.leaderElectionCtx = ctx
+	for _, group := range cm.leaderElectionGroups {
+		cm.startLeaderElectionGroup(ctx, group)
+	}
+}
+
+// startLeaderElectionGroup must be called with the leaderElectionGroupsLock held.
+func (cm *controllerManager) startLeaderElectionGroup(ctx context.Context, group *leaderElectionGroup) {
+	group.stopped = make(chan struct{})
+	go func() {
+		defer close(group.stopped)
+		group.elector.Run(ctx)
+		// Campaign again after the lease was lost, the runnables of the group were
+		// stopped and prepared to be restarted by then.
+		for cm.leaderElectionReacquireOnLoss && ctx.Err() == nil {
+			elector, err := cm.initGroupLeaderElector(group)
+			if err != nil {
+				cm.errChan <- fmt.Errorf("failed during initialization of leader election group %q: %w", group.name, err)
+				break
+			}
+			elector.Run(ctx)
+		}
+		<-ctx.Done()
+	}()
+}
+
+// stopLeaderElectionGroupForReacquire stops the runnables of the group after its lease was
+// lost and prepares them to be started again once the lease is re-acquired.
+func (cm *controllerManager) stopLeaderElectionGroupForReacquire(group *leaderElectionGroup) {
+	// The leader elector also returns when the manager is stopped, which takes
+	// care of stopping the runnables on its own.
+	if atomic.LoadInt64(cm.stopProcedureEngaged) != 0 {
+		return
+	}
+
+	previous := group.reset(cm)
+	if previous == nil {
+		return
+	}
+
+	cm.logger.Info("Leadership of leader election group lost, stopping its runnables until it is re-acquired", "group", group.name)
+	cm.stopRunnablesForReacquire(previous, false)
+}
+
+// stopLeaderElectionGroupRunnables stops the runnables of all leader election groups and waits
+// for them to return.
+func (cm *controllerManager) stopLeaderElectionGroupRunnables(ctx context.Context) {
+	cm.leaderElectionGroupsLock.Lock()
+	groups := make([]*leaderElectionGroup, 0, len(cm.leaderElectionGroups))
+	for _, group := range cm.leaderElectionGroups {
+		groups = append(groups, group)
+	}
+	cm.leaderElectionGroupsLock.Unlock()
+
+	for _, group := range groups {
+		cm.logger.Info("Stopping and waiting for leader election group runnables", "group", group.name)
+		group.stop(ctx)
+	}
+}
+
+// waitForLeaderElectionGroupsStopped waits for the leader electors of all started
+// groups to return. The leader election context must be cancelled before.
+func (cm *controllerManager) waitForLeaderElectionGroupsStopped() {
+	// Don't wait while holding the lock, so that groups can still be looked up
+	// while their leader electors return.
+	cm.leaderElectionGroupsLock.Lock()
+	stopped := make([]chan struct{}, 0, len(cm.leaderElectionGroups))
+	for _, group := range cm.leaderElectionGroups {
+		if group.stopped != nil {
+			stopped = append(stopped, group.stopped)
+		}
+	}
+	cm.leaderElectionGroupsLock.Unlock()
+
+	for _, ch := range stopped {
+		<-ch
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/internal/syncs"
+)
+
+// LifecycleEventType is the type of a LifecycleEvent.
+type LifecycleEventType string
+
+const (
+	// LeadershipAcquired is published once the Manager, or one of its leader election
+	// groups, became leader and started its leader election runnables. It is also
+	// published once if leader election is disabled.
+	LeadershipAcquired LifecycleEventType = "LeadershipAcquired"
+
+	// LeadershipLost is published once the Manager, or one of its leader election
+	// groups, lost or gave up leadership.
+	LeadershipLost LifecycleEventType = "LeadershipLost"
+
+	// CachesSynced is published once the caches of the Manager have synced.
+	CachesSynced LifecycleEventType = "CachesSynced"
+
+	// RunnableFailed is published for every error of a runnable or of the Manager
+	// itself, including the one that makes Start return.
+	RunnableFailed LifecycleEventType = "RunnableFailed"
+
+	// ShutdownInitiated is published once the Manager starts to stop its runnables.
+	ShutdownInitiated LifecycleEventType = "ShutdownInitiated"
+)
+
+// LifecycleEvent is a notification about a lifecycle transition of a Manager.
+type LifecycleEvent struct {
+	// Type is the type of the event.
+	Type LifecycleEventType
+
+	// Time is the time at which the event was published.
+	Time time.Time
+
+	// LeaderElectionGroup is the leader election group of LeadershipAcquired and
+	// LeadershipLost events. It is empty for the leader election of the Manager itself.
+	LeaderElectionGroup string
+
+	// Err is the error of RunnableFailed events.
+	Err error
+}
+
+// LifecycleSubscriber is implemented by Managers that publish lifecycle events,
+// which includes the Manager returned by New. It allows to observe the Manager
+// without wrapping its runnables.
+type LifecycleSubscriber interface {
+	// Subscribe calls f with all lifecycle events of the given types, or of all types
+	// if none are given, until the returned function is called. The events are
+	// delivered in order from a goroutine of the subscription, so that slow
+	// subscribers don't hold up the Manager. Up to 1000 events are queued for a
+	// subscriber, after that the oldest pending event is dropped for every new
+	// one. Events that are published before Subscribe is called are not delivered.
+	Subscribe(f func(LifecycleEvent), types ...LifecycleEventType) (unsubscribe func())
+}
+
+// maxPendingLifecycleEvents is the number of events that are queued for a subscriber
+// before the oldest one is dropped.
+const maxPendingLifecycleEvents = 1000
+
+// lifecycleEvents delivers lifecycle events to subscribers. Its zero value is
+// ready to use.
+type lifecycleEvents struct {
+	mu            sync.Mutex
+	subscriptions []*lifecycleSubscription
+}
+
+type lifecycleSubscription struct {
+	types []LifecycleEventType
+	queue *syncs.Queue[LifecycleEvent]
+}
+
+func (e *lifecycleEvents) subscribe(f func(LifecycleEvent), types ...LifecycleEventType) func() {
+	s := &lifecycleSubscription{types: types, queue: syncs.NewQueue(f, maxPendingLifecycleEvents)}
+	e.mu.Lock()
+	e.subscriptions = append(e.subscriptions, s)
+	e.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			e.mu.Lock()
+			e.subscriptions = slices.DeleteFunc(e.subscriptions, func(other *lifecycleSubscription) bool {
+				return other == s
+			})
+			e.mu.Unlock()
+			s.queue.Stop()
+		})
+	}
+}
+
+func (e *lifecycleEvents) publish(event LifecycleEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range e.subscriptions {
+		if len(s.types) == 0 || slices.Contains(s.types, event.Type) {
+			s.queue.Push(event)
+		}
+	}
+}
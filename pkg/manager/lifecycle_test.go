@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("lifecycleEvents", func() {
+	It("should deliver the subscribed events in order", func() {
+		var events lifecycleEvents
+
+		all := make(chan LifecycleEventType, 10)
+		unsubscribeAll := events.subscribe(func(event LifecycleEvent) { all <- event.Type })
+		defer unsubscribeAll()
+		failures := make(chan error, 10)
+		unsubscribeFailures := events.subscribe(func(event LifecycleEvent) { failures <- event.Err }, RunnableFailed)
+		defer unsubscribeFailures()
+
+		expectedErr := errors.New("expected error")
+		events.publish(LifecycleEvent{Type: CachesSynced})
+		events.publish(LifecycleEvent{Type: RunnableFailed, Err: expectedErr})
+		events.publish(LifecycleEvent{Type: ShutdownInitiated})
+
+		Eventually(all).Should(Receive(Equal(CachesSynced)))
+		Eventually(all).Should(Receive(Equal(RunnableFailed)))
+		Eventually(all).Should(Receive(Equal(ShutdownInitiated)))
+		Eventually(failures).Should(Receive(MatchError(expectedErr)))
+		Consistently(failures).ShouldNot(Receive())
+	})
+
+	It("should not block publishers on slow subscribers", func() {
+		var events lifecycleEvents
+		block := make(chan struct{})
+		received := make(chan LifecycleEventType, 10)
+		unsubscribe := events.subscribe(func(event LifecycleEvent) {
+			<-block
+			received <- event.Type
+		})
+		defer unsubscribe()
+
+		for range 3 {
+			events.publish(LifecycleEvent{Type: RunnableFailed})
+		}
+		close(block)
+		Eventually(received).Should(HaveLen(3))
+	})
+
+	It("should stop delivering events after unsubscribing", func() {
+		var events lifecycleEvents
+		var (
+			mu       sync.Mutex
+			received int
+		)
+		unsubscribe := events.subscribe(func(LifecycleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			received++
+		})
+		events.publish(LifecycleEvent{Type: CachesSynced})
+		Eventually(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return received
+		}).Should(Equal(1))
+
+		unsubscribe()
+		unsubscribe()
+		events.publish(LifecycleEvent{Type: ShutdownInitiated})
+		Consistently(func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return received
+		}).Should(Equal(1))
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -41,10 +42,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/eventbus"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	intrec "sigs.k8s.io/controller-runtime/pkg/internal/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/profiling"
 	"sigs.k8s.io/controller-runtime/pkg/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -59,6 +63,10 @@ type Manager interface {
 	// started when Start is called.
 	// Depending on if a Runnable implements LeaderElectionRunnable interface, a Runnable can be run in either
 	// non-leaderelection mode (always running) or leader election mode (managed by leader election if enabled).
+	//
+	// Runnables can also be added after Start was called. They are started right away, or
+	// once the Manager is elected leader if they need leader election. Adding runnables
+	// fails once the Manager is stopping. See RunnableRemover for removing them again.
 	Add(Runnable) error
 
 	// Elected is closed when this manager is elected leader of a group of
@@ -103,6 +111,93 @@ type Manager interface {
 	GetConverterRegistry() conversion.Registry
 }
 
+// ShutdownHookRegistrar is implemented by Managers that support shutdown hooks,
+// which includes the Manager returned by New.
+type ShutdownHookRegistrar interface {
+	// AddShutdownHook registers a hook that is run once the manager has been
+	// asked to stop. Hooks are run sequentially in registration order after all
+	// leader election runnables (including controllers) have stopped, but before
+	// the caches, webhooks and HTTP servers are torn down, so they can still use
+	// the client and cache to flush buffers or write final status updates.
+	//
+	// Each hook is given at most ShutdownHookTimeout to complete, bound by what is left
+	// of the GracefulShutdownTimeout. Hooks that didn't start before the
+	// GracefulShutdownTimeout expired are skipped. Errors returned by hooks, including
+	// the ones for skipped hooks, are aggregated and returned from Start.
+	//
+	// All hooks are skipped if graceful shutdown is disabled with a GracefulShutdownTimeout
+	// of 0. This is also the case after the leader election lease was lost, as another
+	// replica may already be leading then and hooks must not write on behalf of the
+	// previous leader. Hooks that must always run have to be run by the caller of Start
+	// after it returned.
+	AddShutdownHook(name string, hook ShutdownHookFunc) error
+}
+
+// StepDowner is implemented by Managers that can give up leadership without exiting,
+// which includes the Manager returned by New.
+type StepDowner interface {
+	// StepDown voluntarily gives up leadership. The leader election runnables are
+	// given GracefulShutdownTimeout to stop and started again once leadership is
+	// re-acquired, while the other runnables keep running. The Manager only campaigns
+	// for leadership again after LeaseDuration, so that another replica can take over.
+	// Leader election groups are not affected.
+	//
+	// It returns an error if LeaderElectionReacquireOnLoss isn't set or the Manager
+	// isn't the leader.
+	StepDown() error
+}
+
+// RunnableRemover is implemented by Managers that support removing runnables,
+// which includes the Manager returned by New. Together with adding runnables after
+// Start, this allows to run dynamically provisioned components, e.g. a controller
+// per tenant, for the lifetime of a long-lived Manager.
+type RunnableRemover interface {
+	// RemoveRunnable removes a runnable that was added with Add, so that it isn't
+	// started again, e.g. when leadership is re-acquired, and cancels the context it
+	// was started with. It waits for Start of the runnable to return or for ctx to be
+	// done. The runnable is compared by identity, so it must be comparable, e.g. a
+	// pointer.
+	//
+	// Dependencies of the runnable, like informers it started in the cache of the
+	// Manager, are not removed.
+	RemoveRunnable(ctx context.Context, r Runnable) error
+}
+
+// HealthCheckRemover is implemented by Managers that support removing health checks,
+// which includes the Manager returned by New. It allows to remove the checks of
+// runnables that are removed with RunnableRemover, e.g. controllers.
+type HealthCheckRemover interface {
+	// RemoveHealthzCheck removes a check that was added with AddHealthzCheck. Unlike
+	// adding checks, it can be called after the Manager was started. It returns an
+	// error if there is no check with the given name.
+	RemoveHealthzCheck(name string) error
+
+	// RemoveReadyzCheck removes a check that was added with AddReadyzCheck. Unlike
+	// adding checks, it can be called after the Manager was started. It returns an
+	// error if there is no check with the given name.
+	RemoveReadyzCheck(name string) error
+}
+
+// RunnableDescriber is implemented by Managers that describe their runnables,
+// which includes the Manager returned by New.
+type RunnableDescriber interface {
+	// GetRunnables describes all runnables registered with the Manager, including
+	// the ones it adds itself like the metrics server, in the order they were added
+	// to their group.
+	GetRunnables() []RunnableInfo
+
+	// GetControllers describes the controllers registered with the Manager.
+	GetControllers() []RunnableInfo
+}
+
+// EventBusProvider is implemented by Managers that provide an event bus, which
+// includes the Manager returned by New.
+type EventBusProvider interface {
+	// GetEventBus returns the in-process event bus of the Manager, which controllers
+	// use to trigger reconciles in other controllers through eventbus.Bus.Source.
+	GetEventBus() *eventbus.Bus
+}
+
 // Options are the arguments for creating a new Manager.
 type Options struct {
 	// Scheme is the scheme used to resolve runtime.Objects to GroupVersionKinds / Resources.
@@ -196,6 +291,20 @@ type Options struct {
 	// will use for holding the leader lock.
 	LeaderElectionID string
 
+	// ConfigReload makes the Manager reload its rest.Config when credentials rotate,
+	// e.g. when a client certificate or the CA bundle of the API server changes, and
+	// transparently rebuild the transport of its clients and restart the watches of
+	// its informers, instead of requiring a restart of the process. Leader election
+	// uses the reloaded credentials as well, unless LeaderElectionConfig is set.
+	// See cluster.ConfigReloadOptions for details.
+	ConfigReload *cluster.ConfigReloadOptions
+
+	// ConfigFile loads Options from a YAML file, see v1alpha1.ControllerManagerConfiguration for its format. Options
+	// set in code take precedence over the ones in the file, see Options.AndFrom.
+	// The file can optionally be reloaded while the Manager is running, see
+	// ConfigFileOptions.Reload.
+	ConfigFile *ConfigFileOptions
+
 	// LeaderElectionConfig can be specified to override the default configuration
 	// that is used to build the leader election client.
 	LeaderElectionConfig *rest.Config
@@ -207,6 +316,52 @@ type Options struct {
 	// LeaseDuration time first.
 	LeaderElectionReleaseOnCancel bool
 
+	// LeaderElectionIdentity is the identity of this replica in the leader election,
+	// e.g. the name of its pod. It must be unique among all replicas. Defaults to the
+	// hostname followed by a random UUID.
+	LeaderElectionIdentity string
+
+	// LeaderElectionHandoverTarget enables handing over leadership on a planned shutdown.
+	// It is called when the Manager stops while it is the leader, after the leader
+	// election runnables have stopped, and returns the LeaderElectionIdentity of the
+	// standby replica that the lease is handed over to, e.g. another ready pod of the
+	// same Deployment during a rolling update. The standby takes over with its next
+	// retry, within RetryPeriod, instead of after the lease expired. Standbys should
+	// use EnableWarmup for their controllers, so that their caches are synced already.
+	//
+	// The lease expires as usual if the standby doesn't take over. The handover is
+	// skipped if the function returns the empty string or an error, and always for
+	// the leases of leader election groups.
+	LeaderElectionHandoverTarget func(ctx context.Context) (string, error)
+
+	// LeaderElectionReacquireOnLoss makes the Manager stop the leader election runnables
+	// when it loses leadership or StepDown is called, and campaign for leadership again
+	// instead of returning an error from Start. The leader election runnables are started
+	// again once leadership is re-acquired, while the other runnables, like metrics and
+	// webhook servers, keep running. Elected is only closed when leadership is acquired
+	// for the first time. The same applies to the lease of each leader election group.
+	//
+	// After an involuntary loss, the leader election runnables are stopped without a
+	// grace period, and the Manager only campaigns again once all of them returned.
+	// Leader election runnables must support being started again after they returned,
+	// see RestartableRunnable.
+	LeaderElectionReacquireOnLoss bool
+
+	// OnStartedLeading is called when the Manager acquired leadership, after the leader
+	// election runnables were started. The context is cancelled once leadership is lost.
+	// It can be used to e.g. update readiness or reconfigure an external load balancer.
+	// It is only called for the lease of the Manager, acquiring the lease of a leader
+	// election group is not reported.
+	OnStartedLeading func(ctx context.Context)
+
+	// OnStoppedLeading is called when the Manager lost leadership after it was the leader,
+	// including when the Manager is stopped. If leadership is lost while the Manager runs,
+	// it is called before the leader election runnables are stopped. When the Manager is
+	// stopped, leader election is only cancelled once all runnables, caches and servers
+	// returned, so it is called last. Like OnStartedLeading, it is only called for the
+	// lease of the Manager, losing the lease of a leader election group is not reported.
+	OnStoppedLeading func()
+
 	// LeaderElectionLabels allows a controller to supplement all leader election api calls with a set of custom labels based on
 	// the replica attempting to acquire leader status.
 	LeaderElectionLabels map[string]string
@@ -218,6 +373,14 @@ type Options struct {
 	// two Kubernetes clusters.
 	LeaderElectionResourceLockInterface resourcelock.Interface
 
+	// LeaderElectionLockBackend stores the leader election locks of the Manager and of its
+	// leader election groups in a system other than Kubernetes, e.g. etcd, Consul or DynamoDB,
+	// see leaderelection.NewExternalLock. The locks are named after the LeaderElectionID.
+	// If this value is set the options LeaderElectionNamespace, LeaderElectionResourceLock,
+	// LeaderElectionConfig and LeaderElectionLabels are ignored. It can't be combined
+	// with LeaderElectionResourceLockInterface.
+	LeaderElectionLockBackend leaderelection.LockBackend
+
 	// LeaseDuration is the duration that non-leader candidates will
 	// wait to force acquire leadership. This is measured against time of
 	// last observed ack. Default is 15 seconds.
@@ -245,6 +408,13 @@ type Options struct {
 	// Liveness probe endpoint name, defaults to "healthz"
 	LivenessEndpointName string
 
+	// HealthProbeServerHook is called with the health probe server and its probes before
+	// the server is started. It can wrap the Handler of the server, e.g. to serve additional
+	// protocols next to the liveness and readiness endpoints, like the grpc.health.v1 Health
+	// service served by grpchealth.HealthProbeServerHook. The server is not started if it
+	// returns an error.
+	HealthProbeServerHook func(srv *http.Server, probes HealthProbes) error
+
 	// PprofBindAddress is the TCP address that the controller should bind to
 	// for serving pprof.
 	// It can be set to "" or "0" to disable the pprof serving.
@@ -252,11 +422,34 @@ type Options struct {
 	// before exposing it to public.
 	PprofBindAddress string
 
+	// PprofSnapshotDir enables the /debug/pprof/snapshot endpoint of the pprof server,
+	// which writes a snapshot of the heap and goroutine profiles to the directory on
+	// POST requests, see profiling.SnapshotHandler. It requires PprofBindAddress.
+	PprofSnapshotDir string
+
+	// PprofContentionProfile enables the mutex and block profiles of the runtime while
+	// the Manager is running, e.g. profiling.ControllerContention. They are served by
+	// the pprof server and are disabled by default.
+	//
+	// The profiles are disabled again when the Manager stops. This overrides a block
+	// profile rate that was set with runtime.SetBlockProfileRate, which can't be read
+	// and restored, so the block profile should not be configured otherwise.
+	PprofContentionProfile *profiling.ContentionProfile
+
 	// WebhookServer is an externally configured webhook.Server. By default,
 	// a Manager will create a server via webhook.NewServer with default settings.
 	// If this is set, the Manager will use this server instead.
 	WebhookServer webhook.Server
 
+	// HTTPMiddlewares wrap the handlers of all servers hosted by the Manager, i.e. the
+	// metrics, health probe, pprof and webhook servers, e.g. to log requests or to add a
+	// request ID. They run before the middlewares configured on the metrics and webhook
+	// servers themselves. The first middleware is the outermost one.
+	//
+	// Note: Webhook servers that are not created by webhook.NewServer have to apply the
+	// middlewares themselves.
+	HTTPMiddlewares []func(http.Handler) http.Handler
+
 	// BaseContext is the function that provides Context values to Runnables
 	// managed by the Manager. If a BaseContext function isn't provided, Runnables
 	// will receive a new Background Context instead.
@@ -275,6 +468,48 @@ type Options struct {
 	// The graceful shutdown is skipped for safety reasons in case the leader election lease is lost.
 	GracefulShutdownTimeout *time.Duration
 
+	// ServeIntrospection makes the Manager serve the description of its runnables,
+	// as returned by RunnableDescriber.GetRunnables, as JSON at /debug/manager on the metrics server.
+	// The requests that are pending in the queue of a controller, with the provenances that added
+	// them, are served at /debug/manager/pending-requests?controller=<name>.
+	ServeIntrospection bool
+
+	// ServeWebhookRoutes makes the Manager serve the handlers registered on the webhook
+	// server, with the GVKs and operations they serve if the server implements
+	// webhook.RouteDescriber, as JSON at /debug/webhook-routes on the metrics server.
+	ServeWebhookRoutes bool
+
+	// ServeCacheDump makes the Manager serve the keys and resourceVersions of the objects
+	// in its cache, as written by cache.Dump, at /debug/cache on the metrics server. The
+	// sanitized objects are included with /debug/cache?objects=true.
+	ServeCacheDump bool
+
+	// RunnablePanicRecovery makes the Manager recover panics of its runnables, report them
+	// through the controller_runtime_runnable_panics_total metric and optionally start
+	// the runnables again. By default, a panicking runnable crashes the process.
+	RunnablePanicRecovery *RunnablePanicRecovery
+
+	// RunnableRestartPolicy makes the Manager start runnables again that return an error,
+	// e.g. a webhook server that can't bind its port yet, instead of stopping. By default,
+	// an error returned by any runnable stops the Manager. Caches are never restarted,
+	// since they can't be started again.
+	RunnableRestartPolicy *RunnableRestartPolicy
+
+	// ShutdownOrder is the order in which the groups of runnables are stopped on shutdown,
+	// optionally with a grace period per group. Groups that are not listed are stopped
+	// after the listed ones, in the default order: Others, LeaderElection, Caches, Webhooks
+	// and HTTPServers. Shutdown hooks are run right after the LeaderElection group stopped.
+	// The LeaderElection group, which includes the controllers, must be stopped before the
+	// Caches and Webhooks groups, so that reconcilers never run without their caches and
+	// webhooks are served as long as reconcilers write to the API server.
+	ShutdownOrder []ShutdownStep
+
+	// ShutdownHookTimeout is the maximum duration given to each hook registered through
+	// AddShutdownHook to complete. Defaults to 10 seconds.
+	// To run shutdown hooks without a timeout of their own, set to a negative duration,
+	// e.G. time.Duration(-1). Shutdown hooks are always bound by the GracefulShutdownTimeout.
+	ShutdownHookTimeout *time.Duration
+
 	// Controller contains global configuration options for controllers
 	// registered within this manager.
 	// +optional
@@ -294,10 +529,27 @@ type Options struct {
 	newPprofListener       func(addr string) (net.Listener, error)
 }
 
+// HealthProbes are the probes served by the health probe server.
+type HealthProbes struct {
+	// ReadinessEndpointName is the path the readiness checks are served at, e.g. "/readyz".
+	ReadinessEndpointName string
+	// Readiness runs the readiness checks. It is nil if no readiness checks were added.
+	Readiness *healthz.Handler
+
+	// LivenessEndpointName is the path the liveness checks are served at, e.g. "/healthz".
+	LivenessEndpointName string
+	// Liveness runs the liveness checks. It is nil if no liveness checks were added.
+	Liveness *healthz.Handler
+}
+
 // BaseContextFunc is a function used to provide a base Context to Runnables
 // managed by a Manager.
 type BaseContextFunc func() context.Context
 
+// ShutdownHookFunc is a function run by the Manager when it is stopping.
+// The given context is cancelled once the hook timeout expires.
+type ShutdownHookFunc func(ctx context.Context) error
+
 // Runnable allows a component to be started.
 // It's very important that Start blocks until
 // it's done running.
@@ -325,6 +577,136 @@ type LeaderElectionRunnable interface {
 	NeedLeaderElection() bool
 }
 
+// LeaderElectionGroupRunnable knows which leader election group a Runnable belongs to.
+// Each leader election group is elected through its own lease, so runnables of different
+// groups can be run by different replicas. This is only taken into account for runnables
+// that need leader election and if leader election is enabled on the Manager without a
+// custom LeaderElectionResourceLockInterface.
+type LeaderElectionGroupRunnable interface {
+	// LeaderElectionGroup returns the name of the leader election group of the Runnable.
+	// The empty group is the leader election of the Manager itself.
+	LeaderElectionGroup() string
+}
+
+// RestartableRunnable is implemented by leader election runnables that need to reset
+// their state before they can be started again. Leader election runnables are started
+// again when the Manager or their leader election group re-acquires leadership with
+// LeaderElectionReacquireOnLoss set, and any runnable is started again after a panic
+// with RunnablePanicRecovery set or after an error with RunnableRestartPolicy set.
+type RestartableRunnable interface {
+	// PrepareRestart is called after Start returned and before it is called again.
+	PrepareRestart()
+}
+
+// NamedRunnable is implemented by runnables that have a name, which identifies them in
+// logs and in the runnable label of metrics. Runnables without a name share the
+// "unnamed" label, controllers are labelled with their name.
+type NamedRunnable interface {
+	// RunnableName returns the name of the runnable. It must be constant and unique
+	// within the Manager.
+	RunnableName() string
+}
+
+// RunnablePanicRecovery configures how the Manager recovers panics of its runnables.
+// Only panics in the goroutine calling Start are recovered, panics in goroutines
+// started by the runnable still crash the process.
+type RunnablePanicRecovery struct {
+	// MaxRestarts is the number of times a runnable is started again after it panicked.
+	// Once exceeded, the panic is returned as error of the runnable, which stops the
+	// Manager. Zero never restarts the runnable, a negative value restarts it indefinitely.
+	MaxRestarts int
+
+	// InitialBackoff is the duration to wait before the first restart, it doubles
+	// with every further restart. Defaults to 1 second.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum duration to wait before a restart. Defaults to 1 minute.
+	MaxBackoff time.Duration
+}
+
+// RestartPolicy determines whether the Manager starts runnables again that returned an error.
+type RestartPolicy string
+
+const (
+	// RestartPolicyNever stops the Manager once a runnable returns an error.
+	RestartPolicyNever RestartPolicy = "Never"
+
+	// RestartPolicyOnFailure starts a runnable again with backoff after it returned
+	// an error, until MaxRestarts is exceeded.
+	RestartPolicyOnFailure RestartPolicy = "OnFailure"
+)
+
+// RunnableRestartPolicy configures how the Manager restarts runnables that returned an
+// error. Runnables that return after their context was cancelled are never restarted,
+// panics are handled by RunnablePanicRecovery.
+type RunnableRestartPolicy struct {
+	// Policy determines whether failed runnables are started again. Defaults to Never.
+	Policy RestartPolicy
+
+	// MaxRestarts is the number of times a runnable is started again after it failed.
+	// Once exceeded, the error is returned, which stops the Manager. Zero or a negative
+	// value restarts it indefinitely.
+	MaxRestarts int
+
+	// InitialBackoff is the duration to wait before the first restart, it doubles
+	// with every further restart. Defaults to 1 second.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum duration to wait before a restart. Defaults to 1 minute.
+	MaxBackoff time.Duration
+
+	// ResetAfter is the duration after which a runnable that keeps running is considered
+	// healthy again, which resets its restarts and backoff. Defaults to 10 minutes.
+	ResetAfter time.Duration
+}
+
+// RunnableGroup is a group of runnables the Manager starts and stops together.
+type RunnableGroup string
+
+const (
+	// RunnableGroupHTTPServers holds the HTTP servers of the Manager, e.g. the metrics
+	// and health probe servers.
+	RunnableGroupHTTPServers RunnableGroup = "HTTPServers"
+
+	// RunnableGroupWebhooks holds the webhook servers.
+	RunnableGroupWebhooks RunnableGroup = "Webhooks"
+
+	// RunnableGroupCaches holds the caches.
+	RunnableGroupCaches RunnableGroup = "Caches"
+
+	// RunnableGroupLeaderElection holds the runnables that need leader election,
+	// including the ones of leader election groups.
+	RunnableGroupLeaderElection RunnableGroup = "LeaderElection"
+
+	// RunnableGroupOthers holds the runnables that don't need leader election.
+	RunnableGroupOthers RunnableGroup = "Others"
+
+	// RunnableGroupWarmup holds the warmup of runnables that implement a Warmup method,
+	// which is run before the Manager becomes the leader. It is stopped together with
+	// the other groups and can't be listed in ShutdownOrder.
+	RunnableGroupWarmup RunnableGroup = "Warmup"
+)
+
+// defaultShutdownOrder is the order in which the groups of runnables are stopped by default.
+var defaultShutdownOrder = []RunnableGroup{
+	RunnableGroupOthers,
+	RunnableGroupLeaderElection,
+	RunnableGroupCaches,
+	RunnableGroupWebhooks,
+	RunnableGroupHTTPServers,
+}
+
+// ShutdownStep stops a group of runnables on shutdown, see Options.ShutdownOrder.
+type ShutdownStep struct {
+	// Group is the group of runnables to stop.
+	Group RunnableGroup
+
+	// GracePeriod is the duration given to the runnables of the group to stop. It is
+	// bound by the remaining GracefulShutdownTimeout, after which the Manager stops
+	// waiting for any runnable. Defaults to the remaining GracefulShutdownTimeout.
+	GracePeriod *time.Duration
+}
+
 // warmupRunnable knows if a Runnable requires warmup. A warmup runnable is a runnable
 // that should be run when the manager is started but before it becomes leader.
 // Note: Implementing this interface is only useful when LeaderElection can be enabled, as the
@@ -334,6 +716,16 @@ type warmupRunnable interface {
 	Warmup(context.Context) error
 }
 
+// warmup is the runnable of the Warmup group that runs the warmup of a runnable.
+type warmup struct {
+	runnable warmupRunnable
+}
+
+// Start implements Runnable.
+func (w *warmup) Start(ctx context.Context) error {
+	return w.runnable.Warmup(ctx)
+}
+
 // New returns a new Manager for creating Controllers.
 // Note that if ContentType in the given config is not set, "application/vnd.kubernetes.protobuf"
 // will be used for all built-in resources of Kubernetes, and "application/json" is for other types
@@ -342,6 +734,28 @@ func New(config *rest.Config, options Options) (Manager, error) {
 	if config == nil {
 		return nil, errors.New("must specify Config")
 	}
+	if policy := options.RunnableRestartPolicy; policy != nil {
+		switch policy.Policy {
+		case "", RestartPolicyNever, RestartPolicyOnFailure:
+		default:
+			return nil, fmt.Errorf("unknown RunnableRestartPolicy %q, must be %q or %q", policy.Policy, RestartPolicyNever, RestartPolicyOnFailure)
+		}
+	}
+	var configFileContent []byte
+	var reloadConfigFile bool
+	if options.ConfigFile != nil {
+		var file *v1alpha1.ControllerManagerConfiguration
+		var err error
+		configFileContent, file, err = loadConfigFile(options.ConfigFile.Path)
+		if err != nil {
+			return nil, err
+		}
+		// Only the concurrency of controllers can be reloaded, which is pointless
+		// if it is set in code.
+		reloadConfigFile = options.ConfigFile.Reload && options.Controller.MaxConcurrentReconciles <= 0
+		options = options.AndFrom(file)
+	}
+
 	// Set default values for options fields
 	options, err := setOptionsDefaults(config, options)
 	if err != nil {
@@ -357,6 +771,7 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		clusterOptions.Cache = options.Cache
 		clusterOptions.Client = options.Client
 		clusterOptions.EventBroadcaster = options.EventBroadcaster //nolint:staticcheck
+		clusterOptions.ConfigReload = options.ConfigReload
 	})
 	if err != nil {
 		return nil, err
@@ -403,24 +818,68 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		}
 	}
 
+	leaderElectionOptions := leaderelection.Options{
+		LeaderElection:             options.LeaderElection,
+		LeaderElectionResourceLock: options.LeaderElectionResourceLock,
+		LeaderElectionID:           options.LeaderElectionID,
+		LeaderElectionNamespace:    options.LeaderElectionNamespace,
+		RenewDeadline:              *options.RenewDeadline,
+		LeaderLabels:               options.LeaderElectionLabels,
+		Identity:                   options.LeaderElectionIdentity,
+	}
+	if options.ConfigReload != nil && options.LeaderElectionConfig == nil {
+		// Renew the lease with the reloaded credentials as well.
+		leaderElectionOptions.HTTPClient = cluster.GetHTTPClient()
+	}
+
 	var resourceLock resourcelock.Interface
-	if options.LeaderElectionResourceLockInterface != nil && options.LeaderElection {
+	var newGroupResourceLock func(group string) (resourcelock.Interface, error)
+	switch {
+	case options.LeaderElectionLockBackend != nil && options.LeaderElectionResourceLockInterface != nil:
+		return nil, errors.New("LeaderElectionLockBackend and LeaderElectionResourceLockInterface are mutually exclusive")
+	case options.LeaderElectionResourceLockInterface != nil && options.LeaderElection:
 		resourceLock = options.LeaderElectionResourceLockInterface
-	} else {
-		resourceLock, err = options.newResourceLock(leaderConfig, leaderRecorderProvider, leaderelection.Options{
-			LeaderElection:             options.LeaderElection,
-			LeaderElectionResourceLock: options.LeaderElectionResourceLock,
-			LeaderElectionID:           options.LeaderElectionID,
-			LeaderElectionNamespace:    options.LeaderElectionNamespace,
-			RenewDeadline:              *options.RenewDeadline,
-			LeaderLabels:               options.LeaderElectionLabels,
+	case options.LeaderElectionLockBackend != nil && options.LeaderElection:
+		if options.LeaderElectionID == "" {
+			return nil, errors.New("LeaderElectionID must be configured")
+		}
+		resourceLock, err = leaderelection.NewExternalLock(options.LeaderElectionLockBackend, leaderelection.ExternalLockOptions{
+			Name:     options.LeaderElectionID,
+			Identity: options.LeaderElectionIdentity,
 		})
 		if err != nil {
 			return nil, err
 		}
+		// Leader election groups use their own lock with the same identity.
+		identity := resourceLock.Identity()
+		newGroupResourceLock = func(group string) (resourcelock.Interface, error) {
+			return leaderelection.NewExternalLock(options.LeaderElectionLockBackend, leaderelection.ExternalLockOptions{
+				Name:     leaderElectionGroupID(options.LeaderElectionID, group),
+				Identity: identity,
+			})
+		}
+	default:
+		resourceLock, err = options.newResourceLock(leaderConfig, leaderRecorderProvider, leaderElectionOptions)
+		if err != nil {
+			return nil, err
+		}
+		if resourceLock != nil {
+			// Leader election groups use their own lease next to the one of the manager.
+			newGroupResourceLock = func(group string) (resourcelock.Interface, error) {
+				groupOptions := leaderElectionOptions
+				groupOptions.LeaderElectionID = leaderElectionGroupID(options.LeaderElectionID, group)
+				return options.newResourceLock(leaderConfig, leaderRecorderProvider, groupOptions)
+			}
+		}
 	}
 
 	// Create the metrics server.
+	if len(options.HTTPMiddlewares) > 0 {
+		options.Metrics.Middlewares = append(slices.Clone(options.HTTPMiddlewares), options.Metrics.Middlewares...)
+		if webhookServer, ok := options.WebhookServer.(*webhook.DefaultServer); ok {
+			webhookServer.Options.Middlewares = append(slices.Clone(options.HTTPMiddlewares), webhookServer.Options.Middlewares...)
+		}
+	}
 	metricsServer, err := options.newMetricsServer(options.Metrics, config, cluster.GetHTTPClient())
 	if err != nil {
 		return nil, err
@@ -441,14 +900,18 @@ func New(config *rest.Config, options Options) (Manager, error) {
 	}
 
 	errChan := make(chan error, 1)
-	runnables := newRunnables(options.BaseContext, errChan).withLogger(options.Logger)
-	return &controllerManager{
+	runnables := newRunnables(options.BaseContext, errChan).withLogger(options.Logger).withPanicRecovery(options.RunnablePanicRecovery).withRestartPolicy(options.RunnableRestartPolicy)
+	cm := &controllerManager{
 		stopProcedureEngaged:          new(int64(0)),
 		cluster:                       cluster,
 		runnables:                     runnables,
+		eventBus:                      eventbus.New(),
 		errChan:                       errChan,
 		recorderProvider:              recorderProvider,
 		resourceLock:                  resourceLock,
+		newGroupResourceLock:          newGroupResourceLock,
+		leaderElectionGroups:          map[string]*leaderElectionGroup{},
+		baseContext:                   options.BaseContext,
 		metricsServer:                 metricsServer,
 		controllerConfig:              options.Controller,
 		logger:                        options.Logger,
@@ -462,12 +925,46 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		healthProbeListener:           healthProbeListener,
 		readinessEndpointName:         options.ReadinessEndpointName,
 		livenessEndpointName:          options.LivenessEndpointName,
+		healthProbeServerHook:         options.HealthProbeServerHook,
 		pprofListener:                 pprofListener,
+		pprofSnapshotDir:              options.PprofSnapshotDir,
+		httpMiddlewares:               options.HTTPMiddlewares,
+		pprofContentionProfile:        options.PprofContentionProfile,
 		gracefulShutdownTimeout:       *options.GracefulShutdownTimeout,
+		shutdownHookTimeout:           *options.ShutdownHookTimeout,
+		shutdownOrder:                 options.ShutdownOrder,
 		internalProceduresStop:        make(chan struct{}),
 		leaderElectionStopped:         make(chan struct{}),
 		leaderElectionReleaseOnCancel: options.LeaderElectionReleaseOnCancel,
-	}, nil
+		leaderElectionHandoverTarget:  options.LeaderElectionHandoverTarget,
+		leaderElectionReacquireOnLoss: options.LeaderElectionReacquireOnLoss,
+		startedLeadingCallback:        options.OnStartedLeading,
+		stoppedLeadingCallback:        options.OnStoppedLeading,
+	}
+	if reloadConfigFile {
+		if err := cm.add(newConfigFileReloader(cm, *options.ConfigFile, configFileContent)); err != nil {
+			return nil, fmt.Errorf("failed to add config file reloader: %w", err)
+		}
+	}
+	if options.ServeIntrospection && metricsServer != nil {
+		if err := metricsServer.AddExtraHandler(debugManagerEndpoint, cm.introspectionHandler()); err != nil {
+			return nil, fmt.Errorf("failed to serve the manager introspection endpoint: %w", err)
+		}
+		if err := metricsServer.AddExtraHandler(debugPendingRequestsEndpoint, cm.pendingRequestsHandler()); err != nil {
+			return nil, fmt.Errorf("failed to serve the pending requests endpoint: %w", err)
+		}
+	}
+	if options.ServeWebhookRoutes && metricsServer != nil {
+		if err := metricsServer.AddExtraHandler(webhookRoutesEndpoint, webhook.RoutesHandler(options.WebhookServer)); err != nil {
+			return nil, fmt.Errorf("failed to serve the webhook routes endpoint: %w", err)
+		}
+	}
+	if options.ServeCacheDump && metricsServer != nil {
+		if err := metricsServer.AddExtraHandler(debugCacheEndpoint, cache.DumpHandler(cluster.GetCache())); err != nil {
+			return nil, fmt.Errorf("failed to serve the cache dump endpoint: %w", err)
+		}
+	}
+	return cm, nil
 }
 
 // defaultHealthProbeListener creates the default health probes listener bound to the given address.
@@ -504,6 +1001,12 @@ func defaultBaseContext() context.Context {
 
 // setOptionsDefaults set default values for Options fields.
 func setOptionsDefaults(config *rest.Config, options Options) (Options, error) {
+	shutdownOrder, err := completeShutdownOrder(options.ShutdownOrder)
+	if err != nil {
+		return options, err
+	}
+	options.ShutdownOrder = shutdownOrder
+
 	// Allow newResourceLock to be mocked
 	if options.newResourceLock == nil {
 		options.newResourceLock = leaderelection.NewResourceLock
@@ -576,6 +1079,11 @@ func setOptionsDefaults(config *rest.Config, options Options) (Options, error) {
 		options.GracefulShutdownTimeout = &gracefulShutdownTimeout
 	}
 
+	if options.ShutdownHookTimeout == nil {
+		shutdownHookTimeout := defaultShutdownHookTimeout
+		options.ShutdownHookTimeout = &shutdownHookTimeout
+	}
+
 	if options.Logger.GetSink() == nil {
 		options.Logger = log.Log
 	}
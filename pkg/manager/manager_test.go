@@ -18,6 +18,7 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -44,11 +45,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/rest"
+	clientleaderelection "k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	intrec "sigs.k8s.io/controller-runtime/pkg/internal/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
 	fakeleaderelection "sigs.k8s.io/controller-runtime/pkg/leaderelection/fake"
@@ -509,6 +512,270 @@ var _ = Describe("manger.Manager", func() {
 				Expect(exists).To(BeTrue())
 				Expect(val).To(Equal("my-val"))
 			})
+			It("should run leader election group runnables under their own lease", func(specCtx SpecContext) {
+				var lockIDs []string
+				m, err := New(cfg, Options{
+					LeaderElection:          true,
+					LeaderElectionNamespace: "default",
+					LeaderElectionID:        "test-leader-election-group",
+					newResourceLock: func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
+						lockIDs = append(lockIDs, options.LeaderElectionID)
+						return fakeleaderelection.NewResourceLock(config, recorderProvider, options)
+					},
+					HealthProbeBindAddress: "0",
+					Metrics:                metricsserver.Options{BindAddress: "0"},
+					PprofBindAddress:       "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				var lock sync.Mutex
+				var stopped []string
+				record := func(name string) {
+					lock.Lock()
+					defer lock.Unlock()
+					stopped = append(stopped, name)
+				}
+				Expect(m.Add(&stopRecordingCacheProvider{
+					Cache:  &informertest.FakeInformers{},
+					onStop: func() { record("cache") },
+				})).To(Succeed())
+
+				groupRunnableStarted := make(chan struct{})
+				Expect(m.Add(&leaderElectionGroupRunnable{
+					group: "shard-a",
+					RunnableFunc: func(ctx context.Context) error {
+						close(groupRunnableStarted)
+						<-ctx.Done()
+						record("group")
+						return nil
+					},
+				})).To(Succeed())
+				Expect(lockIDs).To(Equal([]string{"test-leader-election-group", "test-leader-election-group-shard-a"}))
+
+				ctx, cancel := context.WithCancel(specCtx)
+				mgrDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(mgrDone)
+				}()
+				<-groupRunnableStarted
+
+				// Runnables of an existing group added after start are started as well.
+				lateRunnableStarted := make(chan struct{})
+				Expect(m.Add(&leaderElectionGroupRunnable{
+					group: "shard-a",
+					RunnableFunc: func(ctx context.Context) error {
+						close(lateRunnableStarted)
+						<-ctx.Done()
+						return nil
+					},
+				})).To(Succeed())
+				<-lateRunnableStarted
+
+				cancel()
+				<-mgrDone
+
+				// Group runnables are stopped before the caches.
+				Expect(stopped).To(Equal([]string{"group", "cache"}))
+			})
+			It("should restart leader election runnables after stepping down", func(specCtx SpecContext) {
+				m, err := New(cfg, Options{
+					LeaderElection:                true,
+					LeaderElectionNamespace:       "default",
+					LeaderElectionID:              "test-leader-election-step-down",
+					LeaderElectionReacquireOnLoss: true,
+					LeaseDuration:                 new(time.Second),
+					RenewDeadline:                 new(500 * time.Millisecond),
+					RetryPeriod:                   new(100 * time.Millisecond),
+					newResourceLock:               fakeleaderelection.NewResourceLock,
+					HealthProbeBindAddress:        "0",
+					Metrics:                       metricsserver.Options{BindAddress: "0"},
+					PprofBindAddress:              "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(m.(StepDowner).StepDown()).To(MatchError(ContainSubstring("not the leader")))
+
+				leaderStarted := make(chan struct{}, 2)
+				leaderStopped := make(chan struct{}, 2)
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					leaderStarted <- struct{}{}
+					<-ctx.Done()
+					leaderStopped <- struct{}{}
+					return nil
+				}))).To(Succeed())
+				var otherStopped atomic.Bool
+				Expect(m.Add(noLeaderElectionRunnable{RunnableFunc: func(ctx context.Context) error {
+					<-ctx.Done()
+					otherStopped.Store(true)
+					return nil
+				}})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				mgrDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(mgrDone)
+				}()
+				<-m.Elected()
+				Eventually(leaderStarted).Should(Receive())
+
+				Expect(m.(StepDowner).StepDown()).To(Succeed())
+				Eventually(leaderStopped).Should(Receive())
+				Eventually(leaderStarted).WithTimeout(5 * time.Second).Should(Receive())
+				Expect(otherStopped.Load()).To(BeFalse())
+
+				cancel()
+				<-mgrDone
+				Expect(otherStopped.Load()).To(BeTrue())
+			})
+
+			It("should re-acquire the lease of a leader election group after losing it", func(specCtx SpecContext) {
+				var groupLock fakeleaderelection.ControllableResourceLockInterface
+				m, err := New(cfg, Options{
+					LeaderElection:                true,
+					LeaderElectionNamespace:       "default",
+					LeaderElectionID:              "test-leader-election-group-reacquire",
+					LeaderElectionReacquireOnLoss: true,
+					LeaseDuration:                 new(time.Second),
+					RenewDeadline:                 new(500 * time.Millisecond),
+					RetryPeriod:                   new(100 * time.Millisecond),
+					newResourceLock: func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
+						lock, err := fakeleaderelection.NewResourceLock(config, recorderProvider, options)
+						if err == nil && options.LeaderElectionID != "test-leader-election-group-reacquire" {
+							groupLock = lock.(fakeleaderelection.ControllableResourceLockInterface)
+						}
+						return lock, err
+					},
+					HealthProbeBindAddress: "0",
+					Metrics:                metricsserver.Options{BindAddress: "0"},
+					PprofBindAddress:       "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				groupStarted := make(chan struct{}, 2)
+				groupStopped := make(chan struct{}, 2)
+				r := &restartableGroupRunnable{leaderElectionGroupRunnable: leaderElectionGroupRunnable{
+					group: "shard-a",
+					RunnableFunc: func(ctx context.Context) error {
+						groupStarted <- struct{}{}
+						<-ctx.Done()
+						groupStopped <- struct{}{}
+						return nil
+					},
+				}}
+				Expect(m.Add(r)).To(Succeed())
+				Expect(groupLock).NotTo(BeNil())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				mgrDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					// Losing the lease of the group doesn't make Start return an error.
+					Expect(m.Start(ctx)).To(Succeed())
+					close(mgrDone)
+				}()
+				Eventually(groupStarted).Should(Receive())
+
+				By("Losing the lease of the group")
+				groupLock.BlockLeaderElection()
+				Eventually(groupStopped).WithTimeout(5 * time.Second).Should(Receive())
+				Eventually(r.restarts.Load).Should(BeEquivalentTo(1))
+
+				By("Re-acquiring the lease of the group")
+				groupLock.UnblockLeaderElection()
+				Eventually(groupStarted).WithTimeout(5 * time.Second).Should(Receive())
+
+				cancel()
+				<-mgrDone
+			})
+
+			It("should call the leader election callbacks", func(specCtx SpecContext) {
+				var leaderCtx context.Context
+				startedLeading := make(chan struct{})
+				stoppedLeading := make(chan struct{})
+				m, err := New(cfg, Options{
+					LeaderElection:          true,
+					LeaderElectionNamespace: "default",
+					LeaderElectionID:        "test-leader-election-callbacks",
+					OnStartedLeading: func(ctx context.Context) {
+						leaderCtx = ctx
+						close(startedLeading)
+					},
+					OnStoppedLeading: func() {
+						close(stoppedLeading)
+					},
+					newResourceLock:        fakeleaderelection.NewResourceLock,
+					HealthProbeBindAddress: "0",
+					Metrics:                metricsserver.Options{BindAddress: "0"},
+					PprofBindAddress:       "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				cm := m.(*controllerManager)
+				cm.onStoppedLeading = func() {}
+
+				leaderRunnableStarted := make(chan struct{})
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					close(leaderRunnableStarted)
+					<-ctx.Done()
+					return nil
+				}))).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				mgrDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(mgrDone)
+				}()
+				Eventually(startedLeading).Should(BeClosed())
+				Eventually(leaderRunnableStarted).Should(BeClosed())
+				Expect(leaderCtx.Err()).NotTo(HaveOccurred())
+				Consistently(stoppedLeading).ShouldNot(BeClosed())
+
+				cancel()
+				Eventually(stoppedLeading).Should(BeClosed())
+				Expect(leaderCtx.Err()).To(HaveOccurred())
+				<-mgrDone
+			})
+
+			It("should publish lifecycle events", func(specCtx SpecContext) {
+				m, err := New(cfg, Options{
+					LeaderElection:          true,
+					LeaderElectionNamespace: "default",
+					LeaderElectionID:        "test-leader-election-lifecycle-events",
+					newResourceLock:         fakeleaderelection.NewResourceLock,
+					HealthProbeBindAddress:  "0",
+					Metrics:                 metricsserver.Options{BindAddress: "0"},
+					PprofBindAddress:        "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				cm := m.(*controllerManager)
+				cm.onStoppedLeading = func() {}
+
+				events := make(chan LifecycleEventType, 10)
+				unsubscribe := m.(LifecycleSubscriber).Subscribe(func(event LifecycleEvent) {
+					events <- event.Type
+				})
+				defer unsubscribe()
+
+				ctx, cancel := context.WithCancel(specCtx)
+				mgrDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(mgrDone)
+				}()
+				Eventually(events).Should(Receive(Equal(CachesSynced)))
+				Eventually(events).Should(Receive(Equal(LeadershipAcquired)))
+
+				cancel()
+				<-mgrDone
+				Eventually(events).Should(Receive(Equal(ShutdownInitiated)))
+				Eventually(events).Should(Receive(Equal(LeadershipLost)))
+			})
+
 			When("using a custom LeaderElectionResourceLockInterface", func() {
 				It("should use the custom LeaderElectionResourceLockInterface", func() {
 					rl, err := fakeleaderelection.NewResourceLock(nil, nil, leaderelection.Options{})
@@ -1153,32 +1420,311 @@ var _ = Describe("manger.Manager", func() {
 				}))).To(Succeed())
 				ctx, cancel := context.WithCancel(specCtx)
 				managerStopDone := make(chan struct{})
-				go func() { err = m.Start(ctx); close(managerStopDone) }()
-				// Use the 'elected' channel to find out if startup was done, otherwise we stop
-				// before we started the Runnable and see flakes, mostly in low-CPU envs like CI
-				<-m.(*controllerManager).elected
+				go func() { err = m.Start(ctx); close(managerStopDone) }()
+				// Use the 'elected' channel to find out if startup was done, otherwise we stop
+				// before we started the Runnable and see flakes, mostly in low-CPU envs like CI
+				<-m.(*controllerManager).elected
+				cancel()
+				<-managerStopDone
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed waiting for all runnables to end within grace period of 1ns: context deadline exceeded"))
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+				Expect(errors.Is(err, runnableError{})).ToNot(BeTrue())
+			})
+
+			It("should return only runnables error if stop doesn't error", func(ctx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+				Expect(m.Add(RunnableFunc(func(context.Context) error {
+					return runnableError{}
+				}))).To(Succeed())
+				err = m.Start(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("not feeling like that"))
+				Expect(errors.Is(err, context.DeadlineExceeded)).ToNot(BeTrue())
+				Expect(errors.Is(err, runnableError{})).To(BeTrue())
+			})
+
+			It("should run shutdown hooks in registration order before stopping the caches", func(specCtx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+
+				var lock sync.Mutex
+				var calls []string
+				record := func(name string) {
+					lock.Lock()
+					defer lock.Unlock()
+					calls = append(calls, name)
+				}
+
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					<-ctx.Done()
+					record("runnable")
+					return nil
+				}))).To(Succeed())
+				Expect(m.Add(&stopRecordingCacheProvider{
+					Cache:  &informertest.FakeInformers{},
+					onStop: func() { record("cache") },
+				})).To(Succeed())
+				for _, name := range []string{"first", "second"} {
+					Expect(m.(ShutdownHookRegistrar).AddShutdownHook(name, func(ctx context.Context) error {
+						record(name)
+						return nil
+					})).To(Succeed())
+				}
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("first", func(context.Context) error { return nil })).NotTo(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				<-managerStopDone
+
+				Expect(calls).To(Equal([]string{"runnable", "first", "second", "cache"}))
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("third", func(context.Context) error { return nil })).NotTo(Succeed())
+			})
+
+			It("should stop the runnable groups in the configured shutdown order", func(specCtx SpecContext) {
+				opts := options
+				opts.ShutdownOrder = []ShutdownStep{
+					{Group: RunnableGroupLeaderElection},
+					{Group: RunnableGroupCaches, GracePeriod: new(time.Second)},
+				}
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+
+				var lock sync.Mutex
+				var calls []string
+				record := func(name string) {
+					lock.Lock()
+					defer lock.Unlock()
+					calls = append(calls, name)
+				}
+
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					<-ctx.Done()
+					record("runnable")
+					return nil
+				}))).To(Succeed())
+				Expect(m.Add(noLeaderElectionRunnable{RunnableFunc(func(ctx context.Context) error {
+					<-ctx.Done()
+					record("other")
+					return nil
+				})})).To(Succeed())
+				Expect(m.Add(&stopRecordingCacheProvider{
+					Cache:  &informertest.FakeInformers{},
+					onStop: func() { record("cache") },
+				})).To(Succeed())
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("hook", func(ctx context.Context) error {
+					record("hook")
+					return nil
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				<-managerStopDone
+
+				Expect(calls).To(Equal([]string{"runnable", "hook", "cache", "other"}))
+			})
+
+			It("should reject invalid shutdown orders", func() {
+				opts := options
+				opts.ShutdownOrder = []ShutdownStep{{Group: RunnableGroupCaches}, {Group: RunnableGroupCaches}}
+				_, err := New(cfg, opts)
+				Expect(err).To(MatchError(ContainSubstring("listed more than once")))
+
+				opts.ShutdownOrder = []ShutdownStep{{Group: "Unknown"}}
+				_, err = New(cfg, opts)
+				Expect(err).To(MatchError(ContainSubstring("unknown runnable group")))
+
+				opts.ShutdownOrder = []ShutdownStep{{Group: RunnableGroupCaches}}
+				_, err = New(cfg, opts)
+				Expect(err).To(MatchError(ContainSubstring("must be stopped before")))
+
+				opts.ShutdownOrder = []ShutdownStep{{Group: RunnableGroupWebhooks}, {Group: RunnableGroupLeaderElection}}
+				_, err = New(cfg, opts)
+				Expect(err).To(MatchError(ContainSubstring("must be stopped before")))
+			})
+
+			It("should return shutdown hook errors and bound hooks by ShutdownHookTimeout", func(specCtx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+				m.(*controllerManager).shutdownHookTimeout = 10 * time.Millisecond
+
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("slow", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				})).To(Succeed())
+				secondRan := make(chan struct{})
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("fast", func(context.Context) error {
+					close(secondRan)
+					return nil
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					err = m.Start(ctx)
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				<-managerStopDone
+
+				Expect(secondRan).To(BeClosed())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`shutdown hook "slow" failed`))
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			})
+
+			It("should not hang on a shutdown hook that ignores its context", func(specCtx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+				m.(*controllerManager).gracefulShutdownTimeout = 50 * time.Millisecond
+				m.(*controllerManager).shutdownHookTimeout = -1
+
+				block := make(chan struct{})
+				defer close(block)
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("stuck", func(context.Context) error {
+					<-block
+					return nil
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					err = m.Start(ctx)
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				Eventually(managerStopDone).Should(BeClosed())
+
+				Expect(err).To(HaveOccurred())
+				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+			})
+
+			It("should skip shutdown hooks and return an error when the grace period expired", func(specCtx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+				m.(*controllerManager).gracefulShutdownTimeout = 10 * time.Millisecond
+
+				testDone := make(chan struct{})
+				defer close(testDone)
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					<-ctx.Done()
+					<-testDone
+					return nil
+				}))).To(Succeed())
+				hookRan := make(chan struct{})
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("hook", func(ctx context.Context) error {
+					close(hookRan)
+					return nil
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					err = m.Start(ctx)
+					close(managerStopDone)
+				}()
+				<-m.Elected()
 				cancel()
 				<-managerStopDone
+
+				Expect(hookRan).NotTo(BeClosed())
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("failed waiting for all runnables to end within grace period of 1ns: context deadline exceeded"))
+				Expect(err.Error()).To(ContainSubstring(`shutdown hook "hook" was skipped`))
 				Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
-				Expect(errors.Is(err, runnableError{})).ToNot(BeTrue())
 			})
 
-			It("should return only runnables error if stop doesn't error", func(ctx SpecContext) {
+			It("should bound shutdown hooks by the grace period", func(specCtx SpecContext) {
 				m, err := New(cfg, options)
 				Expect(err).NotTo(HaveOccurred())
 				for _, cb := range callbacks {
 					cb(m)
 				}
-				Expect(m.Add(RunnableFunc(func(context.Context) error {
-					return runnableError{}
-				}))).To(Succeed())
-				err = m.Start(ctx)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("not feeling like that"))
-				Expect(errors.Is(err, context.DeadlineExceeded)).ToNot(BeTrue())
-				Expect(errors.Is(err, runnableError{})).To(BeTrue())
+				m.(*controllerManager).gracefulShutdownTimeout = 50 * time.Millisecond
+				m.(*controllerManager).shutdownHookTimeout = time.Hour
+
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("slow", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					err = m.Start(ctx)
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				Eventually(managerStopDone).Should(BeClosed())
+
+				Expect(err).To(MatchError(ContainSubstring(`shutdown hook "slow" failed`)))
+			})
+
+			It("should skip shutdown hooks if gracefulShutdownTimeout is 0", func(specCtx SpecContext) {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+				m.(*controllerManager).gracefulShutdownTimeout = time.Duration(0)
+
+				hookRan := make(chan struct{})
+				Expect(m.(ShutdownHookRegistrar).AddShutdownHook("hook", func(context.Context) error {
+					close(hookRan)
+					return nil
+				})).To(Succeed())
+
+				ctx, cancel := context.WithCancel(specCtx)
+				managerStopDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).To(Succeed())
+					close(managerStopDone)
+				}()
+				<-m.Elected()
+				cancel()
+				<-managerStopDone
+
+				Consistently(hookRan).ShouldNot(BeClosed())
 			})
 
 			It("should not wait for runnables if gracefulShutdownTimeout is 0", func(specCtx SpecContext) {
@@ -1457,6 +2003,153 @@ var _ = Describe("manger.Manager", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(body)).To(Equal("Some debug info"))
 			})
+
+			It("should wrap the metrics and webhook servers with the HTTP middlewares", func(ctx SpecContext) {
+				opts.HTTPMiddlewares = []func(http.Handler) http.Handler{
+					func(next http.Handler) http.Handler {
+						return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+							w.Header().Set("X-Request-Id", "test")
+							next.ServeHTTP(w, req)
+						})
+					},
+				}
+				webhookServer := webhook.NewServer(webhook.Options{}).(*webhook.DefaultServer)
+				opts.WebhookServer = webhookServer
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(webhookServer.Options.Middlewares).To(HaveLen(1))
+
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+				<-m.Elected()
+				Eventually(func() string { return defaultServer.GetBindAddr() }, 10*time.Second).ShouldNot(BeEmpty())
+
+				resp, err := http.Get(fmt.Sprintf("http://%s/metrics", defaultServer.GetBindAddr()))
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("X-Request-Id")).To(Equal("test"))
+			})
+
+			It("should serve the routes of the webhook server if ServeWebhookRoutes is set", func(ctx SpecContext) {
+				opts.ServeWebhookRoutes = true
+				opts.WebhookServer = &blockingWebhookServer{DefaultServer: webhook.NewServer(webhook.Options{}).(*webhook.DefaultServer)}
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+				m.GetWebhookServer().Register("/validate-apps-v1-deployment", http.NotFoundHandler())
+
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+				<-m.Elected()
+				// Note: Wait until metrics server has been started. A finished leader election
+				// doesn't guarantee that the metrics server is up.
+				Eventually(func() string { return defaultServer.GetBindAddr() }, 10*time.Second).ShouldNot(BeEmpty())
+
+				endpoint := fmt.Sprintf("http://%s/debug/webhook-routes", defaultServer.GetBindAddr())
+				resp, err := http.Get(endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := io.ReadAll(resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(ContainSubstring(`"path":"/validate-apps-v1-deployment"`))
+			})
+
+			It("should describe its runnables and serve them if ServeIntrospection is set", func(ctx SpecContext) {
+				opts.ServeIntrospection = true
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(m.Add(&namedControllerRunnable{name: "deployments", RunnableFunc: func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}})).To(Succeed())
+				Expect(m.Add(noLeaderElectionRunnable{RunnableFunc: func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}})).To(Succeed())
+				Expect(m.(RunnableDescriber).GetControllers()).To(Equal([]RunnableInfo{{
+					Name:               "deployments",
+					Type:               "*manager.namedControllerRunnable",
+					Group:              "LeaderElection",
+					NeedLeaderElection: true,
+					State:              RunnableStatePending,
+				}}))
+
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+				<-m.Elected()
+				Eventually(func() string { return defaultServer.GetBindAddr() }, 10*time.Second).ShouldNot(BeEmpty())
+				Eventually(func() []RunnableState {
+					var states []RunnableState
+					for _, info := range m.(RunnableDescriber).GetRunnables() {
+						if info.Group == "LeaderElection" || info.Group == "Others" {
+							states = append(states, info.State)
+						}
+					}
+					return states
+				}).Should(Equal([]RunnableState{RunnableStateRunning, RunnableStateRunning}))
+
+				endpoint := fmt.Sprintf("http://%s/debug/manager", defaultServer.GetBindAddr())
+				resp, err := http.Get(endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				var infos []RunnableInfo
+				Expect(json.NewDecoder(resp.Body).Decode(&infos)).To(Succeed())
+				Expect(infos).To(ContainElement(RunnableInfo{
+					Name:               "deployments",
+					Type:               "*manager.namedControllerRunnable",
+					Group:              "LeaderElection",
+					NeedLeaderElection: true,
+					State:              RunnableStateRunning,
+				}))
+				Expect(infos).To(ContainElement(HaveField("Group", "HTTPServers")))
+			})
+
+			It("should serve the pending requests of a controller if ServeIntrospection is set", func(ctx SpecContext) {
+				opts.ServeIntrospection = true
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(m.Add(&pendingRequestsRunnable{namedControllerRunnable: namedControllerRunnable{name: "deployments", RunnableFunc: func(ctx context.Context) error {
+					<-ctx.Done()
+					return nil
+				}}})).To(Succeed())
+
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+				Eventually(func() string { return defaultServer.GetBindAddr() }, 10*time.Second).ShouldNot(BeEmpty())
+
+				get := func(query string) (int, string) {
+					resp, err := http.Get(fmt.Sprintf("http://%s/debug/manager/pending-requests%s", defaultServer.GetBindAddr(), query))
+					Expect(err).NotTo(HaveOccurred())
+					defer resp.Body.Close()
+					body, err := io.ReadAll(resp.Body)
+					Expect(err).NotTo(HaveOccurred())
+					return resp.StatusCode, string(body)
+				}
+
+				status, body := get("?controller=deployments")
+				Expect(status).To(Equal(http.StatusOK))
+				Expect(body).To(MatchJSON(`[{"key":"default/foo","priority":0,"provenance":["Watch"]}]`))
+
+				status, _ = get("?controller=unknown")
+				Expect(status).To(Equal(http.StatusNotFound))
+
+				status, _ = get("")
+				Expect(status).To(Equal(http.StatusBadRequest))
+			})
 		})
 	})
 
@@ -1508,6 +2201,33 @@ var _ = Describe("manger.Manager", func() {
 			}, 10*time.Second).ShouldNot(Succeed())
 		})
 
+		It("should wrap the health probe server with the HTTP middlewares", func(ctx SpecContext) {
+			opts.HealthProbeBindAddress = ":0"
+			opts.HTTPMiddlewares = []func(http.Handler) http.Handler{
+				func(next http.Handler) http.Handler {
+					return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+						w.Header().Set("X-Request-Id", "test")
+						next.ServeHTTP(w, req)
+					})
+				},
+			}
+			m, err := New(cfg, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.AddHealthzCheck("check", healthz.Ping)).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).NotTo(HaveOccurred())
+			}()
+			<-m.Elected()
+
+			resp, err := http.Get(fmt.Sprint("http://", listener.Addr().String(), defaultLivenessEndpoint))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Header.Get("X-Request-Id")).To(Equal("test"))
+		})
+
 		It("should serve readiness endpoint", func(ctx SpecContext) {
 			opts.HealthProbeBindAddress = ":0"
 			m, err := New(cfg, opts)
@@ -1561,6 +2281,38 @@ var _ = Describe("manger.Manager", func() {
 			Expect(resp.StatusCode).To(Equal(http.StatusOK))
 		})
 
+		It("should stop serving readiness checks that were removed after start", func(ctx SpecContext) {
+			opts.HealthProbeBindAddress = ":0"
+			opts.Metrics.BindAddress = "0"
+			m, err := New(cfg, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			namedCheck := "check"
+			Expect(m.AddReadyzCheck(namedCheck, func(_ *http.Request) error { return fmt.Errorf("not ready") })).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).NotTo(HaveOccurred())
+			}()
+			<-m.Elected()
+
+			remover, ok := m.(HealthCheckRemover)
+			Expect(ok).To(BeTrue())
+			Expect(remover.RemoveReadyzCheck(namedCheck)).To(Succeed())
+			Expect(remover.RemoveReadyzCheck(namedCheck)).NotTo(Succeed())
+			Expect(remover.RemoveHealthzCheck(namedCheck)).NotTo(Succeed())
+
+			resp, err := http.Get(fmt.Sprint("http://", listener.Addr().String(), defaultReadinessEndpoint))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			resp, err = http.Get(fmt.Sprint("http://", listener.Addr().String(), path.Join(defaultReadinessEndpoint, namedCheck)))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+		})
+
 		It("should serve liveness endpoint", func(ctx SpecContext) {
 			opts.HealthProbeBindAddress = ":0"
 			m, err := New(cfg, opts)
@@ -1613,6 +2365,59 @@ var _ = Describe("manger.Manager", func() {
 			defer resp.Body.Close()
 			Expect(resp.StatusCode).To(Equal(http.StatusOK))
 		})
+		It("should call the health probe server hook with the probes", func(ctx SpecContext) {
+			opts.HealthProbeBindAddress = ":0"
+			var probes HealthProbes
+			opts.HealthProbeServerHook = func(srv *http.Server, p HealthProbes) error {
+				probes = p
+				next := srv.Handler
+				srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/hooked" {
+						w.WriteHeader(http.StatusTeapot)
+						return
+					}
+					next.ServeHTTP(w, r)
+				})
+				return nil
+			}
+			m, err := New(cfg, opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.AddReadyzCheck("check", healthz.Ping)).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).NotTo(HaveOccurred())
+			}()
+			<-m.Elected()
+
+			Eventually(func(g Gomega) {
+				resp, err := http.Get(fmt.Sprint("http://", listener.Addr().String(), "/hooked"))
+				g.Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				g.Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+			}).Should(Succeed())
+			resp, err := http.Get(fmt.Sprint("http://", listener.Addr().String(), defaultReadinessEndpoint))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			Expect(probes.ReadinessEndpointName).To(Equal(defaultReadinessEndpoint))
+			Expect(probes.Readiness).NotTo(BeNil())
+			Expect(probes.Readiness.Checks).To(HaveKey("check"))
+			Expect(probes.LivenessEndpointName).To(Equal(defaultLivenessEndpoint))
+			Expect(probes.Liveness).To(BeNil())
+		})
+
+		It("should not start if the health probe server hook fails", func(ctx SpecContext) {
+			opts.HealthProbeBindAddress = ":0"
+			opts.HealthProbeServerHook = func(*http.Server, HealthProbes) error {
+				return errors.New("hook failed")
+			}
+			m, err := New(cfg, opts)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.Start(ctx)).To(MatchError(ContainSubstring("hook failed")))
+		})
 	})
 
 	Context("should start serving pprof", func() {
@@ -2095,6 +2900,33 @@ func (c *cacheProvider) Start(ctx context.Context) error {
 	return c.cache.Start(ctx)
 }
 
+// blockingWebhookServer is a webhook server that doesn't serve anything, so that
+// it can be started without certificates.
+type blockingWebhookServer struct {
+	*webhook.DefaultServer
+}
+
+func (s *blockingWebhookServer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// stopRecordingCacheProvider is a cache runnable that calls onStop when it stops.
+type stopRecordingCacheProvider struct {
+	cache.Cache
+	onStop func()
+}
+
+func (c *stopRecordingCacheProvider) GetCache() cache.Cache {
+	return c
+}
+
+func (c *stopRecordingCacheProvider) Start(ctx context.Context) error {
+	<-ctx.Done()
+	c.onStop()
+	return nil
+}
+
 type startSignalingInformer struct {
 	mu sync.Mutex
 
@@ -2155,3 +2987,109 @@ func (n *needElection) Start(_ context.Context) error {
 func (n *needElection) NeedLeaderElection() bool {
 	return true
 }
+
+type leaderElectionGroupRunnable struct {
+	RunnableFunc
+	group string
+}
+
+func (r *leaderElectionGroupRunnable) LeaderElectionGroup() string {
+	return r.group
+}
+
+var _ = Describe("leader election handover", func() {
+	var backend *fakeleaderelection.LockBackend
+
+	newLock := func(identity string) resourcelock.Interface {
+		lock, err := leaderelection.NewExternalLock(backend, leaderelection.ExternalLockOptions{Name: "handover", Identity: identity})
+		Expect(err).NotTo(HaveOccurred())
+		return lock
+	}
+	newManager := func(lock resourcelock.Interface, target string) *controllerManager {
+		return &controllerManager{
+			resourceLock:  lock,
+			leaseDuration: 15 * time.Second,
+			renewDeadline: 10 * time.Second,
+			logger:        logr.Discard(),
+			leaderElectionHandoverTarget: func(context.Context) (string, error) {
+				return target, nil
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		backend = &fakeleaderelection.LockBackend{}
+	})
+
+	It("should hand the lease over to the target, which takes over before it expires", func(ctx SpecContext) {
+		leader := newLock("leader")
+		now := metav1.Now()
+		Expect(leader.Create(ctx, resourcelock.LeaderElectionRecord{
+			HolderIdentity: "leader", LeaseDurationSeconds: 15, AcquireTime: now, RenewTime: now,
+		})).To(Succeed())
+
+		newManager(leader, "standby").handOverLeadership()
+		Expect(backend.Holder("handover")).To(Equal("standby"))
+
+		elected := make(chan struct{})
+		elector, err := clientleaderelection.NewLeaderElector(clientleaderelection.LeaderElectionConfig{
+			Lock:          newLock("standby"),
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   100 * time.Millisecond,
+			Callbacks: clientleaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) { close(elected) },
+				OnStoppedLeading: func() {},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		go elector.Run(ctx)
+		Eventually(elected).Should(BeClosed())
+	})
+
+	It("should not overwrite a lease that another replica acquired", func(ctx SpecContext) {
+		other := newLock("other")
+		now := metav1.Now()
+		Expect(other.Create(ctx, resourcelock.LeaderElectionRecord{
+			HolderIdentity: "other", LeaseDurationSeconds: 15, AcquireTime: now, RenewTime: now,
+		})).To(Succeed())
+
+		newManager(newLock("leader"), "standby").handOverLeadership()
+		Expect(backend.Holder("handover")).To(Equal("other"))
+	})
+})
+
+// pendingRequestsRunnable is a controller runnable that describes its pending requests.
+type pendingRequestsRunnable struct {
+	namedControllerRunnable
+}
+
+func (r *pendingRequestsRunnable) DescribePendingRequests() any {
+	return []map[string]any{{"key": "default/foo", "priority": 0, "provenance": []string{"Watch"}}}
+}
+
+type namedControllerRunnable struct {
+	RunnableFunc
+	name string
+}
+
+func (r *namedControllerRunnable) ControllerName() string {
+	return r.name
+}
+
+type restartableGroupRunnable struct {
+	leaderElectionGroupRunnable
+	restarts atomic.Int32
+}
+
+func (r *restartableGroupRunnable) PrepareRestart() {
+	r.restarts.Add(1)
+}
+
+type noLeaderElectionRunnable struct {
+	RunnableFunc
+}
+
+func (noLeaderElectionRunnable) NeedLeaderElection() bool {
+	return false
+}
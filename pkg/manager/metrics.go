@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// runnablePanics is a prometheus counter metric which holds the total number of
+// recovered panics of runnables, see RunnablePanicRecovery. Runnables are labelled by
+// their controller name or NamedRunnable name.
+var runnablePanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "controller_runtime_runnable_panics_total",
+	Help: "Total number of recovered panics per runnable",
+}, []string{"runnable"})
+
+// startPhaseDuration is a prometheus gauge metric which holds the duration of each
+// phase of the start of the manager, see StartPhaseDescriber.
+var startPhaseDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "controller_runtime_manager_start_phase_duration_seconds",
+	Help: "Duration of each phase of the start of the manager",
+}, []string{"phase"})
+
+// managerInfo is a prometheus info metric which holds the key options of the manager
+// in its labels, its value is always 1. It is set when the manager starts.
+var managerInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "controller_runtime_manager_info",
+	Help: "Key options of the manager, the value is always 1",
+}, []string{"leader_election", "leader_election_id", "lease_duration", "renew_deadline", "retry_period", "graceful_shutdown_timeout"})
+
+func init() {
+	metrics.Registry.MustRegister(runnablePanics, startPhaseDuration, managerInfo)
+}
+
+// setInfoMetric exports the key options of the manager as the labels of its info metric.
+func (cm *controllerManager) setInfoMetric() {
+	managerInfo.Reset()
+	managerInfo.WithLabelValues(
+		strconv.FormatBool(cm.resourceLock != nil),
+		cm.leaderElectionID,
+		cm.leaseDuration.String(),
+		cm.renewDeadline.String(),
+		cm.retryPeriod.String(),
+		cm.getGracefulShutdownTimeout().String(),
+	).Set(1)
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("managerInfo", func() {
+	It("should export the key options of the manager", func() {
+		cm := &controllerManager{
+			leaderElectionID:        "test-lock",
+			leaseDuration:           15 * time.Second,
+			renewDeadline:           10 * time.Second,
+			retryPeriod:             2 * time.Second,
+			gracefulShutdownTimeout: 30 * time.Second,
+		}
+		cm.setInfoMetric()
+		Expect(testutil.CollectAndCount(managerInfo)).To(Equal(1))
+		Expect(testutil.ToFloat64(managerInfo.WithLabelValues("false", "test-lock", "15s", "10s", "2s", "30s"))).To(Equal(1.0))
+	})
+})
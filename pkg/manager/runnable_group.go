@@ -3,9 +3,15 @@ package manager
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
@@ -19,6 +25,61 @@ type readyRunnable struct {
 	Runnable
 	Check       runnableCheck
 	signalReady bool
+
+	// started and returned track the state of the runnable for introspection.
+	started  atomic.Bool
+	returned atomic.Bool
+
+	// done is closed once the runnable returned, or once it was dropped without
+	// being started.
+	done chan struct{}
+
+	// stopLock guards removed and cancel, which cancels the context the runnable
+	// was started with.
+	stopLock sync.Mutex
+	removed  bool
+	cancel   context.CancelFunc
+}
+
+// starting stores the cancel func of the context the runnable is started with. It
+// returns false if the runnable was removed and must not be started.
+func (r *readyRunnable) starting(cancel context.CancelFunc) bool {
+	r.stopLock.Lock()
+	defer r.stopLock.Unlock()
+	if r.removed {
+		return false
+	}
+	r.cancel = cancel
+	return true
+}
+
+// stop marks the runnable as removed and stops it if it was started.
+func (r *readyRunnable) stop() {
+	r.stopLock.Lock()
+	defer r.stopLock.Unlock()
+	r.removed = true
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// isRemoved returns whether the runnable was removed.
+func (r *readyRunnable) isRemoved() bool {
+	r.stopLock.Lock()
+	defer r.stopLock.Unlock()
+	return r.removed
+}
+
+// state returns the state of the runnable.
+func (r *readyRunnable) state() RunnableState {
+	switch {
+	case r.returned.Load():
+		return RunnableStateStopped
+	case r.started.Load():
+		return RunnableStateRunning
+	default:
+		return RunnableStatePending
+	}
 }
 
 // runnableCheck can be passed to Add() to let the runnable group determine that a
@@ -35,6 +96,19 @@ type runnables struct {
 	LeaderElection *runnableGroup
 	Warmup         *runnableGroup
 	Others         *runnableGroup
+
+	// leaderElectionLock guards LeaderElection, which is replaced by resetLeaderElection
+	// when the manager loses leadership and campaigns for it again.
+	leaderElectionLock sync.RWMutex
+	// leaderElectionStopping is set once the leader election runnables are stopped
+	// for good, after which LeaderElection is not replaced anymore.
+	leaderElectionStopping bool
+
+	baseContext   BaseContextFunc
+	errChan       chan error
+	logger        logr.Logger
+	panicRecovery *RunnablePanicRecovery
+	restartPolicy *RunnableRestartPolicy
 }
 
 // newRunnables creates a new runnables object.
@@ -46,11 +120,15 @@ func newRunnables(baseContext BaseContextFunc, errChan chan error) *runnables {
 		LeaderElection: newRunnableGroup(baseContext, errChan),
 		Warmup:         newRunnableGroup(baseContext, errChan),
 		Others:         newRunnableGroup(baseContext, errChan),
+		baseContext:    baseContext,
+		errChan:        errChan,
+		logger:         logr.Discard(),
 	}
 }
 
 // withLogger returns the runnables with the logger set for all runnable groups.
 func (r *runnables) withLogger(logger logr.Logger) *runnables {
+	r.logger = logger
 	r.HTTPServers.withLogger(logger)
 	r.Webhooks.withLogger(logger)
 	r.Caches.withLogger(logger)
@@ -59,6 +137,100 @@ func (r *runnables) withLogger(logger logr.Logger) *runnables {
 	return r
 }
 
+// withPanicRecovery returns the runnables with panic recovery set for all runnable groups.
+func (r *runnables) withPanicRecovery(panicRecovery *RunnablePanicRecovery) *runnables {
+	r.panicRecovery = panicRecovery
+	r.HTTPServers.withPanicRecovery(panicRecovery)
+	r.Webhooks.withPanicRecovery(panicRecovery)
+	r.Caches.withPanicRecovery(panicRecovery)
+	r.LeaderElection.withPanicRecovery(panicRecovery)
+	r.Warmup.withPanicRecovery(panicRecovery)
+	r.Others.withPanicRecovery(panicRecovery)
+	return r
+}
+
+// withRestartPolicy returns the runnables with the restart policy set for all runnable groups
+// but the caches, whose Start can't be called again.
+func (r *runnables) withRestartPolicy(restartPolicy *RunnableRestartPolicy) *runnables {
+	r.restartPolicy = restartPolicy
+	r.HTTPServers.withRestartPolicy(restartPolicy)
+	r.Webhooks.withRestartPolicy(restartPolicy)
+	r.LeaderElection.withRestartPolicy(restartPolicy)
+	r.Warmup.withRestartPolicy(restartPolicy)
+	r.Others.withRestartPolicy(restartPolicy)
+	return r
+}
+
+// leaderElection returns the current group of leader election runnables.
+func (r *runnables) leaderElection() *runnableGroup {
+	r.leaderElectionLock.RLock()
+	defer r.leaderElectionLock.RUnlock()
+	return r.LeaderElection
+}
+
+// addLeaderElection adds the runnable to the current group of leader election runnables.
+func (r *runnables) addLeaderElection(fn Runnable) error {
+	r.leaderElectionLock.RLock()
+	defer r.leaderElectionLock.RUnlock()
+	return r.LeaderElection.Add(fn, nil)
+}
+
+// resetLeaderElection replaces the group of leader election runnables by a new group
+// that holds the same runnables but isn't started yet, and returns the previous group
+// which the caller has to stop. It returns nil if the leader election runnables are
+// stopped for good.
+func (r *runnables) resetLeaderElection() *runnableGroup {
+	r.leaderElectionLock.Lock()
+	defer r.leaderElectionLock.Unlock()
+
+	if r.leaderElectionStopping {
+		return nil
+	}
+	previous := r.LeaderElection
+	r.LeaderElection = renewRunnableGroup(previous, r.baseContext, r.errChan, r.logger)
+	return previous
+}
+
+// renewRunnableGroup returns a new group that holds the same runnables as the given
+// group, but isn't started yet.
+func renewRunnableGroup(previous *runnableGroup, baseContext BaseContextFunc, errChan chan error, logger logr.Logger) *runnableGroup {
+	group := newRunnableGroup(baseContext, errChan)
+	group.withLogger(logger)
+	group.withPanicRecovery(previous.panicRecovery)
+	group.withRestartPolicy(previous.restartPolicy)
+	for _, rn := range previous.added() {
+		// The new group is neither started nor stopped, so this only queues up the runnable.
+		_ = group.Add(rn.Runnable, rn.Check)
+	}
+	return group
+}
+
+// stopLeaderElection stops the leader election runnables for good and waits for them to return.
+func (r *runnables) stopLeaderElection(ctx context.Context) {
+	r.leaderElectionLock.Lock()
+	r.leaderElectionStopping = true
+	group := r.LeaderElection
+	r.leaderElectionLock.Unlock()
+
+	// Prevent leader election when shutting down a non-elected manager
+	group.startOnce.Do(func() {})
+	group.StopAndWait(ctx)
+}
+
+// Remove removes the runnables that match from all groups and stops them. It returns
+// a channel per removed runnable that is closed once it returned.
+func (r *runnables) Remove(match func(Runnable) bool) []<-chan struct{} {
+	var done []<-chan struct{}
+	for _, group := range []*runnableGroup{r.HTTPServers, r.Webhooks, r.Caches, r.Warmup, r.Others} {
+		done = append(done, group.remove(match)...)
+	}
+	// Hold the lock so that the leader election runnables aren't replaced meanwhile,
+	// which would start the removed runnables again.
+	r.leaderElectionLock.RLock()
+	defer r.leaderElectionLock.RUnlock()
+	return append(done, r.LeaderElection.remove(match)...)
+}
+
 // Add adds a runnable to closest group of runnable that they belong to.
 //
 // Add should be able to be called before and after Start, but not after StopAndWait.
@@ -69,18 +241,20 @@ func (r *runnables) Add(fn Runnable) error {
 	switch runnable := fn.(type) {
 	case *Server:
 		if runnable.NeedLeaderElection() {
-			return r.LeaderElection.Add(fn, nil)
+			return r.addLeaderElection(fn)
 		}
 		return r.HTTPServers.Add(fn, nil)
 	case hasCache:
 		return r.Caches.Add(fn, func(ctx context.Context) bool {
-			return runnable.GetCache().WaitForCacheSync(ctx)
+			return cache.WaitForCacheSyncWithProgress(ctx, runnable.GetCache(), cacheSyncProgressInterval, func(statuses []cache.InformerSyncStatus) {
+				logPendingInformers(r.logger, statuses)
+			})
 		})
 	case webhook.Server:
 		return r.Webhooks.Add(fn, nil)
 	case warmupRunnable, LeaderElectionRunnable:
 		if warmupRunnable, ok := fn.(warmupRunnable); ok {
-			if err := r.Warmup.Add(RunnableFunc(warmupRunnable.Warmup), nil); err != nil {
+			if err := r.Warmup.Add(&warmup{runnable: warmupRunnable}, nil); err != nil {
 				return err
 			}
 		}
@@ -88,15 +262,15 @@ func (r *runnables) Add(fn Runnable) error {
 		leaderElectionRunnable, ok := fn.(LeaderElectionRunnable)
 		if !ok {
 			// If the runnable is not a LeaderElectionRunnable, add it to the leader election group for backwards compatibility
-			return r.LeaderElection.Add(fn, nil)
+			return r.addLeaderElection(fn)
 		}
 
 		if !leaderElectionRunnable.NeedLeaderElection() {
 			return r.Others.Add(fn, nil)
 		}
-		return r.LeaderElection.Add(fn, nil)
+		return r.addLeaderElection(fn)
 	default:
-		return r.LeaderElection.Add(fn, nil)
+		return r.addLeaderElection(fn)
 	}
 }
 
@@ -115,6 +289,10 @@ type runnableGroup struct {
 	startQueue   []*readyRunnable
 	startReadyCh chan *readyRunnable
 
+	// all holds every runnable added to the group, so that they can be added
+	// to a new group when the group is replaced. It is guarded by start.
+	all []*readyRunnable
+
 	stop     sync.RWMutex
 	stopOnce sync.Once
 	stopped  bool
@@ -133,6 +311,14 @@ type runnableGroup struct {
 
 	// logger is used for logging when errors are dropped during shutdown
 	logger logr.Logger
+
+	// panicRecovery configures the recovery of panics of the runnables,
+	// panics are not recovered if it is nil.
+	panicRecovery *RunnablePanicRecovery
+
+	// restartPolicy configures the restart of runnables that returned an error,
+	// they are not restarted if it is nil.
+	restartPolicy *RunnableRestartPolicy
 }
 
 func newRunnableGroup(baseContext BaseContextFunc, errChan chan error) *runnableGroup {
@@ -153,6 +339,16 @@ func (r *runnableGroup) withLogger(logger logr.Logger) {
 	r.logger = logger
 }
 
+// withPanicRecovery sets the panic recovery for this runnable group.
+func (r *runnableGroup) withPanicRecovery(panicRecovery *RunnablePanicRecovery) {
+	r.panicRecovery = panicRecovery
+}
+
+// withRestartPolicy sets the restart policy for this runnable group.
+func (r *runnableGroup) withRestartPolicy(restartPolicy *RunnableRestartPolicy) {
+	r.restartPolicy = restartPolicy
+}
+
 // Started returns true if the group has started.
 func (r *runnableGroup) Started() bool {
 	r.start.Lock()
@@ -228,6 +424,7 @@ func (r *runnableGroup) reconcile() {
 				// Drop any runnables if we're stopped.
 				r.errChan <- errRunnableGroupStopped
 				r.stop.RUnlock()
+				close(runnable.done)
 				continue
 			}
 
@@ -242,22 +439,36 @@ func (r *runnableGroup) reconcile() {
 
 		// Start the runnable.
 		go func(rn *readyRunnable) {
+			// If we return, the runnable ended cleanly
+			// or returned an error to the channel.
+			//
+			// We should always decrement the WaitGroup here.
+			defer r.wg.Done()
+			defer close(rn.done)
+
+			ctx, cancel := context.WithCancel(r.ctx)
+			defer cancel()
+			if !rn.starting(cancel) {
+				// The runnable was removed before it was started, don't let Start wait for it.
+				if rn.signalReady {
+					r.startReadyCh <- rn
+				}
+				return
+			}
+
 			go func() {
-				if rn.Check(r.ctx) {
+				if rn.Check(ctx) || rn.isRemoved() {
 					if rn.signalReady {
 						r.startReadyCh <- rn
 					}
 				}
 			}()
 
-			// If we return, the runnable ended cleanly
-			// or returned an error to the channel.
-			//
-			// We should always decrement the WaitGroup here.
-			defer r.wg.Done()
+			defer rn.returned.Store(true)
 
 			// Start the runnable.
-			if err := rn.Start(r.ctx); err != nil {
+			rn.started.Store(true)
+			if err := r.run(ctx, rn); err != nil {
 				// Check if we're during the shutdown process.
 				r.stop.RLock()
 				isStopped := r.stopped
@@ -284,6 +495,162 @@ func (r *runnableGroup) reconcile() {
 	}
 }
 
+// run starts the runnable. If panic recovery is enabled, panics of the runnable are
+// recovered and it is started again with backoff until MaxRestarts is exceeded. With
+// the OnFailure restart policy, the same applies to errors returned by the runnable.
+func (r *runnableGroup) run(ctx context.Context, rn *readyRunnable) error {
+	restartOnFailure := r.restartPolicy != nil && r.restartPolicy.Policy == RestartPolicyOnFailure
+	if r.panicRecovery == nil && !restartOnFailure {
+		return rn.Start(ctx)
+	}
+
+	var panicBackoff, failureBackoff *restartBackoff
+	if r.panicRecovery != nil {
+		panicBackoff = newRestartBackoff(r.panicRecovery.MaxRestarts, r.panicRecovery.InitialBackoff, r.panicRecovery.MaxBackoff)
+	}
+	if restartOnFailure {
+		maxRestarts := r.restartPolicy.MaxRestarts
+		if maxRestarts == 0 {
+			maxRestarts = -1
+		}
+		failureBackoff = newRestartBackoff(maxRestarts, r.restartPolicy.InitialBackoff, r.restartPolicy.MaxBackoff)
+	}
+
+	for {
+		var panicked bool
+		var err error
+		started := time.Now()
+		if r.panicRecovery != nil {
+			panicked, err = r.startRecovering(ctx, rn)
+		} else {
+			err = rn.Start(ctx)
+		}
+		if failureBackoff != nil && time.Since(started) >= r.restartPolicy.resetAfter() {
+			// The runnable was healthy for long enough, so that a failure isn't counted
+			// against the restarts of earlier failures.
+			failureBackoff.reset()
+		}
+
+		var backoff time.Duration
+		switch {
+		case panicked:
+			var ok bool
+			if backoff, ok = panicBackoff.next(); !ok {
+				return err
+			}
+			r.logger.Info("Restarting runnable after panic", "runnable", runnableName(rn.Runnable), "restarts", panicBackoff.restarts, "backoff", backoff)
+		case err != nil && restartOnFailure && ctx.Err() == nil:
+			var ok bool
+			if backoff, ok = failureBackoff.next(); !ok {
+				return err
+			}
+			r.logger.Error(err, "Restarting runnable after failure", "runnable", runnableName(rn.Runnable), "restarts", failureBackoff.restarts, "backoff", backoff)
+		default:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		if restartable, ok := rn.Runnable.(RestartableRunnable); ok {
+			restartable.PrepareRestart()
+		}
+	}
+}
+
+// restartBackoff tracks the restarts of a runnable and the exponential backoff between them.
+type restartBackoff struct {
+	maxRestarts    int
+	restarts       int
+	initialBackoff time.Duration
+	backoff        time.Duration
+	maxBackoff     time.Duration
+}
+
+// resetAfter returns the duration after which a running runnable is considered healthy again.
+func (p *RunnableRestartPolicy) resetAfter() time.Duration {
+	if p.ResetAfter <= 0 {
+		return 10 * time.Minute
+	}
+	return p.ResetAfter
+}
+
+func newRestartBackoff(maxRestarts int, initialBackoff, maxBackoff time.Duration) *restartBackoff {
+	if initialBackoff <= 0 {
+		initialBackoff = time.Second
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = time.Minute
+	}
+	return &restartBackoff{maxRestarts: maxRestarts, initialBackoff: initialBackoff, backoff: initialBackoff, maxBackoff: maxBackoff}
+}
+
+// reset resets the restarts and the backoff.
+func (b *restartBackoff) reset() {
+	b.restarts = 0
+	b.backoff = b.initialBackoff
+}
+
+// next returns the backoff before the next restart, or false if the restarts are exhausted.
+func (b *restartBackoff) next() (time.Duration, bool) {
+	if b.maxRestarts >= 0 && b.restarts >= b.maxRestarts {
+		return 0, false
+	}
+	b.restarts++
+	backoff := b.backoff
+	b.backoff = min(2*b.backoff, b.maxBackoff)
+	return backoff, true
+}
+
+// startRecovering starts the runnable and recovers its panic, which is reported
+// and returned as error.
+func (r *runnableGroup) startRecovering(ctx context.Context, rn *readyRunnable) (panicked bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			name := runnableName(rn.Runnable)
+			runnablePanics.WithLabelValues(runnableMetricLabel(rn.Runnable)).Inc()
+			for _, fn := range utilruntime.PanicHandlers {
+				fn(ctx, p)
+			}
+			panicked = true
+			err = fmt.Errorf("panic in runnable %s: %v [recovered]", name, p)
+		}
+	}()
+	return false, rn.Start(ctx)
+}
+
+// unnamedRunnableLabel is the metric label of runnables that are neither a controller
+// nor implement NamedRunnable.
+const unnamedRunnableLabel = "unnamed"
+
+// runnableName returns the name of the controller or named runnable, or the type of
+// any other runnable.
+func runnableName(rn Runnable) string {
+	if controller, ok := rn.(controllerRunnable); ok {
+		return controller.ControllerName()
+	}
+	if named, ok := rn.(NamedRunnable); ok {
+		return named.RunnableName()
+	}
+	return fmt.Sprintf("%T", rn)
+}
+
+// runnableMetricLabel returns the name of the controller or named runnable. Other
+// runnables share a fixed label, as their type names aren't bounded, e.g. with
+// generic or anonymous types.
+func runnableMetricLabel(rn Runnable) string {
+	if controller, ok := rn.(controllerRunnable); ok {
+		return controller.ControllerName()
+	}
+	if named, ok := rn.(NamedRunnable); ok {
+		return named.RunnableName()
+	}
+	return unnamedRunnableLabel
+}
+
 // Add should be able to be called before and after Start, but not after StopAndWait.
 // Add should return an error when called during StopAndWait.
 func (r *runnableGroup) Add(rn Runnable, ready runnableCheck) error {
@@ -301,6 +668,7 @@ func (r *runnableGroup) Add(rn Runnable, ready runnableCheck) error {
 	readyRunnable := &readyRunnable{
 		Runnable: rn,
 		Check:    ready,
+		done:     make(chan struct{}),
 	}
 
 	// Handle start.
@@ -309,6 +677,7 @@ func (r *runnableGroup) Add(rn Runnable, ready runnableCheck) error {
 	// queue them up again later.
 	{
 		r.start.Lock()
+		r.all = append(r.all, readyRunnable)
 
 		// Check if we're already started.
 		if !r.started {
@@ -334,6 +703,44 @@ func (r *runnableGroup) Add(rn Runnable, ready runnableCheck) error {
 	return nil
 }
 
+// remove removes the runnables that match from the group and stops them. It returns a
+// channel per removed runnable that is closed once it returned.
+func (r *runnableGroup) remove(match func(Runnable) bool) []<-chan struct{} {
+	r.start.Lock()
+	defer r.start.Unlock()
+
+	var done []<-chan struct{}
+	r.all = slices.DeleteFunc(r.all, func(rn *readyRunnable) bool {
+		if !match(rn.Runnable) {
+			return false
+		}
+		done = append(done, rn.done)
+		if !r.started {
+			// Runnables that were added before the group was started are only
+			// queued up, drop them.
+			r.startQueue = slices.DeleteFunc(r.startQueue, func(queued *readyRunnable) bool { return queued == rn })
+			close(rn.done)
+			return true
+		}
+		rn.stop()
+		return true
+	})
+	return done
+}
+
+// added returns all runnables that were added to the group.
+func (r *runnableGroup) added() []*readyRunnable {
+	r.start.Lock()
+	defer r.start.Unlock()
+	return slices.Clone(r.all)
+}
+
+// waitStopped waits for all the runnables of a stopped group to return, even
+// if StopAndWait gave up waiting for them.
+func (r *runnableGroup) waitStopped() {
+	r.wg.Wait()
+}
+
 // StopAndWait waits for all the runnables to finish before returning.
 func (r *runnableGroup) StopAndWait(ctx context.Context) {
 	r.stopOnce.Do(func() {
@@ -369,3 +776,26 @@ func (r *runnableGroup) StopAndWait(ctx context.Context) {
 		}
 	})
 }
+
+// cacheSyncProgressInterval is the interval at which the informers that didn't
+// sync yet are logged while waiting for a cache to sync.
+var cacheSyncProgressInterval = 10 * time.Second
+
+// logPendingInformers logs the informers that didn't sync yet, if any.
+func logPendingInformers(logger logr.Logger, statuses []cache.InformerSyncStatus) {
+	var pending []string
+	for _, status := range statuses {
+		if status.Synced {
+			continue
+		}
+		informer := status.GVK.String()
+		if status.Namespace != "" {
+			informer += " in namespace " + status.Namespace
+		}
+		pending = append(pending, fmt.Sprintf("%s (%s)", informer, status.Elapsed.Round(time.Second)))
+	}
+	if len(pending) > 0 {
+		slices.Sort(pending)
+		logger.Info("Waiting for informers to sync", "pending", pending)
+	}
+}
@@ -75,6 +75,21 @@ var _ = Describe("runnables", func() {
 		Expect(r.Others.startQueue).To(BeEmpty())
 	})
 
+	It("should describe the warmup of a WarmupRunnable as part of the Warmup group", func() {
+		warmupRunnable := newWarmupRunnableFunc(
+			func(c context.Context) error { return nil },
+			func(c context.Context) error { return nil },
+		)
+
+		r := newRunnables(defaultBaseContext, errCh)
+		Expect(r.Add(warmupRunnable)).To(Succeed())
+		cm := &controllerManager{runnables: r}
+		Expect(cm.GetRunnables()).To(ConsistOf(
+			RunnableInfo{Type: "*manager.warmupRunnableFunc", Group: RunnableGroupLeaderElection, NeedLeaderElection: true, State: RunnableStatePending},
+			RunnableInfo{Type: "*manager.warmupRunnableFunc", Group: RunnableGroupWarmup, State: RunnableStatePending},
+		))
+	})
+
 	It("should add WarmupRunnable that doesn't needs leader election to warmup group only", func() {
 		warmupRunnable := newLeaderElectionAndWarmupRunnable(
 			func(c context.Context) error {
@@ -142,6 +157,28 @@ var _ = Describe("runnables", func() {
 			}
 		}).Should(Equal(expectedErr))
 	})
+	It("should remove a WarmupRunnable from the Warmup and LeaderElection group", func() {
+		warmupRunnable := newWarmupRunnableFunc(
+			func(c context.Context) error { return nil },
+			func(c context.Context) error { return nil },
+		)
+
+		r := newRunnables(defaultBaseContext, errCh)
+		Expect(r.Add(warmupRunnable)).To(Succeed())
+		done := r.Remove(func(rn Runnable) bool {
+			if w, ok := rn.(*warmup); ok {
+				return any(w.runnable) == any(warmupRunnable)
+			}
+			return rn == warmupRunnable
+		})
+		Expect(done).To(HaveLen(2))
+		for _, d := range done {
+			Expect(d).To(BeClosed())
+		}
+		Expect(r.Warmup.startQueue).To(BeEmpty())
+		Expect(r.LeaderElection.startQueue).To(BeEmpty())
+		Expect(r.LeaderElection.added()).To(BeEmpty())
+	})
 })
 
 var _ = Describe("runnableGroup", func() {
@@ -306,8 +343,209 @@ var _ = Describe("runnableGroup", func() {
 			}(i)
 		}
 	})
+
+	It("should restart a panicking runnable with panic recovery enabled", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withPanicRecovery(&RunnablePanicRecovery{MaxRestarts: 2, InitialBackoff: time.Millisecond})
+
+		var starts atomic.Int32
+		running := make(chan struct{})
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			if starts.Add(1) < 3 {
+				panic("expected panic")
+			}
+			close(running)
+			<-ctx.Done()
+			return nil
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		Eventually(running).Should(BeClosed())
+		Expect(starts.Load()).To(BeEquivalentTo(3))
+		rg.StopAndWait(specCtx)
+		Expect(errCh).NotTo(Receive())
+	})
+
+	It("should return the panic as error once the restarts are exhausted", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withPanicRecovery(&RunnablePanicRecovery{MaxRestarts: 1, InitialBackoff: time.Millisecond})
+
+		var starts atomic.Int32
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			starts.Add(1)
+			panic("expected panic")
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		var err error
+		Eventually(errCh).Should(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring("expected panic")))
+		Expect(starts.Load()).To(BeEquivalentTo(2))
+		rg.StopAndWait(specCtx)
+	})
+
+	It("should restart a failing runnable with the OnFailure restart policy", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withRestartPolicy(&RunnableRestartPolicy{Policy: RestartPolicyOnFailure, InitialBackoff: time.Millisecond})
+
+		var starts atomic.Int32
+		running := make(chan struct{})
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			if starts.Add(1) < 3 {
+				return errors.New("address already in use")
+			}
+			close(running)
+			<-ctx.Done()
+			return nil
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		Eventually(running).Should(BeClosed())
+		Expect(starts.Load()).To(BeEquivalentTo(3))
+		rg.StopAndWait(specCtx)
+		Expect(errCh).NotTo(Receive())
+	})
+
+	It("should return the error once the restarts of the OnFailure restart policy are exhausted", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withRestartPolicy(&RunnableRestartPolicy{Policy: RestartPolicyOnFailure, MaxRestarts: 2, InitialBackoff: time.Millisecond})
+
+		var starts atomic.Int32
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			starts.Add(1)
+			return errors.New("address already in use")
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		var err error
+		Eventually(errCh).Should(Receive(&err))
+		Expect(err).To(MatchError("address already in use"))
+		Expect(starts.Load()).To(BeEquivalentTo(3))
+		rg.StopAndWait(specCtx)
+	})
+
+	It("should reset the restarts of the OnFailure restart policy after a healthy run", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withRestartPolicy(&RunnableRestartPolicy{
+			Policy:         RestartPolicyOnFailure,
+			MaxRestarts:    1,
+			InitialBackoff: time.Millisecond,
+			ResetAfter:     20 * time.Millisecond,
+		})
+
+		var starts atomic.Int32
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			if starts.Add(1) <= 3 {
+				// Fail after running healthy, which doesn't exhaust the restarts.
+				time.Sleep(30 * time.Millisecond)
+			}
+			return errors.New("connection reset")
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		var err error
+		Eventually(errCh).Should(Receive(&err))
+		Expect(err).To(MatchError("connection reset"))
+		Expect(starts.Load()).To(BeEquivalentTo(4))
+		rg.StopAndWait(specCtx)
+	})
+
+	It("should not set the restart policy for caches", func() {
+		r := newRunnables(defaultBaseContext, errCh).withRestartPolicy(&RunnableRestartPolicy{Policy: RestartPolicyOnFailure})
+		Expect(r.Others.restartPolicy).NotTo(BeNil())
+		Expect(r.Caches.restartPolicy).To(BeNil())
+	})
+
+	It("should not restart a failing runnable with the Never restart policy", func(specCtx SpecContext) {
+		errCh := make(chan error, 1)
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		rg.withRestartPolicy(&RunnableRestartPolicy{Policy: RestartPolicyNever, MaxRestarts: -1, InitialBackoff: time.Millisecond})
+
+		var starts atomic.Int32
+		Expect(rg.Add(RunnableFunc(func(ctx context.Context) error {
+			starts.Add(1)
+			return errors.New("address already in use")
+		}), nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+
+		Eventually(errCh).Should(Receive())
+		Consistently(starts.Load, 50*time.Millisecond).Should(BeEquivalentTo(1))
+		rg.StopAndWait(specCtx)
+	})
+
+	It("should stop a removed runnable and not start it again when the group is renewed", func(specCtx SpecContext) {
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		removed := &blockingRunnable{}
+		kept := &blockingRunnable{}
+		Expect(rg.Add(removed, nil)).To(Succeed())
+		Expect(rg.Add(kept, nil)).To(Succeed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+		Eventually(removed.running.Load).Should(BeTrue())
+
+		done := rg.remove(func(rn Runnable) bool { return rn == removed })
+		Expect(done).To(HaveLen(1))
+		Eventually(done[0]).Should(BeClosed())
+		Expect(removed.running.Load()).To(BeFalse())
+		Expect(kept.running.Load()).To(BeTrue())
+
+		renewed := renewRunnableGroup(rg, defaultBaseContext, errCh, rg.logger)
+		Expect(renewed.added()).To(HaveLen(1))
+		Expect(renewed.added()[0].Runnable).To(BeIdenticalTo(kept))
+		rg.StopAndWait(specCtx)
+	})
+
+	It("should not wait for runnables that were removed before they were started", func(specCtx SpecContext) {
+		rg := newRunnableGroup(defaultBaseContext, errCh)
+		removed := &blockingRunnable{}
+		Expect(rg.Add(removed, func(ctx context.Context) bool {
+			<-ctx.Done()
+			return false
+		})).To(Succeed())
+
+		done := rg.remove(func(rn Runnable) bool { return rn == removed })
+		Expect(done).To(HaveLen(1))
+		Expect(done[0]).To(BeClosed())
+		Expect(rg.Start(specCtx)).To(Succeed())
+		Expect(removed.running.Load()).To(BeFalse())
+		rg.StopAndWait(specCtx)
+	})
 })
 
+var _ = Describe("runnableMetricLabel", func() {
+	It("should use the name of controllers and named runnables", func() {
+		Expect(runnableMetricLabel(&namedRunnable{name: "cleanup"})).To(Equal("cleanup"))
+		Expect(runnableName(&namedRunnable{name: "cleanup"})).To(Equal("cleanup"))
+	})
+
+	It("should use a fixed label for other runnables", func() {
+		Expect(runnableMetricLabel(RunnableFunc(func(context.Context) error { return nil }))).To(Equal(unnamedRunnableLabel))
+	})
+})
+
+type blockingRunnable struct {
+	running atomic.Bool
+}
+
+func (r *blockingRunnable) Start(ctx context.Context) error {
+	r.running.Store(true)
+	defer r.running.Store(false)
+	<-ctx.Done()
+	return nil
+}
+
+type namedRunnable struct {
+	name string
+}
+
+func (r *namedRunnable) Start(context.Context) error { return nil }
+
+func (r *namedRunnable) RunnableName() string { return r.name }
+
 var _ warmupRunnable = &warmupRunnableFunc{}
 
 func newWarmupRunnableFunc(
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("runShutdownHooks", func() {
+	It("should not block on a hook that ignores its context", func() {
+		cm := &controllerManager{logger: logr.Discard(), stopProcedureEngaged: new(int64(0)), shutdownHookTimeout: 10 * time.Millisecond}
+		block := make(chan struct{})
+		defer close(block)
+		Expect(cm.AddShutdownHook("stuck", func(context.Context) error {
+			<-block
+			return nil
+		})).To(Succeed())
+		secondRan := make(chan struct{})
+		Expect(cm.AddShutdownHook("fast", func(context.Context) error {
+			close(secondRan)
+			return nil
+		})).To(Succeed())
+
+		done := make(chan error)
+		go func() {
+			done <- cm.runShutdownHooks(context.Background())
+		}()
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(err).To(MatchError(ContainSubstring(`shutdown hook "stuck" failed`)))
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(secondRan).To(BeClosed())
+	})
+
+	It("should give up on a hook that ignores its context when the context is done", func() {
+		cm := &controllerManager{logger: logr.Discard(), stopProcedureEngaged: new(int64(0)), shutdownHookTimeout: -1}
+		block := make(chan struct{})
+		defer close(block)
+		Expect(cm.AddShutdownHook("stuck", func(context.Context) error {
+			<-block
+			return nil
+		})).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		done := make(chan error)
+		go func() {
+			done <- cm.runShutdownHooks(ctx)
+		}()
+		var err error
+		Eventually(done).Should(Receive(&err))
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// StartPhase is a phase of the start of a Manager.
+type StartPhase string
+
+const (
+	// StartPhaseHTTPServers starts the HTTP servers, e.g. the metrics and health probe servers.
+	StartPhaseHTTPServers StartPhase = "HTTPServers"
+
+	// StartPhaseWebhooks starts the webhook servers until they serve.
+	StartPhaseWebhooks StartPhase = "Webhooks"
+
+	// StartPhaseCaches starts the caches until they synced.
+	StartPhaseCaches StartPhase = "Caches"
+
+	// StartPhaseWarmup runs the warmup of the runnables that implement a Warmup method.
+	StartPhaseWarmup StartPhase = "Warmup"
+
+	// StartPhaseLeaderElection waits until the Manager became leader. It is skipped if
+	// leader election is disabled.
+	StartPhaseLeaderElection StartPhase = "LeaderElection"
+
+	// StartPhaseLeaderElectionRunnables starts the runnables that need leader election,
+	// including the controllers, until they are ready.
+	StartPhaseLeaderElectionRunnables StartPhase = "LeaderElectionRunnables"
+)
+
+// StartPhaseInfo describes a phase of the start of a Manager.
+type StartPhaseInfo struct {
+	// Phase is the phase.
+	Phase StartPhase `json:"phase"`
+
+	// Started is the time the phase started at.
+	Started time.Time `json:"started"`
+
+	// Completed is the time the phase completed at, it is zero while the phase is running.
+	Completed time.Time `json:"completed,omitzero"`
+}
+
+// StartPhaseDescriber is implemented by Managers that describe the phases of their start,
+// which includes the Manager returned by New. The duration of every phase is also logged
+// and reported through the controller_runtime_manager_start_phase_duration_seconds metric.
+type StartPhaseDescriber interface {
+	// GetStartPhases returns the phases that started so far, in the order they started.
+	// Only the first run of a phase is reported, e.g. not the one after the Manager
+	// re-acquired leadership.
+	GetStartPhases() []StartPhaseInfo
+}
+
+// startPhases tracks the phases of the start of a manager. Its zero value is ready to use.
+type startPhases struct {
+	mu     sync.Mutex
+	phases []StartPhaseInfo
+}
+
+// begin records the start of the phase and returns a func that records its completion.
+// Phases that already ran before are not recorded again.
+func (p *startPhases) begin(logger logr.Logger, phase StartPhase) func() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if slices.ContainsFunc(p.phases, func(info StartPhaseInfo) bool { return info.Phase == phase }) {
+		return func() {}
+	}
+	started := time.Now()
+	p.phases = append(p.phases, StartPhaseInfo{Phase: phase, Started: started})
+	logger.V(1).Info("Starting phase", "phase", phase)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			completed := time.Now()
+			p.mu.Lock()
+			for i := range p.phases {
+				if p.phases[i].Phase == phase {
+					p.phases[i].Completed = completed
+				}
+			}
+			p.mu.Unlock()
+
+			duration := completed.Sub(started)
+			startPhaseDuration.WithLabelValues(string(phase)).Set(duration.Seconds())
+			logger.Info("Completed start phase", "phase", phase, "duration", duration)
+		})
+	}
+}
+
+func (p *startPhases) list() []StartPhaseInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.phases)
+}
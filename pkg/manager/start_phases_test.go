@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("startPhases", func() {
+	It("should record the phases in the order they started", func() {
+		p := &startPhases{}
+		cachesDone := p.begin(logr.Discard(), StartPhaseCaches)
+		warmupDone := p.begin(logr.Discard(), StartPhaseWarmup)
+		warmupDone()
+
+		phases := p.list()
+		Expect(phases).To(HaveLen(2))
+		Expect(phases[0].Phase).To(Equal(StartPhaseCaches))
+		Expect(phases[0].Completed.IsZero()).To(BeTrue())
+		Expect(phases[1].Phase).To(Equal(StartPhaseWarmup))
+		Expect(phases[1].Completed).NotTo(BeTemporally("<", phases[1].Started))
+
+		cachesDone()
+		Expect(p.list()[0].Completed.IsZero()).To(BeFalse())
+	})
+
+	It("should only record the first run of a phase", func() {
+		p := &startPhases{}
+		p.begin(logr.Discard(), StartPhaseLeaderElectionRunnables)()
+		first := p.list()[0]
+
+		p.begin(logr.Discard(), StartPhaseLeaderElectionRunnables)()
+		Expect(p.list()).To(ConsistOf(first))
+	})
+
+	It("should report the duration of completed phases", func() {
+		p := &startPhases{}
+		p.begin(logr.Discard(), StartPhaseWebhooks)()
+		Expect(testutil.ToFloat64(startPhaseDuration.WithLabelValues(string(StartPhaseWebhooks)))).To(BeNumerically(">=", 0))
+	})
+})
@@ -0,0 +1,27 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	internalclientmetrics "sigs.k8s.io/controller-runtime/pkg/internal/client/metrics"
+)
+
+func init() {
+	// The metrics of the clients of pkg/client are registered here rather than by
+	// pkg/client itself, so that using a client doesn't register any metrics.
+	Registry.MustRegister(internalclientmetrics.Collectors()...)
+}
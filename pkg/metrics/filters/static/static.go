@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package static provides filters for the metrics server that authenticate and
+// authorize requests without the kube-apiserver, e.g. in clusters without
+// kube-rbac-proxy. Unlike the filters package it doesn't depend on "k8s.io/apiserver".
+package static
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// Chain returns a metricsserver.FilterProvider that applies the filters of all given
+// providers. The filter of the first provider is the outermost one, i.e. it sees a
+// request first and the handler is only called if all filters let it pass.
+func Chain(providers ...metricsserver.FilterProvider) metricsserver.FilterProvider {
+	return func(config *rest.Config, httpClient *http.Client) (metricsserver.Filter, error) {
+		filters := make([]metricsserver.Filter, 0, len(providers))
+		for _, provider := range providers {
+			filter, err := provider(config, httpClient)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+
+		return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+			for _, filter := range slices.Backward(filters) {
+				var err error
+				handler, err = filter(log, handler)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return handler, nil
+		}, nil
+	}
+}
+
+// WithBearerTokens provides a metrics.Filter that only lets requests pass which carry
+// one of the given tokens in their "Authorization: Bearer <token>" header. The tokens
+// are static, e.g. read from a mounted Secret, and are not reviewed by the kube-apiserver.
+func WithBearerTokens(tokens ...string) metricsserver.FilterProvider {
+	return func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+		if len(tokens) == 0 {
+			return nil, errors.New("at least one bearer token is required")
+		}
+		// Comparing the hashes makes the comparison constant time regardless of the token length.
+		hashes := make([][sha256.Size]byte, 0, len(tokens))
+		for _, token := range tokens {
+			if token == "" {
+				return nil, errors.New("bearer tokens must not be empty")
+			}
+			hashes = append(hashes, sha256.Sum256([]byte(token)))
+		}
+
+		return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+				if !ok || token == "" {
+					log.V(4).Info("Authentication failed, no bearer token")
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				hash := sha256.Sum256([]byte(token))
+				allowed := 0
+				for _, h := range hashes {
+					allowed |= subtle.ConstantTimeCompare(hash[:], h[:])
+				}
+				if allowed != 1 {
+					log.V(4).Info("Authentication failed, unknown bearer token")
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				handler.ServeHTTP(w, req)
+			}), nil
+		}, nil
+	}
+}
+
+// WithClientCertificates provides a metrics.Filter that only lets requests pass whose client
+// presented a certificate that was verified against the ClientCAFile of the metrics server.
+// If common names are given, the common name of the client certificate must be one of them.
+func WithClientCertificates(commonNames ...string) metricsserver.FilterProvider {
+	return func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+		return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+					log.V(4).Info("Authentication failed, no verified client certificate")
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+
+				commonName := req.TLS.VerifiedChains[0][0].Subject.CommonName
+				if len(commonNames) > 0 && !slices.Contains(commonNames, commonName) {
+					msg := fmt.Sprintf("Authorization denied for client certificate %s", commonName)
+					log.V(4).Info(msg)
+					http.Error(w, msg, http.StatusForbidden)
+					return
+				}
+
+				handler.ServeHTTP(w, req)
+			}), nil
+		}, nil
+	}
+}
+
+// Authorizer decides whether an authenticated request is allowed. It returns the reason
+// for denying the request, or an error if no decision could be made.
+type Authorizer func(req *http.Request) (allowed bool, reason string, err error)
+
+// WithAuthorizer provides a metrics.Filter that only lets requests pass which are allowed
+// by the given authorizer. It must be chained after an authenticating filter, e.g. with
+// Chain(WithClientCertificates(), WithAuthorizer(authorize)), which the authorizer can
+// rely on, e.g. by inspecting req.TLS.
+func WithAuthorizer(authorize Authorizer) metricsserver.FilterProvider {
+	return func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+		if authorize == nil {
+			return nil, errors.New("authorizer must not be nil")
+		}
+
+		return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				allowed, reason, err := authorize(req)
+				if err != nil {
+					log.Error(err, "Authorization error", "path", req.URL.Path)
+					http.Error(w, fmt.Sprintf("Authorization error (%s)", err), http.StatusInternalServerError)
+					return
+				}
+				if !allowed {
+					msg := fmt.Sprintf("Authorization denied for %s %s", req.Method, req.URL.Path)
+					if reason != "" {
+						msg += ": " + reason
+					}
+					log.V(4).Info(msg)
+					http.Error(w, msg, http.StatusForbidden)
+					return
+				}
+
+				handler.ServeHTTP(w, req)
+			}), nil
+		}, nil
+	}
+}
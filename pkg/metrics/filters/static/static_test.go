@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package static
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/rest"
+
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+var _ = Describe("Static filters", func() {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	serve := func(provider metricsserver.FilterProvider, req *http.Request) *httptest.ResponseRecorder {
+		filter, err := provider(nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		handler, err := filter(logr.Discard(), ok)
+		Expect(err).NotTo(HaveOccurred())
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	Describe("WithBearerTokens", func() {
+		provider := WithBearerTokens("token-a", "token-b")
+
+		It("should let requests with an allowed token pass", func() {
+			for _, token := range []string{"token-a", "token-b"} {
+				req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+				req.Header.Set("Authorization", "Bearer "+token)
+				Expect(serve(provider, req).Code).To(Equal(http.StatusOK))
+			}
+		})
+
+		It("should reject requests without or with an unknown token", func() {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			resp := serve(provider, req)
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+			Expect(resp.Header().Get("WWW-Authenticate")).To(Equal("Bearer"))
+
+			req.Header.Set("Authorization", "Bearer token-c")
+			Expect(serve(provider, req).Code).To(Equal(http.StatusUnauthorized))
+
+			req.Header.Set("Authorization", "Basic token-a")
+			Expect(serve(provider, req).Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should require non-empty tokens", func() {
+			_, err := WithBearerTokens()(nil, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = WithBearerTokens("")(nil, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("WithClientCertificates", func() {
+		withClientCertificate := func(req *http.Request, commonName string) *http.Request {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+				{Subject: pkix.Name{CommonName: commonName}},
+			}}}
+			return req
+		}
+
+		It("should reject requests without a verified client certificate", func() {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			Expect(serve(WithClientCertificates(), req).Code).To(Equal(http.StatusUnauthorized))
+
+			req.TLS = &tls.ConnectionState{}
+			Expect(serve(WithClientCertificates(), req).Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should let requests with a verified client certificate pass", func() {
+			req := withClientCertificate(httptest.NewRequest(http.MethodGet, "/metrics", nil), "prometheus")
+			Expect(serve(WithClientCertificates(), req).Code).To(Equal(http.StatusOK))
+		})
+
+		It("should only let allowed common names pass", func() {
+			provider := WithClientCertificates("prometheus")
+
+			req := withClientCertificate(httptest.NewRequest(http.MethodGet, "/metrics", nil), "prometheus")
+			Expect(serve(provider, req).Code).To(Equal(http.StatusOK))
+
+			req = withClientCertificate(httptest.NewRequest(http.MethodGet, "/metrics", nil), "intruder")
+			Expect(serve(provider, req).Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("WithAuthorizer", func() {
+		It("should only let allowed requests pass", func() {
+			provider := WithAuthorizer(func(req *http.Request) (bool, string, error) {
+				if req.URL.Path == "/metrics" {
+					return true, "", nil
+				}
+				return false, "only metrics can be scraped", nil
+			})
+
+			Expect(serve(provider, httptest.NewRequest(http.MethodGet, "/metrics", nil)).Code).To(Equal(http.StatusOK))
+			resp := serve(provider, httptest.NewRequest(http.MethodGet, "/debug/pprof", nil))
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).To(ContainSubstring("only metrics can be scraped"))
+		})
+
+		It("should fail requests the authorizer returns an error for", func() {
+			provider := WithAuthorizer(func(*http.Request) (bool, string, error) {
+				return false, "", errors.New("boom")
+			})
+			Expect(serve(provider, httptest.NewRequest(http.MethodGet, "/metrics", nil)).Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should require an authorizer", func() {
+			_, err := WithAuthorizer(nil)(nil, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Chain", func() {
+		recording := func(name string, calls *[]string) metricsserver.FilterProvider {
+			return func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+				return func(_ logr.Logger, handler http.Handler) (http.Handler, error) {
+					return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+						*calls = append(*calls, name)
+						handler.ServeHTTP(w, req)
+					}), nil
+				}, nil
+			}
+		}
+
+		It("should apply the filters in order", func() {
+			var calls []string
+			provider := Chain(recording("first", &calls), recording("second", &calls))
+
+			resp := serve(provider, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(calls).To(Equal([]string{"first", "second"}))
+		})
+
+		It("should stop at the first filter that rejects the request", func() {
+			var calls []string
+			provider := Chain(WithBearerTokens("token"), recording("second", &calls))
+
+			resp := serve(provider, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+			Expect(calls).To(BeEmpty())
+		})
+
+		It("should return the errors of providers", func() {
+			provider := Chain(recording("first", new([]string)), func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+				return nil, errors.New("boom")
+			})
+			_, err := provider(nil, nil)
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+})
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// clientCAs provides the CAs used to verify client certificates, re-reading the
+// file whenever its content changes so that the CAs can be rotated.
+type clientCAs struct {
+	path string
+
+	mu   sync.Mutex
+	pem  []byte
+	pool *x509.CertPool
+}
+
+func newClientCAs(path string) (*clientCAs, error) {
+	c := &clientCAs{path: path}
+	if _, err := c.get(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// get returns the current CAs. If the file can't be read or doesn't contain any
+// certificate, the previously read CAs are kept and the error is returned.
+func (c *clientCAs) get() (*x509.CertPool, error) {
+	caPEM, err := os.ReadFile(c.path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		return c.pool, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	if c.pool != nil && bytes.Equal(caPEM, c.pem) {
+		return c.pool, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return c.pool, fmt.Errorf("client CA file %q doesn't contain any PEM encoded certificate", c.path)
+	}
+	if c.pool != nil {
+		log.Info("Reloaded client CA file", "path", c.path)
+	}
+	c.pem, c.pool = caPEM, pool
+	return pool, nil
+}
+
+// configForClient returns a GetConfigForClient func that uses the current CAs to
+// verify the client certificates of each connection.
+func (c *clientCAs) configForClient(cfg *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		pool, err := c.get()
+		if err != nil {
+			log.Error(err, "Failed to reload client CA file, using the previous CAs", "path", c.path)
+		}
+		clientCfg := cfg.Clone()
+		clientCfg.ClientCAs = pool
+		return clientCfg, nil
+	}
+}
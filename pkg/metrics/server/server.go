@@ -80,7 +80,15 @@ type Options struct {
 	// the metrics and the extra handlers on the metrics server.
 	// This can be e.g. used to enforce authentication and authorization on the handlers
 	// endpoint by setting this field to filters.WithAuthenticationAndAuthorization.
-	FilterProvider func(c *rest.Config, httpClient *http.Client) (Filter, error)
+	// Several filters can be combined with static.Chain from pkg/metrics/filters/static,
+	// e.g. to require a bearer token from static.WithBearerTokens in addition to a custom
+	// filter. The filters of that package don't depend on "k8s.io/apiserver".
+	FilterProvider FilterProvider
+
+	// Middlewares wrap the handler of the server, including the metrics and the extra
+	// handlers, e.g. to log requests or to add a request ID. They run before the filter
+	// of the FilterProvider. The first middleware is the outermost one.
+	Middlewares []func(http.Handler) http.Handler
 
 	// CertDir is the directory that contains the server key and certificate. Defaults to
 	// <temp-dir>/k8s-metrics-server/serving-certs.
@@ -101,6 +109,17 @@ type Options struct {
 	// Note: If certificate or key doesn't exist a self-signed certificate will be used.
 	KeyName string
 
+	// ClientCAFile is the path to a PEM encoded CA bundle that is used to verify the
+	// certificates of clients. Clients that present a certificate which isn't signed
+	// by one of these CAs are rejected during the TLS handshake. Clients without a
+	// certificate are still accepted, use static.WithClientCertificates from
+	// pkg/metrics/filters/static to require one.
+	// The file is re-read when a connection is accepted after its content changed, so the
+	// CAs can be rotated without restarting. If it can't be read, the previous CAs are used.
+	//
+	// Note: This option can only be used together with SecureServing.
+	ClientCAFile string
+
 	// TLSOpts is used to allow configuring the TLS config used for the server.
 	// This also allows providing a certificate via GetCertificate.
 	TLSOpts []func(*tls.Config)
@@ -112,6 +131,9 @@ type Options struct {
 // Filter is a func that is added around metrics and extra handlers on the metrics server.
 type Filter func(log logr.Logger, handler http.Handler) (http.Handler, error)
 
+// FilterProvider provides the Filter of the metrics server.
+type FilterProvider func(c *rest.Config, httpClient *http.Client) (Filter, error)
+
 // NewServer constructs a new metrics.Server from the provided options.
 func NewServer(o Options, config *rest.Config, httpClient *http.Client) (Server, error) {
 	o.setDefaults()
@@ -128,6 +150,10 @@ func NewServer(o Options, config *rest.Config, httpClient *http.Client) (Server,
 		}
 	}
 
+	if o.ClientCAFile != "" && !o.SecureServing {
+		return nil, fmt.Errorf("client CA file %q can only be used with secure serving", o.ClientCAFile)
+	}
+
 	// Create the metrics filter if a FilterProvider is set.
 	var metricsFilter Filter
 	if o.FilterProvider != nil {
@@ -246,7 +272,7 @@ func (s *defaultServer) Start(ctx context.Context) error {
 
 	log.Info("Serving metrics server", "bindAddress", s.options.BindAddress, "secure", s.options.SecureServing)
 
-	srv := httpserver.New(ctx, mux)
+	srv := httpserver.New(ctx, httpserver.WithMiddlewares(mux, s.options.Middlewares...))
 
 	idleConnsClosed := make(chan struct{})
 	go func() {
@@ -278,6 +304,15 @@ func (s *defaultServer) createListener(ctx context.Context, log logr.Logger) (ne
 	cfg := &tls.Config{
 		NextProtos: []string{"h2"},
 	}
+	var cas *clientCAs
+	if s.options.ClientCAFile != "" {
+		var err error
+		cas, err = newClientCAs(s.options.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
 	// fallback TLS config ready, will now mutate if passer wants full control over it
 	for _, op := range s.options.TLSOpts {
 		op(cfg)
@@ -325,6 +360,13 @@ func (s *defaultServer) createListener(ctx context.Context, log logr.Logger) (ne
 		cfg.Certificates = []tls.Certificate{keyPair}
 	}
 
+	// Verify client certificates with the current content of the client CA file, so that
+	// the CAs can be rotated without restarting. Configs from TLSOpts that pick their own
+	// config per client take precedence.
+	if cas != nil && cfg.GetConfigForClient == nil {
+		cfg.GetConfigForClient = cas.configForClient(cfg.Clone())
+	}
+
 	l, err := s.options.ListenConfig.Listen(ctx, "tcp", s.options.BindAddress)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	internalcontroller "sigs.k8s.io/controller-runtime/pkg/internal/controller"
+)
+
+// Controller is a controller that reconciles the Requests of all engaged clusters.
+type Controller interface {
+	controller.TypedController[Request]
+	Aware
+
+	// MultiClusterWatch watches the sources that src creates for every engaged
+	// cluster, including clusters that are engaged later on.
+	MultiClusterWatch(src Source) error
+}
+
+type mcController struct {
+	*internalcontroller.Controller[Request]
+
+	// mu guards sources and clusters.
+	mu       sync.Mutex
+	sources  []Source
+	clusters map[string]*engagement
+}
+
+// engagement is a cluster that is engaged with a controller and the sources the
+// controller watches for it.
+type engagement struct {
+	ctx     context.Context
+	cluster cluster.Cluster
+	sources []*clusterSource
+}
+
+var _ Controller = &mcController{}
+
+// NewController returns a new multi-cluster controller added to the Manager, which
+// engages it with all clusters of its Provider. Options are defaulted like the
+// options of controller.NewTyped, and the default LogConstructor adds the cluster
+// name to the logger of every reconciliation.
+func NewController(name string, mgr Manager, options controller.TypedOptions[Request]) (Controller, error) {
+	maxConcurrentReconcilesFromConfig := options.MaxConcurrentReconciles <= 0
+	options.DefaultFromConfig(mgr.GetControllerOptions())
+	if options.LogConstructor == nil {
+		log := options.Logger.WithValues("controller", name)
+		options.LogConstructor = func(req *Request) logr.Logger {
+			if req == nil {
+				return log
+			}
+			return log.WithValues(
+				"cluster", req.ClusterName,
+				"object", klog.KRef(req.Namespace, req.Name),
+				"namespace", req.Namespace, "name", req.Name,
+			)
+		}
+	}
+
+	c, err := controller.NewTypedUnmanaged(name, options)
+	if err != nil {
+		return nil, err
+	}
+	ctrl, ok := c.(*internalcontroller.Controller[Request])
+	if !ok {
+		return nil, fmt.Errorf("unexpected controller type %T", c)
+	}
+	ctrl.MaxConcurrentReconcilesFromConfig = maxConcurrentReconcilesFromConfig
+	mc := &mcController{Controller: ctrl, clusters: map[string]*engagement{}}
+
+	if check := controller.InitialReconcileChecker(c); check != nil {
+		if err := controller.AddReadyzCheck(mgr, c, name+"-initial-reconcile", check); err != nil {
+			return nil, err
+		}
+	}
+	return mc, mgr.Add(mc)
+}
+
+// Engage implements Aware.
+func (c *mcController) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.clusters[name]; ok && e.ctx.Err() == nil {
+		return nil
+	}
+	e := &engagement{ctx: ctx, cluster: cl}
+	for _, src := range c.sources {
+		if err := c.watchLocked(name, e, src); err != nil {
+			return err
+		}
+	}
+	c.clusters[name] = e
+
+	context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.clusters[name] == e {
+			delete(c.clusters, name)
+		}
+		// The sources stop on their own, don't start them again on restarts.
+		for _, src := range e.sources {
+			c.Controller.RemoveWatch(src)
+		}
+	})
+	return nil
+}
+
+// MultiClusterWatch implements Controller.
+func (c *mcController) MultiClusterWatch(src Source) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sources = append(c.sources, src)
+	for name, e := range c.clusters {
+		if e.ctx.Err() != nil {
+			continue
+		}
+		if err := c.watchLocked(name, e, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mcController) watchLocked(name string, e *engagement, src Source) error {
+	s, err := src.ForCluster(name, e.cluster)
+	if err != nil {
+		return fmt.Errorf("failed to create source for cluster %s: %w", name, err)
+	}
+	cs := &clusterSource{clusterName: name, engaged: e.ctx, source: s}
+	if err := c.Controller.Watch(cs); err != nil {
+		return fmt.Errorf("failed to watch source for cluster %s: %w", name, err)
+	}
+	e.sources = append(e.sources, cs)
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package multicluster runs controllers against a dynamic set of clusters.
+
+A Provider discovers clusters, e.g. from a cluster inventory, and engages every cluster
+it discovered with the Manager returned by New, which in turn engages it with all
+multi-cluster controllers. Clusters are disengaged once the context they were engaged
+with is done, e.g. when the Provider noticed that they were removed.
+
+Controllers created with NewController reconcile Requests, which carry the name of the
+cluster of the object. Their sources are created for every engaged cluster through a
+Source, e.g. Kind, and stopped once the cluster is disengaged:
+
+	mcMgr, err := multicluster.New(mgr, provider)
+	...
+	c, err := multicluster.NewController("pods", mcMgr, controller.TypedOptions[multicluster.Request]{
+		Reconciler: reconcile.TypedFunc[multicluster.Request](func(ctx context.Context, req multicluster.Request) (reconcile.Result, error) {
+			cl, err := mcMgr.GetCluster(ctx, req.ClusterName)
+			...
+		}),
+	})
+	...
+	err = c.MultiClusterWatch(multicluster.Kind(&corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}))
+
+Objects created in one cluster for an object of another cluster can't reference it in
+their ownerReferences. SetClusterOwner records the owner in a label and an annotation
+instead, and a Janitor created with NewJanitor deletes the objects whose owner is gone.
+*/
+package multicluster
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("multicluster")
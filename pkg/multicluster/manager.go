@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Manager is a manager.Manager that engages the clusters of a Provider with all
+// multi-cluster components that are added to it.
+type Manager interface {
+	manager.Manager
+	Aware
+
+	// GetCluster returns an engaged cluster, or the cluster from the Provider if it
+	// isn't engaged. The empty name returns the cluster of the Manager itself.
+	GetCluster(ctx context.Context, clusterName string) (cluster.Cluster, error)
+
+	// GetProvider returns the Provider of the Manager.
+	GetProvider() Provider
+}
+
+type mcManager struct {
+	manager.Manager
+	provider Provider
+
+	// mu guards engaged and awares, so that every cluster is engaged with
+	// every Aware runnable exactly once.
+	mu      sync.Mutex
+	engaged map[string]*engagedCluster
+	awares  []Aware
+}
+
+type engagedCluster struct {
+	ctx     context.Context
+	cluster cluster.Cluster
+}
+
+var _ Manager = &mcManager{}
+
+// New returns a Manager that engages the clusters of the given Provider with the
+// multi-cluster components that are added to mgr through it. If the Provider is a
+// ProviderRunnable, it is added to mgr and started with it on all replicas,
+// regardless of leader election.
+func New(mgr manager.Manager, provider Provider) (Manager, error) {
+	if provider == nil {
+		return nil, errors.New("must specify a Provider")
+	}
+	m := &mcManager{
+		Manager:  mgr,
+		provider: provider,
+		engaged:  map[string]*engagedCluster{},
+	}
+	if runnable, ok := provider.(ProviderRunnable); ok {
+		if err := mgr.Add(&providerRunnable{provider: runnable, aware: m}); err != nil {
+			return nil, fmt.Errorf("failed to add provider: %w", err)
+		}
+	}
+	return m, nil
+}
+
+// Add adds the runnable to the Manager. If it is Aware, all engaged clusters are
+// engaged with it once it was added, and all clusters that are engaged later on.
+func (m *mcManager) Add(r manager.Runnable) error {
+	if err := m.Manager.Add(r); err != nil {
+		return err
+	}
+	aware, ok := r.(Aware)
+	if !ok {
+		return nil
+	}
+
+	m.mu.Lock()
+	m.awares = append(m.awares, aware)
+	engaged := make(map[string]*engagedCluster, len(m.engaged))
+	for name, ec := range m.engaged {
+		engaged[name] = ec
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for name, ec := range engaged {
+		if err := aware.Engage(ec.ctx, name, ec.cluster); err != nil {
+			errs = append(errs, fmt.Errorf("failed to engage cluster %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Engage implements Aware. The cluster is engaged with all Aware runnables of the
+// Manager, including the ones added later on, until ctx is done.
+func (m *mcManager) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	if name == "" {
+		return errors.New("the empty cluster name is reserved for the cluster of the Manager")
+	}
+
+	m.mu.Lock()
+	if _, ok := m.engaged[name]; ok {
+		m.mu.Unlock()
+		return nil
+	}
+	ec := &engagedCluster{ctx: ctx, cluster: cl}
+	m.engaged[name] = ec
+	awares := append([]Aware(nil), m.awares...)
+	m.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.engaged[name] == ec {
+			delete(m.engaged, name)
+		}
+	})
+
+	var errs []error
+	for _, aware := range awares {
+		errs = append(errs, aware.Engage(ctx, name, cl))
+	}
+	return errors.Join(errs...)
+}
+
+// GetCluster implements Manager.
+func (m *mcManager) GetCluster(ctx context.Context, name string) (cluster.Cluster, error) {
+	if name == "" {
+		return m.Manager, nil
+	}
+	m.mu.Lock()
+	ec, ok := m.engaged[name]
+	m.mu.Unlock()
+	if ok {
+		return ec.cluster, nil
+	}
+	return m.provider.Get(ctx, name)
+}
+
+// GetProvider implements Manager.
+func (m *mcManager) GetProvider() Provider {
+	return m.provider
+}
+
+// providerRunnable runs a ProviderRunnable as part of the Manager.
+type providerRunnable struct {
+	provider ProviderRunnable
+	aware    Aware
+}
+
+func (p *providerRunnable) Start(ctx context.Context) error {
+	return p.provider.Start(ctx, p.aware)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Clusters are engaged
+// on all replicas, so that the sources of controllers are ready once a replica becomes
+// leader.
+func (p *providerRunnable) NeedLeaderElection() bool {
+	return false
+}
+
+// RunnableName implements manager.NamedRunnable.
+func (p *providerRunnable) RunnableName() string {
+	return "multicluster-provider"
+}
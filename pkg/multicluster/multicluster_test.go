@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Multicluster", func() {
+	var (
+		mgr      *fakeManager
+		provider *Clusters
+		mcMgr    Manager
+	)
+
+	BeforeEach(func() {
+		mgr = &fakeManager{}
+		provider = NewClusters()
+		var err error
+		mcMgr, err = New(mgr, provider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.runnables).To(HaveLen(1))
+	})
+
+	Describe("Request", func() {
+		It("should include the cluster name in its string", func() {
+			req := Request{Request: reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "foo"}}}
+			Expect(req.String()).To(Equal("ns/foo"))
+			req.ClusterName = "one"
+			Expect(req.String()).To(Equal("cluster://one/ns/foo"))
+		})
+	})
+
+	Describe("Manager", func() {
+		It("should engage clusters with Aware runnables added before and after", func(ctx SpecContext) {
+			before := &recordingAware{}
+			Expect(mcMgr.Add(before)).To(Succeed())
+
+			clusterCtx, cancel := context.WithCancel(ctx)
+			one := newFakeCluster()
+			Expect(mcMgr.Engage(clusterCtx, "one", one)).To(Succeed())
+			Expect(before.engaged()).To(ConsistOf("one"))
+
+			after := &recordingAware{}
+			Expect(mcMgr.Add(after)).To(Succeed())
+			Expect(after.engaged()).To(ConsistOf("one"))
+
+			cl, err := mcMgr.GetCluster(ctx, "one")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl).To(BeIdenticalTo(one))
+
+			cancel()
+			Eventually(func() error {
+				_, err := mcMgr.GetCluster(ctx, "one")
+				return err
+			}).Should(MatchError(ErrClusterNotFound))
+
+			late := &recordingAware{}
+			Expect(mcMgr.Add(late)).To(Succeed())
+			Expect(late.engaged()).To(BeEmpty())
+		})
+
+		It("should not engage Aware runnables that the Manager failed to add", func(ctx SpecContext) {
+			Expect(mcMgr.Engage(ctx, "one", newFakeCluster())).To(Succeed())
+
+			mgr.addErr = errors.New("expected error")
+			failed := &recordingAware{}
+			Expect(mcMgr.Add(failed)).To(MatchError(mgr.addErr))
+			Expect(failed.engaged()).To(BeEmpty())
+
+			Expect(mcMgr.Engage(ctx, "two", newFakeCluster())).To(Succeed())
+			Expect(failed.engaged()).To(BeEmpty())
+		})
+
+		It("should return itself for the empty cluster name", func(ctx SpecContext) {
+			cl, err := mcMgr.GetCluster(ctx, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl).To(BeIdenticalTo(mgr))
+			Expect(mcMgr.Engage(ctx, "", newFakeCluster())).NotTo(Succeed())
+		})
+	})
+
+	Describe("Clusters", func() {
+		It("should start, engage and disengage clusters", func(ctx SpecContext) {
+			aware := &recordingAware{}
+			early := newFakeCluster()
+			Expect(provider.Add("early", early)).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(provider.Start(ctx, aware)).To(Succeed())
+			}()
+			Eventually(aware.engaged).Should(ConsistOf("early"))
+			Eventually(early.started).Should(BeClosed())
+
+			late := newFakeCluster()
+			Expect(provider.Add("late", late)).To(Succeed())
+			Expect(aware.engaged()).To(ConsistOf("early", "late"))
+			Eventually(late.started).Should(BeClosed())
+
+			provider.Remove("early")
+			Eventually(early.stopped).Should(BeClosed())
+			Expect(aware.context("early").Err()).To(HaveOccurred())
+			Expect(aware.context("late").Err()).NotTo(HaveOccurred())
+
+			_, err := provider.Get(ctx, "early")
+			Expect(err).To(MatchError(ErrClusterNotFound))
+			cl, err := provider.Get(ctx, "late")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl).To(BeIdenticalTo(late))
+		})
+	})
+
+	Describe("Controller", func() {
+		It("should reconcile the objects of all engaged clusters", func(ctx SpecContext) {
+			reconciled := make(chan Request, 10)
+			c, err := NewController("multicluster", mcMgr, controller.TypedOptions[Request]{
+				Reconciler: reconcile.TypedFunc[Request](func(_ context.Context, req Request) (reconcile.Result, error) {
+					reconciled <- req
+					return reconcile.Result{}, nil
+				}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mgr.runnables).To(ContainElement(c))
+			Expect(c.MultiClusterWatch(Kind(&corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}))).To(Succeed())
+
+			one, two := newFakeCluster(), newFakeCluster()
+			oneInformer := one.informer(ctx)
+			twoInformer := two.informer(ctx)
+			oneCtx, disengageOne := context.WithCancel(ctx)
+			Expect(mcMgr.Engage(oneCtx, "one", one)).To(Succeed())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(c.Start(ctx)).To(Succeed())
+			}()
+			Eventually(oneInformer.HandlerCount).Should(Equal(1))
+
+			// Clusters engaged while the controller is running are watched as well.
+			Expect(mcMgr.Engage(ctx, "two", two)).To(Succeed())
+			Eventually(twoInformer.HandlerCount).Should(Equal(1))
+
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+			oneInformer.Add(pod)
+			Eventually(reconciled).Should(Receive(Equal(request("one", pod))))
+			twoInformer.Add(pod)
+			Eventually(reconciled).Should(Receive(Equal(request("two", pod))))
+
+			By("disengaging a cluster")
+			disengageOne()
+			Eventually(oneInformer.HandlerCount).Should(Equal(0))
+			Expect(twoInformer.HandlerCount()).To(Equal(1))
+		})
+
+		It("should add the initial reconcile readiness check", func() {
+			_, err := NewController("multicluster-ready", mcMgr, controller.TypedOptions[Request]{
+				Reconciler: reconcile.TypedFunc[Request](func(context.Context, Request) (reconcile.Result, error) {
+					return reconcile.Result{}, nil
+				}),
+				ReadyAfterInitialReconcile: new(true),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mgr.readyzChecks).To(HaveKey("multicluster-ready-initial-reconcile"))
+		})
+	})
+})
+
+func request(clusterName string, obj metav1.Object) Request {
+	return Request{
+		ClusterName: clusterName,
+		Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}},
+	}
+}
+
+// fakeManager records the runnables and checks that are added to it.
+type fakeManager struct {
+	manager.Manager
+
+	mu           sync.Mutex
+	runnables    []manager.Runnable
+	readyzChecks map[string]healthz.Checker
+	addErr       error
+}
+
+func (m *fakeManager) Add(r manager.Runnable) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+func (m *fakeManager) AddReadyzCheck(name string, check healthz.Checker) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.readyzChecks == nil {
+		m.readyzChecks = map[string]healthz.Checker{}
+	}
+	m.readyzChecks[name] = check
+	return nil
+}
+
+func (m *fakeManager) GetControllerOptions() config.Controller {
+	return config.Controller{SkipNameValidation: new(true)}
+}
+
+// fakeCluster is a cluster backed by fake informers.
+type fakeCluster struct {
+	cluster.Cluster
+
+	cache   *informertest.FakeInformers
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func newFakeCluster() *fakeCluster {
+	return &fakeCluster{
+		cache:   &informertest.FakeInformers{},
+		started: make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (c *fakeCluster) GetCache() cache.Cache {
+	return c.cache
+}
+
+func (c *fakeCluster) Start(ctx context.Context) error {
+	close(c.started)
+	<-ctx.Done()
+	close(c.stopped)
+	return nil
+}
+
+// informer creates the fake Pod informer before sources use it.
+func (c *fakeCluster) informer(ctx context.Context) *controllertest.FakeInformer {
+	informer, err := c.cache.FakeInformerFor(ctx, &corev1.Pod{})
+	Expect(err).NotTo(HaveOccurred())
+	return informer
+}
+
+// recordingAware records the clusters it is engaged with.
+type recordingAware struct {
+	mu       sync.Mutex
+	clusters map[string]context.Context
+}
+
+func (a *recordingAware) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.clusters == nil {
+		a.clusters = map[string]context.Context{}
+	}
+	a.clusters[name] = ctx
+	return nil
+}
+
+func (a *recordingAware) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (a *recordingAware) engaged() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var names []string
+	for name, ctx := range a.clusters {
+		if ctx.Err() == nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (a *recordingAware) context(name string) context.Context {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.clusters[name]
+}
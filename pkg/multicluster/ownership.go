@@ -0,0 +1,327 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// OwnerUIDLabel is set on objects that are owned by an object in another cluster,
+	// see SetClusterOwner. Its value is the UID of the owner.
+	OwnerUIDLabel = "multicluster.controller-runtime.io/owner-uid"
+
+	// OwnerAnnotation holds the ClusterOwnerReference of objects that are owned by an
+	// object in another cluster as JSON.
+	OwnerAnnotation = "multicluster.controller-runtime.io/owner"
+
+	defaultJanitorInterval = 5 * time.Minute
+)
+
+var (
+	// orphansDetected is a prometheus counter metric which holds the total number of
+	// objects whose owner in another cluster was found to be gone, by cluster.
+	orphansDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_multicluster_orphans_detected_total",
+		Help: "Total number of objects whose owner in another cluster is gone per cluster",
+	}, []string{"cluster"})
+
+	// orphansDeleted is a prometheus counter metric which holds the total number of
+	// orphaned objects that were deleted, by cluster.
+	orphansDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_multicluster_orphans_deleted_total",
+		Help: "Total number of deleted objects whose owner in another cluster is gone per cluster",
+	}, []string{"cluster"})
+
+	// janitorErrors is a prometheus counter metric which holds the total number of
+	// errors of the janitor, by cluster.
+	janitorErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_multicluster_janitor_errors_total",
+		Help: "Total number of errors while collecting orphaned objects per cluster",
+	}, []string{"cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphansDetected, orphansDeleted, janitorErrors)
+}
+
+// ClusterOwnerReference references the owner of an object in another cluster, which
+// ownerReferences can't do.
+type ClusterOwnerReference struct {
+	// ClusterName is the name of the cluster of the owner. The empty name is the
+	// cluster of the Manager.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+}
+
+// SetClusterOwner records owner, an object of the cluster with the given name, as the
+// owner of obj, which is created in another cluster, in the OwnerUIDLabel and
+// OwnerAnnotation of obj. A Janitor deletes obj once owner is gone. It replaces a
+// previous owner. owner must have been created already, so that it has a UID.
+func SetClusterOwner(clusterName string, owner, obj client.Object, scheme *runtime.Scheme) error {
+	if owner.GetUID() == "" {
+		return fmt.Errorf("owner %s has no UID", client.ObjectKeyFromObject(owner))
+	}
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return err
+	}
+	ref, err := json.Marshal(ClusterOwnerReference{
+		ClusterName: clusterName,
+		APIVersion:  gvk.GroupVersion().String(),
+		Kind:        gvk.Kind,
+		Namespace:   owner.GetNamespace(),
+		Name:        owner.GetName(),
+		UID:         owner.GetUID(),
+	})
+	if err != nil {
+		return err
+	}
+
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	objLabels[OwnerUIDLabel] = string(owner.GetUID())
+	obj.SetLabels(objLabels)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[OwnerAnnotation] = string(ref)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// GetClusterOwner returns the owner of obj in another cluster that was set with
+// SetClusterOwner, or nil if it has none.
+func GetClusterOwner(obj client.Object) (*ClusterOwnerReference, error) {
+	data, ok := obj.GetAnnotations()[OwnerAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	ref := &ClusterOwnerReference{}
+	if err := json.Unmarshal([]byte(data), ref); err != nil {
+		return nil, fmt.Errorf("failed to decode annotation %s: %w", OwnerAnnotation, err)
+	}
+	return ref, nil
+}
+
+// JanitorOptions are the options of a Janitor.
+type JanitorOptions struct {
+	// Children are the types of the objects that are owned by objects in other
+	// clusters, e.g. &corev1.ConfigMap{}. Required.
+	Children []client.Object
+
+	// Interval is the time between two collections. Defaults to 5 minutes.
+	Interval time.Duration
+}
+
+// Janitor deletes objects in the engaged clusters whose owner in another cluster,
+// set with SetClusterOwner, is gone. It is the garbage collection for ownership across
+// clusters.
+//
+// Objects whose owner can't be read are kept, including the ones whose owner is in a
+// cluster that isn't known to the Provider anymore, as it can't be told whether the
+// owner still exists. The detected and deleted orphans and the errors are counted per
+// cluster by the controller_runtime_multicluster_orphans_detected_total,
+// controller_runtime_multicluster_orphans_deleted_total and
+// controller_runtime_multicluster_janitor_errors_total metrics.
+type Janitor struct {
+	mgr  Manager
+	opts JanitorOptions
+
+	mu       sync.Mutex
+	clusters map[string]cluster.Cluster
+}
+
+// NewJanitor returns a Janitor for the clusters engaged with mgr and adds it to mgr.
+// It runs on the leader only.
+func NewJanitor(mgr Manager, opts JanitorOptions) (*Janitor, error) {
+	if len(opts.Children) == 0 {
+		return nil, errors.New("must specify the types of the children")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultJanitorInterval
+	}
+	j := &Janitor{
+		mgr:      mgr,
+		opts:     opts,
+		clusters: map[string]cluster.Cluster{},
+	}
+	if err := mgr.Add(j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Engage implements Aware.
+func (j *Janitor) Engage(ctx context.Context, clusterName string, cl cluster.Cluster) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, ok := j.clusters[clusterName]; ok {
+		return nil
+	}
+	j.clusters[clusterName] = cl
+	context.AfterFunc(ctx, func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if j.clusters[clusterName] == cl {
+			delete(j.clusters, clusterName)
+		}
+	})
+	return nil
+}
+
+// Start collects orphans every Interval until ctx is done.
+func (j *Janitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(j.opts.Interval)
+	defer ticker.Stop()
+	for {
+		j.Collect(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (j *Janitor) NeedLeaderElection() bool {
+	return true
+}
+
+// RunnableName implements manager.NamedRunnable.
+func (j *Janitor) RunnableName() string {
+	return "multicluster-janitor"
+}
+
+// Collect deletes the orphans in all engaged clusters once. Errors are logged and
+// counted, the objects that failed are retried by the next collection.
+func (j *Janitor) Collect(ctx context.Context) {
+	j.mu.Lock()
+	clusters := make(map[string]cluster.Cluster, len(j.clusters))
+	for name, cl := range j.clusters {
+		clusters[name] = cl
+	}
+	j.mu.Unlock()
+
+	for name, cl := range clusters {
+		for _, child := range j.opts.Children {
+			if err := j.collect(ctx, name, cl, child); err != nil {
+				janitorErrors.WithLabelValues(name).Inc()
+				log.Error(err, "Failed to collect orphans", "cluster", name, "type", fmt.Sprintf("%T", child))
+			}
+		}
+	}
+}
+
+func (j *Janitor) collect(ctx context.Context, clusterName string, cl cluster.Cluster, child client.Object) error {
+	gvk, err := apiutil.GVKForObject(child, cl.GetScheme())
+	if err != nil {
+		return err
+	}
+	hasOwner, err := labels.NewRequirement(OwnerUIDLabel, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := cl.GetAPIReader().List(ctx, list, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*hasOwner)}); err != nil {
+		return fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+	}
+
+	var errs []error
+	for i := range list.Items {
+		obj := &list.Items[i]
+		obj.SetGroupVersionKind(gvk)
+		log := log.WithValues("cluster", clusterName, "kind", gvk.Kind, "object", client.ObjectKeyFromObject(obj))
+
+		orphaned, err := j.orphaned(ctx, obj)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check the owner of %s: %w", client.ObjectKeyFromObject(obj), err))
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		orphansDetected.WithLabelValues(clusterName).Inc()
+		uid := obj.GetUID()
+		err = cl.GetClient().Delete(ctx, obj, client.Preconditions{UID: &uid}, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete orphan %s: %w", client.ObjectKeyFromObject(obj), err))
+			continue
+		}
+		orphansDeleted.WithLabelValues(clusterName).Inc()
+		log.Info("Deleted object whose owner in another cluster is gone")
+	}
+	return errors.Join(errs...)
+}
+
+// orphaned returns whether the owner of obj is gone.
+func (j *Janitor) orphaned(ctx context.Context, obj client.Object) (bool, error) {
+	ref, err := GetClusterOwner(obj)
+	if err != nil || ref == nil {
+		// Objects whose label was set without the annotation are not ours to delete.
+		return false, err
+	}
+	ownerCluster, err := j.mgr.GetCluster(ctx, ref.ClusterName)
+	if err != nil {
+		return false, err
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	owner := &metav1.PartialObjectMetadata{}
+	owner.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	err = ownerCluster.GetAPIReader().Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, owner)
+	switch {
+	case apierrors.IsNotFound(err):
+		return true, nil
+	case err != nil:
+		return false, err
+	default:
+		return owner.GetUID() != ref.UID, nil
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// managerWithClient is a fakeManager whose cluster is backed by a client.
+type managerWithClient struct {
+	*fakeManager
+	client client.Client
+}
+
+func (m *managerWithClient) GetAPIReader() client.Reader { return m.client }
+
+// clusterWithClient is a fakeCluster backed by a client.
+type clusterWithClient struct {
+	*fakeCluster
+	client client.Client
+}
+
+func (c *clusterWithClient) GetClient() client.Client    { return c.client }
+func (c *clusterWithClient) GetAPIReader() client.Reader { return c.client }
+func (c *clusterWithClient) GetScheme() *runtime.Scheme  { return scheme.Scheme }
+
+var _ = Describe("Cross-cluster ownership", func() {
+	configMap := func(name string, uid types.UID) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: uid}}
+	}
+
+	It("should set and get the owner in another cluster", func() {
+		child := configMap("child", "")
+		Expect(SetClusterOwner("hub", configMap("owner", "uid"), child, scheme.Scheme)).To(Succeed())
+		Expect(child.Labels).To(HaveKeyWithValue(OwnerUIDLabel, "uid"))
+
+		ref, err := GetClusterOwner(child)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).To(Equal(&ClusterOwnerReference{
+			ClusterName: "hub", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "owner", UID: "uid",
+		}))
+
+		ref, err = GetClusterOwner(configMap("other", ""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ref).To(BeNil())
+
+		Expect(SetClusterOwner("hub", configMap("owner", ""), child, scheme.Scheme)).To(MatchError(ContainSubstring("has no UID")))
+	})
+
+	It("should delete children whose owner in another cluster is gone", func(ctx SpecContext) {
+		owner := configMap("owner", "owner-uid")
+		hub := fake.NewClientBuilder().WithObjects(owner).Build()
+		mcMgr, err := New(&managerWithClient{fakeManager: &fakeManager{}, client: hub}, NewClusters())
+		Expect(err).NotTo(HaveOccurred())
+
+		owned := func(name string, ownerCluster string, owner *corev1.ConfigMap) *corev1.ConfigMap {
+			child := configMap(name, types.UID(name+"-uid"))
+			Expect(SetClusterOwner(ownerCluster, owner, child, scheme.Scheme)).To(Succeed())
+			return child
+		}
+		spoke := fake.NewClientBuilder().WithObjects(
+			owned("kept", "", owner),
+			owned("owner-deleted", "", configMap("deleted", "deleted-uid")),
+			owned("owner-recreated", "", configMap("owner", "old-owner-uid")),
+			owned("owner-cluster-unknown", "removed", owner),
+			configMap("unowned", "unowned-uid"),
+		).Build()
+		Expect(mcMgr.Engage(ctx, "spoke", &clusterWithClient{fakeCluster: newFakeCluster(), client: spoke})).To(Succeed())
+
+		janitor, err := NewJanitor(mcMgr, JanitorOptions{Children: []client.Object{&corev1.ConfigMap{}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(janitor.NeedLeaderElection()).To(BeTrue())
+
+		deletedBefore := testutil.ToFloat64(orphansDeleted.WithLabelValues("spoke"))
+		errorsBefore := testutil.ToFloat64(janitorErrors.WithLabelValues("spoke"))
+		janitor.Collect(ctx)
+
+		remaining := &corev1.ConfigMapList{}
+		Expect(spoke.List(ctx, remaining)).To(Succeed())
+		var names []string
+		for _, cm := range remaining.Items {
+			names = append(names, cm.Name)
+		}
+		Expect(names).To(ConsistOf("kept", "owner-cluster-unknown", "unowned"))
+		Expect(testutil.ToFloat64(orphansDeleted.WithLabelValues("spoke")) - deletedBefore).To(BeEquivalentTo(2))
+		Expect(testutil.ToFloat64(janitorErrors.WithLabelValues("spoke")) - errorsBefore).To(BeEquivalentTo(1))
+	})
+
+	It("should require the types of the children", func() {
+		mcMgr, err := New(&fakeManager{}, NewClusters())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = NewJanitor(mcMgr, JanitorOptions{})
+		Expect(err).To(MatchError(ContainSubstring("must specify the types of the children")))
+	})
+})
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ErrClusterNotFound is returned by Providers if a cluster isn't known.
+var ErrClusterNotFound = errors.New("cluster not found")
+
+// Request is a reconcile.Request for an object of a cluster.
+type Request struct {
+	// ClusterName is the name of the cluster of the object. The empty name is the
+	// cluster of the Manager itself.
+	ClusterName string
+
+	reconcile.Request
+}
+
+// String returns the cluster name and the namespace and name of the object.
+func (r Request) String() string {
+	if r.ClusterName == "" {
+		return r.Request.String()
+	}
+	return fmt.Sprintf("cluster://%s/%s", r.ClusterName, r.Request.String())
+}
+
+// Aware is implemented by components that run against all engaged clusters, like the
+// Manager returned by New and controllers created with NewController.
+type Aware interface {
+	// Engage starts running against the cluster until ctx is done. The cluster must have
+	// been started by the caller, which cancels ctx once the cluster is gone. Engaging a
+	// cluster that is already engaged is a no-op.
+	Engage(ctx context.Context, clusterName string, cl cluster.Cluster) error
+}
+
+// Provider gives access to clusters by their name.
+type Provider interface {
+	// Get returns the cluster with the given name, or an error wrapping
+	// ErrClusterNotFound if it isn't known.
+	Get(ctx context.Context, clusterName string) (cluster.Cluster, error)
+}
+
+// ProviderRunnable is implemented by Providers that discover clusters.
+type ProviderRunnable interface {
+	// Start discovers clusters until ctx is done. It starts every discovered cluster
+	// and engages it with aware, and cancels the context it was engaged with once
+	// the cluster is removed.
+	Start(ctx context.Context, aware Aware) error
+}
+
+// Clusters is a Provider for clusters that are added and removed explicitly, e.g.
+// by a controller that watches objects describing clusters.
+type Clusters struct {
+	mu       sync.Mutex
+	aware    Aware
+	ctx      context.Context
+	clusters map[string]*providedCluster
+}
+
+type providedCluster struct {
+	cluster cluster.Cluster
+	cancel  context.CancelFunc
+}
+
+var _ ProviderRunnable = &Clusters{}
+
+// NewClusters returns a new Clusters provider.
+func NewClusters() *Clusters {
+	return &Clusters{clusters: map[string]*providedCluster{}}
+}
+
+// Start implements ProviderRunnable. Clusters that were added before are started
+// and engaged with aware.
+func (p *Clusters) Start(ctx context.Context, aware Aware) error {
+	p.mu.Lock()
+	if p.aware != nil {
+		p.mu.Unlock()
+		return errors.New("provider was started more than once")
+	}
+	p.aware = aware
+	p.ctx = ctx
+	clusters := make(map[string]cluster.Cluster, len(p.clusters))
+	for name, pc := range p.clusters {
+		clusters[name] = pc.cluster
+	}
+	p.mu.Unlock()
+
+	for name, cl := range clusters {
+		if err := p.run(name, cl); err != nil {
+			log.Error(err, "Failed to run cluster", "cluster", name)
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// Add adds a cluster, which is started and engaged right away if the provider is
+// started. Adding a cluster with the name of an existing cluster replaces it.
+func (p *Clusters) Add(name string, cl cluster.Cluster) error {
+	p.Remove(name)
+
+	p.mu.Lock()
+	p.clusters[name] = &providedCluster{cluster: cl}
+	started := p.aware != nil
+	p.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+	return p.run(name, cl)
+}
+
+// Remove removes a cluster. It is disengaged and stopped.
+func (p *Clusters) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.clusters[name]; ok {
+		if pc.cancel != nil {
+			pc.cancel()
+		}
+		delete(p.clusters, name)
+	}
+}
+
+// Get implements Provider.
+func (p *Clusters) Get(_ context.Context, name string) (cluster.Cluster, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.clusters[name]; ok {
+		return pc.cluster, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+}
+
+// run starts and engages the cluster until it is removed or the provider is stopped.
+func (p *Clusters) run(name string, cl cluster.Cluster) error {
+	p.mu.Lock()
+	pc, ok := p.clusters[name]
+	if !ok || pc.cluster != cl {
+		// The cluster was removed or replaced meanwhile.
+		p.mu.Unlock()
+		return nil
+	}
+	ctx, cancel := context.WithCancel(p.ctx)
+	pc.cancel = cancel
+	aware := p.aware
+	p.mu.Unlock()
+
+	go func() {
+		if err := cl.Start(ctx); err != nil {
+			log.Error(err, "Cluster stopped with an error", "cluster", name)
+		}
+	}()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return fmt.Errorf("failed waiting for the cache of cluster %s to sync", name)
+	}
+	if err := aware.Engage(ctx, name, cl); err != nil {
+		cancel()
+		return fmt.Errorf("failed to engage cluster %s: %w", name, err)
+	}
+	return nil
+}
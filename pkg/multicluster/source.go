@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Source creates the sources of a controller for every engaged cluster.
+type Source interface {
+	// ForCluster returns the source for the given cluster.
+	ForCluster(clusterName string, cl cluster.Cluster) (source.TypedSource[Request], error)
+}
+
+// SourceFunc is a function that implements Source.
+type SourceFunc func(clusterName string, cl cluster.Cluster) (source.TypedSource[Request], error)
+
+// ForCluster implements Source.
+func (f SourceFunc) ForCluster(clusterName string, cl cluster.Cluster) (source.TypedSource[Request], error) {
+	return f(clusterName, cl)
+}
+
+// Kind returns a Source for events of objects in the cache of every engaged cluster, see
+// source.Kind. The handler enqueues reconcile.Requests, which are turned into Requests
+// for the cluster the event originated from.
+func Kind[object client.Object](
+	obj object,
+	h handler.TypedEventHandler[object, reconcile.Request],
+	predicates ...predicate.TypedPredicate[object],
+) Source {
+	return SourceFunc(func(clusterName string, cl cluster.Cluster) (source.TypedSource[Request], error) {
+		return source.TypedKind(cl.GetCache(), obj, ForCluster(clusterName, h), predicates...), nil
+	})
+}
+
+// ForCluster turns an event handler that enqueues reconcile.Requests into one that
+// enqueues Requests for the given cluster.
+func ForCluster[object any](clusterName string, h handler.TypedEventHandler[object, reconcile.Request]) handler.TypedEventHandler[object, Request] {
+	return &clusterHandler[object]{clusterName: clusterName, handler: h}
+}
+
+type clusterHandler[object any] struct {
+	clusterName string
+	handler     handler.TypedEventHandler[object, reconcile.Request]
+}
+
+func (h *clusterHandler[object]) Create(ctx context.Context, evt event.TypedCreateEvent[object], q workqueue.TypedRateLimitingInterface[Request]) {
+	h.handler.Create(ctx, evt, h.queue(q))
+}
+
+func (h *clusterHandler[object]) Update(ctx context.Context, evt event.TypedUpdateEvent[object], q workqueue.TypedRateLimitingInterface[Request]) {
+	h.handler.Update(ctx, evt, h.queue(q))
+}
+
+func (h *clusterHandler[object]) Delete(ctx context.Context, evt event.TypedDeleteEvent[object], q workqueue.TypedRateLimitingInterface[Request]) {
+	h.handler.Delete(ctx, evt, h.queue(q))
+}
+
+func (h *clusterHandler[object]) Generic(ctx context.Context, evt event.TypedGenericEvent[object], q workqueue.TypedRateLimitingInterface[Request]) {
+	h.handler.Generic(ctx, evt, h.queue(q))
+}
+
+// queue returns a queue of reconcile.Requests that adds Requests of the cluster to q.
+// It is a priority queue if q is one, so that handlers can set priorities.
+func (h *clusterHandler[object]) queue(q workqueue.TypedRateLimitingInterface[Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	cq := &clusterQueue{clusterName: h.clusterName, queue: q}
+	if pq, ok := q.(priorityqueue.PriorityQueue[Request]); ok {
+		return &clusterPriorityQueue{clusterQueue: cq, priorityQueue: pq}
+	}
+	return cq
+}
+
+// clusterQueue is a queue of reconcile.Requests that adds Requests of a cluster to
+// the queue of a multi-cluster controller. Event handlers only add items, the other
+// methods are forwarded for completeness.
+type clusterQueue struct {
+	clusterName string
+	queue       workqueue.TypedRateLimitingInterface[Request]
+}
+
+func (q *clusterQueue) request(req reconcile.Request) Request {
+	return Request{ClusterName: q.clusterName, Request: req}
+}
+
+func (q *clusterQueue) Add(item reconcile.Request) {
+	q.queue.Add(q.request(item))
+}
+
+func (q *clusterQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.queue.AddAfter(q.request(item), duration)
+}
+
+func (q *clusterQueue) AddRateLimited(item reconcile.Request) {
+	q.queue.AddRateLimited(q.request(item))
+}
+
+func (q *clusterQueue) Forget(item reconcile.Request) {
+	q.queue.Forget(q.request(item))
+}
+
+func (q *clusterQueue) NumRequeues(item reconcile.Request) int {
+	return q.queue.NumRequeues(q.request(item))
+}
+
+func (q *clusterQueue) Done(item reconcile.Request) {
+	q.queue.Done(q.request(item))
+}
+
+func (q *clusterQueue) Get() (reconcile.Request, bool) {
+	item, shutdown := q.queue.Get()
+	return item.Request, shutdown
+}
+
+func (q *clusterQueue) Len() int {
+	return q.queue.Len()
+}
+
+func (q *clusterQueue) ShutDown() {
+	q.queue.ShutDown()
+}
+
+func (q *clusterQueue) ShutDownWithDrain() {
+	q.queue.ShutDownWithDrain()
+}
+
+func (q *clusterQueue) ShuttingDown() bool {
+	return q.queue.ShuttingDown()
+}
+
+type clusterPriorityQueue struct {
+	*clusterQueue
+	priorityQueue priorityqueue.PriorityQueue[Request]
+}
+
+func (q *clusterPriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	requests := make([]Request, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, q.request(item))
+	}
+	q.priorityQueue.AddWithOpts(o, requests...)
+}
+
+func (q *clusterPriorityQueue) GetWithPriority() (reconcile.Request, int, bool) {
+	item, priority, shutdown := q.priorityQueue.GetWithPriority()
+	return item.Request, priority, shutdown
+}
+
+// clusterSource is the source of an engaged cluster. It is stopped once the cluster
+// is disengaged.
+type clusterSource struct {
+	clusterName string
+	engaged     context.Context
+	source      source.TypedSource[Request]
+}
+
+// Start implements source.TypedSource. It doesn't start the source if the cluster
+// was disengaged already, e.g. when a controller is restarted.
+func (s *clusterSource) Start(ctx context.Context, q workqueue.TypedRateLimitingInterface[Request]) error {
+	if s.engaged.Err() != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(s.engaged, cancel)
+	context.AfterFunc(ctx, func() { stop() })
+	return s.source.Start(ctx, q)
+}
+
+// WaitForSync implements source.TypedSyncingSource. Sources of clusters that are
+// disengaged while waiting don't fail the controller.
+func (s *clusterSource) WaitForSync(ctx context.Context) error {
+	syncing, ok := s.source.(source.TypedSyncingSource[Request])
+	if !ok {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(s.engaged, cancel)
+	defer stop()
+	err := syncing.WaitForSync(ctx)
+	if s.engaged.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (s *clusterSource) String() string {
+	return fmt.Sprintf("cluster %s: %v", s.clusterName, s.source)
+}
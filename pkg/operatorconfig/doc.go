@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package operatorconfig watches the configuration of an operator itself, stored in
+a custom resource or a ConfigMap, and distributes it to the controllers and webhooks
+of the operator while it keeps running.
+
+A Watcher decodes, defaults and validates the configuration object whenever it
+changes and passes the resulting Snapshot to its subscribers:
+
+	watcher, err := operatorconfig.New(mgr.GetCache(), &corev1.ConfigMap{}, operatorconfig.Options[*corev1.ConfigMap, Config]{
+		Key:      client.ObjectKey{Namespace: "operator-system", Name: "operator-config"},
+		Decode:   operatorconfig.FromConfigMapKey[Config]("config.yaml"),
+		Default:  setDefaults,
+		Validate: validate,
+	})
+	if err := mgr.Add(watcher); err != nil {
+		return err
+	}
+	err = watcher.Subscribe(ctx, func(ctx context.Context, s operatorconfig.Snapshot[Config]) error {
+		return reconciler.SetConcurrency(s.Config.Concurrency)
+	})
+
+Invalid configurations are rejected, the subscribers keep the last valid one. A
+subscriber can reject a configuration as well by returning an error, the
+subscribers that got it already are then rolled back to the previous snapshot.
+While the object doesn't exist, the defaulted zero value is the configuration.
+*/
+package operatorconfig
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("operatorconfig")
@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Snapshot is a validated and defaulted configuration.
+type Snapshot[T any] struct {
+	// Config is the configuration.
+	Config T
+
+	// Version identifies the snapshot. It starts at 1 and is increased by one for
+	// every configuration that is accepted.
+	Version int64
+
+	// ResourceVersion is the resourceVersion of the object the configuration was
+	// read from. It is empty if the object doesn't exist.
+	ResourceVersion string
+}
+
+// Subscriber applies a snapshot of the configuration, e.g. to a controller. An error
+// rejects the snapshot, the subscribers that applied it already are rolled back.
+type Subscriber[T any] func(ctx context.Context, snapshot Snapshot[T]) error
+
+// Options are the options of a Watcher.
+type Options[object client.Object, T any] struct {
+	// Key is the namespace and name of the configuration object. Required.
+	Key client.ObjectKey
+
+	// Decode returns the configuration stored in the object. Required.
+	Decode func(obj object) (T, error)
+
+	// Default sets the defaults of unset fields of the configuration.
+	Default func(config *T)
+
+	// Validate returns an error if the defaulted configuration is invalid.
+	Validate func(config T) error
+}
+
+// Watcher watches a configuration object and distributes its configuration to
+// subscribers. It is a Runnable that runs on all replicas, not only on the leader.
+type Watcher[object client.Object, T any] struct {
+	cache cache.Cache
+	obj   object
+	opts  Options[object, T]
+
+	changed chan struct{}
+	synced  chan struct{}
+
+	// deliver serializes the delivery of snapshots to subscribers.
+	deliver sync.Mutex
+
+	mu          sync.Mutex
+	current     *Snapshot[T]
+	subscribers []Subscriber[T]
+	// rejected is the resourceVersion of the last rejected object and rejectErr
+	// the reason, they are cleared once a configuration is accepted.
+	rejected  *string
+	rejectErr error
+}
+
+// New returns a Watcher for the object of the type of obj, e.g. a ConfigMap, that is
+// read through c.
+func New[object client.Object, T any](c cache.Cache, obj object, opts Options[object, T]) (*Watcher[object, T], error) {
+	if opts.Key.Name == "" {
+		return nil, errors.New("must specify the key of the configuration object")
+	}
+	if opts.Decode == nil {
+		return nil, errors.New("must specify Decode")
+	}
+	return &Watcher[object, T]{
+		cache:   c,
+		obj:     obj,
+		opts:    opts,
+		changed: make(chan struct{}, 1),
+		synced:  make(chan struct{}),
+	}, nil
+}
+
+// Subscribe adds a subscriber. If a configuration was loaded already, the subscriber
+// applies it before Subscribe returns and its error is returned, the subscriber is
+// then not added.
+func (w *Watcher[object, T]) Subscribe(ctx context.Context, subscriber Subscriber[T]) error {
+	w.deliver.Lock()
+	defer w.deliver.Unlock()
+
+	w.mu.Lock()
+	current := w.current
+	w.mu.Unlock()
+	if current != nil {
+		if err := subscriber(ctx, *current); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, subscriber)
+	return nil
+}
+
+// Current returns the current snapshot, or false if no configuration was loaded yet.
+func (w *Watcher[object, T]) Current() (Snapshot[T], bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.current == nil {
+		return Snapshot[T]{}, false
+	}
+	return *w.current, true
+}
+
+// Err returns why the latest version of the configuration object was rejected, or
+// nil if it was accepted.
+func (w *Watcher[object, T]) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rejectErr
+}
+
+// WaitForInitialConfig waits until the configuration was loaded for the first time
+// and returns it, e.g. to configure controllers before they are built.
+func (w *Watcher[object, T]) WaitForInitialConfig(ctx context.Context) (Snapshot[T], error) {
+	select {
+	case <-w.synced:
+	case <-ctx.Done():
+		return Snapshot[T]{}, ctx.Err()
+	}
+	snapshot, ok := w.Current()
+	if !ok {
+		return Snapshot[T]{}, fmt.Errorf("initial configuration was rejected: %w", w.Err())
+	}
+	return snapshot, nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The configuration
+// is watched on all replicas.
+func (w *Watcher[object, T]) NeedLeaderElection() bool {
+	return false
+}
+
+// Start watches the configuration object until ctx is done.
+func (w *Watcher[object, T]) Start(ctx context.Context) error {
+	informer, err := w.cache.GetInformer(ctx, w.obj)
+	if err != nil {
+		return fmt.Errorf("failed to get informer for the configuration object: %w", err)
+	}
+	notify := func(obj any) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if o, ok := obj.(client.Object); ok && client.ObjectKeyFromObject(o) == w.opts.Key {
+			select {
+			case w.changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    notify,
+		UpdateFunc: func(_, obj any) { notify(obj) },
+		DeleteFunc: notify,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch the configuration object: %w", err)
+	}
+	defer func() {
+		_ = informer.RemoveEventHandler(registration)
+	}()
+	if !toolscache.WaitForCacheSync(ctx.Done(), registration.HasSynced) {
+		return ctx.Err()
+	}
+
+	w.reload(ctx)
+	close(w.synced)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.changed:
+			w.reload(ctx)
+		}
+	}
+}
+
+// reload reads the configuration object and distributes its configuration if it
+// changed and is valid.
+func (w *Watcher[object, T]) reload(ctx context.Context) {
+	obj := reflect.New(reflect.TypeOf(w.obj).Elem()).Interface().(object)
+	var (
+		config          T
+		resourceVersion string
+	)
+	err := w.cache.Get(ctx, w.opts.Key, obj)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		log.Error(err, "Failed to read the configuration object", "key", w.opts.Key)
+		return
+	default:
+		resourceVersion = obj.GetResourceVersion()
+	}
+
+	w.mu.Lock()
+	unchanged := (w.current != nil && w.current.ResourceVersion == resourceVersion) ||
+		(w.rejected != nil && *w.rejected == resourceVersion)
+	previous := w.current
+	w.mu.Unlock()
+	if unchanged {
+		return
+	}
+
+	if resourceVersion != "" {
+		if config, err = w.opts.Decode(obj); err != nil {
+			w.reject(resourceVersion, fmt.Errorf("failed to decode configuration: %w", err))
+			return
+		}
+	}
+	if w.opts.Default != nil {
+		w.opts.Default(&config)
+	}
+	if w.opts.Validate != nil {
+		if err := w.opts.Validate(config); err != nil {
+			w.reject(resourceVersion, fmt.Errorf("invalid configuration: %w", err))
+			return
+		}
+	}
+
+	snapshot := Snapshot[T]{Config: config, Version: 1, ResourceVersion: resourceVersion}
+	if previous != nil {
+		snapshot.Version = previous.Version + 1
+	}
+	w.distribute(ctx, snapshot, previous)
+}
+
+// distribute passes snapshot to all subscribers. If one of them rejects it, the
+// subscribers that applied it already are rolled back to previous.
+func (w *Watcher[object, T]) distribute(ctx context.Context, snapshot Snapshot[T], previous *Snapshot[T]) {
+	w.deliver.Lock()
+	defer w.deliver.Unlock()
+
+	w.mu.Lock()
+	subscribers := w.subscribers
+	w.mu.Unlock()
+
+	for i, subscriber := range subscribers {
+		err := subscriber(ctx, snapshot)
+		if err == nil {
+			continue
+		}
+		if previous != nil {
+			for _, applied := range subscribers[:i] {
+				if err := applied(ctx, *previous); err != nil {
+					log.Error(err, "Failed to roll back configuration", "key", w.opts.Key, "version", previous.Version)
+				}
+			}
+		}
+		w.reject(snapshot.ResourceVersion, fmt.Errorf("configuration was rejected by a subscriber: %w", err))
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.current = &snapshot
+	w.rejected, w.rejectErr = nil, nil
+	log.Info("Applied configuration", "key", w.opts.Key, "version", snapshot.Version, "resourceVersion", snapshot.ResourceVersion)
+}
+
+func (w *Watcher[object, T]) reject(resourceVersion string, err error) {
+	log.Error(err, "Rejected configuration, keeping the previous one", "key", w.opts.Key, "resourceVersion", resourceVersion)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rejected, w.rejectErr = &resourceVersion, err
+}
+
+// FromConfigMapKey returns a Decode func that strictly decodes the YAML or JSON
+// stored in the given key of a ConfigMap. A missing key is the zero value.
+func FromConfigMapKey[T any](key string) func(cm *corev1.ConfigMap) (T, error) {
+	return func(cm *corev1.ConfigMap) (T, error) {
+		var config T
+		data, ok := cm.Data[key]
+		if !ok {
+			return config, nil
+		}
+		if err := yaml.UnmarshalStrict([]byte(data), &config); err != nil {
+			return config, fmt.Errorf("failed to decode key %q: %w", key, err)
+		}
+		return config, nil
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+type testConfig struct {
+	Concurrency int `json:"concurrency"`
+}
+
+// testCache serves the informers of FakeInformers and reads objects from a client.
+type testCache struct {
+	*informertest.FakeInformers
+	client client.Client
+}
+
+func (c *testCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.client.Get(ctx, key, obj, opts...)
+}
+
+// recorder is a Subscriber that records the configurations it applied.
+type recorder struct {
+	mu      sync.Mutex
+	applied []int
+	reject  int
+}
+
+func (r *recorder) subscribe(_ context.Context, s Snapshot[testConfig]) error {
+	if s.Config.Concurrency == r.reject {
+		return errors.New("rejected")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.applied = append(r.applied, s.Config.Concurrency)
+	return nil
+}
+
+func (r *recorder) get() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int(nil), r.applied...)
+}
+
+var _ = Describe("Watcher", func() {
+	var (
+		cl       client.Client
+		informer *controllertest.FakeInformer
+		watcher  *Watcher[*corev1.ConfigMap, testConfig]
+		cm       *corev1.ConfigMap
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		cl = fake.NewClientBuilder().Build()
+		informers := &informertest.FakeInformers{}
+		var err error
+		informer, err = informers.FakeInformerFor(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "operator-system", Name: "config"},
+			Data:       map[string]string{"config.yaml": "concurrency: 2"},
+		}
+		Expect(cl.Create(ctx, cm)).To(Succeed())
+
+		watcher, err = New(&testCache{FakeInformers: informers, client: cl}, &corev1.ConfigMap{}, Options[*corev1.ConfigMap, testConfig]{
+			Key:    client.ObjectKeyFromObject(cm),
+			Decode: FromConfigMapKey[testConfig]("config.yaml"),
+			Default: func(c *testConfig) {
+				if c.Concurrency == 0 {
+					c.Concurrency = 1
+				}
+			},
+			Validate: func(c testConfig) error {
+				if c.Concurrency < 0 {
+					return errors.New("concurrency must not be negative")
+				}
+				return nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	start := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() {
+			defer GinkgoRecover()
+			Expect(watcher.Start(ctx)).To(Succeed())
+		}()
+	}
+
+	update := func(ctx context.Context, data string) {
+		old := cm.DeepCopy()
+		cm.Data["config.yaml"] = data
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+		informer.Update(old, cm)
+	}
+
+	It("should distribute the configuration and keep the last valid one", func(ctx SpecContext) {
+		first := &recorder{}
+		Expect(watcher.Subscribe(ctx, first.subscribe)).To(Succeed())
+		start()
+
+		snapshot, err := watcher.WaitForInitialConfig(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot.Config.Concurrency).To(Equal(2))
+		Expect(snapshot.Version).To(BeEquivalentTo(1))
+		Expect(snapshot.ResourceVersion).To(Equal(cm.ResourceVersion))
+		Expect(first.get()).To(Equal([]int{2}))
+
+		By("rejecting an invalid configuration")
+		update(ctx, "concurrency: -1")
+		Eventually(watcher.Err).Should(MatchError(ContainSubstring("concurrency must not be negative")))
+		current, _ := watcher.Current()
+		Expect(current.Version).To(BeEquivalentTo(1))
+
+		By("rejecting configurations with unknown fields")
+		update(ctx, "concurency: 3")
+		Eventually(watcher.Err).Should(MatchError(ContainSubstring("failed to decode")))
+
+		By("accepting a valid configuration again")
+		update(ctx, "concurrency: 3")
+		Eventually(func() int64 {
+			current, _ := watcher.Current()
+			return current.Version
+		}).Should(BeEquivalentTo(2))
+		Expect(watcher.Err()).NotTo(HaveOccurred())
+		Expect(first.get()).To(Equal([]int{2, 3}))
+
+		By("defaulting the configuration once the object is deleted")
+		Expect(cl.Delete(ctx, cm)).To(Succeed())
+		informer.Delete(cm)
+		Eventually(func() Snapshot[testConfig] {
+			current, _ := watcher.Current()
+			return current
+		}).Should(Equal(Snapshot[testConfig]{Config: testConfig{Concurrency: 1}, Version: 3}))
+	})
+
+	It("should roll back subscribers when a subscriber rejects a configuration", func(ctx SpecContext) {
+		first, second := &recorder{}, &recorder{reject: 5}
+		Expect(watcher.Subscribe(ctx, first.subscribe)).To(Succeed())
+		start()
+		_, err := watcher.WaitForInitialConfig(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("applying the current configuration to new subscribers")
+		Expect(watcher.Subscribe(ctx, second.subscribe)).To(Succeed())
+		Expect(second.get()).To(Equal([]int{2}))
+
+		update(ctx, "concurrency: 5")
+		Eventually(watcher.Err).Should(MatchError(ContainSubstring("rejected by a subscriber")))
+		Expect(first.get()).To(Equal([]int{2, 5, 2}))
+		Expect(second.get()).To(Equal([]int{2}))
+		current, _ := watcher.Current()
+		Expect(current.Config.Concurrency).To(Equal(2))
+	})
+
+	It("should fail if the initial configuration is invalid", func(ctx SpecContext) {
+		cm.Data["config.yaml"] = "concurrency: -1"
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+		start()
+
+		_, err := watcher.WaitForInitialConfig(ctx)
+		Expect(err).To(MatchError(ContainSubstring("initial configuration was rejected")))
+	})
+})
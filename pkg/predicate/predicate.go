@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var log = logf.RuntimeLog.WithName("predicate").WithName("eventFilters")
@@ -289,6 +290,38 @@ func (TypedLabelChangedPredicate[object]) Update(e event.TypedUpdateEvent[object
 	return !maps.Equal(e.ObjectNew.GetLabels(), e.ObjectOld.GetLabels())
 }
 
+// ReconcileRequestedPredicate implements an update predicate function that passes update events
+// in which the reconcile.RequestedAtAnnotation of the object was set or changed, i.e. in which a
+// user requested a reconciliation of the object.
+// It is intended to be used in conjunction with the GenerationChangedPredicate, as in the following example:
+//
+//	Controller.Watch(
+//		source.Kind(cache, &v1.MyCustomKind{},
+//			handler.WithHighPriorityWhenReconcileRequested(&handler.EnqueueRequestForObject{}),
+//			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.ReconcileRequestedPredicate{})))
+type ReconcileRequestedPredicate = TypedReconcileRequestedPredicate[client.Object]
+
+// TypedReconcileRequestedPredicate implements an update predicate function that passes update events
+// in which the reconcile.RequestedAtAnnotation of the object was set or changed.
+type TypedReconcileRequestedPredicate[object metav1.Object] struct {
+	TypedFuncs[object]
+}
+
+// Update implements UpdateEvent filter for checking a change of the reconcile.RequestedAtAnnotation.
+func (TypedReconcileRequestedPredicate[object]) Update(e event.TypedUpdateEvent[object]) bool {
+	if isNil(e.ObjectOld) {
+		log.Error(nil, "Update event has no old object to update", "event", e)
+		return false
+	}
+	if isNil(e.ObjectNew) {
+		log.Error(nil, "Update event has no new object for update", "event", e)
+		return false
+	}
+
+	requestedAt := e.ObjectNew.GetAnnotations()[reconcile.RequestedAtAnnotation]
+	return requestedAt != "" && requestedAt != e.ObjectOld.GetAnnotations()[reconcile.RequestedAtAnnotation]
+}
+
 // And returns a composite predicate that implements a logical AND of the predicates passed to it.
 func And[object any](predicates ...TypedPredicate[object]) TypedPredicate[object] {
 	return and[object]{predicates}
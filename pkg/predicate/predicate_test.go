@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ = Describe("Predicate", func() {
@@ -926,6 +927,39 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("When checking a ReconcileRequestedPredicate", func() {
+		instance := predicate.ReconcileRequestedPredicate{}
+		podWithAnnotations := func(annotations map[string]string) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "biz", Annotations: annotations}}
+		}
+
+		It("should pass all other events", func() {
+			Expect(instance.Create(event.CreateEvent{})).To(BeTrue())
+			Expect(instance.Delete(event.DeleteEvent{})).To(BeTrue())
+			Expect(instance.Generic(event.GenericEvent{})).To(BeTrue())
+		})
+
+		It("should return false if an object is missing", func() {
+			requested := podWithAnnotations(map[string]string{reconcile.RequestedAtAnnotation: "now"})
+			Expect(instance.Update(event.UpdateEvent{ObjectNew: requested})).To(BeFalse())
+			Expect(instance.Update(event.UpdateEvent{ObjectOld: requested})).To(BeFalse())
+		})
+
+		DescribeTable("should only pass updates that request a reconciliation",
+			func(oldAnnotations, newAnnotations map[string]string, expected bool) {
+				Expect(instance.Update(event.UpdateEvent{
+					ObjectOld: podWithAnnotations(oldAnnotations),
+					ObjectNew: podWithAnnotations(newAnnotations),
+				})).To(Equal(expected))
+			},
+			Entry("annotation set", nil, map[string]string{reconcile.RequestedAtAnnotation: "now"}, true),
+			Entry("annotation changed", map[string]string{reconcile.RequestedAtAnnotation: "now"}, map[string]string{reconcile.RequestedAtAnnotation: "later"}, true),
+			Entry("annotation unchanged", map[string]string{reconcile.RequestedAtAnnotation: "now"}, map[string]string{reconcile.RequestedAtAnnotation: "now", "other": "value"}, false),
+			Entry("annotation removed", map[string]string{reconcile.RequestedAtAnnotation: "now"}, nil, false),
+			Entry("other annotation changed", nil, map[string]string{"other": "value"}, false),
+		)
+	})
+
 	Describe("When checking a LabelSelectorPredicate", func() {
 		instance, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}})
 		if err != nil {
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package profiling captures pprof profiles on demand, e.g. when the process receives
+a signal or an admin endpoint is called, in addition to the pprof endpoints served
+by the Manager on its PprofBindAddress.
+
+A snapshot of the heap and goroutine profiles can be written whenever the process
+receives SIGUSR1 by adding a SignalTrigger to the Manager:
+
+	err := mgr.Add(&profiling.SignalTrigger{
+		Signals: []os.Signal{syscall.SIGUSR1},
+		Dir:     "/tmp/profiles",
+	})
+
+ControllerContention is a preset for the mutex and block profiles, which are
+disabled by default, that is tuned for controllers.
+*/
+package profiling
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("profiling")
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+)
+
+// Names of the profiles that are captured by default.
+var defaultProfiles = []string{"heap", "goroutine"}
+
+// MaxSnapshots is the number of snapshots of each profile that Snapshot keeps in a
+// directory, older snapshots are deleted when a new one is written.
+const MaxSnapshots = 10
+
+const (
+	snapshotTimestampFormat = "20060102T150405.000Z"
+	snapshotSuffix          = ".pb.gz"
+)
+
+// WriteProfile writes a snapshot of the runtime/pprof profile with the given name,
+// e.g. "heap", "goroutine", "mutex" or "block", to w. debug is passed on to
+// pprof.Profile.WriteTo, zero writes the compressed protobuf format.
+func WriteProfile(w io.Writer, name string, debug int) error {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	if name == "heap" {
+		// Include all allocations up to now, like the pprof endpoint with gc=1.
+		runtime.GC()
+	}
+	return profile.WriteTo(w, debug)
+}
+
+// validateProfile checks that name is a known profile that can be used in a file name.
+func validateProfile(name string) error {
+	if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	if pprof.Lookup(name) == nil {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	return nil
+}
+
+// Snapshot writes a snapshot of each of the given profiles to a file in dir,
+// named after the profile and the current time, and returns the paths of the
+// files. It defaults to the heap and goroutine profiles. Only the newest
+// MaxSnapshots snapshots of each profile are kept in dir.
+func Snapshot(dir string, profiles ...string) ([]string, error) {
+	if len(profiles) == 0 {
+		profiles = defaultProfiles
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	timestamp := time.Now().UTC().Format(snapshotTimestampFormat)
+	paths := make([]string, 0, len(profiles))
+	var errs []error
+	for _, name := range profiles {
+		if err := validateProfile(name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		path := filepath.Join(dir, name+"-"+timestamp+snapshotSuffix)
+		if err := writeProfileFile(path, name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		paths = append(paths, path)
+		if err := pruneSnapshots(dir, name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return paths, errors.Join(errs...)
+}
+
+func writeProfileFile(path, name string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file for profile %s: %w", name, err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write profile %s: %w", name, closeErr)
+		}
+	}()
+	if err := WriteProfile(f, name, 0); err != nil {
+		return fmt.Errorf("failed to write profile %s: %w", name, err)
+	}
+	return nil
+}
+
+// pruneSnapshots deletes all but the newest MaxSnapshots snapshots of a profile in dir.
+// Files that were not written by Snapshot are left alone.
+func pruneSnapshots(dir, name string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots in %s: %w", dir, err)
+	}
+	var snapshots []string
+	for _, entry := range entries {
+		timestamp, ok := strings.CutPrefix(entry.Name(), name+"-")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		timestamp, ok = strings.CutSuffix(timestamp, snapshotSuffix)
+		if !ok {
+			continue
+		}
+		if _, err := time.Parse(snapshotTimestampFormat, timestamp); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, entry.Name())
+	}
+	if len(snapshots) <= MaxSnapshots {
+		return nil
+	}
+
+	// The timestamps sort chronologically, and os.ReadDir returns the entries sorted by name.
+	var errs []error
+	for _, snapshot := range snapshots[:len(snapshots)-MaxSnapshots] {
+		if err := os.Remove(filepath.Join(dir, snapshot)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete old snapshot: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SignalTrigger writes a Snapshot of its profiles whenever the process receives
+// one of its signals. It implements manager.Runnable and runs on all replicas.
+type SignalTrigger struct {
+	// Signals trigger a snapshot, e.g. syscall.SIGUSR1. Required.
+	Signals []os.Signal
+
+	// Dir is the directory the snapshots are written to. Required.
+	Dir string
+
+	// Profiles are the names of the profiles to capture. Defaults to the heap and
+	// goroutine profiles.
+	Profiles []string
+}
+
+// Start writes snapshots on signals until ctx is done.
+func (t *SignalTrigger) Start(ctx context.Context) error {
+	if len(t.Signals) == 0 {
+		return errors.New("must specify at least one signal")
+	}
+	if t.Dir == "" {
+		return errors.New("must specify a directory")
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, t.Signals...)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case sig := <-signals:
+			paths, err := Snapshot(t.Dir, t.Profiles...)
+			if err != nil {
+				log.Error(err, "Failed to write profiles", "signal", sig.String())
+			}
+			if len(paths) > 0 {
+				log.Info("Wrote profiles", "signal", sig.String(), "files", paths)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (t *SignalTrigger) NeedLeaderElection() bool {
+	return false
+}
+
+// SnapshotHandler returns a handler that writes a Snapshot of the given profiles
+// to dir on POST requests and responds with the paths of the files as a JSON list.
+// The "profile" query parameter overrides the profiles, e.g. ?profile=heap&profile=mutex,
+// requests for unknown profiles are rejected.
+// Like the pprof endpoints it must not be exposed publicly.
+func SnapshotHandler(dir string, profiles ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		names := profiles
+		if requested := r.URL.Query()["profile"]; len(requested) > 0 {
+			names = requested
+		}
+		for _, name := range names {
+			if err := validateProfile(name); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		paths, err := Snapshot(dir, names...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(paths); err != nil {
+			log.Error(err, "Failed to write response")
+		}
+	})
+}
+
+// ContentionProfile configures the mutex and block profiles of the runtime, which
+// are disabled by default.
+type ContentionProfile struct {
+	// MutexProfileFraction is passed to runtime.SetMutexProfileFraction, on average
+	// 1/MutexProfileFraction of the mutex contention events are reported.
+	MutexProfileFraction int
+
+	// BlockProfileRate is passed to runtime.SetBlockProfileRate, on average one
+	// blocking event per BlockProfileRate nanoseconds spent blocked is reported.
+	BlockProfileRate int
+}
+
+// ControllerContention is a ContentionProfile for controllers, which spend most of
+// their time waiting on the workqueue, informers and the API server. It samples
+// enough events to find contention on caches and queues with an overhead that is
+// acceptable in production. Blocking events shorter than 10µs, like the hand-off
+// between informers and event handlers, are sampled proportionally to their
+// duration, so that they don't dominate the profile.
+var ControllerContention = ContentionProfile{
+	MutexProfileFraction: 100,
+	BlockProfileRate:     int(10 * time.Microsecond),
+}
+
+// Enable enables the mutex and block profiles with the rates of the profile and
+// returns a function that restores the previous mutex profile fraction and disables
+// the block profile. The runtime doesn't allow reading the block profile rate, so a
+// rate that was set before with runtime.SetBlockProfileRate is not restored.
+func (p ContentionProfile) Enable() (restore func()) {
+	previousFraction := runtime.SetMutexProfileFraction(p.MutexProfileFraction)
+	runtime.SetBlockProfileRate(p.BlockProfileRate)
+	return func() {
+		runtime.SetMutexProfileFraction(previousFraction)
+		runtime.SetBlockProfileRate(0)
+	}
+}
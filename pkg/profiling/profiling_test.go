@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profiling", func() {
+	Describe("WriteProfile", func() {
+		It("should write the profile", func() {
+			buf := &bytes.Buffer{}
+			Expect(WriteProfile(buf, "goroutine", 1)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("goroutine profile:"))
+		})
+
+		It("should fail for unknown profiles", func() {
+			Expect(WriteProfile(&bytes.Buffer{}, "unknown", 0)).To(MatchError(ContainSubstring("unknown profile")))
+		})
+	})
+
+	Describe("Snapshot", func() {
+		It("should write the heap and goroutine profiles by default", func() {
+			dir := filepath.Join(GinkgoT().TempDir(), "profiles")
+			paths, err := Snapshot(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(HaveLen(2))
+			Expect(filepath.Base(paths[0])).To(HavePrefix("heap-"))
+			Expect(filepath.Base(paths[1])).To(HavePrefix("goroutine-"))
+			for _, path := range paths {
+				Expect(os.Stat(path)).To(HaveField("Size()", BeNumerically(">", 0)))
+			}
+		})
+
+		It("should write the profiles that are known", func() {
+			paths, err := Snapshot(GinkgoT().TempDir(), "unknown", "mutex")
+			Expect(err).To(MatchError(ContainSubstring("unknown profile")))
+			Expect(paths).To(HaveLen(1))
+			Expect(filepath.Glob(filepath.Join(filepath.Dir(paths[0]), "unknown-*"))).To(BeEmpty())
+		})
+
+		It("should keep the newest snapshots of each profile", func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "goroutine-other.pb.gz"), nil, 0o600)).To(Succeed())
+			oldest := filepath.Join(dir, "goroutine-20000101T000000.000Z.pb.gz")
+			Expect(os.WriteFile(oldest, nil, 0o600)).To(Succeed())
+			for i := range MaxSnapshots - 1 {
+				Expect(os.WriteFile(filepath.Join(dir, fmt.Sprintf("goroutine-20000101T00000%d.000Z.pb.gz", i+1)), nil, 0o600)).To(Succeed())
+			}
+
+			paths, err := Snapshot(dir, "goroutine")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths[0]).To(BeAnExistingFile())
+			Expect(oldest).NotTo(BeAnExistingFile())
+			Expect(filepath.Join(dir, "goroutine-other.pb.gz")).To(BeAnExistingFile())
+			Expect(os.ReadDir(dir)).To(HaveLen(MaxSnapshots + 1))
+		})
+	})
+
+	Describe("SnapshotHandler", func() {
+		It("should write the requested profiles on POST", func() {
+			h := SnapshotHandler(GinkgoT().TempDir())
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/pprof/snapshot?profile=block", nil))
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var paths []string
+			Expect(json.Unmarshal(rec.Body.Bytes(), &paths)).To(Succeed())
+			Expect(paths).To(HaveLen(1))
+			Expect(paths[0]).To(BeAnExistingFile())
+			Expect(filepath.Base(paths[0])).To(HavePrefix("block-"))
+
+			rec = httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/snapshot", nil))
+			Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
+
+		It("should reject invalid profile names", func() {
+			root := GinkgoT().TempDir()
+			h := SnapshotHandler(filepath.Join(root, "profiles"))
+
+			for _, name := range []string{"../goroutine", "unknown"} {
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/pprof/snapshot?profile="+name, nil))
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			}
+			Expect(os.ReadDir(root)).To(BeEmpty())
+		})
+	})
+
+	Describe("SignalTrigger", func() {
+		It("should write a snapshot on signals", func(ctx SpecContext) {
+			dir := GinkgoT().TempDir()
+			trigger := &SignalTrigger{Signals: []os.Signal{syscall.SIGUSR1}, Dir: dir, Profiles: []string{"goroutine"}}
+			Expect(trigger.NeedLeaderElection()).To(BeFalse())
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(trigger.Start(ctx)).To(Succeed())
+			}()
+
+			Eventually(func() ([]string, error) {
+				Expect(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).To(Succeed())
+				return filepath.Glob(filepath.Join(dir, "goroutine-*"))
+			}).ShouldNot(BeEmpty())
+		})
+
+		It("should require signals and a directory", func(ctx SpecContext) {
+			Expect((&SignalTrigger{Dir: "dir"}).Start(ctx)).NotTo(Succeed())
+			Expect((&SignalTrigger{Signals: []os.Signal{syscall.SIGUSR1}}).Start(ctx)).NotTo(Succeed())
+		})
+	})
+
+	Describe("ContentionProfile", func() {
+		It("should enable and restore the mutex profile", func() {
+			previous := runtime.SetMutexProfileFraction(-1)
+			restore := ControllerContention.Enable()
+			Expect(runtime.SetMutexProfileFraction(-1)).To(Equal(ControllerContention.MutexProfileFraction))
+			restore()
+			Expect(runtime.SetMutexProfileFraction(-1)).To(Equal(previous))
+		})
+	})
+})
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pipeline structures long reconcilers as a pipeline of named steps. Every
+// step is logged with its name and reports its own latency, error and skip metrics,
+// so that complex reconcilers can be observed and tested per step:
+//
+//	p := pipeline.New(
+//		pipeline.Step{Name: "finalizer", Run: r.ensureFinalizer},
+//		pipeline.Step{Name: "deployment", Run: r.reconcileDeployment},
+//		pipeline.Step{Name: "status", Run: r.updateStatus, If: r.statusChanged},
+//	).WithName("foo")
+//
+//	func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+//		return p.Run(ctx)
+//	}
+//
+// The results of the steps are merged with a results.Aggregator.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile/results"
+)
+
+// Step is a named step of a Pipeline.
+type Step struct {
+	// Name identifies the step in logs and metrics. It must be unique within
+	// the pipeline.
+	Name string
+
+	// Run runs the step. The logger of the context is named after the step.
+	Run results.Phase
+
+	// If makes the step only run if it returns true. The step always runs if it is nil.
+	If func(ctx context.Context) bool
+}
+
+// Pipeline runs its steps in order and merges their results.
+type Pipeline struct {
+	name            string
+	steps           []Step
+	continueOnError bool
+}
+
+// New returns a pipeline of the given steps. It panics if a step has no name, no Run
+// func or the same name as another step.
+func New(steps ...Step) *Pipeline {
+	names := make(map[string]struct{}, len(steps))
+	for _, step := range steps {
+		if step.Name == "" {
+			panic("pipeline steps must have a name")
+		}
+		if step.Run == nil {
+			panic(fmt.Sprintf("pipeline step %q must have a Run func", step.Name))
+		}
+		if _, ok := names[step.Name]; ok {
+			panic(fmt.Sprintf("pipeline step %q is not unique", step.Name))
+		}
+		names[step.Name] = struct{}{}
+	}
+	return &Pipeline{name: "default", steps: steps}
+}
+
+// WithName sets the name of the pipeline, which distinguishes its steps in metrics from
+// the ones of other pipelines, e.g. the name of the controller. Defaults to "default".
+func (p *Pipeline) WithName(name string) *Pipeline {
+	p.name = name
+	return p
+}
+
+// WithContinueOnError makes the steps following a failed step run, e.g. if the steps
+// are independent of each other. By default, the pipeline halts on the first error.
+func (p *Pipeline) WithContinueOnError() *Pipeline {
+	p.continueOnError = true
+	return p
+}
+
+type aggregatorKey struct{}
+
+// Halt stops the pipeline that runs the current step without an error, e.g. because
+// the step has to wait for another object. The steps following the current one don't
+// run. Halt has no effect if ctx is not the context of a step.
+func Halt(ctx context.Context) {
+	if agg, ok := ctx.Value(aggregatorKey{}).(*results.Aggregator); ok {
+		agg.Halt()
+	}
+}
+
+// Run runs the steps in order until one of them halts the pipeline and returns the
+// merged result.
+func (p *Pipeline) Run(ctx context.Context) (reconcile.Result, error) {
+	agg := &results.Aggregator{ContinueOnError: p.continueOnError}
+	ctx = context.WithValue(ctx, aggregatorKey{}, agg)
+	for _, step := range p.steps {
+		if agg.Halted() {
+			break
+		}
+		agg.Add(p.runStep(ctx, step))
+	}
+	return agg.Result()
+}
+
+func (p *Pipeline) runStep(ctx context.Context, step Step) (reconcile.Result, error) {
+	logger := log.FromContext(ctx).WithValues("step", step.Name)
+	ctx = log.IntoContext(ctx, logger)
+
+	if step.If != nil && !step.If(ctx) {
+		stepSkips.WithLabelValues(p.name, step.Name).Inc()
+		logger.V(1).Info("Skipping step")
+		return reconcile.Result{}, nil
+	}
+
+	logger.V(1).Info("Running step")
+	start := time.Now()
+	result, err := step.Run(ctx)
+	stepTime.WithLabelValues(p.name, step.Name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		stepErrors.WithLabelValues(p.name, step.Name).Inc()
+		logger.V(1).Info("Step failed", "error", err.Error())
+	}
+	return result, err
+}
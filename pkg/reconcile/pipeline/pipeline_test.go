@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Pipeline", func() {
+	var ran []string
+	record := func(name string, result reconcile.Result, err error) Step {
+		return Step{Name: name, Run: func(context.Context) (reconcile.Result, error) {
+			ran = append(ran, name)
+			return result, err
+		}}
+	}
+
+	BeforeEach(func() {
+		ran = nil
+	})
+
+	It("should run the steps in order and merge their results", func(ctx SpecContext) {
+		result, err := New(
+			record("first", reconcile.Result{RequeueAfter: time.Minute}, nil),
+			record("second", reconcile.Result{RequeueAfter: time.Second}, nil),
+		).WithName("merge").Run(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+		Expect(ran).To(Equal([]string{"first", "second"}))
+		for _, step := range []string{"first", "second"} {
+			m := &dto.Metric{}
+			Expect(stepTime.WithLabelValues("merge", step).(prometheus.Metric).Write(m)).To(Succeed())
+			Expect(m.GetHistogram().GetSampleCount()).To(BeEquivalentTo(1))
+		}
+	})
+
+	It("should halt on the first error and count it", func(ctx SpecContext) {
+		expected := errors.New("failed")
+		_, err := New(
+			record("first", reconcile.Result{}, expected),
+			record("second", reconcile.Result{}, nil),
+		).WithName("halt").Run(ctx)
+		Expect(err).To(MatchError(expected))
+		Expect(ran).To(Equal([]string{"first"}))
+		Expect(testutil.ToFloat64(stepErrors.WithLabelValues("halt", "first"))).To(BeEquivalentTo(1))
+	})
+
+	It("should run the following steps with WithContinueOnError", func(ctx SpecContext) {
+		expected := errors.New("failed")
+		_, err := New(
+			record("first", reconcile.Result{}, expected),
+			record("second", reconcile.Result{}, nil),
+		).WithContinueOnError().Run(ctx)
+		Expect(err).To(MatchError(expected))
+		Expect(ran).To(Equal([]string{"first", "second"}))
+	})
+
+	It("should skip steps whose condition doesn't hold", func(ctx SpecContext) {
+		skipped := record("skipped", reconcile.Result{}, nil)
+		skipped.If = func(context.Context) bool { return false }
+		_, err := New(skipped, record("second", reconcile.Result{}, nil)).WithName("skip").Run(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ran).To(Equal([]string{"second"}))
+		Expect(testutil.ToFloat64(stepSkips.WithLabelValues("skip", "skipped"))).To(BeEquivalentTo(1))
+	})
+
+	It("should stop once a step halts the pipeline", func(ctx SpecContext) {
+		_, err := New(
+			Step{Name: "halting", Run: func(ctx context.Context) (reconcile.Result, error) {
+				ran = append(ran, "halting")
+				Halt(ctx)
+				return reconcile.Result{}, nil
+			}},
+			record("second", reconcile.Result{}, nil),
+		).Run(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ran).To(Equal([]string{"halting"}))
+	})
+
+	It("should reject steps without a unique name", func() {
+		Expect(func() { New(Step{Run: record("", reconcile.Result{}, nil).Run}) }).To(PanicWith("pipeline steps must have a name"))
+		Expect(func() { New(Step{Name: "no-run"}) }).To(PanicWith(`pipeline step "no-run" must have a Run func`))
+		Expect(func() {
+			New(record("step", reconcile.Result{}, nil), record("step", reconcile.Result{}, nil))
+		}).To(PanicWith(`pipeline step "step" is not unique`))
+	})
+})
@@ -26,6 +26,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// RequestedAtAnnotation is the annotation that users set to request a reconciliation of an object,
+// e.g. with `kubectl annotate --overwrite foo/bar reconcile.controller-runtime.io/requestedAt="$(date -Is)"`.
+// Its value is opaque, every change of it requests a new reconciliation. See
+// predicate.ReconcileRequestedPredicate, handler.WithHighPriorityWhenReconcileRequested and
+// controllerutil.AcknowledgeReconcileRequest.
+const RequestedAtAnnotation = "reconcile.controller-runtime.io/requestedAt"
+
 // Result contains the result of a Reconciler invocation.
 type Result struct {
 	// Requeue tells the Controller to perform a ratelimited requeue
@@ -49,6 +56,13 @@ type Result struct {
 	// If Priority is not set the original Priority of the request is preserved.
 	// Note: Priority is only respected if the controller is using a priorityqueue.PriorityQueue.
 	Priority *int
+
+	// NoOp reports that the reconciliation didn't change anything, e.g. because the
+	// object was already in its desired state. Successful reconciliations that set
+	// it are counted by the controller_runtime_reconcile_noop_total metric, which
+	// allows to quantify reconciliations that could have been filtered out, e.g. by
+	// predicates. It doesn't affect how the request is requeued.
+	NoOp bool
 }
 
 // IsZero returns true if this result is empty.
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package results helps reconcilers that are structured as a pipeline of
+// sub-reconcilers, or phases, to merge the results and errors of their phases into
+// the result of the reconciler.
+package results
+
+import (
+	"context"
+	"errors"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Phase is a sub-reconciler of a reconciler.
+type Phase func(ctx context.Context) (reconcile.Result, error)
+
+// Aggregator merges the results and errors of the phases of a reconciler. Its zero
+// value is ready to use and halts on the first error.
+//
+// The aggregated result requeues after the shortest RequeueAfter of all phases and
+// with the highest Priority, it is a NoOp if the results of all phases are. The
+// aggregated error combines the errors of all phases, it is only terminal if all of
+// them are terminal.
+type Aggregator struct {
+	// ContinueOnError makes the phases following a failed phase run, e.g. if the
+	// phases are independent of each other.
+	ContinueOnError bool
+
+	result reconcile.Result
+	errs   []error
+	halted bool
+	added  bool
+}
+
+// Add merges the result and error of a phase and returns whether the next phase
+// should run.
+func (a *Aggregator) Add(result reconcile.Result, err error) bool {
+	if result.RequeueAfter > 0 && (a.result.RequeueAfter == 0 || result.RequeueAfter < a.result.RequeueAfter) {
+		a.result.RequeueAfter = result.RequeueAfter
+	}
+	a.result.Requeue = a.result.Requeue || result.Requeue //nolint:staticcheck // We have to handle Requeue until it is removed
+	if result.Priority != nil && (a.result.Priority == nil || *result.Priority > *a.result.Priority) {
+		a.result.Priority = result.Priority
+	}
+	a.result.NoOp = result.NoOp && (a.result.NoOp || !a.added)
+	a.added = true
+
+	if err != nil {
+		a.errs = append(a.errs, err)
+		if !a.ContinueOnError {
+			a.halted = true
+		}
+	}
+	return !a.halted
+}
+
+// Halt stops the pipeline without an error, e.g. because a phase has to wait for
+// another object. The phases following the current one don't run.
+func (a *Aggregator) Halt() {
+	a.halted = true
+}
+
+// Halted returns whether the pipeline was halted.
+func (a *Aggregator) Halted() bool {
+	return a.halted
+}
+
+// Run runs the phases in order until one of them halts the pipeline and returns the
+// aggregated result.
+func (a *Aggregator) Run(ctx context.Context, phases ...Phase) (reconcile.Result, error) {
+	for _, phase := range phases {
+		if a.halted {
+			break
+		}
+		a.Add(phase(ctx))
+	}
+	return a.Result()
+}
+
+// Result returns the aggregated result and error. The error is a TerminalError if
+// all errors are terminal. Otherwise, errors.Is and errors.As only match the errors
+// that are not terminal, so that the request is retried.
+func (a *Aggregator) Result() (reconcile.Result, error) {
+	if len(a.errs) == 0 {
+		return a.result, nil
+	}
+	if len(a.errs) == 1 {
+		return a.result, a.errs[0]
+	}
+
+	var retriable []error
+	for _, err := range a.errs {
+		if !errors.Is(err, reconcile.TerminalError(nil)) {
+			retriable = append(retriable, err)
+		}
+	}
+	if len(retriable) == 0 {
+		return a.result, reconcile.TerminalError(kerrors.NewAggregate(a.errs))
+	}
+	return a.result, &aggregateError{Aggregate: kerrors.NewAggregate(a.errs), retriable: retriable}
+}
+
+// aggregateError is the error of phases of which only some failed terminally. It
+// reports the messages of all errors but only unwraps to the retriable ones, so that
+// the controller doesn't consider it terminal.
+type aggregateError struct {
+	kerrors.Aggregate
+	retriable []error
+}
+
+func (e *aggregateError) Is(target error) bool {
+	for _, err := range e.retriable {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *aggregateError) Unwrap() []error {
+	return e.retriable
+}
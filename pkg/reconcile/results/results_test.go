@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package results_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile/results"
+)
+
+var _ = Describe("Aggregator", func() {
+	phase := func(result reconcile.Result, err error, ran *[]int, i int) results.Phase {
+		return func(context.Context) (reconcile.Result, error) {
+			*ran = append(*ran, i)
+			return result, err
+		}
+	}
+
+	It("should requeue after the shortest duration and with the highest priority", func() {
+		var agg results.Aggregator
+		Expect(agg.Add(reconcile.Result{RequeueAfter: time.Minute, Priority: new(1)}, nil)).To(BeTrue())
+		Expect(agg.Add(reconcile.Result{}, nil)).To(BeTrue())
+		Expect(agg.Add(reconcile.Result{RequeueAfter: time.Second, Priority: new(5)}, nil)).To(BeTrue())
+		Expect(agg.Add(reconcile.Result{RequeueAfter: time.Hour, Priority: new(2)}, nil)).To(BeTrue())
+
+		result, err := agg.Result()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+		Expect(result.Priority).To(HaveValue(Equal(5)))
+	})
+
+	It("should only be a no-op if all phases are", func() {
+		var agg results.Aggregator
+		result, _ := agg.Result()
+		Expect(result.NoOp).To(BeFalse())
+
+		Expect(agg.Add(reconcile.Result{NoOp: true}, nil)).To(BeTrue())
+		Expect(agg.Add(reconcile.Result{NoOp: true}, nil)).To(BeTrue())
+		result, _ = agg.Result()
+		Expect(result.NoOp).To(BeTrue())
+
+		Expect(agg.Add(reconcile.Result{}, nil)).To(BeTrue())
+		Expect(agg.Add(reconcile.Result{NoOp: true}, nil)).To(BeTrue())
+		result, _ = agg.Result()
+		Expect(result.NoOp).To(BeFalse())
+	})
+
+	It("should halt on the first error by default", func(ctx SpecContext) {
+		var agg results.Aggregator
+		var ran []int
+		expected := errors.New("failed")
+		result, err := agg.Run(ctx,
+			phase(reconcile.Result{RequeueAfter: time.Minute}, nil, &ran, 0),
+			phase(reconcile.Result{}, expected, &ran, 1),
+			phase(reconcile.Result{}, nil, &ran, 2),
+		)
+		Expect(err).To(MatchError(expected))
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(ran).To(Equal([]int{0, 1}))
+		Expect(agg.Halted()).To(BeTrue())
+	})
+
+	It("should run all phases with ContinueOnError and combine their errors", func(ctx SpecContext) {
+		agg := results.Aggregator{ContinueOnError: true}
+		var ran []int
+		first, second := errors.New("first"), errors.New("second")
+		_, err := agg.Run(ctx,
+			phase(reconcile.Result{}, first, &ran, 0),
+			phase(reconcile.Result{}, nil, &ran, 1),
+			phase(reconcile.Result{}, second, &ran, 2),
+		)
+		Expect(ran).To(Equal([]int{0, 1, 2}))
+		Expect(err).To(MatchError(first))
+		Expect(err).To(MatchError(second))
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeFalse())
+	})
+
+	It("should stop running phases once a phase halts", func(ctx SpecContext) {
+		var agg results.Aggregator
+		var ran []int
+		result, err := agg.Run(ctx,
+			phase(reconcile.Result{}, nil, &ran, 0),
+			func(context.Context) (reconcile.Result, error) {
+				ran = append(ran, 1)
+				agg.Halt()
+				return reconcile.Result{RequeueAfter: time.Second}, nil
+			},
+			phase(reconcile.Result{}, nil, &ran, 2),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Second))
+		Expect(ran).To(Equal([]int{0, 1}))
+	})
+
+	It("should only return a terminal error if all errors are terminal", func() {
+		terminal := reconcile.TerminalError(errors.New("terminal"))
+		retriable := errors.New("retriable")
+
+		agg := results.Aggregator{ContinueOnError: true}
+		agg.Add(reconcile.Result{}, terminal)
+		agg.Add(reconcile.Result{}, reconcile.TerminalError(errors.New("also terminal")))
+		_, err := agg.Result()
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+
+		agg = results.Aggregator{ContinueOnError: true}
+		agg.Add(reconcile.Result{}, terminal)
+		agg.Add(reconcile.Result{}, retriable)
+		_, err = agg.Result()
+		Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeFalse())
+		Expect(err).To(MatchError(retriable))
+		Expect(err.Error()).To(ContainSubstring("terminal error: terminal"))
+	})
+})
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package timeslice lets long reconciles run in time slices. A reconcile that uses up
+// its slice checkpoints its progress and yields its worker back to the queue, the
+// request is requeued and the next reconcile of it continues from the checkpoint.
+// This prevents reconciles that take minutes, e.g. because they migrate many
+// external resources, from blocking workers that other requests wait for, and from
+// blocking the shutdown of the manager:
+//
+//	type migration struct {
+//		Step   int
+//		Cursor string
+//	}
+//
+//	slicer := timeslice.New[reconcile.Request, migration](timeslice.Options{Slice: 10 * time.Second})
+//
+//	func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+//		return slicer.Reconcile(ctx, req, func(ctx context.Context, m *migration) (reconcile.Result, error) {
+//			return reconcile.Result{}, timeslice.Steps(ctx, &m.Step, r.prepare, r.migrate, r.cleanup)
+//		})
+//	}
+//
+// Checkpoints are only kept in memory, they are lost when the process restarts or
+// another replica becomes the leader, and every reconcile may see a new state of the
+// object. Reconciles must be correct when they start from scratch, the checkpoint
+// only allows them to skip work that was done already.
+package timeslice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const defaultYieldRequeueAfter = time.Millisecond
+
+// ErrYield is returned by a Func to yield: its checkpoint is kept and the request is
+// requeued. Use Yield to only yield when the time slice is used up.
+var ErrYield = errors.New("reconcile yielded its time slice")
+
+// Options are the options of a Slicer.
+type Options struct {
+	// Slice is the time a reconcile may run before ShouldYield returns true. A zero
+	// Slice never expires, reconciles then only yield when the context is done.
+	Slice time.Duration
+
+	// YieldRequeueAfter is the time after which a request that yielded is requeued.
+	// It is added to the back of the queue, behind the requests that wait already.
+	// Defaults to one millisecond.
+	YieldRequeueAfter time.Duration
+
+	// YieldPriority is the priority a request that yielded is requeued with, e.g. a
+	// low priority lets events of other objects go first. It is only respected if the
+	// controller uses a priorityqueue.PriorityQueue. Defaults to the priority of the
+	// request.
+	YieldPriority *int
+}
+
+// Func is a reconcile that runs in time slices. state is the checkpoint of the
+// previous reconcile of the request if it yielded, or the zero value. The Func updates
+// state as it progresses and returns ErrYield to continue in the next reconcile.
+type Func[state any] func(ctx context.Context, state *state) (reconcile.Result, error)
+
+// Slicer runs reconciles in time slices and keeps the checkpoints of the ones that
+// yielded by request. A Slicer is safe for concurrent use and is usually shared by
+// all workers of a controller.
+type Slicer[request comparable, state any] struct {
+	opts Options
+
+	mu          sync.Mutex
+	checkpoints map[request]*state
+}
+
+// New returns a Slicer with the given options.
+func New[request comparable, state any](opts Options) *Slicer[request, state] {
+	if opts.YieldRequeueAfter <= 0 {
+		opts.YieldRequeueAfter = defaultYieldRequeueAfter
+	}
+	return &Slicer[request, state]{
+		opts:        opts,
+		checkpoints: map[request]*state{},
+	}
+}
+
+type sliceEndKey struct{}
+
+// Reconcile runs fn with the checkpoint of req. If fn returns ErrYield, possibly
+// wrapped, the checkpoint is kept and the request is requeued after YieldRequeueAfter.
+// Otherwise the checkpoint is dropped and the result of fn is returned, so a failed
+// reconcile starts from scratch when it is retried.
+func (s *Slicer[request, state]) Reconcile(ctx context.Context, req request, fn Func[state]) (reconcile.Result, error) {
+	s.mu.Lock()
+	checkpoint, resumed := s.checkpoints[req]
+	delete(s.checkpoints, req)
+	s.mu.Unlock()
+	if !resumed {
+		checkpoint = new(state)
+	}
+
+	if s.opts.Slice > 0 {
+		ctx = context.WithValue(ctx, sliceEndKey{}, time.Now().Add(s.opts.Slice))
+	}
+	logger := log.FromContext(ctx)
+	if resumed {
+		logger.V(1).Info("Resuming reconcile from checkpoint")
+	}
+
+	result, err := fn(ctx, checkpoint)
+	if !errors.Is(err, ErrYield) {
+		return result, err
+	}
+
+	s.mu.Lock()
+	s.checkpoints[req] = checkpoint
+	s.mu.Unlock()
+	logger.V(1).Info("Reconcile yielded, requeueing it", "requeueAfter", s.opts.YieldRequeueAfter)
+	return reconcile.Result{RequeueAfter: s.opts.YieldRequeueAfter, Priority: s.opts.YieldPriority}, nil
+}
+
+// Forget drops the checkpoint of req, e.g. when the object of the request was deleted.
+func (s *Slicer[request, state]) Forget(req request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkpoints, req)
+}
+
+// ShouldYield returns whether the time slice of the reconcile that ctx belongs to is
+// used up or ctx is done, e.g. because the manager is shutting down. It returns
+// false for contexts of reconciles that don't run in a Slicer and aren't done.
+func ShouldYield(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	end, ok := ctx.Value(sliceEndKey{}).(time.Time)
+	return ok && !time.Now().Before(end)
+}
+
+// Yield returns ErrYield if ShouldYield returns true and nil otherwise. It is called
+// at points where the reconcile can continue from its checkpoint, e.g.
+//
+//	for ; m.Next < len(items); m.Next++ {
+//		if err := timeslice.Yield(ctx); err != nil {
+//			return reconcile.Result{}, err
+//		}
+//		...
+//	}
+func Yield(ctx context.Context) error {
+	if ShouldYield(ctx) {
+		return ErrYield
+	}
+	return nil
+}
+
+// Steps runs resumable steps in order, starting at the step with the index *next,
+// which it advances after each step that succeeds. It yields after a step if the time
+// slice is used up, so every reconcile makes progress and a step that is started
+// always runs to completion. Steps that are long themselves can call Yield and
+// continue in the next reconcile, the step is then run again.
+func Steps(ctx context.Context, next *int, steps ...func(ctx context.Context) error) error {
+	for *next < len(steps) {
+		if err := steps[*next](ctx); err != nil {
+			return err
+		}
+		*next++
+		if *next < len(steps) {
+			if err := Yield(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timeslice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type checkpoint struct {
+	Step int
+}
+
+var _ = Describe("Slicer", func() {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "foo"}}
+
+	steps := func(ran *[]int, n int) []func(context.Context) error {
+		var steps []func(context.Context) error
+		for i := range n {
+			steps = append(steps, func(context.Context) error {
+				*ran = append(*ran, i)
+				return nil
+			})
+		}
+		return steps
+	}
+
+	It("should resume the steps of a reconcile that yielded", func(ctx SpecContext) {
+		slicer := New[reconcile.Request, checkpoint](Options{Slice: time.Nanosecond})
+		var ran []int
+		reconcileSteps := func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			return reconcile.Result{}, Steps(ctx, &c.Step, steps(&ran, 3)...)
+		}
+
+		for range 2 {
+			result, err := slicer.Reconcile(ctx, req, reconcileSteps)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Millisecond))
+		}
+		result, err := slicer.Reconcile(ctx, req, reconcileSteps)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(ran).To(Equal([]int{0, 1, 2}))
+
+		By("starting from scratch once the reconcile completed")
+		_, err = slicer.Reconcile(ctx, req, reconcileSteps)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ran).To(Equal([]int{0, 1, 2, 0}))
+	})
+
+	It("should run all steps if the slice isn't used up", func(ctx SpecContext) {
+		slicer := New[reconcile.Request, checkpoint](Options{Slice: time.Hour})
+		var ran []int
+		result, err := slicer.Reconcile(ctx, req, func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			return reconcile.Result{}, Steps(ctx, &c.Step, steps(&ran, 3)...)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsZero()).To(BeTrue())
+		Expect(ran).To(Equal([]int{0, 1, 2}))
+	})
+
+	It("should yield when the context is done", func() {
+		slicer := New[reconcile.Request, checkpoint](Options{YieldPriority: new(-10)})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		result, err := slicer.Reconcile(ctx, req, func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			c.Step = 5
+			return reconcile.Result{}, fmt.Errorf("stopping: %w", Yield(ctx))
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Millisecond, Priority: new(-10)}))
+
+		_, _ = slicer.Reconcile(context.Background(), req, func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			Expect(c.Step).To(Equal(5))
+			Expect(ShouldYield(ctx)).To(BeFalse())
+			return reconcile.Result{}, nil
+		})
+	})
+
+	It("should drop the checkpoint of a failed reconcile", func(ctx SpecContext) {
+		slicer := New[reconcile.Request, checkpoint](Options{Slice: time.Nanosecond})
+		failing := errors.New("failing")
+		calls := 0
+		reconcileSteps := func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			return reconcile.Result{}, Steps(ctx, &c.Step,
+				func(context.Context) error { return nil },
+				func(context.Context) error {
+					calls++
+					return failing
+				},
+			)
+		}
+
+		_, err := slicer.Reconcile(ctx, req, reconcileSteps)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = slicer.Reconcile(ctx, req, reconcileSteps)
+		Expect(err).To(MatchError(failing))
+		_, err = slicer.Reconcile(ctx, req, reconcileSteps)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should forget checkpoints", func(ctx SpecContext) {
+		slicer := New[reconcile.Request, checkpoint](Options{})
+		_, _ = slicer.Reconcile(ctx, req, func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			c.Step = 1
+			return reconcile.Result{}, ErrYield
+		})
+		slicer.Forget(req)
+		_, _ = slicer.Reconcile(ctx, req, func(ctx context.Context, c *checkpoint) (reconcile.Result, error) {
+			Expect(c.Step).To(BeZero())
+			return reconcile.Result{}, nil
+		})
+	})
+})
@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package render renders the child objects of a controller from Go templates, instead of
+building them from YAML strings or from deeply nested struct literals.
+
+A Template renders one or more YAML documents and decodes every one of them strictly:
+objects of types that are registered in the scheme are decoded into their Go types and
+fields that don't exist in them, as well as duplicate fields, are errors. Errors name the
+template, the document and its line in the rendered output, and the path of the field:
+
+	var deploymentTemplate = render.Must(render.Parse("deployment", `
+	apiVersion: apps/v1
+	kind: Deployment
+	metadata:
+	  name: {{ .Name }}
+	  namespace: {{ .Namespace }}
+	spec:
+	  replicas: {{ .Spec.Replicas }}
+	  template:
+	    spec:
+	      containers:
+	      - name: app
+	        image: {{ .Spec.Image | quote }}
+	`, render.Options{Scheme: mgr.GetScheme()}))
+
+	deployment, err := render.Object[*appsv1.Deployment](deploymentTemplate, app)
+
+Templates fail on missing map keys, and provide the toYaml, toJson, indent, nindent,
+quote, default and required functions in addition to the builtin ones.
+*/
+package render
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	sigsjson "sigs.k8s.io/json"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Options are the options of a Template.
+type Options struct {
+	// Scheme is the scheme the rendered objects are decoded with. Objects of types
+	// that are registered in it are returned as their Go types and are checked
+	// against them.
+	//
+	// Defaults to the client-go scheme.
+	Scheme *runtime.Scheme
+
+	// Funcs are added to the functions of the template, they take precedence over
+	// the functions the package provides.
+	Funcs template.FuncMap
+
+	// AllowUnstructured allows objects of types that are not registered in the scheme,
+	// e.g. of CustomResourceDefinitions without Go types. They are returned as
+	// *unstructured.Unstructured and are not checked.
+	//
+	// Defaults to false, which makes them an error.
+	AllowUnstructured bool
+
+	// Validate is called with every rendered object, e.g. to check them against the
+	// OpenAPI schema of their type, and fails the rendering if it returns an error.
+	Validate func(obj client.Object) error
+}
+
+// Template renders objects from a Go template. It is safe for concurrent use.
+type Template struct {
+	name     string
+	template *template.Template
+	opts     Options
+}
+
+// Parse parses a template that renders one or more YAML documents, separated by
+// "---" lines, that are Kubernetes objects.
+func Parse(name, text string, opts Options) (*Template, error) {
+	if opts.Scheme == nil {
+		opts.Scheme = scheme.Scheme
+	}
+	t, err := template.New(name).Option("missingkey=error").Funcs(funcs).Funcs(opts.Funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %q: %w", name, err)
+	}
+	return &Template{name: name, template: t, opts: opts}, nil
+}
+
+// Must returns t, and panics if err is not nil. It allows to parse templates in
+// variable initializations.
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Error is the error of rendering a document of a Template.
+type Error struct {
+	// Template is the name of the template.
+	Template string
+
+	// Document is the index of the document in the rendered output, starting at 0.
+	Document int
+
+	// Line is the line in the rendered output the document starts at, starting at 1.
+	Line int
+
+	// Object describes the object the document is, e.g. "apps/v1, Kind=Deployment default/app".
+	// It is empty if the document couldn't be decoded far enough.
+	Object string
+
+	// Err is the error.
+	Err error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("template %q, document %d at line %d", e.Template, e.Document, e.Line)
+	if e.Object != "" {
+		msg += " (" + e.Object + ")"
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Render renders the template with data and returns the objects of its documents in
+// order. Empty documents, e.g. of conditional blocks, are skipped. Errors of documents
+// are returned as *Error.
+func (t *Template) Render(data any) ([]client.Object, error) {
+	var out bytes.Buffer
+	if err := t.template.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %q: %w", t.name, err)
+	}
+
+	var objs []client.Object
+	for i, doc := range splitDocuments(out.String()) {
+		obj, err := t.decode([]byte(doc.text))
+		if err != nil {
+			err.Template, err.Document, err.Line = t.name, i, doc.line
+			return nil, err
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// Object renders a template that renders a single object of type T, e.g. *appsv1.Deployment.
+func Object[T client.Object](t *Template, data any) (T, error) {
+	var zero T
+	objs, err := t.Render(data)
+	if err != nil {
+		return zero, err
+	}
+	if len(objs) != 1 {
+		return zero, fmt.Errorf("template %q rendered %d objects instead of one", t.name, len(objs))
+	}
+	obj, ok := objs[0].(T)
+	if !ok {
+		return zero, fmt.Errorf("template %q rendered a %T instead of a %T", t.name, objs[0], zero)
+	}
+	return obj, nil
+}
+
+// decode decodes a document into an object, it returns nil for empty documents.
+func (t *Template) decode(doc []byte) (client.Object, *Error) {
+	data, err := yaml.YAMLToJSONStrict(doc)
+	if err != nil {
+		return nil, &Error{Err: err}
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(data); err != nil {
+		return nil, &Error{Err: err}
+	}
+	gvk := u.GroupVersionKind()
+	desc := gvk.String() + " " + client.ObjectKeyFromObject(u).String()
+	if u.GetName() == "" && u.GetGenerateName() == "" {
+		return nil, &Error{Object: desc, Err: errors.New("metadata.name is required")}
+	}
+
+	var obj client.Object = u
+	if t.opts.Scheme.Recognizes(gvk) {
+		obj, err = t.decodeTyped(gvk, data)
+		if err != nil {
+			return nil, &Error{Object: desc, Err: err}
+		}
+	} else if !t.opts.AllowUnstructured {
+		return nil, &Error{Object: desc, Err: fmt.Errorf("%s is not registered in the scheme", gvk)}
+	}
+
+	if t.opts.Validate != nil {
+		if err := t.opts.Validate(obj); err != nil {
+			return nil, &Error{Object: desc, Err: err}
+		}
+	}
+	return obj, nil
+}
+
+// decodeTyped decodes data into the Go type of gvk, failing for unknown fields.
+func (t *Template) decodeTyped(gvk schema.GroupVersionKind, data []byte) (client.Object, error) {
+	runtimeObj, err := t.opts.Scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := runtimeObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", runtimeObj)
+	}
+	strictErrs, err := sigsjson.UnmarshalStrict(data, obj, sigsjson.DisallowDuplicateFields, sigsjson.DisallowUnknownFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(strictErrs) > 0 {
+		return nil, errors.Join(strictErrs...)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return obj, nil
+}
+
+// document is a document of the rendered output and the line it starts at.
+type document struct {
+	text string
+	line int
+}
+
+// splitDocuments splits YAML into its documents.
+func splitDocuments(text string) []document {
+	var (
+		docs    []document
+		current strings.Builder
+		start   = 1
+	)
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t\r\n") == "---" {
+			docs = append(docs, document{text: current.String(), line: start})
+			current.Reset()
+			start = i + 2
+			continue
+		}
+		current.WriteString(line)
+	}
+	return append(docs, document{text: current.String(), line: start})
+}
+
+var funcs = template.FuncMap{
+	"toYaml": func(v any) (string, error) {
+		data, err := yaml.Marshal(v)
+		return strings.TrimSuffix(string(data), "\n"), err
+	},
+	"toJson": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"nindent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return "\n" + pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"quote": func(v any) string {
+		return strconv.Quote(fmt.Sprint(v))
+	},
+	"default": func(def, v any) any {
+		if isEmpty(v) {
+			return def
+		}
+		return v
+	},
+	"required": func(msg string, v any) (any, error) {
+		if isEmpty(v) {
+			return nil, errors.New(msg)
+		}
+		return v, nil
+	},
+}
+
+// isEmpty returns whether v is nil or the zero value of its type, or an empty
+// string, slice or map.
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	truth, _ := template.IsTrue(v)
+	return !truth
+}
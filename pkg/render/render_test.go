@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render_test
+
+import (
+	"errors"
+	texttemplate "text/template"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/render"
+)
+
+type app struct {
+	Name     string
+	Replicas int
+	Image    string
+	Labels   map[string]string
+	Config   bool
+}
+
+const appTemplate = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+  namespace: default
+  labels: {{ .Labels | toYaml | nindent 4 }}
+spec:
+  replicas: {{ .Replicas }}
+  selector:
+    matchLabels: {{ toJson .Labels }}
+  template:
+    spec:
+      containers:
+      - name: app
+        image: {{ required "an image is required" .Image | quote }}
+{{- if .Config }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Name }}
+  namespace: default
+data:
+  replicas: {{ .Replicas | quote }}
+{{- end }}
+`
+
+var _ = Describe("Template", func() {
+	var data app
+
+	BeforeEach(func() {
+		data = app{Name: "app", Replicas: 2, Image: "app:v1", Labels: map[string]string{"app": "app"}}
+	})
+
+	It("should render typed objects", func() {
+		t, err := render.Parse("app", appTemplate, render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		data.Config = true
+		objs, err := t.Render(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(2))
+
+		deployment, ok := objs[0].(*appsv1.Deployment)
+		Expect(ok).To(BeTrue())
+		Expect(deployment.Name).To(Equal("app"))
+		Expect(deployment.Labels).To(Equal(map[string]string{"app": "app"}))
+		Expect(deployment.Spec.Replicas).To(Equal(new(int32(2))))
+		Expect(deployment.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "app"}))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("app:v1"))
+		Expect(deployment.GroupVersionKind()).To(Equal(appsv1.SchemeGroupVersion.WithKind("Deployment")))
+
+		configMap, ok := objs[1].(*corev1.ConfigMap)
+		Expect(ok).To(BeTrue())
+		Expect(configMap.Data).To(Equal(map[string]string{"replicas": "2"}))
+	})
+
+	It("should skip empty documents", func() {
+		t, err := render.Parse("app", appTemplate+"---\n# nothing\n", render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		objs, err := t.Render(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+	})
+
+	It("should render a single object of a type", func() {
+		t, err := render.Parse("app", appTemplate, render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment, err := render.Object[*appsv1.Deployment](t, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deployment.Name).To(Equal("app"))
+
+		_, err = render.Object[*corev1.ConfigMap](t, data)
+		Expect(err).To(MatchError(ContainSubstring("instead of a *v1.ConfigMap")))
+		data.Config = true
+		_, err = render.Object[*appsv1.Deployment](t, data)
+		Expect(err).To(MatchError(ContainSubstring("rendered 2 objects")))
+	})
+
+	It("should report unknown fields with their path and the document", func() {
+		t, err := render.Parse("app", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: b
+spec:
+  replica: 1
+`, render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = t.Render(nil)
+		renderErr := &render.Error{}
+		Expect(errors.As(err, &renderErr)).To(BeTrue())
+		Expect(renderErr.Document).To(Equal(1))
+		Expect(renderErr.Line).To(Equal(6))
+		Expect(renderErr.Object).To(Equal("apps/v1, Kind=Deployment /b"))
+		Expect(err.Error()).To(ContainSubstring(`unknown field "spec.replica"`))
+	})
+
+	It("should report duplicate fields and invalid values", func() {
+		t, err := render.Parse("dup", "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  name: b\n", render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = t.Render(nil)
+		Expect(err).To(MatchError(ContainSubstring(`key "name" already set`)))
+
+		t, err = render.Parse("invalid", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: a\nspec:\n  replicas: many\n", render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = t.Render(nil)
+		Expect(err).To(MatchError(ContainSubstring("Deployment /a")))
+	})
+
+	It("should require a name", func() {
+		t, err := render.Parse("unnamed", "apiVersion: v1\nkind: ConfigMap\n", render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = t.Render(nil)
+		Expect(err).To(MatchError(ContainSubstring("metadata.name is required")))
+	})
+
+	It("should only render types that are not in the scheme if allowed", func() {
+		text := "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: a\nspec:\n  size: 1\n"
+		t, err := render.Parse("widget", text, render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = t.Render(nil)
+		Expect(err).To(MatchError(ContainSubstring("not registered in the scheme")))
+
+		t, err = render.Parse("widget", text, render.Options{AllowUnstructured: true})
+		Expect(err).NotTo(HaveOccurred())
+		objs, err := t.Render(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objs).To(HaveLen(1))
+		Expect(objs[0]).To(BeAssignableToTypeOf(&unstructured.Unstructured{}))
+	})
+
+	It("should fail for template errors and missing keys", func() {
+		_, err := render.Parse("broken", "{{ .Name ", render.Options{})
+		Expect(err).To(MatchError(ContainSubstring(`failed to parse template "broken"`)))
+
+		t, err := render.Parse("missing", "name: {{ .missing }}", render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = t.Render(map[string]string{})
+		Expect(err).To(MatchError(ContainSubstring("missing")))
+
+		t, err = render.Parse("app", appTemplate, render.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		data.Image = ""
+		_, err = t.Render(data)
+		Expect(err).To(MatchError(ContainSubstring("an image is required")))
+	})
+
+	It("should validate the rendered objects and use custom functions", func() {
+		t, err := render.Parse("app", appTemplate, render.Options{
+			Funcs: texttemplate.FuncMap{"quote": func(v any) string { return "'custom'" }},
+			Validate: func(obj client.Object) error {
+				if obj.GetNamespace() != "default" {
+					return errors.New("unexpected namespace")
+				}
+				if d, ok := obj.(*appsv1.Deployment); ok && *d.Spec.Replicas > 3 {
+					return errors.New("too many replicas")
+				}
+				return nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment, err := render.Object[*appsv1.Deployment](t, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("custom"))
+
+		data.Replicas = 4
+		_, err = t.Render(data)
+		Expect(err).To(MatchError(ContainSubstring("too many replicas")))
+	})
+})
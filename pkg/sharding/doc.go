@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package sharding allows multiple replicas of a manager to split reconcile work
+between each other, for active-active HA.
+
+Every replica runs a Coordinator that maintains a Lease for the replica and
+watches the Leases of the other replicas of the same shard group. The keyspace
+is split between all live replicas by namespace or by a hash of the namespaced
+name of the reconciled object, using rendezvous hashing so that only the keys
+of a replica joining or leaving are moved when replicas come and go.
+
+Controllers filter the watch of the reconciled type with Coordinator.Predicate
+and secondary watches, e.g. through Owns() or Watches(), with
+Coordinator.EventHandler, which filters on the requests events map to. They drop
+requests that were queued before a rebalance with Coordinator.Reconciler, which
+also defers requests received before the replica joined the shard group, and
+pick up keys a replica became responsible for through Coordinator.Source.
+
+Ownership is eventually consistent: for a short time after a rebalance two
+replicas may reconcile the same object, so reconcilers must be idempotent.
+*/
+package sharding
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("sharding")
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EventHandler wraps the given event handler and drops the requests it enqueues
+// that this replica is not responsible for. Unlike Predicate, it filters on the
+// requests an event maps to rather than on the object of the event, so it must
+// be used for secondary watches, e.g. with handler.EnqueueRequestForOwner or
+// handler.EnqueueRequestsFromMapFunc.
+func (c *Coordinator) EventHandler(h handler.EventHandler) handler.EventHandler {
+	return &shardedEventHandler{coordinator: c, handler: h}
+}
+
+type shardedEventHandler struct {
+	coordinator *Coordinator
+	handler     handler.EventHandler
+}
+
+// Create implements handler.EventHandler.
+func (e *shardedEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handler.Create(ctx, evt, e.coordinator.shardedQueue(q))
+}
+
+// Update implements handler.EventHandler.
+func (e *shardedEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handler.Update(ctx, evt, e.coordinator.shardedQueue(q))
+}
+
+// Delete implements handler.EventHandler.
+func (e *shardedEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handler.Delete(ctx, evt, e.coordinator.shardedQueue(q))
+}
+
+// Generic implements handler.EventHandler.
+func (e *shardedEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handler.Generic(ctx, evt, e.coordinator.shardedQueue(q))
+}
+
+// shardedQueue wraps the queue so that only requests owned by this replica are added,
+// or all of them as long as it isn't part of the shard group.
+// Priority queues are kept as such, so that handlers can still set priorities.
+func (c *Coordinator) shardedQueue(q workqueue.TypedRateLimitingInterface[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	if pq, isPriorityQueue := q.(priorityqueue.PriorityQueue[reconcile.Request]); isPriorityQueue {
+		return &shardedPriorityQueue{PriorityQueue: pq, coordinator: c}
+	}
+	return &shardedRateLimitingQueue{TypedRateLimitingInterface: q, coordinator: c}
+}
+
+type shardedRateLimitingQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	coordinator *Coordinator
+}
+
+func (q *shardedRateLimitingQueue) Add(item reconcile.Request) {
+	if q.coordinator.accepts(item.NamespacedName) {
+		q.TypedRateLimitingInterface.Add(item)
+	}
+}
+
+func (q *shardedRateLimitingQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	if q.coordinator.accepts(item.NamespacedName) {
+		q.TypedRateLimitingInterface.AddAfter(item, duration)
+	}
+}
+
+func (q *shardedRateLimitingQueue) AddRateLimited(item reconcile.Request) {
+	if q.coordinator.accepts(item.NamespacedName) {
+		q.TypedRateLimitingInterface.AddRateLimited(item)
+	}
+}
+
+type shardedPriorityQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	coordinator *Coordinator
+}
+
+func (q *shardedPriorityQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+func (q *shardedPriorityQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration}, item)
+}
+
+func (q *shardedPriorityQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+func (q *shardedPriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	owned := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		if q.coordinator.accepts(item.NamespacedName) {
+			owned = append(owned, item)
+		}
+	}
+	q.PriorityQueue.AddWithOpts(o, owned...)
+}
@@ -0,0 +1,431 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GroupLabel is the label set on the Leases of all replicas of a shard group,
+// its value is the name of the group.
+const GroupLabel = "sharding.controller-runtime.sigs.k8s.io/group"
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewPeriod   = 5 * time.Second
+)
+
+// Strategy determines how the keyspace is split between replicas.
+type Strategy string
+
+const (
+	// StrategyNamespace assigns all objects of a namespace to the same replica.
+	StrategyNamespace Strategy = "Namespace"
+
+	// StrategyHash assigns objects to replicas by the hash of their namespaced name.
+	StrategyHash Strategy = "Hash"
+)
+
+// Options are the arguments for creating a new Coordinator.
+type Options struct {
+	// Name is the name of the shard group. All replicas using the same Name and
+	// Namespace split the keyspace between each other.
+	Name string
+
+	// Namespace is the namespace the Leases of the replicas are created in.
+	Namespace string
+
+	// Identity is the unique identity of this replica.
+	// Defaults to the hostname followed by a random suffix.
+	Identity string
+
+	// Strategy determines how the keyspace is split between replicas.
+	// Defaults to StrategyHash.
+	Strategy Strategy
+
+	// LeaseDuration is the duration after which a replica that didn't renew its
+	// Lease is considered gone and its keys are moved to the remaining replicas.
+	// Defaults to 15 seconds.
+	LeaseDuration *time.Duration
+
+	// RenewPeriod is the interval in which the Lease of this replica is renewed
+	// and the Leases of the other replicas are checked. It must be lower than
+	// LeaseDuration.
+	// Defaults to 5 seconds.
+	RenewPeriod *time.Duration
+}
+
+// Coordinator maintains the Lease of a replica and determines the keys the
+// replica is responsible for. It must be added to a manager, e.g. through
+// mgr.Add, to take part in its shard group.
+type Coordinator struct {
+	client        client.Client
+	name          string
+	namespace     string
+	identity      string
+	strategy      Strategy
+	leaseDuration time.Duration
+	renewPeriod   time.Duration
+
+	now func() time.Time
+
+	// observed are the Leases of the other replicas by holder identity, with the
+	// local time their renewal was observed at. It is only accessed by sync.
+	observed map[string]observedLease
+
+	mu sync.RWMutex
+	// members are the sorted identities of all live replicas, including this one.
+	// It is empty as long as this replica isn't part of the shard group.
+	members     []string
+	lastRenew   time.Time
+	subscribers []chan struct{}
+}
+
+// observedLease is the last observed state of the Lease of another replica. The
+// RenewTime of the Lease is only compared for changes, never with the local clock,
+// so that clock skew between replicas doesn't make members flap.
+type observedLease struct {
+	renewTime     metav1.MicroTime
+	leaseDuration time.Duration
+	observedAt    time.Time
+}
+
+// New returns a new Coordinator. The client is used to manage Leases and should
+// not read from a cache, e.g. one created with client.New(mgr.GetConfig(), ...).
+func New(c client.Client, opts Options) (*Coordinator, error) {
+	if c == nil {
+		return nil, errors.New("must specify client")
+	}
+	if opts.Name == "" {
+		return nil, errors.New("must specify Name")
+	}
+	if opts.Namespace == "" {
+		return nil, errors.New("must specify Namespace")
+	}
+
+	if opts.Identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		opts.Identity = hostname + "_" + string(uuid.NewUUID())
+	}
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyHash
+	}
+	if opts.Strategy != StrategyHash && opts.Strategy != StrategyNamespace {
+		return nil, fmt.Errorf("unknown sharding strategy %q", opts.Strategy)
+	}
+	if opts.LeaseDuration == nil {
+		opts.LeaseDuration = ptr.To(defaultLeaseDuration)
+	}
+	if opts.RenewPeriod == nil {
+		opts.RenewPeriod = ptr.To(defaultRenewPeriod)
+	}
+	if *opts.RenewPeriod <= 0 || *opts.RenewPeriod >= *opts.LeaseDuration {
+		return nil, fmt.Errorf("RenewPeriod (%s) must be greater than zero and lower than LeaseDuration (%s)", *opts.RenewPeriod, *opts.LeaseDuration)
+	}
+
+	return &Coordinator{
+		client:        c,
+		name:          opts.Name,
+		namespace:     opts.Namespace,
+		identity:      opts.Identity,
+		strategy:      opts.Strategy,
+		leaseDuration: *opts.LeaseDuration,
+		renewPeriod:   *opts.RenewPeriod,
+		now:           time.Now,
+		observed:      map[string]observedLease{},
+	}, nil
+}
+
+// Identity returns the identity of this replica.
+func (c *Coordinator) Identity() string {
+	return c.identity
+}
+
+// Members returns the sorted identities of all live replicas of the shard group.
+func (c *Coordinator) Members() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c.members)
+}
+
+// Owns returns true if this replica is responsible for the object with the given
+// namespaced name. It returns false for all objects as long as this replica isn't
+// part of the shard group, e.g. before its Lease was created.
+func (c *Coordinator) Owns(key types.NamespacedName) bool {
+	owned, _ := c.owns(key)
+	return owned
+}
+
+// owns returns whether this replica is responsible for the given key, and whether
+// it is part of the shard group at all.
+func (c *Coordinator) owns(key types.NamespacedName) (owned, joined bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return ownerOf(c.members, c.shardKey(key)) == c.identity, len(c.members) > 0
+}
+
+// accepts returns true if requests for the given key should be queued. As long as
+// this replica isn't part of the shard group its owner is unknown, so the requests
+// are queued and deferred by Reconciler until it is.
+func (c *Coordinator) accepts(key types.NamespacedName) bool {
+	owned, joined := c.owns(key)
+	return owned || !joined
+}
+
+// Predicate returns a predicate that filters out events of objects this replica
+// is not responsible for. It decides by the object of the event, so it is only
+// valid for the watch of the reconciled type, e.g. through For(). Secondary
+// watches must use EventHandler instead.
+//
+// Events received before this replica joined the shard group are let through,
+// so that Reconciler can defer them until it is known whether this replica is
+// responsible for them.
+func (c *Coordinator) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return c.accepts(client.ObjectKeyFromObject(obj))
+	})
+}
+
+// Reconciler wraps the given reconciler and drops requests this replica is not
+// responsible for, e.g. because they were queued before a rebalance. Requests
+// processed while this replica isn't part of the shard group are requeued after
+// the RenewPeriod.
+func (c *Coordinator) Reconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		owned, joined := c.owns(req.NamespacedName)
+		if !joined {
+			return reconcile.Result{RequeueAfter: c.renewPeriod}, nil
+		}
+		if !owned {
+			return reconcile.Result{}, nil
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. All replicas
+// take part in sharding, so the Coordinator doesn't need leader election.
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start maintains the Lease of this replica until the context is cancelled.
+// The Lease is deleted on return, so that the remaining replicas take over the
+// keys of this replica right away.
+func (c *Coordinator) Start(ctx context.Context) error {
+	log.Info("Starting sharding coordinator", "group", c.name, "identity", c.identity)
+
+	ticker := time.NewTicker(c.renewPeriod)
+	defer ticker.Stop()
+	for {
+		if err := c.sync(ctx); err != nil && ctx.Err() == nil {
+			log.Error(err, "Failed to sync shard group", "group", c.name)
+		}
+		select {
+		case <-ctx.Done():
+			c.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync renews the Lease of this replica and updates the live members of the shard group.
+func (c *Coordinator) sync(ctx context.Context) error {
+	now := c.now()
+	if err := c.renew(ctx, now); err != nil {
+		c.mu.RLock()
+		expired := now.Sub(c.lastRenew) > c.leaseDuration
+		c.mu.RUnlock()
+		if expired {
+			// The other replicas consider this one gone, stop processing
+			// the keys they took over.
+			c.setMembers(nil)
+		}
+		return fmt.Errorf("failed to renew lease: %w", err)
+	}
+	c.mu.Lock()
+	c.lastRenew = now
+	c.mu.Unlock()
+
+	leases := &coordinationv1.LeaseList{}
+	if err := c.client.List(ctx, leases, client.InNamespace(c.namespace), client.MatchingLabels{GroupLabel: c.name}); err != nil {
+		return fmt.Errorf("failed to list leases: %w", err)
+	}
+	members := []string{c.identity}
+	observed := make(map[string]observedLease, len(leases.Items))
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == c.identity {
+			continue
+		}
+		if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		holder := *lease.Spec.HolderIdentity
+		record, ok := c.observed[holder]
+		if !ok || !record.renewTime.Equal(lease.Spec.RenewTime) {
+			record = observedLease{renewTime: *lease.Spec.RenewTime, observedAt: now}
+		}
+		record.leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		observed[holder] = record
+		if record.isExpired(now) {
+			continue
+		}
+		members = append(members, holder)
+	}
+	c.observed = observed
+	slices.Sort(members)
+	c.setMembers(slices.Compact(members))
+	return nil
+}
+
+func (c *Coordinator) renew(ctx context.Context, now time.Time) error {
+	lease := &coordinationv1.Lease{}
+	err := c.client.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: c.leaseName()}, lease)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	found := err == nil
+	lease.Namespace = c.namespace
+	lease.Name = c.leaseName()
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	lease.Labels[GroupLabel] = c.name
+	lease.Spec.HolderIdentity = ptr.To(c.identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.leaseDuration / time.Second))
+	// Other replicas only compare the RenewTime for changes, so it doesn't
+	// matter that it is taken from the local clock.
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	if !found {
+		lease.Spec.AcquireTime = &metav1.MicroTime{Time: now}
+		return c.client.Create(ctx, lease)
+	}
+	return c.client.Update(ctx, lease)
+}
+
+// release deletes the Lease of this replica.
+func (c *Coordinator) release() {
+	c.setMembers(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.renewPeriod)
+	defer cancel()
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: c.namespace, Name: c.leaseName()}}
+	if err := c.client.Delete(ctx, lease); client.IgnoreNotFound(err) != nil {
+		log.Error(err, "Failed to release lease", "group", c.name)
+	}
+}
+
+// setMembers updates the live members and notifies subscribers if they changed.
+func (c *Coordinator) setMembers(members []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slices.Equal(c.members, members) {
+		return
+	}
+	log.Info("Shard group members changed", "group", c.name, "members", members)
+	c.members = members
+	for _, ch := range c.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// subscribe returns the current members and a channel that receives a notification
+// whenever they change. The returned function must be called to unsubscribe.
+func (c *Coordinator) subscribe() ([]string, <-chan struct{}, func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan struct{}, 1)
+	c.subscribers = append(c.subscribers, ch)
+	unsubscribe := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.subscribers = slices.DeleteFunc(c.subscribers, func(s chan struct{}) bool {
+			return s == ch
+		})
+	}
+	return slices.Clone(c.members), ch, unsubscribe
+}
+
+// leaseName returns the name of the Lease of this replica. The identity is hashed as it
+// isn't necessarily a valid object name, using 128 bits of a SHA-256 so that the Leases
+// of different replicas don't collide.
+func (c *Coordinator) leaseName() string {
+	sum := sha256.Sum256([]byte(c.identity))
+	return c.name + "-" + hex.EncodeToString(sum[:16])
+}
+
+func (c *Coordinator) shardKey(key types.NamespacedName) string {
+	if c.strategy == StrategyNamespace {
+		return key.Namespace
+	}
+	return key.String()
+}
+
+// isExpired returns true if the Lease wasn't observed to be renewed within its
+// duration, measured with the local clock.
+func (l observedLease) isExpired(now time.Time) bool {
+	return l.observedAt.Add(l.leaseDuration).Before(now)
+}
+
+// ownerOf returns the member responsible for the given key using rendezvous hashing,
+// which only moves the keys of a member that joins or leaves.
+func ownerOf(members []string, key string) string {
+	var (
+		owner     string
+		bestScore uint64
+	)
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+		if score := h.Sum64(); owner == "" || score > bestScore {
+			owner, bestScore = member, score
+		}
+	}
+	return owner
+}
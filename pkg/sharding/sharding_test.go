@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Coordinator", func() {
+	var (
+		c    client.Client
+		keys []types.NamespacedName
+	)
+
+	newCoordinator := func(identity string, strategy Strategy) *Coordinator {
+		coordinator, err := New(c, Options{Name: "shards", Namespace: "default", Identity: identity, Strategy: strategy})
+		Expect(err).NotTo(HaveOccurred())
+		return coordinator
+	}
+
+	owned := func(coordinator *Coordinator) []types.NamespacedName {
+		var result []types.NamespacedName
+		for _, key := range keys {
+			if coordinator.Owns(key) {
+				result = append(result, key)
+			}
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().Build()
+		keys = nil
+		for i := range 50 {
+			keys = append(keys, types.NamespacedName{Namespace: fmt.Sprintf("ns-%d", i%5), Name: fmt.Sprintf("obj-%d", i)})
+		}
+	})
+
+	It("should reject invalid options", func() {
+		_, err := New(c, Options{Namespace: "default"})
+		Expect(err).To(MatchError(ContainSubstring("Name")))
+
+		_, err = New(c, Options{Name: "shards", Namespace: "default", Strategy: "Random"})
+		Expect(err).To(MatchError(ContainSubstring("unknown sharding strategy")))
+
+		_, err = New(c, Options{Name: "shards", Namespace: "default", RenewPeriod: ptr.To(time.Minute)})
+		Expect(err).To(MatchError(ContainSubstring("must be greater than zero and lower than LeaseDuration")))
+	})
+
+	It("should defer requests received before joining the shard group", func(ctx SpecContext) {
+		a := newCoordinator("a", StrategyHash)
+		Expect(owned(a)).To(BeEmpty())
+
+		obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: keys[0].Namespace, Name: keys[0].Name}}
+		Expect(a.Predicate().Create(event.CreateEvent{Object: obj})).To(BeTrue())
+
+		var reconciled int
+		r := a.Reconciler(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+			reconciled++
+			return reconcile.Result{}, nil
+		}))
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: keys[0]})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(defaultRenewPeriod))
+		Expect(reconciled).To(BeZero())
+
+		Expect(a.sync(ctx)).To(Succeed())
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: keys[0]})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciled).To(Equal(1))
+	})
+
+	It("should split the keyspace between all live replicas", func(ctx SpecContext) {
+		a := newCoordinator("a", StrategyHash)
+		b := newCoordinator("b", StrategyHash)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+
+		Expect(a.Members()).To(Equal([]string{"a", "b"}))
+		Expect(b.Members()).To(Equal([]string{"a", "b"}))
+
+		ownedByA, ownedByB := owned(a), owned(b)
+		Expect(ownedByA).NotTo(BeEmpty())
+		Expect(ownedByB).NotTo(BeEmpty())
+		Expect(len(ownedByA) + len(ownedByB)).To(Equal(len(keys)))
+		for _, key := range ownedByA {
+			Expect(ownedByB).NotTo(ContainElement(key))
+		}
+
+		By("moving the keys of a replica that left to the remaining ones")
+		b.release()
+		Expect(owned(b)).To(BeEmpty())
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.Members()).To(Equal([]string{"a"}))
+		Expect(owned(a)).To(HaveLen(len(keys)))
+	})
+
+	It("should keep all objects of a namespace on the same replica", func(ctx SpecContext) {
+		a := newCoordinator("a", StrategyNamespace)
+		b := newCoordinator("b", StrategyNamespace)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+
+		for _, key := range keys {
+			sameNamespace := types.NamespacedName{Namespace: key.Namespace, Name: "other"}
+			Expect(a.Owns(key)).To(Equal(a.Owns(sameNamespace)))
+			Expect(a.Owns(key)).NotTo(Equal(b.Owns(key)))
+		}
+	})
+
+	It("should expire replicas that weren't observed to renew their lease", func(ctx SpecContext) {
+		// The renew time of the other replica is far in the past, which must not
+		// matter as only its changes are observed.
+		Expect(c.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shards-gone", Labels: map[string]string{GroupLabel: "shards"}},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To("gone"),
+				LeaseDurationSeconds: ptr.To(int32(15)),
+				RenewTime:            &metav1.MicroTime{Time: time.Now().Add(-time.Minute)},
+			},
+		})).To(Succeed())
+
+		a := newCoordinator("a", StrategyHash)
+		now := time.Now()
+		a.now = func() time.Time { return now }
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.Members()).To(Equal([]string{"a", "gone"}))
+
+		now = now.Add(10 * time.Second)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.Members()).To(Equal([]string{"a", "gone"}))
+
+		now = now.Add(10 * time.Second)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.Members()).To(Equal([]string{"a"}))
+	})
+
+	It("should use distinct leases for identities with a common prefix", func() {
+		a := newCoordinator("replica-1", StrategyHash)
+		b := newCoordinator("replica-10", StrategyHash)
+		Expect(a.leaseName()).NotTo(Equal(b.leaseName()))
+		Expect(a.leaseName()).To(HavePrefix("shards-"))
+	})
+
+	It("should drop requests of keys owned by other replicas", func(ctx SpecContext) {
+		a := newCoordinator("a", StrategyHash)
+		b := newCoordinator("b", StrategyHash)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+
+		var reconciled []types.NamespacedName
+		r := a.Reconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+			reconciled = append(reconciled, req.NamespacedName)
+			return reconcile.Result{}, nil
+		}))
+		for _, key := range keys {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(reconciled).To(Equal(owned(a)))
+	})
+
+	It("should filter secondary watches by the requests events map to", func(ctx SpecContext) {
+		a := newCoordinator("a", StrategyHash)
+		b := newCoordinator("b", StrategyHash)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+
+		// Every child maps to the parent with the same index, which is sharded
+		// independently of the child.
+		h := a.EventHandler(handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+			var index int
+			_, err := fmt.Sscanf(obj.GetName(), "child-%d", &index)
+			Expect(err).NotTo(HaveOccurred())
+			return []reconcile.Request{{NamespacedName: keys[index]}}
+		}))
+
+		for _, newQueue := range []func() workqueue.TypedRateLimitingInterface[reconcile.Request]{
+			func() workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			},
+			func() workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return priorityqueue.New[reconcile.Request]("sharding-test")
+			},
+		} {
+			q := newQueue()
+			for i := range keys {
+				child := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "children", Name: fmt.Sprintf("child-%d", i)}}
+				h.Create(ctx, event.CreateEvent{Object: child}, q)
+			}
+
+			Eventually(q.Len).Should(Equal(len(owned(a))))
+			var enqueued []types.NamespacedName
+			for q.Len() > 0 {
+				req, _ := q.Get()
+				enqueued = append(enqueued, req.NamespacedName)
+				q.Done(req)
+			}
+			Expect(enqueued).To(ConsistOf(owned(a)))
+			q.ShutDown()
+		}
+	})
+
+	It("should enqueue the objects a replica became responsible for on rebalance", func(ctx SpecContext) {
+		for _, key := range keys {
+			Expect(c.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}})).To(Succeed())
+		}
+		a := newCoordinator("a", StrategyHash)
+		b := newCoordinator("b", StrategyHash)
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+		ownedByB := owned(b)
+
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+		Expect(a.Source(c, &corev1.ConfigMapList{}).Start(ctx, q)).To(Succeed())
+
+		b.release()
+		Expect(a.sync(ctx)).To(Succeed())
+
+		Eventually(q.Len).Should(Equal(len(ownedByB)))
+		var enqueued []types.NamespacedName
+		for range len(ownedByB) {
+			req, _ := q.Get()
+			enqueued = append(enqueued, req.NamespacedName)
+			q.Done(req)
+		}
+		Expect(enqueued).To(ConsistOf(ownedByB))
+	})
+
+	It("should unsubscribe the rebalance source when it is stopped", func(specCtx SpecContext) {
+		a := newCoordinator("a", StrategyHash)
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer q.ShutDown()
+
+		ctx, cancel := context.WithCancel(specCtx)
+		Expect(a.Source(c, &corev1.ConfigMapList{}).Start(ctx, q)).To(Succeed())
+		Expect(a.subscribers).To(HaveLen(1))
+
+		cancel()
+		Eventually(func() int {
+			a.mu.RLock()
+			defer a.mu.RUnlock()
+			return len(a.subscribers)
+		}).Should(BeZero())
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Source returns a source that enqueues the objects this replica became responsible
+// for whenever the members of the shard group change. Events of these objects were
+// filtered out by Predicate before, so without it they wouldn't be reconciled until
+// their next change.
+//
+// Objects are listed through the given reader, which is usually the cache of the
+// manager, using list as the type of list to use.
+func (c *Coordinator) Source(reader client.Reader, list client.ObjectList) source.Source {
+	return &rebalanceSource{coordinator: c, reader: reader, list: list}
+}
+
+type rebalanceSource struct {
+	coordinator *Coordinator
+	reader      client.Reader
+	list        client.ObjectList
+}
+
+func (s *rebalanceSource) String() string {
+	return fmt.Sprintf("sharding rebalance source: %T", s.list)
+}
+
+// Start implements source.Source.
+func (s *rebalanceSource) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	members, changed, unsubscribe := s.coordinator.subscribe()
+	go func() {
+		defer unsubscribe()
+		var retry <-chan time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			case <-retry:
+			}
+			retry = nil
+			current := s.coordinator.Members()
+			if err := s.enqueueMoved(ctx, queue, members, current); err != nil {
+				log.Error(err, "Failed to enqueue objects after rebalance", "group", s.coordinator.name, "source", s.String())
+				// Keep the previous members so that no moved object is lost.
+				retry = time.After(s.coordinator.renewPeriod)
+				continue
+			}
+			members = current
+		}
+	}()
+	return nil
+}
+
+// enqueueMoved enqueues all objects that are owned by this replica with the current
+// members but weren't with the previous ones.
+func (s *rebalanceSource) enqueueMoved(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request], previous, current []string) error {
+	identity := s.coordinator.identity
+	if !slices.Contains(current, identity) {
+		return nil
+	}
+
+	list := s.list.DeepCopyObject().(client.ObjectList)
+	if err := s.reader.List(ctx, list); err != nil {
+		return err
+	}
+	return meta.EachListItem(list, func(o runtime.Object) error {
+		obj, err := meta.Accessor(o)
+		if err != nil {
+			return err
+		}
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		shardKey := s.coordinator.shardKey(key)
+		if ownerOf(current, shardKey) == identity && ownerOf(previous, shardKey) != identity {
+			queue.Add(reconcile.Request{NamespacedName: key})
+		}
+		return nil
+	})
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+// QueueCapacityPollInterval is the interval at which WaitForQueueCapacity checks
+// the depth of the queue.
+var QueueCapacityPollInterval = 100 * time.Millisecond
+
+// WaitForQueueCapacity blocks while the queue holds maxDepth or more items that are
+// ready to be picked up and have at least the given priority, or until ctx is done.
+// Sources that are fed by producers outside of the cluster, e.g. webhooks or polls,
+// can call it before they add items, so that their producers are paused instead of
+// growing the queue without bounds when they send more events than the controller
+// can reconcile.
+//
+// Only the items that are picked up before the items of the source are counted, so
+// that e.g. a storm of low priority items doesn't pause a source that adds items at
+// the default priority. All items are counted if the queue isn't a priority queue.
+func WaitForQueueCapacity[request comparable](ctx context.Context, queue workqueue.TypedRateLimitingInterface[request], maxDepth, priority int) error {
+	return wait.PollUntilContextCancel(ctx, QueueCapacityPollInterval, true, func(context.Context) (bool, error) {
+		return priorityqueue.LenAtPriority(queue, priority) < maxDepth, nil
+	})
+}
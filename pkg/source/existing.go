@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ExistingObjects returns a source that lists the objects of the type of list that match
+// the options from the cache once the source is started, and enqueues a reconcile.Request
+// for each of them at handler.LowPriority, so that they don't delay the requests for
+// changed objects. This makes processing every object once when the controller starts
+// explicit, instead of relying on the informer replaying its objects as Create events.
+//
+// The list is read from the cache after it synced, its WaitForSync returns once all
+// requests are enqueued. The source lists again when the controller is restarted, e.g.
+// after its Manager re-acquired leadership.
+func ExistingObjects(cache cache.Cache, list client.ObjectList, opts ...client.ListOption) SyncingSource {
+	return &existingObjects{cache: cache, list: list, opts: opts}
+}
+
+type existingObjects struct {
+	cache cache.Cache
+	list  client.ObjectList
+	opts  []client.ListOption
+
+	mu sync.Mutex
+	// enqueued receives the result of listing and enqueueing the objects of the
+	// last Start.
+	enqueued chan error
+}
+
+func (s *existingObjects) String() string {
+	return fmt.Sprintf("existing objects source: %T", s.list)
+}
+
+// Start implements Source.
+func (s *existingObjects) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	if s.cache == nil {
+		return errors.New("must create ExistingObjects with a non-nil cache")
+	}
+	if s.list == nil {
+		return errors.New("must create ExistingObjects with a non-nil list")
+	}
+
+	enqueued := make(chan error, 1) // Buffer chan to not leak goroutines if WaitForSync isn't called
+	s.mu.Lock()
+	s.enqueued = enqueued
+	s.mu.Unlock()
+	go func() {
+		enqueued <- s.enqueue(ctx, queue)
+	}()
+	return nil
+}
+
+func (s *existingObjects) enqueue(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	// The cache blocks until the informer of the type synced.
+	list := s.list.DeepCopyObject().(client.ObjectList)
+	if err := s.cache.List(ctx, list, s.opts...); err != nil {
+		return fmt.Errorf("failed to list existing objects for %s: %w", s, err)
+	}
+
+	var requests []reconcile.Request
+	if err := meta.EachListItem(list, func(item runtime.Object) error {
+		obj, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}})
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue existing objects for %s: %w", s, err)
+	}
+
+	if priorityQueue, isPriorityQueue := queue.(priorityqueue.PriorityQueue[reconcile.Request]); isPriorityQueue {
+		priorityQueue.AddWithOpts(priorityqueue.AddOpts{Priority: new(handler.LowPriority)}, requests...)
+		return nil
+	}
+	for _, req := range requests {
+		queue.Add(req)
+	}
+	return nil
+}
+
+// WaitForSync implements SyncingSource to allow controllers to wait with starting
+// workers until the existing objects are enqueued.
+func (s *existingObjects) WaitForSync(ctx context.Context) error {
+	s.mu.Lock()
+	enqueued := s.enqueued
+	s.mu.Unlock()
+	if enqueued == nil {
+		return errors.New("must call Start before WaitForSync")
+	}
+
+	select {
+	case err := <-enqueued:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil
+		}
+		return fmt.Errorf("timed out waiting for %s to enqueue the existing objects", s)
+	}
+}
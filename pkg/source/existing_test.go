@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// readerCache is a cache that serves List from a client.Reader.
+type readerCache struct {
+	cache.Cache
+	reader client.Reader
+}
+
+func (c *readerCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+var _ = Describe("ExistingObjects", func() {
+	var c cache.Cache
+
+	BeforeEach(func() {
+		c = &readerCache{reader: fake.NewClientBuilder().WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "match", Labels: map[string]string{"app": "a"}}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", Labels: map[string]string{"app": "b"}}},
+		).Build()}
+	})
+
+	It("should enqueue the matching objects at low priority", func(ctx SpecContext) {
+		q := priorityqueue.New[reconcile.Request]("existing")
+		DeferCleanup(q.ShutDown)
+
+		src := source.ExistingObjects(c, &corev1.ConfigMapList{}, client.MatchingLabels{"app": "a"})
+		Expect(src.Start(ctx, q)).To(Succeed())
+		Expect(src.WaitForSync(ctx)).To(Succeed())
+
+		Expect(q.Len()).To(Equal(1))
+		item, priority, _ := q.GetWithPriority()
+		Expect(item).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "match"}}))
+		Expect(priority).To(Equal(handler.LowPriority))
+	})
+
+	It("should enqueue the objects into a queue that isn't a priority queue", func(ctx SpecContext) {
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(q.ShutDown)
+
+		src := source.ExistingObjects(c, &metav1.PartialObjectMetadataList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMapList"}})
+		Expect(src.Start(ctx, q)).To(Succeed())
+		Expect(src.WaitForSync(ctx)).To(Succeed())
+		Expect(q.Len()).To(Equal(2))
+	})
+
+	It("should enqueue the objects again when it is started again", func(ctx SpecContext) {
+		src := source.ExistingObjects(c, &corev1.ConfigMapList{})
+		for range 2 {
+			q := priorityqueue.New[reconcile.Request]("existing")
+			DeferCleanup(q.ShutDown)
+			Expect(src.Start(ctx, q)).To(Succeed())
+			Expect(src.WaitForSync(ctx)).To(Succeed())
+			Expect(q.Len()).To(Equal(2))
+		}
+	})
+
+	It("should return the error of the list from WaitForSync", func(ctx SpecContext) {
+		q := priorityqueue.New[reconcile.Request]("existing")
+		DeferCleanup(q.ShutDown)
+
+		c = &readerCache{reader: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+				return errors.New("list failed")
+			},
+		}).Build()}
+		src := source.ExistingObjects(c, &corev1.ConfigMapList{})
+		Expect(src.Start(ctx, q)).To(Succeed())
+		Expect(src.WaitForSync(ctx)).To(MatchError(ContainSubstring("list failed")))
+	})
+
+	It("should return an error from WaitForSync if it wasn't started", func(ctx SpecContext) {
+		Expect(source.ExistingObjects(c, &corev1.ConfigMapList{}).WaitForSync(ctx)).NotTo(Succeed())
+	})
+})
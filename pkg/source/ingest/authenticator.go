@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingest
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Authenticator authenticates the producer that sent a request. It returns the name
+// of the producer, which is passed to the Decoder and Validate and used to rate limit
+// it, false if the request isn't authenticated, or an error if it couldn't decide.
+type Authenticator func(req *http.Request) (producer string, authenticated bool, err error)
+
+// BearerTokens returns an Authenticator that authenticates producers by the token in
+// the "Authorization: Bearer <token>" header of their requests. The tokens are keyed
+// by the name of their producer. They are static, e.g. read from a mounted Secret, and
+// are not reviewed by the kube-apiserver.
+func BearerTokens(tokens map[string]string) (Authenticator, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("at least one bearer token is required")
+	}
+	type producerToken struct {
+		producer string
+		hash     [sha256.Size]byte
+	}
+	// Comparing the hashes makes the comparison constant time regardless of the token length.
+	hashes := make([]producerToken, 0, len(tokens))
+	for producer, token := range tokens {
+		if token == "" {
+			return nil, errors.New("bearer tokens must not be empty")
+		}
+		hashes = append(hashes, producerToken{producer: producer, hash: sha256.Sum256([]byte(token))})
+	}
+
+	return func(req *http.Request) (string, bool, error) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			return "", false, nil
+		}
+		hash := sha256.Sum256([]byte(token))
+		producer, found := "", false
+		for _, h := range hashes {
+			if subtle.ConstantTimeCompare(hash[:], h.hash[:]) == 1 {
+				producer, found = h.producer, true
+			}
+		}
+		return producer, found, nil
+	}, nil
+}
+
+// ClientCertificates returns an Authenticator that authenticates producers by the
+// client certificate of their requests, which must have been verified against the
+// ClientCAs of the TLSConfig of the Endpoint. The name of the producer is the common
+// name of the certificate. If common names are given, it must be one of them.
+func ClientCertificates(commonNames ...string) Authenticator {
+	return func(req *http.Request) (string, bool, error) {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+			return "", false, nil
+		}
+		commonName := req.TLS.VerifiedChains[0][0].Subject.CommonName
+		if len(commonNames) > 0 && !slices.Contains(commonNames, commonName) {
+			return "", false, nil
+		}
+		return commonName, true, nil
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	defaultPath         = "/events"
+	defaultBufferSize   = 1024
+	defaultMaxBodyBytes = 1 << 20
+)
+
+// Decoder converts the payload of a request of a producer into the objects it sends
+// events for.
+type Decoder[object any] func(producer string, body []byte) ([]object, error)
+
+// JSON returns a Decoder that decodes a JSON object or a JSON array of objects, e.g.
+// `{"metadata":{"namespace":"default","name":"app"}}` for a *corev1.ConfigMap.
+func JSON[object any]() Decoder[object] {
+	return func(_ string, body []byte) ([]object, error) {
+		var objs []object
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(trimmed, &objs); err != nil {
+				return nil, err
+			}
+			return objs, nil
+		}
+		var obj object
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return nil, err
+		}
+		return append(objs, obj), nil
+	}
+}
+
+// Options are the options of an Endpoint.
+type Options[object any] struct {
+	// BindAddress is the address the Endpoint listens on, e.g. ":8085". It is
+	// required unless Listener is set.
+	BindAddress string
+
+	// Listener is the listener the Endpoint serves on, it takes precedence over
+	// BindAddress.
+	Listener net.Listener
+
+	// TLSConfig makes the Endpoint serve HTTPS. It needs to have a certificate, and
+	// it needs to verify client certificates for the ClientCertificates Authenticator,
+	// e.g. with ClientAuth set to tls.RequireAndVerifyClientCert and ClientCAs.
+	//
+	// Defaults to nil, which serves plain HTTP.
+	TLSConfig *tls.Config
+
+	// Path is the path producers send their events to.
+	//
+	// Defaults to "/events".
+	Path string
+
+	// Authenticator authenticates the producers. It is required.
+	Authenticator Authenticator
+
+	// Decoder converts the payload of a request into objects. It is required, see JSON.
+	Decoder Decoder[object]
+
+	// Validate rejects the objects of a producer it returns an error for. A request
+	// is rejected as a whole if any of its objects is, so that producers can retry it.
+	Validate func(producer string, obj object) error
+
+	// RateLimit limits the requests per second of each producer, with bursts of up to
+	// Burst requests. Requests above the limit are rejected with 429 Too Many Requests.
+	//
+	// Defaults to 0, which doesn't limit producers.
+	RateLimit rate.Limit
+
+	// Burst is the burst of requests of each producer if RateLimit is set.
+	//
+	// Defaults to 1.
+	Burst int
+
+	// MaxBodyBytes is the maximum size of the payload of a request.
+	//
+	// Defaults to 1 MiB.
+	MaxBodyBytes int64
+
+	// BufferSize is the number of events the channel of the Endpoint buffers.
+	// Requests wait for buffer space before they are accepted.
+	//
+	// Defaults to 1024.
+	BufferSize int
+}
+
+// Endpoint is a Runnable that serves an HTTP endpoint to which authenticated external
+// systems, e.g. CI systems, send events for objects. It writes them as GenericEvents
+// to the channel returned by Events, which is meant to be used with source.Channel:
+//
+//	ep, err := ingest.New(ingest.Options[*corev1.ConfigMap]{
+//		BindAddress:   ":8085",
+//		Authenticator: authenticator,
+//		Decoder:       ingest.JSON[*corev1.ConfigMap](),
+//	})
+//	if err != nil { ... }
+//	if err := mgr.Add(ep); err != nil { ... }
+//	err = ctrl.NewControllerManagedBy(mgr).
+//		Named("configmaps").
+//		WatchesRawSource(source.Channel(ep.Events(), &handler.TypedEnqueueRequestForObject[*corev1.ConfigMap]{})).
+//		Complete(r)
+//
+// Requests must be POST requests. They are accepted with 202 Accepted once all of
+// their events were written to the channel.
+//
+// The Endpoint only runs while the manager is the leader, as the controllers that
+// read from the channel only run then.
+type Endpoint[object any] struct {
+	opts   Options[object]
+	events chan event.TypedGenericEvent[object]
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+var (
+	_ manager.Runnable               = (*Endpoint[any])(nil)
+	_ manager.LeaderElectionRunnable = (*Endpoint[any])(nil)
+	_ http.Handler                   = (*Endpoint[any])(nil)
+)
+
+// New returns an Endpoint with the given options.
+func New[object any](opts Options[object]) (*Endpoint[object], error) {
+	if opts.Authenticator == nil {
+		return nil, errors.New("an Authenticator is required")
+	}
+	if opts.Decoder == nil {
+		return nil, errors.New("a Decoder is required")
+	}
+	if opts.BindAddress == "" && opts.Listener == nil {
+		return nil, errors.New("a BindAddress or Listener is required")
+	}
+	if opts.Path == "" {
+		opts.Path = defaultPath
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = defaultBufferSize
+	}
+	return &Endpoint[object]{
+		opts:     opts,
+		events:   make(chan event.TypedGenericEvent[object], opts.BufferSize),
+		limiters: map[string]*rate.Limiter{},
+	}, nil
+}
+
+// Events returns the channel the Endpoint writes the events of producers to.
+func (e *Endpoint[object]) Events() <-chan event.TypedGenericEvent[object] {
+	return e.events
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (e *Endpoint[object]) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable. It serves the Endpoint until ctx is done.
+func (e *Endpoint[object]) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(e.opts.Path, e)
+
+	listener := e.opts.Listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", e.opts.BindAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", e.opts.BindAddress, err)
+		}
+	}
+	if e.opts.TLSConfig != nil {
+		listener = tls.NewListener(listener, e.opts.TLSConfig)
+	}
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	server := &manager.Server{
+		Name:            "event ingestion",
+		Server:          srv,
+		Listener:        listener,
+		ShutdownTimeout: new(30 * time.Second),
+	}
+	return server.Start(ctx)
+}
+
+// ServeHTTP implements http.Handler. It allows to serve the Endpoint on another server
+// than its own, Start doesn't need to be called then.
+func (e *Endpoint[object]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	producer, authenticated, err := e.opts.Authenticator(req)
+	if err != nil {
+		log.Error(err, "Authentication error")
+		http.Error(w, "Authentication error", http.StatusInternalServerError)
+		return
+	}
+	if !authenticated {
+		log.V(4).Info("Authentication failed", "remoteAddr", req.RemoteAddr)
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	log := log.WithValues("producer", producer)
+
+	if limiter := e.limiterFor(producer); limiter != nil {
+		reservation := limiter.Reserve()
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+			log.V(4).Info("Rate limited")
+			w.Header().Set("Retry-After", strconv.Itoa(int(delay.Seconds())+1))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, e.opts.MaxBodyBytes))
+	if err != nil {
+		if maxBytesErr := (*http.MaxBytesError)(nil); errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("Payload exceeds %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to read payload: %v", err), http.StatusBadRequest)
+		return
+	}
+	objs, err := e.opts.Decoder(producer, body)
+	if err != nil {
+		log.V(4).Info("Rejected invalid payload", "error", err)
+		http.Error(w, fmt.Sprintf("Invalid payload: %v", err), http.StatusBadRequest)
+		return
+	}
+	if e.opts.Validate != nil {
+		for i, obj := range objs {
+			if err := e.opts.Validate(producer, obj); err != nil {
+				log.V(4).Info("Rejected invalid object", "error", err)
+				http.Error(w, fmt.Sprintf("Invalid object %d: %v", i, err), http.StatusUnprocessableEntity)
+				return
+			}
+		}
+	}
+
+	for i, obj := range objs {
+		select {
+		case e.events <- event.TypedGenericEvent[object]{Object: obj}:
+		case <-req.Context().Done():
+			log.Info("Request was cancelled before all of its events were accepted", "accepted", i, "events", len(objs))
+			http.Error(w, "Request cancelled", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	log.V(1).Info("Accepted events", "events", len(objs))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// limiterFor returns the rate limiter of a producer, nil if producers aren't limited.
+func (e *Endpoint[object]) limiterFor(producer string) *rate.Limiter {
+	if e.opts.RateLimit == 0 {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	limiter, ok := e.limiters[producer]
+	if !ok {
+		limiter = rate.NewLimiter(e.opts.RateLimit, e.opts.Burst)
+		e.limiters[producer] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingest_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source/ingest"
+)
+
+var _ = Describe("Endpoint", func() {
+	var (
+		authenticator ingest.Authenticator
+		opts          ingest.Options[*corev1.ConfigMap]
+	)
+
+	BeforeEach(func() {
+		var err error
+		authenticator, err = ingest.BearerTokens(map[string]string{"ci": "ci-token", "other": "other-token"})
+		Expect(err).NotTo(HaveOccurred())
+		opts = ingest.Options[*corev1.ConfigMap]{
+			BindAddress:   ":0",
+			Authenticator: authenticator,
+			Decoder:       ingest.JSON[*corev1.ConfigMap](),
+		}
+	})
+
+	post := func(ep http.Handler, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		ep.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("should require an Authenticator, a Decoder and an address", func() {
+		_, err := ingest.New(ingest.Options[*corev1.ConfigMap]{BindAddress: ":0", Decoder: opts.Decoder})
+		Expect(err).To(HaveOccurred())
+		_, err = ingest.New(ingest.Options[*corev1.ConfigMap]{BindAddress: ":0", Authenticator: authenticator})
+		Expect(err).To(HaveOccurred())
+		_, err = ingest.New(ingest.Options[*corev1.ConfigMap]{Authenticator: authenticator, Decoder: opts.Decoder})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should write the events of authenticated producers to its channel", func() {
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		rec := post(ep, "ci-token", `[{"metadata":{"namespace":"default","name":"a"}},{"metadata":{"namespace":"default","name":"b"}}]`)
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+		rec = post(ep, "other-token", `{"metadata":{"namespace":"default","name":"c"}}`)
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+		var names []string
+		for range 3 {
+			var evt event.TypedGenericEvent[*corev1.ConfigMap]
+			Eventually(ep.Events()).Should(Receive(&evt))
+			names = append(names, evt.Object.Name)
+		}
+		Expect(names).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("should reject requests of unauthenticated producers", func() {
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		rec := post(ep, "", `{"metadata":{"name":"a"}}`)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rec.Header().Get("WWW-Authenticate")).To(Equal("Bearer"))
+		rec = post(ep, "wrong-token", `{"metadata":{"name":"a"}}`)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		Expect(ep.Events()).To(BeEmpty())
+	})
+
+	It("should only accept POST requests", func() {
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest(http.MethodGet, "/events", nil)
+		req.Header.Set("Authorization", "Bearer ci-token")
+		rec := httptest.NewRecorder()
+		ep.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("should reject payloads it can't decode or that are too large", func() {
+		opts.MaxBodyBytes = 64
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(post(ep, "ci-token", `{"metadata":`).Code).To(Equal(http.StatusBadRequest))
+		Expect(post(ep, "ci-token", `{"metadata":{"name":"`+strings.Repeat("a", 64)+`"}}`).Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(ep.Events()).To(BeEmpty())
+	})
+
+	It("should reject requests with invalid objects as a whole", func() {
+		opts.Validate = func(producer string, cm *corev1.ConfigMap) error {
+			if cm.Namespace != producer {
+				return errors.New("producers may only send events for their own namespace")
+			}
+			return nil
+		}
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		rec := post(ep, "ci-token", `[{"metadata":{"namespace":"ci","name":"a"}},{"metadata":{"namespace":"other","name":"b"}}]`)
+		Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(rec.Body.String()).To(ContainSubstring("own namespace"))
+		Expect(ep.Events()).To(BeEmpty())
+
+		Expect(post(ep, "ci-token", `{"metadata":{"namespace":"ci","name":"a"}}`).Code).To(Equal(http.StatusAccepted))
+		Expect(ep.Events()).To(HaveLen(1))
+	})
+
+	It("should rate limit each producer", func() {
+		opts.RateLimit = 0.001
+		opts.Burst = 2
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		body := `{"metadata":{"name":"a"}}`
+		Expect(post(ep, "ci-token", body).Code).To(Equal(http.StatusAccepted))
+		Expect(post(ep, "ci-token", body).Code).To(Equal(http.StatusAccepted))
+		rec := post(ep, "ci-token", body)
+		Expect(rec.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rec.Header().Get("Retry-After")).NotTo(BeEmpty())
+
+		By("not limiting other producers")
+		Expect(post(ep, "other-token", body).Code).To(Equal(http.StatusAccepted))
+	})
+
+	It("should give up on events if the request is cancelled while the buffer is full", func() {
+		opts.BufferSize = 1
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := httptest.NewRequestWithContext(ctx, http.MethodPost, "/events", strings.NewReader(`[{"metadata":{"name":"a"}},{"metadata":{"name":"b"}}]`))
+		req.Header.Set("Authorization", "Bearer ci-token")
+		rec := httptest.NewRecorder()
+		ep.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	It("should serve its path when started", func(specCtx SpecContext) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		opts.Listener = listener
+		ep, err := ingest.New(opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ep.NeedLeaderElection()).To(BeTrue())
+
+		ctx, cancel := context.WithCancel(specCtx)
+		done := make(chan error)
+		go func() { done <- ep.Start(ctx) }()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		req, err := http.NewRequestWithContext(specCtx, http.MethodPost, "http://"+listener.Addr().String()+"/events", strings.NewReader(`{"metadata":{"name":"a"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Authorization", "Bearer ci-token")
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(ep.Events()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("ClientCertificates", func() {
+	request := func(commonName string, verified bool) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/events", nil)
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+		if verified {
+			req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return req
+	}
+
+	It("should authenticate producers by the common name of their verified certificate", func() {
+		producer, ok, err := ingest.ClientCertificates()(request("ci", true))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(producer).To(Equal("ci"))
+	})
+
+	It("should not authenticate unverified certificates or requests without TLS", func() {
+		_, ok, err := ingest.ClientCertificates()(request("ci", false))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		_, ok, err = ingest.ClientCertificates()(httptest.NewRequest(http.MethodPost, "/events", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should only authenticate the given common names", func() {
+		_, ok, err := ingest.ClientCertificates("ci")(request("other", true))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	toolscache "k8s.io/client-go/tools/cache"
@@ -44,6 +45,10 @@ var logInformer = logf.RuntimeLog.WithName("source").WithName("Informer")
 //
 // * Use Channel for events originating outside the cluster (e.g. GitHub Webhook callback, Polling external urls).
 //
+// * Use FromSubscription for messages of a message broker that have to be acknowledged (e.g. GCP Pub/Sub, AWS EventBridge).
+//
+// * Use ExistingObjects to process the objects that exist when a controller starts once.
+//
 // Users may build their own Source implementations.
 type Source = TypedSource[reconcile.Request]
 
@@ -117,6 +122,19 @@ func WithBufferSize[object any, request comparable](bufferSize int) ChannelOpt[o
 	}
 }
 
+// WithBackpressure pauses a source.Channel while the queue holds maxDepth or more
+// items with at least the given priority, see WaitForQueueCapacity. The priority is
+// the one the handler of the source adds items with, which is zero by default.
+//
+// While the source is paused, its buffer fills up and then writes to the source
+// channel block, so that the producer of the events is paused as well.
+func WithBackpressure[object any, request comparable](maxDepth, priority int) ChannelOpt[object, request] {
+	return func(c *channel[object, request]) {
+		c.maxQueueDepth = maxDepth
+		c.priority = priority
+	}
+}
+
 // Channel is used to provide a source of events originating outside the cluster
 // (e.g. GitHub Webhook callback).  Channel requires the user to wire the external
 // source (e.g. http handler) to write GenericEvents to the underlying channel.
@@ -148,9 +166,6 @@ func TypedChannel[object any, request comparable](
 }
 
 type channel[object any, request comparable] struct {
-	// once ensures the event distribution goroutine will be performed only once
-	once sync.Once
-
 	// source is the source channel to fetch GenericEvents
 	source <-chan event.TypedGenericEvent[object]
 
@@ -160,9 +175,20 @@ type channel[object any, request comparable] struct {
 
 	bufferSize *int
 
+	// maxQueueDepth and priority configure the backpressure of the source, it is
+	// disabled if maxQueueDepth is zero.
+	maxQueueDepth int
+	priority      int
+
 	// dest is the destination channels of the added event handlers
 	dest []chan event.TypedGenericEvent[object]
 
+	// stopSyncLoop stops the running syncLoop, it is nil if no syncLoop is running.
+	stopSyncLoop chan struct{}
+
+	// sourceClosed is true once the source channel was closed.
+	sourceClosed bool
+
 	// destLock is to ensure the destination channels are safely added/removed
 	destLock sync.Mutex
 }
@@ -171,7 +197,9 @@ func (cs *channel[object, request]) String() string {
 	return fmt.Sprintf("channel source: %p", cs)
 }
 
-// Start implements Source and should only be called by the Controller.
+// Start implements Source and should only be called by the Controller. It can be
+// called again after the context of a previous call was cancelled, e.g. when the
+// Controller is restarted.
 func (cs *channel[object, request]) Start(
 	ctx context.Context,
 	queue workqueue.TypedRateLimitingInterface[request],
@@ -191,14 +219,22 @@ func (cs *channel[object, request]) Start(
 	dst := make(chan event.TypedGenericEvent[object], *cs.bufferSize)
 
 	cs.destLock.Lock()
-	cs.dest = append(cs.dest, dst)
+	if cs.sourceClosed {
+		close(dst)
+	} else {
+		cs.dest = append(cs.dest, dst)
+		if cs.stopSyncLoop == nil {
+			// Distribute GenericEvents to all EventHandler / Queue pairs Watching this source
+			cs.stopSyncLoop = make(chan struct{})
+			go cs.syncLoop(cs.stopSyncLoop)
+		}
+		go func() {
+			<-ctx.Done()
+			cs.removeDest(dst)
+		}()
+	}
 	cs.destLock.Unlock()
 
-	cs.once.Do(func() {
-		// Distribute GenericEvents to all EventHandler / Queue pairs Watching this source
-		go cs.syncLoop(ctx)
-	})
-
 	go func() {
 		for evt := range dst {
 			shouldHandle := true
@@ -210,6 +246,11 @@ func (cs *channel[object, request]) Start(
 			}
 
 			if shouldHandle {
+				if cs.maxQueueDepth > 0 {
+					// Only returns early once the source is stopped, handle the
+					// remaining events as usual then.
+					_ = WaitForQueueCapacity(ctx, queue, cs.maxQueueDepth, cs.priority)
+				}
 				func() {
 					ctx, cancel := context.WithCancel(ctx)
 					defer cancel()
@@ -222,6 +263,27 @@ func (cs *channel[object, request]) Start(
 	return nil
 }
 
+// removeDest closes the given destination channel and stops the syncLoop once no
+// destination channels are left, so that events stay in the source channel until
+// the source is started again.
+func (cs *channel[object, request]) removeDest(dst chan event.TypedGenericEvent[object]) {
+	cs.destLock.Lock()
+	defer cs.destLock.Unlock()
+
+	i := slices.Index(cs.dest, dst)
+	if i < 0 {
+		// Already closed because the source channel was closed.
+		return
+	}
+	cs.dest = slices.Delete(cs.dest, i, i+1)
+	close(dst)
+
+	if len(cs.dest) == 0 {
+		close(cs.stopSyncLoop)
+		cs.stopSyncLoop = nil
+	}
+}
+
 func (cs *channel[object, request]) doStop() {
 	cs.destLock.Lock()
 	defer cs.destLock.Unlock()
@@ -229,6 +291,9 @@ func (cs *channel[object, request]) doStop() {
 	for _, dst := range cs.dest {
 		close(dst)
 	}
+	cs.dest = nil
+	cs.sourceClosed = true
+	cs.stopSyncLoop = nil
 }
 
 func (cs *channel[object, request]) distribute(evt event.TypedGenericEvent[object]) {
@@ -245,12 +310,10 @@ func (cs *channel[object, request]) distribute(evt event.TypedGenericEvent[objec
 	}
 }
 
-func (cs *channel[object, request]) syncLoop(ctx context.Context) {
+func (cs *channel[object, request]) syncLoop(stop <-chan struct{}) {
 	for {
 		select {
-		case <-ctx.Done():
-			// Close destination channels
-			cs.doStop()
+		case <-stop:
 			return
 		case evt, stillOpen := <-cs.source:
 			if !stillOpen {
@@ -279,7 +342,7 @@ type Informer = TypedInformer[client.Object, reconcile.Request]
 var _ Source = &Informer{}
 
 // Start is internal and should be called only by the Controller to register an EventHandler with the Informer
-// to enqueue reconcile.Requests.
+// to enqueue reconcile.Requests. The EventHandler is removed once the context is cancelled.
 func (is *TypedInformer[object, request]) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[request]) error {
 	// Informer should have been specified by the user.
 	if is.Informer == nil {
@@ -289,12 +352,20 @@ func (is *TypedInformer[object, request]) Start(ctx context.Context, queue workq
 		return errors.New("must specify Informer.Handler")
 	}
 
-	_, err := is.Informer.AddEventHandlerWithOptions(internal.NewEventHandler(ctx, queue, is.Handler, is.Predicates), toolscache.HandlerOptions{
+	handlerRegistration, err := is.Informer.AddEventHandlerWithOptions(internal.NewEventHandler(ctx, queue, is.Handler, is.Predicates), toolscache.HandlerOptions{
 		Logger: &logInformer,
 	})
 	if err != nil {
 		return err
 	}
+	// Remove the handler once the source is stopped, so that it can be started
+	// again when the controller is restarted.
+	go func() {
+		<-ctx.Done()
+		if err := is.Informer.RemoveEventHandler(handlerRegistration); err != nil {
+			logInformer.Error(err, "failed to remove event handler", "source", is.String())
+		}
+	}()
 	return nil
 }
 
@@ -496,6 +496,67 @@ var _ = Describe("Source", func() {
 				Eventually(processed).Should(Receive())
 				Consistently(processed).ShouldNot(Receive())
 			})
+			It("should provide GenericEvents again after being restarted", func(ctx SpecContext) {
+				ch := make(chan event.GenericEvent)
+				received := make(chan workqueue.TypedRateLimitingInterface[reconcile.Request])
+				src := source.Channel(ch, handler.Funcs{
+					GenericFunc: func(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+						received <- q
+					},
+				})
+				newQueue := func() workqueue.TypedRateLimitingInterface[reconcile.Request] {
+					return workqueue.NewTypedRateLimitingQueueWithConfig(
+						workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+						workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+							Name: "test",
+						})
+				}
+				sendAndReceive := func() workqueue.TypedRateLimitingInterface[reconcile.Request] {
+					ch <- event.GenericEvent{Object: &corev1.Pod{}}
+					return <-received
+				}
+
+				By("starting and stopping the source")
+				firstCtx, cancel := context.WithCancel(ctx)
+				first := newQueue()
+				Expect(src.Start(firstCtx, first)).To(Succeed())
+				Expect(sendAndReceive()).To(BeIdenticalTo(first))
+				cancel()
+
+				By("starting the source again")
+				second := newQueue()
+				Expect(src.Start(ctx, second)).To(Succeed())
+				Eventually(sendAndReceive).Should(BeIdenticalTo(second))
+			})
+			It("should pause while the queue is too deep with WithBackpressure", func(ctx SpecContext) {
+				defer func(interval time.Duration) { source.QueueCapacityPollInterval = interval }(source.QueueCapacityPollInterval)
+				source.QueueCapacityPollInterval = 10 * time.Millisecond
+
+				q := workqueue.NewTypedRateLimitingQueueWithConfig(
+					workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
+					workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+						Name: "test",
+					})
+				defer q.ShutDown()
+				ch := make(chan event.GenericEvent, 2)
+				instance := source.Channel(ch, &handler.EnqueueRequestForObject{},
+					source.WithBackpressure[client.Object, reconcile.Request](1, 0))
+				Expect(instance.Start(ctx, q)).To(Succeed())
+
+				ch <- event.GenericEvent{Object: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}}
+				ch <- event.GenericEvent{Object: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}}}
+				Eventually(q.Len).Should(Equal(1))
+				Consistently(q.Len).Should(Equal(1))
+
+				By("draining the queue")
+				item, _ := q.Get()
+				Expect(item.Name).To(Equal("a"))
+				q.Done(item)
+				Eventually(q.Len).Should(Equal(1))
+				item, _ = q.Get()
+				Expect(item.Name).To(Equal("b"))
+				q.Done(item)
+			})
 			It("should get error if no source specified", func(ctx SpecContext) {
 				q := workqueue.NewTypedRateLimitingQueueWithConfig(
 					workqueue.DefaultTypedControllerRateLimiter[reconcile.Request](),
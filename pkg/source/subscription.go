@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var logSubscription = logf.RuntimeLog.WithName("source").WithName("Subscription")
+
+// Message is a message received from a Subscription.
+type Message interface {
+	// Ack acknowledges the message, so that it isn't delivered again.
+	Ack()
+
+	// Nack negatively acknowledges the message, so that it is delivered again.
+	Nack()
+
+	// DeliveryAttempt returns how often the message has been delivered, including
+	// the current delivery, or 0 if it isn't known.
+	DeliveryAttempt() int
+}
+
+// Subscription is a stream of messages of a message broker, e.g. a GCP Pub/Sub
+// subscription or an AWS SQS queue that is fed by EventBridge.
+type Subscription[msg Message] interface {
+	// Receive calls f for every received message until ctx is done or receiving
+	// fails. f may be called concurrently.
+	Receive(ctx context.Context, f func(context.Context, msg)) error
+}
+
+// SubscriptionOption configures a source created with FromSubscription.
+type SubscriptionOption func(*subscriptionOptions)
+
+type subscriptionOptions struct {
+	maxDeliveryAttempts int
+	onPoisonMessage     func(ctx context.Context, msg Message, err error)
+}
+
+// WithMaxDeliveryAttempts treats messages as poison messages once their reconciliation
+// failed after they were delivered n times. By default, messages are redelivered until
+// their reconciliation succeeds, unless it fails with a terminal error.
+func WithMaxDeliveryAttempts(n int) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.maxDeliveryAttempts = n
+	}
+}
+
+// WithPoisonMessageHandler sets a handler for poison messages, e.g. to move them to a
+// dead-letter queue, before they are acknowledged. It defaults to logging them.
+func WithPoisonMessageHandler(f func(ctx context.Context, msg Message, err error)) SubscriptionOption {
+	return func(o *subscriptionOptions) {
+		o.onPoisonMessage = f
+	}
+}
+
+// SubscriptionSource is a Source for the messages of a Subscription, see FromSubscription.
+type SubscriptionSource[msg Message] = TypedSubscriptionSource[msg, reconcile.Request]
+
+// TypedSubscriptionSource is a TypedSource for the messages of a Subscription, see TypedFromSubscription.
+type TypedSubscriptionSource[msg Message, request comparable] struct {
+	subscription Subscription[msg]
+	mapFn        func(context.Context, msg) ([]request, error)
+	opts         subscriptionOptions
+
+	mu sync.Mutex
+	// wrapsReconciler is set once Reconciler was called.
+	wrapsReconciler bool
+	// pending holds the deliveries of every request that wasn't reconciled yet.
+	pending map[request][]*delivery[msg]
+}
+
+// delivery is a received message whose requests are reconciled.
+type delivery[msg Message] struct {
+	msg msg
+	// remaining is the number of requests of the message that weren't reconciled yet.
+	remaining int
+	// err is the first error of a reconciliation of the requests of the message.
+	err error
+	// settled is set once the message was acknowledged or negatively acknowledged.
+	settled bool
+}
+
+// FromSubscription returns a Source that enqueues the requests that mapFn returns for the
+// messages of a Subscription, see TypedFromSubscription.
+func FromSubscription[msg Message](
+	sub Subscription[msg],
+	mapFn func(context.Context, msg) ([]reconcile.Request, error),
+	opts ...SubscriptionOption,
+) *SubscriptionSource[msg] {
+	return TypedFromSubscription(sub, mapFn, opts...)
+}
+
+// TypedFromSubscription returns a TypedSource that enqueues the requests that mapFn returns
+// for the messages of a Subscription. Messages are acknowledged once all of their requests
+// were reconciled successfully and negatively acknowledged, so that the broker redelivers
+// them, if a reconciliation failed. This requires the reconciler of the controller to be
+// wrapped with Reconciler of the returned source.
+//
+// Messages for which mapFn returns an error, whose reconciliation failed with a terminal error,
+// or that were delivered WithMaxDeliveryAttempts times are poison messages: they are handed to
+// the WithPoisonMessageHandler and acknowledged. Messages without requests are acknowledged
+// right away, and messages that weren't reconciled yet when the source is stopped are negatively
+// acknowledged.
+func TypedFromSubscription[msg Message, request comparable](
+	sub Subscription[msg],
+	mapFn func(context.Context, msg) ([]request, error),
+	opts ...SubscriptionOption,
+) *TypedSubscriptionSource[msg, request] {
+	s := &TypedSubscriptionSource[msg, request]{
+		subscription: sub,
+		mapFn:        mapFn,
+		pending:      map[request][]*delivery[msg]{},
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+	return s
+}
+
+// Reconciler wraps the reconciler of the controller the source is used with, so that
+// messages are acknowledged according to the result of the reconciliation of their
+// requests. Requeues of a successful reconciliation don't affect this.
+func (s *TypedSubscriptionSource[msg, request]) Reconciler(r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	s.mu.Lock()
+	s.wrapsReconciler = true
+	s.mu.Unlock()
+
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (_ reconcile.Result, err error) {
+		s.mu.Lock()
+		deliveries := s.pending[req]
+		delete(s.pending, req)
+		s.mu.Unlock()
+
+		completed := false
+		defer func() {
+			if !completed {
+				err = errors.New("reconciler panicked")
+			}
+			for _, d := range deliveries {
+				s.settle(ctx, d, err)
+			}
+		}()
+		res, err := r.Reconcile(ctx, req)
+		completed = true
+		return res, err
+	})
+}
+
+// Start implements TypedSource. It can be called again after the context of a
+// previous call was cancelled, e.g. when the Controller is restarted.
+func (s *TypedSubscriptionSource[msg, request]) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[request]) error {
+	if s.subscription == nil {
+		return errors.New("must specify the Subscription")
+	}
+	if s.mapFn == nil {
+		return errors.New("must specify the map function")
+	}
+	s.mu.Lock()
+	wrapsReconciler := s.wrapsReconciler
+	s.mu.Unlock()
+	if !wrapsReconciler {
+		return errors.New("the reconciler of the controller must be wrapped with the Reconciler of the subscription source")
+	}
+
+	go func() {
+		defer s.nackPending()
+		for ctx.Err() == nil {
+			err := s.subscription.Receive(ctx, func(ctx context.Context, m msg) {
+				s.handle(ctx, m, queue)
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			logSubscription.Error(err, "Receiving messages failed, retrying", "source", s.String())
+			select {
+			case <-ctx.Done():
+			case <-time.After(wait.Jitter(time.Second, 1)):
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *TypedSubscriptionSource[msg, request]) handle(ctx context.Context, m msg, queue workqueue.TypedRateLimitingInterface[request]) {
+	reqs, err := s.mapFn(ctx, m)
+	if err != nil {
+		s.poison(ctx, m, fmt.Errorf("failed to map message to requests: %w", err))
+		return
+	}
+	if len(reqs) == 0 {
+		m.Ack()
+		return
+	}
+
+	d := &delivery[msg]{msg: m, remaining: len(reqs)}
+	s.mu.Lock()
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		m.Nack()
+		return
+	}
+	for _, req := range reqs {
+		s.pending[req] = append(s.pending[req], d)
+	}
+	s.mu.Unlock()
+
+	for _, req := range reqs {
+		queue.Add(req)
+	}
+}
+
+// settle records the result of the reconciliation of a request of a delivery and
+// settles its message once all of its requests were reconciled.
+func (s *TypedSubscriptionSource[msg, request]) settle(ctx context.Context, d *delivery[msg], err error) {
+	s.mu.Lock()
+	if d.settled {
+		s.mu.Unlock()
+		return
+	}
+	if d.err == nil {
+		d.err = err
+	}
+	d.remaining--
+	if d.remaining > 0 {
+		s.mu.Unlock()
+		return
+	}
+	d.settled = true
+	s.mu.Unlock()
+
+	switch {
+	case d.err == nil:
+		d.msg.Ack()
+	case errors.Is(d.err, reconcile.TerminalError(nil)),
+		s.opts.maxDeliveryAttempts > 0 && d.msg.DeliveryAttempt() >= s.opts.maxDeliveryAttempts:
+		s.poison(ctx, d.msg, d.err)
+	default:
+		d.msg.Nack()
+	}
+}
+
+func (s *TypedSubscriptionSource[msg, request]) poison(ctx context.Context, m msg, err error) {
+	if s.opts.onPoisonMessage != nil {
+		s.opts.onPoisonMessage(ctx, m, err)
+	} else {
+		logSubscription.Error(err, "Dropping poison message", "source", s.String(), "deliveryAttempt", m.DeliveryAttempt())
+	}
+	m.Ack()
+}
+
+// nackPending negatively acknowledges the messages of all requests that weren't
+// reconciled yet, so that the broker redelivers them without waiting for their
+// acknowledgement deadline.
+func (s *TypedSubscriptionSource[msg, request]) nackPending() {
+	s.mu.Lock()
+	var msgs []msg
+	for _, deliveries := range s.pending {
+		for _, d := range deliveries {
+			if !d.settled {
+				d.settled = true
+				msgs = append(msgs, d.msg)
+			}
+		}
+	}
+	clear(s.pending)
+	s.mu.Unlock()
+
+	for _, m := range msgs {
+		m.Nack()
+	}
+}
+
+func (s *TypedSubscriptionSource[msg, request]) String() string {
+	return fmt.Sprintf("subscription source: %p", s)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+type fakeMessage struct {
+	name    string
+	attempt int
+
+	mu    sync.Mutex
+	state string
+}
+
+func (m *fakeMessage) Ack()                 { m.settle("acked") }
+func (m *fakeMessage) Nack()                { m.settle("nacked") }
+func (m *fakeMessage) DeliveryAttempt() int { return m.attempt }
+
+func (m *fakeMessage) settle(state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	Expect(m.state).To(BeEmpty(), "message %s was settled twice", m.name)
+	m.state = state
+}
+
+func (m *fakeMessage) State() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+type fakeSubscription chan *fakeMessage
+
+func (s fakeSubscription) Receive(ctx context.Context, f func(context.Context, *fakeMessage)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m := <-s:
+			f(ctx, m)
+		}
+	}
+}
+
+var _ = Describe("Subscription", func() {
+	var (
+		sub     fakeSubscription
+		q       workqueue.TypedRateLimitingInterface[reconcile.Request]
+		results map[string]error
+		poison  chan error
+		src     *source.SubscriptionSource[*fakeMessage]
+		rec     reconcile.Reconciler
+	)
+
+	mapFn := func(_ context.Context, m *fakeMessage) ([]reconcile.Request, error) {
+		switch m.name {
+		case "invalid":
+			return nil, errors.New("invalid message")
+		case "none":
+			return nil, nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: m.name}}}, nil
+	}
+
+	reconcileNext := func() {
+		req, _ := q.Get()
+		defer q.Done(req)
+		_, _ = rec.Reconcile(context.Background(), req)
+	}
+
+	BeforeEach(func() {
+		sub = make(fakeSubscription)
+		q = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		DeferCleanup(q.ShutDown)
+		results = map[string]error{}
+		poison = make(chan error, 10)
+		src = source.FromSubscription(sub, mapFn,
+			source.WithMaxDeliveryAttempts(3),
+			source.WithPoisonMessageHandler(func(_ context.Context, _ source.Message, err error) {
+				poison <- err
+			}),
+		)
+		rec = src.Reconciler(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, results[req.Name]
+		}))
+	})
+
+	It("should require the reconciler to be wrapped", func(ctx SpecContext) {
+		unwrapped := source.FromSubscription(sub, mapFn)
+		Expect(unwrapped.Start(ctx, q)).To(MatchError(ContainSubstring("must be wrapped")))
+	})
+
+	It("should ack messages whose requests were reconciled successfully", func(ctx SpecContext) {
+		Expect(src.Start(ctx, q)).To(Succeed())
+
+		m := &fakeMessage{name: "foo", attempt: 1}
+		sub <- m
+		reconcileNext()
+		Expect(m.State()).To(Equal("acked"))
+	})
+
+	It("should nack messages whose reconciliation failed until they were delivered too often", func(ctx SpecContext) {
+		Expect(src.Start(ctx, q)).To(Succeed())
+		results["foo"] = errors.New("transient error")
+
+		m := &fakeMessage{name: "foo", attempt: 2}
+		sub <- m
+		reconcileNext()
+		Expect(m.State()).To(Equal("nacked"))
+
+		redelivered := &fakeMessage{name: "foo", attempt: 3}
+		sub <- redelivered
+		reconcileNext()
+		Expect(redelivered.State()).To(Equal("acked"))
+		Expect(poison).To(Receive(MatchError("transient error")))
+	})
+
+	It("should treat messages as poison messages if their reconciliation failed with a terminal error", func(ctx SpecContext) {
+		Expect(src.Start(ctx, q)).To(Succeed())
+		results["foo"] = reconcile.TerminalError(errors.New("permanent error"))
+
+		m := &fakeMessage{name: "foo", attempt: 1}
+		sub <- m
+		reconcileNext()
+		Expect(m.State()).To(Equal("acked"))
+		Expect(poison).To(Receive(MatchError(ContainSubstring("permanent error"))))
+	})
+
+	It("should handle messages that can't be mapped and messages without requests", func(ctx SpecContext) {
+		Expect(src.Start(ctx, q)).To(Succeed())
+
+		invalid := &fakeMessage{name: "invalid", attempt: 1}
+		sub <- invalid
+		Eventually(invalid.State).Should(Equal("acked"))
+		Expect(poison).To(Receive(MatchError(ContainSubstring("invalid message"))))
+
+		none := &fakeMessage{name: "none", attempt: 1}
+		sub <- none
+		Eventually(none.State).Should(Equal("acked"))
+		Expect(q.Len()).To(BeZero())
+	})
+
+	It("should nack messages that weren't reconciled when the source is stopped", func(specCtx SpecContext) {
+		ctx, cancel := context.WithCancel(specCtx)
+		Expect(src.Start(ctx, q)).To(Succeed())
+
+		m := &fakeMessage{name: "foo", attempt: 1}
+		sub <- m
+		Eventually(q.Len).Should(Equal(1))
+		cancel()
+		Eventually(m.State).Should(Equal("nacked"))
+
+		By("not settling the message again when its request is reconciled")
+		reconcileNext()
+		Expect(m.State()).To(Equal("nacked"))
+	})
+})
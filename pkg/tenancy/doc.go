@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package tenancy runs a set of controllers per tenant object.
+
+New adds a controller to the Manager that watches tenant objects, e.g. a Tenant custom
+resource, and sets up a Tenant for every one of them through a SetupFunc. A Tenant can
+have its own cluster, whose cache and client are scoped to the tenant, e.g. to its
+namespaces. The controllers and runnables the SetupFunc adds to the Tenant are added to
+the Manager and removed from it again once the tenant object is deleted, or before the
+Tenant is set up again when the generation of the tenant object changed:
+
+	err := tenancy.New(mgr, "tenants", &tenantv1.Tenant{}, tenancy.Options[*tenantv1.Tenant]{
+		NewCluster: func(ctx context.Context, tenant *tenantv1.Tenant) (cluster.Cluster, error) {
+			return cluster.New(mgr.GetConfig(), func(o *cluster.Options) {
+				o.Scheme = mgr.GetScheme()
+				o.Cache.DefaultNamespaces = map[string]cache.Config{tenant.Spec.Namespace: {}}
+			})
+		},
+		Setup: func(ctx context.Context, t *tenancy.Tenant, tenant *tenantv1.Tenant) error {
+			c, err := controller.NewUnmanaged(tenant.Name+"-pods", controller.Options{Reconciler: ...})
+			...
+			err = c.Watch(source.Kind(t.GetCluster().GetCache(), &corev1.Pod{}, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{}))
+			...
+			return tenancy.AddController(t, c)
+		},
+	})
+
+Controller names must be unique, so the controllers of a tenant are usually named after
+the tenant object. Their names are released when the Tenant is torn down.
+*/
+package tenancy
+
+import (
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
+)
+
+var log = logf.RuntimeLog.WithName("tenancy")
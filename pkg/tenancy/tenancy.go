@@ -0,0 +1,254 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// SetupFunc sets up the controllers of the tenant of a tenant object by adding them
+// to the Tenant.
+type SetupFunc[T client.Object] func(ctx context.Context, t *Tenant, obj T) error
+
+// Options configures the tenants of New.
+type Options[T client.Object] struct {
+	// Setup sets up a Tenant for a tenant object. It is called when the tenant object is
+	// reconciled for the first time, and again after the generation of the tenant object
+	// changed, once the previous Tenant was torn down. If it fails, the Tenant is torn down
+	// and set up again with backoff.
+	Setup SetupFunc[T]
+
+	// NewCluster returns the cluster of a tenant, e.g. one created with cluster.New whose
+	// cache is restricted to the namespaces of the tenant, or that uses the credentials of
+	// the tenant. The cluster is added to the Manager and removed again with the Tenant.
+	// Defaults to using the Manager as the cluster of all tenants.
+	NewCluster func(ctx context.Context, obj T) (cluster.Cluster, error)
+
+	// ControllerOptions configures the controller that reconciles the tenant objects. Its
+	// Reconciler is set by New.
+	ControllerOptions controller.Options
+}
+
+// Tenant is a set of controllers and runnables that is added to the Manager for a
+// tenant object and removed from it again together.
+type Tenant struct {
+	// Key is the key of the tenant object.
+	Key client.ObjectKey
+
+	mgr        manager.Manager
+	cluster    cluster.Cluster
+	generation int64
+
+	// mu guards removers.
+	mu       sync.Mutex
+	removers []func(context.Context) error
+}
+
+// GetCluster returns the cluster of the tenant, see Options.NewCluster.
+func (t *Tenant) GetCluster() cluster.Cluster {
+	return t.cluster
+}
+
+// Add adds a runnable to the Manager, which is removed from it again once the Tenant
+// is torn down. Use AddController to add controllers.
+func (t *Tenant) Add(r manager.Runnable) error {
+	if err := t.mgr.Add(r); err != nil {
+		return err
+	}
+	t.addRemover(func(ctx context.Context) error {
+		return t.mgr.(manager.RunnableRemover).RemoveRunnable(ctx, r)
+	})
+	return nil
+}
+
+// AddController adds a controller, e.g. one created with controller.NewTypedUnmanaged, to
+// the Manager, which is removed from it again with controller.Remove once the Tenant is
+// torn down. This also deletes the metrics of the controller and releases its name.
+func AddController[request comparable](t *Tenant, c controller.TypedController[request]) error {
+	if err := t.mgr.Add(c); err != nil {
+		return err
+	}
+	t.addRemover(func(ctx context.Context) error {
+		return controller.Remove(ctx, t.mgr, c)
+	})
+	return nil
+}
+
+func (t *Tenant) addRemover(remove func(context.Context) error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.removers = append(t.removers, remove)
+}
+
+// tearDown removes everything that was added to the Tenant from the Manager, in the
+// reverse order it was added. What failed to be removed is kept, so that tearing down
+// the Tenant can be retried.
+func (t *Tenant) tearDown(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var errs []error
+	var failed []func(context.Context) error
+	for _, remove := range slices.Backward(t.removers) {
+		if err := remove(ctx); err != nil {
+			errs = append(errs, err)
+			failed = append(failed, remove)
+		}
+	}
+	slices.Reverse(failed)
+	t.removers = failed
+	return errors.Join(errs...)
+}
+
+// New adds a controller with the given name to the Manager, which sets up a Tenant for
+// every object of the type of obj and tears it down again once the object is deleted.
+// The Manager must be a manager.RunnableRemover, like the Manager returned by
+// manager.New.
+func New[T client.Object](mgr manager.Manager, name string, obj T, options Options[T]) error {
+	if _, ok := mgr.(manager.RunnableRemover); !ok {
+		return fmt.Errorf("manager of type %T doesn't support removing runnables", mgr)
+	}
+	if options.Setup == nil {
+		return errors.New("must specify Setup")
+	}
+
+	r := &tenants[T]{
+		mgr:     mgr,
+		obj:     obj,
+		options: options,
+		running: map[client.ObjectKey]*Tenant{},
+	}
+	ctrlOptions := options.ControllerOptions
+	ctrlOptions.Reconciler = r
+	c, err := controller.New(name, mgr, ctrlOptions)
+	if err != nil {
+		return err
+	}
+	return c.Watch(source.Kind(mgr.GetCache(), obj, &handler.TypedEnqueueRequestForObject[T]{}))
+}
+
+// tenants reconciles tenant objects by setting up and tearing down their Tenants.
+type tenants[T client.Object] struct {
+	mgr     manager.Manager
+	obj     T
+	options Options[T]
+
+	// mu guards running. The requests of a tenant object are never reconciled
+	// concurrently, so that its Tenant is only replaced by its own reconciliation.
+	mu      sync.Mutex
+	running map[client.ObjectKey]*Tenant
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (r *tenants[T]) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	obj := r.obj.DeepCopyObject().(T)
+	if err := r.mgr.GetClient().Get(ctx, req.NamespacedName, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.tearDown(ctx, req.NamespacedName)
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return reconcile.Result{}, r.tearDown(ctx, req.NamespacedName)
+	}
+
+	r.mu.Lock()
+	t := r.running[req.NamespacedName]
+	r.mu.Unlock()
+	if t != nil && t.generation == obj.GetGeneration() {
+		return reconcile.Result{}, nil
+	}
+	if err := r.tearDown(ctx, req.NamespacedName); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, r.setUp(ctx, obj)
+}
+
+func (r *tenants[T]) setUp(ctx context.Context, obj T) error {
+	t := &Tenant{
+		Key:        client.ObjectKeyFromObject(obj),
+		mgr:        r.mgr,
+		cluster:    r.mgr,
+		generation: obj.GetGeneration(),
+	}
+	log.V(1).Info("Setting up tenant", "tenant", t.Key)
+
+	err := func() error {
+		if r.options.NewCluster != nil {
+			cl, err := r.options.NewCluster(ctx, obj)
+			if err != nil {
+				return fmt.Errorf("failed to create cluster: %w", err)
+			}
+			if err := t.Add(cl); err != nil {
+				return fmt.Errorf("failed to add cluster: %w", err)
+			}
+			t.cluster = cl
+		}
+		return r.options.Setup(ctx, t, obj)
+	}()
+	if err != nil {
+		err = fmt.Errorf("failed to set up tenant %s: %w", t.Key, err)
+		if tearDownErr := t.tearDown(ctx); tearDownErr != nil {
+			// Keep the Tenant with a generation that never matches, so that tearing
+			// it down is retried before it is set up again.
+			t.generation = -1
+			r.mu.Lock()
+			r.running[t.Key] = t
+			r.mu.Unlock()
+			return errors.Join(err, tearDownErr)
+		}
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running[t.Key] = t
+	return nil
+}
+
+func (r *tenants[T]) tearDown(ctx context.Context, key client.ObjectKey) error {
+	r.mu.Lock()
+	t, ok := r.running[key]
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	log.V(1).Info("Tearing down tenant", "tenant", key)
+	if err := t.tearDown(ctx); err != nil {
+		// Keep the Tenant, so that tearing it down is retried.
+		return fmt.Errorf("failed to tear down tenant %s: %w", key, err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.running, key)
+	return nil
+}
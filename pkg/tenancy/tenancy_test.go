@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Tenancy", func() {
+	var (
+		mgr    *fakeManager
+		tenant *corev1.ConfigMap
+		setUps int
+		r      *tenants[*corev1.ConfigMap]
+		req    reconcile.Request
+	)
+
+	BeforeEach(func() {
+		tenant = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tenant", Generation: 1}}
+		mgr = &fakeManager{client: fake.NewClientBuilder().WithObjects(tenant).Build()}
+		setUps = 0
+		r = &tenants[*corev1.ConfigMap]{
+			mgr: mgr,
+			obj: &corev1.ConfigMap{},
+			options: Options[*corev1.ConfigMap]{
+				Setup: func(_ context.Context, t *Tenant, obj *corev1.ConfigMap) error {
+					setUps++
+					Expect(t.Key).To(Equal(client.ObjectKeyFromObject(obj)))
+					if obj.Data["fail"] != "" {
+						Expect(t.Add(&fakeRunnable{})).To(Succeed())
+						return errors.New("setup failed")
+					}
+					return t.Add(&fakeRunnable{})
+				},
+			},
+			running: map[client.ObjectKey]*Tenant{},
+		}
+		req = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tenant)}
+	})
+
+	It("should set up a tenant once per generation", func(ctx SpecContext) {
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(setUps).To(Equal(1))
+		Expect(mgr.runnables).To(HaveLen(1))
+		first := mgr.runnables[0]
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(setUps).To(Equal(1))
+
+		tenant.Generation = 2
+		Expect(mgr.client.Update(ctx, tenant)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(setUps).To(Equal(2))
+		Expect(mgr.runnables).To(HaveLen(1))
+		Expect(mgr.runnables[0]).NotTo(BeIdenticalTo(first))
+	})
+
+	It("should tear down a tenant once its object is deleted", func(ctx SpecContext) {
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.runnables).To(HaveLen(1))
+
+		Expect(mgr.client.Delete(ctx, tenant)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.runnables).To(BeEmpty())
+		Expect(r.running).To(BeEmpty())
+	})
+
+	It("should tear down a tenant whose setup failed", func(ctx SpecContext) {
+		tenant.Data = map[string]string{"fail": "true"}
+		Expect(mgr.client.Update(ctx, tenant)).To(Succeed())
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).To(MatchError(ContainSubstring("setup failed")))
+		Expect(mgr.runnables).To(BeEmpty())
+		Expect(r.running).To(BeEmpty())
+	})
+
+	It("should add the cluster of a tenant", func(ctx SpecContext) {
+		cl := &fakeCluster{}
+		r.options.NewCluster = func(context.Context, *corev1.ConfigMap) (cluster.Cluster, error) {
+			return cl, nil
+		}
+		setup := r.options.Setup
+		r.options.Setup = func(ctx context.Context, t *Tenant, obj *corev1.ConfigMap) error {
+			Expect(t.GetCluster()).To(BeIdenticalTo(cl))
+			return setup(ctx, t, obj)
+		}
+
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.runnables).To(HaveLen(2))
+		Expect(mgr.runnables[0]).To(BeIdenticalTo(cl))
+
+		Expect(mgr.client.Delete(ctx, tenant)).To(Succeed())
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.runnables).To(BeEmpty())
+	})
+})
+
+// fakeManager records the runnables that are added to it and not removed.
+type fakeManager struct {
+	manager.Manager
+
+	client client.Client
+
+	mu        sync.Mutex
+	runnables []manager.Runnable
+}
+
+var _ manager.RunnableRemover = &fakeManager{}
+
+func (m *fakeManager) GetClient() client.Client {
+	return m.client
+}
+
+func (m *fakeManager) Add(r manager.Runnable) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+func (m *fakeManager) RemoveRunnable(_ context.Context, r manager.Runnable) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := slices.Index(m.runnables, r)
+	if i < 0 {
+		return errors.New("runnable was not added")
+	}
+	m.runnables = slices.Delete(m.runnables, i, i+1)
+	return nil
+}
+
+type fakeRunnable struct {
+	// Make the runnables distinguishable by identity.
+	_ int
+}
+
+func (*fakeRunnable) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+type fakeCluster struct {
+	cluster.Cluster
+}
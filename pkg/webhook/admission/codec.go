@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	jsonserializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// AdmissionReviewCodec decodes requests and encodes responses of one version of the
+// AdmissionReview API. Handlers always work with the types of admission/v1, so codecs
+// convert between them and their version.
+type AdmissionReviewCodec interface {
+	// GroupVersionKind returns the AdmissionReview kind of the codec.
+	GroupVersionKind() schema.GroupVersionKind
+
+	// Decode decodes the request of the AdmissionReview in data into req.
+	Decode(data []byte, req *admissionv1.AdmissionRequest) error
+
+	// Encode writes an AdmissionReview with the given response to w.
+	Encode(w io.Writer, resp *admissionv1.AdmissionResponse) error
+}
+
+var (
+	admissionReviewCodecsLock sync.RWMutex
+	admissionReviewCodecs     = map[string]AdmissionReviewCodec{}
+)
+
+func init() {
+	RegisterAdmissionReviewCodec(NewAdmissionReviewCodec(admissionv1.SchemeGroupVersion.WithKind("AdmissionReview")))
+	RegisterAdmissionReviewCodec(NewAdmissionReviewCodec(schema.GroupVersionKind{Group: admissionv1.GroupName, Version: "v1beta1", Kind: "AdmissionReview"}))
+}
+
+// RegisterAdmissionReviewCodec registers the codec for its version of the AdmissionReview
+// API, so that webhooks accept requests of that version. Codecs for v1 and v1beta1 are
+// registered by default. Registering a codec for a version replaces the existing one.
+func RegisterAdmissionReviewCodec(codec AdmissionReviewCodec) {
+	admissionReviewCodecsLock.Lock()
+	defer admissionReviewCodecsLock.Unlock()
+	admissionReviewCodecs[codec.GroupVersionKind().Version] = codec
+}
+
+// AdmissionReviewVersions returns the versions of the AdmissionReview API that have a
+// registered codec, e.g. to set the admissionReviewVersions of a webhook configuration.
+func AdmissionReviewVersions() []string {
+	admissionReviewCodecsLock.RLock()
+	defer admissionReviewCodecsLock.RUnlock()
+	versions := make([]string, 0, len(admissionReviewCodecs))
+	for version := range admissionReviewCodecs {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+func admissionReviewCodecFor(version string) (AdmissionReviewCodec, bool) {
+	admissionReviewCodecsLock.RLock()
+	defer admissionReviewCodecsLock.RUnlock()
+	codec, ok := admissionReviewCodecs[version]
+	return codec, ok
+}
+
+// NewAdmissionReviewCodec returns a codec for an AdmissionReview version whose serialization
+// is identical to admission/v1, like v1beta1.
+func NewAdmissionReviewCodec(gvk schema.GroupVersionKind) AdmissionReviewCodec {
+	return admissionReviewCodec{gvk: gvk}
+}
+
+// admissionReviewCodec encodes and decodes AdmissionReviews that are serialized like v1.
+// The zero value encodes responses without apiVersion and kind.
+type admissionReviewCodec struct {
+	gvk schema.GroupVersionKind
+}
+
+func (c admissionReviewCodec) GroupVersionKind() schema.GroupVersionKind {
+	return c.gvk
+}
+
+func (c admissionReviewCodec) Decode(data []byte, req *admissionv1.AdmissionRequest) error {
+	// Decode into an unregistered type, as the codec factory would convert between the
+	// registered versions otherwise. Both versions share the same structure.
+	ar := unversionedAdmissionReview{}
+	// avoid an extra copy
+	ar.Request = req
+	ar.SetGroupVersionKind(c.gvk)
+	_, _, err := admissionCodecs.UniversalDeserializer().Decode(data, nil, &ar)
+	return err
+}
+
+func (c admissionReviewCodec) Encode(w io.Writer, resp *admissionv1.AdmissionResponse) error {
+	ar := admissionv1.AdmissionReview{Response: resp}
+	if !c.gvk.Empty() {
+		ar.SetGroupVersionKind(c.gvk)
+	}
+	return json.NewEncoder(w).Encode(ar)
+}
+
+// negotiateAdmissionReviewCodec returns the codec for the version of the AdmissionReview
+// in data. Requests without apiVersion are treated as v1.
+func (wh *Webhook) negotiateAdmissionReviewCodec(data []byte) (AdmissionReviewCodec, error) {
+	gvk, err := jsonserializer.DefaultMetaFactory.Interpret(data)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.Version == "" {
+		gvk.Version = admissionv1.SchemeGroupVersion.Version
+	} else if gvk.Group != admissionv1.GroupName || (gvk.Kind != "" && gvk.Kind != "AdmissionReview") {
+		return nil, fmt.Errorf("expected an AdmissionReview of group %s, got %s", admissionv1.GroupName, gvk)
+	}
+
+	versions := wh.AdmissionReviewVersions
+	if len(versions) == 0 {
+		versions = AdmissionReviewVersions()
+	}
+	codec, ok := admissionReviewCodecFor(gvk.Version)
+	if !ok || !slices.Contains(versions, gvk.Version) {
+		return nil, fmt.Errorf("unsupported AdmissionReview version %s, supported versions are %v", gvk.Version, versions)
+	}
+	return codec, nil
+}
@@ -102,6 +102,11 @@ type defaulterForType[T runtime.Object] struct {
 	new                           func() T
 }
 
+// Operations implements OperationsDescriber. Delete requests are allowed without defaulting.
+func (h *defaulterForType[T]) Operations() []admissionv1.Operation {
+	return []admissionv1.Operation{admissionv1.Create, admissionv1.Update, admissionv1.Connect}
+}
+
 // Handle handles admission requests.
 func (h *defaulterForType[T]) Handle(ctx context.Context, req Request) Response {
 	if h.decoder == nil {
@@ -17,7 +17,6 @@ limitations under the License.
 package admission
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -26,9 +25,10 @@ import (
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/metrics"
 )
 
 var admissionScheme = runtime.NewScheme()
@@ -97,71 +97,53 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Both v1 and v1beta1 AdmissionReview types are exactly the same, so the v1beta1 type can
-	// be decoded into the v1 type. However the runtime codec's decoder guesses which type to
-	// decode into by type name if an Object's TypeMeta isn't set. By setting TypeMeta of an
-	// unregistered type to the v1 GVK, the decoder will coerce a v1beta1 AdmissionReview to v1.
-	// The actual AdmissionReview GVK will be used to write a typed response in case the
-	// webhook config permits multiple versions, otherwise this response will fail.
-	req := Request{}
-	ar := unversionedAdmissionReview{}
-	// avoid an extra copy
-	ar.Request = &req.AdmissionRequest
-	ar.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("AdmissionReview"))
-	_, actualAdmRevGVK, err := admissionCodecs.UniversalDeserializer().Decode(body, nil, &ar)
+	// Negotiate the AdmissionReview version with the API server, the response must be of
+	// the same version as the request.
+	codec, err := wh.negotiateAdmissionReviewCodec(body)
 	if err != nil {
+		metrics.AdmissionReviewRequests.WithLabelValues("unsupported").Inc()
+		wh.getLogger(nil).Error(err, "unable to decode the request")
+		wh.writeResponse(w, Errored(http.StatusBadRequest, err))
+		return
+	}
+	metrics.AdmissionReviewRequests.WithLabelValues(codec.GroupVersionKind().Version).Inc()
+
+	req := Request{}
+	if err := codec.Decode(body, &req.AdmissionRequest); err != nil {
 		wh.getLogger(nil).Error(err, "unable to decode the request")
 		wh.writeResponse(w, Errored(http.StatusBadRequest, err))
 		return
 	}
 	wh.getLogger(&req).V(5).Info("received request")
 
-	wh.writeResponseTyped(w, wh.Handle(ctx, req), actualAdmRevGVK)
+	wh.writeResponseTyped(w, wh.Handle(ctx, req), codec)
 }
 
 // writeResponse writes response to w generically, i.e. without encoding GVK information.
 func (wh *Webhook) writeResponse(w io.Writer, response Response) {
-	wh.writeAdmissionResponse(w, v1.AdmissionReview{Response: &response.AdmissionResponse})
-}
-
-// writeResponseTyped writes response to w with GVK set to admRevGVK, which is necessary
-// if multiple AdmissionReview versions are permitted by the webhook.
-func (wh *Webhook) writeResponseTyped(w io.Writer, response Response, admRevGVK *schema.GroupVersionKind) {
-	ar := v1.AdmissionReview{
-		Response: &response.AdmissionResponse,
-	}
-	// Default to a v1 AdmissionReview, otherwise the API server may not recognize the request
-	// if multiple AdmissionReview versions are permitted by the webhook config.
-	// TODO(estroz): this should be configurable since older API servers won't know about v1.
-	if admRevGVK == nil || *admRevGVK == (schema.GroupVersionKind{}) {
-		ar.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("AdmissionReview"))
-	} else {
-		ar.SetGroupVersionKind(*admRevGVK)
-	}
-	wh.writeAdmissionResponse(w, ar)
+	wh.writeResponseTyped(w, response, admissionReviewCodec{})
 }
 
-// writeAdmissionResponse writes ar to w.
-func (wh *Webhook) writeAdmissionResponse(w io.Writer, ar v1.AdmissionReview) {
-	if err := json.NewEncoder(w).Encode(ar); err != nil {
+// writeResponseTyped writes response to w as AdmissionReview of the version of codec,
+// which is necessary if multiple AdmissionReview versions are permitted by the webhook.
+func (wh *Webhook) writeResponseTyped(w io.Writer, response Response, codec AdmissionReviewCodec) {
+	res := &response.AdmissionResponse
+	if err := codec.Encode(w, res); err != nil {
 		wh.getLogger(nil).Error(err, "unable to encode and write the response")
-		// Since the `ar v1.AdmissionReview` is a clear and legal object,
+		// Since the response is a clear and legal object,
 		// it should not have problem to be marshalled into bytes.
 		// The error here is probably caused by the abnormal HTTP connection,
 		// e.g., broken pipe, so we can only write the error response once,
 		// to avoid endless circular calling.
 		serverError := Errored(http.StatusInternalServerError, err)
-		if err = json.NewEncoder(w).Encode(v1.AdmissionReview{Response: &serverError.AdmissionResponse}); err != nil {
+		if err = codec.Encode(w, &serverError.AdmissionResponse); err != nil {
 			wh.getLogger(nil).Error(err, "still unable to encode and write the InternalServerError response")
 		}
-	} else {
-		res := ar.Response
-		if log := wh.getLogger(nil); log.V(5).Enabled() {
-			if res.Result != nil {
-				log = log.WithValues("code", res.Result.Code, "reason", res.Result.Reason, "message", res.Result.Message)
-			}
-			log.V(5).Info("wrote response", "requestID", res.UID, "allowed", res.Allowed)
+	} else if log := wh.getLogger(nil); log.V(5).Enabled() {
+		if res.Result != nil {
+			log = log.WithValues("code", res.Result.Code, "reason", res.Result.Reason, "message", res.Result.Message)
 		}
+		log.V(5).Info("wrote response", "requestID", res.UID, "allowed", res.Allowed)
 	}
 }
 
@@ -30,6 +30,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Admission Webhooks", func() {
@@ -156,6 +157,79 @@ var _ = Describe("Admission Webhooks", func() {
 			Expect(respRecorder.Body.String()).To(Equal(expected))
 		})
 
+		It("should return bad-request when given an unsupported AdmissionReview version", func() {
+			req := &http.Request{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   nopCloser{Reader: bytes.NewBufferString(`{"kind":"AdmissionReview","apiVersion":"admission.k8s.io/v2","request":{}}`)},
+			}
+			webhook := &Webhook{
+				Handler: &fakeHandler{},
+			}
+
+			expected := `{"response":{"uid":"","allowed":false,"status":{"metadata":{},"message":"unsupported AdmissionReview version v2, supported versions are [v1 v1beta1]","code":400}}}
+`
+			webhook.ServeHTTP(respRecorder, req)
+			Expect(respRecorder.Body.String()).To(Equal(expected))
+		})
+
+		It("should return bad-request when given a version the webhook doesn't accept", func() {
+			req := &http.Request{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   nopCloser{Reader: bytes.NewBufferString(fmt.Sprintf(`{%s,"request":{}}`, gvkJSONv1beta1))},
+			}
+			webhook := &Webhook{
+				Handler:                 &fakeHandler{},
+				AdmissionReviewVersions: []string{"v1"},
+			}
+
+			expected := `{"response":{"uid":"","allowed":false,"status":{"metadata":{},"message":"unsupported AdmissionReview version v1beta1, supported versions are [v1]","code":400}}}
+`
+			webhook.ServeHTTP(respRecorder, req)
+			Expect(respRecorder.Body.String()).To(Equal(expected))
+		})
+
+		It("should return bad-request when given a different kind", func() {
+			req := &http.Request{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   nopCloser{Reader: bytes.NewBufferString(`{"kind":"Pod","apiVersion":"v1"}`)},
+			}
+			webhook := &Webhook{
+				Handler: &fakeHandler{},
+			}
+
+			webhook.ServeHTTP(respRecorder, req)
+			Expect(respRecorder.Body.String()).To(ContainSubstring(`"code":400`))
+			Expect(respRecorder.Body.String()).To(ContainSubstring("expected an AdmissionReview of group admission.k8s.io"))
+		})
+
+		It("should decode and encode requests through a registered codec", func() {
+			gvk := schema.GroupVersionKind{Group: admissionv1.GroupName, Version: "v1alpha1", Kind: "AdmissionReview"}
+			RegisterAdmissionReviewCodec(NewAdmissionReviewCodec(gvk))
+			DeferCleanup(func() {
+				admissionReviewCodecsLock.Lock()
+				defer admissionReviewCodecsLock.Unlock()
+				delete(admissionReviewCodecs, gvk.Version)
+			})
+			Expect(AdmissionReviewVersions()).To(Equal([]string{"v1", "v1alpha1", "v1beta1"}))
+
+			req := &http.Request{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   nopCloser{Reader: bytes.NewBufferString(`{"kind":"AdmissionReview","apiVersion":"admission.k8s.io/v1alpha1","request":{"uid":"123"}}`)},
+			}
+			webhook := &Webhook{
+				Handler: &fakeHandler{
+					fn: func(ctx context.Context, req Request) Response {
+						return Allowed(string(req.UID))
+					},
+				},
+			}
+
+			expected := `{"kind":"AdmissionReview","apiVersion":"admission.k8s.io/v1alpha1","response":{"uid":"123","allowed":true,"status":{"metadata":{},"message":"123","code":200}}}
+`
+			webhook.ServeHTTP(respRecorder, req)
+			Expect(respRecorder.Body.String()).To(Equal(expected))
+		})
+
 		It("should present the Context from the HTTP request, if any", func(specCtx SpecContext) {
 			req := &http.Request{
 				Header: http.Header{"Content-Type": []string{"application/json"}},
@@ -28,11 +28,20 @@ var (
 		Name: "controller_runtime_webhook_panics_total",
 		Help: "Total number of webhook panics",
 	}, []string{})
+
+	// AdmissionReviewRequests is a prometheus counter metrics which holds the total
+	// number of admission requests by their AdmissionReview version. Requests of
+	// versions that are not supported are counted as "unsupported".
+	AdmissionReviewRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "controller_runtime_webhook_admission_review_requests_total",
+		Help: "Total number of admission requests by AdmissionReview version",
+	}, []string{"version"})
 )
 
 func init() {
 	metrics.Registry.MustRegister(
 		WebhookPanics,
+		AdmissionReviewRequests,
 	)
 	// Init metric.
 	WebhookPanics.WithLabelValues().Add(0)
@@ -92,6 +92,11 @@ type validatorForType[T runtime.Object] struct {
 	new       func() T
 }
 
+// Operations implements OperationsDescriber. Connect requests are allowed without validation.
+func (h *validatorForType[T]) Operations() []v1.Operation {
+	return []v1.Operation{v1.Create, v1.Update, v1.Delete}
+}
+
 // Handle handles admission requests.
 func (h *validatorForType[T]) Handle(ctx context.Context, req Request) Response {
 	if h.decoder == nil {
@@ -104,6 +104,12 @@ type Handler interface {
 	Handle(context.Context, Request) Response
 }
 
+// OperationsDescriber is implemented by Handlers that only act on some admission operations.
+type OperationsDescriber interface {
+	// Operations returns the admission operations the Handler acts on.
+	Operations() []admissionv1.Operation
+}
+
 // HandlerFunc implements Handler interface using a single function.
 type HandlerFunc func(context.Context, Request) Response
 
@@ -138,10 +144,24 @@ type Webhook struct {
 	// outside the context of requests.
 	LogConstructor func(base logr.Logger, req *Request) logr.Logger
 
+	// AdmissionReviewVersions restricts the AdmissionReview versions the webhook accepts,
+	// e.g. "v1". Requests of other versions are rejected with a bad request response.
+	// Defaults to all versions with a registered AdmissionReviewCodec.
+	AdmissionReviewVersions []string
+
 	setupLogOnce sync.Once
 	log          logr.Logger
 }
 
+// Operations returns the admission operations the Handler of the webhook acts on. It returns nil
+// if the Handler doesn't implement OperationsDescriber, e.g. because it acts on all operations.
+func (wh *Webhook) Operations() []admissionv1.Operation {
+	if describer, ok := wh.Handler.(OperationsDescriber); ok {
+		return describer.Operations()
+	}
+	return nil
+}
+
 // WithRecoverPanic takes a bool flag which indicates whether the panic caused by webhook should be recovered.
 // Defaults to true.
 func (wh *Webhook) WithRecoverPanic(recoverPanic bool) *Webhook {
@@ -28,7 +28,9 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	machinerytypes "k8s.io/apimachinery/pkg/types"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -198,6 +200,13 @@ var _ = Describe("Admission Webhooks", func() {
 		Eventually(logBuffer).Should(gbytes.Say(`"msg":"Received request","operation":"CREATE","requestID":"test123"}`))
 	})
 
+	It("should describe the operations of its handler", func() {
+		scheme := runtime.NewScheme()
+		Expect(WithDefaulter[*corev1.Pod](scheme, nil).Operations()).To(Equal([]admissionv1.Operation{admissionv1.Create, admissionv1.Update, admissionv1.Connect}))
+		Expect(WithValidator[*corev1.Pod](scheme, nil).Operations()).To(Equal([]admissionv1.Operation{admissionv1.Create, admissionv1.Update, admissionv1.Delete}))
+		Expect((&Webhook{Handler: HandlerFunc(func(context.Context, Request) Response { return Allowed("") })}).Operations()).To(BeNil())
+	})
+
 	Describe("panic recovery", func() {
 		It("should recover panic if RecoverPanic is true by default", func(ctx SpecContext) {
 			panicHandler := func() *Webhook {
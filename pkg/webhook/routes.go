@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Route types of webhooks registered by controller-runtime.
+const (
+	RouteTypeMutating   = "mutating"
+	RouteTypeValidating = "validating"
+	RouteTypeConversion = "conversion"
+)
+
+// Route describes a handler registered on a webhook Server.
+type Route struct {
+	// Path is the path the handler is served at.
+	Path string `json:"path"`
+
+	// Type is the type of webhook served at Path, e.g. RouteTypeMutating.
+	// It is empty if the handler was registered without describing it.
+	Type string `json:"type,omitempty"`
+
+	// GVKs are the kinds of objects the handler serves.
+	GVKs []schema.GroupVersionKind `json:"gvks,omitempty"`
+
+	// Operations are the admission operations the handler acts on.
+	// It is empty if the handler acts on all operations or isn't an admission webhook.
+	Operations []admissionv1.Operation `json:"operations,omitempty"`
+
+	// Handler is the handler the describing party registered at Path. If it is set and
+	// differs from the handler that is served at Path, e.g. because another builder
+	// registered the path first, the registration is recorded as a conflict. Handlers
+	// of a type that isn't comparable, like http.HandlerFunc, are never considered
+	// different. It is ignored for conversion webhooks, which share a single handler.
+	Handler http.Handler `json:"-"`
+}
+
+// RouteDescriber is implemented by Servers that keep track of what registered
+// handlers serve, to detect conflicting registrations and ease debugging.
+type RouteDescriber interface {
+	// DescribeRoute adds the GVKs and operations of route to the description of the
+	// handler registered at route.Path.
+	// Describing a path with GVKs that is already described by a webhook of another
+	// type, or a mutating or validating path with another GVK, is recorded as a
+	// conflict and makes the server fail to start.
+	DescribeRoute(route Route)
+
+	// Routes returns the description of all registered handlers, sorted by path.
+	Routes() []Route
+}
+
+var _ RouteDescriber = &DefaultServer{}
+
+// RegisteredPaths implements Server.
+func (s *DefaultServer) RegisteredPaths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	paths := make([]string, 0, len(s.webhooks))
+	for path := range s.webhooks {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// DescribeRoute implements RouteDescriber.
+func (s *DefaultServer) DescribeRoute(route Route) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaultingOnce.Do(s.setDefaults)
+	existing, found := s.routes[route.Path]
+	if !found {
+		existing = &Route{Path: route.Path}
+		s.routes[route.Path] = existing
+	}
+
+	if existing.Type != "" && route.Type != "" && existing.Type != route.Type {
+		s.routeConflicts = append(s.routeConflicts, fmt.Sprintf("path %s is described as both %s and %s webhook", route.Path, existing.Type, route.Type))
+	}
+	if served, ok := s.webhooks[route.Path]; ok && route.Handler != nil && route.Type != RouteTypeConversion && !sameHandler(served, route.Handler) {
+		s.routeConflicts = append(s.routeConflicts, fmt.Sprintf("path %s is registered more than once, only the handler registered first is served", route.Path))
+	}
+	if existing.Type == "" {
+		existing.Type = route.Type
+	}
+
+	for _, gvk := range route.GVKs {
+		if slices.Contains(existing.GVKs, gvk) {
+			continue
+		}
+		// Conversion webhooks serve all convertible kinds on a single path, while
+		// admission webhooks generated by the builder handle exactly one kind.
+		if existing.Type != RouteTypeConversion && len(existing.GVKs) > 0 {
+			s.routeConflicts = append(s.routeConflicts, fmt.Sprintf("path %s is registered for %s and %s, only the handler for the former is served", route.Path, existing.GVKs[0], gvk))
+		}
+		existing.GVKs = append(existing.GVKs, gvk)
+	}
+	for _, op := range route.Operations {
+		if !slices.Contains(existing.Operations, op) {
+			existing.Operations = append(existing.Operations, op)
+		}
+	}
+}
+
+// Routes implements RouteDescriber. Paths that were registered but not
+// described are included with only their Path set.
+func (s *DefaultServer) Routes() []Route {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	routes := make([]Route, 0, len(s.webhooks))
+	for path := range s.webhooks {
+		route := Route{Path: path}
+		if described, ok := s.routes[path]; ok {
+			route.Type = described.Type
+			route.GVKs = slices.Clone(described.GVKs)
+			route.Operations = slices.Clone(described.Operations)
+		}
+		routes = append(routes, route)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path
+	})
+	return routes
+}
+
+// RoutesHandler returns a http.Handler that serves the description of all handlers
+// registered on the given server as JSON. For servers that don't implement
+// RouteDescriber, only the paths of the handlers are served. The Manager serves it
+// on the metrics server if its ServeWebhookRoutes option is set.
+func RoutesHandler(server Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		var routes []Route
+		if describer, ok := server.(RouteDescriber); ok {
+			routes = describer.Routes()
+		} else {
+			for _, path := range server.RegisteredPaths() {
+				routes = append(routes, Route{Path: path})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(routes); err != nil {
+			log.Error(err, "unable to encode webhook routes")
+		}
+	})
+}
+
+// sameHandler returns true if a and b are the same handler, or if that can't be told
+// because their type isn't comparable.
+func sameHandler(a, b http.Handler) bool {
+	typeA, typeB := reflect.TypeOf(a), reflect.TypeOf(b)
+	if typeA != typeB {
+		return false
+	}
+	if !typeA.Comparable() {
+		return true
+	}
+	return a == b
+}
+
+// validateRoutes returns an error if DescribeRoute recorded conflicting registrations.
+// It must be called with the lock held.
+func (s *DefaultServer) validateRoutes() error {
+	if len(s.routeConflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("conflicting webhook registrations: %s", strings.Join(s.routeConflicts, "; "))
+}
@@ -65,6 +65,9 @@ type Server interface {
 
 	// WebhookMux returns the servers WebhookMux
 	WebhookMux() *http.ServeMux
+
+	// RegisteredPaths returns the sorted paths of all webhooks registered through Register.
+	RegisteredPaths() []string
 }
 
 // Options are all the available options for a webhook.Server
@@ -101,6 +104,10 @@ type Options struct {
 
 	// WebhookMux is the multiplexer that handles different webhooks.
 	WebhookMux *http.ServeMux
+
+	// Middlewares wrap the handler of the server, e.g. to log requests or to add a
+	// request ID. The first middleware is the outermost one.
+	Middlewares []func(http.Handler) http.Handler
 }
 
 // NewServer constructs a new webhook.Server from the provided options.
@@ -117,6 +124,13 @@ type DefaultServer struct {
 	// webhooks keep track of all registered webhooks
 	webhooks map[string]http.Handler
 
+	// routes keep track of what the registered webhooks serve, as described through DescribeRoute.
+	routes map[string]*Route
+
+	// routeConflicts are the conflicting registrations detected by DescribeRoute.
+	// The server refuses to start if there are any.
+	routeConflicts []string
+
 	// defaultingOnce ensures that the default fields are only ever set once.
 	defaultingOnce sync.Once
 
@@ -155,6 +169,7 @@ func (o *Options) setDefaults() {
 
 func (s *DefaultServer) setDefaults() {
 	s.webhooks = map[string]http.Handler{}
+	s.routes = map[string]*Route{}
 	s.Options.setDefaults()
 
 	s.webhookMux = s.Options.WebhookMux
@@ -190,6 +205,13 @@ func (s *DefaultServer) Start(ctx context.Context) error {
 
 	log.Info("Starting webhook server")
 
+	s.mu.Lock()
+	err := s.validateRoutes()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
 	cfg := &tls.Config{
 		NextProtos: []string{"h2"},
 	}
@@ -241,7 +263,7 @@ func (s *DefaultServer) Start(ctx context.Context) error {
 
 	log.Info("Serving webhook server", "host", s.Options.Host, "port", s.Options.Port)
 
-	srv := httpserver.New(ctx, s.webhookMux)
+	srv := httpserver.New(ctx, httpserver.WithMiddlewares(s.webhookMux, s.Options.Middlewares...))
 
 	idleConnsClosed := make(chan struct{})
 	go func() {
@@ -23,11 +23,14 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path"
 	"reflect"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -120,6 +123,79 @@ var _ = Describe("Webhook Server", func() {
 		Eventually(doneCh, "4s").Should(BeClosed())
 	})
 
+	Context("when introspecting registered webhooks", func() {
+		It("should list the registered paths and their descriptions", func() {
+			server.Register("/validate-apps-v1-deployment", &testHandler{})
+			server.Register("/convert", &testHandler{})
+			server.Register("/somepath", &testHandler{})
+
+			describer, ok := server.(webhook.RouteDescriber)
+			Expect(ok).To(BeTrue())
+			deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+			describer.DescribeRoute(webhook.Route{
+				Path:       "/validate-apps-v1-deployment",
+				Type:       webhook.RouteTypeValidating,
+				GVKs:       []schema.GroupVersionKind{deploymentGVK},
+				Operations: []admissionv1.Operation{admissionv1.Create, admissionv1.Update},
+			})
+			describer.DescribeRoute(webhook.Route{Path: "/convert", Type: webhook.RouteTypeConversion, GVKs: []schema.GroupVersionKind{deploymentGVK}})
+			describer.DescribeRoute(webhook.Route{Path: "/convert", Type: webhook.RouteTypeConversion, GVKs: []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "StatefulSet"}}})
+
+			Expect(server.RegisteredPaths()).To(Equal([]string{"/convert", "/somepath", "/validate-apps-v1-deployment"}))
+			routes := describer.Routes()
+			Expect(routes).To(HaveLen(3))
+			Expect(routes[0].GVKs).To(HaveLen(2))
+			Expect(routes[1]).To(Equal(webhook.Route{Path: "/somepath"}))
+			Expect(routes[2].Operations).To(Equal([]admissionv1.Operation{admissionv1.Create, admissionv1.Update}))
+
+			recorder := httptest.NewRecorder()
+			webhook.RoutesHandler(server).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			Expect(recorder.Body.String()).To(ContainSubstring(`"path":"/validate-apps-v1-deployment","type":"validating"`))
+
+			doneCh := startServer()
+			ctxCancel()
+			Eventually(doneCh, "4s").Should(BeClosed())
+		})
+
+		It("should refuse to start with conflicting registrations", func(ctx SpecContext) {
+			server.Register("/validate-custom", &testHandler{})
+			describer := server.(webhook.RouteDescriber)
+			describer.DescribeRoute(webhook.Route{
+				Path: "/validate-custom",
+				Type: webhook.RouteTypeValidating,
+				GVKs: []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}},
+			})
+			describer.DescribeRoute(webhook.Route{
+				Path: "/validate-custom",
+				Type: webhook.RouteTypeValidating,
+				GVKs: []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "StatefulSet"}},
+			})
+
+			err := server.Start(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("path /validate-custom is registered for apps/v1, Kind=Deployment and apps/v1, Kind=StatefulSet"))
+		})
+
+		It("should refuse to start if a described handler isn't the one served", func(ctx SpecContext) {
+			served := &testHandler{}
+			server.Register("/validate-custom", served)
+			describer := server.(webhook.RouteDescriber)
+			route := webhook.Route{
+				Path:    "/validate-custom",
+				Type:    webhook.RouteTypeValidating,
+				GVKs:    []schema.GroupVersionKind{{Group: "apps", Version: "v1", Kind: "Deployment"}},
+				Handler: served,
+			}
+			describer.DescribeRoute(route)
+			route.Handler = http.NotFoundHandler()
+			describer.DescribeRoute(route)
+
+			err := server.Start(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("path /validate-custom is registered more than once"))
+		})
+	})
+
 	Context("when registering new webhooks before starting", func() {
 		It("should serve a webhook on the requested path", func() {
 			server.Register("/somepath", &testHandler{})
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooktest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/internal/testing/addr"
+	"sigs.k8s.io/controller-runtime/pkg/internal/testing/certs"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// Handler is a webhook handler that is served at Path, e.g. an admission.Webhook.
+type Handler struct {
+	Path    string
+	Handler http.Handler
+}
+
+// Server is a webhook server that was started with StartServer.
+type Server struct {
+	// URL is the base URL of the server, e.g. https://127.0.0.1:34567.
+	URL string
+
+	// CABundle is the PEM encoded certificate of the CA that signed the serving
+	// certificate of the server, e.g. for the caBundle of webhook configurations.
+	CABundle []byte
+
+	// Client is an HTTP client that trusts the serving certificate of the server.
+	Client *http.Client
+}
+
+// StartServer starts a webhook.Server that serves the given handlers over TLS on a
+// free port of the loopback interface, with a certificate of a CA that is generated
+// for it. It returns once the server accepts connections, and stops the server when
+// the test finishes. It fails the test if the server can't be started.
+func StartServer(t testing.TB, handlers ...Handler) *Server {
+	t.Helper()
+
+	ca, err := certs.NewTinyCA()
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	servingCert, err := ca.NewServingCert("127.0.0.1", "localhost")
+	if err != nil {
+		t.Fatalf("failed to create serving certificate: %v", err)
+	}
+	certPEM, keyPEM, err := servingCert.AsBytes()
+	if err != nil {
+		t.Fatalf("failed to encode serving certificate: %v", err)
+	}
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to load serving certificate: %v", err)
+	}
+	port, host, err := addr.Suggest("127.0.0.1")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+
+	server := webhook.NewServer(webhook.Options{
+		Host: host,
+		Port: port,
+		TLSOpts: []func(*tls.Config){func(config *tls.Config) {
+			config.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return &keyPair, nil
+			}
+		}},
+	})
+	for _, handler := range handlers {
+		server.Register(handler.Path, handler.Handler)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("webhook server failed: %v", err)
+		}
+	})
+
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 30*time.Second, true, func(context.Context) (bool, error) {
+		select {
+		case err := <-done:
+			done <- err
+			return false, fmt.Errorf("webhook server stopped: %w", err)
+		default:
+		}
+		return server.StartedChecker()(nil) == nil, nil
+	}); err != nil {
+		t.Fatalf("webhook server did not start: %v", err)
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.CA.Cert)
+	return &Server{
+		URL:      "https://" + net.JoinHostPort(host, strconv.Itoa(port)),
+		CABundle: ca.CA.CertBytes(),
+		Client: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12},
+		}},
+	}
+}
+
+// ClientConfig returns the client config of a webhook configuration that calls the
+// handler at path, e.g. to register the server with an API server of envtest.
+func (s *Server) ClientConfig(path string) admissionregistrationv1.WebhookClientConfig {
+	url := s.URL + path
+	return admissionregistrationv1.WebhookClientConfig{
+		URL:      &url,
+		CABundle: s.CABundle,
+	}
+}
+
+// SendAdmissionReview sends an AdmissionReview with req to the handler at path, like the
+// API server would, and returns the response. The UID of req is generated if it's empty.
+func (s *Server) SendAdmissionReview(ctx context.Context, path string, req admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	if req.UID == "" {
+		req.UID = uuid.NewUUID()
+	}
+	body, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+		Request:  &req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("webhook at %s responded with status %s", path, resp.Status)
+	}
+
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(resp.Body).Decode(review); err != nil {
+		return nil, fmt.Errorf("failed to decode AdmissionReview: %w", err)
+	}
+	if review.Response == nil {
+		return nil, fmt.Errorf("webhook at %s responded without a response", path)
+	}
+	return review.Response, nil
+}
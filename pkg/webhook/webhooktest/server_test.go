@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooktest_test
+
+import (
+	"context"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/webhooktest"
+)
+
+func TestStartServer(t *testing.T) {
+	server := webhooktest.StartServer(t, webhooktest.Handler{
+		Path: "/validate",
+		Handler: &admission.Webhook{Handler: admission.HandlerFunc(func(_ context.Context, req admission.Request) admission.Response {
+			if req.Operation == admissionv1.Delete {
+				return admission.Denied("deleting is not allowed")
+			}
+			return admission.Allowed("")
+		})},
+	})
+
+	if config := server.ClientConfig("/validate"); *config.URL != server.URL+"/validate" || len(config.CABundle) == 0 {
+		t.Fatalf("unexpected client config: %v", config)
+	}
+
+	for _, tc := range []struct {
+		operation admissionv1.Operation
+		allowed   bool
+	}{
+		{operation: admissionv1.Create, allowed: true},
+		{operation: admissionv1.Delete, allowed: false},
+	} {
+		resp, err := server.SendAdmissionReview(t.Context(), "/validate", admissionv1.AdmissionRequest{Operation: tc.operation})
+		if err != nil {
+			t.Fatalf("failed to send AdmissionReview: %v", err)
+		}
+		if resp.Allowed != tc.allowed {
+			t.Errorf("expected %s to be allowed: %t, got %t", tc.operation, tc.allowed, resp.Allowed)
+		}
+		if resp.UID == "" {
+			t.Errorf("expected the response to have the UID of the request")
+		}
+	}
+
+	if _, err := server.SendAdmissionReview(t.Context(), "/missing", admissionv1.AdmissionRequest{}); err == nil {
+		t.Fatal("expected an error for a path without a handler")
+	}
+}